@@ -0,0 +1,130 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package chacha20
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"hash/crc32"
+	"strings"
+)
+
+// Armor block types of envelopes and containers.
+const (
+	ArmorEnvelope  = "CHACHA20 ENVELOPE"
+	ArmorContainer = "CHACHA20 CONTAINER"
+)
+
+const armorLineLength = 64
+
+var (
+	errInvalidArmor   = errors.New("invalid armor encoding")
+	errArmorChecksum  = errors.New("armor checksum mismatch")
+	errArmorBlockType = errors.New("armor block type must be non-empty upper case ASCII")
+)
+
+// The armor encoding of data is:
+//
+//	-----BEGIN <block type>-----
+//	<base64 of data in lines of 64 characters>
+//	=<base64 of the big endian CRC-32 (IEEE) of data>
+//	-----END <block type>-----
+//
+// The checksum detects accidental modifications like copy and paste
+// errors. It does not authenticate anything - the envelope and the
+// container are authenticated by their tags.
+
+// Armor returns the armor encoding of data with the given block type -
+// e.g. ArmorEnvelope for a binary encoded Envelope. The block type must
+// consist of upper case ASCII letters, digits and spaces.
+func Armor(blockType string, data []byte) ([]byte, error) {
+	if !validArmorBlockType(blockType) {
+		return nil, errArmorBlockType
+	}
+	var buf bytes.Buffer
+	buf.WriteString("-----BEGIN " + blockType + "-----\n")
+	encoded := base64.StdEncoding.EncodeToString(data)
+	for len(encoded) > armorLineLength {
+		buf.WriteString(encoded[:armorLineLength] + "\n")
+		encoded = encoded[armorLineLength:]
+	}
+	if len(encoded) > 0 {
+		buf.WriteString(encoded + "\n")
+	}
+	sum := crc32.ChecksumIEEE(data)
+	buf.WriteString("=" + base64.StdEncoding.EncodeToString([]byte{byte(sum >> 24), byte(sum >> 16), byte(sum >> 8), byte(sum)}) + "\n")
+	buf.WriteString("-----END " + blockType + "-----\n")
+	return buf.Bytes(), nil
+}
+
+// Dearmor decodes the first armored block in armored and returns its block
+// type and data. Text before the begin line and after the end line, white
+// space around lines and CRLF line endings are ignored, so armored blocks
+// can be copied from config files, tickets and emails.
+func Dearmor(armored []byte) (blockType string, data []byte, err error) {
+	lines := strings.Split(string(armored), "\n")
+	for len(lines) > 0 {
+		line := strings.TrimSpace(lines[0])
+		lines = lines[1:]
+		if strings.HasPrefix(line, "-----BEGIN ") && strings.HasSuffix(line, "-----") {
+			blockType = line[len("-----BEGIN ") : len(line)-len("-----")]
+			break
+		}
+	}
+	if !validArmorBlockType(blockType) {
+		return "", nil, errInvalidArmor
+	}
+
+	// The base64 lines are collected in a buffer since concatenating
+	// strings is quadratic in the number of lines.
+	var encoded bytes.Buffer
+	encoded.Grow(len(armored))
+	var checksum string
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "-----END "+blockType+"-----":
+			if checksum == "" {
+				return "", nil, errInvalidArmor
+			}
+			data = make([]byte, base64.StdEncoding.DecodedLen(encoded.Len()))
+			n, err := base64.StdEncoding.Decode(data, encoded.Bytes())
+			if err != nil {
+				return "", nil, errInvalidArmor
+			}
+			data = data[:n]
+			sum, err := base64.StdEncoding.DecodeString(checksum)
+			if err != nil || len(sum) != 4 {
+				return "", nil, errInvalidArmor
+			}
+			if crc32.ChecksumIEEE(data) != uint32(sum[0])<<24|uint32(sum[1])<<16|uint32(sum[2])<<8|uint32(sum[3]) {
+				return "", nil, errArmorChecksum
+			}
+			return blockType, data, nil
+		case checksum != "":
+			return "", nil, errInvalidArmor
+		case strings.HasPrefix(line, "="):
+			checksum = line[1:]
+		case line == "":
+			// Mail clients may insert empty lines.
+		default:
+			encoded.WriteString(line)
+		}
+	}
+	return "", nil, errInvalidArmor
+}
+
+func validArmorBlockType(blockType string) bool {
+	if blockType == "" {
+		return false
+	}
+	for _, c := range blockType {
+		if (c < 'A' || c > 'Z') && (c < '0' || c > '9') && c != ' ' {
+			return false
+		}
+	}
+	return true
+}
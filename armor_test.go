@@ -0,0 +1,97 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package chacha20
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestArmor(t *testing.T) {
+	for _, size := range []int{0, 1, 47, 48, 49, 1000} {
+		data := make([]byte, size)
+		for i := range data {
+			data[i] = byte(i * 7)
+		}
+		armored, err := Armor(ArmorEnvelope, data)
+		if err != nil {
+			t.Fatalf("Size %d: Armor failed: %s", size, err)
+		}
+		for _, line := range strings.Split(string(armored), "\n") {
+			if len(line) > armorLineLength {
+				t.Fatalf("Size %d: line is longer than %d characters: %q", size, armorLineLength, line)
+			}
+		}
+
+		// Surrounding text, indentation and CRLF line endings are ignored.
+		pasted := "Hi,\r\nplease find the secret below:\r\n\r\n" + strings.Replace("  "+string(armored), "\n", "\r\n  ", -1) + "Bye"
+		for _, a := range [][]byte{armored, []byte(pasted)} {
+			blockType, decoded, err := Dearmor(a)
+			if err != nil {
+				t.Fatalf("Size %d: Dearmor failed: %s", size, err)
+			}
+			if blockType != ArmorEnvelope || !bytes.Equal(decoded, data) {
+				t.Fatalf("Size %d: Dearmor returns %q and unexpected data", size, blockType)
+			}
+		}
+	}
+}
+
+func TestArmorContainer(t *testing.T) {
+	var key [32]byte
+	var buf bytes.Buffer
+	w, err := NewContainerWriter(&buf, &key, 0)
+	sealContainer(t, w, err, []byte("Hello, World"))
+
+	armored, err := Armor(ArmorContainer, buf.Bytes())
+	if err != nil {
+		t.Fatalf("Armor failed: %s", err)
+	}
+	if blockType, data, err := Dearmor(armored); err != nil || blockType != ArmorContainer || !bytes.Equal(data, buf.Bytes()) {
+		t.Fatalf("Dearmor returns %q: %v", blockType, err)
+	}
+}
+
+func TestDearmorInvalid(t *testing.T) {
+	if _, err := Armor("chacha20", nil); err != errArmorBlockType {
+		t.Fatalf("Armor accepted an invalid block type: %v", err)
+	}
+
+	armored, _ := Armor(ArmorEnvelope, []byte("Hello, World"))
+	lines := strings.Split(string(armored), "\n")
+	if _, _, err := Dearmor([]byte(strings.Replace(string(armored), "SGVsbG8", "SGVsbG9", 1))); err != errArmorChecksum {
+		t.Fatalf("Dearmor accepted modified data: %v", err)
+	}
+
+	invalid := []string{
+		"",
+		strings.Join(lines[:len(lines)-2], "\n"), // missing end line
+		strings.Join(append(lines[:2:2], lines[3:]...), "\n"), // missing checksum
+		strings.Replace(string(armored), "END CHACHA20 ENVELOPE", "END CHACHA20 CONTAINER", 1),
+		strings.Replace(string(armored), "BEGIN CHACHA20 ENVELOPE", "BEGIN chacha20", 1),
+		strings.Replace(string(armored), lines[2], lines[2]+"\nSGVs", 1), // data after checksum
+		strings.Replace(string(armored), lines[1], lines[1][1:], 1),
+	}
+	for i, a := range invalid {
+		if _, _, err := Dearmor([]byte(a)); err != errInvalidArmor {
+			t.Fatalf("Test %d: Dearmor returns unexpected error: %v", i, err)
+		}
+	}
+}
+
+func BenchmarkDearmor(b *testing.B) {
+	armored, err := Armor(ArmorContainer, make([]byte, 1024*1024))
+	if err != nil {
+		b.Fatalf("Armor failed: %s", err)
+	}
+	b.SetBytes(1024 * 1024)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, _, err := Dearmor(armored); err != nil {
+			b.Fatalf("Dearmor failed: %s", err)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package chacha20
+
+import (
+	"github.com/aead/chacha20/chacha"
+	"github.com/aead/poly1305"
+)
+
+// BoxOverhead is the number of bytes of overhead when boxing a message.
+const BoxOverhead = TagSize
+
+// The box functions implement crypto_box_curve25519xchacha20poly1305 of
+// libsodium after the key exchange - the *_afternm functions. The X25519
+// key exchange itself is left to the caller. A box is the poly1305 tag
+// followed by the ciphertext. The message is encrypted with XChaCha20
+// starting at byte 32 of the keystream - the first 32 bytes are the
+// poly1305 key - and the tag authenticates the ciphertext only.
+
+// BoxPrecompute derives the shared key of two parties from their X25519
+// shared secret like crypto_box_curve25519xchacha20poly1305_beforenm.
+func BoxPrecompute(sharedKey, sharedSecret *[32]byte) {
+	var zero [16]byte
+	chacha.HChaCha20(sharedKey, &zero, sharedSecret)
+}
+
+// BoxSealAfterPrecomputation appends an encrypted and authenticated copy of
+// message to out, which must not overlap message, and returns the result.
+// The nonce must be unique for each distinct message and the shared key must
+// be computed by BoxPrecompute. The output is BoxOverhead bytes longer than
+// the message.
+func BoxSealAfterPrecomputation(out, message []byte, nonce *[NonceSizeX]byte, sharedKey *[32]byte) []byte {
+	ret, box := sliceForAppend(out, BoxOverhead+len(message))
+
+	var block [64]byte
+	subKey, subNonce := boxSubKey(nonce, sharedKey)
+	chacha.XORKeyStream64(block[:], block[:], subNonce, subKey, 0, 20)
+	ciphertext := box[BoxOverhead:]
+	n := chacha.XORBytes(ciphertext, message, block[32:])
+	if n < len(message) {
+		chacha.XORKeyStream64(ciphertext[n:], message[n:], subNonce, subKey, 1, 20)
+	}
+
+	var polyKey [32]byte
+	var tag [TagSize]byte
+	copy(polyKey[:], block[:32])
+	poly1305.Sum(&tag, ciphertext, &polyKey)
+	copy(box, tag[:])
+
+	wipe(subKey[:])
+	wipe(block[:])
+	wipe(polyKey[:])
+	return ret
+}
+
+// BoxOpenAfterPrecomputation authenticates and decrypts a box produced by
+// BoxSealAfterPrecomputation and appends the message to out, which must not
+// overlap box. It returns the result and whether the box was authentic.
+func BoxOpenAfterPrecomputation(out, box []byte, nonce *[NonceSizeX]byte, sharedKey *[32]byte) ([]byte, bool) {
+	if len(box) < BoxOverhead {
+		return nil, false
+	}
+	var block [64]byte
+	subKey, subNonce := boxSubKey(nonce, sharedKey)
+	defer wipe(subKey[:])
+	chacha.XORKeyStream64(block[:], block[:], subNonce, subKey, 0, 20)
+	defer wipe(block[:])
+
+	var polyKey [32]byte
+	var sum [TagSize]byte
+	ciphertext := box[BoxOverhead:]
+	copy(polyKey[:], block[:32])
+	poly1305.Sum(&sum, ciphertext, &polyKey)
+	wipe(polyKey[:])
+	if !checkTag(&sum, box[:BoxOverhead], TagSize) {
+		return nil, false
+	}
+
+	ret, message := sliceForAppend(out, len(ciphertext))
+	n := chacha.XORBytes(message, ciphertext, block[32:])
+	if n < len(ciphertext) {
+		chacha.XORKeyStream64(message[n:], ciphertext[n:], subNonce, subKey, 1, 20)
+	}
+	return ret, true
+}
+
+// boxSubKey returns the XChaCha20 sub-key and the 8 byte nonce of the
+// original ChaCha20 construction used by the box functions.
+func boxSubKey(nonce *[NonceSizeX]byte, sharedKey *[32]byte) (*[32]byte, *[8]byte) {
+	var (
+		subKey   [32]byte
+		hNonce   [16]byte
+		subNonce [8]byte
+	)
+	copy(hNonce[:], nonce[:16])
+	copy(subNonce[:], nonce[16:])
+	chacha.HChaCha20(&subKey, &hNonce, sharedKey)
+	return &subKey, &subNonce
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package chacha20
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/aead/chacha20/chacha/reference"
+	"github.com/aead/poly1305"
+	"golang.org/x/crypto/curve25519"
+)
+
+// referenceBox computes a box as specified by libsodium using the
+// reference implementation of ChaCha20.
+func referenceBox(message []byte, nonce *[NonceSizeX]byte, sharedKey *[32]byte) []byte {
+	var (
+		subKey   [32]byte
+		hNonce   [16]byte
+		subNonce [8]byte
+		polyKey  [32]byte
+		tag      [TagSize]byte
+	)
+	copy(hNonce[:], nonce[:16])
+	copy(subNonce[:], nonce[16:])
+	reference.HChaCha20(&subKey, &hNonce, sharedKey)
+
+	stream := make([]byte, 32+len(message))
+	copy(stream[32:], message)
+	reference.XORKeyStream64(stream, stream, &subNonce, &subKey, 0, 20)
+	copy(polyKey[:], stream[:32])
+	poly1305.Sum(&tag, stream[32:], &polyKey)
+	return append(tag[:], stream[32:]...)
+}
+
+func TestBox(t *testing.T) {
+	var sharedKey [32]byte
+	var nonce [NonceSizeX]byte
+	for i := range sharedKey {
+		sharedKey[i] = byte(i)
+	}
+	for i := range nonce {
+		nonce[i] = byte(255 - i)
+	}
+	for _, size := range []int{0, 1, 31, 32, 33, 64, 65, 1000} {
+		message := make([]byte, size)
+		for i := range message {
+			message[i] = byte(i * 5)
+		}
+		box := BoxSealAfterPrecomputation([]byte("prefix"), message, &nonce, &sharedKey)
+		if !bytes.HasPrefix(box, []byte("prefix")) {
+			t.Fatalf("Size %d: BoxSealAfterPrecomputation doesn't append to out", size)
+		}
+		box = box[len("prefix"):]
+		if !bytes.Equal(box, referenceBox(message, &nonce, &sharedKey)) {
+			t.Fatalf("Size %d: box differs from the reference", size)
+		}
+
+		opened, ok := BoxOpenAfterPrecomputation(nil, box, &nonce, &sharedKey)
+		if !ok || !bytes.Equal(opened, message) {
+			t.Fatalf("Size %d: BoxOpenAfterPrecomputation failed", size)
+		}
+		for i := range box {
+			box[i] ^= 1
+			if _, ok = BoxOpenAfterPrecomputation(nil, box, &nonce, &sharedKey); ok {
+				t.Fatalf("Size %d: modified byte %d was not detected", size, i)
+			}
+			box[i] ^= 1
+		}
+	}
+	if _, ok := BoxOpenAfterPrecomputation(nil, make([]byte, BoxOverhead-1), &nonce, &sharedKey); ok {
+		t.Fatal("BoxOpenAfterPrecomputation accepted a too short box")
+	}
+}
+
+func TestBoxPrecompute(t *testing.T) {
+	var alicePriv, bobPriv [32]byte
+	alicePriv[0], bobPriv[0] = 1, 2
+	alicePub, _ := curve25519.X25519(alicePriv[:], curve25519.Basepoint)
+	bobPub, _ := curve25519.X25519(bobPriv[:], curve25519.Basepoint)
+
+	var aliceSecret, bobSecret, aliceKey, bobKey [32]byte
+	s, _ := curve25519.X25519(alicePriv[:], bobPub)
+	copy(aliceSecret[:], s)
+	s, _ = curve25519.X25519(bobPriv[:], alicePub)
+	copy(bobSecret[:], s)
+	BoxPrecompute(&aliceKey, &aliceSecret)
+	BoxPrecompute(&bobKey, &bobSecret)
+	if aliceKey != bobKey || aliceKey == aliceSecret {
+		t.Fatal("BoxPrecompute doesn't derive the same shared key")
+	}
+
+	var nonce [NonceSizeX]byte
+	box := BoxSealAfterPrecomputation(nil, []byte("Hello, Bob"), &nonce, &aliceKey)
+	if message, ok := BoxOpenAfterPrecomputation(nil, box, &nonce, &bobKey); !ok || string(message) != "Hello, Bob" {
+		t.Fatal("Bob cannot open the box of Alice")
+	}
+}
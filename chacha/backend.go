@@ -0,0 +1,203 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package chacha
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// Backend is an implementation of the ChaCha keystream generation.
+type Backend int
+
+const (
+	// Generic is the pure Go implementation. It's supported on all platforms
+	// and used on architectures without an optimized backend - e.g. loong64,
+	// mips or s390x. On loong64 it uses a tuned scalar implementation.
+	Generic Backend = iota
+	// SSE2 is the SSE2 implementation (amd64, 386).
+	SSE2
+	// SSSE3 is the SSSE3 implementation (amd64).
+	SSSE3
+	// AVX is the VEX encoded 128 bit implementation (amd64) for CPUs
+	// supporting AVX but not AVX2 - e.g. Sandy Bridge and Ivy Bridge.
+	AVX
+	// AVX2 is the experimental AVX2 implementation (amd64).
+	// It's only selected by default if built with GOAMD64=v3.
+	AVX2
+	// AVX512 is the AVX512 implementation (amd64).
+	AVX512
+	// NEON is the NEON implementation (arm64, arm). On Apple's M-series
+	// cores the arm64 implementation processes 6 blocks in parallel - see
+	// SetNEONBlocks.
+	NEON
+	// VSX is the VSX implementation (ppc64le).
+	VSX
+	// RVV is the vector extension implementation (riscv64).
+	RVV
+	// SIMD128 is the WebAssembly SIMD implementation (wasm).
+	SIMD128
+)
+
+var errBackendNotSupported = errors.New("chacha20/chacha: backend is not supported by the platform")
+
+var errNEONBlocks = errors.New("chacha20/chacha: NEON backend processes 4 or 6 blocks in parallel")
+
+var backend = defaultBackend()
+
+// neonBlocks is the number of blocks the arm64 NEON backend processes in
+// parallel - 4 or 6. It's 0 on other platforms.
+var neonBlocks = defaultNEONBlocks()
+
+// String returns the name of the backend.
+func (b Backend) String() string {
+	switch b {
+	case Generic:
+		return "Generic"
+	case SSE2:
+		return "SSE2"
+	case SSSE3:
+		return "SSSE3"
+	case AVX:
+		return "AVX"
+	case AVX2:
+		return "AVX2"
+	case AVX512:
+		return "AVX512"
+	case NEON:
+		return "NEON"
+	case VSX:
+		return "VSX"
+	case RVV:
+		return "RVV"
+	case SIMD128:
+		return "SIMD128"
+	default:
+		return "unknown"
+	}
+}
+
+// ActiveBackend returns the backend used for keystream generation.
+func ActiveBackend() Backend { return backend }
+
+// SupportedBackends returns the backends supported by the platform and
+// the CPU - the backends which can be selected by SetBackend.
+func SupportedBackends() []Backend {
+	backends := []Backend{Generic}
+	for b := Generic + 1; b <= SIMD128; b++ {
+		if supportsBackend(b) {
+			backends = append(backends, b)
+		}
+	}
+	return backends
+}
+
+// SetBackend selects the backend used for keystream generation. It returns
+// an error if the backend is not supported by the platform or the CPU.
+// If the package is built with GOAMD64=v3 or v4 only Generic and the
+// backend selected at build time are supported. SetBackend is meant for
+// benchmarks and debugging. It must not be called concurrently with any
+// other function of this package.
+func SetBackend(b Backend) error {
+	if b != Generic && !supportsBackend(b) {
+		return errBackendNotSupported
+	}
+	backend = b
+	return nil
+}
+
+// SetNEONBlocks selects the number of blocks - 4 or 6 - the arm64 NEON
+// backend processes in parallel. By default it uses 6 blocks on Apple's
+// M-series cores and 4 blocks on other cores. SetNEONBlocks returns an
+// error on other platforms. Like SetBackend it's meant for benchmarks and
+// debugging and must not be called concurrently with any other function
+// of this package.
+func SetNEONBlocks(n int) error {
+	if neonBlocks == 0 {
+		return errBackendNotSupported
+	}
+	if n != 4 && n != 6 {
+		return errNEONBlocks
+	}
+	neonBlocks = n
+	return nil
+}
+
+// BackendInfo describes the active backend and why it is used.
+type BackendInfo struct {
+	// Active is the backend used for keystream generation.
+	Active Backend
+	// Default is the backend selected by the package for the platform and the CPU.
+	Default Backend
+	// Variant describes the variant of the active backend if it has
+	// several - e.g. "6 block schedule" for the arm64 NEON backend on
+	// Apple's M-series cores. See SetNEONBlocks.
+	Variant string
+	// Reason describes why the active backend is used.
+	Reason string
+	// Unsupported contains the optimized backends of the platform which
+	// are not supported and the reason - e.g. a missing CPU feature.
+	Unsupported map[Backend]string
+}
+
+// DescribeBackend returns which backend is active and why. It's meant for
+// bug reports and diagnostics and should not be called on hot paths.
+func DescribeBackend() BackendInfo {
+	info := BackendInfo{
+		Active:      backend,
+		Default:     defaultBackend(),
+		Unsupported: make(map[Backend]string),
+	}
+	for b := Generic + 1; b <= SIMD128; b++ {
+		if reason := missingFeature(b); reason != "" {
+			info.Unsupported[b] = reason
+		}
+	}
+	if info.Active == NEON && neonBlocks != 0 {
+		info.Variant = strconv.Itoa(neonBlocks) + " block schedule"
+	}
+	switch {
+	case info.Active != info.Default:
+		info.Reason = "selected by SetBackend"
+	case info.Active != Generic:
+		info.Reason = "fastest default backend supported by the CPU"
+	case len(info.Unsupported) > 0:
+		info.Reason = "no optimized backend is supported by the CPU"
+	default:
+		info.Reason = "no optimized backend for the platform and build tags"
+	}
+	return info
+}
+
+// String returns a one-line description of the backend - for example:
+// "SSSE3 (fastest default backend supported by the CPU; AVX512: CPU lacks AVX512F)".
+func (i BackendInfo) String() string {
+	s := i.Active.String() + " ("
+	if i.Variant != "" {
+		s += i.Variant + "; "
+	}
+	s += i.Reason
+	if i.Active != i.Default {
+		s += "; default: " + i.Default.String()
+	}
+	var reasons []string
+	for b := Generic + 1; b <= SIMD128; b++ {
+		if reason, ok := i.Unsupported[b]; ok {
+			reasons = append(reasons, b.String()+": "+reason)
+		}
+	}
+	if len(reasons) > 0 {
+		s += "; " + strings.Join(reasons, ", ")
+	}
+	return s + ")"
+}
+
+// Calibrate measures the throughput of the backends on the running CPU and
+// adjusts the min. input length for which the AVX512 backend is used. It takes
+// a few milliseconds and is never called by the package itself, so the
+// dispatching is deterministic unless Calibrate is called. It must not be
+// called concurrently with any other function of this package.
+func Calibrate() { calibrate() }
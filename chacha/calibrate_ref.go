@@ -0,0 +1,11 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+// +build !amd64 !go1.7 gccgo appengine purego
+
+package chacha
+
+// calibrate does nothing since there are no size thresholds between
+// the backends of this platform.
+func calibrate() {}
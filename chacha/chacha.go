@@ -6,37 +6,100 @@
 // ChaCha cipher family.
 package chacha // import "github.com/aead/chacha20/chacha"
 
+import (
+	"encoding/binary"
+	"errors"
+	"runtime"
+	"sync"
+
+	"github.com/aead/chacha20/internal/argerr"
+)
+
+// The SSE2, SSSE3, AVX, AVX2 and AVX-512 assembly is generated with avo. The
+// generators live in their own module, so the package doesn't depend on avo.
+//go:generate go run -C internal/asm ./sse -out ../../chachaSSE_amd64.s
+//go:generate go run -C internal/asm ./avx -out ../../chachaAVX_amd64.s
+//go:generate go run -C internal/asm ./avx2 -out ../../chachaAVX2_amd64.s
+//go:generate go run -C internal/asm ./avx512 -out ../../chachaAVX512_amd64.s
+
+// parallelMinLength is the min. number of bytes processed by
+// XORKeyStreamParallel using multiple goroutines. Every goroutine
+// processes at least parallelMinLength / 4 bytes.
+const parallelMinLength = 1024 * 1024
+
+// ErrCounterOverflow is the panic value of the functions and methods which
+// would exceed the 2^32 keystream blocks of one key-nonce combination and
+// therefore repeat the keystream.
+var ErrCounterOverflow = errors.New("chacha20/chacha: block counter overflow")
+
 // Cipher is the ChaCha/X struct.
 // X is the number of rounds (e.g. ChaCha20 for 20 rounds)
 type Cipher struct {
 	state, block [64]byte
 	off          int
 	rounds       int
+	counter64    bool
+	exhausted    bool // the 32 bit counter reached 2^32
+
+	ring           []byte // prefetched keystream
+	head, buffered int
 }
 
 // Sets the counter of the cipher.
-// This function skips the unused keystream of the current 64 byte block.
+// This function skips the unused keystream of the current 64 byte block
+// and discards the prefetched keystream.
 func (c *Cipher) SetCounter(ctr uint32) {
 	c.state[48] = byte(ctr)
 	c.state[49] = byte(ctr >> 8)
 	c.state[50] = byte(ctr >> 16)
 	c.state[51] = byte(ctr >> 24)
-	c.off = 0
+	c.off, c.head, c.buffered = 0, 0, 0
+	c.exhausted = false
 }
 
 // Sets the nonce of the cipher.
-// This function skips the unused keystream of the current 64 byte block.
+// This function skips the unused keystream of the current 64 byte block
+// and discards the prefetched keystream.
 func (c *Cipher) SetNonce(nonce *[12]byte) {
 	copy(c.state[52:], nonce[:])
-	c.off = 0
+	c.off, c.head, c.buffered = 0, 0, 0
+	c.exhausted = false
+}
+
+// Sets the key of the cipher.
+// This function skips the unused keystream of the current 64 byte block
+// and discards the prefetched keystream.
+func (c *Cipher) SetKey(key *[32]byte) {
+	copy(c.state[16:48], key[:])
+	c.off, c.head, c.buffered = 0, 0, 0
+	c.exhausted = false
+}
+
+// Wipe overwrites the key, the nonce and the unused keystream of the cipher
+// with zeros. The cipher must not be used afterwards.
+func (c *Cipher) Wipe() {
+	for i := range c.state {
+		c.state[i] = 0
+	}
+	for i := range c.block {
+		c.block[i] = 0
+	}
+	for i := range c.ring {
+		c.ring[i] = 0
+	}
+	c.ring = nil
+	c.off, c.head, c.buffered = 0, 0, 0
 }
 
 // XORKeyStream crypts bytes from src to dst. Src and dst may be the same slice
 // but otherwise should not overlap. If len(dst) < len(src) the function panics.
+// The unused keystream of a partial block is kept for the next call, so src
+// may have any length. If the 32 bit counter of the cipher would overflow,
+// XORKeyStream panics with ErrCounterOverflow.
 func (c *Cipher) XORKeyStream(dst, src []byte) {
 	length := len(src)
 	if len(dst) < length {
-		panic("chacha20/chacha: dst buffer is to small")
+		panic(argerr.Error("chacha20/chacha: dst buffer is to small"))
 	}
 
 	if c.off > 0 {
@@ -51,13 +114,322 @@ func (c *Cipher) XORKeyStream(dst, src []byte) {
 		c.off = 0
 	}
 
+	for c.buffered > 0 {
+		end := c.head + c.buffered
+		if end > len(c.ring) {
+			end = len(c.ring)
+		}
+		n := xor(dst, src, c.ring[c.head:end])
+		c.head = (c.head + n) % len(c.ring)
+		c.buffered -= n
+		if n == length {
+			return
+		}
+		src = src[n:]
+		dst = dst[n:]
+		length -= n
+	}
+
+	c.checkCounter((length + 63) / 64)
 	if length >= 64 {
-		xorBlocks(dst, src, &(c.state), c.rounds)
+		xorBlocksCounter(dst, src, &(c.state), c.rounds, c.counter64)
 	}
 
 	if n := length & (^(64 - 1)); length-n > 0 {
-		Core(&(c.block), &(c.state), c.rounds)
+		coreCounter(&(c.block), &(c.state), c.rounds, c.counter64)
 
 		c.off += xor(dst[n:], src[n:], c.block[:])
 	}
 }
+
+// Prefetch precomputes at least n bytes of keystream and keeps them in an
+// internal ring buffer. Subsequent XORKeyStream calls consume the buffered
+// keystream first, so they only have to pay for the XOR. Like XORKeyStream
+// Prefetch panics with ErrCounterOverflow if the 32 bit counter would overflow.
+// Prefetch must not be called concurrently with other methods of the cipher.
+func (c *Cipher) Prefetch(n int) {
+	if n <= c.buffered {
+		return
+	}
+	blocks := (n - c.buffered + 63) / 64
+	c.checkCounter(blocks)
+	if (len(c.ring)-c.buffered)/64 < blocks {
+		c.growRing(64 * blocks)
+	}
+
+	// The write position is always a multiple of 64, so the blocks
+	// never wrap around the end of the ring.
+	w := (c.head + c.buffered) % len(c.ring)
+	for blocks > 0 {
+		k := (len(c.ring) - w) / 64
+		if k > blocks {
+			k = blocks
+		}
+		p := c.ring[w : w+64*k]
+		for i := range p {
+			p[i] = 0
+		}
+		xorBlocksCounter(p, p, &(c.state), c.rounds, c.counter64)
+		c.buffered += len(p)
+		blocks -= k
+		w = 0
+	}
+}
+
+// checkCounter panics with ErrCounterOverflow if generating the given number
+// of blocks would exceed the 32 bit counter. It doesn't restrict ciphers with
+// a 64 bit counter.
+func (c *Cipher) checkCounter(blocks int) {
+	if c.counter64 || blocks == 0 {
+		return
+	}
+	end := uint64(binary.LittleEndian.Uint32(c.state[48:])) + uint64(blocks)
+	if c.exhausted || end > 1<<32 {
+		panic(ErrCounterOverflow)
+	}
+	c.exhausted = end == 1<<32
+}
+
+// checkCounter panics with ErrCounterOverflow if n bytes of keystream starting
+// at the counter exceed the 32 bit counter.
+func checkCounter(counter uint32, n int) {
+	if uint64(counter)+(uint64(n)+63)/64 > 1<<32 {
+		panic(ErrCounterOverflow)
+	}
+}
+
+// growRing replaces the ring buffer by a larger one with at least
+// extra free bytes. It keeps the write position 64 byte aligned.
+func (c *Cipher) growRing(extra int) {
+	pad := (64 - c.buffered%64) % 64
+	size := pad + c.buffered + extra
+	if size < 2*len(c.ring) {
+		size = 2 * len(c.ring)
+	}
+	ring := make([]byte, size)
+	if c.buffered > 0 {
+		end := c.head + c.buffered
+		if end > len(c.ring) {
+			end = len(c.ring)
+		}
+		n := copy(ring[pad:], c.ring[c.head:end])
+		copy(ring[pad+n:], c.ring[:c.buffered-n])
+	}
+	c.ring, c.head = ring, pad
+}
+
+// NewCipher64 returns a new *chacha.Cipher implementing the original ChaCha/X
+// construction with a 64 bit nonce and a 64 bit block counter. SetCounter sets
+// the lower half of the counter, SetNonce must not be used. The nonce must be
+// unique for one key for all time.
+func NewCipher64(nonce *[8]byte, key *[32]byte, rounds int) *Cipher {
+	if rounds <= 0 || rounds%2 != 0 {
+		panic(argerr.Error("chacha20/chacha: rounds must be a multiple of 2"))
+	}
+	c := new(Cipher)
+	c.rounds = rounds
+	c.counter64 = true
+	setState64(&(c.state), key, nonce, 0)
+
+	return c
+}
+
+// XORKeyStream64 crypts bytes from src to dst like XORKeyStream but uses a 64 bit
+// nonce and a 64 bit block counter like the original ChaCha construction.
+func XORKeyStream64(dst, src []byte, nonce *[8]byte, key *[32]byte, counter uint64, rounds int) {
+	length := len(src)
+	if len(dst) < length {
+		panic(argerr.Error("chacha20/chacha: dst buffer is to small"))
+	}
+	if rounds <= 0 || rounds%2 != 0 {
+		panic(argerr.Error("chacha20/chacha: rounds must be a multiple of 2"))
+	}
+
+	var state [64]byte
+	setState64(&state, key, nonce, counter)
+
+	if length >= 64 {
+		xorBlocksCounter(dst, src, &state, rounds, true)
+	}
+
+	if n := length & (^(64 - 1)); length-n > 0 {
+		var block [64]byte
+		Core(&block, &state, rounds)
+		xor(dst[n:], src[n:], block[:])
+	}
+}
+
+// setState64 builds the ChaCha state with a 64 bit counter followed by a
+// 64 bit nonce.
+func setState64(state *[64]byte, key *[32]byte, nonce *[8]byte, counter uint64) {
+	var n [12]byte
+	binary.LittleEndian.PutUint32(n[:], uint32(counter>>32))
+	copy(n[4:], nonce[:])
+	setState(state, key, &n, uint32(counter))
+}
+
+// xorBlocksCounter crypts full blocks like xorBlocks but handles the overflow
+// of the 32 bit block counter the same way for all backends: src is split at
+// the overflow and the second counter word - the first nonce word - is
+// incremented for a 64 bit counter and left unchanged otherwise.
+func xorBlocksCounter(dst, src []byte, state *[64]byte, rounds int, counter64 bool) {
+	for {
+		left := (1<<32 - uint64(binary.LittleEndian.Uint32(state[48:]))) * 64
+		if uint64(len(src)) < left {
+			xorBlocks(dst, src, state, rounds)
+			return
+		}
+		hi := binary.LittleEndian.Uint32(state[52:])
+		xorBlocks(dst[:left], src[:left], state, rounds)
+		if counter64 {
+			hi++
+		}
+		binary.LittleEndian.PutUint32(state[52:], hi)
+		dst, src = dst[left:], src[left:]
+	}
+}
+
+// coreCounter generates one keystream block like Core but handles the overflow
+// of the 32 bit block counter like xorBlocksCounter.
+func coreCounter(dst *[64]byte, state *[64]byte, rounds int, counter64 bool) {
+	if binary.LittleEndian.Uint32(state[48:]) != 0xFFFFFFFF {
+		Core(dst, state, rounds)
+		return
+	}
+	hi := binary.LittleEndian.Uint32(state[52:])
+	Core(dst, state, rounds)
+	if counter64 {
+		hi++
+	}
+	binary.LittleEndian.PutUint32(state[52:], hi)
+}
+
+// Block generates the 64 byte keystream block for the given key, nonce and
+// counter performing 'rounds' rounds and writes it to dst. (See RFC 7539 2.3)
+// The rounds argument must be a multiple of 2.
+func Block(dst *[64]byte, nonce *[12]byte, key *[32]byte, counter uint32, rounds int) {
+	if rounds <= 0 || rounds%2 != 0 {
+		panic(argerr.Error("chacha20/chacha: rounds must be a multiple of 2"))
+	}
+	var state [64]byte
+	setState(&state, key, nonce, counter)
+	Core(dst, &state, rounds)
+}
+
+// XORKeyStreamAt crypts bytes from src to dst using the keystream of the given key
+// and nonce starting at the byte offset. It allows random access to the keystream
+// without managing the block counter. The rounds argument specifies the number of
+// rounds (must be even). Src and dst may be the same slice but otherwise should not
+// overlap. If len(dst) < len(src) or the keystream exceeds the 32 bit block counter
+// this function panics.
+func XORKeyStreamAt(dst, src []byte, nonce *[12]byte, key *[32]byte, offset uint64, rounds int) {
+	if len(dst) < len(src) {
+		panic(argerr.Error("chacha20/chacha: dst buffer is to small"))
+	}
+	if offset>>6 > 0xFFFFFFFF {
+		panic(argerr.Error("chacha20/chacha: offset exceeds the keystream"))
+	}
+	if offset+uint64(len(src)) > 1<<38 {
+		panic(ErrCounterOverflow)
+	}
+	counter := uint32(offset >> 6)
+
+	if skip := int(offset & 63); skip > 0 && len(src) > 0 {
+		var block [64]byte
+		Block(&block, nonce, key, counter, rounds)
+		n := xor(dst, src, block[skip:])
+		src, dst = src[n:], dst[n:]
+		counter++
+	}
+	if len(src) > 0 {
+		XORKeyStream(dst, src, nonce, key, counter, rounds)
+	}
+}
+
+// XORKeyStreamParallel crypts bytes from src to dst like XORKeyStream but splits
+// buffers of at least 1 MiB into chunks which are crypted concurrently by up to
+// runtime.GOMAXPROCS(0) goroutines. Every chunk uses the block counter of its
+// position in the keystream, so the result is the same as of XORKeyStream.
+// Src and dst may be the same slice but otherwise should not overlap.
+// If len(dst) < len(src) this function panics.
+func XORKeyStreamParallel(dst, src []byte, nonce *[12]byte, key *[32]byte, counter uint32, rounds int) {
+	length := len(src)
+	if len(dst) < length {
+		panic(argerr.Error("chacha20/chacha: dst buffer is to small"))
+	}
+	checkCounter(counter, length)
+	procs := runtime.GOMAXPROCS(0)
+	if length < parallelMinLength || procs < 2 {
+		XORKeyStream(dst, src, nonce, key, counter, rounds)
+		return
+	}
+	if rounds <= 0 || rounds%2 != 0 {
+		panic(argerr.Error("chacha20/chacha: rounds must be a multiple of 2"))
+	}
+
+	chunk := (length/procs + 63) &^ 63
+	if chunk < parallelMinLength/4 {
+		chunk = parallelMinLength / 4
+	}
+
+	var wg sync.WaitGroup
+	for off := 0; off < length; off += chunk {
+		end := off + chunk
+		if end > length {
+			end = length
+		}
+		wg.Add(1)
+		go func(dst, src []byte, counter uint32) {
+			defer wg.Done()
+			XORKeyStream(dst, src, nonce, key, counter, rounds)
+		}(dst[off:end], src[off:end], counter+uint32(off/64))
+	}
+	wg.Wait()
+}
+
+// HChaCha20 generates 32 pseudo-random bytes from a 128 bit nonce and a 256 bit key.
+// It can be used as a key-derivation-function (KDF) and is the building block
+// of the XChaCha20 construction.
+func HChaCha20(out *[32]byte, nonce *[16]byte, key *[32]byte) {
+	var v [16]uint32
+	v[0], v[1], v[2], v[3] = 0x61707865, 0x3320646e, 0x79622d32, 0x6b206574
+	for i := 0; i < 8; i++ {
+		v[4+i] = uint32(key[4*i]) | uint32(key[4*i+1])<<8 | uint32(key[4*i+2])<<16 | uint32(key[4*i+3])<<24
+	}
+	for i := 0; i < 4; i++ {
+		v[12+i] = uint32(nonce[4*i]) | uint32(nonce[4*i+1])<<8 | uint32(nonce[4*i+2])<<16 | uint32(nonce[4*i+3])<<24
+	}
+
+	for i := 0; i < 20; i += 2 {
+		quarterRound(&v, 0, 4, 8, 12)
+		quarterRound(&v, 1, 5, 9, 13)
+		quarterRound(&v, 2, 6, 10, 14)
+		quarterRound(&v, 3, 7, 11, 15)
+		quarterRound(&v, 0, 5, 10, 15)
+		quarterRound(&v, 1, 6, 11, 12)
+		quarterRound(&v, 2, 7, 8, 13)
+		quarterRound(&v, 3, 4, 9, 14)
+	}
+
+	for i, w := range [8]uint32{v[0], v[1], v[2], v[3], v[12], v[13], v[14], v[15]} {
+		out[4*i] = byte(w)
+		out[4*i+1] = byte(w >> 8)
+		out[4*i+2] = byte(w >> 16)
+		out[4*i+3] = byte(w >> 24)
+	}
+}
+
+func quarterRound(v *[16]uint32, a, b, c, d int) {
+	v[a] += v[b]
+	v[d] ^= v[a]
+	v[d] = (v[d] << 16) | (v[d] >> 16)
+	v[c] += v[d]
+	v[b] ^= v[c]
+	v[b] = (v[b] << 12) | (v[b] >> 20)
+	v[a] += v[b]
+	v[d] ^= v[a]
+	v[d] = (v[d] << 8) | (v[d] >> 24)
+	v[c] += v[d]
+	v[b] ^= v[c]
+	v[b] = (v[b] << 7) | (v[b] >> 25)
+}
@@ -2,29 +2,111 @@
 // Use of this source code is governed by a license that can be
 // found in the LICENSE file.
 
-// +build go1.7, amd64, !gccgo, !appengine, !go1.6
+// +build go1.7,amd64,!gccgo,!appengine,!purego
 
 package chacha
 
-// go1.7 is still beta (and this AVX2 implementation is experimenal) disabled
-var useAVX2 = supportAVX2() == 1 && false
+import "time"
+
+const hasAVX = true
+
+// The AVX2 implementation is experimental, so it's only used if selected
+// explicitly. (see SetBackend)
+const hasAVX2 = true
+
+// avx512MinLength is the min. number of bytes processed with AVX512.
+// For shorter inputs the clock frequency penalty of the ZMM registers
+// outweighs the higher throughput. It's adjusted by Calibrate.
+var avx512MinLength = 8 * 1024
+
+// calibrate sets avx512MinLength to the smallest power of two - between
+// 1 KiB and 64 KiB - for which AVX512 is faster than SSSE3.
+func calibrate() {
+	if !supportsBackend(AVX512) {
+		return
+	}
+	buf := make([]byte, 64*1024)
+	for size := 1024; size < len(buf); size *= 2 {
+		if measureBlocks(xorBlocksAVX512, buf, size) < measureBlocks(xorBlocksSSSE3, buf, size) {
+			avx512MinLength = size
+			return
+		}
+	}
+	avx512MinLength = len(buf)
+}
+
+// measureBlocks returns the fastest of 5 runs crypting buf in chunks of
+// size bytes using f.
+func measureBlocks(f func(dst, src []byte, state *[64]byte, rounds int), buf []byte, size int) time.Duration {
+	var state [64]byte
+	fastest := time.Duration(1<<63 - 1)
+	for i := 0; i < 5; i++ {
+		start := time.Now()
+		for off := 0; off+size <= len(buf); off += size {
+			f(buf[off:off+size], buf[off:off+size], &state, 20)
+		}
+		if d := time.Since(start); d < fastest {
+			fastest = d
+		}
+	}
+	return fastest
+}
 
 // xorBlocks crypts full block ( len(src) - (len(src) mod 64) bytes ) from src to
 // dst using the state. Src and dst may be the same slice but otherwise should not
 // overlap. This function increments the counter of state.
 func xorBlocks(dst, src []byte, state *[64]byte, rounds int) {
-	if useAVX2 && len(src) >= 128 {
-		xorBlocksAVX2(dst, src, state, rounds)
-	} else if useSSSE3 {
-		xorBlocksSSSE3(dst, src, state, rounds)
-	} else {
+	if staticBackend != Generic && backend != Generic {
+		xorBlocksStatic(dst, src, state, rounds)
+		return
+	}
+	switch backend {
+	case Generic:
+		xorBlocksGeneric(dst, src, state, rounds)
+		return
+	case SSE2:
 		xorBlocksSSE2(dst, src, state, rounds)
+		return
+	case AVX:
+		if len(src) >= 256 {
+			n := len(src) &^ (256 - 1)
+			xorBlocksAVX(dst, src, state, rounds)
+			dst, src = dst[n:], src[n:]
+		}
+	case AVX2:
+		if len(src) >= 128 {
+			xorBlocksAVX2(dst, src, state, rounds)
+			return
+		}
+	case AVX512:
+		if len(src) >= avx512MinLength {
+			n := len(src) &^ (1024 - 1)
+			xorBlocksAVX512(dst, src, state, rounds)
+			dst, src = dst[n:], src[n:]
+		}
+	}
+	xorBlocksSSSE3(dst, src, state, rounds)
+}
+
+// xorBlocksStatic crypts full blocks like xorBlocks using the backend
+// selected at build time by GOAMD64.
+func xorBlocksStatic(dst, src []byte, state *[64]byte, rounds int) {
+	if staticBackend == AVX2 && len(src) >= 128 {
+		xorBlocksAVX2(dst, src, state, rounds)
+		return
+	}
+	if staticBackend == AVX512 && len(src) >= avx512MinLength {
+		n := len(src) &^ (1024 - 1)
+		xorBlocksAVX512(dst, src, state, rounds)
+		dst, src = dst[n:], src[n:]
 	}
+	xorBlocksSSSE3(dst, src, state, rounds)
 }
 
-// supportAVX2 returns 1 if the runtime (the executing machine) supports AVX2.
+// xorBlocksAVX crypts len(src) - (len(src) mod 256) bytes from src to dst
+// using the state. It processes 4 blocks in parallel.
 //go:noescape
-func supportAVX2() int
+func xorBlocksAVX(dst, src []byte, state *[64]byte, rounds int)
 
 // xorBlocksAVX2 crypts full block ( len(src) - (len(src) mod 64) bytes ) from src to
 // dst using the state.
@@ -2,224 +2,594 @@
 // Use of this source code is governed by a license that can be
 // found in the LICENSE file.
 
-// +build go1.7, amd64, !gccgo, !appengine, !go1.6
+// Code generated by command: go run asm.go -out ../../chachaAVX2_amd64.s. DO NOT EDIT.
+
+//go:build go1.7 && amd64 && !gccgo && !appengine && !purego
+// +build go1.7,amd64,!gccgo,!appengine,!purego
 
 #include "textflag.h"
 
-DATA rol16<>+0x00(SB)/8, $0x0504070601000302
-DATA rol16<>+0x08(SB)/8, $0x0D0C0F0E09080B0A
-DATA rol16<>+0x10(SB)/8, $0x0504070601000302
-DATA rol16<>+0x18(SB)/8, $0x0D0C0F0E09080B0A
-GLOBL rol16<>(SB), (NOPTR+RODATA), $32
-
-DATA rol8<>+0x00(SB)/8, $0x0605040702010003
-DATA rol8<>+0x08(SB)/8, $0x0E0D0C0F0A09080B
-DATA rol8<>+0x10(SB)/8, $0x0605040702010003
-DATA rol8<>+0x18(SB)/8, $0x0E0D0C0F0A09080B
-GLOBL rol8<>(SB), (NOPTR+RODATA), $32
-
-// func supportAVX2() int
-TEXT ·supportAVX2(SB),4,$0-8
-	MOVQ $0, AX
-	CMPB runtime·support_avx2(SB), $1
-	JNE DONE
-	MOVQ $1, AX
-DONE:
-	MOVQ AX, ret+0(FP)
-	RET
+DATA rol16<>+0(SB)/8, $0x0504070601000302
+DATA rol16<>+8(SB)/8, $0x0d0c0f0e09080b0a
+DATA rol16<>+16(SB)/8, $0x0504070601000302
+DATA rol16<>+24(SB)/8, $0x0d0c0f0e09080b0a
+GLOBL rol16<>(SB), RODATA|NOPTR, $32
+
+DATA rol8<>+0(SB)/8, $0x0605040702010003
+DATA rol8<>+8(SB)/8, $0x0e0d0c0f0a09080b
+DATA rol8<>+16(SB)/8, $0x0605040702010003
+DATA rol8<>+24(SB)/8, $0x0e0d0c0f0a09080b
+GLOBL rol8<>(SB), RODATA|NOPTR, $32
+
+DATA inc1<>+0(SB)/8, $0x0000000000000000
+DATA inc1<>+8(SB)/8, $0x0000000000000000
+DATA inc1<>+16(SB)/8, $0x0000000000000001
+DATA inc1<>+24(SB)/8, $0x0000000000000000
+GLOBL inc1<>(SB), RODATA|NOPTR, $32
+
+DATA inc2<>+0(SB)/8, $0x0000000000000002
+DATA inc2<>+8(SB)/8, $0x0000000000000000
+DATA inc2<>+16(SB)/8, $0x0000000000000002
+DATA inc2<>+24(SB)/8, $0x0000000000000000
+GLOBL inc2<>(SB), RODATA|NOPTR, $32
 
-#define ROTL(n, v, t) \
-	VPSLLD $n, v, t; \
-	VPSRLD $(32-n), v, v; \
-	VPXOR v, t, v
-
-#define ROTL_FAST(c, v) \
-	VPSHUFB c, v, v
-
-// If src is a mem location, src must contain at least 32 bytes -> see xorBlocksAVX2
-#define BROADCASTI128(src, dst) \
-	VPERM2I128 $0x22, src, dst, dst		// len(src) < 32 (e.g. state[48]) -> error!
-
-// VPSHUFD $-109 -> See github.com/golang/go/issues/16499
-#define SHUFFLE_128(a, b, c) \
-	VPSHUFD $0x39, a, a; \
-	VPSHUFD $0x4E, b, b; \
-	VPSHUFD $-109, c, c
-
-#define SHUFFLE_256(a0, a1, b0, b1, c0, c1) \
-	VPSHUFD $0x39, a0, a0; \
-	VPSHUFD $0x39, a1, a1; \
-	VPSHUFD $0x4E, b0, b0; \
-	VPSHUFD $0x4E, b1, b1; \
-	VPSHUFD $-109, c0, c0; \
-	VPSHUFD $-109, c1, c1
-
-#define HALF_ROUND_128(v0, v1, v2, v3, t0) \
-	VPADDD v0, v1, v0; \
-	VPXOR v3, v0, v3; \
-	ROTL_FAST(rol16<>(SB), v3); \
-	VPADDD v2, v3, v2; \
-	VPXOR v1, v2, v1; \
-	ROTL(12, v1, t0); \
-	VPADDD v0, v1, v0; \
-	VPXOR v3, v0, v3; \
-	ROTL_FAST(rol8<>(SB), v3); \
-	VPADDD v2, v3, v2; \
-	VPXOR v1, v2, v1; \
-	ROTL(7, v1, t0)
-
-#define HALF_ROUND_256(v0, v1, v2, v3, v4, v5, v6, v7, t0) \
-	VPADDD v0, v1, v0; \
-	VPADDD v4, v5, v4; \
-	VPXOR v3, v0, v3; \
-	VPXOR v7, v4, v7; \
-	ROTL_FAST(rol16<>(SB), v3); \
-	ROTL_FAST(rol16<>(SB), v7); \
-	VPADDD v2, v3, v2; \
-	VPADDD v6, v7, v6; \
-	VPXOR v1, v2, v1; \
-	VPXOR v5, v6, v5; \
-	ROTL(12, v1, t0); \
-	ROTL(12, v5, t0); \
-	VPADDD v0, v1, v0; \
-	VPADDD v4, v5, v4; \
-	VPXOR v3, v0, v3; \
-	VPXOR v7, v4, v7; \
-	ROTL_FAST(rol8<>(SB), v3); \
-	ROTL_FAST(rol8<>(SB), v7); \
-	VPADDD v2, v3, v2; \
-	VPADDD v6, v7, v6; \
-	VPXOR v1, v2, v1; \
-	VPXOR v5, v6, v5; \
-	ROTL(7, v1, t0); \
-	ROTL(7, v5, t0)
-
-#define XOR_128(dst, src, off, v0, v1, v2, v3, t0) \
-	VPERM2I128 $32, v1, v0, t0; \
-	VPXOR (0+off)(src), t0, t0; \
-	VMOVDQU t0, (0+off)(dst); \
-	VPERM2I128 $32, v3, v2, t0; \
-	VPXOR (32+off)(src), t0, t0; \
-	VMOVDQU t0, (32+off)(dst); \
-	VPERM2I128 $49, v1, v0, t0; \
-	VPXOR (64+off)(src), t0, t0; \
-	VMOVDQU t0, (64+off)(dst); \
-	VPERM2I128 $49, v3, v2, t0; \
-	VPXOR (96+off)(src), t0, t0; \
-	VMOVDQU t0, (96+off)(dst)
-
-
-// func xorBlocksAVX2(dst, src []byte, state *[64]byte, rounds int)
-TEXT ·xorBlocksAVX2(SB),4,$0-64
+// func xorBlocksAVX2(dst []byte, src []byte, state *[64]byte, rounds int)
+// Requires: AVX, AVX2
+TEXT ·xorBlocksAVX2(SB), NOSPLIT, $128-64
 	MOVQ state+48(FP), AX
 	MOVQ dst_base+0(FP), CX
 	MOVQ src_base+24(FP), BX
 	MOVQ src_len+32(FP), DX
 	MOVQ rounds+56(FP), R8
-	ANDQ $0xFFFFFFFFFFFFFFC0, DX	// DX = len(src) - (len(src) % 64)
-	
-	// for some weird reason cannot use a constant (no stack alloc) (experimental?!)
-	MOVQ SP, R15
-	ANDQ $0xFFFFFFFFFFFFFFE0, SP	// align 32 byte
-	SUBQ $32, SP
-	SUBQ $32, SP
-	VPXOR Y0, Y0, Y0
-	VMOVDQA Y0, 0(SP)
-	MOVL $1, SI
-	MOVL SI, 16(SP)
-	VMOVDQA 0(SP), Y0
-	MOVL $2, SI
-	MOVL SI, 0(SP)
-	MOVL SI, 16(SP)
-	VMOVDQA 0(SP), Y14
-	MOVQ R15, SP
-	
-	
-	BROADCASTI128(0(AX), Y8) 
-	BROADCASTI128(16(AX), Y9)
-	BROADCASTI128(32(AX), Y10)
-	VPERM2I128 $0x33, 32(AX), Y11, Y11	// cannot use BROADCASTI128 -> 48(AX) contains only 16 bytes
-	VPADDQ Y0, Y11, Y11
-	SUBQ $256, DX
-	JCS BYTES_BETWEEN_0_AND_255
+
+	// DX = len(src) - (len(src) % 64)
+	ANDQ $-64, DX
+
+	// The 8 block loop uses 64 bytes at 0(SP) as spill slots and stores the
+	// counters of the blocks at 64(SP).
+	CMPQ DX, $0x00000200
+	JB   BYTES_LESS_THAN_512
+	MOVQ 48(AX), R9
+
+BYTES_AT_LEAST_512:
+	LEAQ         (R9), R11
+	MOVL         R11, 64(SP)
+	SHRQ         $0x20, R11
+	MOVL         R11, 96(SP)
+	LEAQ         1(R9), R11
+	MOVL         R11, 68(SP)
+	SHRQ         $0x20, R11
+	MOVL         R11, 100(SP)
+	LEAQ         2(R9), R11
+	MOVL         R11, 72(SP)
+	SHRQ         $0x20, R11
+	MOVL         R11, 104(SP)
+	LEAQ         3(R9), R11
+	MOVL         R11, 76(SP)
+	SHRQ         $0x20, R11
+	MOVL         R11, 108(SP)
+	LEAQ         4(R9), R11
+	MOVL         R11, 80(SP)
+	SHRQ         $0x20, R11
+	MOVL         R11, 112(SP)
+	LEAQ         5(R9), R11
+	MOVL         R11, 84(SP)
+	SHRQ         $0x20, R11
+	MOVL         R11, 116(SP)
+	LEAQ         6(R9), R11
+	MOVL         R11, 88(SP)
+	SHRQ         $0x20, R11
+	MOVL         R11, 120(SP)
+	LEAQ         7(R9), R11
+	MOVL         R11, 92(SP)
+	SHRQ         $0x20, R11
+	MOVL         R11, 124(SP)
+	VPBROADCASTD (AX), Y0
+	VPBROADCASTD 4(AX), Y1
+	VPBROADCASTD 8(AX), Y2
+	VPBROADCASTD 12(AX), Y3
+	VPBROADCASTD 16(AX), Y4
+	VPBROADCASTD 20(AX), Y5
+	VPBROADCASTD 24(AX), Y6
+	VPBROADCASTD 28(AX), Y7
+	VPBROADCASTD 32(AX), Y8
+	VPBROADCASTD 36(AX), Y9
+	VPBROADCASTD 40(AX), Y10
+	VPBROADCASTD 44(AX), Y11
+	VMOVDQU      64(SP), Y12
+	VMOVDQU      96(SP), Y13
+	VPBROADCASTD 56(AX), Y14
+	VPBROADCASTD 60(AX), Y15
+	MOVQ         R8, R10
+
+CHACHA_LOOP_512:
+	VPADDD       Y4, Y0, Y0
+	VPADDD       Y5, Y1, Y1
+	VPADDD       Y6, Y2, Y2
+	VPADDD       Y7, Y3, Y3
+	VPXOR        Y0, Y12, Y12
+	VPXOR        Y1, Y13, Y13
+	VPXOR        Y2, Y14, Y14
+	VPXOR        Y3, Y15, Y15
+	VPSHUFB      rol16<>+0(SB), Y12, Y12
+	VPSHUFB      rol16<>+0(SB), Y13, Y13
+	VPSHUFB      rol16<>+0(SB), Y14, Y14
+	VPSHUFB      rol16<>+0(SB), Y15, Y15
+	VPADDD       Y12, Y8, Y8
+	VPADDD       Y13, Y9, Y9
+	VPADDD       Y14, Y10, Y10
+	VPADDD       Y15, Y11, Y11
+	VPXOR        Y8, Y4, Y4
+	VPXOR        Y9, Y5, Y5
+	VPXOR        Y10, Y6, Y6
+	VPXOR        Y11, Y7, Y7
+	VMOVDQU      Y12, (SP)
+	VPSLLD       $0x0c, Y4, Y12
+	VPSRLD       $0x14, Y4, Y4
+	VPXOR        Y4, Y12, Y4
+	VPSLLD       $0x0c, Y5, Y12
+	VPSRLD       $0x14, Y5, Y5
+	VPXOR        Y5, Y12, Y5
+	VPSLLD       $0x0c, Y6, Y12
+	VPSRLD       $0x14, Y6, Y6
+	VPXOR        Y6, Y12, Y6
+	VPSLLD       $0x0c, Y7, Y12
+	VPSRLD       $0x14, Y7, Y7
+	VPXOR        Y7, Y12, Y7
+	VMOVDQU      (SP), Y12
+	VPADDD       Y4, Y0, Y0
+	VPADDD       Y5, Y1, Y1
+	VPADDD       Y6, Y2, Y2
+	VPADDD       Y7, Y3, Y3
+	VPXOR        Y0, Y12, Y12
+	VPXOR        Y1, Y13, Y13
+	VPXOR        Y2, Y14, Y14
+	VPXOR        Y3, Y15, Y15
+	VPSHUFB      rol8<>+0(SB), Y12, Y12
+	VPSHUFB      rol8<>+0(SB), Y13, Y13
+	VPSHUFB      rol8<>+0(SB), Y14, Y14
+	VPSHUFB      rol8<>+0(SB), Y15, Y15
+	VPADDD       Y12, Y8, Y8
+	VPADDD       Y13, Y9, Y9
+	VPADDD       Y14, Y10, Y10
+	VPADDD       Y15, Y11, Y11
+	VPXOR        Y8, Y4, Y4
+	VPXOR        Y9, Y5, Y5
+	VPXOR        Y10, Y6, Y6
+	VPXOR        Y11, Y7, Y7
+	VMOVDQU      Y12, (SP)
+	VPSLLD       $0x07, Y4, Y12
+	VPSRLD       $0x19, Y4, Y4
+	VPXOR        Y4, Y12, Y4
+	VPSLLD       $0x07, Y5, Y12
+	VPSRLD       $0x19, Y5, Y5
+	VPXOR        Y5, Y12, Y5
+	VPSLLD       $0x07, Y6, Y12
+	VPSRLD       $0x19, Y6, Y6
+	VPXOR        Y6, Y12, Y6
+	VPSLLD       $0x07, Y7, Y12
+	VPSRLD       $0x19, Y7, Y7
+	VPXOR        Y7, Y12, Y7
+	VMOVDQU      (SP), Y12
+	VPADDD       Y5, Y0, Y0
+	VPADDD       Y6, Y1, Y1
+	VPADDD       Y7, Y2, Y2
+	VPADDD       Y4, Y3, Y3
+	VPXOR        Y0, Y15, Y15
+	VPXOR        Y1, Y12, Y12
+	VPXOR        Y2, Y13, Y13
+	VPXOR        Y3, Y14, Y14
+	VPSHUFB      rol16<>+0(SB), Y15, Y15
+	VPSHUFB      rol16<>+0(SB), Y12, Y12
+	VPSHUFB      rol16<>+0(SB), Y13, Y13
+	VPSHUFB      rol16<>+0(SB), Y14, Y14
+	VPADDD       Y15, Y10, Y10
+	VPADDD       Y12, Y11, Y11
+	VPADDD       Y13, Y8, Y8
+	VPADDD       Y14, Y9, Y9
+	VPXOR        Y10, Y5, Y5
+	VPXOR        Y11, Y6, Y6
+	VPXOR        Y8, Y7, Y7
+	VPXOR        Y9, Y4, Y4
+	VMOVDQU      Y15, (SP)
+	VPSLLD       $0x0c, Y5, Y15
+	VPSRLD       $0x14, Y5, Y5
+	VPXOR        Y5, Y15, Y5
+	VPSLLD       $0x0c, Y6, Y15
+	VPSRLD       $0x14, Y6, Y6
+	VPXOR        Y6, Y15, Y6
+	VPSLLD       $0x0c, Y7, Y15
+	VPSRLD       $0x14, Y7, Y7
+	VPXOR        Y7, Y15, Y7
+	VPSLLD       $0x0c, Y4, Y15
+	VPSRLD       $0x14, Y4, Y4
+	VPXOR        Y4, Y15, Y4
+	VMOVDQU      (SP), Y15
+	VPADDD       Y5, Y0, Y0
+	VPADDD       Y6, Y1, Y1
+	VPADDD       Y7, Y2, Y2
+	VPADDD       Y4, Y3, Y3
+	VPXOR        Y0, Y15, Y15
+	VPXOR        Y1, Y12, Y12
+	VPXOR        Y2, Y13, Y13
+	VPXOR        Y3, Y14, Y14
+	VPSHUFB      rol8<>+0(SB), Y15, Y15
+	VPSHUFB      rol8<>+0(SB), Y12, Y12
+	VPSHUFB      rol8<>+0(SB), Y13, Y13
+	VPSHUFB      rol8<>+0(SB), Y14, Y14
+	VPADDD       Y15, Y10, Y10
+	VPADDD       Y12, Y11, Y11
+	VPADDD       Y13, Y8, Y8
+	VPADDD       Y14, Y9, Y9
+	VPXOR        Y10, Y5, Y5
+	VPXOR        Y11, Y6, Y6
+	VPXOR        Y8, Y7, Y7
+	VPXOR        Y9, Y4, Y4
+	VMOVDQU      Y15, (SP)
+	VPSLLD       $0x07, Y5, Y15
+	VPSRLD       $0x19, Y5, Y5
+	VPXOR        Y5, Y15, Y5
+	VPSLLD       $0x07, Y6, Y15
+	VPSRLD       $0x19, Y6, Y6
+	VPXOR        Y6, Y15, Y6
+	VPSLLD       $0x07, Y7, Y15
+	VPSRLD       $0x19, Y7, Y7
+	VPXOR        Y7, Y15, Y7
+	VPSLLD       $0x07, Y4, Y15
+	VPSRLD       $0x19, Y4, Y4
+	VPXOR        Y4, Y15, Y4
+	VMOVDQU      (SP), Y15
+	SUBQ         $0x02, R10
+	JA           CHACHA_LOOP_512
+	VMOVDQU      Y15, (SP)
+	VPBROADCASTD (AX), Y15
+	VPADDD       Y15, Y0, Y0
+	VPBROADCASTD 4(AX), Y15
+	VPADDD       Y15, Y1, Y1
+	VPBROADCASTD 8(AX), Y15
+	VPADDD       Y15, Y2, Y2
+	VPBROADCASTD 12(AX), Y15
+	VPADDD       Y15, Y3, Y3
+	VPBROADCASTD 16(AX), Y15
+	VPADDD       Y15, Y4, Y4
+	VPBROADCASTD 20(AX), Y15
+	VPADDD       Y15, Y5, Y5
+	VPBROADCASTD 24(AX), Y15
+	VPADDD       Y15, Y6, Y6
+	VPBROADCASTD 28(AX), Y15
+	VPADDD       Y15, Y7, Y7
+	VPBROADCASTD 32(AX), Y15
+	VPADDD       Y15, Y8, Y8
+	VPBROADCASTD 36(AX), Y15
+	VPADDD       Y15, Y9, Y9
+	VPBROADCASTD 40(AX), Y15
+	VPADDD       Y15, Y10, Y10
+	VPBROADCASTD 44(AX), Y15
+	VPADDD       Y15, Y11, Y11
+	VPADDD       64(SP), Y12, Y12
+	VPADDD       96(SP), Y13, Y13
+	VPBROADCASTD 56(AX), Y15
+	VPADDD       Y15, Y14, Y14
+	VPBROADCASTD 60(AX), Y15
+	VPADDD       (SP), Y15, Y15
+	VMOVDQU      Y14, (SP)
+	VMOVDQU      Y15, 32(SP)
+	VPUNPCKLDQ   Y1, Y0, Y14
+	VPUNPCKHDQ   Y1, Y0, Y15
+	VPUNPCKLDQ   Y3, Y2, Y0
+	VPUNPCKHDQ   Y3, Y2, Y1
+	VPUNPCKLQDQ  Y0, Y14, Y2
+	VPUNPCKHQDQ  Y0, Y14, Y3
+	VPUNPCKLQDQ  Y1, Y15, Y0
+	VPUNPCKHQDQ  Y1, Y15, Y1
+	VPUNPCKLDQ   Y5, Y4, Y14
+	VPUNPCKHDQ   Y5, Y4, Y15
+	VPUNPCKLDQ   Y7, Y6, Y4
+	VPUNPCKHDQ   Y7, Y6, Y5
+	VPUNPCKLQDQ  Y4, Y14, Y6
+	VPUNPCKHQDQ  Y4, Y14, Y7
+	VPUNPCKLQDQ  Y5, Y15, Y4
+	VPUNPCKHQDQ  Y5, Y15, Y5
+	VPERM2I128   $0x20, Y6, Y2, Y14
+	VPXOR        (BX), Y14, Y14
+	VMOVDQU      Y14, (CX)
+	VPERM2I128   $0x31, Y6, Y2, Y14
+	VPXOR        256(BX), Y14, Y14
+	VMOVDQU      Y14, 256(CX)
+	VPERM2I128   $0x20, Y7, Y3, Y14
+	VPXOR        64(BX), Y14, Y14
+	VMOVDQU      Y14, 64(CX)
+	VPERM2I128   $0x31, Y7, Y3, Y14
+	VPXOR        320(BX), Y14, Y14
+	VMOVDQU      Y14, 320(CX)
+	VPERM2I128   $0x20, Y4, Y0, Y14
+	VPXOR        128(BX), Y14, Y14
+	VMOVDQU      Y14, 128(CX)
+	VPERM2I128   $0x31, Y4, Y0, Y14
+	VPXOR        384(BX), Y14, Y14
+	VMOVDQU      Y14, 384(CX)
+	VPERM2I128   $0x20, Y5, Y1, Y14
+	VPXOR        192(BX), Y14, Y14
+	VMOVDQU      Y14, 192(CX)
+	VPERM2I128   $0x31, Y5, Y1, Y14
+	VPXOR        448(BX), Y14, Y14
+	VMOVDQU      Y14, 448(CX)
+	VMOVDQU      (SP), Y14
+	VMOVDQU      32(SP), Y15
+	VPUNPCKLDQ   Y9, Y8, Y0
+	VPUNPCKHDQ   Y9, Y8, Y1
+	VPUNPCKLDQ   Y11, Y10, Y8
+	VPUNPCKHDQ   Y11, Y10, Y9
+	VPUNPCKLQDQ  Y8, Y0, Y10
+	VPUNPCKHQDQ  Y8, Y0, Y11
+	VPUNPCKLQDQ  Y9, Y1, Y8
+	VPUNPCKHQDQ  Y9, Y1, Y9
+	VPUNPCKLDQ   Y13, Y12, Y0
+	VPUNPCKHDQ   Y13, Y12, Y1
+	VPUNPCKLDQ   Y15, Y14, Y12
+	VPUNPCKHDQ   Y15, Y14, Y13
+	VPUNPCKLQDQ  Y12, Y0, Y14
+	VPUNPCKHQDQ  Y12, Y0, Y15
+	VPUNPCKLQDQ  Y13, Y1, Y12
+	VPUNPCKHQDQ  Y13, Y1, Y13
+	VPERM2I128   $0x20, Y14, Y10, Y0
+	VPXOR        32(BX), Y0, Y0
+	VMOVDQU      Y0, 32(CX)
+	VPERM2I128   $0x31, Y14, Y10, Y0
+	VPXOR        288(BX), Y0, Y0
+	VMOVDQU      Y0, 288(CX)
+	VPERM2I128   $0x20, Y15, Y11, Y0
+	VPXOR        96(BX), Y0, Y0
+	VMOVDQU      Y0, 96(CX)
+	VPERM2I128   $0x31, Y15, Y11, Y0
+	VPXOR        352(BX), Y0, Y0
+	VMOVDQU      Y0, 352(CX)
+	VPERM2I128   $0x20, Y12, Y8, Y0
+	VPXOR        160(BX), Y0, Y0
+	VMOVDQU      Y0, 160(CX)
+	VPERM2I128   $0x31, Y12, Y8, Y0
+	VPXOR        416(BX), Y0, Y0
+	VMOVDQU      Y0, 416(CX)
+	VPERM2I128   $0x20, Y13, Y9, Y0
+	VPXOR        224(BX), Y0, Y0
+	VMOVDQU      Y0, 224(CX)
+	VPERM2I128   $0x31, Y13, Y9, Y0
+	VPXOR        480(BX), Y0, Y0
+	VMOVDQU      Y0, 480(CX)
+	ADDQ         $0x08, R9
+	ADDQ         $0x00000200, BX
+	ADDQ         $0x00000200, CX
+	SUBQ         $0x00000200, DX
+	CMPQ         DX, $0x00000200
+	JAE          BYTES_AT_LEAST_512
+	MOVQ         R9, 48(AX)
+	VPXOR        Y0, Y0, Y0
+	VMOVDQU      Y0, (SP)
+	VMOVDQU      Y0, 32(SP)
+
+BYTES_LESS_THAN_512:
+	VMOVDQU    inc1<>+0(SB), Y0
+	VMOVDQU    inc2<>+0(SB), Y14
+	VPERM2I128 $0x22, (AX), Y8, Y8
+	VPERM2I128 $0x22, 16(AX), Y9, Y9
+	VPERM2I128 $0x22, 32(AX), Y10, Y10
+
+	// 48(AX) contains only 16 bytes, so the upper lane of 32(AX) is broadcasted.
+	VPERM2I128 $0x33, 32(AX), Y11, Y11
+	VPADDQ     Y0, Y11, Y11
+	SUBQ       $0x00000100, DX
+	JCS        BYTES_BETWEEN_0_AND_255
+
 BYTES_AT_LEAST_256:
-		VMOVDQA Y8, Y0
-		VMOVDQA Y9, Y1
-		VMOVDQA Y10, Y2
-		VMOVDQA Y11, Y3
-		VMOVDQA Y8, Y4
-		VMOVDQA Y9, Y5
-		VMOVDQA Y10, Y6
-		VPADDQ Y11, Y14, Y7
-		MOVQ R8, R9
+	VMOVDQA Y8, Y0
+	VMOVDQA Y9, Y1
+	VMOVDQA Y10, Y2
+	VMOVDQA Y11, Y3
+	VMOVDQA Y8, Y4
+	VMOVDQA Y9, Y5
+	VMOVDQA Y10, Y6
+	VPADDQ  Y11, Y14, Y7
+	MOVQ    R8, R9
+
 CHACHA_LOOP_256:
-			HALF_ROUND_256(Y0, Y1, Y2, Y3, Y4, Y5, Y6, Y7, Y12)
-			SHUFFLE_256(Y1, Y5, Y2, Y6, Y3, Y7)
-			HALF_ROUND_256(Y0, Y1, Y2, Y3, Y4, Y5, Y6, Y7, Y12)
-			SHUFFLE_256(Y3, Y7, Y2, Y6, Y1, Y5)
-			SUBQ $2, R9
-			JA CHACHA_LOOP_256
-		VPADDD Y0, Y8, Y0
-		VPADDD Y1, Y9, Y1
-		VPADDD Y2, Y10, Y2
-		VPADDD Y3, Y11, Y3
-		XOR_128(CX, BX, 0, Y0, Y1, Y2, Y3, Y12)
-		VPADDD Y11, Y14, Y11
-		VPADDD Y4, Y8, Y4
-		VPADDD Y5, Y9, Y5
-		VPADDD Y6, Y10, Y6
-		VPADDD Y7, Y11, Y7
-		XOR_128(CX, BX, 128, Y4, Y5, Y6, Y7, Y12)
-		VPADDD Y11, Y14, Y11
-		ADDQ $256, BX
-		ADDQ $256, CX
-		SUBQ $256, DX
-		JCC BYTES_AT_LEAST_256
+	VPADDD     Y0, Y1, Y0
+	VPADDD     Y4, Y5, Y4
+	VPXOR      Y3, Y0, Y3
+	VPXOR      Y7, Y4, Y7
+	VPSHUFB    rol16<>+0(SB), Y3, Y3
+	VPSHUFB    rol16<>+0(SB), Y7, Y7
+	VPADDD     Y2, Y3, Y2
+	VPADDD     Y6, Y7, Y6
+	VPXOR      Y1, Y2, Y1
+	VPXOR      Y5, Y6, Y5
+	VPSLLD     $0x0c, Y1, Y12
+	VPSRLD     $0x14, Y1, Y1
+	VPXOR      Y1, Y12, Y1
+	VPSLLD     $0x0c, Y5, Y12
+	VPSRLD     $0x14, Y5, Y5
+	VPXOR      Y5, Y12, Y5
+	VPADDD     Y0, Y1, Y0
+	VPADDD     Y4, Y5, Y4
+	VPXOR      Y3, Y0, Y3
+	VPXOR      Y7, Y4, Y7
+	VPSHUFB    rol8<>+0(SB), Y3, Y3
+	VPSHUFB    rol8<>+0(SB), Y7, Y7
+	VPADDD     Y2, Y3, Y2
+	VPADDD     Y6, Y7, Y6
+	VPXOR      Y1, Y2, Y1
+	VPXOR      Y5, Y6, Y5
+	VPSLLD     $0x07, Y1, Y12
+	VPSRLD     $0x19, Y1, Y1
+	VPXOR      Y1, Y12, Y1
+	VPSLLD     $0x07, Y5, Y12
+	VPSRLD     $0x19, Y5, Y5
+	VPXOR      Y5, Y12, Y5
+	VPSHUFD    $0x39, Y1, Y1
+	VPSHUFD    $0x39, Y5, Y5
+	VPSHUFD    $0x4e, Y2, Y2
+	VPSHUFD    $0x4e, Y6, Y6
+	VPSHUFD    $0x93, Y3, Y3
+	VPSHUFD    $0x93, Y7, Y7
+	VPADDD     Y0, Y1, Y0
+	VPADDD     Y4, Y5, Y4
+	VPXOR      Y3, Y0, Y3
+	VPXOR      Y7, Y4, Y7
+	VPSHUFB    rol16<>+0(SB), Y3, Y3
+	VPSHUFB    rol16<>+0(SB), Y7, Y7
+	VPADDD     Y2, Y3, Y2
+	VPADDD     Y6, Y7, Y6
+	VPXOR      Y1, Y2, Y1
+	VPXOR      Y5, Y6, Y5
+	VPSLLD     $0x0c, Y1, Y12
+	VPSRLD     $0x14, Y1, Y1
+	VPXOR      Y1, Y12, Y1
+	VPSLLD     $0x0c, Y5, Y12
+	VPSRLD     $0x14, Y5, Y5
+	VPXOR      Y5, Y12, Y5
+	VPADDD     Y0, Y1, Y0
+	VPADDD     Y4, Y5, Y4
+	VPXOR      Y3, Y0, Y3
+	VPXOR      Y7, Y4, Y7
+	VPSHUFB    rol8<>+0(SB), Y3, Y3
+	VPSHUFB    rol8<>+0(SB), Y7, Y7
+	VPADDD     Y2, Y3, Y2
+	VPADDD     Y6, Y7, Y6
+	VPXOR      Y1, Y2, Y1
+	VPXOR      Y5, Y6, Y5
+	VPSLLD     $0x07, Y1, Y12
+	VPSRLD     $0x19, Y1, Y1
+	VPXOR      Y1, Y12, Y1
+	VPSLLD     $0x07, Y5, Y12
+	VPSRLD     $0x19, Y5, Y5
+	VPXOR      Y5, Y12, Y5
+	VPSHUFD    $0x39, Y3, Y3
+	VPSHUFD    $0x39, Y7, Y7
+	VPSHUFD    $0x4e, Y2, Y2
+	VPSHUFD    $0x4e, Y6, Y6
+	VPSHUFD    $0x93, Y1, Y1
+	VPSHUFD    $0x93, Y5, Y5
+	SUBQ       $0x02, R9
+	JA         CHACHA_LOOP_256
+	VPADDD     Y0, Y8, Y0
+	VPADDD     Y1, Y9, Y1
+	VPADDD     Y2, Y10, Y2
+	VPADDD     Y3, Y11, Y3
+	VPERM2I128 $0x20, Y1, Y0, Y12
+	VPXOR      (BX), Y12, Y12
+	VMOVDQU    Y12, (CX)
+	VPERM2I128 $0x20, Y3, Y2, Y12
+	VPXOR      32(BX), Y12, Y12
+	VMOVDQU    Y12, 32(CX)
+	VPERM2I128 $0x31, Y1, Y0, Y12
+	VPXOR      64(BX), Y12, Y12
+	VMOVDQU    Y12, 64(CX)
+	VPERM2I128 $0x31, Y3, Y2, Y12
+	VPXOR      96(BX), Y12, Y12
+	VMOVDQU    Y12, 96(CX)
+	VPADDQ     Y11, Y14, Y11
+	VPADDD     Y4, Y8, Y4
+	VPADDD     Y5, Y9, Y5
+	VPADDD     Y6, Y10, Y6
+	VPADDD     Y7, Y11, Y7
+	VPERM2I128 $0x20, Y5, Y4, Y12
+	VPXOR      128(BX), Y12, Y12
+	VMOVDQU    Y12, 128(CX)
+	VPERM2I128 $0x20, Y7, Y6, Y12
+	VPXOR      160(BX), Y12, Y12
+	VMOVDQU    Y12, 160(CX)
+	VPERM2I128 $0x31, Y5, Y4, Y12
+	VPXOR      192(BX), Y12, Y12
+	VMOVDQU    Y12, 192(CX)
+	VPERM2I128 $0x31, Y7, Y6, Y12
+	VPXOR      224(BX), Y12, Y12
+	VMOVDQU    Y12, 224(CX)
+	VPADDQ     Y11, Y14, Y11
+	ADDQ       $0x00000100, BX
+	ADDQ       $0x00000100, CX
+	SUBQ       $0x00000100, DX
+	JCC        BYTES_AT_LEAST_256
+
 BYTES_BETWEEN_0_AND_255:
-	ADDQ $256, DX
-	JEQ WRITE_EVEN_64_BLOCKS
+	ADDQ $0x00000100, DX
+	JEQ  WRITE_EVEN_64_BLOCKS
+
 BYTES_LESS_THAN_255:
-		VMOVDQA Y8, Y0
-		VMOVDQA Y9, Y1
-		VMOVDQA Y10, Y2
-		VMOVDQA Y11, Y3
-		MOVQ R8, R9
+	VMOVDQA Y8, Y0
+	VMOVDQA Y9, Y1
+	VMOVDQA Y10, Y2
+	VMOVDQA Y11, Y3
+	MOVQ    R8, R9
+
 CHACHA_LOOP_128:
-			HALF_ROUND_128(Y0, Y1, Y2, Y3, Y12)
-			SHUFFLE_128(Y1, Y2, Y3)
-			HALF_ROUND_128(Y0, Y1, Y2, Y3, Y12)
-			SHUFFLE_128(Y3, Y2, Y1)
-			SUBQ $2, R9
-			JA CHACHA_LOOP_128
-		VPADDD Y0, Y8, Y0
-		VPADDD Y1, Y9, Y1
-		VPADDD Y2, Y10, Y2
-		VPADDD Y3, Y11, Y3
-		
-		VPERM2I128 $32, Y1, Y0, Y12
-		VPXOR 0(BX), Y12, Y12
-		VMOVDQU Y12, 0(CX)
-		VPERM2I128 $32, Y3, Y2, Y12
-		VPXOR 32(BX), Y12, Y12
-		VMOVDQU Y12, 32(CX)
-		SUBQ $64, DX
-		JEQ WRITE_ODD_64_BLOCKS
-		
-		VPADDD Y11, Y14, Y11
-		VPERM2I128 $49, Y1, Y0, Y12
-		VPXOR 64(BX), Y12, Y12
-		VMOVDQU Y12, 64(CX)
-		VPERM2I128 $49, Y3, Y2, Y12
-		VPXOR 96(BX), Y12, Y12
-		VMOVDQU Y12, 96(CX)
-		SUBQ $64, DX
-		JEQ WRITE_EVEN_64_BLOCKS
-		
-		ADDQ $128, BX
-		ADDQ $128, CX
-		JMP BYTES_LESS_THAN_255
+	VPADDD     Y0, Y1, Y0
+	VPXOR      Y3, Y0, Y3
+	VPSHUFB    rol16<>+0(SB), Y3, Y3
+	VPADDD     Y2, Y3, Y2
+	VPXOR      Y1, Y2, Y1
+	VPSLLD     $0x0c, Y1, Y12
+	VPSRLD     $0x14, Y1, Y1
+	VPXOR      Y1, Y12, Y1
+	VPADDD     Y0, Y1, Y0
+	VPXOR      Y3, Y0, Y3
+	VPSHUFB    rol8<>+0(SB), Y3, Y3
+	VPADDD     Y2, Y3, Y2
+	VPXOR      Y1, Y2, Y1
+	VPSLLD     $0x07, Y1, Y12
+	VPSRLD     $0x19, Y1, Y1
+	VPXOR      Y1, Y12, Y1
+	VPSHUFD    $0x39, Y1, Y1
+	VPSHUFD    $0x4e, Y2, Y2
+	VPSHUFD    $0x93, Y3, Y3
+	VPADDD     Y0, Y1, Y0
+	VPXOR      Y3, Y0, Y3
+	VPSHUFB    rol16<>+0(SB), Y3, Y3
+	VPADDD     Y2, Y3, Y2
+	VPXOR      Y1, Y2, Y1
+	VPSLLD     $0x0c, Y1, Y12
+	VPSRLD     $0x14, Y1, Y1
+	VPXOR      Y1, Y12, Y1
+	VPADDD     Y0, Y1, Y0
+	VPXOR      Y3, Y0, Y3
+	VPSHUFB    rol8<>+0(SB), Y3, Y3
+	VPADDD     Y2, Y3, Y2
+	VPXOR      Y1, Y2, Y1
+	VPSLLD     $0x07, Y1, Y12
+	VPSRLD     $0x19, Y1, Y1
+	VPXOR      Y1, Y12, Y1
+	VPSHUFD    $0x39, Y3, Y3
+	VPSHUFD    $0x4e, Y2, Y2
+	VPSHUFD    $0x93, Y1, Y1
+	SUBQ       $0x02, R9
+	JA         CHACHA_LOOP_128
+	VPADDD     Y0, Y8, Y0
+	VPADDD     Y1, Y9, Y1
+	VPADDD     Y2, Y10, Y2
+	VPADDD     Y3, Y11, Y3
+	VPERM2I128 $0x20, Y1, Y0, Y12
+	VPXOR      (BX), Y12, Y12
+	VMOVDQU    Y12, (CX)
+	VPERM2I128 $0x20, Y3, Y2, Y12
+	VPXOR      32(BX), Y12, Y12
+	VMOVDQU    Y12, 32(CX)
+	SUBQ       $0x40, DX
+	JEQ        WRITE_ODD_64_BLOCKS
+	VPADDQ     Y11, Y14, Y11
+	VPERM2I128 $0x31, Y1, Y0, Y12
+	VPXOR      64(BX), Y12, Y12
+	VMOVDQU    Y12, 64(CX)
+	VPERM2I128 $0x31, Y3, Y2, Y12
+	VPXOR      96(BX), Y12, Y12
+	VMOVDQU    Y12, 96(CX)
+	SUBQ       $0x40, DX
+	JEQ        WRITE_EVEN_64_BLOCKS
+	ADDQ       $0x00000080, BX
+	ADDQ       $0x00000080, CX
+	JMP        BYTES_LESS_THAN_255
+
 WRITE_ODD_64_BLOCKS:
-	VPERM2I128 $1, Y11, Y11, Y11
+	VPERM2I128 $0x01, Y11, Y11, Y11
+
 WRITE_EVEN_64_BLOCKS:
-	MOVO X11, 48(AX)
+	VMOVDQU X11, 48(AX)
+	VZEROUPPER
 	RET
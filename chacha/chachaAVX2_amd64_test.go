@@ -0,0 +1,77 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+// +build go1.7,amd64,!gccgo,!appengine,!purego
+
+package chacha
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestCalibrate(t *testing.T) {
+	defer func(n int) { avx512MinLength = n }(avx512MinLength)
+	defer SetBackend(ActiveBackend())
+
+	Calibrate()
+	if n := avx512MinLength; n < 1024 || n > 64*1024 || n%1024 != 0 {
+		t.Fatalf("Calibrate sets invalid AVX512 threshold: %d", n)
+	}
+
+	var key [32]byte
+	var nonce [12]byte
+	src := make([]byte, 64*1024+64+17)
+	for i := range src {
+		src[i] = byte(i)
+	}
+	dst0, dst1 := make([]byte, len(src)), make([]byte, len(src))
+	XORKeyStream(dst0, src, &nonce, &key, 0, 20)
+	SetBackend(Generic)
+	XORKeyStream(dst1, src, &nonce, &key, 0, 20)
+	if !bytes.Equal(dst0, dst1) {
+		t.Fatalf("XORKeyStream produces unexpected keystream after Calibrate")
+	}
+}
+
+// The post-call benchmarks measure legacy SSE code running directly after
+// an AVX2 / AVX512 call. If the wide code leaves dirty upper register state
+// behind, "Wide+SSE" is noticeably slower than "Wide" and "SSE" together.
+func BenchmarkPostCallAVX2(b *testing.B)   { benchmarkPostCall(b, AVX2, 1024) }
+func BenchmarkPostCallAVX512(b *testing.B) { benchmarkPostCall(b, AVX512, avx512MinLength) }
+
+func benchmarkPostCall(b *testing.B, backend Backend, size int) {
+	if err := SetBackend(backend); err != nil {
+		b.Skipf("%v is not supported", backend)
+	}
+	defer SetBackend(defaultBackend())
+
+	var key [32]byte
+	var nonce [12]byte
+	var state, block [64]byte
+	buf := make([]byte, size)
+
+	wide := func() { XORKeyStream(buf, buf, &nonce, &key, 0, 20) }
+	sse := func() {
+		for i := 0; i < 16; i++ {
+			coreSSE2(&block, &state, 20)
+		}
+	}
+	b.Run("Wide", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			wide()
+		}
+	})
+	b.Run("SSE", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			sse()
+		}
+	})
+	b.Run("Wide+SSE", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			wide()
+			sse()
+		}
+	})
+}
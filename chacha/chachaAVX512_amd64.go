@@ -0,0 +1,14 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+// +build go1.11,amd64,!gccgo,!appengine,!purego
+
+package chacha
+
+const hasAVX512 = true
+
+// xorBlocksAVX512 crypts len(src) - (len(src) mod 1024) bytes from src to
+// dst using the state. It processes 16 blocks in parallel.
+//go:noescape
+func xorBlocksAVX512(dst, src []byte, state *[64]byte, rounds int)
@@ -0,0 +1,59 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+// +build go1.11,amd64,!gccgo,!appengine,!purego
+
+package chacha
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestXORBlocksAVX512(t *testing.T) {
+	if !supportsBackend(AVX512) {
+		t.Skip("AVX512 is not supported")
+	}
+	var key [32]byte
+	var nonce [12]byte
+	for i := range key {
+		key[i] = byte(i)
+	}
+	for i := range nonce {
+		nonce[i] = byte(255 - i)
+	}
+	src := make([]byte, avx512MinLength+3*1024+64+17)
+	for i := range src {
+		src[i] = byte(i)
+	}
+	defer SetBackend(ActiveBackend())
+
+	// the last counter tests the end of the keystream of the 32 bit counter
+	for _, counter := range []uint32{0, 1, uint32(1<<32 - (len(src)+63)/64)} {
+		dst0, dst1 := make([]byte, len(src)), make([]byte, len(src))
+
+		SetBackend(AVX512)
+		XORKeyStream(dst0, src, &nonce, &key, counter, 20)
+		SetBackend(Generic)
+		XORKeyStream(dst1, src, &nonce, &key, counter, 20)
+		if !bytes.Equal(dst0, dst1) {
+			t.Fatalf("Counter %x: AVX512 produces unexpected keystream", counter)
+		}
+	}
+}
+
+func BenchmarkXORKeyStreamAVX512(b *testing.B) {
+	if err := SetBackend(AVX512); err != nil {
+		b.Skip("AVX512 is not supported")
+	}
+	defer SetBackend(defaultBackend())
+	var key [32]byte
+	var nonce [12]byte
+	buf := make([]byte, 64*1024)
+	b.SetBytes(int64(len(buf)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		XORKeyStream(buf, buf, &nonce, &key, 0, 20)
+	}
+}
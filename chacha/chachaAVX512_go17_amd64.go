@@ -0,0 +1,14 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+// +build go1.7,!go1.11,amd64,!gccgo,!appengine,!purego
+
+package chacha
+
+// The assembler supports AVX512 since go1.11
+const hasAVX512 = false
+
+func xorBlocksAVX512(dst, src []byte, state *[64]byte, rounds int) {
+	panic("chacha20/chacha: AVX512 is not supported")
+}
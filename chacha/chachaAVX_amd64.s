@@ -0,0 +1,364 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+// Code generated by command: go run asm.go -out ../../chachaAVX_amd64.s. DO NOT EDIT.
+
+//go:build go1.7 && amd64 && !gccgo && !appengine && !purego
+// +build go1.7,amd64,!gccgo,!appengine,!purego
+
+#include "textflag.h"
+
+DATA rol16<>+0(SB)/8, $0x0504070601000302
+DATA rol16<>+8(SB)/8, $0x0d0c0f0e09080b0a
+GLOBL rol16<>(SB), RODATA|NOPTR, $16
+
+DATA rol8<>+0(SB)/8, $0x0605040702010003
+DATA rol8<>+8(SB)/8, $0x0e0d0c0f0a09080b
+GLOBL rol8<>(SB), RODATA|NOPTR, $16
+
+// func xorBlocksAVX(dst []byte, src []byte, state *[64]byte, rounds int)
+// Requires: AVX
+TEXT ·xorBlocksAVX(SB), NOSPLIT, $0-64
+	MOVQ state+48(FP), AX
+	MOVQ dst_base+0(FP), BX
+	MOVQ src_base+24(FP), CX
+	MOVQ src_len+32(FP), DX
+	MOVQ rounds+56(FP), DI
+	MOVQ dst_len+8(FP), R8
+
+	// The stack holds a 32 byte spill slot and the splatted state (every
+	// word of the state copied 4 times) at 32(SP).
+	MOVQ    SP, SI
+	ANDQ    $-16, SP
+	SUBQ    $0x00000120, SP
+	CMPQ    R8, DX
+	JB      DONE
+	CMPQ    DX, $0x00000100
+	JB      DONE
+	MOVQ    48(AX), R9
+	VMOVDQU (AX), X0
+	VPSHUFD $0x00, X0, X1
+	VMOVDQA X1, 32(SP)
+	VPSHUFD $0x55, X0, X1
+	VMOVDQA X1, 48(SP)
+	VPSHUFD $0xaa, X0, X1
+	VMOVDQA X1, 64(SP)
+	VPSHUFD $0xff, X0, X1
+	VMOVDQA X1, 80(SP)
+	VMOVDQU 16(AX), X0
+	VPSHUFD $0x00, X0, X1
+	VMOVDQA X1, 96(SP)
+	VPSHUFD $0x55, X0, X1
+	VMOVDQA X1, 112(SP)
+	VPSHUFD $0xaa, X0, X1
+	VMOVDQA X1, 128(SP)
+	VPSHUFD $0xff, X0, X1
+	VMOVDQA X1, 144(SP)
+	VMOVDQU 32(AX), X0
+	VPSHUFD $0x00, X0, X1
+	VMOVDQA X1, 160(SP)
+	VPSHUFD $0x55, X0, X1
+	VMOVDQA X1, 176(SP)
+	VPSHUFD $0xaa, X0, X1
+	VMOVDQA X1, 192(SP)
+	VPSHUFD $0xff, X0, X1
+	VMOVDQA X1, 208(SP)
+	VMOVDQU 48(AX), X0
+	VPSHUFD $0x00, X0, X1
+	VMOVDQA X1, 224(SP)
+	VPSHUFD $0x55, X0, X1
+	VMOVDQA X1, 240(SP)
+	VPSHUFD $0xaa, X0, X1
+	VMOVDQA X1, 256(SP)
+	VPSHUFD $0xff, X0, X1
+	VMOVDQA X1, 272(SP)
+
+BYTES_AT_LEAST_256:
+	LEAQ    (R9), R10
+	MOVL    R10, 224(SP)
+	SHRQ    $0x20, R10
+	MOVL    R10, 240(SP)
+	LEAQ    1(R9), R10
+	MOVL    R10, 228(SP)
+	SHRQ    $0x20, R10
+	MOVL    R10, 244(SP)
+	LEAQ    2(R9), R10
+	MOVL    R10, 232(SP)
+	SHRQ    $0x20, R10
+	MOVL    R10, 248(SP)
+	LEAQ    3(R9), R10
+	MOVL    R10, 236(SP)
+	SHRQ    $0x20, R10
+	MOVL    R10, 252(SP)
+	VMOVDQA 32(SP), X0
+	VMOVDQA 48(SP), X1
+	VMOVDQA 64(SP), X2
+	VMOVDQA 80(SP), X3
+	VMOVDQA 96(SP), X4
+	VMOVDQA 112(SP), X5
+	VMOVDQA 128(SP), X6
+	VMOVDQA 144(SP), X7
+	VMOVDQA 160(SP), X8
+	VMOVDQA 176(SP), X9
+	VMOVDQA 192(SP), X10
+	VMOVDQA 208(SP), X11
+	VMOVDQA 224(SP), X12
+	VMOVDQA 240(SP), X13
+	VMOVDQA 256(SP), X14
+	VMOVDQA 272(SP), X15
+	MOVQ    DI, R8
+
+CHACHA_LOOP_256:
+	VPADDD      X4, X0, X0
+	VPADDD      X5, X1, X1
+	VPADDD      X6, X2, X2
+	VPADDD      X7, X3, X3
+	VPXOR       X0, X12, X12
+	VPXOR       X1, X13, X13
+	VPXOR       X2, X14, X14
+	VPXOR       X3, X15, X15
+	VPSHUFB     rol16<>+0(SB), X12, X12
+	VPSHUFB     rol16<>+0(SB), X13, X13
+	VPSHUFB     rol16<>+0(SB), X14, X14
+	VPSHUFB     rol16<>+0(SB), X15, X15
+	VPADDD      X12, X8, X8
+	VPADDD      X13, X9, X9
+	VPADDD      X14, X10, X10
+	VPADDD      X15, X11, X11
+	VPXOR       X8, X4, X4
+	VPXOR       X9, X5, X5
+	VPXOR       X10, X6, X6
+	VPXOR       X11, X7, X7
+	VMOVDQA     X12, (SP)
+	VPSLLD      $0x0c, X4, X12
+	VPSRLD      $0x14, X4, X4
+	VPXOR       X12, X4, X4
+	VPSLLD      $0x0c, X5, X12
+	VPSRLD      $0x14, X5, X5
+	VPXOR       X12, X5, X5
+	VPSLLD      $0x0c, X6, X12
+	VPSRLD      $0x14, X6, X6
+	VPXOR       X12, X6, X6
+	VPSLLD      $0x0c, X7, X12
+	VPSRLD      $0x14, X7, X7
+	VPXOR       X12, X7, X7
+	VMOVDQA     (SP), X12
+	VPADDD      X4, X0, X0
+	VPADDD      X5, X1, X1
+	VPADDD      X6, X2, X2
+	VPADDD      X7, X3, X3
+	VPXOR       X0, X12, X12
+	VPXOR       X1, X13, X13
+	VPXOR       X2, X14, X14
+	VPXOR       X3, X15, X15
+	VPSHUFB     rol8<>+0(SB), X12, X12
+	VPSHUFB     rol8<>+0(SB), X13, X13
+	VPSHUFB     rol8<>+0(SB), X14, X14
+	VPSHUFB     rol8<>+0(SB), X15, X15
+	VPADDD      X12, X8, X8
+	VPADDD      X13, X9, X9
+	VPADDD      X14, X10, X10
+	VPADDD      X15, X11, X11
+	VPXOR       X8, X4, X4
+	VPXOR       X9, X5, X5
+	VPXOR       X10, X6, X6
+	VPXOR       X11, X7, X7
+	VMOVDQA     X12, (SP)
+	VPSLLD      $0x07, X4, X12
+	VPSRLD      $0x19, X4, X4
+	VPXOR       X12, X4, X4
+	VPSLLD      $0x07, X5, X12
+	VPSRLD      $0x19, X5, X5
+	VPXOR       X12, X5, X5
+	VPSLLD      $0x07, X6, X12
+	VPSRLD      $0x19, X6, X6
+	VPXOR       X12, X6, X6
+	VPSLLD      $0x07, X7, X12
+	VPSRLD      $0x19, X7, X7
+	VPXOR       X12, X7, X7
+	VMOVDQA     (SP), X12
+	VPADDD      X5, X0, X0
+	VPADDD      X6, X1, X1
+	VPADDD      X7, X2, X2
+	VPADDD      X4, X3, X3
+	VPXOR       X0, X15, X15
+	VPXOR       X1, X12, X12
+	VPXOR       X2, X13, X13
+	VPXOR       X3, X14, X14
+	VPSHUFB     rol16<>+0(SB), X15, X15
+	VPSHUFB     rol16<>+0(SB), X12, X12
+	VPSHUFB     rol16<>+0(SB), X13, X13
+	VPSHUFB     rol16<>+0(SB), X14, X14
+	VPADDD      X15, X10, X10
+	VPADDD      X12, X11, X11
+	VPADDD      X13, X8, X8
+	VPADDD      X14, X9, X9
+	VPXOR       X10, X5, X5
+	VPXOR       X11, X6, X6
+	VPXOR       X8, X7, X7
+	VPXOR       X9, X4, X4
+	VMOVDQA     X15, (SP)
+	VPSLLD      $0x0c, X5, X15
+	VPSRLD      $0x14, X5, X5
+	VPXOR       X15, X5, X5
+	VPSLLD      $0x0c, X6, X15
+	VPSRLD      $0x14, X6, X6
+	VPXOR       X15, X6, X6
+	VPSLLD      $0x0c, X7, X15
+	VPSRLD      $0x14, X7, X7
+	VPXOR       X15, X7, X7
+	VPSLLD      $0x0c, X4, X15
+	VPSRLD      $0x14, X4, X4
+	VPXOR       X15, X4, X4
+	VMOVDQA     (SP), X15
+	VPADDD      X5, X0, X0
+	VPADDD      X6, X1, X1
+	VPADDD      X7, X2, X2
+	VPADDD      X4, X3, X3
+	VPXOR       X0, X15, X15
+	VPXOR       X1, X12, X12
+	VPXOR       X2, X13, X13
+	VPXOR       X3, X14, X14
+	VPSHUFB     rol8<>+0(SB), X15, X15
+	VPSHUFB     rol8<>+0(SB), X12, X12
+	VPSHUFB     rol8<>+0(SB), X13, X13
+	VPSHUFB     rol8<>+0(SB), X14, X14
+	VPADDD      X15, X10, X10
+	VPADDD      X12, X11, X11
+	VPADDD      X13, X8, X8
+	VPADDD      X14, X9, X9
+	VPXOR       X10, X5, X5
+	VPXOR       X11, X6, X6
+	VPXOR       X8, X7, X7
+	VPXOR       X9, X4, X4
+	VMOVDQA     X15, (SP)
+	VPSLLD      $0x07, X5, X15
+	VPSRLD      $0x19, X5, X5
+	VPXOR       X15, X5, X5
+	VPSLLD      $0x07, X6, X15
+	VPSRLD      $0x19, X6, X6
+	VPXOR       X15, X6, X6
+	VPSLLD      $0x07, X7, X15
+	VPSRLD      $0x19, X7, X7
+	VPXOR       X15, X7, X7
+	VPSLLD      $0x07, X4, X15
+	VPSRLD      $0x19, X4, X4
+	VPXOR       X15, X4, X4
+	VMOVDQA     (SP), X15
+	SUBQ        $0x02, R8
+	JA          CHACHA_LOOP_256
+	VPADDD      32(SP), X0, X0
+	VPADDD      48(SP), X1, X1
+	VPADDD      64(SP), X2, X2
+	VPADDD      80(SP), X3, X3
+	VPADDD      96(SP), X4, X4
+	VPADDD      112(SP), X5, X5
+	VPADDD      128(SP), X6, X6
+	VPADDD      144(SP), X7, X7
+	VPADDD      160(SP), X8, X8
+	VPADDD      176(SP), X9, X9
+	VPADDD      192(SP), X10, X10
+	VPADDD      208(SP), X11, X11
+	VPADDD      224(SP), X12, X12
+	VPADDD      240(SP), X13, X13
+	VPADDD      256(SP), X14, X14
+	VPADDD      272(SP), X15, X15
+	VMOVDQA     X14, (SP)
+	VMOVDQA     X15, 16(SP)
+	VPUNPCKLDQ  X1, X0, X14
+	VPUNPCKHDQ  X1, X0, X15
+	VPUNPCKLDQ  X3, X2, X0
+	VPUNPCKHDQ  X3, X2, X1
+	VPUNPCKLQDQ X0, X14, X2
+	VPUNPCKHQDQ X0, X14, X3
+	VPUNPCKLQDQ X1, X15, X0
+	VPUNPCKHQDQ X1, X15, X1
+	VPXOR       (CX), X2, X14
+	VMOVDQU     X14, (BX)
+	VPXOR       64(CX), X3, X14
+	VMOVDQU     X14, 64(BX)
+	VPXOR       128(CX), X0, X14
+	VMOVDQU     X14, 128(BX)
+	VPXOR       192(CX), X1, X14
+	VMOVDQU     X14, 192(BX)
+	VPUNPCKLDQ  X5, X4, X0
+	VPUNPCKHDQ  X5, X4, X1
+	VPUNPCKLDQ  X7, X6, X4
+	VPUNPCKHDQ  X7, X6, X5
+	VPUNPCKLQDQ X4, X0, X6
+	VPUNPCKHQDQ X4, X0, X7
+	VPUNPCKLQDQ X5, X1, X4
+	VPUNPCKHQDQ X5, X1, X5
+	VPXOR       16(CX), X6, X0
+	VMOVDQU     X0, 16(BX)
+	VPXOR       80(CX), X7, X0
+	VMOVDQU     X0, 80(BX)
+	VPXOR       144(CX), X4, X0
+	VMOVDQU     X0, 144(BX)
+	VPXOR       208(CX), X5, X0
+	VMOVDQU     X0, 208(BX)
+	VPUNPCKLDQ  X9, X8, X0
+	VPUNPCKHDQ  X9, X8, X1
+	VPUNPCKLDQ  X11, X10, X8
+	VPUNPCKHDQ  X11, X10, X9
+	VPUNPCKLQDQ X8, X0, X10
+	VPUNPCKHQDQ X8, X0, X11
+	VPUNPCKLQDQ X9, X1, X8
+	VPUNPCKHQDQ X9, X1, X9
+	VPXOR       32(CX), X10, X0
+	VMOVDQU     X0, 32(BX)
+	VPXOR       96(CX), X11, X0
+	VMOVDQU     X0, 96(BX)
+	VPXOR       160(CX), X8, X0
+	VMOVDQU     X0, 160(BX)
+	VPXOR       224(CX), X9, X0
+	VMOVDQU     X0, 224(BX)
+	VMOVDQA     (SP), X14
+	VMOVDQA     16(SP), X15
+	VPUNPCKLDQ  X13, X12, X0
+	VPUNPCKHDQ  X13, X12, X1
+	VPUNPCKLDQ  X15, X14, X12
+	VPUNPCKHDQ  X15, X14, X13
+	VPUNPCKLQDQ X12, X0, X14
+	VPUNPCKHQDQ X12, X0, X15
+	VPUNPCKLQDQ X13, X1, X12
+	VPUNPCKHQDQ X13, X1, X13
+	VPXOR       48(CX), X14, X0
+	VMOVDQU     X0, 48(BX)
+	VPXOR       112(CX), X15, X0
+	VMOVDQU     X0, 112(BX)
+	VPXOR       176(CX), X12, X0
+	VMOVDQU     X0, 176(BX)
+	VPXOR       240(CX), X13, X0
+	VMOVDQU     X0, 240(BX)
+	ADDQ        $0x04, R9
+	ADDQ        $0x00000100, CX
+	ADDQ        $0x00000100, BX
+	SUBQ        $0x00000100, DX
+	CMPQ        DX, $0x00000100
+	JAE         BYTES_AT_LEAST_256
+	MOVQ        R9, 48(AX)
+
+DONE:
+	VPXOR   X0, X0, X0
+	VMOVDQA X0, (SP)
+	VMOVDQA X0, 16(SP)
+	VMOVDQA X0, 32(SP)
+	VMOVDQA X0, 48(SP)
+	VMOVDQA X0, 64(SP)
+	VMOVDQA X0, 80(SP)
+	VMOVDQA X0, 96(SP)
+	VMOVDQA X0, 112(SP)
+	VMOVDQA X0, 128(SP)
+	VMOVDQA X0, 144(SP)
+	VMOVDQA X0, 160(SP)
+	VMOVDQA X0, 176(SP)
+	VMOVDQA X0, 192(SP)
+	VMOVDQA X0, 208(SP)
+	VMOVDQA X0, 224(SP)
+	VMOVDQA X0, 240(SP)
+	VMOVDQA X0, 256(SP)
+	VMOVDQA X0, 272(SP)
+	MOVQ    SI, SP
+	RET
@@ -2,17 +2,26 @@
 // Use of this source code is governed by a license that can be
 // found in the LICENSE file.
 
-// +build amd64, !gccgo, !appengine, !go1.7
+// +build amd64,!gccgo,!appengine,!purego,!go1.7
 
 package chacha
 
+const (
+	hasAVX    = false
+	hasAVX2   = false
+	hasAVX512 = false
+)
+
 // xorBlocks crypts full block ( len(src) - (len(src) mod 64) bytes ) from src to
 // dst using the state. Src and dst may be the same slice but otherwise should not
 // overlap. This function increments the counter of state.
 func xorBlocks(dst, src []byte, state *[64]byte, rounds int) {
-	if useSSSE3 {
-		xorBlocksSSSE3(dst, src, state, rounds)
-	} else {
+	switch backend {
+	case Generic:
+		xorBlocksGeneric(dst, src, state, rounds)
+	case SSE2:
 		xorBlocksSSE2(dst, src, state, rounds)
+	default:
+		xorBlocksSSSE3(dst, src, state, rounds)
 	}
 }
@@ -0,0 +1,56 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+// +build 386,!gccgo,!appengine,!purego
+
+package chacha
+
+func defaultBackend() Backend { return SSE2 }
+
+func supportsBackend(b Backend) bool { return b == SSE2 }
+
+func missingFeature(b Backend) string { return "" }
+
+// xorBlocks crypts full block ( len(src) - (len(src) mod 64) bytes ) from src to
+// dst using the state. Src and dst may be the same slice
+// but otherwise should not overlap. If len(dst) < len(src) the behavior is undefined.
+// This function increments the counter of state.
+func xorBlocks(dst, src []byte, state *[64]byte, rounds int) {
+	if backend == Generic {
+		xorBlocksGeneric(dst, src, state, rounds)
+		return
+	}
+
+	n := len(src) & (^(128 - 1))
+	if n > 0 {
+		xorBlocksSSE2(dst[:n], src[:n], state, rounds)
+	}
+
+	if n < len(src)&(^(64 - 1)) {
+		var block [64]byte
+		coreSSE2(&block, state, rounds)
+		xor(dst[n:], src[n:], block[:])
+	}
+}
+
+// Core generates 64 byte keystream from the given state performing 'rounds' rounds
+// and writes them to dst. This function expects valid values. (no nil ptr etc.)
+// Core increments the counter of the state.
+func Core(dst *[64]byte, state *[64]byte, rounds int) {
+	if backend == Generic {
+		core(dst, state, rounds)
+	} else {
+		coreSSE2(dst, state, rounds)
+	}
+}
+
+// xorBlocksSSE2 crypts len(src) - (len(src) mod 128) bytes from src to
+// dst using the state. It processes 2 blocks in parallel.
+//go:noescape
+func xorBlocksSSE2(dst, src []byte, state *[64]byte, rounds int)
+
+// coreSSE2 generates 64 byte keystream from the given state performing 'rounds' rounds
+// and writes them to dst.
+//go:noescape
+func coreSSE2(dst *[64]byte, state *[64]byte, rounds int)
@@ -0,0 +1,194 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+// +build 386,!gccgo,!appengine,!purego
+
+#include "textflag.h"
+
+DATA one<>+0x00(SB)/4, $1
+DATA one<>+0x04(SB)/4, $0
+DATA one<>+0x08(SB)/4, $0
+DATA one<>+0x0c(SB)/4, $0
+GLOBL one<>(SB), (NOPTR+RODATA), $16
+
+// On 386 there are only 8 XMM registers, so the SSE2 implementation computes
+// at most 2 blocks in parallel. The state and the stack are not necessarily
+// 16 byte aligned, so all memory accesses are unaligned (MOVOU).
+
+#define ROTL_SSE2(n, t, v) \
+	MOVO v, t; \
+	PSLLL $n, t; \
+	PSRLL $(32-n), v; \
+	PXOR t, v
+
+#define SHUFFLE_64(k0, k1, k2, a, b, c) \
+	PSHUFL $k0, a, a; \
+	PSHUFL $k1, b, b; \
+	PSHUFL $k2, c, c
+
+#define SHUFFLE_128(k0, k1, k2, a0, a1, b0, b1, c0, c1) \
+	PSHUFL $k0, a0, a0; \
+	PSHUFL $k0, a1, a1; \
+	PSHUFL $k1, b0, b0; \
+	PSHUFL $k1, b1, b1; \
+	PSHUFL $k2, c0, c0; \
+	PSHUFL $k2, c1, c1
+
+#define HALF_ROUND_64_SSE2(v0, v1, v2, v3, t0) \
+	PADDL v1, v0; \
+	PXOR v0, v3; \
+	ROTL_SSE2(16, t0, v3); \
+	PADDL v3, v2; \
+	PXOR v2, v1; \
+	ROTL_SSE2(12, t0, v1); \
+	PADDL v1, v0; \
+	PXOR v0, v3; \
+	ROTL_SSE2(8, t0, v3); \
+	PADDL v3, v2; \
+	PXOR v2, v1; \
+	ROTL_SSE2(7, t0, v1)
+
+// HALF_ROUND_128_SSE2 uses v4 as temp. register while its value is
+// spilled to the stack slot t0.
+#define HALF_ROUND_128_SSE2(v0, v1, v2, v3, v4, v5, v6, v7, t0) \
+	PADDL v1, v0; \
+	PADDL v5, v4; \
+	PXOR v0, v3; \
+	PXOR v4, v7; \
+	MOVOU v4, t0; \
+	ROTL_SSE2(16, v4, v3); \
+	ROTL_SSE2(16, v4, v7); \
+	MOVOU t0, v4; \
+	PADDL v3, v2; \
+	PADDL v7, v6; \
+	PXOR v2, v1; \
+	PXOR v6, v5; \
+	MOVOU v4, t0; \
+	ROTL_SSE2(12, v4, v1); \
+	ROTL_SSE2(12, v4, v5); \
+	MOVOU t0, v4; \
+	PADDL v1, v0; \
+	PADDL v5, v4; \
+	PXOR v0, v3; \
+	PXOR v4, v7; \
+	MOVOU v4, t0; \
+	ROTL_SSE2(8, v4, v3); \
+	ROTL_SSE2(8, v4, v7); \
+	PADDL v3, v2; \
+	PADDL v7, v6; \
+	PXOR v2, v1; \
+	PXOR v6, v5; \
+	ROTL_SSE2(7, v4, v1); \
+	ROTL_SSE2(7, v4, v5); \
+	MOVOU t0, v4
+
+// XOR_64 xors the 64 bytes at off(src) with the block v0, v1, v2, v3
+// and writes the result to off(dst).
+#define XOR_64(dst, src, off, v0, v1, v2, v3, t0) \
+	MOVOU 0+off(src), t0; \
+	PXOR v0, t0; \
+	MOVOU t0, 0+off(dst); \
+	MOVOU 16+off(src), t0; \
+	PXOR v1, t0; \
+	MOVOU t0, 16+off(dst); \
+	MOVOU 32+off(src), t0; \
+	PXOR v2, t0; \
+	MOVOU t0, 32+off(dst); \
+	MOVOU 48+off(src), t0; \
+	PXOR v3, t0; \
+	MOVOU t0, 48+off(dst)
+
+// ADD_STATE adds the state rows at state to v0, v1, v2 and v3.
+#define ADD_STATE(state, v0, v1, v2, v3, t0) \
+	MOVOU 0(state), t0; \
+	PADDL t0, v0; \
+	MOVOU 16(state), t0; \
+	PADDL t0, v1; \
+	MOVOU 32(state), t0; \
+	PADDL t0, v2; \
+	MOVOU 48(state), t0; \
+	PADDL t0, v3
+
+// func xorBlocksSSE2(dst, src []byte, state *[64]byte, rounds int)
+TEXT ·xorBlocksSSE2(SB),4,$16-32
+	MOVL dst_base+0(FP), DI
+	MOVL src_base+12(FP), SI
+	MOVL src_len+16(FP), DX
+	MOVL state+24(FP), AX
+	SHRL $7, DX // number of 2 block chunks
+	JZ DONE
+
+LOOP:
+	MOVOU 0(AX), X0
+	MOVOU 16(AX), X1
+	MOVOU 32(AX), X2
+	MOVOU 48(AX), X3
+	MOVOU one<>(SB), X4
+	MOVO X3, X7
+	PADDL X4, X7
+	MOVO X0, X4
+	MOVO X1, X5
+	MOVO X2, X6
+
+	MOVL rounds+28(FP), BX
+CHACHA_LOOP:
+	HALF_ROUND_128_SSE2(X0, X1, X2, X3, X4, X5, X6, X7, tmp-16(SP))
+	SHUFFLE_128(0x39, 0x4E, 0x93, X1, X5, X2, X6, X3, X7)
+	HALF_ROUND_128_SSE2(X0, X1, X2, X3, X4, X5, X6, X7, tmp-16(SP))
+	SHUFFLE_128(0x93, 0x4E, 0x39, X1, X5, X2, X6, X3, X7)
+	SUBL $2, BX
+	JA CHACHA_LOOP
+
+	// the first block uses X4 as temp. register
+	MOVOU X4, tmp-16(SP)
+	ADD_STATE(AX, X0, X1, X2, X3, X4)
+	XOR_64(DI, SI, 0, X0, X1, X2, X3, X4)
+	MOVOU tmp-16(SP), X4
+	ADD_STATE(AX, X4, X5, X6, X7, X0)
+	MOVOU one<>(SB), X0
+	PADDL X0, X7
+	XOR_64(DI, SI, 64, X4, X5, X6, X7, X0)
+
+	// increment the 32 bit counter
+	MOVL 48(AX), CX
+	ADDL $2, CX
+	MOVL CX, 48(AX)
+
+	ADDL $128, SI
+	ADDL $128, DI
+	SUBL $1, DX
+	JNZ LOOP
+
+DONE:
+	RET
+
+// func coreSSE2(dst *[64]byte, state *[64]byte, rounds int)
+TEXT ·coreSSE2(SB),4,$0-12
+	MOVL dst+0(FP), DI
+	MOVL state+4(FP), AX
+	MOVL rounds+8(FP), BX
+
+	MOVOU 0(AX), X0
+	MOVOU 16(AX), X1
+	MOVOU 32(AX), X2
+	MOVOU 48(AX), X3
+CHACHA_LOOP:
+	HALF_ROUND_64_SSE2(X0, X1, X2, X3, X4)
+	SHUFFLE_64(0x39, 0x4E, 0x93, X1, X2, X3)
+	HALF_ROUND_64_SSE2(X0, X1, X2, X3, X4)
+	SHUFFLE_64(0x93, 0x4E, 0x39, X1, X2, X3)
+	SUBL $2, BX
+	JA CHACHA_LOOP
+
+	ADD_STATE(AX, X0, X1, X2, X3, X4)
+	MOVOU X0, 0(DI)
+	MOVOU X1, 16(DI)
+	MOVOU X2, 32(DI)
+	MOVOU X3, 48(DI)
+
+	// increment the 32 bit counter
+	MOVL 48(AX), CX
+	ADDL $1, CX
+	MOVL CX, 48(AX)
+	RET
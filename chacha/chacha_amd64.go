@@ -2,32 +2,110 @@
 // Use of this source code is governed by a license that can be
 // found in the LICENSE file.
 
-// +build amd64,!gccgo,!appengine
+// +build amd64,!gccgo,!appengine,!purego
 
 package chacha
 
-import "unsafe"
+import (
+	"github.com/aead/chacha20/internal/argerr"
+	"golang.org/x/sys/cpu"
+)
+
+// defaultBackend returns the fastest backend supported by the CPU.
+// The AVX2 implementation is experimental and must be selected explicitly -
+// either by SetBackend or by building with GOAMD64=v3. The AVX implementation
+// is only selected on CPUs without AVX2 (Sandy Bridge, Ivy Bridge), since it's
+// not faster than SSSE3 on newer CPUs.
+func defaultBackend() Backend {
+	switch {
+	case staticBackend != Generic:
+		return staticBackend
+	case supportsBackend(AVX512):
+		return AVX512
+	case supportsBackend(AVX) && !cpu.X86.HasAVX2:
+		return AVX
+	case supportsBackend(SSSE3):
+		return SSSE3
+	default:
+		return SSE2
+	}
+}
 
-var useSSSE3 = supportSSSE3()
+// supportsBackend reports whether the CPU supports b. If the backend is
+// selected at build time, only the static backend is supported.
+func supportsBackend(b Backend) bool {
+	if staticBackend != Generic {
+		return b == staticBackend
+	}
+	switch b {
+	case SSE2:
+		return true
+	case SSSE3:
+		return cpu.X86.HasSSSE3
+	case AVX:
+		return hasAVX && cpu.X86.HasAVX && cpu.X86.HasSSSE3
+	case AVX2:
+		return hasAVX2 && cpu.X86.HasAVX2 && cpu.X86.HasSSSE3
+	case AVX512:
+		return hasAVX512 && cpu.X86.HasAVX512F && cpu.X86.HasSSSE3
+	default:
+		return false
+	}
+}
+
+// missingFeature returns why b is not supported or an empty string if b is
+// supported or not implemented for amd64.
+func missingFeature(b Backend) string {
+	if b != SSE2 && b != SSSE3 && b != AVX && b != AVX2 && b != AVX512 {
+		return ""
+	}
+	if staticBackend != Generic {
+		if b == staticBackend {
+			return ""
+		}
+		return "package built with GOAMD64 selecting " + staticBackend.String()
+	}
+	switch {
+	case b == AVX && !hasAVX:
+		return "package built with a Go version without AVX support"
+	case b == AVX2 && !hasAVX2:
+		return "package built with a Go version without AVX2 support"
+	case b == AVX512 && !hasAVX512:
+		return "package built with a Go version without AVX512 support"
+	case b != SSE2 && !cpu.X86.HasSSSE3:
+		return "CPU lacks SSSE3"
+	case b == AVX && !cpu.X86.HasAVX:
+		return "CPU or OS lacks AVX"
+	case b == AVX2 && !cpu.X86.HasAVX2:
+		return "CPU lacks AVX2"
+	case b == AVX512 && !cpu.X86.HasAVX512F:
+		return "CPU lacks AVX512F"
+	default:
+		return ""
+	}
+}
 
 // XORKeyStream crypts bytes from src to dst using the given key, nonce and counter.
 // The rounds argument specifies the number of rounds (must be even) performed for
 // keystream generation. (Common values are 20, 12 or 8) Src and dst may be the same
 // slice but otherwise should not overlap. If len(dst) < len(src) this function panics.
+// If src exceeds the keystream left before the 32 bit counter overflows, it panics
+// with ErrCounterOverflow.
 func XORKeyStream(dst, src []byte, nonce *[12]byte, key *[32]byte, counter uint32, rounds int) {
 	length := len(src)
 	if len(dst) < length {
-		panic("chacha20/chacha: dst buffer is to small")
+		panic(argerr.Error("chacha20/chacha: dst buffer is to small"))
 	}
 	if rounds <= 0 || rounds%2 != 0 {
-		panic("chacha20/chacha: rounds must be a multiple of 2")
+		panic(argerr.Error("chacha20/chacha: rounds must be a multiple of 2"))
 	}
+	checkCounter(counter, length)
 
 	var state [64]byte
 	setState(&state, key, nonce, counter)
 
 	if length >= 64 {
-		xorBlocks(dst, src, &state, rounds)
+		xorBlocksCounter(dst, src, &state, rounds, false)
 	}
 
 	if n := length & (^(64 - 1)); length-n > 0 {
@@ -41,7 +119,7 @@ func XORKeyStream(dst, src []byte, nonce *[12]byte, key *[32]byte, counter uint3
 // stream cipher. The nonce must be unique for one key for all time.
 func NewCipher(nonce *[12]byte, key *[32]byte, rounds int) *Cipher {
 	if rounds <= 0 || rounds%2 != 0 {
-		panic("chacha20/chacha: rounds must be a multiply of 2")
+		panic(argerr.Error("chacha20/chacha: rounds must be a multiply of 2"))
 	}
 	c := new(Cipher)
 	c.rounds = rounds
@@ -54,45 +132,16 @@ func NewCipher(nonce *[12]byte, key *[32]byte, rounds int) *Cipher {
 // and writes them to dst. This function expects valid values. (no nil ptr etc.)
 // Core increments the counter of state.
 func Core(dst *[64]byte, state *[64]byte, rounds int) {
-	if useSSSE3 {
-		coreSSSE3(dst, state, rounds)
-	} else {
+	switch backend {
+	case Generic:
+		core(dst, state, rounds)
+	case SSE2:
 		coreSSE2(dst, state, rounds)
+	default:
+		coreSSSE3(dst, state, rounds)
 	}
 }
 
-// xor xors the bytes in src and with and writes the result to dst.
-// The destination is assumed to have enough space. Returns the
-// number of bytes xor'd.
-func xor(dst, src, with []byte) int {
-	n := len(src)
-	if len(with) < n {
-		n = len(with)
-	}
-
-	w := n / 8
-	if w > 0 {
-		dstPtr := *(*[]uint64)(unsafe.Pointer(&dst))
-		srcPtr := *(*[]uint64)(unsafe.Pointer(&src))
-		withPtr := *(*[]uint64)(unsafe.Pointer(&with))
-		for i, v := range srcPtr[:w] {
-			dstPtr[i] = withPtr[i] ^ v
-		}
-	}
-
-	for i := (n & (^(8 - 1))); i < n; i++ {
-		dst[i] = src[i] ^ with[i]
-	}
-
-	return n
-}
-
-// supportSSSE3 returns true if the runtime (the executing machine) supports SSSE3.
-func supportSSSE3() bool {
-	cx := cpuid()
-	return ((cx & 1) != 0) && ((cx & 0x200) != 0) // return SSE3 && SSSE3
-}
-
 // xorBlocksSSE2 crypts full block ( len(src) - (len(src) mod 64) bytes ) from src to
 // dst using the state.
 //go:noescape
@@ -105,16 +154,14 @@ func xorBlocksSSSE3(dst, src []byte, state *[64]byte, rounds int)
 
 // coreSSE2 generates 64 byte keystream from the given state performing 'rounds' rounds
 // and writes them to dst.
+//go:noescape
 func coreSSE2(dst *[64]byte, state *[64]byte, rounds int)
 
 // coreSSSE3 generates 64 byte keystream from the given state performing 'rounds' rounds
 // and writes them to dst.
+//go:noescape
 func coreSSSE3(dst *[64]byte, state *[64]byte, rounds int)
 
 // setState builds the ChaCha state from the key, the nonce and the counter.
 //go:noescape
 func setState(state *[64]byte, key *[32]byte, nonce *[12]byte, counter uint32)
-
-// cpuid returns the cx register after the CPUID instruction is executed.
-//go:noescape
-func cpuid() (cx uint32)
@@ -0,0 +1,64 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+// +build arm,!gccgo,!appengine,!purego
+
+package chacha
+
+import "golang.org/x/sys/cpu"
+
+func defaultBackend() Backend {
+	if cpu.ARM.HasNEON {
+		return NEON
+	}
+	return Generic
+}
+
+func supportsBackend(b Backend) bool { return b == NEON && cpu.ARM.HasNEON }
+
+func missingFeature(b Backend) string {
+	if b == NEON && !cpu.ARM.HasNEON {
+		return "CPU lacks NEON"
+	}
+	return ""
+}
+
+// xorBlocks crypts full block ( len(src) - (len(src) mod 64) bytes ) from src to
+// dst using the state. Src and dst may be the same slice
+// but otherwise should not overlap. If len(dst) < len(src) the behavior is undefined.
+// This function increments the counter of state.
+func xorBlocks(dst, src []byte, state *[64]byte, rounds int) {
+	n := 0
+	if backend == NEON {
+		n = len(src) - len(src)%192
+		xorBlocksNEON(dst[:n], src[:n], state, rounds)
+	}
+
+	var block [64]byte
+	for i := n; i < len(src)&(^(64 - 1)); i += 64 {
+		Core(&block, state, rounds)
+		xorBlock(dst[i:i+64], src[i:i+64], &block)
+	}
+}
+
+// Core generates 64 byte keystream from the given state performing 'rounds' rounds
+// and writes them to dst. This function expects valid values. (no nil ptr etc.)
+// Core increments the counter of the state.
+func Core(dst *[64]byte, state *[64]byte, rounds int) {
+	if backend == NEON {
+		coreNEON(dst, state, rounds)
+	} else {
+		core(dst, state, rounds)
+	}
+}
+
+// xorBlocksNEON crypts len(src) - (len(src) mod 192) bytes from src to
+// dst using the state. It processes 3 blocks in parallel.
+//go:noescape
+func xorBlocksNEON(dst, src []byte, state *[64]byte, rounds int)
+
+// coreNEON generates 64 byte keystream from the given state performing 'rounds' rounds
+// and writes them to dst.
+//go:noescape
+func coreNEON(dst *[64]byte, state *[64]byte, rounds int)
@@ -0,0 +1,200 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+// +build arm,!gccgo,!appengine,!purego
+
+#include "textflag.h"
+
+// The Go assembler doesn't support NEON instructions on ARM, so the
+// instructions are encoded by the following macros. The arguments are
+// the numbers of the Q registers. (e.g. VADD_I32(0, 1, 2) is vadd.i32 q0, q1, q2)
+
+#define QD(q) (((((q)*2)&15)<<12) | ((((q)*2)>>4)<<22))
+#define QN(q) (((((q)*2)&15)<<16) | ((((q)*2)>>4)<<7))
+#define QM(q) ((((q)*2)&15) | ((((q)*2)>>4)<<5))
+
+#define VADD_I32(d, n, m) WORD $(0xF2200840 | QD(d) | QN(n) | QM(m))
+#define VEOR(d, n, m) WORD $(0xF3000150 | QD(d) | QN(n) | QM(m))
+#define VMOV(d, m) WORD $(0xF2200150 | QD(d) | QN(m) | QM(m))
+#define VREV32_16(d, m) WORD $(0xF3B400C0 | QD(d) | QM(m))
+#define VSHL_I32(d, m, imm) WORD $(0xF2A00550 | ((imm)<<16) | QD(d) | QM(m))
+#define VSRI_32(d, m, imm) WORD $(0xF3800450 | ((64-(imm))<<16) | QD(d) | QM(m))
+#define VEXT_8(d, n, m, imm) WORD $(0xF2B00040 | ((imm)<<8) | QD(d) | QN(n) | QM(m))
+
+// VLD1_Q loads 16 bytes from [Rn] into q (vld1.8 {d(2q), d(2q+1)}, [Rn]) and
+// VLD1_QQ_WB loads 32 bytes into q and q+1 and increments Rn by 32.
+// VST1_QQ_WB stores q and q+1 to [Rn] and increments Rn by 32.
+#define VLD1_Q(q, n) WORD $(0xF4200A0F | ((n)<<16) | QD(q))
+#define VLD1_QQ_WB(q, n) WORD $(0xF420020D | ((n)<<16) | QD(q))
+#define VST1_QQ_WB(q, n) WORD $(0xF400020D | ((n)<<16) | QD(q))
+
+DATA one<>+0x00(SB)/4, $1
+DATA one<>+0x04(SB)/4, $0
+DATA one<>+0x08(SB)/4, $0
+DATA one<>+0x0c(SB)/4, $0
+GLOBL one<>(SB), (NOPTR+RODATA), $16
+
+// ROTL computes v = (v ^ w) <<< n using the temp. register t.
+#define ROTL(n, w, v, t) \
+	VEOR(t, v, w); \
+	VSHL_I32(v, t, n); \
+	VSRI_32(v, t, 32-n)
+
+// QUARTER_ROUND performs the quarter rounds on the rows a, b, c and d
+// of one block using the temp. register t.
+#define QUARTER_ROUND(a, b, c, d, t) \
+	VADD_I32(a, a, b); \
+	VEOR(d, d, a); \
+	VREV32_16(d, d); \
+	VADD_I32(c, c, d); \
+	ROTL(12, c, b, t); \
+	VADD_I32(a, a, b); \
+	ROTL(8, a, d, t); \
+	VADD_I32(c, c, d); \
+	ROTL(7, c, b, t)
+
+// QUARTER_ROUND_3 performs the quarter rounds on the rows of the three
+// blocks in q0-q3, q4-q7 and q8-q11. The registers q12-q14 are used as temp. registers.
+#define QUARTER_ROUND_3 \
+	VADD_I32(0, 0, 1); VADD_I32(4, 4, 5); VADD_I32(8, 8, 9); \
+	VEOR(3, 3, 0); VEOR(7, 7, 4); VEOR(11, 11, 8); \
+	VREV32_16(3, 3); VREV32_16(7, 7); VREV32_16(11, 11); \
+	VADD_I32(2, 2, 3); VADD_I32(6, 6, 7); VADD_I32(10, 10, 11); \
+	VEOR(12, 1, 2); VEOR(13, 5, 6); VEOR(14, 9, 10); \
+	VSHL_I32(1, 12, 12); VSHL_I32(5, 13, 12); VSHL_I32(9, 14, 12); \
+	VSRI_32(1, 12, 20); VSRI_32(5, 13, 20); VSRI_32(9, 14, 20); \
+	VADD_I32(0, 0, 1); VADD_I32(4, 4, 5); VADD_I32(8, 8, 9); \
+	VEOR(12, 3, 0); VEOR(13, 7, 4); VEOR(14, 11, 8); \
+	VSHL_I32(3, 12, 8); VSHL_I32(7, 13, 8); VSHL_I32(11, 14, 8); \
+	VSRI_32(3, 12, 24); VSRI_32(7, 13, 24); VSRI_32(11, 14, 24); \
+	VADD_I32(2, 2, 3); VADD_I32(6, 6, 7); VADD_I32(10, 10, 11); \
+	VEOR(12, 1, 2); VEOR(13, 5, 6); VEOR(14, 9, 10); \
+	VSHL_I32(1, 12, 7); VSHL_I32(5, 13, 7); VSHL_I32(9, 14, 7); \
+	VSRI_32(1, 12, 25); VSRI_32(5, 13, 25); VSRI_32(9, 14, 25)
+
+// SHUFFLE rotates the rows b, c and d of a block by 1, 2 and 3 words
+// (or by 3, 2 and 1 words if n = 12) to switch between columns and diagonals.
+#define SHUFFLE(b, c, d, n) \
+	VEXT_8(b, b, b, n); \
+	VEXT_8(c, c, c, 8); \
+	VEXT_8(d, d, d, 16-n)
+
+// XOR_BLOCK xors the next 64 bytes of src (R1) with the block in a, a+1, a+2 and
+// a+3 and writes the result to dst (R0).
+#define XOR_BLOCK(a) \
+	VLD1_QQ_WB(12, 1); \
+	VEOR(12, 12, a); \
+	VEOR(13, 13, a+1); \
+	VST1_QQ_WB(12, 0); \
+	VLD1_QQ_WB(12, 1); \
+	VEOR(12, 12, a+2); \
+	VEOR(13, 13, a+3); \
+	VST1_QQ_WB(12, 0)
+
+// func xorBlocksNEON(dst, src []byte, state *[64]byte, rounds int)
+TEXT ·xorBlocksNEON(SB),4,$0-32
+	MOVW dst_base+0(FP), R0
+	MOVW src_base+12(FP), R1
+	MOVW src_len+16(FP), R3
+	MOVW state+24(FP), R2
+
+	MOVW $one<>(SB), R5
+	VLD1_Q(15, 5)
+
+LOOP:
+	CMP $192, R3
+	BLT DONE
+
+	MOVW R2, R6
+	VLD1_QQ_WB(0, 6)
+	VLD1_QQ_WB(2, 6)
+	VMOV(4, 0)
+	VMOV(5, 1)
+	VMOV(6, 2)
+	VADD_I32(7, 3, 15)
+	VMOV(8, 0)
+	VMOV(9, 1)
+	VMOV(10, 2)
+	VADD_I32(11, 7, 15)
+
+	MOVW rounds+28(FP), R4
+CHACHA_LOOP:
+	QUARTER_ROUND_3
+	SHUFFLE(1, 2, 3, 4)
+	SHUFFLE(5, 6, 7, 4)
+	SHUFFLE(9, 10, 11, 4)
+	QUARTER_ROUND_3
+	SHUFFLE(1, 2, 3, 12)
+	SHUFFLE(5, 6, 7, 12)
+	SHUFFLE(9, 10, 11, 12)
+	SUB.S $2, R4
+	BNE CHACHA_LOOP
+
+	// add the initial state
+	MOVW R2, R6
+	VLD1_QQ_WB(12, 6)
+	VADD_I32(0, 0, 12)
+	VADD_I32(4, 4, 12)
+	VADD_I32(8, 8, 12)
+	VADD_I32(1, 1, 13)
+	VADD_I32(5, 5, 13)
+	VADD_I32(9, 9, 13)
+	VLD1_QQ_WB(12, 6)
+	VADD_I32(2, 2, 12)
+	VADD_I32(6, 6, 12)
+	VADD_I32(10, 10, 12)
+	VADD_I32(3, 3, 13)
+	VADD_I32(13, 13, 15)
+	VADD_I32(7, 7, 13)
+	VADD_I32(13, 13, 15)
+	VADD_I32(11, 11, 13)
+
+	XOR_BLOCK(0)
+	XOR_BLOCK(4)
+	XOR_BLOCK(8)
+
+	// increment the 32 bit counter
+	MOVW 48(R2), R6
+	ADD $3, R6
+	MOVW R6, 48(R2)
+
+	SUB $192, R3
+	B LOOP
+
+DONE:
+	RET
+
+// func coreNEON(dst *[64]byte, state *[64]byte, rounds int)
+TEXT ·coreNEON(SB),4,$0-12
+	MOVW dst+0(FP), R0
+	MOVW state+4(FP), R2
+	MOVW rounds+8(FP), R4
+
+	MOVW R2, R6
+	VLD1_QQ_WB(0, 6)
+	VLD1_QQ_WB(2, 6)
+
+CHACHA_LOOP:
+	QUARTER_ROUND(0, 1, 2, 3, 12)
+	SHUFFLE(1, 2, 3, 4)
+	QUARTER_ROUND(0, 1, 2, 3, 12)
+	SHUFFLE(1, 2, 3, 12)
+	SUB.S $2, R4
+	BNE CHACHA_LOOP
+
+	MOVW R2, R6
+	VLD1_QQ_WB(12, 6)
+	VLD1_QQ_WB(14, 6)
+	VADD_I32(0, 0, 12)
+	VADD_I32(1, 1, 13)
+	VADD_I32(2, 2, 14)
+	VADD_I32(3, 3, 15)
+	VST1_QQ_WB(0, 0)
+	VST1_QQ_WB(2, 0)
+
+	// increment the 32 bit counter
+	MOVW 48(R2), R6
+	ADD $1, R6
+	MOVW R6, 48(R2)
+	RET
@@ -0,0 +1,112 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+// +build arm64,!gccgo,!appengine,!purego
+
+package chacha
+
+import (
+	"runtime"
+
+	"golang.org/x/sys/cpu"
+)
+
+// appleImplementer is the implementer code of Apple in the MIDR register.
+const appleImplementer = 0x61
+
+// defaultNEONBlocks returns 6 on Apple's M-series cores and 4 otherwise.
+// Their wide out-of-order pipelines hide the latency of 6 independent
+// blocks better than that of 4.
+func defaultNEONBlocks() int {
+	if isAppleCore() {
+		return 6
+	}
+	return 4
+}
+
+// isAppleCore reports whether the CPU is designed by Apple. macOS and iOS only
+// run on Apple cores. On linux (e.g. Asahi Linux) the implementer is read from
+// the MIDR register if the kernel emulates the access to the ID registers.
+func isAppleCore() bool {
+	switch runtime.GOOS {
+	case "darwin", "ios":
+		return true
+	case "linux":
+		return cpu.ARM64.HasCPUID && (readMIDR()>>24)&0xff == appleImplementer
+	default:
+		return false
+	}
+}
+
+func defaultBackend() Backend { return NEON }
+
+func supportsBackend(b Backend) bool { return b == NEON }
+
+func missingFeature(b Backend) string { return "" }
+
+// xorBlocks crypts full block ( len(src) - (len(src) mod 64) bytes ) from src to
+// dst using the state. Src and dst may be the same slice
+// but otherwise should not overlap. If len(dst) < len(src) the behavior is undefined.
+// This function increments the counter of state.
+func xorBlocks(dst, src []byte, state *[64]byte, rounds int) {
+	if backend == Generic {
+		xorBlocksGeneric(dst, src, state, rounds)
+		return
+	}
+
+	n := 0
+	if neonBlocks == 6 {
+		n = len(src) - len(src)%384
+		if n > 0 {
+			xorBlocksNEON6(dst[:n], src[:n], state, rounds)
+		}
+	}
+	if m := n + (len(src)-n)&(^(256-1)); m > n {
+		xorBlocksNEON(dst[n:m], src[n:m], state, rounds)
+		n = m
+	}
+
+	var block [64]byte
+	for i := n; i < len(src)&(^(64 - 1)); i += 64 {
+		coreNEON(&block, state, rounds)
+		xor(dst[i:], src[i:], block[:])
+	}
+}
+
+// Core generates 64 byte keystream from the given state performing 'rounds' rounds
+// and writes them to dst. This function expects valid values. (no nil ptr etc.)
+// Core increments the counter of the state.
+func Core(dst *[64]byte, state *[64]byte, rounds int) {
+	if backend == Generic {
+		core(dst, state, rounds)
+	} else {
+		coreNEON(dst, state, rounds)
+	}
+}
+
+// xorBlocksNEON crypts len(src) - (len(src) mod 256) bytes from src to
+// dst using the state. It processes 4 blocks in parallel in the column layout:
+// every vector register holds one state word of all 4 blocks. So the 4 quarter
+// rounds of a column or diagonal round are independent and the latency of each
+// NEON instruction is hidden by the other 3 - on in-order cores as well as on
+// most out-of-order cores.
+//go:noescape
+func xorBlocksNEON(dst, src []byte, state *[64]byte, rounds int)
+
+// xorBlocksNEON6 crypts len(src) - (len(src) mod 384) bytes from src to
+// dst using the state. It processes 6 blocks in parallel: 4 blocks in the
+// column layout like xorBlocksNEON and 2 blocks in the row layout using the
+// remaining vector registers. It's used on Apple's M-series cores - see
+// defaultNEONBlocks and SetNEONBlocks.
+//go:noescape
+func xorBlocksNEON6(dst, src []byte, state *[64]byte, rounds int)
+
+// coreNEON generates 64 byte keystream from the given state performing 'rounds' rounds
+// and writes them to dst.
+//go:noescape
+func coreNEON(dst *[64]byte, state *[64]byte, rounds int)
+
+// readMIDR returns the content of the MIDR_EL1 register. It must only be
+// called if cpu.ARM64.HasCPUID is set.
+func readMIDR() uint64
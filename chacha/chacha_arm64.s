@@ -0,0 +1,324 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+// +build arm64,!gccgo,!appengine,!purego
+
+#include "textflag.h"
+
+DATA iota<>+0x00(SB)/8, $0x0000000100000000
+DATA iota<>+0x08(SB)/8, $0x0000000300000002
+GLOBL iota<>(SB), (NOPTR+RODATA), $16
+
+DATA rol8<>+0x00(SB)/8, $0x0605040702010003
+DATA rol8<>+0x08(SB)/8, $0x0E0D0C0F0A09080B
+GLOBL rol8<>(SB), (NOPTR+RODATA), $16
+
+DATA four<>+0x00(SB)/8, $0x0000000000000004
+DATA four<>+0x08(SB)/8, $0x0000000000000000
+GLOBL four<>(SB), (NOPTR+RODATA), $16
+
+DATA one<>+0x00(SB)/8, $0x0000000000000001
+DATA one<>+0x08(SB)/8, $0x0000000000000000
+GLOBL one<>(SB), (NOPTR+RODATA), $16
+
+// ROTL computes v = (v ^ w) <<< n using the temp. register t.
+#define ROTL(n, w, v, t) \
+	VEOR w.B16, v.B16, t.B16; \
+	VSHL $n, t.S4, v.S4; \
+	VSRI $(32-n), t.S4, v.S4
+
+// QUARTER_ROUND performs one quarter round on the rows
+// (or the columns of 4 blocks) a, b, c and d.
+// The rol8 table must be in V31.
+#define QUARTER_ROUND(a, b, c, d, t) \
+	VADD b.S4, a.S4, a.S4; \
+	VEOR a.B16, d.B16, d.B16; \
+	VREV32 d.H8, d.H8; \
+	VADD d.S4, c.S4, c.S4; \
+	ROTL(12, c, b, t); \
+	VADD b.S4, a.S4, a.S4; \
+	VEOR a.B16, d.B16, d.B16; \
+	VTBL V31.B16, [d.B16], d.B16; \
+	VADD d.S4, c.S4, c.S4; \
+	ROTL(7, c, b, t)
+
+// SHUFFLE rotates the rows b, c and d of a block in the row layout, so
+// the next quarter round operates on the diagonals. UNSHUFFLE reverts it.
+#define SHUFFLE(b, c, d) \
+	VEXT $4, b.B16, b.B16, b.B16; \
+	VEXT $8, c.B16, c.B16, c.B16; \
+	VEXT $12, d.B16, d.B16, d.B16
+
+#define UNSHUFFLE(b, c, d) \
+	VEXT $12, b.B16, b.B16, b.B16; \
+	VEXT $8, c.B16, c.B16, c.B16; \
+	VEXT $4, d.B16, d.B16, d.B16
+
+// TRANSPOSE transposes the 4x4 matrix of 32 bit words a, b, c and d.
+#define TRANSPOSE(a, b, c, d) \
+	VZIP1 b.S4, a.S4, V16.S4; \
+	VZIP2 b.S4, a.S4, V17.S4; \
+	VZIP1 d.S4, c.S4, V18.S4; \
+	VZIP2 d.S4, c.S4, V19.S4; \
+	VZIP1 V18.D2, V16.D2, a.D2; \
+	VZIP2 V18.D2, V16.D2, b.D2; \
+	VZIP1 V19.D2, V17.D2, c.D2; \
+	VZIP2 V19.D2, V17.D2, d.D2
+
+// XOR_BLOCK xors the next 64 bytes of src with the keystream block
+// a, b, c, d and writes the result to dst.
+#define XOR_BLOCK(a, b, c, d) \
+	VLD1.P 64(R2), [V16.B16, V17.B16, V18.B16, V19.B16]; \
+	VEOR a.B16, V16.B16, V16.B16; \
+	VEOR b.B16, V17.B16, V17.B16; \
+	VEOR c.B16, V18.B16, V18.B16; \
+	VEOR d.B16, V19.B16, V19.B16; \
+	VST1.P [V16.B16, V17.B16, V18.B16, V19.B16], 64(R1)
+
+// func xorBlocksNEON(dst, src []byte, state *[64]byte, rounds int)
+TEXT ·xorBlocksNEON(SB),4,$0-64
+	MOVD dst_base+0(FP), R1
+	MOVD src_base+24(FP), R2
+	MOVD src_len+32(FP), R3
+	MOVD state+48(FP), R4
+	MOVD rounds+56(FP), R5
+	LSR $8, R3 // number of 4 block chunks
+	CBZ R3, DONE
+
+	MOVD $iota<>(SB), R6
+	VLD1 (R6), [V30.S4]
+	MOVD $rol8<>(SB), R6
+	VLD1 (R6), [V31.S4]
+
+LOOP:
+	// every register Vi holds the word i of 4 consecutive blocks
+	MOVD R4, R6
+	VLD4R.P 16(R6), [V0.S4, V1.S4, V2.S4, V3.S4]
+	VLD4R.P 16(R6), [V4.S4, V5.S4, V6.S4, V7.S4]
+	VLD4R.P 16(R6), [V8.S4, V9.S4, V10.S4, V11.S4]
+	VLD4R (R6), [V12.S4, V13.S4, V14.S4, V15.S4]
+	VADD V30.S4, V12.S4, V12.S4
+	VMOV V12.B16, V28.B16
+
+	MOVD R5, R7
+CHACHA_LOOP:
+	QUARTER_ROUND(V0, V4, V8, V12, V16)
+	QUARTER_ROUND(V1, V5, V9, V13, V17)
+	QUARTER_ROUND(V2, V6, V10, V14, V18)
+	QUARTER_ROUND(V3, V7, V11, V15, V19)
+	QUARTER_ROUND(V0, V5, V10, V15, V16)
+	QUARTER_ROUND(V1, V6, V11, V12, V17)
+	QUARTER_ROUND(V2, V7, V8, V13, V18)
+	QUARTER_ROUND(V3, V4, V9, V14, V19)
+	SUB $2, R7
+	CBNZ R7, CHACHA_LOOP
+
+	// add the initial state
+	MOVD R4, R6
+	VLD4R.P 16(R6), [V16.S4, V17.S4, V18.S4, V19.S4]
+	VLD4R.P 16(R6), [V20.S4, V21.S4, V22.S4, V23.S4]
+	VLD4R.P 16(R6), [V24.S4, V25.S4, V26.S4, V27.S4]
+	VADD V16.S4, V0.S4, V0.S4
+	VADD V17.S4, V1.S4, V1.S4
+	VADD V18.S4, V2.S4, V2.S4
+	VADD V19.S4, V3.S4, V3.S4
+	VLD4R (R6), [V16.S4, V17.S4, V18.S4, V19.S4]
+	VADD V20.S4, V4.S4, V4.S4
+	VADD V21.S4, V5.S4, V5.S4
+	VADD V22.S4, V6.S4, V6.S4
+	VADD V23.S4, V7.S4, V7.S4
+	VADD V24.S4, V8.S4, V8.S4
+	VADD V25.S4, V9.S4, V9.S4
+	VADD V26.S4, V10.S4, V10.S4
+	VADD V27.S4, V11.S4, V11.S4
+	VADD V28.S4, V12.S4, V12.S4
+	VADD V17.S4, V13.S4, V13.S4
+	VADD V18.S4, V14.S4, V14.S4
+	VADD V19.S4, V15.S4, V15.S4
+
+	// afterwards the block i is V(i), V(4+i), V(8+i), V(12+i)
+	TRANSPOSE(V0, V1, V2, V3)
+	TRANSPOSE(V4, V5, V6, V7)
+	TRANSPOSE(V8, V9, V10, V11)
+	TRANSPOSE(V12, V13, V14, V15)
+
+	XOR_BLOCK(V0, V4, V8, V12)
+	XOR_BLOCK(V1, V5, V9, V13)
+	XOR_BLOCK(V2, V6, V10, V14)
+	XOR_BLOCK(V3, V7, V11, V15)
+
+	// increment the 32 bit counter
+	MOVWU 48(R4), R7
+	ADDW $4, R7
+	MOVW R7, 48(R4)
+
+	SUB $1, R3
+	CBNZ R3, LOOP
+
+DONE:
+	RET
+
+// func xorBlocksNEON6(dst, src []byte, state *[64]byte, rounds int)
+TEXT ·xorBlocksNEON6(SB),4,$0-64
+	MOVD dst_base+0(FP), R1
+	MOVD src_base+24(FP), R2
+	MOVD src_len+32(FP), R3
+	MOVD state+48(FP), R4
+	MOVD rounds+56(FP), R5
+	MOVD $384, R6
+	UDIV R6, R3, R3 // number of 6 block chunks
+	CBZ R3, DONE
+
+	MOVD $iota<>(SB), R6
+	VLD1 (R6), [V30.S4]
+	MOVD $rol8<>(SB), R6
+	VLD1 (R6), [V31.S4]
+	MOVD $four<>(SB), R6
+	VLD1 (R6), [V29.S4]
+	MOVD $one<>(SB), R8
+
+LOOP:
+	// the blocks 0 to 3 are in the column layout - like in xorBlocksNEON
+	MOVD R4, R6
+	VLD4R.P 16(R6), [V0.S4, V1.S4, V2.S4, V3.S4]
+	VLD4R.P 16(R6), [V4.S4, V5.S4, V6.S4, V7.S4]
+	VLD4R.P 16(R6), [V8.S4, V9.S4, V10.S4, V11.S4]
+	VLD4R (R6), [V12.S4, V13.S4, V14.S4, V15.S4]
+	VADD V30.S4, V12.S4, V12.S4
+	VMOV V12.B16, V28.B16
+
+	// the blocks 4 and 5 are in the row layout: V20 - V23 and V24 - V27
+	VLD1 (R4), [V20.S4, V21.S4, V22.S4, V23.S4]
+	VADD V29.S4, V23.S4, V23.S4
+	VLD1 (R8), [V16.S4]
+	VMOV V20.B16, V24.B16
+	VMOV V21.B16, V25.B16
+	VMOV V22.B16, V26.B16
+	VADD V16.S4, V23.S4, V27.S4
+
+	MOVD R5, R7
+CHACHA_LOOP:
+	QUARTER_ROUND(V0, V4, V8, V12, V16)
+	QUARTER_ROUND(V20, V21, V22, V23, V18)
+	QUARTER_ROUND(V1, V5, V9, V13, V17)
+	QUARTER_ROUND(V24, V25, V26, V27, V19)
+	QUARTER_ROUND(V2, V6, V10, V14, V16)
+	QUARTER_ROUND(V3, V7, V11, V15, V17)
+	SHUFFLE(V21, V22, V23)
+	SHUFFLE(V25, V26, V27)
+	QUARTER_ROUND(V0, V5, V10, V15, V16)
+	QUARTER_ROUND(V20, V21, V22, V23, V18)
+	QUARTER_ROUND(V1, V6, V11, V12, V17)
+	QUARTER_ROUND(V24, V25, V26, V27, V19)
+	QUARTER_ROUND(V2, V7, V8, V13, V16)
+	QUARTER_ROUND(V3, V4, V9, V14, V17)
+	UNSHUFFLE(V21, V22, V23)
+	UNSHUFFLE(V25, V26, V27)
+	SUB $2, R7
+	CBNZ R7, CHACHA_LOOP
+
+	// add the initial state to the blocks 0 to 3
+	MOVD R4, R6
+	VLD4R.P 16(R6), [V16.S4, V17.S4, V18.S4, V19.S4]
+	VADD V16.S4, V0.S4, V0.S4
+	VADD V17.S4, V1.S4, V1.S4
+	VADD V18.S4, V2.S4, V2.S4
+	VADD V19.S4, V3.S4, V3.S4
+	VLD4R.P 16(R6), [V16.S4, V17.S4, V18.S4, V19.S4]
+	VADD V16.S4, V4.S4, V4.S4
+	VADD V17.S4, V5.S4, V5.S4
+	VADD V18.S4, V6.S4, V6.S4
+	VADD V19.S4, V7.S4, V7.S4
+	VLD4R.P 16(R6), [V16.S4, V17.S4, V18.S4, V19.S4]
+	VADD V16.S4, V8.S4, V8.S4
+	VADD V17.S4, V9.S4, V9.S4
+	VADD V18.S4, V10.S4, V10.S4
+	VADD V19.S4, V11.S4, V11.S4
+	VLD4R (R6), [V16.S4, V17.S4, V18.S4, V19.S4]
+	VADD V28.S4, V12.S4, V12.S4
+	VADD V17.S4, V13.S4, V13.S4
+	VADD V18.S4, V14.S4, V14.S4
+	VADD V19.S4, V15.S4, V15.S4
+
+	// and to the blocks 4 and 5
+	VLD1 (R4), [V16.S4, V17.S4, V18.S4, V19.S4]
+	VADD V16.S4, V20.S4, V20.S4
+	VADD V16.S4, V24.S4, V24.S4
+	VADD V17.S4, V21.S4, V21.S4
+	VADD V17.S4, V25.S4, V25.S4
+	VADD V18.S4, V22.S4, V22.S4
+	VADD V18.S4, V26.S4, V26.S4
+	VADD V29.S4, V19.S4, V19.S4
+	VADD V19.S4, V23.S4, V23.S4
+	VLD1 (R8), [V16.S4]
+	VADD V16.S4, V19.S4, V19.S4
+	VADD V19.S4, V27.S4, V27.S4
+
+	TRANSPOSE(V0, V1, V2, V3)
+	TRANSPOSE(V4, V5, V6, V7)
+	TRANSPOSE(V8, V9, V10, V11)
+	TRANSPOSE(V12, V13, V14, V15)
+
+	XOR_BLOCK(V0, V4, V8, V12)
+	XOR_BLOCK(V1, V5, V9, V13)
+	XOR_BLOCK(V2, V6, V10, V14)
+	XOR_BLOCK(V3, V7, V11, V15)
+	XOR_BLOCK(V20, V21, V22, V23)
+	XOR_BLOCK(V24, V25, V26, V27)
+
+	// increment the 32 bit counter
+	MOVWU 48(R4), R7
+	ADDW $6, R7
+	MOVW R7, 48(R4)
+
+	SUB $1, R3
+	CBNZ R3, LOOP
+
+DONE:
+	RET
+
+// func coreNEON(dst *[64]byte, state *[64]byte, rounds int)
+TEXT ·coreNEON(SB),4,$0-24
+	MOVD dst+0(FP), R1
+	MOVD state+8(FP), R4
+	MOVD rounds+16(FP), R5
+
+	MOVD $rol8<>(SB), R6
+	VLD1 (R6), [V31.S4]
+
+	VLD1 (R4), [V0.S4, V1.S4, V2.S4, V3.S4]
+	VMOV V0.B16, V20.B16
+	VMOV V1.B16, V21.B16
+	VMOV V2.B16, V22.B16
+	VMOV V3.B16, V23.B16
+
+CHACHA_LOOP:
+	QUARTER_ROUND(V0, V1, V2, V3, V16)
+	VEXT $4, V1.B16, V1.B16, V1.B16
+	VEXT $8, V2.B16, V2.B16, V2.B16
+	VEXT $12, V3.B16, V3.B16, V3.B16
+	QUARTER_ROUND(V0, V1, V2, V3, V16)
+	VEXT $12, V1.B16, V1.B16, V1.B16
+	VEXT $8, V2.B16, V2.B16, V2.B16
+	VEXT $4, V3.B16, V3.B16, V3.B16
+	SUB $2, R5
+	CBNZ R5, CHACHA_LOOP
+
+	VADD V20.S4, V0.S4, V0.S4
+	VADD V21.S4, V1.S4, V1.S4
+	VADD V22.S4, V2.S4, V2.S4
+	VADD V23.S4, V3.S4, V3.S4
+	VST1 [V0.B16, V1.B16, V2.B16, V3.B16], (R1)
+
+	// increment the 32 bit counter
+	MOVWU 48(R4), R7
+	ADDW $1, R7
+	MOVW R7, 48(R4)
+	RET
+
+// func readMIDR() uint64
+TEXT ·readMIDR(SB),4,$0-8
+	MRS MIDR_EL1, R0
+	MOVD R0, ret+0(FP)
+	RET
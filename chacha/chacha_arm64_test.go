@@ -0,0 +1,98 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+// +build arm64,!gccgo,!appengine,!purego
+
+package chacha
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/aead/chacha20/chacha/reference"
+)
+
+// TestNEON6 compares both NEON schedules against the reference
+// implementation - independent of the CPU the tests run on.
+func TestNEON6(t *testing.T) {
+	defer func(n int) { neonBlocks = n }(neonBlocks)
+	defer SetBackend(ActiveBackend())
+	if err := SetBackend(NEON); err != nil {
+		t.Fatalf("SetBackend failed: %v", err)
+	}
+
+	rng := rand.New(rand.NewSource(4))
+	var key [32]byte
+	var nonce [12]byte
+	rng.Read(key[:])
+	rng.Read(nonce[:])
+	src := make([]byte, 4*384+256+64+17)
+	rng.Read(src)
+
+	for _, blocks := range []int{4, 6} {
+		neonBlocks = blocks
+		for _, rounds := range []int{8, 12, 20} {
+			for _, counter := range []uint32{0, 1, ^uint32(0) - uint32(len(src)/64) - 1} {
+				for _, size := range []int{64, 256, 320, 383, 384, 385, 448, 640, 704, 768 + 17, len(src)} {
+					expected, dst := make([]byte, size), make([]byte, size)
+					reference.XORKeyStream(expected, src[:size], &nonce, &key, counter, rounds)
+					XORKeyStream(dst, src[:size], &nonce, &key, counter, rounds)
+					if !bytes.Equal(dst, expected) {
+						t.Fatalf("NEON %d blocks: ChaCha%d: Counter %d: Size %d: XORKeyStream differs from the reference", blocks, rounds, counter, size)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestXORBlocksNEON6(t *testing.T) {
+	var key [32]byte
+	var nonce [12]byte
+	src := make([]byte, 3*384)
+	for i := range src {
+		src[i] = byte(i)
+	}
+
+	for _, rounds := range []int{8, 12, 20} {
+		var state, stateGeneric [64]byte
+		setState(&state, &key, &nonce, ^uint32(0)-8)
+		stateGeneric = state
+		dst, expected := make([]byte, len(src)), make([]byte, len(src))
+
+		xorBlocksGeneric(expected, src, &stateGeneric, rounds)
+		xorBlocksNEON6(dst, src, &state, rounds)
+		if !bytes.Equal(dst, expected) {
+			t.Fatalf("ChaCha%d: xorBlocksNEON6 differs from xorBlocksGeneric", rounds)
+		}
+		if state != stateGeneric {
+			t.Fatalf("ChaCha%d: xorBlocksNEON6 updates the counter differently", rounds)
+		}
+	}
+}
+
+// BenchmarkNEON6 compares the 4 and 6 block schedules. Run it on a new core
+// before changing defaultNEONBlocks.
+func BenchmarkNEON6(b *testing.B) {
+	defer func(n int) { neonBlocks = n }(neonBlocks)
+	defer SetBackend(ActiveBackend())
+	SetBackend(NEON)
+
+	var key [32]byte
+	var nonce [12]byte
+	for _, blocks := range []int{4, 6} {
+		for _, size := range []int{1024, 16 * 1024} {
+			buf := make([]byte, size)
+			b.Run(fmt.Sprintf("Blocks=%d/%d", blocks, size), func(b *testing.B) {
+				neonBlocks = blocks
+				b.SetBytes(int64(size))
+				for i := 0; i < b.N; i++ {
+					XORKeyStream(buf, buf, &nonce, &key, 0, 20)
+				}
+			})
+		}
+	}
+}
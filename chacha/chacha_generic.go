@@ -0,0 +1,28 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+// +build !amd64,!386,!arm64,!arm,!ppc64le,!riscv64,!wasm,!loong64 gccgo appengine purego !go1.25,riscv64 !go1.27,wasm
+
+package chacha
+
+func defaultBackend() Backend { return Generic }
+
+func supportsBackend(b Backend) bool { return false }
+
+func missingFeature(b Backend) string { return "" }
+
+// xorBlocks crypts full block ( len(src) - (len(src) mod 64) bytes ) from src to
+// dst using the state. Src and dst may be the same slice
+// but otherwise should not overlap. If len(dst) < len(src) the behavior is undefined.
+// This function increments the counter of state.
+func xorBlocks(dst, src []byte, state *[64]byte, rounds int) {
+	xorBlocksGeneric(dst, src, state, rounds)
+}
+
+// Core generates 64 byte keystream from the given state performing 'rounds' rounds
+// and writes them to dst. This function expects valid values. (no nil ptr etc.)
+// Core increments the counter of the state.
+func Core(dst *[64]byte, state *[64]byte, rounds int) {
+	core(dst, state, rounds)
+}
@@ -0,0 +1,118 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+// +build loong64,!gccgo,!appengine,!purego
+
+package chacha
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+func defaultBackend() Backend { return Generic }
+
+func supportsBackend(b Backend) bool { return false }
+
+func missingFeature(b Backend) string { return "" }
+
+// xorBlocks crypts full block ( len(src) - (len(src) mod 64) bytes ) from src to
+// dst using the state. Src and dst may be the same slice
+// but otherwise should not overlap. If len(dst) < len(src) the behavior is undefined.
+// This function increments the counter of state.
+func xorBlocks(dst, src []byte, state *[64]byte, rounds int) {
+	xorBlocksScalar(dst, src, state, rounds)
+}
+
+// Core generates 64 byte keystream from the given state performing 'rounds' rounds
+// and writes them to dst. This function expects valid values. (no nil ptr etc.)
+// Core increments the counter of the state.
+func Core(dst *[64]byte, state *[64]byte, rounds int) {
+	core(dst, state, rounds)
+}
+
+// xorBlocksScalar crypts full block ( len(src) - (len(src) mod 64) bytes ) from src to
+// dst using the state and 32 bit scalar operations. Unlike xorBlocksGeneric it parses
+// the state only once, computes the counter independent part of the first round only
+// once and xors the keystream words directly into dst instead of writing them to a
+// block buffer first. There is no LSX assembly yet, so loong64 uses it instead of
+// xorBlocksGeneric.
+// This function increments the counter of state.
+func xorBlocksScalar(dst, src []byte, state *[64]byte, rounds int) {
+	n := len(src) & (^(64 - 1))
+	if n == 0 {
+		return
+	}
+
+	var s [16]uint32
+	for i := range s {
+		s[i] = binary.LittleEndian.Uint32(state[4*i:])
+	}
+
+	// The first column round of the columns 1, 2 and 3 doesn't depend on
+	// the counter, so it's the same for all blocks.
+	p01, p05, p09, p13 := quarterRoundScalar(s[1], s[5], s[9], s[13])
+	p02, p06, p10, p14 := quarterRoundScalar(s[2], s[6], s[10], s[14])
+	p03, p07, p11, p15 := quarterRoundScalar(s[3], s[7], s[11], s[15])
+
+	for i := 0; i < n; i += 64 {
+		v00, v04, v08, v12 := quarterRoundScalar(s[0], s[4], s[8], s[12])
+		v01, v05, v09, v13 := p01, p05, p09, p13
+		v02, v06, v10, v14 := p02, p06, p10, p14
+		v03, v07, v11, v15 := p03, p07, p11, p15
+
+		v00, v05, v10, v15 = quarterRoundScalar(v00, v05, v10, v15)
+		v01, v06, v11, v12 = quarterRoundScalar(v01, v06, v11, v12)
+		v02, v07, v08, v13 = quarterRoundScalar(v02, v07, v08, v13)
+		v03, v04, v09, v14 = quarterRoundScalar(v03, v04, v09, v14)
+
+		for r := 2; r < rounds; r += 2 {
+			v00, v04, v08, v12 = quarterRoundScalar(v00, v04, v08, v12)
+			v01, v05, v09, v13 = quarterRoundScalar(v01, v05, v09, v13)
+			v02, v06, v10, v14 = quarterRoundScalar(v02, v06, v10, v14)
+			v03, v07, v11, v15 = quarterRoundScalar(v03, v07, v11, v15)
+
+			v00, v05, v10, v15 = quarterRoundScalar(v00, v05, v10, v15)
+			v01, v06, v11, v12 = quarterRoundScalar(v01, v06, v11, v12)
+			v02, v07, v08, v13 = quarterRoundScalar(v02, v07, v08, v13)
+			v03, v04, v09, v14 = quarterRoundScalar(v03, v04, v09, v14)
+		}
+
+		in, out := src[i:i+64], dst[i:i+64]
+		binary.LittleEndian.PutUint32(out[0:4], binary.LittleEndian.Uint32(in[0:4])^(v00+s[0]))
+		binary.LittleEndian.PutUint32(out[4:8], binary.LittleEndian.Uint32(in[4:8])^(v01+s[1]))
+		binary.LittleEndian.PutUint32(out[8:12], binary.LittleEndian.Uint32(in[8:12])^(v02+s[2]))
+		binary.LittleEndian.PutUint32(out[12:16], binary.LittleEndian.Uint32(in[12:16])^(v03+s[3]))
+		binary.LittleEndian.PutUint32(out[16:20], binary.LittleEndian.Uint32(in[16:20])^(v04+s[4]))
+		binary.LittleEndian.PutUint32(out[20:24], binary.LittleEndian.Uint32(in[20:24])^(v05+s[5]))
+		binary.LittleEndian.PutUint32(out[24:28], binary.LittleEndian.Uint32(in[24:28])^(v06+s[6]))
+		binary.LittleEndian.PutUint32(out[28:32], binary.LittleEndian.Uint32(in[28:32])^(v07+s[7]))
+		binary.LittleEndian.PutUint32(out[32:36], binary.LittleEndian.Uint32(in[32:36])^(v08+s[8]))
+		binary.LittleEndian.PutUint32(out[36:40], binary.LittleEndian.Uint32(in[36:40])^(v09+s[9]))
+		binary.LittleEndian.PutUint32(out[40:44], binary.LittleEndian.Uint32(in[40:44])^(v10+s[10]))
+		binary.LittleEndian.PutUint32(out[44:48], binary.LittleEndian.Uint32(in[44:48])^(v11+s[11]))
+		binary.LittleEndian.PutUint32(out[48:52], binary.LittleEndian.Uint32(in[48:52])^(v12+s[12]))
+		binary.LittleEndian.PutUint32(out[52:56], binary.LittleEndian.Uint32(in[52:56])^(v13+s[13]))
+		binary.LittleEndian.PutUint32(out[56:60], binary.LittleEndian.Uint32(in[56:60])^(v14+s[14]))
+		binary.LittleEndian.PutUint32(out[60:64], binary.LittleEndian.Uint32(in[60:64])^(v15+s[15]))
+
+		s[12]++
+	}
+
+	binary.LittleEndian.PutUint32(state[48:52], s[12])
+}
+
+// quarterRoundScalar is the ChaCha quarter round. Unlike quarterRound it
+// returns the words, so the compiler inlines it and keeps them in registers.
+func quarterRoundScalar(a, b, c, d uint32) (uint32, uint32, uint32, uint32) {
+	a += b
+	d = bits.RotateLeft32(d^a, 16)
+	c += d
+	b = bits.RotateLeft32(b^c, 12)
+	a += b
+	d = bits.RotateLeft32(d^a, 8)
+	c += d
+	b = bits.RotateLeft32(b^c, 7)
+	return a, b, c, d
+}
@@ -0,0 +1,69 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+// +build loong64,!gccgo,!appengine,!purego
+
+package chacha
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+)
+
+func TestXORBlocksScalar(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	var key [32]byte
+	var nonce [12]byte
+	rng.Read(key[:])
+	rng.Read(nonce[:])
+	src := make([]byte, 4*1024+17)
+	rng.Read(src)
+
+	for _, rounds := range []int{8, 12, 20} {
+		for _, counter := range []uint32{0, 1, ^uint32(0) - 2} {
+			for _, size := range []int{0, 63, 64, 65, 128, 1024, len(src)} {
+				var state, stateGeneric [64]byte
+				setState(&state, &key, &nonce, counter)
+				stateGeneric = state
+				dst, expected := make([]byte, size), make([]byte, size)
+
+				xorBlocksGeneric(expected, src[:size], &stateGeneric, rounds)
+				xorBlocksScalar(dst, src[:size], &state, rounds)
+				if !bytes.Equal(dst, expected) {
+					t.Fatalf("ChaCha%d: Counter %d: Size %d: xorBlocksScalar differs from xorBlocksGeneric", rounds, counter, size)
+				}
+				if state != stateGeneric {
+					t.Fatalf("ChaCha%d: Counter %d: Size %d: xorBlocksScalar updates the counter differently", rounds, counter, size)
+				}
+			}
+		}
+	}
+}
+
+func benchmarkXORBlocks(b *testing.B, xorBlocks func(dst, src []byte, state *[64]byte, rounds int), size int) {
+	var state [64]byte
+	var key [32]byte
+	var nonce [12]byte
+	setState(&state, &key, &nonce, 0)
+	buf := make([]byte, size)
+
+	b.SetBytes(int64(size))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		xorBlocks(buf, buf, &state, 20)
+	}
+}
+
+func BenchmarkXORBlocksGeneric64(b *testing.B) { benchmarkXORBlocks(b, xorBlocksGeneric, 64) }
+func BenchmarkXORBlocksGeneric1K(b *testing.B) { benchmarkXORBlocks(b, xorBlocksGeneric, 1024) }
+func BenchmarkXORBlocksGeneric16K(b *testing.B) {
+	benchmarkXORBlocks(b, xorBlocksGeneric, 16*1024)
+}
+
+func BenchmarkXORBlocksScalar64(b *testing.B) { benchmarkXORBlocks(b, xorBlocksScalar, 64) }
+func BenchmarkXORBlocksScalar1K(b *testing.B) { benchmarkXORBlocks(b, xorBlocksScalar, 1024) }
+func BenchmarkXORBlocksScalar16K(b *testing.B) {
+	benchmarkXORBlocks(b, xorBlocksScalar, 16*1024)
+}
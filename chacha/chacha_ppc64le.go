@@ -0,0 +1,47 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+// +build ppc64le,!gccgo,!appengine,!purego
+
+package chacha
+
+func defaultBackend() Backend { return VSX }
+
+func supportsBackend(b Backend) bool { return b == VSX }
+
+func missingFeature(b Backend) string { return "" }
+
+// xorBlocks crypts full block ( len(src) - (len(src) mod 64) bytes ) from src to
+// dst using the state. Src and dst may be the same slice
+// but otherwise should not overlap. If len(dst) < len(src) the behavior is undefined.
+// This function increments the counter of state.
+func xorBlocks(dst, src []byte, state *[64]byte, rounds int) {
+	if backend == Generic {
+		xorBlocksGeneric(dst, src, state, rounds)
+		return
+	}
+
+	n := len(src) & (^(256 - 1))
+	if n > 0 {
+		xorBlocksVSX(dst[:n], src[:n], state, rounds)
+	}
+
+	var block [64]byte
+	for i := n; i < len(src)&(^(64 - 1)); i += 64 {
+		core(&block, state, rounds)
+		xor(dst[i:], src[i:], block[:])
+	}
+}
+
+// Core generates 64 byte keystream from the given state performing 'rounds' rounds
+// and writes them to dst. This function expects valid values. (no nil ptr etc.)
+// Core increments the counter of the state.
+func Core(dst *[64]byte, state *[64]byte, rounds int) {
+	core(dst, state, rounds)
+}
+
+// xorBlocksVSX crypts len(src) - (len(src) mod 256) bytes from src to
+// dst using the state. It processes 4 blocks in parallel.
+//go:noescape
+func xorBlocksVSX(dst, src []byte, state *[64]byte, rounds int)
@@ -0,0 +1,168 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+// +build ppc64le,!gccgo,!appengine,!purego
+
+#include "textflag.h"
+
+DATA iota<>+0x00(SB)/4, $0
+DATA iota<>+0x04(SB)/4, $1
+DATA iota<>+0x08(SB)/4, $2
+DATA iota<>+0x0c(SB)/4, $3
+GLOBL iota<>(SB), (NOPTR+RODATA), $16
+
+// The rotation amounts are in V16 (16), V17 (12), V18 (8) and V19 (7).
+#define QUARTER_ROUND_4(a0, b0, c0, d0, a1, b1, c1, d1, a2, b2, c2, d2, a3, b3, c3, d3) \
+	VADDUWM a0, b0, a0; VADDUWM a1, b1, a1; VADDUWM a2, b2, a2; VADDUWM a3, b3, a3; \
+	VXOR d0, a0, d0; VXOR d1, a1, d1; VXOR d2, a2, d2; VXOR d3, a3, d3; \
+	VRLW d0, V16, d0; VRLW d1, V16, d1; VRLW d2, V16, d2; VRLW d3, V16, d3; \
+	VADDUWM c0, d0, c0; VADDUWM c1, d1, c1; VADDUWM c2, d2, c2; VADDUWM c3, d3, c3; \
+	VXOR b0, c0, b0; VXOR b1, c1, b1; VXOR b2, c2, b2; VXOR b3, c3, b3; \
+	VRLW b0, V17, b0; VRLW b1, V17, b1; VRLW b2, V17, b2; VRLW b3, V17, b3; \
+	VADDUWM a0, b0, a0; VADDUWM a1, b1, a1; VADDUWM a2, b2, a2; VADDUWM a3, b3, a3; \
+	VXOR d0, a0, d0; VXOR d1, a1, d1; VXOR d2, a2, d2; VXOR d3, a3, d3; \
+	VRLW d0, V18, d0; VRLW d1, V18, d1; VRLW d2, V18, d2; VRLW d3, V18, d3; \
+	VADDUWM c0, d0, c0; VADDUWM c1, d1, c1; VADDUWM c2, d2, c2; VADDUWM c3, d3, c3; \
+	VXOR b0, c0, b0; VXOR b1, c1, b1; VXOR b2, c2, b2; VXOR b3, c3, b3; \
+	VRLW b0, V19, b0; VRLW b1, V19, b1; VRLW b2, V19, b2; VRLW b3, V19, b3
+
+// TRANSPOSE transposes the 4x4 matrix of 32 bit words in the vector
+// registers a, b, c and d. (xa, xb, xc and xd are the same registers as
+// VSX registers) The registers V22 - V25 are used as temp. registers.
+#define TRANSPOSE(a, b, c, d, xa, xb, xc, xd) \
+	VMRGEW a, b, V22; \
+	VMRGOW a, b, V23; \
+	VMRGEW c, d, V24; \
+	VMRGOW c, d, V25; \
+	XXPERMDI VS54, VS56, $0, xa; \
+	XXPERMDI VS55, VS57, $0, xb; \
+	XXPERMDI VS54, VS56, $3, xc; \
+	XXPERMDI VS55, VS57, $3, xd
+
+// XOR_BLOCK xors the next 64 bytes of src (R5) with the keystream block
+// a, b, c, d and writes the result to dst (R3).
+#define XOR_BLOCK(a, b, c, d) \
+	LXVW4X (R5)(R0), VS58; \
+	LXVW4X (R5)(R8), VS59; \
+	LXVW4X (R5)(R9), VS60; \
+	LXVW4X (R5)(R10), VS61; \
+	VXOR V26, a, V26; \
+	VXOR V27, b, V27; \
+	VXOR V28, c, V28; \
+	VXOR V29, d, V29; \
+	STXVW4X VS58, (R3)(R0); \
+	STXVW4X VS59, (R3)(R8); \
+	STXVW4X VS60, (R3)(R9); \
+	STXVW4X VS61, (R3)(R10); \
+	ADD $64, R5; \
+	ADD $64, R3
+
+// func xorBlocksVSX(dst, src []byte, state *[64]byte, rounds int)
+TEXT ·xorBlocksVSX(SB),4,$0-64
+	MOVD dst_base+0(FP), R3
+	MOVD src_base+24(FP), R5
+	MOVD src_len+32(FP), R6
+	MOVD state+48(FP), R4
+	MOVD rounds+56(FP), R7
+	SRD $8, R6 // number of 4 block chunks
+	CMP R6, $0
+	BEQ DONE
+	MOVD R6, CTR
+
+	MOVD $16, R8
+	MOVD $32, R9
+	MOVD $48, R10
+	MOVD $iota<>(SB), R11
+	LXVW4X (R11)(R0), VS52
+	VSPLTISW $-16, V16 // the rotation uses the lower 5 bits: 16
+	VSPLTISW $12, V17
+	VSPLTISW $8, V18
+	VSPLTISW $7, V19
+
+LOOP:
+	// every register Vi holds the word i of 4 consecutive blocks
+	LXVW4X (R4)(R0), VS58
+	LXVW4X (R4)(R8), VS59
+	LXVW4X (R4)(R9), VS60
+	LXVW4X (R4)(R10), VS61
+	VSPLTW $0, V26, V0
+	VSPLTW $1, V26, V1
+	VSPLTW $2, V26, V2
+	VSPLTW $3, V26, V3
+	VSPLTW $0, V27, V4
+	VSPLTW $1, V27, V5
+	VSPLTW $2, V27, V6
+	VSPLTW $3, V27, V7
+	VSPLTW $0, V28, V8
+	VSPLTW $1, V28, V9
+	VSPLTW $2, V28, V10
+	VSPLTW $3, V28, V11
+	VSPLTW $0, V29, V12
+	VSPLTW $1, V29, V13
+	VSPLTW $2, V29, V14
+	VSPLTW $3, V29, V15
+	VADDUWM V12, V20, V12
+	VOR V12, V12, V31
+
+	MOVD R7, R12
+CHACHA_LOOP:
+	QUARTER_ROUND_4(V0, V4, V8, V12, V1, V5, V9, V13, V2, V6, V10, V14, V3, V7, V11, V15)
+	QUARTER_ROUND_4(V0, V5, V10, V15, V1, V6, V11, V12, V2, V7, V8, V13, V3, V4, V9, V14)
+	ADD $-2, R12
+	CMP R12, $0
+	BNE CHACHA_LOOP
+
+	// add the initial state (still in V26 - V29 and V31)
+	VSPLTW $0, V26, V22
+	VSPLTW $1, V26, V23
+	VSPLTW $2, V26, V24
+	VSPLTW $3, V26, V25
+	VADDUWM V0, V22, V0
+	VADDUWM V1, V23, V1
+	VADDUWM V2, V24, V2
+	VADDUWM V3, V25, V3
+	VSPLTW $0, V27, V22
+	VSPLTW $1, V27, V23
+	VSPLTW $2, V27, V24
+	VSPLTW $3, V27, V25
+	VADDUWM V4, V22, V4
+	VADDUWM V5, V23, V5
+	VADDUWM V6, V24, V6
+	VADDUWM V7, V25, V7
+	VSPLTW $0, V28, V22
+	VSPLTW $1, V28, V23
+	VSPLTW $2, V28, V24
+	VSPLTW $3, V28, V25
+	VADDUWM V8, V22, V8
+	VADDUWM V9, V23, V9
+	VADDUWM V10, V24, V10
+	VADDUWM V11, V25, V11
+	VSPLTW $1, V29, V23
+	VSPLTW $2, V29, V24
+	VSPLTW $3, V29, V25
+	VADDUWM V12, V31, V12
+	VADDUWM V13, V23, V13
+	VADDUWM V14, V24, V14
+	VADDUWM V15, V25, V15
+
+	// afterwards the block i is V(i), V(4+i), V(8+i), V(12+i)
+	TRANSPOSE(V0, V1, V2, V3, VS32, VS33, VS34, VS35)
+	TRANSPOSE(V4, V5, V6, V7, VS36, VS37, VS38, VS39)
+	TRANSPOSE(V8, V9, V10, V11, VS40, VS41, VS42, VS43)
+	TRANSPOSE(V12, V13, V14, V15, VS44, VS45, VS46, VS47)
+
+	XOR_BLOCK(V0, V4, V8, V12)
+	XOR_BLOCK(V1, V5, V9, V13)
+	XOR_BLOCK(V2, V6, V10, V14)
+	XOR_BLOCK(V3, V7, V11, V15)
+
+	// increment the 32 bit counter
+	MOVWZ 48(R4), R11
+	ADD $4, R11
+	MOVW R11, 48(R4)
+
+	BC 16, 0, LOOP // bdnz
+
+DONE:
+	RET
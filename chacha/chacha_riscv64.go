@@ -0,0 +1,52 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+// +build go1.25,riscv64,!gccgo,!appengine,!purego
+
+package chacha
+
+// hwCapV is the bit of the V (vector) extension in the hwcap entry of the
+// auxiliary vector. (1 << ('V' - 'A'))
+const hwCapV = 1 << 21
+
+func defaultBackend() Backend {
+	if readHWCap()&hwCapV != 0 {
+		return RVV
+	}
+	return Generic
+}
+
+func supportsBackend(b Backend) bool { return b == RVV && readHWCap()&hwCapV != 0 }
+
+func missingFeature(b Backend) string {
+	if b == RVV && readHWCap()&hwCapV == 0 {
+		return "hwcap doesn't report the V extension"
+	}
+	return ""
+}
+
+// xorBlocks crypts full block ( len(src) - (len(src) mod 64) bytes ) from src to
+// dst using the state. Src and dst may be the same slice
+// but otherwise should not overlap. If len(dst) < len(src) the behavior is undefined.
+// This function increments the counter of state.
+func xorBlocks(dst, src []byte, state *[64]byte, rounds int) {
+	if backend == RVV {
+		xorBlocksRVV(dst, src, state, rounds)
+	} else {
+		xorBlocksGeneric(dst, src, state, rounds)
+	}
+}
+
+// Core generates 64 byte keystream from the given state performing 'rounds' rounds
+// and writes them to dst. This function expects valid values. (no nil ptr etc.)
+// Core increments the counter of the state.
+func Core(dst *[64]byte, state *[64]byte, rounds int) {
+	core(dst, state, rounds)
+}
+
+// xorBlocksRVV crypts full block ( len(src) - (len(src) mod 64) bytes ) from src to
+// dst using the state. The number of blocks processed in parallel depends on the
+// vector length of the CPU.
+//go:noescape
+func xorBlocksRVV(dst, src []byte, state *[64]byte, rounds int)
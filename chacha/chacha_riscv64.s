@@ -0,0 +1,162 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+// +build go1.25,riscv64,!gccgo,!appengine,!purego
+
+#include "textflag.h"
+
+// The RVV implementation is vector length agnostic. Every vector register Vi
+// holds the word i of vl consecutive blocks. The blocks are loaded and stored
+// with a stride of 64 bytes, so no transposition is necessary.
+
+// ROTL rotates the words of v by n bits using the temp. register t.
+#define ROTL(n, v, t) \
+	VSLLVI $n, v, t; \
+	VSRLVI $(32-n), v, v; \
+	VORVV t, v, v
+
+// QUARTER_ROUND_4 performs four quarter rounds using the temp. registers V16 - V19.
+#define QUARTER_ROUND_4(a0, b0, c0, d0, a1, b1, c1, d1, a2, b2, c2, d2, a3, b3, c3, d3) \
+	VADDVV b0, a0, a0; VADDVV b1, a1, a1; VADDVV b2, a2, a2; VADDVV b3, a3, a3; \
+	VXORVV a0, d0, d0; VXORVV a1, d1, d1; VXORVV a2, d2, d2; VXORVV a3, d3, d3; \
+	ROTL(16, d0, V16); ROTL(16, d1, V17); ROTL(16, d2, V18); ROTL(16, d3, V19); \
+	VADDVV d0, c0, c0; VADDVV d1, c1, c1; VADDVV d2, c2, c2; VADDVV d3, c3, c3; \
+	VXORVV c0, b0, b0; VXORVV c1, b1, b1; VXORVV c2, b2, b2; VXORVV c3, b3, b3; \
+	ROTL(12, b0, V16); ROTL(12, b1, V17); ROTL(12, b2, V18); ROTL(12, b3, V19); \
+	VADDVV b0, a0, a0; VADDVV b1, a1, a1; VADDVV b2, a2, a2; VADDVV b3, a3, a3; \
+	VXORVV a0, d0, d0; VXORVV a1, d1, d1; VXORVV a2, d2, d2; VXORVV a3, d3, d3; \
+	ROTL(8, d0, V16); ROTL(8, d1, V17); ROTL(8, d2, V18); ROTL(8, d3, V19); \
+	VADDVV d0, c0, c0; VADDVV d1, c1, c1; VADDVV d2, c2, c2; VADDVV d3, c3, c3; \
+	VXORVV c0, b0, b0; VXORVV c1, b1, b1; VXORVV c2, b2, b2; VXORVV c3, b3, b3; \
+	ROTL(7, b0, V16); ROTL(7, b1, V17); ROTL(7, b2, V18); ROTL(7, b3, V19)
+
+// XOR_WORD xors the word i of the vl blocks at X20 (src) with v and writes
+// the result to X21 (dst). Afterwards X20 and X21 point to the next word.
+#define XOR_WORD(v) \
+	VLSE32V (X20), X15, V16; \
+	VXORVV V16, v, V16; \
+	VSSE32V V16, X15, (X21); \
+	ADD $4, X20; \
+	ADD $4, X21
+
+// func xorBlocksRVV(dst, src []byte, state *[64]byte, rounds int)
+TEXT ·xorBlocksRVV(SB),4,$0-64
+	MOV dst_base+0(FP), X10
+	MOV src_base+24(FP), X11
+	MOV src_len+32(FP), X12
+	MOV state+48(FP), X13
+	MOV rounds+56(FP), X14
+	SRL $6, X12 // number of blocks
+	BEQZ X12, DONE
+	MOV $64, X15
+
+LOOP:
+	VSETVLI X12, E32, M1, TA, MA, X16 // X16 = number of blocks processed in parallel
+	MOVWU 0(X13), X17
+	VMVVX X17, V0
+	MOVWU 4(X13), X17
+	VMVVX X17, V1
+	MOVWU 8(X13), X17
+	VMVVX X17, V2
+	MOVWU 12(X13), X17
+	VMVVX X17, V3
+	MOVWU 16(X13), X17
+	VMVVX X17, V4
+	MOVWU 20(X13), X17
+	VMVVX X17, V5
+	MOVWU 24(X13), X17
+	VMVVX X17, V6
+	MOVWU 28(X13), X17
+	VMVVX X17, V7
+	MOVWU 32(X13), X17
+	VMVVX X17, V8
+	MOVWU 36(X13), X17
+	VMVVX X17, V9
+	MOVWU 40(X13), X17
+	VMVVX X17, V10
+	MOVWU 44(X13), X17
+	VMVVX X17, V11
+	MOVWU 48(X13), X17
+	VIDV V12
+	VADDVX X17, V12, V12
+	VMVVV V12, V31
+	MOVWU 52(X13), X17
+	VMVVX X17, V13
+	MOVWU 56(X13), X17
+	VMVVX X17, V14
+	MOVWU 60(X13), X17
+	VMVVX X17, V15
+
+	MOV X14, X18
+CHACHA_LOOP:
+	QUARTER_ROUND_4(V0, V4, V8, V12, V1, V5, V9, V13, V2, V6, V10, V14, V3, V7, V11, V15)
+	QUARTER_ROUND_4(V0, V5, V10, V15, V1, V6, V11, V12, V2, V7, V8, V13, V3, V4, V9, V14)
+	SUB $2, X18
+	BNEZ X18, CHACHA_LOOP
+
+	// add the initial state
+	MOVWU 0(X13), X17
+	VADDVX X17, V0, V0
+	MOVWU 4(X13), X17
+	VADDVX X17, V1, V1
+	MOVWU 8(X13), X17
+	VADDVX X17, V2, V2
+	MOVWU 12(X13), X17
+	VADDVX X17, V3, V3
+	MOVWU 16(X13), X17
+	VADDVX X17, V4, V4
+	MOVWU 20(X13), X17
+	VADDVX X17, V5, V5
+	MOVWU 24(X13), X17
+	VADDVX X17, V6, V6
+	MOVWU 28(X13), X17
+	VADDVX X17, V7, V7
+	MOVWU 32(X13), X17
+	VADDVX X17, V8, V8
+	MOVWU 36(X13), X17
+	VADDVX X17, V9, V9
+	MOVWU 40(X13), X17
+	VADDVX X17, V10, V10
+	MOVWU 44(X13), X17
+	VADDVX X17, V11, V11
+	VADDVV V31, V12, V12
+	MOVWU 52(X13), X17
+	VADDVX X17, V13, V13
+	MOVWU 56(X13), X17
+	VADDVX X17, V14, V14
+	MOVWU 60(X13), X17
+	VADDVX X17, V15, V15
+
+	MOV X11, X20
+	MOV X10, X21
+	XOR_WORD(V0)
+	XOR_WORD(V1)
+	XOR_WORD(V2)
+	XOR_WORD(V3)
+	XOR_WORD(V4)
+	XOR_WORD(V5)
+	XOR_WORD(V6)
+	XOR_WORD(V7)
+	XOR_WORD(V8)
+	XOR_WORD(V9)
+	XOR_WORD(V10)
+	XOR_WORD(V11)
+	XOR_WORD(V12)
+	XOR_WORD(V13)
+	XOR_WORD(V14)
+	XOR_WORD(V15)
+	SLL $6, X16, X19
+	ADD X19, X11
+	ADD X19, X10
+
+	// increment the 32 bit counter
+	MOVWU 48(X13), X17
+	ADD X16, X17
+	MOVW X17, 48(X13)
+
+	SUB X16, X12
+	BNEZ X12, LOOP
+
+DONE:
+	RET
@@ -6,7 +6,14 @@ package chacha
 
 import (
 	"bytes"
+	"encoding/binary"
 	"encoding/hex"
+	"fmt"
+	"math/rand"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
 	"testing"
 )
 
@@ -75,6 +82,44 @@ func TestSetNonce(t *testing.T) {
 	}
 }
 
+func TestSetKey(t *testing.T) {
+	var key [32]byte
+	var nonce [12]byte
+	buf0, buf1 := make([]byte, 128), make([]byte, 128)
+
+	c := NewCipher(&nonce, &key, 20)
+	c.XORKeyStream(buf0[:1], buf0[:1])
+	key[0] = 1
+	c.SetKey(&key)
+	c.XORKeyStream(buf0[1:], buf0[1:])
+
+	key[0] = 0
+	XORKeyStream(buf1[:1], buf1[:1], &nonce, &key, 0, 20)
+	key[0] = 1
+	XORKeyStream(buf1[1:], buf1[1:], &nonce, &key, 1, 20)
+
+	if !bytes.Equal(buf0, buf1) {
+		t.Fatalf("XORKeyStream differ from chacha.XORKeyStream\n XORKeyStream: %s \n chacha.XORKeyStream: %s", hex.EncodeToString(buf1), hex.EncodeToString(buf0))
+	}
+}
+
+func TestWipe(t *testing.T) {
+	var key [32]byte
+	var nonce [12]byte
+	for i := range key {
+		key[i] = byte(i + 1)
+	}
+	c := NewCipher(&nonce, &key, 20)
+	c.Prefetch(256)
+	buf := make([]byte, 100)
+	c.XORKeyStream(buf, buf)
+
+	c.Wipe()
+	if c.state != [64]byte{} || c.block != [64]byte{} || c.ring != nil || c.buffered != 0 {
+		t.Fatal("Wipe doesn't overwrite the cipher state")
+	}
+}
+
 func TestXORKeyStream(t *testing.T) {
 	var key [32]byte
 	var nonce [12]byte
@@ -97,6 +142,251 @@ func TestXORKeyStream(t *testing.T) {
 	}
 }
 
+func TestPrefetch(t *testing.T) {
+	var key [32]byte
+	var nonce [12]byte
+	for i := range key {
+		key[i] = byte(i)
+	}
+	buf0, buf1 := make([]byte, 32*1024), make([]byte, 32*1024)
+	XORKeyStream(buf1, buf1, &nonce, &key, 0, 20)
+
+	c := NewCipher(&nonce, &key, 20)
+	off := 0
+	for i := 0; i < 256; i++ {
+		c.Prefetch((i * 37) % 301)
+		n := (i * 53) % 203
+		c.XORKeyStream(buf0[off:off+n], buf0[off:off+n])
+		off += n
+	}
+	c.XORKeyStream(buf0[off:], buf0[off:])
+
+	if !bytes.Equal(buf0, buf1) {
+		t.Fatalf("XORKeyStream differ from chacha.XORKeyStream\n XORKeyStream: %s \n chacha.XORKeyStream: %s", hex.EncodeToString(buf1), hex.EncodeToString(buf0))
+	}
+
+	c = NewCipher(&nonce, &key, 20)
+	c.Prefetch(256)
+	c.SetCounter(1)
+	buf0 = make([]byte, 128)
+	c.XORKeyStream(buf0, buf0)
+	if !bytes.Equal(buf0, buf1[64:192]) {
+		t.Fatalf("SetCounter does not discard the prefetched keystream")
+	}
+}
+
+func TestPipelinedCipher(t *testing.T) {
+	var key [32]byte
+	var nonce [12]byte
+	for i := range key {
+		key[i] = byte(i)
+	}
+	buf0, buf1 := make([]byte, 32*1024), make([]byte, 32*1024)
+	XORKeyStream(buf1, buf1, &nonce, &key, 0, 20)
+
+	for _, buffer := range []int{0, pipelineChunkSize + 1, 64 * 1024} {
+		for i := range buf0 {
+			buf0[i] = 0
+		}
+		c := NewPipelinedCipher(&nonce, &key, 20, buffer)
+		off := 0
+		for i := 0; off < len(buf0); i++ {
+			n := (i * 997) % 5003
+			if n > len(buf0)-off {
+				n = len(buf0) - off
+			}
+			c.XORKeyStream(buf0[off:off+n], buf0[off:off+n])
+			off += n
+		}
+		if !bytes.Equal(buf0, buf1) {
+			t.Fatalf("Buffer %d: PipelinedCipher differs from XORKeyStream", buffer)
+		}
+		c.Close()
+		for _, p := range c.chunks {
+			if !bytes.Equal(p, make([]byte, len(p))) {
+				t.Fatalf("Buffer %d: Close doesn't wipe the buffered keystream", buffer)
+			}
+		}
+		func() {
+			defer recFail(t, "cipher is closed")
+			c.XORKeyStream(buf0[:1], buf0[:1])
+		}()
+		c.Close()
+	}
+
+	c := newPipelinedCipher(&nonce, &key, 20, 0, 0xFFFFFFFE)
+	defer c.Close()
+	buf := make([]byte, 129)
+	c.XORKeyStream(buf[:100], buf[:100])
+	c.XORKeyStream(buf[100:128], buf[100:128])
+	mustPanicOverflow(t, func() { c.XORKeyStream(buf[128:], buf[128:]) })
+	expected := make([]byte, 128)
+	XORKeyStream(expected, expected, &nonce, &key, 0xFFFFFFFE, 20)
+	if !bytes.Equal(buf[:128], expected) {
+		t.Fatal("PipelinedCipher produces unexpected keystream at the end of the counter")
+	}
+}
+
+func TestXORKeyStreamChunks(t *testing.T) {
+	defer SetBackend(ActiveBackend())
+
+	var key [32]byte
+	var nonce [12]byte
+	for i := range key {
+		key[i] = byte(i)
+	}
+	expected := make([]byte, 1000)
+	XORKeyStream(expected, expected, &nonce, &key, 0, 20)
+
+	for b := Generic; b <= SIMD128; b++ {
+		if err := SetBackend(b); err != nil {
+			continue
+		}
+		for size := 1; size <= 130; size++ {
+			buf := make([]byte, len(expected))
+			c := NewCipher(&nonce, &key, 20)
+			for off := 0; off < len(buf); off += size {
+				end := off + size
+				if end > len(buf) {
+					end = len(buf)
+				}
+				c.XORKeyStream(buf[off:end], buf[off:end])
+			}
+			if !bytes.Equal(buf, expected) {
+				t.Fatalf("Backend %s: Chunk size %d: XORKeyStream produces unexpected keystream", b, size)
+			}
+		}
+	}
+}
+
+func TestXORKeyStreamUnaligned(t *testing.T) {
+	defer SetBackend(ActiveBackend())
+
+	var key [32]byte
+	var nonce [12]byte
+	for i := range key {
+		key[i] = byte(i)
+	}
+	src := make([]byte, 9*1024+64+17+16)
+	for i := range src {
+		src[i] = byte(i)
+	}
+	expected := make([]byte, len(src))
+	SetBackend(Generic)
+	XORKeyStream(expected, src, &nonce, &key, 0, 20)
+
+	for b := Generic; b <= SIMD128; b++ {
+		if err := SetBackend(b); err != nil {
+			continue
+		}
+		for srcOff := 0; srcOff < 16; srcOff += 3 {
+			for dstOff := 0; dstOff < 16; dstOff += 5 {
+				n := len(src) - 16
+				dst := make([]byte, len(src))
+				XORKeyStream(dst[dstOff:dstOff+n], src[srcOff:srcOff+n], &nonce, &key, 0, 20)
+
+				want := make([]byte, n)
+				for i := range want {
+					want[i] = expected[i] ^ src[i] ^ src[srcOff+i]
+				}
+				if !bytes.Equal(dst[dstOff:dstOff+n], want) {
+					t.Fatalf("Backend %s: src offset %d dst offset %d: XORKeyStream produces unexpected result", b, srcOff, dstOff)
+				}
+			}
+		}
+	}
+}
+
+func TestCounterOverflow(t *testing.T) {
+	defer SetBackend(ActiveBackend())
+
+	var key [32]byte
+	var nonce [12]byte
+	var nonce64 [8]byte
+	for i := range key {
+		key[i] = byte(i)
+	}
+	for i := range nonce64 {
+		nonce64[i] = byte(255 - i)
+	}
+	copy(nonce[4:], nonce64[:])
+
+	// The 32 bit counter must not wrap around - the keystream ends after
+	// 6 blocks. The 64 bit counter carries into the first nonce word.
+	const blocks = 12
+	const start = 0xFFFFFFFF - 5
+	var expected32, expected64 [blocks * 64]byte
+	for i := 0; i < blocks; i++ {
+		var block [64]byte
+		ctr := uint64(start) + uint64(i)
+		Block(&block, &nonce, &key, uint32(ctr), 20)
+		copy(expected32[64*i:], block[:])
+
+		n := nonce
+		n[0] = byte(ctr >> 32)
+		Block(&block, &n, &key, uint32(ctr), 20)
+		copy(expected64[64*i:], block[:])
+	}
+
+	for b := Generic; b <= SIMD128; b++ {
+		if err := SetBackend(b); err != nil {
+			continue
+		}
+		for _, size := range []int{6*64 - 17, 6 * 64, 6*64 + 1, 7 * 64, len(expected32) - 17, len(expected32)} {
+			dst := make([]byte, size)
+			if size <= 6*64 {
+				XORKeyStream(dst, dst, &nonce, &key, start, 20)
+				if !bytes.Equal(dst, expected32[:size]) {
+					t.Fatalf("Backend %s: Size %d: unexpected keystream before the counter overflow", b, size)
+				}
+			} else {
+				mustPanicOverflow(t, func() { XORKeyStream(dst, dst, &nonce, &key, start, 20) })
+			}
+			dst = make([]byte, size)
+			XORKeyStream64(dst, dst, &nonce64, &key, start, 20)
+			if !bytes.Equal(dst, expected64[:size]) {
+				t.Fatalf("Backend %s: Size %d: 64 bit counter does not carry", b, size)
+			}
+		}
+
+		c32, c64 := NewCipher(&nonce, &key, 20), NewCipher64(&nonce64, &key, 20)
+		c32.SetCounter(start)
+		c64.SetCounter(start)
+		dst32, dst64 := make([]byte, 6*64), make([]byte, len(expected64))
+		for off := 0; off < len(dst64); off += 67 {
+			end := off + 67
+			if end > len(dst64) {
+				end = len(dst64)
+			}
+			if end <= len(dst32) {
+				c32.XORKeyStream(dst32[off:end], dst32[off:end])
+			} else if off < len(dst32) {
+				c32.XORKeyStream(dst32[off:], dst32[off:])
+			}
+			c64.XORKeyStream(dst64[off:end], dst64[off:end])
+		}
+		if !bytes.Equal(dst32, expected32[:len(dst32)]) {
+			t.Fatalf("Backend %s: Cipher: unexpected keystream before the counter overflow", b)
+		}
+		mustPanicOverflow(t, func() { c32.XORKeyStream(dst32[:1], dst32[:1]) })
+		mustPanicOverflow(t, func() { c32.Prefetch(1) })
+		c32.SetCounter(start)
+		mustPanicOverflow(t, func() { c32.Prefetch(6*64 + 1) })
+		if !bytes.Equal(dst64, expected64[:]) {
+			t.Fatalf("Backend %s: Cipher: 64 bit counter does not carry", b)
+		}
+	}
+}
+
+func mustPanicOverflow(t *testing.T, f func()) {
+	defer func() {
+		if err := recover(); err != ErrCounterOverflow {
+			t.Fatalf("Expected panic %v - got %v", ErrCounterOverflow, err)
+		}
+	}()
+	f()
+}
+
 func TestXORKeyStreamPanic(t *testing.T) {
 	mustFail := func(t *testing.T, msg string, dst, src []byte, nonce *[12]byte, key *[32]byte, counter uint32, rounds int) {
 		defer recFail(t, msg)
@@ -122,6 +412,10 @@ func TestXORKeyStreamPanic(t *testing.T) {
 
 	mustFail2(t, "len(dst) < len(src)", dst[:len(src)-1], src)
 
+	mustPanicOverflow(t, func() { XORKeyStream(dst, src, nonce, key, 0xFFFFFFFF, 20) })
+	mustPanicOverflow(t, func() { XORKeyStreamAt(dst[:2], src[:2], nonce, key, 1<<38-1, 20) })
+	mustPanicOverflow(t, func() { XORKeyStreamParallel(dst, src, nonce, key, 0xFFFFFFFF, 20) })
+
 }
 
 func testXORBlocks(t *testing.T, size int) {
@@ -154,4 +448,454 @@ func TestXorBlocks(t *testing.T) {
 	testXORBlocks(t, 448)
 	testXORBlocks(t, 512)
 	testXORBlocks(t, 1024)
+	testXORBlocks(t, 8*1024)
+	testXORBlocks(t, 8*1024+64)
+	testXORBlocks(t, 17*1024+192)
+}
+
+func TestXor(t *testing.T) {
+	src, with := make([]byte, 100), make([]byte, 100)
+	for i := range src {
+		src[i], with[i] = byte(i), byte(3*i+1)
+	}
+	for off := 0; off < 8; off++ {
+		for n := 0; n < 64; n++ {
+			dst := make([]byte, n+off)
+			if r := xor(dst[off:], src[off:off+n], with[1:]); r != n {
+				t.Fatalf("Offset %d: xor returned %d - expected %d", off, r, n)
+			}
+			for i := 0; i < n; i++ {
+				if dst[off+i] != src[off+i]^with[1+i] {
+					t.Fatalf("Offset %d: Size %d: xor produces unexpected result at %d", off, n, i)
+				}
+			}
+		}
+	}
+}
+
+func TestXORBytes(t *testing.T) {
+	x, y := make([]byte, 300), make([]byte, 300)
+	for i := range x {
+		x[i], y[i] = byte(i), byte(5*i+3)
+	}
+	for off := 0; off < 8; off++ {
+		for _, n := range []int{0, 1, 7, 8, 15, 16, 31, 32, 33, 63, 64, 255, 256, 291} {
+			dst := make([]byte, n+off)
+			if r := XORBytes(dst[off:], x[off:off+n], y[1:]); r != n {
+				t.Fatalf("Offset %d: XORBytes returned %d - expected %d", off, r, n)
+			}
+			for i := 0; i < n; i++ {
+				if dst[off+i] != x[off+i]^y[1+i] {
+					t.Fatalf("Offset %d: Size %d: XORBytes produces unexpected result at %d", off, n, i)
+				}
+			}
+		}
+	}
+
+	inPlace := append([]byte{}, x...)
+	XORBytes(inPlace, inPlace, y)
+	XORBytes(inPlace, y, inPlace)
+	if !bytes.Equal(inPlace, x) {
+		t.Fatal("XORBytes fails if dst is the same slice as x or y")
+	}
+	func() {
+		defer recFail(t, "dst buffer is to small")
+		XORBytes(make([]byte, 9), x[:10], y)
+	}()
+}
+
+func TestHChaCha20(t *testing.T) {
+	// Test vector from:
+	// https://tools.ietf.org/html/draft-irtf-cfrg-xchacha-01#section-2.2.1
+	var (
+		key    [32]byte
+		nonce  [16]byte
+		subKey [32]byte
+	)
+	for i := range key {
+		key[i] = byte(i)
+	}
+	hex.Decode(nonce[:], []byte("000000090000004a0000000031415927"))
+	expected, _ := hex.DecodeString("82413b4227b27bfed30e42508a877d73a0f9e4d58a74a853c12ec41326d3ecdc")
+
+	HChaCha20(&subKey, &nonce, &key)
+	if !bytes.Equal(subKey[:], expected) {
+		t.Fatalf("HChaCha20 produces unexpected output:\nFound   : %s\nExpected: %s", hex.EncodeToString(subKey[:]), hex.EncodeToString(expected))
+	}
+}
+
+func TestXORKeyStreamAt(t *testing.T) {
+	var key [32]byte
+	var nonce [12]byte
+	for i := range key {
+		key[i] = byte(i)
+	}
+	keystream := make([]byte, 1024)
+	XORKeyStream(keystream, keystream, &nonce, &key, 0, 20)
+
+	for _, offset := range []int{0, 1, 63, 64, 65, 200, 511} {
+		for _, size := range []int{0, 1, 63, 64, 65, 300} {
+			buf := make([]byte, size)
+			XORKeyStreamAt(buf, buf, &nonce, &key, uint64(offset), 20)
+			if !bytes.Equal(buf, keystream[offset:offset+size]) {
+				t.Fatalf("Offset %d Size %d: XORKeyStreamAt produces unexpected keystream", offset, size)
+			}
+		}
+	}
+}
+
+func TestXORKeyStreamAllocs(t *testing.T) {
+	var key [32]byte
+	var nonce [12]byte
+	buf := make([]byte, 1024+63)
+	c := NewCipher(&nonce, &key, 20)
+
+	allocs := testing.AllocsPerRun(10, func() {
+		XORKeyStream(buf, buf, &nonce, &key, 0, 20)
+		c.XORKeyStream(buf, buf)
+	})
+	if allocs != 0 {
+		t.Fatalf("XORKeyStream allocates %v times", allocs)
+	}
+}
+
+// The test vectors are from RFC 7539. They don't depend on the byte order of
+// the platform, so running them on big endian targets (e.g. s390x or mips)
+// verifies that the state is built and serialized in little endian order.
+
+func TestBlock(t *testing.T) {
+	// https://tools.ietf.org/html/rfc7539#section-2.3.2
+	var (
+		key   [32]byte
+		nonce [12]byte
+		block [64]byte
+	)
+	for i := range key {
+		key[i] = byte(i)
+	}
+	hex.Decode(nonce[:], []byte("000000090000004a00000000"))
+	expected, _ := hex.DecodeString("10f1e7e4d13b5915500fdd1fa32071c4c7d1f4c733c068030422aa9ac3d46c4e" +
+		"d2826446079faa0914c2d705d98b02a2b5129cd1de164eb9cbd083e8a2503c4e")
+
+	Block(&block, &nonce, &key, 1, 20)
+	if !bytes.Equal(block[:], expected) {
+		t.Fatalf("Block produces unexpected output:\nFound   : %s\nExpected: %s", hex.EncodeToString(block[:]), hex.EncodeToString(expected))
+	}
+}
+
+func TestXORKeyStreamVector(t *testing.T) {
+	// https://tools.ietf.org/html/rfc7539#section-2.4.2
+	var (
+		key   [32]byte
+		nonce [12]byte
+	)
+	for i := range key {
+		key[i] = byte(i)
+	}
+	hex.Decode(nonce[:], []byte("000000000000004a00000000"))
+	plaintext := []byte("Ladies and Gentlemen of the class of '99: If I could offer you only one tip for the future, sunscreen would be it.")
+	expected, _ := hex.DecodeString("6e2e359a2568f98041ba0728dd0d6981e97e7aec1d4360c20a27afccfd9fae0b" +
+		"f91b65c5524733ab8f593dabcd62b3571639d624e65152ab8f530c359f0861d8" +
+		"07ca0dbf500d6a6156a38e088a22b65e52bc514d16ccf806818ce91ab7793736" +
+		"5af90bbf74a35be6b40b8eedf2785e42874d")
+
+	ciphertext := make([]byte, len(plaintext))
+	XORKeyStream(ciphertext, plaintext, &nonce, &key, 1, 20)
+	if !bytes.Equal(ciphertext, expected) {
+		t.Fatalf("XORKeyStream produces unexpected output:\nFound   : %s\nExpected: %s", hex.EncodeToString(ciphertext), hex.EncodeToString(expected))
+	}
+
+	c := NewCipher(&nonce, &key, 20)
+	c.SetCounter(1)
+	c.XORKeyStream(ciphertext[:7], plaintext[:7])
+	c.XORKeyStream(ciphertext[7:], plaintext[7:])
+	if !bytes.Equal(ciphertext, expected) {
+		t.Fatalf("Cipher.XORKeyStream produces unexpected output:\nFound   : %s\nExpected: %s", hex.EncodeToString(ciphertext), hex.EncodeToString(expected))
+	}
+}
+
+func TestSetState(t *testing.T) {
+	var (
+		key   [32]byte
+		nonce [12]byte
+		state [64]byte
+	)
+	for i := range key {
+		key[i] = byte(i)
+	}
+	for i := range nonce {
+		nonce[i] = byte(0x80 + i)
+	}
+	setState(&state, &key, &nonce, 0x04030201)
+
+	expected, _ := hex.DecodeString("657870616e642033322d62797465206b" +
+		"000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f" +
+		"01020304" + "808182838485868788898a8b")
+	if !bytes.Equal(state[:], expected) {
+		t.Fatalf("setState produces unexpected state:\nFound   : %s\nExpected: %s", hex.EncodeToString(state[:]), hex.EncodeToString(expected))
+	}
+}
+
+func TestSetBackend(t *testing.T) {
+	defer SetBackend(ActiveBackend())
+
+	var key [32]byte
+	var nonce [12]byte
+	for i := range key {
+		key[i] = byte(i)
+	}
+	src := make([]byte, 17*1024+192+17)
+	for i := range src {
+		src[i] = byte(i)
+	}
+	sizes := []int{1, 64, 65, 128, 192, 256, 1024 + 64, len(src)}
+
+	expected := make([][]byte, len(sizes))
+	if err := SetBackend(Generic); err != nil {
+		t.Fatalf("Failed to select the generic backend: %v", err)
+	}
+	for i, size := range sizes {
+		expected[i] = make([]byte, size)
+		XORKeyStream(expected[i], src[:size], &nonce, &key, 0, 20)
+	}
+
+	for b := Generic; b <= SIMD128; b++ {
+		if err := SetBackend(b); err != nil {
+			if supportsBackend(b) {
+				t.Fatalf("Backend %s: SetBackend failed: %v", b, err)
+			}
+			continue
+		}
+		if ActiveBackend() != b {
+			t.Fatalf("Backend %s: ActiveBackend returns %s", b, ActiveBackend())
+		}
+		for i, size := range sizes {
+			dst := make([]byte, size)
+			XORKeyStream(dst, src[:size], &nonce, &key, 0, 20)
+			if !bytes.Equal(dst, expected[i]) {
+				t.Fatalf("Backend %s: Size %d: XORKeyStream produces unexpected keystream", b, size)
+			}
+		}
+	}
+
+	if err := SetBackend(Backend(-1)); err == nil {
+		t.Fatal("SetBackend accepts an invalid backend")
+	}
+
+	var accepted []Backend
+	for b := Generic; b <= SIMD128; b++ {
+		if SetBackend(b) == nil {
+			accepted = append(accepted, b)
+		}
+	}
+	if supported := SupportedBackends(); fmt.Sprint(supported) != fmt.Sprint(accepted) {
+		t.Fatalf("SupportedBackends returns %v but SetBackend accepts %v", supported, accepted)
+	}
+}
+
+func TestXORKeyStreamParallel(t *testing.T) {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(4))
+
+	var key [32]byte
+	var nonce [12]byte
+	for i := range key {
+		key[i] = byte(i)
+	}
+	src := make([]byte, 3*parallelMinLength+17)
+	for i := range src {
+		src[i] = byte(i)
+	}
+
+	for _, size := range []int{65, parallelMinLength - 1, parallelMinLength, len(src)} {
+		expected, dst := make([]byte, size), make([]byte, size)
+		XORKeyStream(expected, src[:size], &nonce, &key, 7, 20)
+		XORKeyStreamParallel(dst, src[:size], &nonce, &key, 7, 20)
+		if !bytes.Equal(dst, expected) {
+			t.Fatalf("Size %d: XORKeyStreamParallel differs from XORKeyStream", size)
+		}
+
+		copy(dst, src)
+		XORKeyStreamParallel(dst, dst, &nonce, &key, 7, 20)
+		if !bytes.Equal(dst, expected) {
+			t.Fatalf("Size %d: in-place XORKeyStreamParallel differs from XORKeyStream", size)
+		}
+	}
+}
+
+func TestSource(t *testing.T) {
+	var key [32]byte
+	var nonce [8]byte
+	for i := range key {
+		key[i] = byte(i)
+	}
+	nonce[0] = 1
+
+	var _ rand.Source64 = NewSource(&nonce, &key)
+	keystream := make([]byte, 1000*8)
+	XORKeyStream64(keystream, keystream, &nonce, &key, 0, 8)
+
+	s := NewSource(&nonce, &key)
+	for i := 0; i < len(keystream); i += 8 {
+		if v := s.Uint64(); v != binary.LittleEndian.Uint64(keystream[i:]) {
+			t.Fatalf("Uint64 %d: got %x - want the keystream %x", i/8, v, keystream[i:i+8])
+		}
+	}
+
+	s.Seed(42)
+	binary.LittleEndian.PutUint64(key[:], 42)
+	for i := 8; i < len(key); i++ {
+		key[i] = 0
+	}
+	for i := range keystream {
+		keystream[i] = 0
+	}
+	XORKeyStream64(keystream, keystream, &nonce, &key, 0, 8)
+	for i := 0; i < len(keystream); i += 8 {
+		v := s.Int63()
+		if v < 0 || v != int64(binary.LittleEndian.Uint64(keystream[i:])>>1) {
+			t.Fatalf("Int63 %d: produces unexpected number %x after Seed", i/8, v)
+		}
+	}
+}
+
+// BenchmarkXORKeyStreamTiny compares the backends for inputs of at most 32 bytes
+// (e.g. header protection samples), where the keystream of a single block is
+// generated per call. The SSSE3 (amd64) and SSE2 (386) cores are faster than
+// the pure Go core even for one byte, so XORKeyStream doesn't switch to the
+// pure Go core for tiny inputs.
+func BenchmarkXORKeyStreamTiny(b *testing.B) {
+	defer SetBackend(ActiveBackend())
+
+	var key [32]byte
+	var nonce [12]byte
+	for backend := Generic; backend <= SIMD128; backend++ {
+		if SetBackend(backend) != nil {
+			continue
+		}
+		for _, size := range []int{1, 16, 32} {
+			buf := make([]byte, size)
+			b.Run(fmt.Sprintf("%s/%d", backend, size), func(b *testing.B) {
+				b.SetBytes(int64(size))
+				for i := 0; i < b.N; i++ {
+					XORKeyStream(buf, buf, &nonce, &key, 0, 20)
+				}
+			})
+		}
+	}
+}
+
+// BenchmarkXORKeyStreamGeneric measures the pure Go backend. It's the only
+// implementation on architectures like mips, loong64 or 32 bit arm without
+// NEON, so it should be run for them - e.g. GOARCH=386 or GOARCH=arm - too.
+// BenchmarkXORBlocksScalar compares the loong64 implementation with it.
+func BenchmarkXORKeyStreamGeneric(b *testing.B) {
+	defer SetBackend(ActiveBackend())
+	SetBackend(Generic)
+
+	var key [32]byte
+	var nonce [12]byte
+	for _, size := range []int{64, 1024, 16 * 1024} {
+		buf := make([]byte, size)
+		b.Run(fmt.Sprintf("%d", size), func(b *testing.B) {
+			b.SetBytes(int64(size))
+			for i := 0; i < b.N; i++ {
+				XORKeyStream(buf, buf, &nonce, &key, 0, 20)
+			}
+		})
+	}
+}
+
+// BenchmarkPipelinedCipher measures the latency of XORKeyStream for audio
+// frame sized inputs while the keystream is generated in the background.
+func BenchmarkPipelinedCipher(b *testing.B) {
+	var key [32]byte
+	var nonce [12]byte
+	for _, size := range []int{160, 1920} {
+		buf := make([]byte, size)
+		b.Run(fmt.Sprintf("%d", size), func(b *testing.B) {
+			c := NewPipelinedCipher(&nonce, &key, 20, 64*1024)
+			defer c.Close()
+			b.SetBytes(int64(size))
+			for i := 0; i < b.N; i++ {
+				c.XORKeyStream(buf, buf)
+			}
+		})
+	}
+}
+
+func BenchmarkSourceUint64(b *testing.B) {
+	var key [32]byte
+	var nonce [8]byte
+	s := NewSource(&nonce, &key)
+	b.SetBytes(8)
+	for i := 0; i < b.N; i++ {
+		s.Uint64()
+	}
+}
+
+func TestDescribeBackend(t *testing.T) {
+	defer SetBackend(ActiveBackend())
+
+	info := DescribeBackend()
+	if info.Active != ActiveBackend() {
+		t.Fatalf("DescribeBackend: active backend %s - want %s", info.Active, ActiveBackend())
+	}
+	if info.Reason == "" {
+		t.Fatal("DescribeBackend: reason is empty")
+	}
+	for b, reason := range info.Unsupported {
+		if supportsBackend(b) {
+			t.Fatalf("DescribeBackend: supported backend %s reported as unsupported: %s", b, reason)
+		}
+	}
+	if !strings.HasPrefix(info.String(), info.Active.String()+" (") {
+		t.Fatalf("BackendInfo: unexpected description %q", info.String())
+	}
+
+	if info.Default == Generic {
+		return
+	}
+	if err := SetBackend(Generic); err != nil {
+		t.Fatalf("Failed to select the generic backend: %v", err)
+	}
+	if info = DescribeBackend(); info.Active != Generic || info.Reason != "selected by SetBackend" {
+		t.Fatalf("DescribeBackend doesn't report the override: %s", info)
+	}
+}
+
+func TestSetNEONBlocks(t *testing.T) {
+	defer func(n int) { neonBlocks = n }(neonBlocks)
+
+	if neonBlocks == 0 {
+		if err := SetNEONBlocks(4); err == nil {
+			t.Fatal("SetNEONBlocks succeeded on a platform without the arm64 NEON backend")
+		}
+		return
+	}
+	if err := SetNEONBlocks(5); err == nil {
+		t.Fatal("SetNEONBlocks accepted 5 blocks")
+	}
+	for _, n := range []int{4, 6} {
+		if err := SetNEONBlocks(n); err != nil {
+			t.Fatalf("SetNEONBlocks(%d) failed: %v", n, err)
+		}
+		info := DescribeBackend()
+		if want := strconv.Itoa(n) + " block schedule"; info.Active == NEON && info.Variant != want {
+			t.Fatalf("DescribeBackend reports the variant %q - want %q", info.Variant, want)
+		}
+	}
+}
+
+// TestExpectedBackend fails if the default backend isn't the one named by the
+// CHACHA20_EXPECT_BACKEND environment variable. The CI jobs running the tests
+// under qemu set it, so a broken feature detection can't silently reduce the
+// differential tests to the generic backend.
+func TestExpectedBackend(t *testing.T) {
+	name := os.Getenv("CHACHA20_EXPECT_BACKEND")
+	if name == "" {
+		t.Skip("CHACHA20_EXPECT_BACKEND is not set")
+	}
+	if info := DescribeBackend(); info.Default.String() != name {
+		t.Fatalf("Default backend is %s - want %s", info, name)
+	}
 }
@@ -0,0 +1,277 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+// +build go1.27,wasm,!gccgo,!appengine,!purego
+
+#include "textflag.h"
+
+// The SIMD128 implementation computes 4 blocks in parallel. Every register Vi
+// holds the word i of 4 consecutive blocks. WebAssembly is a stack machine,
+// so no temp. registers are necessary.
+
+// ADD computes a += b.
+#define ADD(a, b) \
+	Get a; \
+	Get b; \
+	I32x4Add; \
+	Set a
+
+// ROTL computes v = (v ^ w) <<< n.
+#define ROTL(n, w, v) \
+	Get v; \
+	Get w; \
+	V128Xor; \
+	Tee v; \
+	I32Const $n; \
+	I32x4Shl; \
+	Get v; \
+	I32Const $(32-n); \
+	I32x4ShrU; \
+	V128Or; \
+	Set v
+
+// ROTL_16 computes v = (v ^ w) <<< 16.
+#define ROTL_16(w, v) \
+	Get v; \
+	Get w; \
+	V128Xor; \
+	V128Const $0x0504070601000302, $0x0D0C0F0E09080B0A; \
+	I8x16Swizzle; \
+	Set v
+
+// ROTL_8 computes v = (v ^ w) <<< 8.
+#define ROTL_8(w, v) \
+	Get v; \
+	Get w; \
+	V128Xor; \
+	V128Const $0x0605040702010003, $0x0E0D0C0F0A09080B; \
+	I8x16Swizzle; \
+	Set v
+
+#define QUARTER_ROUND(a, b, c, d) \
+	ADD(a, b); \
+	ROTL_16(a, d); \
+	ADD(c, d); \
+	ROTL(12, c, b); \
+	ADD(a, b); \
+	ROTL_8(a, d); \
+	ADD(c, d); \
+	ROTL(7, c, b)
+
+// LOAD_WORD broadcasts the word at off(state) to all lanes.
+#define LOAD_WORD(off) \
+	Get R3; \
+	I32WrapI64; \
+	I32Load $off; \
+	I32x4Splat
+
+// LANE pushes the lane i of v.
+// The assembler takes the lane index of I32x4ExtractLane and I32x4ReplaceLane
+// and the offset of V128Store from the second operand.
+#define LANE(v, i) \
+	Get v; \
+	I32x4ExtractLane $0, $i
+
+// XOR_ROW xors the row r of the block i (the lane i of a, b, c and d) with
+// the src and writes it to dst.
+#define XOR_ROW(i, r, a, b, c, d) \
+	Get R0; \
+	I32WrapI64; \
+	Get R1; \
+	I32WrapI64; \
+	V128Load $(64*i+16*r); \
+	LANE(a, i); \
+	I32x4Splat; \
+	LANE(b, i); \
+	I32x4ReplaceLane $0, $1; \
+	LANE(c, i); \
+	I32x4ReplaceLane $0, $2; \
+	LANE(d, i); \
+	I32x4ReplaceLane $0, $3; \
+	V128Xor; \
+	V128Store $0, $(64*i+16*r)
+
+#define XOR_BLOCK(i) \
+	XOR_ROW(i, 0, V0, V1, V2, V3); \
+	XOR_ROW(i, 1, V4, V5, V6, V7); \
+	XOR_ROW(i, 2, V8, V9, V10, V11); \
+	XOR_ROW(i, 3, V12, V13, V14, V15)
+
+// func xorBlocksSIMD128(dst, src []byte, state *[64]byte, rounds int)
+TEXT ·xorBlocksSIMD128(SB),4,$0-64
+	MOVD dst_base+0(FP), R0
+	MOVD src_base+24(FP), R1
+	MOVD src_len+32(FP), R2
+	MOVD state+48(FP), R3
+	MOVD rounds+56(FP), R4
+
+	Get R2
+	I64Const $8
+	I64ShrU
+	Tee R2 // number of 4 block chunks
+	I64Eqz
+	If
+		RET
+	End
+
+	Loop
+		// load the state and compute the counters of the 4 blocks
+		LOAD_WORD(0)
+		Set V0
+		LOAD_WORD(4)
+		Set V1
+		LOAD_WORD(8)
+		Set V2
+		LOAD_WORD(12)
+		Set V3
+		LOAD_WORD(16)
+		Set V4
+		LOAD_WORD(20)
+		Set V5
+		LOAD_WORD(24)
+		Set V6
+		LOAD_WORD(28)
+		Set V7
+		LOAD_WORD(32)
+		Set V8
+		LOAD_WORD(36)
+		Set V9
+		LOAD_WORD(40)
+		Set V10
+		LOAD_WORD(44)
+		Set V11
+		LOAD_WORD(48)
+		V128Const $0x0000000100000000, $0x0000000300000002
+		I32x4Add
+		Set V12
+		LOAD_WORD(52)
+		Set V13
+		LOAD_WORD(56)
+		Set V14
+		LOAD_WORD(60)
+		Set V15
+
+		MOVD R4, R5
+		Loop
+			QUARTER_ROUND(V0, V4, V8, V12)
+			QUARTER_ROUND(V1, V5, V9, V13)
+			QUARTER_ROUND(V2, V6, V10, V14)
+			QUARTER_ROUND(V3, V7, V11, V15)
+			QUARTER_ROUND(V0, V5, V10, V15)
+			QUARTER_ROUND(V1, V6, V11, V12)
+			QUARTER_ROUND(V2, V7, V8, V13)
+			QUARTER_ROUND(V3, V4, V9, V14)
+
+			Get R5
+			I64Const $2
+			I64Sub
+			Tee R5
+			I64Eqz
+			I32Eqz
+			BrIf $0
+		End
+
+		// add the initial state
+		Get V0
+		LOAD_WORD(0)
+		I32x4Add
+		Set V0
+		Get V1
+		LOAD_WORD(4)
+		I32x4Add
+		Set V1
+		Get V2
+		LOAD_WORD(8)
+		I32x4Add
+		Set V2
+		Get V3
+		LOAD_WORD(12)
+		I32x4Add
+		Set V3
+		Get V4
+		LOAD_WORD(16)
+		I32x4Add
+		Set V4
+		Get V5
+		LOAD_WORD(20)
+		I32x4Add
+		Set V5
+		Get V6
+		LOAD_WORD(24)
+		I32x4Add
+		Set V6
+		Get V7
+		LOAD_WORD(28)
+		I32x4Add
+		Set V7
+		Get V8
+		LOAD_WORD(32)
+		I32x4Add
+		Set V8
+		Get V9
+		LOAD_WORD(36)
+		I32x4Add
+		Set V9
+		Get V10
+		LOAD_WORD(40)
+		I32x4Add
+		Set V10
+		Get V11
+		LOAD_WORD(44)
+		I32x4Add
+		Set V11
+		Get V12
+		LOAD_WORD(48)
+		V128Const $0x0000000100000000, $0x0000000300000002
+		I32x4Add
+		I32x4Add
+		Set V12
+		Get V13
+		LOAD_WORD(52)
+		I32x4Add
+		Set V13
+		Get V14
+		LOAD_WORD(56)
+		I32x4Add
+		Set V14
+		Get V15
+		LOAD_WORD(60)
+		I32x4Add
+		Set V15
+
+		// the block i is the lane i of V0 - V15
+		XOR_BLOCK(0)
+		XOR_BLOCK(1)
+		XOR_BLOCK(2)
+		XOR_BLOCK(3)
+
+		// increment the 32 bit counter
+		Get R3
+		I32WrapI64
+		Get R3
+		I32WrapI64
+		I32Load $48
+		I32Const $4
+		I32Add
+		I32Store $48
+
+		Get R0
+		I64Const $256
+		I64Add
+		Set R0
+		Get R1
+		I64Const $256
+		I64Add
+		Set R1
+
+		Get R2
+		I64Const $1
+		I64Sub
+		Tee R2
+		I64Eqz
+		I32Eqz
+		BrIf $0
+	End
+
+	RET
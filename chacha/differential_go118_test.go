@@ -0,0 +1,49 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+// +build go1.18
+
+package chacha
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/aead/chacha20/chacha/reference"
+)
+
+// FuzzXORKeyStream compares XORKeyStream of every supported backend
+// against the reference implementation for arbitrary keys, nonces,
+// counters, rounds, offsets and messages.
+func FuzzXORKeyStream(f *testing.F) {
+	f.Add(make([]byte, 32), make([]byte, 12), uint32(0), uint8(10), uint8(0), make([]byte, 64))
+	f.Add(make([]byte, 32), make([]byte, 12), uint32(1<<32-2), uint8(4), uint8(3), make([]byte, 129))
+	f.Add(make([]byte, 32), make([]byte, 12), uint32(7), uint8(6), uint8(1), make([]byte, 1024+17))
+
+	f.Fuzz(func(t *testing.T, k, n []byte, counter uint32, r, off uint8, src []byte) {
+		var (
+			key   [32]byte
+			nonce [12]byte
+		)
+		copy(key[:], k)
+		copy(nonce[:], n)
+		rounds := 2 * (1 + int(r%10))
+		if blocks := (uint64(len(src)) + 63) / 64; uint64(counter)+blocks > 1<<32 {
+			counter = uint32(1<<32 - blocks)
+		}
+		buf := make([]byte, len(src)+int(off%64))
+		copy(buf[off%64:], src)
+		src = buf[off%64:]
+
+		expected := make([]byte, len(src))
+		reference.XORKeyStream(expected, src, &nonce, &key, counter, rounds)
+		forEachBackend(t, func(t *testing.T, b Backend) {
+			dst := make([]byte, len(src))
+			XORKeyStream(dst, src, &nonce, &key, counter, rounds)
+			if !bytes.Equal(dst, expected) {
+				t.Fatalf("Backend %s: ChaCha%d: Size %d: XORKeyStream differs from the reference", b, rounds, len(src))
+			}
+		})
+	})
+}
@@ -0,0 +1,190 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package chacha
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+
+	"github.com/aead/chacha20/chacha/reference"
+)
+
+// The differential tests compare every backend supported by the platform
+// against the reference implementation. A new backend is covered once
+// supportsBackend reports it.
+
+var differentialSizes = []int{
+	0, 1, 15, 16, 17, 63, 64, 65, 127, 128, 129, 191, 192, 193, 255, 256, 257,
+	511, 512, 513, 1023, 1024, 1025, 4*1024 + 17, 17*1024 + 192 + 17, 64*1024 + 63,
+}
+
+// forEachBackend calls f with every supported backend selected.
+func forEachBackend(t *testing.T, f func(t *testing.T, b Backend)) {
+	defer SetBackend(ActiveBackend())
+	for _, b := range SupportedBackends() {
+		if err := SetBackend(b); err != nil {
+			t.Fatalf("Backend %s: SetBackend failed: %v", b, err)
+		}
+		f(t, b)
+	}
+}
+
+func TestDifferentialXORKeyStream(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	var key [32]byte
+	var nonce [12]byte
+	rng.Read(key[:])
+	rng.Read(nonce[:])
+	buf := make([]byte, differentialSizes[len(differentialSizes)-1]+3)
+	rng.Read(buf)
+
+	forEachBackend(t, func(t *testing.T, b Backend) {
+		for _, rounds := range []int{8, 12, 20} {
+			for _, size := range differentialSizes {
+				// The offsets misalign src and dst.
+				for off := 0; off < 4; off++ {
+					src := buf[off : off+size]
+					expected, dst := make([]byte, size), make([]byte, size+off)[off:]
+					counter := uint32(size + off)
+
+					reference.XORKeyStream(expected, src, &nonce, &key, counter, rounds)
+					XORKeyStream(dst, src, &nonce, &key, counter, rounds)
+					if !bytes.Equal(dst, expected) {
+						t.Fatalf("Backend %s: ChaCha%d: Size %d: Offset %d: XORKeyStream differs from the reference", b, rounds, size, off)
+					}
+
+					c := NewCipher(&nonce, &key, rounds)
+					c.SetCounter(counter)
+					for i := 0; i < size; {
+						n := 1 + (i+off)%97
+						if n > size-i {
+							n = size - i
+						}
+						c.XORKeyStream(dst[i:i+n], src[i:i+n])
+						i += n
+					}
+					if !bytes.Equal(dst, expected) {
+						t.Fatalf("Backend %s: ChaCha%d: Size %d: Offset %d: Cipher.XORKeyStream differs from the reference", b, rounds, size, off)
+					}
+				}
+			}
+		}
+	})
+}
+
+func TestDifferentialXORKeyStreamAt(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	var key [32]byte
+	var nonce [12]byte
+	rng.Read(key[:])
+	rng.Read(nonce[:])
+	src := make([]byte, 4*1024+17)
+	rng.Read(src)
+
+	forEachBackend(t, func(t *testing.T, b Backend) {
+		for _, offset := range []uint64{0, 1, 63, 64, 100, 4095, 1<<38 - uint64(len(src))} {
+			// The reference keystream starts at the block of the offset.
+			skip := int(offset % 64)
+			expected := make([]byte, skip+len(src))
+			copy(expected[skip:], src)
+			reference.XORKeyStream(expected, expected, &nonce, &key, uint32(offset/64), 20)
+
+			dst := make([]byte, len(src))
+			XORKeyStreamAt(dst, src, &nonce, &key, offset, 20)
+			if !bytes.Equal(dst, expected[skip:]) {
+				t.Fatalf("Backend %s: Offset %d: XORKeyStreamAt differs from the reference", b, offset)
+			}
+		}
+	})
+}
+
+func TestDifferentialXORKeyStream64(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	var key [32]byte
+	var nonce [8]byte
+	rng.Read(key[:])
+	rng.Read(nonce[:])
+	src := make([]byte, 17*1024+192+17)
+	rng.Read(src)
+
+	forEachBackend(t, func(t *testing.T, b Backend) {
+		// The counters cross the overflow of the lower counter word.
+		for _, counter := range []uint64{0, 1<<32 - 1, 1<<32 - 3, 1<<33 - 100} {
+			for _, size := range []int{1, 64, 129, 1024 + 17, len(src)} {
+				expected, dst := make([]byte, size), make([]byte, size)
+				reference.XORKeyStream64(expected, src[:size], &nonce, &key, counter, 20)
+				XORKeyStream64(dst, src[:size], &nonce, &key, counter, 20)
+				if !bytes.Equal(dst, expected) {
+					t.Fatalf("Backend %s: Counter %d: Size %d: XORKeyStream64 differs from the reference", b, counter, size)
+				}
+
+				// SetCounter only sets the lower half of the counter.
+				c := NewCipher64(&nonce, &key, 20)
+				c.SetCounter(uint32(counter))
+				reference.XORKeyStream64(expected, src[:size], &nonce, &key, uint64(uint32(counter)), 20)
+				c.XORKeyStream(dst, src[:size])
+				if !bytes.Equal(dst, expected) {
+					t.Fatalf("Backend %s: Counter %d: Size %d: Cipher64 differs from the reference", b, counter, size)
+				}
+			}
+		}
+	})
+}
+
+func TestDifferentialBlock(t *testing.T) {
+	rng := rand.New(rand.NewSource(4))
+	forEachBackend(t, func(t *testing.T, b Backend) {
+		for i := 0; i < 64; i++ {
+			var (
+				key             [32]byte
+				nonce           [12]byte
+				hNonce          [16]byte
+				block, expected [64]byte
+				out, hExpected  [32]byte
+			)
+			rng.Read(key[:])
+			rng.Read(nonce[:])
+			rng.Read(hNonce[:])
+			counter := rng.Uint32()
+
+			reference.Block(&expected, &nonce, &key, counter, 20)
+			Block(&block, &nonce, &key, counter, 20)
+			if block != expected {
+				t.Fatalf("Backend %s: Block differs from the reference", b)
+			}
+
+			reference.HChaCha20(&hExpected, &hNonce, &key)
+			HChaCha20(&out, &hNonce, &key)
+			if out != hExpected {
+				t.Fatalf("Backend %s: HChaCha20 differs from the reference", b)
+			}
+		}
+	})
+}
+
+func TestDifferentialSource(t *testing.T) {
+	var key [32]byte
+	var nonce [8]byte
+	for i := range key {
+		key[i] = byte(i)
+	}
+	forEachBackend(t, func(t *testing.T, b Backend) {
+		expected := make([]byte, 8*1024)
+		reference.XORKeyStream64(expected, expected, &nonce, &key, 0, 8)
+
+		s := NewSource(&nonce, &key)
+		for i := 0; i < len(expected); i += 8 {
+			var buf [8]byte
+			v := s.Uint64()
+			for j := range buf {
+				buf[j] = byte(v >> (8 * uint(j)))
+			}
+			if !bytes.Equal(buf[:], expected[i:i+8]) {
+				t.Fatalf("Backend %s: Source differs from the reference at byte %d", b, i)
+			}
+		}
+	})
+}
@@ -0,0 +1,11 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+// +build amd64.v3,!amd64.v4,!gccgo,!appengine,!purego
+
+package chacha
+
+// GOAMD64=v3 guarantees AVX2 and SSSE3, so the AVX2 backend is
+// used without CPU feature detection.
+const staticBackend = AVX2
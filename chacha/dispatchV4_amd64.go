@@ -0,0 +1,11 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+// +build amd64.v4,!gccgo,!appengine,!purego
+
+package chacha
+
+// GOAMD64=v4 guarantees AVX512F and SSSE3, so the AVX512 backend is
+// used without CPU feature detection.
+const staticBackend = AVX512
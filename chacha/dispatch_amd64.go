@@ -0,0 +1,12 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+// +build amd64,!amd64.v3,!gccgo,!appengine,!purego
+
+package chacha
+
+// staticBackend is the backend selected at build time by GOAMD64.
+// It's Generic if the backend is selected at runtime by CPU feature
+// detection.
+const staticBackend = Generic
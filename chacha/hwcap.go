@@ -0,0 +1,43 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+// +build go1.25,riscv64,!gccgo,!appengine,!purego
+
+package chacha
+
+import (
+	"io/ioutil"
+	"runtime"
+	"unsafe"
+)
+
+// readHWCap returns the hwcap entry of the auxiliary vector of the process
+// which describes the hardware capabilities of the executing machine.
+// The auxiliary vector is only available on linux - on other systems
+// readHWCap returns 0.
+// It's used for features not detected by golang.org/x/sys/cpu. (e.g. RVV)
+func readHWCap() uint64 {
+	if runtime.GOOS != "linux" {
+		return 0
+	}
+	auxv, err := ioutil.ReadFile("/proc/self/auxv")
+	if err != nil {
+		return 0
+	}
+	const atHWCap = 16
+
+	size := int(unsafe.Sizeof(uintptr(0)))
+	word := func(b []byte) (v uint64) {
+		for i := size - 1; i >= 0; i-- {
+			v = v<<8 | uint64(b[i])
+		}
+		return
+	}
+	for i := 0; i+2*size <= len(auxv); i += 2 * size {
+		if word(auxv[i:]) == atHWCap {
+			return word(auxv[i+size:])
+		}
+	}
+	return 0
+}
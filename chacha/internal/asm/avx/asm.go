@@ -0,0 +1,192 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+// This program generates chachaAVX_amd64.s. Run it with go generate in the
+// chacha directory.
+package main
+
+import (
+	"github.com/aead/chacha20/chacha/internal/asm/gen"
+	. "github.com/mmcloughlin/avo/build"
+	. "github.com/mmcloughlin/avo/operand"
+	. "github.com/mmcloughlin/avo/reg"
+)
+
+var rol16, rol8 Mem
+
+func main() {
+	rol16 = GLOBL("rol16", NOPTR|RODATA)
+	DATA(0x00, U64(0x0504070601000302))
+	DATA(0x08, U64(0x0D0C0F0E09080B0A))
+
+	rol8 = GLOBL("rol8", NOPTR|RODATA)
+	DATA(0x00, U64(0x0605040702010003))
+	DATA(0x08, U64(0x0E0D0C0F0A09080B))
+
+	xorBlocksAVX()
+
+	gen.Generate("go1.7,amd64,!gccgo,!appengine,!purego")
+}
+
+// sp returns the memory operand off(SP) of the (hardware) stack pointer.
+func sp(off int) Mem { return Mem{Base: RSP, Disp: off} }
+
+func rotl(n uint64, t, v Op) {
+	VPSLLD(U8(n), v, t)
+	VPSRLD(U8(32-n), v, v)
+	VPXOR(t, v, v)
+}
+
+// quarterRound4 performs four quarter rounds on the transposed state
+// (every register holds one word of 4 blocks). The register d[0] is spilled
+// to the 16 byte aligned stack slot m while it is used as temp. register.
+func quarterRound4(a, b, c, d [4]Op, m Op) {
+	step := func(rol Mem, n uint64) {
+		for i := range a {
+			VPADDD(b[i], a[i], a[i])
+		}
+		for i := range a {
+			VPXOR(a[i], d[i], d[i])
+		}
+		for i := range d {
+			VPSHUFB(rol, d[i], d[i])
+		}
+		for i := range c {
+			VPADDD(d[i], c[i], c[i])
+		}
+		for i := range b {
+			VPXOR(c[i], b[i], b[i])
+		}
+		VMOVDQA(d[0], m)
+		for i := range b {
+			rotl(n, d[0], b[i])
+		}
+		VMOVDQA(m, d[0])
+	}
+	step(rol16, 12)
+	step(rol8, 7)
+}
+
+// transpose4 transposes the 4x4 matrix of 32 bit words in a, b, c and d.
+// Afterwards the rows are in c, d, a and b. (t0 and t1 are clobbered)
+func transpose4(a, b, c, d, t0, t1 Op) {
+	VPUNPCKLDQ(b, a, t0)
+	VPUNPCKHDQ(b, a, t1)
+	VPUNPCKLDQ(d, c, a)
+	VPUNPCKHDQ(d, c, b)
+	VPUNPCKLQDQ(a, t0, c)
+	VPUNPCKHQDQ(a, t0, d)
+	VPUNPCKLQDQ(b, t1, a)
+	VPUNPCKHQDQ(b, t1, b)
+}
+
+// splatRow stores the 4 words of v - each copied 4 times - at off(SP).
+func splatRow(v, t Op, off int) {
+	for i, k := range []uint64{0x00, 0x55, 0xAA, 0xFF} {
+		VPSHUFD(U8(k), v, t)
+		VMOVDQA(t, sp(off+16*i))
+	}
+}
+
+// storeCounter stores the 64 bit counter ctr+i of block i as the words
+// 12 and 13 of the splatted state.
+func storeCounter(i int, ctr Register, t GPPhysical) {
+	LEAQ(Mem{Base: ctr, Disp: i}, t)
+	MOVL(t.As32(), sp(224+4*i))
+	SHRQ(U8(32), t)
+	MOVL(t.As32(), sp(240+4*i))
+}
+
+// xor16x4 xors 16 bytes of 4 consecutive blocks at off(src) with v0 - v3
+// and writes the result to off(dst). dst and src may have any alignment.
+func xor16x4(dst, src Register, off int, v0, v1, v2, v3, t0 Op) {
+	for i, v := range []Op{v0, v1, v2, v3} {
+		VPXOR(Mem{Base: src, Disp: off + 64*i}, v, t0)
+		VMOVDQU(t0, Mem{Base: dst, Disp: off + 64*i})
+	}
+}
+
+func xorBlocksAVX() {
+	TEXT("xorBlocksAVX", NOSPLIT, "func(dst, src []byte, state *[64]byte, rounds int)")
+	Doc(
+		"xorBlocksAVX crypts len(src) - (len(src) mod 256) bytes from src to dst",
+		"using the state. It is the 4 block path of the SSSE3 implementation",
+		"using the VEX encoded 128 bit instructions. The three-operand forms save",
+		"the register copies of the rotations and the transposition, and the VEX",
+		"encoding avoids the SSE/AVX transition penalties on CPUs which execute",
+		"AVX code elsewhere in the process.",
+	)
+	Load(Param("state"), RAX)
+	Load(Param("dst").Base(), RBX)
+	Load(Param("src").Base(), RCX)
+	Load(Param("src").Len(), RDX)
+	Load(Param("rounds"), RDI)
+	Load(Param("dst").Len(), R8)
+
+	Comment(
+		"The stack holds a 32 byte spill slot and the splatted state (every",
+		"word of the state copied 4 times) at 32(SP).",
+	)
+	MOVQ(RSP, RSI)
+	ANDQ(I8(-16), RSP)
+	SUBQ(U32(288), RSP)
+	CMPQ(R8, RDX)
+	JB(LabelRef("DONE"))
+	CMPQ(RDX, U32(256))
+	JB(LabelRef("DONE"))
+
+	MOVQ(Mem{Base: RAX, Disp: 48}, R9)
+	for i := 0; i < 4; i++ {
+		VMOVDQU(Mem{Base: RAX, Disp: 16 * i}, X0)
+		splatRow(X0, X1, 32+64*i)
+	}
+	Label("BYTES_AT_LEAST_256")
+	for i := 0; i < 4; i++ {
+		storeCounter(i, R9, R10)
+	}
+	x := []Op{X0, X1, X2, X3, X4, X5, X6, X7, X8, X9, X10, X11, X12, X13, X14, X15}
+	for i, v := range x {
+		VMOVDQA(sp(32+16*i), v)
+	}
+	MOVQ(RDI, R8)
+	Label("CHACHA_LOOP_256")
+	quarterRound4(
+		[4]Op{X0, X1, X2, X3}, [4]Op{X4, X5, X6, X7},
+		[4]Op{X8, X9, X10, X11}, [4]Op{X12, X13, X14, X15}, sp(0))
+	quarterRound4(
+		[4]Op{X0, X1, X2, X3}, [4]Op{X5, X6, X7, X4},
+		[4]Op{X10, X11, X8, X9}, [4]Op{X15, X12, X13, X14}, sp(0))
+	SUBQ(U8(2), R8)
+	JA(LabelRef("CHACHA_LOOP_256"))
+	for i, v := range x {
+		VPADDD(sp(32+16*i), v, v)
+	}
+	VMOVDQA(X14, sp(0))
+	VMOVDQA(X15, sp(16))
+	transpose4(X0, X1, X2, X3, X14, X15)
+	xor16x4(RBX, RCX, 0, X2, X3, X0, X1, X14)
+	transpose4(X4, X5, X6, X7, X0, X1)
+	xor16x4(RBX, RCX, 16, X6, X7, X4, X5, X0)
+	transpose4(X8, X9, X10, X11, X0, X1)
+	xor16x4(RBX, RCX, 32, X10, X11, X8, X9, X0)
+	VMOVDQA(sp(0), X14)
+	VMOVDQA(sp(16), X15)
+	transpose4(X12, X13, X14, X15, X0, X1)
+	xor16x4(RBX, RCX, 48, X14, X15, X12, X13, X0)
+	ADDQ(U8(4), R9)
+	ADDQ(U32(256), RCX)
+	ADDQ(U32(256), RBX)
+	SUBQ(U32(256), RDX)
+	CMPQ(RDX, U32(256))
+	JAE(LabelRef("BYTES_AT_LEAST_256"))
+	MOVQ(R9, Mem{Base: RAX, Disp: 48})
+
+	Label("DONE")
+	VPXOR(X0, X0, X0)
+	for off := 0; off < 288; off += 16 {
+		VMOVDQA(X0, sp(off))
+	}
+	MOVQ(RSI, RSP)
+	RET()
+}
@@ -0,0 +1,379 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+// This program generates chachaAVX2_amd64.s. Run it with go generate in the
+// chacha directory.
+package main
+
+import (
+	"github.com/aead/chacha20/chacha/internal/asm/gen"
+	. "github.com/mmcloughlin/avo/build"
+	. "github.com/mmcloughlin/avo/operand"
+	. "github.com/mmcloughlin/avo/reg"
+)
+
+var rol16, rol8, inc1, inc2 Mem
+
+func main() {
+	rol16 = GLOBL("rol16", NOPTR|RODATA)
+	DATA(0x00, U64(0x0504070601000302))
+	DATA(0x08, U64(0x0D0C0F0E09080B0A))
+	DATA(0x10, U64(0x0504070601000302))
+	DATA(0x18, U64(0x0D0C0F0E09080B0A))
+
+	rol8 = GLOBL("rol8", NOPTR|RODATA)
+	DATA(0x00, U64(0x0605040702010003))
+	DATA(0x08, U64(0x0E0D0C0F0A09080B))
+	DATA(0x10, U64(0x0605040702010003))
+	DATA(0x18, U64(0x0E0D0C0F0A09080B))
+
+	inc1 = GLOBL("inc1", NOPTR|RODATA)
+	DATA(0x00, U64(0))
+	DATA(0x08, U64(0))
+	DATA(0x10, U64(1))
+	DATA(0x18, U64(0))
+
+	inc2 = GLOBL("inc2", NOPTR|RODATA)
+	DATA(0x00, U64(2))
+	DATA(0x08, U64(0))
+	DATA(0x10, U64(2))
+	DATA(0x18, U64(0))
+
+	xorBlocksAVX2()
+
+	gen.Generate("go1.7,amd64,!gccgo,!appengine,!purego")
+}
+
+// mem returns the memory operand off(base).
+func mem(base Register, off int) Mem { return Mem{Base: base, Disp: off} }
+
+func rotl(n uint64, v, t Op) {
+	VPSLLD(U8(n), v, t)
+	VPSRLD(U8(32-n), v, v)
+	VPXOR(v, t, v)
+}
+
+func rotlFast(c, v Op) {
+	VPSHUFB(c, v, v)
+}
+
+// broadcastI128 copies the 128 bit at src into both lanes of dst. If src
+// is a memory operand, it must contain at least 32 bytes.
+func broadcastI128(src, dst Op) {
+	VPERM2I128(U8(0x22), src, dst, dst)
+}
+
+func shuffle(a, b, c []Op) {
+	for _, v := range a {
+		VPSHUFD(U8(0x39), v, v)
+	}
+	for _, v := range b {
+		VPSHUFD(U8(0x4E), v, v)
+	}
+	for _, v := range c {
+		VPSHUFD(U8(0x93), v, v)
+	}
+}
+
+func halfRound128(v0, v1, v2, v3, t0 Op) {
+	VPADDD(v0, v1, v0)
+	VPXOR(v3, v0, v3)
+	rotlFast(rol16, v3)
+	VPADDD(v2, v3, v2)
+	VPXOR(v1, v2, v1)
+	rotl(12, v1, t0)
+	VPADDD(v0, v1, v0)
+	VPXOR(v3, v0, v3)
+	rotlFast(rol8, v3)
+	VPADDD(v2, v3, v2)
+	VPXOR(v1, v2, v1)
+	rotl(7, v1, t0)
+}
+
+func halfRound256(v0, v1, v2, v3, v4, v5, v6, v7, t0 Op) {
+	VPADDD(v0, v1, v0)
+	VPADDD(v4, v5, v4)
+	VPXOR(v3, v0, v3)
+	VPXOR(v7, v4, v7)
+	rotlFast(rol16, v3)
+	rotlFast(rol16, v7)
+	VPADDD(v2, v3, v2)
+	VPADDD(v6, v7, v6)
+	VPXOR(v1, v2, v1)
+	VPXOR(v5, v6, v5)
+	rotl(12, v1, t0)
+	rotl(12, v5, t0)
+	VPADDD(v0, v1, v0)
+	VPADDD(v4, v5, v4)
+	VPXOR(v3, v0, v3)
+	VPXOR(v7, v4, v7)
+	rotlFast(rol8, v3)
+	rotlFast(rol8, v7)
+	VPADDD(v2, v3, v2)
+	VPADDD(v6, v7, v6)
+	VPXOR(v1, v2, v1)
+	VPXOR(v5, v6, v5)
+	rotl(7, v1, t0)
+	rotl(7, v5, t0)
+}
+
+// xor128 xors the 128 bytes at off(src) - 2 blocks held in the lanes of
+// v0 - v3 - and writes the result to off(dst).
+func xor128(dst, src Register, off int, v0, v1, v2, v3, t0 Op) {
+	for i, p := range [][3]Op{{U8(32), v1, v0}, {U8(32), v3, v2}, {U8(49), v1, v0}, {U8(49), v3, v2}} {
+		VPERM2I128(p[0], p[1], p[2], t0)
+		VPXOR(mem(src, off+32*i), t0, t0)
+		VMOVDQU(t0, mem(dst, off+32*i))
+	}
+}
+
+// quarterRound8 performs four quarter rounds on the transposed state
+// (every register holds one word of 8 blocks). The register d[0] is spilled
+// to m while it is used as temp. register.
+func quarterRound8(a, b, c, d [4]Op, m Op) {
+	step := func(rol Mem, n uint64) {
+		for i := range a {
+			VPADDD(b[i], a[i], a[i])
+		}
+		for i := range a {
+			VPXOR(a[i], d[i], d[i])
+		}
+		for i := range d {
+			rotlFast(rol, d[i])
+		}
+		for i := range c {
+			VPADDD(d[i], c[i], c[i])
+		}
+		for i := range b {
+			VPXOR(c[i], b[i], b[i])
+		}
+		VMOVDQU(d[0], m)
+		for i := range b {
+			rotl(n, b[i], d[0])
+		}
+		VMOVDQU(m, d[0])
+	}
+	step(rol16, 12)
+	step(rol8, 7)
+}
+
+// transpose4 transposes the 4x4 matrices of 32 bit words in both 128 bit
+// lanes of a, b, c and d. Afterwards the rows are in c, d, a and b.
+func transpose4(a, b, c, d, t0, t1 Op) {
+	VPUNPCKLDQ(b, a, t0)
+	VPUNPCKHDQ(b, a, t1)
+	VPUNPCKLDQ(d, c, a)
+	VPUNPCKHDQ(d, c, b)
+	VPUNPCKLQDQ(a, t0, c)
+	VPUNPCKHQDQ(a, t0, d)
+	VPUNPCKLQDQ(b, t1, a)
+	VPUNPCKHQDQ(b, t1, b)
+}
+
+// xor32x2 xors 32 bytes of the blocks i and 4+i at off(src) with the
+// lower (v0) and upper (v1) halves and writes the result to off(dst).
+func xor32x2(dst, src Register, off int, v0, v1, t0 Op) {
+	VPERM2I128(U8(32), v1, v0, t0)
+	VPXOR(mem(src, off), t0, t0)
+	VMOVDQU(t0, mem(dst, off))
+	VPERM2I128(U8(49), v1, v0, t0)
+	VPXOR(mem(src, 256+off), t0, t0)
+	VMOVDQU(t0, mem(dst, 256+off))
+}
+
+// storeCounter stores the 64 bit counter ctr+i of block i as the words
+// 12 and 13 of the transposed state at 64(SP) and 96(SP).
+func storeCounter(stack Mem, i int, ctr Register, t GPPhysical) {
+	LEAQ(mem(ctr, i), t)
+	MOVL(t.As32(), stack.Offset(64+4*i))
+	SHRQ(U8(32), t)
+	MOVL(t.As32(), stack.Offset(96+4*i))
+}
+
+func xorBlocksAVX2() {
+	TEXT("xorBlocksAVX2", NOSPLIT, "func(dst, src []byte, state *[64]byte, rounds int)")
+	Doc("xorBlocksAVX2 crypts full block ( len(src) - (len(src) mod 64) bytes ) from src to", "dst using the state.")
+	stack := AllocLocal(128)
+	Load(Param("state"), RAX)
+	Load(Param("dst").Base(), RCX)
+	Load(Param("src").Base(), RBX)
+	Load(Param("src").Len(), RDX)
+	Load(Param("rounds"), R8)
+	Comment("DX = len(src) - (len(src) % 64)")
+	ANDQ(I8(-64), RDX)
+
+	Comment(
+		"The 8 block loop uses 64 bytes at 0(SP) as spill slots and stores the",
+		"counters of the blocks at 64(SP).",
+	)
+	CMPQ(RDX, U32(512))
+	JB(LabelRef("BYTES_LESS_THAN_512"))
+	MOVQ(mem(RAX, 48), R9)
+	Label("BYTES_AT_LEAST_512")
+	for i := 0; i < 8; i++ {
+		storeCounter(stack, i, R9, R11)
+	}
+	y := []Op{Y0, Y1, Y2, Y3, Y4, Y5, Y6, Y7, Y8, Y9, Y10, Y11, Y12, Y13, Y14, Y15}
+	for i, v := range y {
+		switch i {
+		case 12:
+			VMOVDQU(stack.Offset(64), v)
+		case 13:
+			VMOVDQU(stack.Offset(96), v)
+		default:
+			VPBROADCASTD(mem(RAX, 4*i), v)
+		}
+	}
+	MOVQ(R8, R10)
+	Label("CHACHA_LOOP_512")
+	quarterRound8(
+		[4]Op{Y0, Y1, Y2, Y3}, [4]Op{Y4, Y5, Y6, Y7},
+		[4]Op{Y8, Y9, Y10, Y11}, [4]Op{Y12, Y13, Y14, Y15}, stack)
+	quarterRound8(
+		[4]Op{Y0, Y1, Y2, Y3}, [4]Op{Y5, Y6, Y7, Y4},
+		[4]Op{Y10, Y11, Y8, Y9}, [4]Op{Y15, Y12, Y13, Y14}, stack)
+	SUBQ(U8(2), R10)
+	JA(LabelRef("CHACHA_LOOP_512"))
+	VMOVDQU(Y15, stack)
+	for i, v := range y {
+		switch i {
+		case 12:
+			VPADDD(stack.Offset(64), v, v)
+		case 13:
+			VPADDD(stack.Offset(96), v, v)
+		case 15:
+			VPBROADCASTD(mem(RAX, 4*i), Y15)
+			VPADDD(stack, Y15, Y15)
+		default:
+			VPBROADCASTD(mem(RAX, 4*i), Y15)
+			VPADDD(Y15, v, v)
+		}
+	}
+
+	VMOVDQU(Y14, stack)
+	VMOVDQU(Y15, stack.Offset(32))
+	transpose4(Y0, Y1, Y2, Y3, Y14, Y15)
+	transpose4(Y4, Y5, Y6, Y7, Y14, Y15)
+	xor32x2(RCX, RBX, 0, Y2, Y6, Y14)
+	xor32x2(RCX, RBX, 64, Y3, Y7, Y14)
+	xor32x2(RCX, RBX, 128, Y0, Y4, Y14)
+	xor32x2(RCX, RBX, 192, Y1, Y5, Y14)
+	VMOVDQU(stack, Y14)
+	VMOVDQU(stack.Offset(32), Y15)
+	transpose4(Y8, Y9, Y10, Y11, Y0, Y1)
+	transpose4(Y12, Y13, Y14, Y15, Y0, Y1)
+	xor32x2(RCX, RBX, 32, Y10, Y14, Y0)
+	xor32x2(RCX, RBX, 96, Y11, Y15, Y0)
+	xor32x2(RCX, RBX, 160, Y8, Y12, Y0)
+	xor32x2(RCX, RBX, 224, Y9, Y13, Y0)
+	ADDQ(U8(8), R9)
+	ADDQ(U32(512), RBX)
+	ADDQ(U32(512), RCX)
+	SUBQ(U32(512), RDX)
+	CMPQ(RDX, U32(512))
+	JAE(LabelRef("BYTES_AT_LEAST_512"))
+	MOVQ(R9, mem(RAX, 48))
+	VPXOR(Y0, Y0, Y0)
+	VMOVDQU(Y0, stack)
+	VMOVDQU(Y0, stack.Offset(32))
+
+	Label("BYTES_LESS_THAN_512")
+	VMOVDQU(inc1, Y0)
+	VMOVDQU(inc2, Y14)
+	broadcastI128(mem(RAX, 0), Y8)
+	broadcastI128(mem(RAX, 16), Y9)
+	broadcastI128(mem(RAX, 32), Y10)
+	Comment("48(AX) contains only 16 bytes, so the upper lane of 32(AX) is broadcasted.")
+	VPERM2I128(U8(0x33), mem(RAX, 32), Y11, Y11)
+	VPADDQ(Y0, Y11, Y11)
+	SUBQ(U32(256), RDX)
+	JCS(LabelRef("BYTES_BETWEEN_0_AND_255"))
+
+	Label("BYTES_AT_LEAST_256")
+	VMOVDQA(Y8, Y0)
+	VMOVDQA(Y9, Y1)
+	VMOVDQA(Y10, Y2)
+	VMOVDQA(Y11, Y3)
+	VMOVDQA(Y8, Y4)
+	VMOVDQA(Y9, Y5)
+	VMOVDQA(Y10, Y6)
+	VPADDQ(Y11, Y14, Y7)
+	MOVQ(R8, R9)
+	Label("CHACHA_LOOP_256")
+	halfRound256(Y0, Y1, Y2, Y3, Y4, Y5, Y6, Y7, Y12)
+	shuffle([]Op{Y1, Y5}, []Op{Y2, Y6}, []Op{Y3, Y7})
+	halfRound256(Y0, Y1, Y2, Y3, Y4, Y5, Y6, Y7, Y12)
+	shuffle([]Op{Y3, Y7}, []Op{Y2, Y6}, []Op{Y1, Y5})
+	SUBQ(U8(2), R9)
+	JA(LabelRef("CHACHA_LOOP_256"))
+	VPADDD(Y0, Y8, Y0)
+	VPADDD(Y1, Y9, Y1)
+	VPADDD(Y2, Y10, Y2)
+	VPADDD(Y3, Y11, Y3)
+	xor128(RCX, RBX, 0, Y0, Y1, Y2, Y3, Y12)
+	VPADDQ(Y11, Y14, Y11)
+	VPADDD(Y4, Y8, Y4)
+	VPADDD(Y5, Y9, Y5)
+	VPADDD(Y6, Y10, Y6)
+	VPADDD(Y7, Y11, Y7)
+	xor128(RCX, RBX, 128, Y4, Y5, Y6, Y7, Y12)
+	VPADDQ(Y11, Y14, Y11)
+	ADDQ(U32(256), RBX)
+	ADDQ(U32(256), RCX)
+	SUBQ(U32(256), RDX)
+	JCC(LabelRef("BYTES_AT_LEAST_256"))
+
+	Label("BYTES_BETWEEN_0_AND_255")
+	ADDQ(U32(256), RDX)
+	JEQ(LabelRef("WRITE_EVEN_64_BLOCKS"))
+
+	Label("BYTES_LESS_THAN_255")
+	VMOVDQA(Y8, Y0)
+	VMOVDQA(Y9, Y1)
+	VMOVDQA(Y10, Y2)
+	VMOVDQA(Y11, Y3)
+	MOVQ(R8, R9)
+	Label("CHACHA_LOOP_128")
+	halfRound128(Y0, Y1, Y2, Y3, Y12)
+	shuffle([]Op{Y1}, []Op{Y2}, []Op{Y3})
+	halfRound128(Y0, Y1, Y2, Y3, Y12)
+	shuffle([]Op{Y3}, []Op{Y2}, []Op{Y1})
+	SUBQ(U8(2), R9)
+	JA(LabelRef("CHACHA_LOOP_128"))
+	VPADDD(Y0, Y8, Y0)
+	VPADDD(Y1, Y9, Y1)
+	VPADDD(Y2, Y10, Y2)
+	VPADDD(Y3, Y11, Y3)
+
+	VPERM2I128(U8(32), Y1, Y0, Y12)
+	VPXOR(mem(RBX, 0), Y12, Y12)
+	VMOVDQU(Y12, mem(RCX, 0))
+	VPERM2I128(U8(32), Y3, Y2, Y12)
+	VPXOR(mem(RBX, 32), Y12, Y12)
+	VMOVDQU(Y12, mem(RCX, 32))
+	SUBQ(U8(64), RDX)
+	JEQ(LabelRef("WRITE_ODD_64_BLOCKS"))
+
+	VPADDQ(Y11, Y14, Y11)
+	VPERM2I128(U8(49), Y1, Y0, Y12)
+	VPXOR(mem(RBX, 64), Y12, Y12)
+	VMOVDQU(Y12, mem(RCX, 64))
+	VPERM2I128(U8(49), Y3, Y2, Y12)
+	VPXOR(mem(RBX, 96), Y12, Y12)
+	VMOVDQU(Y12, mem(RCX, 96))
+	SUBQ(U8(64), RDX)
+	JEQ(LabelRef("WRITE_EVEN_64_BLOCKS"))
+
+	ADDQ(U32(128), RBX)
+	ADDQ(U32(128), RCX)
+	JMP(LabelRef("BYTES_LESS_THAN_255"))
+
+	Label("WRITE_ODD_64_BLOCKS")
+	VPERM2I128(U8(1), Y11, Y11, Y11)
+
+	Label("WRITE_EVEN_64_BLOCKS")
+	VMOVDQU(X11, mem(RAX, 48))
+	VZEROUPPER()
+	RET()
+}
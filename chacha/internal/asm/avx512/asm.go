@@ -0,0 +1,177 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+// This program generates chachaAVX512_amd64.s. Run it with go generate in the
+// chacha directory.
+package main
+
+import (
+	"github.com/aead/chacha20/chacha/internal/asm/gen"
+	. "github.com/mmcloughlin/avo/build"
+	. "github.com/mmcloughlin/avo/operand"
+	. "github.com/mmcloughlin/avo/reg"
+)
+
+var iota16, one32 Mem
+
+func main() {
+	iota16 = GLOBL("iota16", NOPTR|RODATA)
+	for i := 0; i < 8; i++ {
+		DATA(8*i, U64(uint64(2*i+1)<<32|uint64(2*i)))
+	}
+
+	one32 = GLOBL("one32", NOPTR|RODATA)
+	DATA(0, U32(1))
+
+	xorBlocksAVX512()
+
+	gen.Generate("go1.11,amd64,!gccgo,!appengine,!purego")
+}
+
+// mem returns the memory operand off(base).
+func mem(base Register, off int) Mem { return Mem{Base: base, Disp: off} }
+
+// quarterRound4 performs four quarter rounds interleaved for better ILP.
+// Every ZMM register holds one state word of all 16 blocks.
+func quarterRound4(a, b, c, d [4]Op) {
+	// step computes x += y; z ^= x; z <<<= n
+	step := func(x, y, z [4]Op, n uint64) {
+		for i := range x {
+			VPADDD(y[i], x[i], x[i])
+		}
+		for i := range z {
+			VPXORD(x[i], z[i], z[i])
+		}
+		for i := range z {
+			VPROLD(U8(n), z[i], z[i])
+		}
+	}
+	step(a, b, d, 16)
+	step(c, d, b, 12)
+	step(a, b, d, 8)
+	step(c, d, b, 7)
+}
+
+// xorTransposed transposes the 128 bit lanes of a, b, c and d and xors the
+// resulting blocks (r, 4+r, 8+r, 12+r) with src and writes them to dst.
+func xorTransposed(r int, a, b, c, d Op) {
+	VSHUFI32X4(U8(0x88), b, a, Z16)
+	VSHUFI32X4(U8(0xDD), b, a, Z17)
+	VSHUFI32X4(U8(0x88), d, c, Z18)
+	VSHUFI32X4(U8(0xDD), d, c, Z19)
+	VSHUFI32X4(U8(0x88), Z18, Z16, Z20)
+	VSHUFI32X4(U8(0x88), Z19, Z17, Z21)
+	VSHUFI32X4(U8(0xDD), Z18, Z16, Z22)
+	VSHUFI32X4(U8(0xDD), Z19, Z17, Z23)
+	for i, v := range []Op{Z20, Z21, Z22, Z23} {
+		VPXORD(mem(RBX, 64*(4*i+r)), v, v)
+	}
+	for i, v := range []Op{Z20, Z21, Z22, Z23} {
+		VMOVDQU32(v, mem(RCX, 64*(4*i+r)))
+	}
+}
+
+func xorBlocksAVX512() {
+	TEXT("xorBlocksAVX512", NOSPLIT, "func(dst, src []byte, state *[64]byte, rounds int)")
+	Doc(
+		"xorBlocksAVX512 crypts len(src) - (len(src) mod 1024) bytes from src to dst",
+		"using the state. It computes 16 blocks in parallel. Every ZMM register",
+		"holds one state word of all 16 blocks (ZMM i, lane j = word i of block j).",
+		"Afterwards the 16x16 word matrix is transposed and xor'd with the src.",
+	)
+	Load(Param("state"), RAX)
+	Load(Param("dst").Base(), RCX)
+	Load(Param("src").Base(), RBX)
+	Load(Param("src").Len(), RDX)
+	Load(Param("rounds"), R8)
+	Comment("number of 16 block chunks")
+	SHRQ(U8(10), RDX)
+	JZ(LabelRef("DONE"))
+
+	Comment("64 bit block counter")
+	MOVQ(mem(RAX, 48), R9)
+	VMOVDQU32(iota16, Z30)
+	VPBROADCASTD(one32, Z31)
+
+	Label("LOOP")
+	Comment("load the state and compute the counters of the 16 blocks")
+	z := []VecPhysical{Z0, Z1, Z2, Z3, Z4, Z5, Z6, Z7, Z8, Z9, Z10, Z11, Z12, Z13, Z14, Z15}
+	for i := 0; i < 12; i++ {
+		VPBROADCASTD(mem(RAX, 4*i), z[i])
+	}
+	VPBROADCASTD(R9L, Z16)
+	VPADDD(Z30, Z16, Z12)
+	Comment("carry into the upper half of the counter")
+	VPCMPUD(U8(1), Z16, Z12, K1)
+	MOVQ(R9, R10)
+	SHRQ(U8(32), R10)
+	VPBROADCASTD(R10L, Z13)
+	VPADDD(Z31, Z13, K1, Z13)
+	VPBROADCASTD(mem(RAX, 56), Z14)
+	VPBROADCASTD(mem(RAX, 60), Z15)
+
+	VMOVDQA32(Z12, Z28)
+	VMOVDQA32(Z13, Z29)
+
+	MOVQ(R8, R11)
+	Label("CHACHA_LOOP")
+	quarterRound4([4]Op{Z0, Z1, Z2, Z3}, [4]Op{Z4, Z5, Z6, Z7}, [4]Op{Z8, Z9, Z10, Z11}, [4]Op{Z12, Z13, Z14, Z15})
+	quarterRound4([4]Op{Z0, Z1, Z2, Z3}, [4]Op{Z5, Z6, Z7, Z4}, [4]Op{Z10, Z11, Z8, Z9}, [4]Op{Z15, Z12, Z13, Z14})
+	SUBQ(U8(2), R11)
+	JA(LabelRef("CHACHA_LOOP"))
+
+	Comment("add the initial state")
+	t := []VecPhysical{Z16, Z17, Z18, Z19}
+	for i := 0; i < 12; i += 4 {
+		for j := range t {
+			VPBROADCASTD(mem(RAX, 4*(i+j)), t[j])
+		}
+		for j := range t {
+			VPADDD(t[j], z[i+j], z[i+j])
+		}
+	}
+	VPBROADCASTD(mem(RAX, 56), Z18)
+	VPBROADCASTD(mem(RAX, 60), Z19)
+	VPADDD(Z28, Z12, Z12)
+	VPADDD(Z29, Z13, Z13)
+	VPADDD(Z18, Z14, Z14)
+	VPADDD(Z19, Z15, Z15)
+
+	Comment("transpose the 32 bit words within the 128 bit lanes")
+	lo := []VecPhysical{Z16, Z18, Z20, Z22, Z24, Z26, Z28, Z0}
+	hi := []VecPhysical{Z17, Z19, Z21, Z23, Z25, Z27, Z29, Z1}
+	for i := range lo {
+		VPUNPCKLDQ(z[2*i+1], z[2*i], lo[i])
+		VPUNPCKHDQ(z[2*i+1], z[2*i], hi[i])
+	}
+	// The 64 bit unpacks combine the results into the row vectors of the
+	// 128 bit lanes. Z24 and Z25 are reused once their content is consumed.
+	for _, r := range [][4]VecPhysical{
+		{Z18, Z16, Z2, Z3}, {Z19, Z17, Z4, Z5},
+		{Z22, Z20, Z6, Z7}, {Z23, Z21, Z8, Z9},
+		{Z26, Z24, Z10, Z11}, {Z27, Z25, Z12, Z13},
+		{Z0, Z28, Z14, Z15}, {Z1, Z29, Z24, Z25},
+	} {
+		VPUNPCKLQDQ(r[0], r[1], r[2])
+		VPUNPCKHQDQ(r[0], r[1], r[3])
+	}
+
+	Comment("transpose the 128 bit lanes and xor the blocks")
+	xorTransposed(0, Z2, Z6, Z10, Z14)
+	xorTransposed(1, Z3, Z7, Z11, Z15)
+	xorTransposed(2, Z4, Z8, Z12, Z24)
+	xorTransposed(3, Z5, Z9, Z13, Z25)
+
+	ADDQ(U8(16), R9)
+	ADDQ(U32(1024), RBX)
+	ADDQ(U32(1024), RCX)
+	SUBQ(U8(1), RDX)
+	JNZ(LabelRef("LOOP"))
+
+	MOVQ(R9, mem(RAX, 48))
+	VZEROUPPER()
+
+	Label("DONE")
+	RET()
+}
@@ -0,0 +1,62 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+// Package gen contains the parts shared by the assembly generators.
+package gen
+
+import (
+	"bytes"
+	"flag"
+	"go/build/constraint"
+	"io/ioutil"
+	"log"
+
+	"github.com/mmcloughlin/avo/build"
+)
+
+const copyright = `// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+`
+
+// Generate generates the assembly file given by the -out flag. The file
+// starts with the copyright header and the build constraint expr - a
+// // +build expression - in both the //go:build and the // +build syntax,
+// since the package still supports Go versions without //go:build.
+func Generate(expr string) {
+	build.Generate()
+
+	out := flag.Lookup("out").Value.String()
+	if out == "" || out == "-" {
+		log.Fatal("gen: the -out flag must name a file")
+	}
+	x, err := constraint.Parse("// +build " + expr)
+	if err != nil {
+		log.Fatalf("gen: invalid build constraint %q: %v", expr, err)
+	}
+	plusBuild, err := constraint.PlusBuildLines(x)
+	if err != nil {
+		log.Fatalf("gen: invalid build constraint %q: %v", expr, err)
+	}
+	src, err := ioutil.ReadFile(out)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// avo writes the "Code generated" line first. The header goes before it
+	// and the build constraints after it.
+	i := bytes.IndexByte(src, '\n') + 1
+	var buf bytes.Buffer
+	buf.WriteString(copyright)
+	buf.Write(src[:i])
+	buf.WriteString("\n//go:build " + x.String() + "\n")
+	for _, line := range plusBuild {
+		buf.WriteString(line + "\n")
+	}
+	buf.Write(src[i:])
+	if err = ioutil.WriteFile(out, buf.Bytes(), 0644); err != nil {
+		log.Fatal(err)
+	}
+}
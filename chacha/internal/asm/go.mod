@@ -0,0 +1,11 @@
+module github.com/aead/chacha20/chacha/internal/asm
+
+go 1.22.0
+
+require github.com/mmcloughlin/avo v0.6.0
+
+require (
+	golang.org/x/mod v0.22.0 // indirect
+	golang.org/x/sync v0.10.0 // indirect
+	golang.org/x/tools v0.28.0 // indirect
+)
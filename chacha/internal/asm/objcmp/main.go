@@ -0,0 +1,134 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+// This program checks that assembly files assemble to the same object code
+// as their version at a git revision. It is used to verify that generated
+// assembly matches the hand-written code it replaced:
+//
+//	go run ./objcmp -rev <revision> ../../chachaSSE_amd64.s ...
+//
+// Only the machine code, the relocations and the data symbols are compared,
+// not the source positions of the instructions.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const pkgPath = "github.com/aead/chacha20/chacha"
+
+// position matches the listing lines of the instructions, which contain
+// the source line numbers.
+var position = regexp.MustCompile(`^\t0x[0-9a-f]{4} \d{5} \(`)
+
+func main() {
+	rev := flag.String("rev", "", "the git revision to compare against")
+	flag.Parse()
+	if *rev == "" || flag.NArg() == 0 {
+		log.Fatal("usage: objcmp -rev <revision> file.s ...")
+	}
+
+	tmp, err := ioutil.TempDir("", "objcmp")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+
+	failed := false
+	for _, file := range flag.Args() {
+		old, err := exec.Command("git", "show", *rev+":./"+filepath.ToSlash(file)).Output()
+		if err != nil {
+			log.Fatalf("%s: git show: %v", file, err)
+		}
+		cur, err := ioutil.ReadFile(file)
+		if err != nil {
+			log.Fatal(err)
+		}
+		want, err := listing(tmp, filepath.Base(file), old)
+		if err != nil {
+			log.Fatalf("%s@%s: %v", file, *rev, err)
+		}
+		got, err := listing(tmp, filepath.Base(file), cur)
+		if err != nil {
+			log.Fatalf("%s: %v", file, err)
+		}
+		if !equal(file, got, want) {
+			failed = true
+		}
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
+
+// listing assembles src for linux/amd64 and returns the symbols of the
+// listing - each with its position independent lines - sorted by name.
+func listing(dir, name string, src []byte) ([]string, error) {
+	goroot, err := exec.Command("go", "env", "GOROOT").Output()
+	if err != nil {
+		return nil, err
+	}
+	file := filepath.Join(dir, name)
+	if err = ioutil.WriteFile(file, src, 0644); err != nil {
+		return nil, err
+	}
+	include := filepath.Join(strings.TrimSpace(string(goroot)), "pkg", "include")
+	cmd := exec.Command("go", "tool", "asm", "-p", pkgPath, "-I", include,
+		"-D", "GOOS_linux", "-D", "GOARCH_amd64", "-S", "-o", file+".o", file)
+	cmd.Env = append(os.Environ(), "GOOS=linux", "GOARCH=amd64")
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("%v\n%s", err, stderr.Bytes())
+	}
+
+	var symbols []string
+	for _, line := range strings.SplitAfter(string(out), "\n") {
+		switch {
+		case line == "" || position.MatchString(line):
+		case !strings.HasPrefix(line, "\t") || len(symbols) == 0:
+			symbols = append(symbols, line)
+		default:
+			symbols[len(symbols)-1] += line
+		}
+	}
+	sort.Strings(symbols)
+	return symbols, nil
+}
+
+// equal reports whether got and want contain the same symbols and prints
+// the symbols which differ.
+func equal(file string, got, want []string) bool {
+	name := func(s string) string { return strings.Fields(s)[0] }
+	have := make(map[string]string, len(got))
+	for _, s := range got {
+		have[name(s)] = s
+	}
+	ok := len(got) == len(want)
+	for _, s := range want {
+		if g, found := have[name(s)]; !found {
+			fmt.Fprintf(os.Stderr, "%s: symbol %s is missing\n", file, name(s))
+			ok = false
+		} else if g != s {
+			fmt.Fprintf(os.Stderr, "%s: object code of %s differs\n", file, name(s))
+			ok = false
+		}
+		delete(have, name(s))
+	}
+	for n := range have {
+		fmt.Fprintf(os.Stderr, "%s: unexpected symbol %s\n", file, n)
+	}
+	return ok
+}
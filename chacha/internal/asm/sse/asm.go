@@ -0,0 +1,614 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+// This program generates chachaSSE_amd64.s. Run it with go generate in the
+// chacha directory.
+package main
+
+import (
+	"github.com/aead/chacha20/chacha/internal/asm/gen"
+	. "github.com/mmcloughlin/avo/build"
+	"github.com/mmcloughlin/avo/gotypes"
+	. "github.com/mmcloughlin/avo/operand"
+	. "github.com/mmcloughlin/avo/reg"
+)
+
+var constants, one, rol16, rol8 Mem
+
+func main() {
+	constants = GLOBL("constants", NOPTR|RODATA)
+	DATA(0x00, U32(0x61707865))
+	DATA(0x04, U32(0x3320646e))
+	DATA(0x08, U32(0x79622d32))
+	DATA(0x0c, U32(0x6b206574))
+
+	one = GLOBL("one", NOPTR|RODATA)
+	DATA(0x00, U64(1))
+	DATA(0x08, U64(0))
+
+	rol16 = GLOBL("rol16", NOPTR|RODATA)
+	DATA(0x00, U64(0x0504070601000302))
+	DATA(0x08, U64(0x0D0C0F0E09080B0A))
+
+	rol8 = GLOBL("rol8", NOPTR|RODATA)
+	DATA(0x00, U64(0x0605040702010003))
+	DATA(0x08, U64(0x0E0D0C0F0A09080B))
+
+	core("coreSSE2", halfRound64SSE2)
+	core("coreSSSE3", halfRound64SSSE3)
+	xorBlocksSSE2()
+	xorBlocksSSSE3()
+	setState()
+
+	gen.Generate("amd64,!gccgo,!appengine,!purego")
+}
+
+// sp returns the memory operand off(SP) of the (hardware) stack pointer.
+func sp(off int) Mem { return Mem{Base: RSP, Disp: off} }
+
+// param returns the memory operand of the (sub-)parameter c.
+func param(c gotypes.Component) Mem {
+	b, err := c.Resolve()
+	if err != nil {
+		panic(err)
+	}
+	return b.Addr
+}
+
+// *** The rotate functions ***
+
+func rotlSSE2(n uint64, t, v Op) {
+	MOVO(v, t)
+	PSLLL(U8(n), t)
+	PSRLL(U8(32-n), v)
+	PXOR(t, v)
+}
+
+func rotlSSSE3(c, v Op) {
+	PSHUFB(c, v)
+}
+
+// *** The shuffle functions ***
+
+func shuffle(k0, k1, k2 uint64, a, b, c []Op) {
+	for _, v := range a {
+		PSHUFL(U8(k0), v, v)
+	}
+	for _, v := range b {
+		PSHUFL(U8(k1), v, v)
+	}
+	for _, v := range c {
+		PSHUFL(U8(k2), v, v)
+	}
+}
+
+// *** The chacha round functions ***
+
+func halfRound64SSE2(v0, v1, v2, v3, t0 Op) {
+	PADDL(v1, v0)
+	PXOR(v0, v3)
+	rotlSSE2(16, t0, v3)
+	PADDL(v3, v2)
+	PXOR(v2, v1)
+	rotlSSE2(12, t0, v1)
+	PADDL(v1, v0)
+	PXOR(v0, v3)
+	rotlSSE2(8, t0, v3)
+	PADDL(v3, v2)
+	PXOR(v2, v1)
+	rotlSSE2(7, t0, v1)
+}
+
+func halfRound64SSSE3(v0, v1, v2, v3, t0 Op) {
+	PADDL(v1, v0)
+	PXOR(v0, v3)
+	rotlSSSE3(rol16, v3)
+	PADDL(v3, v2)
+	PXOR(v2, v1)
+	rotlSSE2(12, t0, v1)
+	PADDL(v1, v0)
+	PXOR(v0, v3)
+	rotlSSSE3(rol8, v3)
+	PADDL(v3, v2)
+	PXOR(v2, v1)
+	rotlSSE2(7, t0, v1)
+}
+
+func halfRound128SSE2(v0, v1, v2, v3, v4, v5, v6, v7, t0 Op) {
+	PADDL(v1, v0)
+	PADDL(v5, v4)
+	PXOR(v0, v3)
+	PXOR(v4, v7)
+	rotlSSE2(16, t0, v3)
+	rotlSSE2(16, t0, v7)
+	PADDL(v3, v2)
+	PADDL(v7, v6)
+	PXOR(v2, v1)
+	PXOR(v6, v5)
+	rotlSSE2(12, t0, v1)
+	rotlSSE2(12, t0, v5)
+	PADDL(v1, v0)
+	PADDL(v5, v4)
+	PXOR(v0, v3)
+	PXOR(v4, v7)
+	rotlSSE2(8, t0, v3)
+	rotlSSE2(8, t0, v7)
+	PADDL(v3, v2)
+	PADDL(v7, v6)
+	PXOR(v2, v1)
+	PXOR(v6, v5)
+	rotlSSE2(7, t0, v1)
+	rotlSSE2(7, t0, v5)
+}
+
+func halfRound128SSSE3(v0, v1, v2, v3, v4, v5, v6, v7, t0 Op) {
+	PADDL(v1, v0)
+	PADDL(v5, v4)
+	PXOR(v0, v3)
+	PXOR(v4, v7)
+	rotlSSSE3(rol16, v3)
+	rotlSSSE3(rol16, v7)
+	PADDL(v3, v2)
+	PADDL(v7, v6)
+	PXOR(v2, v1)
+	PXOR(v6, v5)
+	rotlSSE2(12, t0, v1)
+	rotlSSE2(12, t0, v5)
+	PADDL(v1, v0)
+	PADDL(v5, v4)
+	PXOR(v0, v3)
+	PXOR(v4, v7)
+	rotlSSSE3(rol8, v3)
+	rotlSSSE3(rol8, v7)
+	PADDL(v3, v2)
+	PADDL(v7, v6)
+	PXOR(v2, v1)
+	PXOR(v6, v5)
+	rotlSSE2(7, t0, v1)
+	rotlSSE2(7, t0, v5)
+}
+
+// halfRound256SSE2 performs a half round on 4 blocks. The a, b, c and d
+// rows hold one row of each block. The fourth a row is spilled to the
+// 16 byte aligned stack slot t0 while it is used as temp. register.
+func halfRound256SSE2(a, b, c, d [4]Op, t0 Op) {
+	t := a[3]
+	step := func(n0, n1 uint64) {
+		for i := range a {
+			PADDL(b[i], a[i])
+		}
+		for i := range a {
+			PXOR(a[i], d[i])
+		}
+		MOVO(t, t0)
+		for i := range d {
+			rotlSSE2(n0, t, d[i])
+		}
+		for i := range c {
+			PADDL(d[i], c[i])
+		}
+		for i := range b {
+			PXOR(c[i], b[i])
+		}
+		for i := range b {
+			rotlSSE2(n1, t, b[i])
+		}
+		MOVO(t0, t)
+	}
+	step(16, 12)
+	step(8, 7)
+}
+
+// *** The 4 block (transposed) functions ***
+
+// quarterRound4SSSE3 performs four quarter rounds on the transposed state
+// (every register holds one word of 4 blocks). The register d[0] is spilled
+// to the 16 byte aligned stack slot m while it is used as temp. register.
+func quarterRound4SSSE3(a, b, c, d [4]Op, m Op) {
+	step := func(rol Mem, n uint64) {
+		for i := range a {
+			PADDL(b[i], a[i])
+		}
+		for i := range a {
+			PXOR(a[i], d[i])
+		}
+		for i := range d {
+			rotlSSSE3(rol, d[i])
+		}
+		for i := range c {
+			PADDL(d[i], c[i])
+		}
+		for i := range b {
+			PXOR(c[i], b[i])
+		}
+		MOVO(d[0], m)
+		for i := range b {
+			rotlSSE2(n, d[0], b[i])
+		}
+		MOVO(m, d[0])
+	}
+	step(rol16, 12)
+	step(rol8, 7)
+}
+
+// transpose4 transposes the 4x4 matrix of 32 bit words in a, b, c and d.
+// Afterwards the rows are in a, b, t0 and c. (d and t1 are clobbered)
+func transpose4(a, b, c, d, t0, t1 Op) {
+	MOVO(a, t0)
+	PUNPCKLLQ(b, a)
+	PUNPCKHLQ(b, t0)
+	MOVO(c, t1)
+	PUNPCKLLQ(d, c)
+	PUNPCKHLQ(d, t1)
+	MOVO(a, b)
+	PUNPCKLQDQ(c, a)
+	PUNPCKHQDQ(c, b)
+	MOVO(t0, c)
+	PUNPCKLQDQ(t1, t0)
+	PUNPCKHQDQ(t1, c)
+}
+
+// splatRow stores the 4 words of v - each copied 4 times - at off(SP).
+func splatRow(v, t Op, off int) {
+	for i, k := range []uint64{0x00, 0x55, 0xAA, 0xFF} {
+		PSHUFD(U8(k), v, t)
+		MOVO(t, sp(off+16*i))
+	}
+}
+
+// storeCounter stores the 64 bit counter ctr+i of block i as the words
+// 12 and 13 of the splatted state.
+func storeCounter(i int, ctr Register, t GPPhysical) {
+	LEAQ(Mem{Base: ctr, Disp: i}, t)
+	MOVL(t.As32(), sp(224+4*i))
+	SHRQ(U8(32), t)
+	MOVL(t.As32(), sp(240+4*i))
+}
+
+// *** The xor functions ***
+
+// dst, src and the state are only accessed using unaligned moves (MOVOU),
+// so they may have any alignment. Aligned memory operands are only used
+// for the 16 byte constants and the aligned stack.
+
+// xor64 xors the 64 bytes at off(src) with v0 - v3 and writes the result
+// to off(dst).
+func xor64(dst, src Register, off int, v0, v1, v2, v3, t0 Op) {
+	for i, v := range []Op{v0, v1, v2, v3} {
+		MOVOU(Mem{Base: src, Disp: off + 16*i}, t0)
+		PXOR(v, t0)
+		MOVOU(t0, Mem{Base: dst, Disp: off + 16*i})
+	}
+}
+
+// xor16x4 xors 16 bytes of 4 consecutive blocks at off(src) with v0 - v3
+// and writes the result to off(dst).
+func xor16x4(dst, src Register, off int, v0, v1, v2, v3, t0 Op) {
+	for i, v := range []Op{v0, v1, v2, v3} {
+		MOVOU(Mem{Base: src, Disp: off + 64*i}, t0)
+		PXOR(v, t0)
+		MOVOU(t0, Mem{Base: dst, Disp: off + 64*i})
+	}
+}
+
+// *** Function implementations ***
+
+// core generates the functions coreSSE2 and coreSSSE3.
+func core(name string, halfRound func(v0, v1, v2, v3, t0 Op)) {
+	TEXT(name, NOSPLIT, "func(dst *[64]byte, state *[64]byte, rounds int)")
+	Doc(name + " generates 64 byte keystream from the given state performing 'rounds' rounds")
+	Load(Param("state"), RAX)
+	Load(Param("dst"), RBX)
+	Load(Param("rounds"), RCX)
+	loadState(RAX)
+	MOVO(X0, X4)
+	MOVO(X1, X5)
+	MOVO(X2, X6)
+	MOVO(X3, X7)
+	Label("loop")
+	halfRound(X4, X5, X6, X7, X8)
+	shuffle(0x39, 0x4E, 0x93, []Op{X5}, []Op{X6}, []Op{X7})
+	halfRound(X4, X5, X6, X7, X8)
+	shuffle(0x93, 0x4E, 0x39, []Op{X5}, []Op{X6}, []Op{X7})
+	SUBQ(U8(2), RCX)
+	JA(LabelRef("loop"))
+	PADDL(X0, X4)
+	PADDL(X1, X5)
+	PADDL(X2, X6)
+	PADDL(X3, X7)
+	MOVOU(X4, Mem{Base: RBX})
+	MOVOU(X5, Mem{Base: RBX, Disp: 16})
+	MOVOU(X6, Mem{Base: RBX, Disp: 32})
+	MOVOU(X7, Mem{Base: RBX, Disp: 48})
+	PADDQ(one, X3)
+	MOVOU(X3, Mem{Base: RAX, Disp: 48})
+	RET()
+}
+
+// loadState loads the 4 rows of the state at (state) into X0 - X3.
+func loadState(state Register) {
+	MOVOU(Mem{Base: state}, X0)
+	MOVOU(Mem{Base: state, Disp: 16}, X1)
+	MOVOU(Mem{Base: state, Disp: 32}, X2)
+	MOVOU(Mem{Base: state, Disp: 48}, X3)
+}
+
+// loadParams loads the parameters of the xorBlocks functions.
+func loadParams() {
+	Load(Param("state"), RAX)
+	Load(Param("dst").Base(), RBX)
+	Load(Param("src").Base(), RCX)
+	Load(Param("src").Len(), RDX)
+	Load(Param("rounds"), RDI)
+}
+
+// xorBlocks128 crypts 128 bytes (if at least 128 bytes are left) and
+// then 64 bytes (if at least 64 bytes are left) from CX to BX. This is
+// the common tail of xorBlocksSSE2 and xorBlocksSSSE3.
+func xorBlocks128(halfRound128 func(v0, v1, v2, v3, v4, v5, v6, v7, t0 Op), halfRound64 func(v0, v1, v2, v3, t0 Op)) {
+	Label("BYTES_BETWEEN_0_AND_255")
+	CMPQ(RDX, U8(0))
+	JE(LabelRef("DONE"))
+	CMPQ(RDX, U32(128))
+	JB(LabelRef("BYTES_BETWEEN_0_AND_127"))
+	MOVQ(one, X15)
+	loadState(RAX)
+	MOVO(X0, X4)
+	MOVO(X1, X5)
+	MOVO(X2, X6)
+	MOVO(X3, X7)
+	MOVO(X0, X8)
+	MOVO(X1, X9)
+	MOVO(X2, X10)
+	MOVO(X3, X11)
+	PADDQ(X15, X11)
+	MOVQ(RDI, R8)
+	Label("CHACHA_LOOP_128")
+	halfRound128(X4, X5, X6, X7, X8, X9, X10, X11, X12)
+	shuffle(0x39, 0x4E, 0x93, []Op{X5, X9}, []Op{X6, X10}, []Op{X7, X11})
+	halfRound128(X4, X5, X6, X7, X8, X9, X10, X11, X12)
+	shuffle(0x93, 0x4E, 0x39, []Op{X5, X9}, []Op{X6, X10}, []Op{X7, X11})
+	SUBQ(U8(2), R8)
+	JA(LabelRef("CHACHA_LOOP_128"))
+	PADDL(X0, X4)
+	PADDL(X1, X5)
+	PADDL(X2, X6)
+	PADDL(X3, X7)
+	xor64(RBX, RCX, 0, X4, X5, X6, X7, X12)
+	PADDQ(X15, X3)
+	PADDL(X0, X8)
+	PADDL(X1, X9)
+	PADDL(X2, X10)
+	PADDL(X3, X11)
+	xor64(RBX, RCX, 64, X8, X9, X10, X11, X12)
+	PADDQ(X15, X3)
+	MOVOU(X3, Mem{Base: RAX, Disp: 48})
+	ADDQ(U32(128), RCX)
+	ADDQ(U32(128), RBX)
+	SUBQ(U32(128), RDX)
+
+	Label("BYTES_BETWEEN_0_AND_127")
+	CMPQ(RDX, U8(64))
+	JB(LabelRef("DONE"))
+	MOVQ(one, X15)
+	loadState(RAX)
+	MOVO(X0, X4)
+	MOVO(X1, X5)
+	MOVO(X2, X6)
+	MOVO(X3, X7)
+	MOVQ(RDI, R8)
+	Label("CHACHA_LOOP_64")
+	halfRound64(X4, X5, X6, X7, X8)
+	shuffle(0x39, 0x4E, 0x93, []Op{X5}, []Op{X6}, []Op{X7})
+	halfRound64(X4, X5, X6, X7, X8)
+	shuffle(0x93, 0x4E, 0x39, []Op{X5}, []Op{X6}, []Op{X7})
+	SUBQ(U8(2), R8)
+	JA(LabelRef("CHACHA_LOOP_64"))
+	PADDL(X0, X4)
+	PADDL(X1, X5)
+	PADDL(X2, X6)
+	PADDL(X3, X7)
+	xor64(RBX, RCX, 0, X4, X5, X6, X7, X8)
+	PADDQ(X15, X3)
+	MOVOU(X3, Mem{Base: RAX, Disp: 48})
+}
+
+func xorBlocksSSE2() {
+	TEXT("xorBlocksSSE2", NOSPLIT, "func(dst, src []byte, state *[64]byte, rounds int)")
+	Doc("xorBlocksSSE2 crypts full block ( len(src) - (len(src) mod 64) bytes ) from src to", "dst using the state.")
+	loadParams()
+	CMPQ(param(Param("dst").Len()), RDX)
+	JB(LabelRef("DONE"))
+
+	Comment("Align the stack to 16 bytes and reserve a 16 byte spill slot.")
+	MOVQ(RSP, RSI)
+	ANDQ(I8(-16), RSP)
+	SUBQ(U8(16), RSP)
+
+	CMPQ(RDX, U32(256))
+	JB(LabelRef("BYTES_BETWEEN_0_AND_255"))
+	Label("BYTES_AT_LEAST_256")
+	loadState(RAX)
+	MOVO(X0, X4)
+	MOVO(X1, X5)
+	MOVO(X2, X6)
+	MOVO(X3, X7)
+	PADDQ(one, X7)
+	MOVO(X0, X8)
+	MOVO(X1, X9)
+	MOVO(X2, X10)
+	MOVO(X7, X11)
+	PADDQ(one, X11)
+	MOVO(X0, X12)
+	MOVO(X1, X13)
+	MOVO(X2, X14)
+	MOVO(X11, X15)
+	PADDQ(one, X15)
+	MOVQ(RDI, R8)
+	Label("CHACHA_LOOP_256")
+	a := [4]Op{X0, X4, X8, X12}
+	b := [4]Op{X1, X5, X9, X13}
+	c := [4]Op{X2, X6, X10, X14}
+	d := [4]Op{X3, X7, X11, X15}
+	halfRound256SSE2(a, b, c, d, sp(0))
+	shuffle(0x39, 0x4E, 0x93, b[:], c[:], d[:])
+	halfRound256SSE2(a, b, c, d, sp(0))
+	shuffle(0x93, 0x4E, 0x39, b[:], c[:], d[:])
+	SUBQ(U8(2), R8)
+	JA(LabelRef("CHACHA_LOOP_256"))
+	MOVO(X12, sp(0))
+	MOVOU(Mem{Base: RAX}, X12)
+	PADDL(X12, X0)
+	MOVOU(Mem{Base: RAX, Disp: 16}, X12)
+	PADDL(X12, X1)
+	MOVOU(Mem{Base: RAX, Disp: 32}, X12)
+	PADDL(X12, X2)
+	MOVOU(Mem{Base: RAX, Disp: 48}, X12)
+	PADDL(X12, X3)
+	xor64(RBX, RCX, 0, X0, X1, X2, X3, X12)
+	MOVOU(Mem{Base: RAX, Disp: 48}, X3)
+	PADDQ(one, X3)
+	MOVOU(Mem{Base: RAX}, X12)
+	PADDL(X12, X4)
+	MOVOU(Mem{Base: RAX, Disp: 16}, X12)
+	PADDL(X12, X5)
+	MOVOU(Mem{Base: RAX, Disp: 32}, X12)
+	PADDL(X12, X6)
+	PADDL(X3, X7)
+	xor64(RBX, RCX, 64, X4, X5, X6, X7, X12)
+	PADDQ(one, X3)
+	MOVOU(Mem{Base: RAX}, X12)
+	PADDL(X12, X8)
+	MOVOU(Mem{Base: RAX, Disp: 16}, X12)
+	PADDL(X12, X9)
+	MOVOU(Mem{Base: RAX, Disp: 32}, X12)
+	PADDL(X12, X10)
+	PADDL(X3, X11)
+	xor64(RBX, RCX, 128, X8, X9, X10, X11, X12)
+	PADDQ(one, X3)
+	MOVO(sp(0), X12)
+	MOVOU(Mem{Base: RAX}, X0)
+	PADDL(X0, X12)
+	MOVOU(Mem{Base: RAX, Disp: 16}, X0)
+	PADDL(X0, X13)
+	MOVOU(Mem{Base: RAX, Disp: 32}, X0)
+	PADDL(X0, X14)
+	PADDL(X3, X15)
+	xor64(RBX, RCX, 192, X12, X13, X14, X15, X0)
+	PADDQ(one, X3)
+	MOVOU(X3, Mem{Base: RAX, Disp: 48})
+	ADDQ(U32(256), RCX)
+	ADDQ(U32(256), RBX)
+	SUBQ(U32(256), RDX)
+	CMPQ(RDX, U32(256))
+	JAE(LabelRef("BYTES_AT_LEAST_256"))
+
+	xorBlocks128(halfRound128SSE2, halfRound64SSE2)
+
+	Label("DONE")
+	PXOR(X0, X0)
+	MOVO(X0, sp(0))
+	MOVQ(RSI, RSP)
+	RET()
+}
+
+func xorBlocksSSSE3() {
+	TEXT("xorBlocksSSSE3", NOSPLIT, "func(dst, src []byte, state *[64]byte, rounds int)")
+	Doc("xorBlocksSSSE3 crypts full block ( len(src) - (len(src) mod 64) bytes ) from src to", "dst using the state.")
+	loadParams()
+	Load(Param("dst").Len(), R8)
+
+	Comment(
+		"The stack holds a 32 byte spill slot and the splatted state (every",
+		"word of the state copied 4 times) at 32(SP).",
+	)
+	MOVQ(RSP, RSI)
+	ANDQ(I8(-16), RSP)
+	SUBQ(U32(288), RSP)
+	CMPQ(R8, RDX)
+	JB(LabelRef("DONE"))
+
+	CMPQ(RDX, U32(256))
+	JB(LabelRef("BYTES_BETWEEN_0_AND_255"))
+	MOVQ(Mem{Base: RAX, Disp: 48}, R9)
+	for i := 0; i < 4; i++ {
+		MOVOU(Mem{Base: RAX, Disp: 16 * i}, X0)
+		splatRow(X0, X1, 32+64*i)
+	}
+	Label("BYTES_AT_LEAST_256")
+	for i := 0; i < 4; i++ {
+		storeCounter(i, R9, R10)
+	}
+	x := []Op{X0, X1, X2, X3, X4, X5, X6, X7, X8, X9, X10, X11, X12, X13, X14, X15}
+	for i, v := range x {
+		MOVO(sp(32+16*i), v)
+	}
+	MOVQ(RDI, R8)
+	Label("CHACHA_LOOP_256")
+	quarterRound4SSSE3(
+		[4]Op{X0, X1, X2, X3}, [4]Op{X4, X5, X6, X7},
+		[4]Op{X8, X9, X10, X11}, [4]Op{X12, X13, X14, X15}, sp(0))
+	quarterRound4SSSE3(
+		[4]Op{X0, X1, X2, X3}, [4]Op{X5, X6, X7, X4},
+		[4]Op{X10, X11, X8, X9}, [4]Op{X15, X12, X13, X14}, sp(0))
+	SUBQ(U8(2), R8)
+	JA(LabelRef("CHACHA_LOOP_256"))
+	for i, v := range x {
+		PADDL(sp(32+16*i), v)
+	}
+	MOVO(X14, sp(0))
+	MOVO(X15, sp(16))
+	transpose4(X0, X1, X2, X3, X14, X15)
+	xor16x4(RBX, RCX, 0, X0, X1, X14, X2, X15)
+	transpose4(X4, X5, X6, X7, X0, X1)
+	xor16x4(RBX, RCX, 16, X4, X5, X0, X6, X1)
+	transpose4(X8, X9, X10, X11, X0, X1)
+	xor16x4(RBX, RCX, 32, X8, X9, X0, X10, X1)
+	MOVO(sp(0), X14)
+	MOVO(sp(16), X15)
+	transpose4(X12, X13, X14, X15, X0, X1)
+	xor16x4(RBX, RCX, 48, X12, X13, X0, X14, X1)
+	ADDQ(U8(4), R9)
+	ADDQ(U32(256), RCX)
+	ADDQ(U32(256), RBX)
+	SUBQ(U32(256), RDX)
+	CMPQ(RDX, U32(256))
+	JAE(LabelRef("BYTES_AT_LEAST_256"))
+	MOVQ(R9, Mem{Base: RAX, Disp: 48})
+
+	xorBlocks128(halfRound128SSSE3, halfRound64SSSE3)
+
+	Label("DONE")
+	PXOR(X0, X0)
+	for off := 0; off < 288; off += 16 {
+		MOVO(X0, sp(off))
+	}
+	MOVQ(RSI, RSP)
+	RET()
+}
+
+func setState() {
+	TEXT("setState", NOSPLIT, "func(state *[64]byte, key *[32]byte, nonce *[12]byte, counter uint32)")
+	Doc("setState builds the ChaCha state from the key, the nonce and the counter.")
+	Load(Param("state"), RAX)
+	Load(Param("key"), RBX)
+	Load(Param("nonce"), RCX)
+	Load(Param("counter"), EDX)
+
+	MOVOU(constants, X0)
+	MOVOU(X0, Mem{Base: RAX})
+
+	MOVOU(Mem{Base: RBX}, X0)
+	MOVOU(X0, Mem{Base: RAX, Disp: 16})
+	MOVOU(Mem{Base: RBX, Disp: 16}, X1)
+	MOVOU(X1, Mem{Base: RAX, Disp: 32})
+
+	MOVL(EDX, Mem{Base: RAX, Disp: 48})
+
+	MOVL(Mem{Base: RCX}, R8L)
+	MOVQ(Mem{Base: RCX, Disp: 4}, R9)
+	MOVL(R8L, Mem{Base: RAX, Disp: 52})
+	MOVQ(R9, Mem{Base: RAX, Disp: 56})
+	RET()
+}
@@ -0,0 +1,10 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+// +build !arm64 gccgo appengine purego
+
+package chacha
+
+// defaultNEONBlocks returns 0 since there is no arm64 NEON backend.
+func defaultNEONBlocks() int { return 0 }
@@ -0,0 +1,158 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package chacha
+
+import "github.com/aead/chacha20/internal/argerr"
+
+// pipelineChunkSize is the number of keystream bytes generated at once by
+// the background goroutine of a PipelinedCipher. It's a multiple of 64.
+const pipelineChunkSize = 4096
+
+// PipelinedCipher is a ChaCha/X cipher with a 96 bit nonce and a 32 bit
+// counter - like NewCipher - which generates the keystream in a background
+// goroutine. The goroutine keeps a bounded buffer of keystream filled ahead
+// of time, so XORKeyStream only has to pay for copying and XOR-ing as long
+// as the consumer doesn't outpace the goroutine. It's meant for latency
+// critical consumers like real-time audio or video frames.
+//
+// The buffered keystream is a copy of secret key material, so a
+// PipelinedCipher must be closed to wipe it and to stop the goroutine.
+// A PipelinedCipher is not safe for concurrent use.
+type PipelinedCipher struct {
+	chunks [][]byte // all chunks - for wiping
+
+	free    chan []byte // chunks the goroutine may fill
+	full    chan []byte // chunks of keystream ready for XORKeyStream
+	done    chan struct{}
+	stopped chan struct{}
+
+	chunk  []byte // the current chunk
+	unused []byte // the unused keystream of the current chunk
+	closed bool
+}
+
+// NewPipelinedCipher returns a new PipelinedCipher which keeps at least
+// buffer bytes - but at least two chunks of 4 KB - of keystream ready.
+// The nonce must be unique for one key for all time.
+func NewPipelinedCipher(nonce *[12]byte, key *[32]byte, rounds, buffer int) *PipelinedCipher {
+	return newPipelinedCipher(nonce, key, rounds, buffer, 0)
+}
+
+func newPipelinedCipher(nonce *[12]byte, key *[32]byte, rounds, buffer int, counter uint32) *PipelinedCipher {
+	if rounds <= 0 || rounds%2 != 0 {
+		panic(argerr.Error("chacha20/chacha: rounds must be a multiple of 2"))
+	}
+	if buffer < 0 {
+		panic(argerr.Error("chacha20/chacha: buffer size must not be negative"))
+	}
+	n := (buffer + pipelineChunkSize - 1) / pipelineChunkSize
+	if n < 2 {
+		n = 2
+	}
+	c := &PipelinedCipher{
+		chunks:  make([][]byte, n),
+		free:    make(chan []byte, n),
+		full:    make(chan []byte, n),
+		done:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+	for i := range c.chunks {
+		c.chunks[i] = make([]byte, pipelineChunkSize)
+		c.free <- c.chunks[i]
+	}
+
+	cipher := NewCipher(nonce, key, rounds)
+	cipher.SetCounter(counter)
+	go generateKeyStream(cipher, (1<<32-uint64(counter))*64, c.free, c.full, c.done, c.stopped)
+	return c
+}
+
+// generateKeyStream fills free chunks with the next keystream bytes and
+// passes them to full until done is closed or the keystream of the
+// remaining left bytes is exhausted. It closes full when the keystream
+// is exhausted and stopped when it returns.
+func generateKeyStream(cipher *Cipher, left uint64, free <-chan []byte, full chan<- []byte, done <-chan struct{}, stopped chan<- struct{}) {
+	defer close(stopped)
+	defer cipher.Wipe()
+	for left > 0 {
+		var p []byte
+		select {
+		case p = <-free:
+		case <-done:
+			return
+		}
+		if uint64(len(p)) > left {
+			p = p[:left]
+		}
+		for i := range p {
+			p[i] = 0
+		}
+		cipher.XORKeyStream(p, p)
+		left -= uint64(len(p))
+
+		select {
+		case full <- p:
+		case <-done:
+			return
+		}
+	}
+	close(full)
+}
+
+// XORKeyStream crypts bytes from src to dst using the buffered keystream.
+// Src and dst may be the same slice but otherwise should not overlap.
+// If len(dst) < len(src) the function panics. XORKeyStream blocks if the
+// background goroutine has not generated enough keystream yet. It panics
+// with ErrCounterOverflow once the 2^32 keystream blocks are used up - in
+// this case the keystream available before is applied to src.
+func (c *PipelinedCipher) XORKeyStream(dst, src []byte) {
+	if len(dst) < len(src) {
+		panic(argerr.Error("chacha20/chacha: dst buffer is to small"))
+	}
+	if c.closed {
+		panic(argerr.Error("chacha20/chacha: cipher is closed"))
+	}
+	for len(src) > 0 {
+		if len(c.unused) == 0 {
+			c.next()
+		}
+		n := xor(dst, src, c.unused)
+		c.unused = c.unused[n:]
+		dst, src = dst[n:], src[n:]
+	}
+}
+
+// next returns the current chunk to the background goroutine
+// and waits for the next one.
+func (c *PipelinedCipher) next() {
+	if c.chunk != nil {
+		c.free <- c.chunk[:cap(c.chunk)]
+		c.chunk = nil
+	}
+	chunk, ok := <-c.full
+	if !ok {
+		panic(ErrCounterOverflow)
+	}
+	c.chunk, c.unused = chunk, chunk
+}
+
+// Close stops the background goroutine and overwrites the buffered
+// keystream with zeros. The cipher must not be used afterwards.
+// Close always returns nil.
+func (c *PipelinedCipher) Close() error {
+	if c.closed {
+		return nil
+	}
+	c.closed = true
+	close(c.done)
+	<-c.stopped
+	for _, p := range c.chunks {
+		for i := range p {
+			p[i] = 0
+		}
+	}
+	c.chunk, c.unused = nil, nil
+	return nil
+}
@@ -0,0 +1,146 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+// Package reference implements the ChaCha cipher family as a slow and
+// straightforward transcription of RFC 8439. It's meant to cross-validate
+// optimized implementations - e.g. a new backend of the chacha package -
+// and must not be used to encrypt data.
+package reference // import "github.com/aead/chacha20/chacha/reference"
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// ErrCounterOverflow is the panic value of XORKeyStream if the keystream
+// exceeds the 32 bit block counter.
+var ErrCounterOverflow = errors.New("chacha20/chacha/reference: block counter overflow")
+
+// constants are the words "expand 32-byte k". (See RFC 8439 2.3)
+var constants = [4]uint32{0x61707865, 0x3320646e, 0x79622d32, 0x6b206574}
+
+// Block writes the keystream block of the key, the 96 bit nonce and the
+// 32 bit block counter to dst. (See RFC 8439 2.3)
+func Block(dst *[64]byte, nonce *[12]byte, key *[32]byte, counter uint32, rounds int) {
+	var state [16]uint32
+	initState(&state, key)
+	state[12] = counter
+	state[13] = binary.LittleEndian.Uint32(nonce[0:])
+	state[14] = binary.LittleEndian.Uint32(nonce[4:])
+	state[15] = binary.LittleEndian.Uint32(nonce[8:])
+	block(dst, &state, rounds)
+}
+
+// Block64 writes the keystream block of the key, the 64 bit nonce and the
+// 64 bit block counter of the original ChaCha construction to dst.
+func Block64(dst *[64]byte, nonce *[8]byte, key *[32]byte, counter uint64, rounds int) {
+	var state [16]uint32
+	initState(&state, key)
+	state[12] = uint32(counter)
+	state[13] = uint32(counter >> 32)
+	state[14] = binary.LittleEndian.Uint32(nonce[0:])
+	state[15] = binary.LittleEndian.Uint32(nonce[4:])
+	block(dst, &state, rounds)
+}
+
+// XORKeyStream crypts bytes from src to dst using the keystream of the key
+// and the 96 bit nonce starting at the block counter. If len(dst) < len(src)
+// or the keystream exceeds the 32 bit block counter XORKeyStream panics.
+func XORKeyStream(dst, src []byte, nonce *[12]byte, key *[32]byte, counter uint32, rounds int) {
+	if len(dst) < len(src) {
+		panic("chacha20/chacha/reference: dst buffer is to small")
+	}
+	if uint64(counter)+(uint64(len(src))+63)/64 > 1<<32 {
+		panic(ErrCounterOverflow)
+	}
+	var keystream [64]byte
+	for i := range src {
+		if i%64 == 0 {
+			Block(&keystream, nonce, key, counter+uint32(i/64), rounds)
+		}
+		dst[i] = src[i] ^ keystream[i%64]
+	}
+}
+
+// XORKeyStream64 crypts bytes from src to dst using the keystream of the key
+// and the 64 bit nonce starting at the 64 bit block counter. If len(dst) < len(src)
+// XORKeyStream64 panics.
+func XORKeyStream64(dst, src []byte, nonce *[8]byte, key *[32]byte, counter uint64, rounds int) {
+	if len(dst) < len(src) {
+		panic("chacha20/chacha/reference: dst buffer is to small")
+	}
+	var keystream [64]byte
+	for i := range src {
+		if i%64 == 0 {
+			Block64(&keystream, nonce, key, counter+uint64(i/64), rounds)
+		}
+		dst[i] = src[i] ^ keystream[i%64]
+	}
+}
+
+// HChaCha20 writes the HChaCha20 output of the key and the 128 bit nonce
+// to out. (See draft-irtf-cfrg-xchacha-03 2.2)
+func HChaCha20(out *[32]byte, nonce *[16]byte, key *[32]byte) {
+	var state [16]uint32
+	initState(&state, key)
+	for i := 0; i < 4; i++ {
+		state[12+i] = binary.LittleEndian.Uint32(nonce[4*i:])
+	}
+	rounds(&state, 20)
+	for i := 0; i < 4; i++ {
+		binary.LittleEndian.PutUint32(out[4*i:], state[i])
+		binary.LittleEndian.PutUint32(out[16+4*i:], state[12+i])
+	}
+}
+
+// initState sets the constants and the key words of the state.
+func initState(state *[16]uint32, key *[32]byte) {
+	copy(state[:4], constants[:])
+	for i := 0; i < 8; i++ {
+		state[4+i] = binary.LittleEndian.Uint32(key[4*i:])
+	}
+}
+
+// block writes the serialized sum of the state and the permuted
+// state to dst. (See RFC 8439 2.3)
+func block(dst *[64]byte, state *[16]uint32, n int) {
+	working := *state
+	rounds(&working, n)
+	for i := range working {
+		binary.LittleEndian.PutUint32(dst[4*i:], working[i]+state[i])
+	}
+}
+
+// rounds applies n rounds - n/2 column and n/2 diagonal rounds - to the
+// state. It panics if n is not a positive multiple of 2.
+func rounds(state *[16]uint32, n int) {
+	if n <= 0 || n%2 != 0 {
+		panic("chacha20/chacha/reference: rounds must be a multiple of 2")
+	}
+	for i := 0; i < n; i += 2 {
+		quarterRound(state, 0, 4, 8, 12)
+		quarterRound(state, 1, 5, 9, 13)
+		quarterRound(state, 2, 6, 10, 14)
+		quarterRound(state, 3, 7, 11, 15)
+		quarterRound(state, 0, 5, 10, 15)
+		quarterRound(state, 1, 6, 11, 12)
+		quarterRound(state, 2, 7, 8, 13)
+		quarterRound(state, 3, 4, 9, 14)
+	}
+}
+
+// quarterRound applies the ChaCha quarter round to the words a, b, c
+// and d of the state. (See RFC 8439 2.1)
+func quarterRound(state *[16]uint32, a, b, c, d int) {
+	state[a] += state[b]
+	state[d] = rotl(state[d]^state[a], 16)
+	state[c] += state[d]
+	state[b] = rotl(state[b]^state[c], 12)
+	state[a] += state[b]
+	state[d] = rotl(state[d]^state[a], 8)
+	state[c] += state[d]
+	state[b] = rotl(state[b]^state[c], 7)
+}
+
+func rotl(v uint32, n uint) uint32 { return v<<n | v>>(32-n) }
@@ -0,0 +1,122 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package reference
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+)
+
+func fromHex(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func counterKey() (key [32]byte) {
+	for i := range key {
+		key[i] = byte(i)
+	}
+	return
+}
+
+func TestBlock(t *testing.T) {
+	// RFC 8439 2.3.2
+	key := counterKey()
+	var nonce [12]byte
+	copy(nonce[:], fromHex("000000090000004a00000000"))
+	expected := fromHex("10f1e7e4d13b5915500fdd1fa32071c4c7d1f4c733c068030422aa9ac3d46c4e" +
+		"d2826446079faa0914c2d705d98b02a2b5129cd1de164eb9cbd083e8a2503c4e")
+
+	var block [64]byte
+	Block(&block, &nonce, &key, 1, 20)
+	if !bytes.Equal(block[:], expected) {
+		t.Fatalf("Block produces unexpected keystream:\nBlock(): %x\nExpected: %x", block, expected)
+	}
+}
+
+func TestBlock64(t *testing.T) {
+	// The keystream of the all zero key and nonce. It's the same for
+	// the 64 and the 96 bit nonce construction.
+	var (
+		key       [32]byte
+		nonce     [8]byte
+		nonce12   [12]byte
+		block     [64]byte
+		block12   [64]byte
+		zeroBlock = fromHex("76b8e0ada0f13d90405d6ae55386bd28bdd219b8a08ded1aa836efcc8b770dc7" +
+			"da41597c5157488d7724e03fb8d84a376a43b8f41518a11cc387b669b2ee6586")
+	)
+	Block64(&block, &nonce, &key, 0, 20)
+	Block(&block12, &nonce12, &key, 0, 20)
+	if !bytes.Equal(block[:], zeroBlock) || !bytes.Equal(block12[:], zeroBlock) {
+		t.Fatalf("Block64 produces unexpected keystream:\nBlock64(): %x\nBlock():   %x\nExpected:  %x", block, block12, zeroBlock)
+	}
+
+	// The upper half of the 64 bit counter is the first word of the 96 bit nonce.
+	nonce12[0] = 1
+	Block64(&block, &nonce, &key, 1<<32+7, 20)
+	Block(&block12, &nonce12, &key, 7, 20)
+	if block != block12 {
+		t.Fatalf("Block64 doesn't use a 64 bit counter:\nBlock64(): %x\nBlock():   %x", block, block12)
+	}
+}
+
+func TestXORKeyStream(t *testing.T) {
+	// RFC 8439 2.4.2
+	key := counterKey()
+	var nonce [12]byte
+	copy(nonce[:], fromHex("000000000000004a00000000"))
+	msg := []byte("Ladies and Gentlemen of the class of '99: If I could offer you only one tip for the future, sunscreen would be it.")
+	expected := fromHex("6e2e359a2568f98041ba0728dd0d6981e97e7aec1d4360c20a27afccfd9fae0b" +
+		"f91b65c5524733ab8f593dabcd62b3571639d624e65152ab8f530c359f0861d8" +
+		"07ca0dbf500d6a6156a38e088a22b65e52bc514d16ccf806818ce91ab7793736" +
+		"5af90bbf74a35be6b40b8eedf2785e42874d")
+
+	dst := make([]byte, len(msg))
+	XORKeyStream(dst, msg, &nonce, &key, 1, 20)
+	if !bytes.Equal(dst, expected) {
+		t.Fatalf("XORKeyStream produces unexpected ciphertext:\nXORKeyStream(): %x\nExpected:       %x", dst, expected)
+	}
+
+	var nonce8 [8]byte
+	copy(nonce8[:], nonce[4:])
+	XORKeyStream64(dst, msg, &nonce8, &key, 1, 20)
+	if !bytes.Equal(dst, expected) {
+		t.Fatalf("XORKeyStream64 produces unexpected ciphertext:\nXORKeyStream64(): %x\nExpected:         %x", dst, expected)
+	}
+}
+
+func TestXORKeyStreamOverflow(t *testing.T) {
+	var (
+		key   [32]byte
+		nonce [12]byte
+		buf   [129]byte
+	)
+	XORKeyStream(buf[:64], buf[:64], &nonce, &key, 1<<32-1, 20)
+	defer func() {
+		if err := recover(); err != ErrCounterOverflow {
+			t.Fatalf("XORKeyStream panics with unexpected value: %v", err)
+		}
+	}()
+	XORKeyStream(buf[:65], buf[:65], &nonce, &key, 1<<32-1, 20)
+}
+
+func TestHChaCha20(t *testing.T) {
+	// draft-irtf-cfrg-xchacha-03 2.2.1
+	key := counterKey()
+	var nonce [16]byte
+	copy(nonce[:], fromHex("000000090000004a0000000031415927"))
+	expected := fromHex("82413b4227b27bfed30e42508a877d73a0f9e4d58a74a853c12ec41326d3ecdc")
+
+	var out [32]byte
+	HChaCha20(&out, &nonce, &key)
+	if !bytes.Equal(out[:], expected) {
+		t.Fatalf("HChaCha20 produces unexpected output:\nHChaCha20(): %x\nExpected:    %x", out, expected)
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package chacha
+
+import "encoding/binary"
+
+// Source is a deterministic source of pseudo-random numbers producing
+// the ChaCha8 keystream of a key and a nonce. It implements the
+// math/rand.Source64 and the math/rand/v2.Source interfaces. A Source
+// produces the same numbers for the same key and nonce on all platforms.
+// It uses a 64 bit block counter, so the numbers don't repeat.
+// A Source is not safe for concurrent use.
+type Source struct {
+	state [64]byte
+	buf   [256]byte
+	off   int
+}
+
+// NewSource returns a new Source producing the ChaCha8 keystream
+// of the key and the nonce.
+func NewSource(nonce *[8]byte, key *[32]byte) *Source {
+	s := new(Source)
+	setState64(&(s.state), key, nonce, 0)
+	s.off = len(s.buf)
+	return s
+}
+
+// Seed replaces the key with the little endian encoding of seed followed
+// by 24 zero bytes and resets the block counter. The nonce is not changed.
+func (s *Source) Seed(seed int64) {
+	var key [32]byte
+	binary.LittleEndian.PutUint64(key[:], uint64(seed))
+	copy(s.state[16:48], key[:])
+	for i := 48; i < 56; i++ {
+		s.state[i] = 0
+	}
+	s.off = len(s.buf)
+}
+
+// Int63 returns a non-negative pseudo-random 63 bit integer.
+func (s *Source) Int63() int64 { return int64(s.Uint64() >> 1) }
+
+// Uint64 returns a pseudo-random 64 bit integer. The numbers are the
+// little endian 8 byte words of the keystream.
+func (s *Source) Uint64() uint64 {
+	if s.off == len(s.buf) {
+		for i := range s.buf {
+			s.buf[i] = 0
+		}
+		xorBlocksCounter(s.buf[:], s.buf[:], &(s.state), 8, true)
+		s.off = 0
+	}
+	v := binary.LittleEndian.Uint64(s.buf[s.off:])
+	s.off += 8
+	return v
+}
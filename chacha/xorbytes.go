@@ -0,0 +1,29 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package chacha
+
+import "github.com/aead/chacha20/internal/argerr"
+
+// XORBytes sets dst[i] = x[i] ^ y[i] for all i < n = min(len(x), len(y))
+// and returns n. It panics if dst is shorter than n. The slices may have
+// any alignment. dst may be the same slice as x or y but otherwise must not
+// overlap them. XORBytes does not depend on the values of the bytes, so it
+// runs in constant time for inputs of the same length.
+// It's useful for combining keystreams, applying one-time pads and masks.
+// With Go 1.20 or newer it uses the assembly implementation of
+// crypto/subtle on amd64, arm64, ppc64le and s390x.
+func XORBytes(dst, x, y []byte) int {
+	n := len(x)
+	if len(y) < n {
+		n = len(y)
+	}
+	if len(dst) < n {
+		panic(argerr.Error("chacha20/chacha: dst buffer is to small"))
+	}
+	if n == 0 {
+		return 0
+	}
+	return xorBytes(dst[:n], x[:n], y[:n])
+}
@@ -0,0 +1,11 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+// +build go1.20
+
+package chacha
+
+import "crypto/subtle"
+
+func xorBytes(dst, x, y []byte) int { return subtle.XORBytes(dst, x, y) }
@@ -9,6 +9,12 @@
 // iteration. Following ChaCha20 can en/decrypt up to 2^32 * 64 byte
 // for one key-nonce combination. Notice that one specific key-nonce
 // combination must be unique for all time.
+//
+// The AEADs of this package authenticate a ciphertext before they decrypt
+// it. If the ciphertext is not authentic Open returns an error and doesn't
+// write to dst - not even if dst aliases the ciphertext. The same holds
+// for the functions and types built on the AEADs, e.g. OpenWithRandomNonce,
+// RecvSession and StreamReader.
 package chacha20 // import "github.com/aead/chacha20"
 
 import (
@@ -17,19 +23,72 @@ import (
 	"github.com/aead/chacha20/chacha"
 )
 
-// NonceSize is the size of the ChaCha20 nonce in bytes.
-const NonceSize = 12
+const (
+	// KeySize is the size of the ChaCha20 key in bytes.
+	KeySize = 32
+
+	// NonceSize is the size of the ChaCha20 nonce in bytes.
+	NonceSize = 12
+
+	// NonceSizeX is the size of the XChaCha20 nonce in bytes.
+	NonceSizeX = 24
+
+	// NonceSizeLegacy is the size of the nonce of the original
+	// ChaCha20 construction with a 64 bit nonce in bytes.
+	NonceSizeLegacy = 8
+)
 
 // XORKeyStream crypts bytes from src to dst using the given key, nonce and counter. Src
 // and dst may be the same slice but otherwise should not overlap. If len(dst) < len(src)
-// this function panics.
+// this function panics. If src exceeds the keystream left before the 32 bit counter
+// overflows, it panics with chacha.ErrCounterOverflow.
 func XORKeyStream(dst, src []byte, nonce *[NonceSize]byte, key *[32]byte, counter uint32) {
 	chacha.XORKeyStream(dst, src, nonce, key, counter, 20)
 }
 
+// XORKeyStreamAt crypts bytes from src to dst using the keystream of the given key
+// and nonce starting at the byte offset. It can be used to en/decrypt arbitrary parts
+// of a message - e.g. pages of a database - without managing the block counter.
+// Src and dst may be the same slice but otherwise should not overlap. If len(dst) < len(src)
+// this function panics.
+func XORKeyStreamAt(dst, src []byte, nonce *[NonceSize]byte, key *[32]byte, offset uint64) {
+	chacha.XORKeyStreamAt(dst, src, nonce, key, offset, 20)
+}
+
+// XORKeyStreamParallel crypts bytes from src to dst like XORKeyStream but
+// crypts buffers of at least 1 MiB concurrently using multiple goroutines.
+// Src and dst may be the same slice but otherwise should not overlap.
+// If len(dst) < len(src) this function panics.
+func XORKeyStreamParallel(dst, src []byte, nonce *[NonceSize]byte, key *[32]byte, counter uint32) {
+	chacha.XORKeyStreamParallel(dst, src, nonce, key, counter, 20)
+}
+
 // NewCipher returns a new cipher.Stream implementing the ChaCha20
 // stream cipher. The nonce must be unique for one
 // key for all time.
 func NewCipher(nonce *[NonceSize]byte, key *[32]byte) cipher.Stream {
 	return chacha.NewCipher(nonce, key, 20)
 }
+
+// NewCipherWithCounter returns a new cipher.Stream implementing the ChaCha20
+// stream cipher starting at the given block counter. This is useful for
+// protocols which reserve the first blocks of the keystream - e.g. for key
+// derivation. The nonce must be unique for one key for all time.
+func NewCipherWithCounter(nonce *[NonceSize]byte, key *[32]byte, counter uint32) cipher.Stream {
+	c := chacha.NewCipher(nonce, key, 20)
+	c.SetCounter(counter)
+	return c
+}
+
+// NewCipherLegacy returns a new cipher.Stream implementing the original ChaCha20
+// stream cipher with a 64 bit nonce and a 64 bit block counter, which doesn't
+// wrap around after 2^32 blocks. The nonce must be unique for one key for all time.
+func NewCipherLegacy(nonce *[NonceSizeLegacy]byte, key *[32]byte) cipher.Stream {
+	return chacha.NewCipher64(nonce, key, 20)
+}
+
+// ChaCha20Block generates the 64 byte ChaCha20 keystream block for the given
+// key, nonce and counter and writes it to dst. (See RFC 7539 2.3)
+func ChaCha20Block(dst *[64]byte, nonce *[NonceSize]byte, key *[32]byte, counter uint32) {
+	chacha.Block(dst, nonce, key, counter, 20)
+}
@@ -7,160 +7,455 @@ package chacha20
 import (
 	"crypto/cipher"
 	"crypto/subtle"
+	"encoding/binary"
 	"errors"
 
 	"github.com/aead/chacha20/chacha"
+	"github.com/aead/chacha20/internal/argerr"
 	"github.com/aead/poly1305"
 )
 
-// TagSize is the max. size of the auth. tag for the ChaCha20Poly1305 AEAD in bytes.
-const TagSize = poly1305.TagSize
+const (
+	// TagSize is the max. size of the auth. tag for the ChaCha20Poly1305 AEAD in bytes.
+	TagSize = poly1305.TagSize
+
+	// Overhead is the size difference between a plaintext and its ciphertext
+	// for the ChaCha20Poly1305, XChaCha20Poly1305 and legacy ChaCha20Poly1305 AEADs
+	// with the default tag size.
+	Overhead = TagSize
+
+	// MaxPlaintextSize is the max. size of a plaintext in bytes. Longer
+	// plaintexts would exceed the 32 bit block counter. (See RFC 8439 2.8)
+	MaxPlaintextSize = 1<<38 - 64
+
+	// DefaultMinTagSize is the min. tag size accepted by
+	// NewChaCha20Poly1305WithTagSize unless changed by SetMinTagSize.
+	DefaultMinTagSize = 12
+)
+
+// minTagSize is the min. tag size set by SetMinTagSize.
+var minTagSize = DefaultMinTagSize
+
+// SetMinTagSize sets the min. tag size accepted by NewChaCha20Poly1305WithTagSize
+// and Key.ChaCha20Poly1305WithTagSize. The size must be between 1 and TagSize.
+// An attacker can forge a message authenticated by an n byte tag with a
+// probability of 1 / 2^(8*n) per attempt, so tags shorter than DefaultMinTagSize
+// should only be allowed if the protocol limits the number of forgery attempts.
+// SetMinTagSize should be called during program initialization - it must not be
+// called concurrently with the creation of AEADs.
+func SetMinTagSize(tagsize int) error {
+	if tagsize < 1 || tagsize > TagSize {
+		return errInvalidTagSize
+	}
+	minTagSize = tagsize
+	return nil
+}
+
+// ErrMessageTooLarge is returned by Open and is the panic value of Seal
+// if the plaintext exceeds MaxPlaintextSize.
+var ErrMessageTooLarge = errors.New("message exceeds the max. plaintext size")
 
 var (
 	errAuthFailed       = errors.New("authentication failed")
 	errInvalidNonceSize = errors.New("nonce size is invalid")
 	errInvalidTagSize   = errors.New("tag size must be between 1 and 16")
+	errTagTooShort      = errors.New("tag size is smaller than the min. tag size")
 )
 
 // NewChaCha20Poly1305 returns a cipher.AEAD implementing the
 // ChaCha20Poly1305 construction specified in RFC 7539 with a
 // 128 bit auth. tag.
 func NewChaCha20Poly1305(key *[32]byte) cipher.AEAD {
-	var defaultNonce [12]byte
-	c := &aead{
-		engine:  chacha.NewCipher(&defaultNonce, key, 20),
-		tagsize: TagSize,
-	}
-	return c
+	return NewKey(key).ChaCha20Poly1305()
 }
 
 // NewChaCha20Poly1305WithTagSize returns a cipher.AEAD implementing the
 // ChaCha20Poly1305 construction specified in RFC 7539 with arbitrary tag size.
-// The tagsize must be between 1 and the TagSize constant.
+// The tagsize must be between the min. tag size - see SetMinTagSize - and the
+// TagSize constant.
 func NewChaCha20Poly1305WithTagSize(key *[32]byte, tagsize int) (cipher.AEAD, error) {
-	if tagsize < 1 || tagsize > TagSize {
-		return nil, errInvalidTagSize
-	}
-	var defaultNonce [12]byte
-	c := &aead{
-		engine: chacha.NewCipher(&defaultNonce, key, 20),
-	}
-	c.tagsize = tagsize
-	return c, nil
+	return NewKey(key).ChaCha20Poly1305WithTagSize(tagsize)
+}
+
+// Verifier is implemented by all cipher.AEAD implementations returned by
+// this package. It verifies a detached auth. tag without
+// producing the plaintext, so integrity can be checked before and independently
+// of decryption.
+type Verifier interface {
+	// Verify returns true if and only if tag is the valid auth. tag of the
+	// ciphertext and the additional data for the given nonce.
+	Verify(nonce, ciphertext, additionalData, tag []byte) bool
 }
 
+// openSmallMaxLength is the max. length of the ciphertext and the additional
+// data which are authenticated and decrypted in one pass by openSmall. Longer
+// messages are processed faster by a ChaCha20 engine and poly1305.Hash.
+const openSmallMaxLength = 192
+
 // The AEAD cipher ChaCha20Poly1305
+// The ChaCha20 engines are pooled by the shared Key, so an aead can be
+// used concurrently and doesn't allocate a new engine per message.
 type aead struct {
-	engine  *chacha.Cipher
+	key     *Key
 	tagsize int
+	legacy  bool
+}
+
+func newAEAD(key *[32]byte, tagsize int, legacy bool) *aead {
+	c := &aead{
+		key:     NewKey(key),
+		tagsize: tagsize,
+		legacy:  legacy,
+	}
+	return c
+}
+
+// engine returns a ChaCha20 engine from the pool or creates
+// a new one. The engine must be returned to the pool by the caller.
+func (c *aead) engine() *chacha.Cipher {
+	if engine, ok := c.key.engines.Get().(*chacha.Cipher); ok {
+		return engine
+	}
+	return c.key.newEngine()
 }
 
 func (c *aead) Overhead() int { return c.tagsize }
 
-func (c *aead) NonceSize() int { return NonceSize }
+func (c *aead) NonceSize() int {
+	if c.legacy {
+		return NonceSizeLegacy
+	}
+	return NonceSize
+}
+
+// Close wipes the key of the AEAD. If the AEAD was created from a shared
+// Key, Close wipes the Key, so all AEADs sharing it become unusable.
+// See Key.Wipe.
+func (c *aead) Close() error {
+	c.key.Wipe()
+	return nil
+}
 
 func (c *aead) Seal(dst, nonce, plaintext, additionalData []byte) []byte {
-	if n := len(nonce); n != NonceSize {
-		panic("chacha20: nonce size is invalid")
+	if n := len(nonce); n != c.NonceSize() {
+		panic(argerr.Error("chacha20: nonce size is invalid"))
+	}
+	if c.key.wiped {
+		panic(errKeyWiped)
+	}
+	if exceedsPlaintextSize(len(plaintext), 0) {
+		panic(ErrMessageTooLarge)
+	}
+	countSeal(len(plaintext))
+	if len(plaintext) <= 64 {
+		return c.sealBlock(dst, nonce, plaintext, additionalData)
+	}
+
+	engine := c.engine()
+	ret := c.seal(engine, dst, nonce, plaintext, additionalData)
+	c.key.release(&c.key.engines, engine)
+	return ret
+}
+
+func (c *aead) Open(dst, nonce, ciphertext, additionalData []byte) ([]byte, error) {
+	ret, err := c.openMessage(dst, nonce, ciphertext, additionalData)
+	countOpen(len(ciphertext)-c.tagsize, err)
+	return ret, err
+}
+
+// openMessage implements Open.
+func (c *aead) openMessage(dst, nonce, ciphertext, additionalData []byte) ([]byte, error) {
+	if n := len(nonce); n != c.NonceSize() {
+		return nil, errInvalidNonceSize
+	}
+	if c.key.wiped {
+		return nil, errKeyWiped
+	}
+	if exceedsPlaintextSize(len(ciphertext), c.tagsize) {
+		return nil, ErrMessageTooLarge
+	}
+	if n := len(ciphertext) - c.tagsize; n >= 0 && n <= openSmallMaxLength {
+		if !c.legacy && len(additionalData) <= openSmallMaxLength {
+			return c.openSmall(dst, nonce, ciphertext, additionalData)
+		}
+		if n <= 64 {
+			return c.openBlock(dst, nonce, ciphertext, additionalData)
+		}
+	}
+
+	engine := c.engine()
+	ret, err := c.open(engine, dst, nonce, ciphertext, additionalData)
+	c.key.release(&c.key.engines, engine)
+	return ret, err
+}
+
+// exceedsPlaintextSize returns true if a message of n bytes minus the
+// overhead is longer than MaxPlaintextSize.
+func exceedsPlaintextSize(n, overhead int) bool {
+	return uint64(n) > MaxPlaintextSize+uint64(overhead)
+}
+
+// sealBlock encrypts and authenticates a plaintext of at most 64 bytes.
+// It computes the poly1305 key and the single keystream block in one call
+// without a ChaCha20 engine.
+func (c *aead) sealBlock(dst, nonce, plaintext, additionalData []byte) []byte {
+	var polyKey [32]byte
+	var keystream [128]byte
+	c.keystreamBlocks(&polyKey, &keystream, nonce)
+
+	n := len(plaintext)
+	ret, ciphertext := sliceForAppend(dst, n+c.tagsize)
+	for i, v := range plaintext {
+		ciphertext[i] = v ^ keystream[64+i]
+	}
+
+	var tag [poly1305.TagSize]byte
+	c.authenticate(&tag, ciphertext[:n], additionalData, &polyKey)
+	copy(ciphertext[n:], tag[:c.tagsize])
+
+	return ret
+}
+
+// openBlock authenticates and decrypts a ciphertext of at most 64 bytes
+// (plus the auth. tag) like sealBlock.
+func (c *aead) openBlock(dst, nonce, ciphertext, additionalData []byte) ([]byte, error) {
+	var polyKey [32]byte
+	var keystream [128]byte
+	c.keystreamBlocks(&polyKey, &keystream, nonce)
+
+	n := len(ciphertext) - c.tagsize
+	var sum [poly1305.TagSize]byte
+	c.authenticate(&sum, ciphertext[:n], additionalData, &polyKey)
+	if !checkTag(&sum, ciphertext[n:], c.tagsize) {
+		wipe(keystream[:])
+		return nil, errAuthFailed
+	}
+
+	ret, plaintext := sliceForAppend(dst, n)
+	for i, v := range ciphertext[:n] {
+		plaintext[i] = v ^ keystream[64+i]
+	}
+	return ret, nil
+}
+
+// openSmall authenticates and decrypts a ciphertext of at most openSmallMaxLength
+// bytes (plus the auth. tag). It computes the tag and the plaintext in one pass
+// over the ciphertext and writes the plaintext to dst only if the ciphertext is
+// authentic. The additional data must not be longer than openSmallMaxLength.
+func (c *aead) openSmall(dst, nonce, ciphertext, additionalData []byte) ([]byte, error) {
+	n := len(ciphertext) - c.tagsize
+
+	var Nonce [12]byte
+	copy(Nonce[NonceSize-len(nonce):], nonce)
+	var keystream [64 + openSmallMaxLength]byte
+	c.key.xorKeyStream(keystream[:64+n], keystream[:64+n], &Nonce)
+
+	var polyKey [32]byte
+	copy(polyKey[:], keystream[:32])
+	var mac macState
+	mac.init(&polyKey)
+	mac.writePadded(additionalData)
+
+	// the plaintext replaces the keystream after the first block
+	plaintext := keystream[64 : 64+n]
+	for i := 0; i < n; i += 64 {
+		block := ciphertext[i:n]
+		if len(block) > 64 {
+			block = block[:64]
+		}
+		mac.writePadded(block)
+		xorWords(plaintext[i:], block)
+	}
+	lengths := aeadLengths(len(additionalData), n)
+	mac.writePadded(lengths[:])
+
+	var sum [TagSize]byte
+	mac.sum(&sum)
+	if !checkTag(&sum, ciphertext[n:], c.tagsize) {
+		wipe(plaintext)
+		return nil, errAuthFailed
+	}
+	ret, out := sliceForAppend(dst, n)
+	copy(out, plaintext)
+	wipe(plaintext)
+	return ret, nil
+}
+
+// checkTag returns true if and only if tag matches the first tagsize bytes
+// of sum. It always compares all TagSize bytes, so its timing depends
+// neither on the bytes of the tags nor on the tag size. The length of tag
+// is public - it's the length of the ciphertext minus the plaintext.
+// checkTag overwrites sum, so a failed Open doesn't leave the valid tag
+// of a forged message on the stack.
+func checkTag(sum *[TagSize]byte, tag []byte, tagsize int) bool {
+	var expected [TagSize]byte
+	copy(expected[:], sum[:])
+	copy(expected[:tagsize], tag)
+	ok := subtle.ConstantTimeCompare(sum[:], expected[:]) & subtle.ConstantTimeEq(int32(len(tag)), int32(tagsize))
+	wipe(sum[:])
+	wipe(expected[:])
+	return ok == 1
+}
+
+// wipe overwrites b with zeros.
+func wipe(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
+
+// xorWords xors src into dst using 8 byte words as long as possible.
+// It expects len(dst) >= len(src).
+func xorWords(dst, src []byte) {
+	n := len(src) &^ 7
+	for i := 0; i < n; i += 8 {
+		v := binary.LittleEndian.Uint64(dst[i:]) ^ binary.LittleEndian.Uint64(src[i:])
+		binary.LittleEndian.PutUint64(dst[i:], v)
 	}
+	for i := n; i < len(src); i++ {
+		dst[i] ^= src[i]
+	}
+}
+
+// keystreamBlocks computes the first two keystream blocks for the nonce at
+// once. The first 32 bytes are the poly1305 key and the second block is the
+// keystream of the message.
+func (c *aead) keystreamBlocks(polyKey *[32]byte, keystream *[128]byte, nonce []byte) {
+	var Nonce [12]byte
+	copy(Nonce[NonceSize-len(nonce):], nonce)
+	c.key.xorKeyStream(keystream[:], keystream[:], &Nonce)
+	copy(polyKey[:], keystream[:32])
+}
 
-	// create the poly1305 key
-	var (
-		Nonce   [12]byte
-		polyKey [32]byte
-	)
-	copy(Nonce[:], nonce)
-	c.engine.SetCounter(0)
-	c.engine.SetNonce(&Nonce)
-	c.engine.XORKeyStream(polyKey[:], polyKey[:])
-	c.engine.SetCounter(1)
+// seal encrypts and authenticates the plaintext using the given engine
+// and appends the result to dst.
+func (c *aead) seal(engine *chacha.Cipher, dst, nonce, plaintext, additionalData []byte) []byte {
+	var polyKey [32]byte
+	c.polyKey(engine, &polyKey, nonce)
 
 	// encrypt the plaintext
 	n := len(plaintext)
 	ret, ciphertext := sliceForAppend(dst, n+c.tagsize)
-	c.engine.XORKeyStream(ciphertext, plaintext)
+	engine.XORKeyStream(ciphertext, plaintext)
 
 	// authenticate the ciphertext
 	var tag [poly1305.TagSize]byte
-	authenticate(&tag, ciphertext[:n], additionalData, &polyKey)
+	c.authenticate(&tag, ciphertext[:n], additionalData, &polyKey)
 	copy(ciphertext[n:], tag[:c.tagsize])
 
 	return ret
 }
 
-func (c *aead) Open(dst, nonce, ciphertext, additionalData []byte) ([]byte, error) {
-	if n := len(nonce); n != NonceSize {
-		return nil, errInvalidNonceSize
-	}
+// open authenticates and decrypts the ciphertext using the given engine
+// and appends the plaintext to dst.
+func (c *aead) open(engine *chacha.Cipher, dst, nonce, ciphertext, additionalData []byte) ([]byte, error) {
 	if len(ciphertext) < c.tagsize {
 		return nil, errAuthFailed
 	}
 
-	// create the poly1305 key
-	var (
-		Nonce   [12]byte
-		polyKey [32]byte
-	)
-	copy(Nonce[:], nonce)
-	c.engine.SetCounter(0)
-	c.engine.SetNonce(&Nonce)
-	c.engine.XORKeyStream(polyKey[:], polyKey[:])
-	c.engine.SetCounter(1)
-
 	// authenticate the ciphertext
 	n := len(ciphertext) - c.tagsize
-	var tag [poly1305.TagSize]byte
-	authenticate(&tag, ciphertext[:n], additionalData, &polyKey)
-	sum := ciphertext[n:]
-	if subtle.ConstantTimeCompare(tag[:c.tagsize], sum[:c.tagsize]) != 1 {
+	if !c.verify(engine, ciphertext[n:], nonce, ciphertext[:n], additionalData) {
 		return nil, errAuthFailed
 	}
 
-	// decrypt ciphertext
+	// decrypt ciphertext - verify leaves the engine at counter 1
 	ret, plaintext := sliceForAppend(dst, n)
-	c.engine.XORKeyStream(plaintext, ciphertext[:n])
+	engine.XORKeyStream(plaintext, ciphertext[:n])
 
 	return ret, nil
 }
 
+// Verify returns true if and only if tag is the valid auth. tag of the ciphertext
+// and the additional data for the given nonce. Verify does not decrypt the ciphertext.
+func (c *aead) Verify(nonce, ciphertext, additionalData, tag []byte) bool {
+	if len(nonce) != c.NonceSize() || len(tag) != c.tagsize || exceedsPlaintextSize(len(ciphertext), 0) || c.key.wiped {
+		return false
+	}
+	engine := c.engine()
+	ok := c.verify(engine, tag, nonce, ciphertext, additionalData)
+	c.key.release(&c.key.engines, engine)
+	return ok
+}
+
+// verify returns true if and only if tag is the valid auth. tag of the
+// ciphertext and additional data. After verify returns the engine is
+// set up to en/decrypt the message with the given nonce.
+func (c *aead) verify(engine *chacha.Cipher, tag, nonce, ciphertext, additionalData []byte) bool {
+	var polyKey [32]byte
+	c.polyKey(engine, &polyKey, nonce)
+
+	var sum [poly1305.TagSize]byte
+	c.authenticate(&sum, ciphertext, additionalData, &polyKey)
+	return checkTag(&sum, tag, c.tagsize)
+}
+
+// polyKey sets the nonce of the engine and creates the poly1305 key
+// from the first keystream block. Afterwards the engine is set up to
+// en/decrypt the message starting at counter 1.
+// The legacy construction uses a 64 bit nonce and a 64 bit counter.
+// The upper half of the counter is the first word of the 96 bit nonce.
+func (c *aead) polyKey(engine *chacha.Cipher, polyKey *[32]byte, nonce []byte) {
+	var Nonce [12]byte
+	copy(Nonce[NonceSize-len(nonce):], nonce)
+	engine.SetCounter(0)
+	engine.SetNonce(&Nonce)
+	engine.XORKeyStream(polyKey[:], polyKey[:])
+	engine.SetCounter(1)
+}
+
+func (c *aead) authenticate(out *[TagSize]byte, ciphertext, additionalData []byte, key *[32]byte) {
+	if c.legacy {
+		authenticateLegacy(out, ciphertext, additionalData, key)
+	} else {
+		authenticate(out, ciphertext, additionalData, key)
+	}
+}
+
 // authenticate calculates the poly1305 tag from
 // the given ciphertext and additional data.
 func authenticate(out *[TagSize]byte, ciphertext, additionalData []byte, key *[32]byte) {
-	ctLen := uint64(len(ciphertext))
-	adLen := uint64(len(additionalData))
-
-	var buf, pad [TagSize]byte
-	buf[0] = byte(adLen)
-	buf[1] = byte(adLen >> 8)
-	buf[2] = byte(adLen >> 16)
-	buf[3] = byte(adLen >> 24)
-	buf[4] = byte(adLen >> 32)
-	buf[5] = byte(adLen >> 40)
-	buf[6] = byte(adLen >> 48)
-	buf[7] = byte(adLen >> 56)
-	buf[8] = byte(ctLen)
-	buf[9] = byte(ctLen >> 8)
-	buf[10] = byte(ctLen >> 16)
-	buf[11] = byte(ctLen >> 24)
-	buf[12] = byte(ctLen >> 32)
-	buf[13] = byte(ctLen >> 40)
-	buf[14] = byte(ctLen >> 48)
-	buf[15] = byte(ctLen >> 56)
+	lengths := aeadLengths(len(additionalData), len(ciphertext))
+
+	if n := len(additionalData) + len(ciphertext); n <= macStateMaxLength || useVectorMAC && n >= vectorMACMinLength {
+		var mac macState
+		mac.init(key)
+		mac.writePadded(additionalData)
+		mac.writePadded(ciphertext)
+		mac.writePadded(lengths[:])
+		mac.sum(out)
+		return
+	}
 
 	poly := poly1305.New(key)
+	writeWithPadding(poly, additionalData)
+	writeWithPadding(poly, ciphertext)
+	poly.Write(lengths[:])
+	poly.Sum(out)
+}
 
-	poly.Write(additionalData)
-	if padAD := adLen % TagSize; padAD > 0 {
-		poly.Write(pad[:16-padAD])
-	}
+// zeroPad is the zero padding of the additional data and the ciphertext.
+// It's never modified.
+var zeroPad [TagSize]byte
 
-	poly.Write(ciphertext)
-	if padCT := ctLen % TagSize; padCT > 0 {
-		poly.Write(pad[:16-padCT])
+// writeWithPadding writes the data followed by zero padding up to a
+// multiple of 16 bytes to poly.
+func writeWithPadding(poly *poly1305.Hash, data []byte) {
+	poly.Write(data)
+	if padding := len(data) % TagSize; padding > 0 {
+		poly.Write(zeroPad[:TagSize-padding])
 	}
+}
 
-	poly.Write(buf[:])
-	poly.Sum(out)
+// aeadLengths returns the little endian encoded lengths of the additional
+// data and the ciphertext which are authenticated by ChaCha20Poly1305.
+func aeadLengths(adLen, ctLen int) (buf [16]byte) {
+	for i, ad, ct := 0, uint64(adLen), uint64(ctLen); i < 8; i++ {
+		buf[i], buf[8+i] = byte(ad), byte(ct)
+		ad >>= 8
+		ct >>= 8
+	}
+	return
 }
 
 // sliceForAppend takes a slice and a requested number of bytes. It returns a
@@ -0,0 +1,39 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package chacha20
+
+import (
+	"crypto/cipher"
+
+	"github.com/aead/poly1305"
+)
+
+// NewChaCha20Poly1305Legacy returns a cipher.AEAD implementing the original
+// ChaCha20Poly1305 construction described in draft-agl-tls-chacha20poly1305
+// with a 64 bit nonce and a 128 bit auth. tag. It is only provided for
+// compatibility with existing protocols - new protocols should use the
+// construction specified in RFC 7539.
+func NewChaCha20Poly1305Legacy(key *[32]byte) cipher.AEAD {
+	return newAEAD(key, TagSize, true)
+}
+
+// authenticateLegacy calculates the poly1305 tag from the given
+// ciphertext and additional data as specified by the legacy
+// construction. The lengths follow the data and no padding is used.
+func authenticateLegacy(out *[TagSize]byte, ciphertext, additionalData []byte, key *[32]byte) {
+	var adLen, ctLen [8]byte
+	for i, ad, ct := 0, uint64(len(additionalData)), uint64(len(ciphertext)); i < 8; i++ {
+		adLen[i], ctLen[i] = byte(ad), byte(ct)
+		ad >>= 8
+		ct >>= 8
+	}
+
+	poly := poly1305.New(key)
+	poly.Write(additionalData)
+	poly.Write(adLen[:])
+	poly.Write(ciphertext)
+	poly.Write(ctLen[:])
+	poly.Sum(out)
+}
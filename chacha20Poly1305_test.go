@@ -4,7 +4,21 @@
 
 package chacha20
 
-import "testing"
+import (
+	"bytes"
+	"crypto/cipher"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aead/poly1305"
+)
 
 var recFunc = func(t *testing.T, msg string) {
 	if recover() == nil {
@@ -25,6 +39,37 @@ func TestNewChaCha20Poly1305WithTagSize(t *testing.T) {
 	}
 }
 
+func TestSetMinTagSize(t *testing.T) {
+	defer SetMinTagSize(DefaultMinTagSize)
+
+	var key [32]byte
+	if _, err := NewChaCha20Poly1305WithTagSize(&key, DefaultMinTagSize-1); err != errTagTooShort {
+		t.Fatalf("NewChaCha20Poly1305WithTagSize accepted tag size %d: %v", DefaultMinTagSize-1, err)
+	}
+	if _, err := NewChaCha20Poly1305WithTagSize(&key, DefaultMinTagSize); err != nil {
+		t.Fatalf("NewChaCha20Poly1305WithTagSize rejected tag size %d: %v", DefaultMinTagSize, err)
+	}
+
+	if err := SetMinTagSize(0); err == nil {
+		t.Fatal("SetMinTagSize accepted tag size 0")
+	}
+	if err := SetMinTagSize(TagSize + 1); err == nil {
+		t.Fatalf("SetMinTagSize accepted tag size %d", TagSize+1)
+	}
+	if err := SetMinTagSize(1); err != nil {
+		t.Fatalf("SetMinTagSize failed: %v", err)
+	}
+	if _, err := NewChaCha20Poly1305WithTagSize(&key, 1); err != nil {
+		t.Fatalf("NewChaCha20Poly1305WithTagSize rejected tag size 1 after SetMinTagSize(1): %v", err)
+	}
+	if err := SetMinTagSize(TagSize); err != nil {
+		t.Fatalf("SetMinTagSize failed: %v", err)
+	}
+	if _, err := NewChaCha20Poly1305WithTagSize(&key, TagSize-1); err != errTagTooShort {
+		t.Fatalf("NewChaCha20Poly1305WithTagSize accepted tag size %d after SetMinTagSize(%d): %v", TagSize-1, TagSize, err)
+	}
+}
+
 func TestOverhead(t *testing.T) {
 	var key [32]byte
 	c := NewChaCha20Poly1305(&key)
@@ -50,6 +95,19 @@ func TestNonceSize(t *testing.T) {
 	}
 }
 
+func TestNonceSizeVariants(t *testing.T) {
+	var key [32]byte
+	if n := NewXChaCha20Poly1305(&key).NonceSize(); n != NonceSizeX {
+		t.Fatalf("Expected %d but NonceSize() returned %d", NonceSizeX, n)
+	}
+	if n := NewChaCha20Poly1305Legacy(&key).NonceSize(); n != NonceSizeLegacy {
+		t.Fatalf("Expected %d but NonceSize() returned %d", NonceSizeLegacy, n)
+	}
+	if o := NewXChaCha20Poly1305(&key).Overhead(); o != Overhead {
+		t.Fatalf("Expected %d but Overhead() returned %d", Overhead, o)
+	}
+}
+
 func TestSeal(t *testing.T) {
 	var key [32]byte
 	c := NewChaCha20Poly1305(&key)
@@ -151,3 +209,532 @@ func BenchmarkSeal64K(b *testing.B) { benchmarkSeal(b, 64*1024) }
 func BenchmarkOpen64B(b *testing.B) { benchmarkOpen(b, 64) }
 func BenchmarkOpen1K(b *testing.B)  { benchmarkOpen(b, 1024) }
 func BenchmarkOpen64K(b *testing.B) { benchmarkOpen(b, 64*1024) }
+
+func TestVerify(t *testing.T) {
+	var key [32]byte
+	var nonce [NonceSize]byte
+	c := NewChaCha20Poly1305(&key)
+	v, ok := c.(Verifier)
+	if !ok {
+		t.Fatal("ChaCha20Poly1305 does not implement Verifier")
+	}
+
+	msg, data := make([]byte, 100), []byte("additional data")
+	sealed := c.Seal(nil, nonce[:], msg, data)
+	ciphertext, tag := sealed[:len(msg)], sealed[len(msg):]
+
+	if !v.Verify(nonce[:], ciphertext, data, tag) {
+		t.Fatal("Verify rejected valid tag")
+	}
+	if v.Verify(nonce[:], ciphertext, data, tag[:TagSize-1]) {
+		t.Fatal("Verify accepted truncated tag")
+	}
+	if v.Verify(nonce[:NonceSize-1], ciphertext, data, tag) {
+		t.Fatal("Verify accepted invalid nonce size")
+	}
+	tag[0]++
+	if v.Verify(nonce[:], ciphertext, data, tag) {
+		t.Fatal("Verify accepted invalid tag")
+	}
+}
+
+func TestMaxPlaintextSize(t *testing.T) {
+	if ^uint(0)>>32 == 0 {
+		t.Skip("int cannot hold MaxPlaintextSize")
+	}
+	max := uint64(MaxPlaintextSize)
+	if exceedsPlaintextSize(int(max), 0) || exceedsPlaintextSize(int(max)+TagSize, TagSize) {
+		t.Fatal("MaxPlaintextSize is rejected")
+	}
+	if !exceedsPlaintextSize(int(max)+1, 0) || !exceedsPlaintextSize(int(max)+13, 12) {
+		t.Fatal("MaxPlaintextSize + 1 is accepted")
+	}
+	// The last plaintext byte uses the last block of the 32 bit counter.
+	if blocks := 1 + (max+63)/64; blocks != 1<<32 {
+		t.Fatalf("MaxPlaintextSize requires %d blocks - want %d", blocks, uint64(1<<32))
+	}
+}
+
+func TestConcurrentSeal(t *testing.T) {
+	var key [32]byte
+	var nonce [NonceSize]byte
+	c := NewChaCha20Poly1305(&key)
+	msg := make([]byte, 300)
+	expected := c.Seal(nil, nonce[:], msg, nil)
+
+	errc := make(chan error)
+	for i := 0; i < 4; i++ {
+		go func() {
+			var nonce [NonceSize]byte
+			dst := make([]byte, 0, len(expected))
+			for j := 0; j < 100; j++ {
+				dst = c.Seal(dst[:0], nonce[:], msg, nil)
+				if !bytes.Equal(dst, expected) {
+					errc <- errors.New("concurrent Seal produced unexpected ciphertext")
+					return
+				}
+				if _, err := c.Open(nil, nonce[:], dst, nil); err != nil {
+					errc <- err
+					return
+				}
+			}
+			errc <- nil
+		}()
+	}
+	for i := 0; i < 4; i++ {
+		if err := <-errc; err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestAEADAllocs(t *testing.T) {
+	var key [32]byte
+	aeads := map[string]cipher.AEAD{
+		"ChaCha20Poly1305":       NewChaCha20Poly1305(&key),
+		"XChaCha20Poly1305":      NewXChaCha20Poly1305(&key),
+		"ChaCha20Poly1305Legacy": NewChaCha20Poly1305Legacy(&key),
+	}
+	for name, c := range aeads {
+		nonce := make([]byte, c.NonceSize())
+		msg, data := make([]byte, 100), make([]byte, 13)
+		ciphertext := c.Seal(nil, nonce, msg, data)
+		buf := make([]byte, 0, len(ciphertext))
+
+		if n := testing.AllocsPerRun(10, func() { c.Seal(buf[:0], nonce, msg, data) }); n > 0 {
+			t.Fatalf("%s: Seal allocates %v times", name, n)
+		}
+		if n := testing.AllocsPerRun(10, func() { c.Open(buf[:0], nonce, ciphertext, data) }); n > 0 {
+			t.Fatalf("%s: Open allocates %v times", name, n)
+		}
+	}
+}
+
+func TestAuthenticate(t *testing.T) {
+	for _, b := range []byte{0x00, 0x5a, 0xff} {
+		var key [32]byte
+		for i := range key {
+			key[i] = b ^ byte(i)
+		}
+		data := make([]byte, 4200)
+		for i := range data {
+			data[i] = b ^ byte(3*i)
+		}
+
+		for _, adLen := range []int{0, 1, 15, 16, 17, 33} {
+			for _, ctLen := range []int{0, 1, 15, 16, 31, 64, 255, 256, 511, 1000, 4096 + 15} {
+				additionalData, ciphertext := data[:adLen], data[adLen:adLen+ctLen]
+
+				msg := append([]byte{}, additionalData...)
+				msg = append(msg, make([]byte, (16-adLen%16)%16)...)
+				msg = append(msg, ciphertext...)
+				msg = append(msg, make([]byte, (16-ctLen%16)%16)...)
+				lengths := aeadLengths(adLen, ctLen)
+				msg = append(msg, lengths[:]...)
+
+				var tag, expected [TagSize]byte
+				poly1305.Sum(&expected, msg, &key)
+				authenticate(&tag, ciphertext, additionalData, &key)
+				if tag != expected {
+					t.Fatalf("AD: %d bytes CT: %d bytes: authenticate produces unexpected tag", adLen, ctLen)
+				}
+			}
+		}
+	}
+}
+
+func TestSealOpenBlock(t *testing.T) {
+	var key [32]byte
+	for i := range key {
+		key[i] = byte(i)
+	}
+	aeads := []*aead{newAEAD(&key, TagSize, false), newAEAD(&key, 12, false), newAEAD(&key, TagSize, true)}
+	for _, c := range aeads {
+		nonce := make([]byte, c.NonceSize())
+		nonce[0] = 1
+		msg, data := make([]byte, 65), []byte("additional data")
+		for i := range msg {
+			msg[i] = byte(i)
+		}
+
+		for n := 0; n <= len(msg); n++ {
+			sealed := c.Seal(nil, nonce, msg[:n], data)
+			expected := c.seal(c.engine(), nil, nonce, msg[:n], data)
+			if !bytes.Equal(sealed, expected) {
+				t.Fatalf("Size %d: Seal produces unexpected ciphertext", n)
+			}
+
+			plaintext, err := c.Open(nil, nonce, sealed, data)
+			if err != nil {
+				t.Fatalf("Size %d: Open failed: %v", n, err)
+			}
+			if !bytes.Equal(plaintext, msg[:n]) {
+				t.Fatalf("Size %d: Open produces unexpected plaintext", n)
+			}
+
+			sealed[0] ^= 1
+			if _, err = c.Open(nil, nonce, sealed, data); err == nil {
+				t.Fatalf("Size %d: Open accepted modified ciphertext", n)
+			}
+		}
+	}
+}
+
+func TestOpenSmall(t *testing.T) {
+	var key [32]byte
+	for i := range key {
+		key[i] = byte(i)
+	}
+	aeads := []*aead{newAEAD(&key, TagSize, false), newAEAD(&key, 12, false)}
+	msg, data := make([]byte, openSmallMaxLength+1), make([]byte, openSmallMaxLength+1)
+	for i := range msg {
+		msg[i], data[i] = byte(i), byte(255-i)
+	}
+	for _, c := range aeads {
+		nonce := make([]byte, c.NonceSize())
+		for _, adLen := range []int{0, 15, openSmallMaxLength, openSmallMaxLength + 1} {
+			for n := 0; n <= len(msg); n++ {
+				sealed := c.Seal(nil, nonce, msg[:n], data[:adLen])
+				expected, err := c.open(c.engine(), nil, nonce, sealed, data[:adLen])
+				if err != nil {
+					t.Fatalf("AD: %d bytes Size %d: open failed: %v", adLen, n, err)
+				}
+				plaintext, err := c.Open(nil, nonce, sealed, data[:adLen])
+				if err != nil {
+					t.Fatalf("AD: %d bytes Size %d: Open failed: %v", adLen, n, err)
+				}
+				if !bytes.Equal(plaintext, expected) {
+					t.Fatalf("AD: %d bytes Size %d: Open produces unexpected plaintext", adLen, n)
+				}
+
+				// Open must not write the plaintext if the authentication fails
+				sealed[len(sealed)-1] ^= 1
+				dst := make([]byte, n)
+				for i := range dst {
+					dst[i] = 0xff
+				}
+				if _, err = c.Open(dst[:0], nonce, sealed, data[:adLen]); err == nil {
+					t.Fatalf("AD: %d bytes Size %d: Open accepted modified ciphertext", adLen, n)
+				}
+				for i := range dst {
+					if dst[i] != 0xff {
+						t.Fatalf("AD: %d bytes Size %d: Open wrote plaintext of a modified ciphertext", adLen, n)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestKey(t *testing.T) {
+	var key [32]byte
+	for i := range key {
+		key[i] = byte(i)
+	}
+	k := NewKey(&key)
+	k12, err := k.ChaCha20Poly1305WithTagSize(12)
+	if err != nil {
+		t.Fatalf("ChaCha20Poly1305WithTagSize failed: %v", err)
+	}
+	if _, err = k.ChaCha20Poly1305WithTagSize(TagSize + 1); err == nil {
+		t.Fatalf("ChaCha20Poly1305WithTagSize accepted invalid tag size")
+	}
+	c12, _ := NewChaCha20Poly1305WithTagSize(&key, 12)
+	aeads := [][2]cipher.AEAD{
+		{k.ChaCha20Poly1305(), NewChaCha20Poly1305(&key)},
+		{k12, c12},
+		{k.XChaCha20Poly1305(), NewXChaCha20Poly1305(&key)},
+	}
+	key[0] ^= 1 // the Key must hold its own copy
+
+	msg, data := make([]byte, 1024), []byte("additional data")
+	for i := range msg {
+		msg[i] = byte(i)
+	}
+	var wg sync.WaitGroup
+	errs := make(chan error, 4*len(aeads))
+	for i := 0; i < 4; i++ {
+		for j := range aeads {
+			wg.Add(1)
+			go func(c, ref cipher.AEAD, nonce byte) {
+				defer wg.Done()
+				n := make([]byte, c.NonceSize())
+				n[0] = nonce
+				for _, size := range []int{0, 64, 200, len(msg)} {
+					sealed := c.Seal(nil, n, msg[:size], data)
+					if !bytes.Equal(sealed, ref.Seal(nil, n, msg[:size], data)) {
+						errs <- fmt.Errorf("Size %d: Seal produces unexpected ciphertext", size)
+						return
+					}
+					if _, err := c.Open(nil, n, sealed, data); err != nil {
+						errs <- fmt.Errorf("Size %d: Open failed: %v", size, err)
+						return
+					}
+				}
+			}(aeads[j][0], aeads[j][1], byte(i))
+		}
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatal(err)
+	}
+}
+
+func TestClose(t *testing.T) {
+	var key [32]byte
+	for i := range key {
+		key[i] = byte(i)
+	}
+	k := NewKey(&key)
+	aeads := []cipher.AEAD{NewChaCha20Poly1305(&key), NewXChaCha20Poly1305(&key), k.ChaCha20Poly1305(), k.XChaCha20Poly1305()}
+	sealed := make([][]byte, len(aeads))
+	for i, c := range aeads {
+		sealed[i] = c.Seal(nil, make([]byte, c.NonceSize()), make([]byte, 100), nil)
+	}
+	for i, c := range aeads {
+		nonce, sealed := make([]byte, c.NonceSize()), sealed[i]
+		if err := c.(io.Closer).Close(); err != nil {
+			t.Fatalf("AEAD %d: Close failed: %v", i, err)
+		}
+		if _, err := c.Open(nil, nonce, sealed, nil); err != errKeyWiped {
+			t.Fatalf("AEAD %d: Open returns %v after Close - want %v", i, err, errKeyWiped)
+		}
+		if c.(Verifier).Verify(nonce, sealed[:100], nil, sealed[100:]) {
+			t.Fatalf("AEAD %d: Verify succeeds after Close", i)
+		}
+		func() {
+			defer recFunc(t, "Seal after Close")
+			c.Seal(nil, nonce, nil, nil)
+		}()
+	}
+	if *k.key != [32]byte{} {
+		t.Fatal("Close doesn't wipe the shared key")
+	}
+	if _, err := k.ChaCha20Poly1305().Open(nil, make([]byte, NonceSize), make([]byte, TagSize), nil); err != errKeyWiped {
+		t.Fatal("AEADs sharing a closed key remain usable")
+	}
+}
+
+func TestLockedKey(t *testing.T) {
+	var key [32]byte
+	for i := range key {
+		key[i] = byte(i)
+	}
+	k, err := NewLockedKey(&key)
+	if err != nil {
+		t.Skipf("Locked memory is not available: %v", err)
+	}
+	c, ref := k.XChaCha20Poly1305(), NewXChaCha20Poly1305(&key)
+	nonce, msg := make([]byte, NonceSizeX), make([]byte, 200)
+	for i := 0; i < 3; i++ {
+		sealed := c.Seal(nil, nonce, msg, nil)
+		if !bytes.Equal(sealed, ref.Seal(nil, nonce, msg, nil)) {
+			t.Fatal("Seal produces unexpected ciphertext with a locked key")
+		}
+		if _, err := c.Open(nil, nonce, sealed, nil); err != nil {
+			t.Fatalf("Open failed with a locked key: %v", err)
+		}
+	}
+	if engine := k.subEngines.Get(); engine != nil {
+		t.Fatal("Engines of a locked key are pooled")
+	}
+
+	// A locked key has no cached state, so the AEADs must key their engines
+	// and the single block path from the locked key.
+	c, ref = k.ChaCha20Poly1305(), NewChaCha20Poly1305(&key)
+	nonce = make([]byte, NonceSize)
+	for _, size := range []int{0, 64, 100, len(msg)} {
+		sealed := c.Seal(nil, nonce, msg[:size], nil)
+		if !bytes.Equal(sealed, ref.Seal(nil, nonce, msg[:size], nil)) {
+			t.Fatalf("Size %d: Seal produces unexpected ciphertext with a locked key", size)
+		}
+		if _, err := c.Open(nil, nonce, sealed, nil); err != nil {
+			t.Fatalf("Size %d: Open failed with a locked key: %v", size, err)
+		}
+	}
+	if k.state != nil {
+		t.Fatal("A locked key keeps a copy of the key outside the locked memory")
+	}
+
+	k.Wipe()
+	if k.locked != nil || *k.key != [32]byte{} {
+		t.Fatal("Wipe doesn't release the locked memory")
+	}
+}
+
+func TestCheckTag(t *testing.T) {
+	var sum [TagSize]byte
+	for i := range sum {
+		sum[i] = byte(i)
+	}
+	for tagsize := 1; tagsize <= TagSize; tagsize++ {
+		tag := make([]byte, tagsize)
+		for i := range tag {
+			tag[i] = byte(i)
+		}
+		s := sum
+		if !checkTag(&s, tag, tagsize) {
+			t.Fatalf("Tag size %d: checkTag rejects valid tag", tagsize)
+		}
+		if s != [TagSize]byte{} {
+			t.Fatalf("Tag size %d: checkTag doesn't wipe the sum", tagsize)
+		}
+		for i := range tag {
+			tag[i] ^= 0x80
+			s = sum
+			if checkTag(&s, tag, tagsize) {
+				t.Fatalf("Tag size %d: checkTag accepts tag with modified byte %d", tagsize, i)
+			}
+			tag[i] ^= 0x80
+		}
+		s = sum
+		if checkTag(&s, tag[:tagsize-1], tagsize) {
+			t.Fatalf("Tag size %d: checkTag accepts truncated tag", tagsize)
+		}
+	}
+}
+
+// timing enables the timing tests, which measure wall-clock time and may
+// fail on loaded machines: go test -run Timing -timing
+var timing = flag.Bool("timing", false, "run the timing tests")
+
+// TestOpenTiming compares the timing of failing Opens of tags which
+// differ from the valid tag in the first or in the last byte using Welch's
+// t-test. The measurements of both classes are interleaved and the slowest
+// samples are dropped. The threshold is large enough to be stable on noisy
+// machines but detects an early-exit tag comparison. Since other processes
+// can distort a single measurement, the test only fails if every attempt
+// exceeds the threshold. The test only runs with the -timing flag.
+func TestOpenTiming(t *testing.T) {
+	if !*timing {
+		t.Skip("Skipping timing test - enable it with -timing")
+	}
+	const (
+		samples   = 20000
+		batch     = 16
+		threshold = 10
+		attempts  = 3
+	)
+	var key [32]byte
+	var nonce [NonceSize]byte
+	for _, size := range []int{64, 1024} {
+		for _, tagsize := range []int{DefaultMinTagSize, TagSize} {
+			c, _ := NewChaCha20Poly1305WithTagSize(&key, tagsize)
+			sealed := c.Seal(nil, nonce[:], make([]byte, size), nil)
+			first := append([]byte(nil), sealed...)
+			first[size] ^= 1
+			last := append([]byte(nil), sealed...)
+			last[len(last)-1] ^= 1
+
+			var v float64
+			dst := make([]byte, 0, size)
+			for attempt := 0; attempt < attempts; attempt++ {
+				var timings [2][]float64
+				for i := 0; i < 2*samples; i++ {
+					ciphertext := first
+					if i&1 == 1 {
+						ciphertext = last
+					}
+					start := time.Now()
+					for j := 0; j < batch; j++ {
+						c.Open(dst, nonce[:], ciphertext, nil)
+					}
+					timings[i&1] = append(timings[i&1], float64(time.Since(start)))
+				}
+				if v = welchT(timings[0], timings[1]); math.Abs(v) <= threshold {
+					break
+				}
+			}
+			if math.Abs(v) > threshold {
+				t.Fatalf("Size %d, tag size %d: timing of Open depends on the position of the modified tag byte: t = %.2f", size, tagsize, v)
+			}
+		}
+	}
+}
+
+// welchT returns Welch's t statistic of the samples after dropping
+// the slowest 10% of both sets.
+func welchT(a, b []float64) float64 {
+	all := append(append([]float64(nil), a...), b...)
+	sort.Float64s(all)
+	limit := all[len(all)*9/10]
+
+	meanVar := func(x []float64) (mean, variance, n float64) {
+		for _, v := range x {
+			if v <= limit {
+				mean += v
+				n++
+			}
+		}
+		mean /= n
+		for _, v := range x {
+			if v <= limit {
+				variance += (v - mean) * (v - mean)
+			}
+		}
+		return mean, variance / (n - 1), n
+	}
+	ma, va, na := meanVar(a)
+	mb, vb, nb := meanVar(b)
+	return (ma - mb) / math.Sqrt(va/na+vb/nb)
+}
+
+// TestOpenUntouchedDst checks that Open doesn't write to dst - not even
+// to its spare capacity - if the ciphertext is not authentic. If dst
+// aliases the ciphertext, the ciphertext must not change.
+func TestOpenUntouchedDst(t *testing.T) {
+	var key [32]byte
+	for i := range key {
+		key[i] = byte(i)
+	}
+	lockedKey, err := NewLockedKey(&key)
+	if err != nil {
+		lockedKey = NewKey(&key)
+	}
+	defer lockedKey.Wipe()
+	c12, _ := NewChaCha20Poly1305WithTagSize(&key, 12)
+	aeads := map[string]cipher.AEAD{
+		"ChaCha20Poly1305":        NewChaCha20Poly1305(&key),
+		"ChaCha20Poly1305-12":     c12,
+		"XChaCha20Poly1305":       NewXChaCha20Poly1305(&key),
+		"ChaCha20Poly1305-Legacy": NewChaCha20Poly1305Legacy(&key),
+		"ChaCha20Poly1305-Locked": lockedKey.ChaCha20Poly1305(),
+	}
+
+	msg := make([]byte, 1024+17)
+	data := make([]byte, openSmallMaxLength+1)
+	for name, c := range aeads {
+		nonce := make([]byte, c.NonceSize())
+		for _, adLen := range []int{0, 13, len(data)} {
+			for _, n := range []int{0, 1, 64, 65, openSmallMaxLength, openSmallMaxLength + 1, len(msg)} {
+				sealed := c.Seal(nil, nonce, msg[:n], data[:adLen])
+				for _, pos := range []int{0, len(sealed) - 1} {
+					sealed[pos] ^= 1
+					modified := append([]byte(nil), sealed...)
+
+					dst := make([]byte, 3, 3+n+64)
+					for i := range dst[:cap(dst)] {
+						dst[:cap(dst)][i] = 0xff
+					}
+					if _, err := c.Open(dst, nonce, sealed, data[:adLen]); err == nil {
+						t.Fatalf("%s: AD %d bytes: Size %d: Open accepted modified byte %d", name, adLen, n, pos)
+					}
+					for i, v := range dst[:cap(dst)] {
+						if v != 0xff {
+							t.Fatalf("%s: AD %d bytes: Size %d: Open wrote to dst[%d] before authentication", name, adLen, n, i)
+						}
+					}
+
+					if _, err := c.Open(sealed[:0], nonce, sealed, data[:adLen]); err == nil {
+						t.Fatalf("%s: AD %d bytes: Size %d: in-place Open accepted modified byte %d", name, adLen, n, pos)
+					}
+					if !bytes.Equal(sealed, modified) {
+						t.Fatalf("%s: AD %d bytes: Size %d: in-place Open modified the ciphertext before authentication", name, adLen, n)
+					}
+					sealed[pos] ^= 1
+				}
+			}
+		}
+	}
+}
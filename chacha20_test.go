@@ -4,7 +4,10 @@
 
 package chacha20
 
-import "testing"
+import (
+	"bytes"
+	"testing"
+)
 
 func benchmarkCipher(b *testing.B, size int) {
 	var (
@@ -38,3 +41,67 @@ func BenchmarkCipher64(b *testing.B)        { benchmarkCipher(b, 64) }
 func BenchmarkCipher16K(b *testing.B)       { benchmarkCipher(b, 16*1024) }
 func BenchmarkXORKeyStream64(b *testing.B)  { benchmarkXORKeyStream(b, 64) }
 func BenchmarkXORKeyStream16K(b *testing.B) { benchmarkXORKeyStream(b, 16*1024) }
+
+func TestChaCha20Block(t *testing.T) {
+	// Test vector from:
+	// https://tools.ietf.org/html/rfc7539#section-2.3.2
+	var (
+		key   [32]byte
+		nonce [NonceSize]byte
+		block [64]byte
+	)
+	for i := range key {
+		key[i] = byte(i)
+	}
+	copy(nonce[:], fromHex("000000090000004a00000000"))
+	expected := fromHex("10f1e7e4d13b5915500fdd1fa32071c4c7d1f4c733c068030422aa9ac3d46c4e" +
+		"d2826446079faa0914c2d705d98b02a2b5129cd1de164eb9cbd083e8a2503c4e")
+
+	ChaCha20Block(&block, &nonce, &key, 1)
+	if !bytes.Equal(block[:], expected) {
+		t.Fatalf("ChaCha20Block produces unexpected keystream:\nFound   : %x\nExpected: %x", block, expected)
+	}
+}
+
+func TestNewCipherWithCounter(t *testing.T) {
+	var (
+		key   [32]byte
+		nonce [NonceSize]byte
+	)
+	for i := range key {
+		key[i] = byte(i)
+	}
+	buf0, buf1 := make([]byte, 200), make([]byte, 200)
+
+	c := NewCipherWithCounter(&nonce, &key, 1)
+	c.XORKeyStream(buf0[:3], buf0[:3])
+	c.XORKeyStream(buf0[3:], buf0[3:])
+	XORKeyStream(buf1, buf1, &nonce, &key, 1)
+
+	if !bytes.Equal(buf0, buf1) {
+		t.Fatalf("NewCipherWithCounter produces unexpected keystream:\nFound   : %x\nExpected: %x", buf0, buf1)
+	}
+}
+
+func TestNewCipherLegacy(t *testing.T) {
+	var (
+		key   [32]byte
+		nonce [NonceSizeLegacy]byte
+		ietf  [NonceSize]byte
+	)
+	for i := range key {
+		key[i] = byte(i)
+	}
+	nonce[0] = 1
+	copy(ietf[4:], nonce[:])
+	buf0, buf1 := make([]byte, 200), make([]byte, 200)
+
+	c := NewCipherLegacy(&nonce, &key)
+	c.XORKeyStream(buf0[:3], buf0[:3])
+	c.XORKeyStream(buf0[3:], buf0[3:])
+	XORKeyStream(buf1, buf1, &ietf, &key, 0)
+
+	if !bytes.Equal(buf0, buf1) {
+		t.Fatalf("NewCipherLegacy produces unexpected keystream:\nFound   : %x\nExpected: %x", buf0, buf1)
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package chacha20
+
+import (
+	"github.com/aead/chacha20/chacha"
+	"github.com/aead/chacha20/internal/argerr"
+)
+
+// CheckedAEAD is implemented by all cipher.AEAD implementations returned by
+// this package. It's meant for long-running programs which seal messages
+// with parameters received from a peer and must not crash on malformed
+// input.
+type CheckedAEAD interface {
+	// SealChecked is like Seal but returns an error instead of panicking
+	// if the nonce size is invalid, the key has been wiped or the
+	// plaintext exceeds MaxPlaintextSize.
+	SealChecked(dst, nonce, plaintext, additionalData []byte) ([]byte, error)
+}
+
+func (c *aead) SealChecked(dst, nonce, plaintext, additionalData []byte) ([]byte, error) {
+	if n := len(nonce); n != c.NonceSize() {
+		return nil, errInvalidNonceSize
+	}
+	if c.key.wiped {
+		return nil, errKeyWiped
+	}
+	if exceedsPlaintextSize(len(plaintext), 0) {
+		return nil, ErrMessageTooLarge
+	}
+	return c.Seal(dst, nonce, plaintext, additionalData), nil
+}
+
+func (c *xaead) SealChecked(dst, nonce, plaintext, additionalData []byte) ([]byte, error) {
+	if n := len(nonce); n != NonceSizeX {
+		return nil, errInvalidNonceSize
+	}
+	if c.key.wiped {
+		return nil, errKeyWiped
+	}
+	if exceedsPlaintextSize(len(plaintext), 0) {
+		return nil, ErrMessageTooLarge
+	}
+	return c.Seal(dst, nonce, plaintext, additionalData), nil
+}
+
+// Catch calls f and returns the panic of f as error if f panics because
+// of invalid arguments passed to a function of this package or the chacha
+// package - e.g. an invalid nonce size, a too small dst buffer, an odd
+// number of rounds or a keystream exceeding the block counter. Catch
+// returns nil if f returns normally. Other panics - including runtime
+// errors, failed self-tests, internal errors and panics of f itself - are
+// not recovered.
+func Catch(f func()) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			if err = argumentError(r); err == nil {
+				panic(r)
+			}
+		}
+	}()
+	f()
+	return nil
+}
+
+// argumentError returns the error of the panic value v if the panic is
+// caused by invalid arguments and nil otherwise.
+func argumentError(v interface{}) error {
+	switch v := v.(type) {
+	case argerr.Error:
+		return v
+	case error:
+		switch v {
+		case errKeyWiped, ErrMessageTooLarge, chacha.ErrCounterOverflow:
+			return v
+		}
+	}
+	return nil
+}
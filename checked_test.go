@@ -0,0 +1,80 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package chacha20
+
+import (
+	"bytes"
+	"crypto/cipher"
+	"errors"
+	"testing"
+
+	"github.com/aead/chacha20/chacha"
+)
+
+func TestSealChecked(t *testing.T) {
+	var key [32]byte
+	aeads := []cipher.AEAD{
+		NewChaCha20Poly1305(&key),
+		NewXChaCha20Poly1305(&key),
+		NewChaCha20Poly1305Legacy(&key),
+	}
+	for i, c := range aeads {
+		checked, ok := c.(CheckedAEAD)
+		if !ok {
+			t.Fatalf("AEAD %d: does not implement CheckedAEAD", i)
+		}
+		nonce := make([]byte, c.NonceSize())
+		plaintext := make([]byte, 100)
+		sealed, err := checked.SealChecked(nil, nonce, plaintext, nil)
+		if err != nil {
+			t.Fatalf("AEAD %d: SealChecked failed: %v", i, err)
+		}
+		if !bytes.Equal(sealed, c.Seal(nil, nonce, plaintext, nil)) {
+			t.Fatalf("AEAD %d: SealChecked differs from Seal", i)
+		}
+		if _, err = checked.SealChecked(nil, nonce[1:], plaintext, nil); err != errInvalidNonceSize {
+			t.Fatalf("AEAD %d: SealChecked accepted an invalid nonce: %v", i, err)
+		}
+	}
+
+	c := NewChaCha20Poly1305(&key)
+	c.(interface{ Close() error }).Close()
+	if _, err := c.(CheckedAEAD).SealChecked(nil, make([]byte, NonceSize), nil, nil); err != errKeyWiped {
+		t.Fatalf("SealChecked accepted a wiped key: %v", err)
+	}
+}
+
+func TestCatch(t *testing.T) {
+	var key [32]byte
+	var nonce [12]byte
+	buf := make([]byte, 65)
+
+	if err := Catch(func() { NewChaCha20Poly1305(&key).Seal(nil, nonce[:8], nil, nil) }); err == nil {
+		t.Fatal("Catch: invalid nonce size not returned as error")
+	}
+	if err := Catch(func() { chacha.XORKeyStream(buf[:1], buf, &nonce, &key, 0, 20) }); err == nil {
+		t.Fatal("Catch: short dst not returned as error")
+	}
+	if err := Catch(func() { chacha.XORKeyStream(buf, buf, &nonce, &key, 0, 7) }); err == nil {
+		t.Fatal("Catch: odd rounds not returned as error")
+	}
+	if err := Catch(func() { chacha.XORKeyStream(buf, buf, &nonce, &key, 0xFFFFFFFF, 20) }); err != chacha.ErrCounterOverflow {
+		t.Fatalf("Catch: unexpected counter overflow error: %v", err)
+	}
+	if err := Catch(func() {}); err != nil {
+		t.Fatalf("Catch: unexpected error: %v", err)
+	}
+
+	for _, v := range []interface{}{"foreign", "chacha20: foreign", errors.New("chacha20/chacha: foreign")} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Fatalf("Catch recovered the foreign panic %q", v)
+				}
+			}()
+			Catch(func() { panic(v) })
+		}()
+	}
+}
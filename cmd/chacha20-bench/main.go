@@ -0,0 +1,129 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+// Command chacha20-bench measures the throughput of all ChaCha20 backends
+// supported by the CPU across message sizes and prints a table in MB/s.
+// The table can be attached to performance reports.
+//
+// Usage:
+//
+//	chacha20-bench [-sizes 64,1024,...] [-aead] [-rounds 8|12|20]
+//
+// With -aead the table shows the ChaCha20Poly1305 Seal throughput instead
+// of the XORKeyStream throughput.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+	"text/tabwriter"
+
+	"github.com/aead/chacha20"
+	"github.com/aead/chacha20/chacha"
+)
+
+var errInvalidSizes = errors.New("sizes must be a comma separated list of positive integers")
+
+func main() {
+	var (
+		sizeList = flag.String("sizes", "64,256,1024,8192,65536,1048576", "comma separated list of message `sizes` in bytes")
+		aead     = flag.Bool("aead", false, "measure ChaCha20Poly1305 Seal instead of XORKeyStream")
+		rounds   = flag.Int("rounds", 20, "number of ChaCha rounds used by XORKeyStream")
+	)
+	flag.Parse()
+	sizes, err := parseSizes(*sizeList)
+	if err != nil || flag.NArg() > 0 || *rounds <= 0 || *rounds%2 != 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	b := benchmarkXORKeyStream(*rounds)
+	if *aead {
+		b = benchmarkSeal
+	}
+	printTable(os.Stdout, sizes, chacha.SupportedBackends(), func(backend chacha.Backend, size int) float64 {
+		return throughput(backend, size, b)
+	})
+}
+
+func parseSizes(list string) ([]int, error) {
+	var sizes []int
+	for _, s := range strings.Split(list, ",") {
+		size, err := strconv.Atoi(strings.TrimSpace(s))
+		if err != nil || size <= 0 {
+			return nil, errInvalidSizes
+		}
+		sizes = append(sizes, size)
+	}
+	return sizes, nil
+}
+
+// printTable prints the platform followed by a table with one row per
+// size and one column per backend.
+func printTable(w io.Writer, sizes []int, backends []chacha.Backend, measure func(chacha.Backend, int) float64) {
+	fmt.Fprintf(w, "%s %s/%s, %d CPUs, default backend: %s\n\n", runtime.Version(), runtime.GOOS, runtime.GOARCH, runtime.NumCPU(), chacha.ActiveBackend())
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprint(tw, "Size\t")
+	for _, backend := range backends {
+		fmt.Fprintf(tw, "%s\t", backend)
+	}
+	fmt.Fprintln(tw)
+	for _, size := range sizes {
+		fmt.Fprintf(tw, "%d\t", size)
+		for _, backend := range backends {
+			fmt.Fprintf(tw, "%.1f\t", measure(backend, size))
+		}
+		fmt.Fprintln(tw)
+	}
+	tw.Flush()
+}
+
+// throughput returns the throughput of the benchmark for the backend
+// and the size in MB/s.
+func throughput(backend chacha.Backend, size int, benchmark func(b *testing.B, size int)) float64 {
+	defer chacha.SetBackend(chacha.ActiveBackend())
+	if err := chacha.SetBackend(backend); err != nil {
+		return 0
+	}
+	r := testing.Benchmark(func(b *testing.B) { benchmark(b, size) })
+	if r.T <= 0 {
+		return 0
+	}
+	return float64(r.Bytes) * float64(r.N) / r.T.Seconds() / 1e6
+}
+
+func benchmarkXORKeyStream(rounds int) func(b *testing.B, size int) {
+	return func(b *testing.B, size int) {
+		var (
+			key   [32]byte
+			nonce [12]byte
+		)
+		buf := make([]byte, size)
+		b.SetBytes(int64(size))
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			chacha.XORKeyStream(buf, buf, &nonce, &key, 0, rounds)
+		}
+	}
+}
+
+func benchmarkSeal(b *testing.B, size int) {
+	var key [32]byte
+	c := chacha20.NewChaCha20Poly1305(&key)
+	nonce := make([]byte, c.NonceSize())
+	buf := make([]byte, size+c.Overhead())
+	b.SetBytes(int64(size))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c.Seal(buf[:0], nonce, buf[:size], nil)
+	}
+}
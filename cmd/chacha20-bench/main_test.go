@@ -0,0 +1,57 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/aead/chacha20/chacha"
+)
+
+func TestParseSizes(t *testing.T) {
+	sizes, err := parseSizes("64, 1024,7")
+	if err != nil || !reflect.DeepEqual(sizes, []int{64, 1024, 7}) {
+		t.Fatalf("parseSizes returns %v: %v", sizes, err)
+	}
+	for _, list := range []string{"", "64,", "0", "-1", "1k"} {
+		if _, err := parseSizes(list); err == nil {
+			t.Fatalf("parseSizes accepted %q", list)
+		}
+	}
+}
+
+func TestPrintTable(t *testing.T) {
+	var buf bytes.Buffer
+	backends := chacha.SupportedBackends()
+	printTable(&buf, []int{64, 1024}, backends, func(b chacha.Backend, size int) float64 { return float64(size) })
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 5 {
+		t.Fatalf("printTable prints %d lines - want 5:\n%s", len(lines), buf.String())
+	}
+	for _, b := range backends {
+		if !strings.Contains(lines[2], b.String()) {
+			t.Fatalf("Table header doesn't contain backend %s: %q", b, lines[2])
+		}
+	}
+	if fields := strings.Fields(lines[4]); len(fields) != 1+len(backends) || fields[0] != "1024" || fields[1] != "1024.0" {
+		t.Fatalf("Unexpected table row: %q", lines[4])
+	}
+}
+
+func TestThroughput(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping benchmark in short mode")
+	}
+	if v := throughput(chacha.Generic, 64, benchmarkSeal); v <= 0 {
+		t.Fatalf("throughput returns %f", v)
+	}
+	if v := throughput(chacha.Backend(-1), 64, benchmarkSeal); v != 0 {
+		t.Fatalf("throughput of an unsupported backend is %f", v)
+	}
+}
@@ -0,0 +1,175 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+// Command chacha20-keystream writes ChaCha keystream to stdout as fast as
+// the CPU can produce it. It can replace /dev/urandom for disk wiping,
+// load testing and benchmarking.
+//
+// Usage:
+//
+//	chacha20-keystream [-n BYTES] [-rounds 8|12|20] [-v]
+//	chacha20-keystream -key HEX [-nonce HEX] [-counter N] [-n BYTES] [-rounds 8|12|20] [-v]
+//
+// Without -key the output is cryptographically secure random data: The key
+// and the nonce are read from crypto/rand and replaced after every 64 GiB.
+// With -key the output is the keystream of the given key and nonce, which
+// is limited to 256 GiB by the 32 bit block counter. Without -n the output
+// is unlimited or ends at the end of the keystream. The keystream is
+// generated by multiple goroutines using chacha.XORKeyStreamParallel.
+package main
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/aead/chacha20/chacha"
+)
+
+const (
+	bufferSize = 4 * 1024 * 1024
+
+	// rekeyInterval is the number of random bytes produced with one key.
+	rekeyInterval = 64 * 1024 * 1024 * 1024
+
+	// maxKeystream is the max. keystream of one key and nonce.
+	maxKeystream = 1 << 38
+)
+
+var (
+	errInvalidKey     = errors.New("key must be 64 hex characters")
+	errInvalidNonce   = errors.New("nonce must be 24 hex characters")
+	errInvalidRounds  = errors.New("rounds must be 8, 12 or 20")
+	errKeystreamLimit = errors.New("keystream of one key and nonce is limited to 256 GiB - reduce -n or -counter")
+)
+
+func main() {
+	var (
+		n       = flag.Int64("n", -1, "write `BYTES` bytes - unlimited if negative")
+		rounds  = flag.Int("rounds", 20, "number of ChaCha rounds: 8, 12 or 20")
+		keyHex  = flag.String("key", "", "write the keystream of the hex encoded `KEY` instead of random data")
+		nonce   = flag.String("nonce", "", "hex encoded `NONCE` used with -key - all zero by default")
+		counter = flag.Uint("counter", 0, "initial block `N` used with -key")
+		verbose = flag.Bool("v", false, "print the throughput to stderr")
+	)
+	flag.Parse()
+	if flag.NArg() > 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	g, err := newGenerator(*keyHex, *nonce, uint64(*counter), *rounds, *n)
+	exitOnError(err)
+
+	start := time.Now()
+	written, err := g.writeTo(os.Stdout)
+	if *verbose {
+		d := time.Since(start)
+		fmt.Fprintf(os.Stderr, "%d bytes in %v (%.1f MB/s)\n", written, d, float64(written)/d.Seconds()/1e6)
+	}
+	exitOnError(err)
+}
+
+func exitOnError(err error) {
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "chacha20-keystream: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// generator produces the keystream of a key and a nonce. If random is
+// set the key and the nonce are replaced after every rekeyInterval bytes.
+type generator struct {
+	key     [32]byte
+	nonce   [12]byte
+	counter uint64 // next block
+	rounds  int
+	random  bool
+	n       uint64 // bytes produced with the current key
+	left    int64  // bytes left to produce - unlimited if negative
+}
+
+// newGenerator returns a generator for the hex encoded key and nonce or - if
+// key is empty - a generator of random data. n is the number of bytes which
+// will be produced or negative if unlimited.
+func newGenerator(key, nonce string, counter uint64, rounds int, n int64) (*generator, error) {
+	if rounds != 8 && rounds != 12 && rounds != 20 {
+		return nil, errInvalidRounds
+	}
+	g := &generator{rounds: rounds, random: key == "", left: n}
+	if g.random {
+		return g, g.rekey()
+	}
+
+	b, err := hex.DecodeString(key)
+	if err != nil || len(b) != len(g.key) {
+		return nil, errInvalidKey
+	}
+	copy(g.key[:], b)
+	if nonce != "" {
+		b, err = hex.DecodeString(nonce)
+		if err != nil || len(b) != len(g.nonce) {
+			return nil, errInvalidNonce
+		}
+		copy(g.nonce[:], b)
+	}
+	if counter >= 1<<32 {
+		return nil, errKeystreamLimit
+	}
+	if max := int64(maxKeystream - 64*counter); n < 0 {
+		g.left = max
+	} else if n > max {
+		return nil, errKeystreamLimit
+	}
+	g.counter = counter
+	return g, nil
+}
+
+// rekey reads a new key and nonce from crypto/rand.
+func (g *generator) rekey() error {
+	if _, err := io.ReadFull(rand.Reader, g.key[:]); err != nil {
+		return err
+	}
+	if _, err := io.ReadFull(rand.Reader, g.nonce[:]); err != nil {
+		return err
+	}
+	g.counter, g.n = 0, 0
+	return nil
+}
+
+// writeTo writes the keystream to w until the generator is exhausted or
+// w returns an error. It returns the number of bytes written.
+func (g *generator) writeTo(w io.Writer) (int64, error) {
+	zero, buf := make([]byte, bufferSize), make([]byte, bufferSize)
+	var written int64
+	for g.left != 0 {
+		size := len(buf)
+		if g.left > 0 && g.left < int64(size) {
+			size = int(g.left)
+		}
+		if g.random && g.n+uint64(size) > rekeyInterval {
+			if err := g.rekey(); err != nil {
+				return written, err
+			}
+		}
+		chacha.XORKeyStreamParallel(buf[:size], zero[:size], &g.nonce, &g.key, uint32(g.counter), g.rounds)
+		g.counter += uint64(size) / 64
+		g.n += uint64(size)
+		if g.left > 0 {
+			g.left -= int64(size)
+		}
+
+		m, err := w.Write(buf[:size])
+		written += int64(m)
+		if err != nil {
+			return written, err
+		}
+	}
+	return written, nil
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"io/ioutil"
+	"testing"
+
+	"github.com/aead/chacha20/chacha"
+)
+
+func TestKeystream(t *testing.T) {
+	var key [32]byte
+	var nonce [12]byte
+	for i := range key {
+		key[i] = byte(i)
+	}
+	nonce[11] = 1
+	for _, rounds := range []int{8, 12, 20} {
+		for _, size := range []int64{0, 1, 64, bufferSize + 17} {
+			g, err := newGenerator(hex.EncodeToString(key[:]), hex.EncodeToString(nonce[:]), 7, rounds, size)
+			if err != nil {
+				t.Fatalf("ChaCha%d: Size %d: newGenerator failed: %v", rounds, size, err)
+			}
+			var buf bytes.Buffer
+			if n, err := g.writeTo(&buf); err != nil || n != size {
+				t.Fatalf("ChaCha%d: Size %d: writeTo wrote %d bytes: %v", rounds, size, n, err)
+			}
+			expected := make([]byte, size)
+			chacha.XORKeyStream(expected, expected, &nonce, &key, 7, rounds)
+			if !bytes.Equal(buf.Bytes(), expected) {
+				t.Fatalf("ChaCha%d: Size %d: writeTo produces unexpected keystream", rounds, size)
+			}
+		}
+	}
+}
+
+func TestRandom(t *testing.T) {
+	g0, err := newGenerator("", "", 0, 20, 1024)
+	if err != nil {
+		t.Fatalf("newGenerator failed: %v", err)
+	}
+	g1, _ := newGenerator("", "", 0, 20, 1024)
+	var buf0, buf1 bytes.Buffer
+	if n, err := g0.writeTo(&buf0); err != nil || n != 1024 {
+		t.Fatalf("writeTo wrote %d bytes: %v", n, err)
+	}
+	g1.writeTo(&buf1)
+	if bytes.Equal(buf0.Bytes(), buf1.Bytes()) {
+		t.Fatal("Two random generators produce the same output")
+	}
+
+	// The generator rekeys before the interval is exceeded.
+	g0, _ = newGenerator("", "", 0, 20, 64)
+	key := g0.key
+	g0.n = rekeyInterval - 63
+	g0.writeTo(ioutil.Discard)
+	if g0.key == key || g0.n != 64 {
+		t.Fatal("Generator doesn't rekey after the rekey interval")
+	}
+}
+
+func TestGeneratorLimits(t *testing.T) {
+	key := hex.EncodeToString(make([]byte, 32))
+	g, err := newGenerator(key, "", 1<<32-1, 20, -1)
+	if err != nil {
+		t.Fatalf("newGenerator failed: %v", err)
+	}
+	if n, err := g.writeTo(ioutil.Discard); err != nil || n != 64 {
+		t.Fatalf("writeTo wrote %d bytes of the last block: %v", n, err)
+	}
+
+	for _, args := range []struct {
+		key, nonce string
+		counter    uint64
+		rounds     int
+		n          int64
+	}{
+		{key: key[2:], n: 1, rounds: 20},
+		{key: key, nonce: "00", n: 1, rounds: 20},
+		{key: key, n: 1, rounds: 10},
+		{key: key, counter: 1 << 32, n: 1, rounds: 20},
+		{key: key, counter: 1<<32 - 1, n: 65, rounds: 20},
+	} {
+		if _, err := newGenerator(args.key, args.nonce, args.counter, args.rounds, args.n); err == nil {
+			t.Fatalf("newGenerator accepted invalid arguments: %+v", args)
+		}
+	}
+}
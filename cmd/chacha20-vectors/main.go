@@ -0,0 +1,196 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+// Command chacha20-vectors generates ChaCha20, XChaCha20, ChaCha20Poly1305
+// and XChaCha20Poly1305 test vectors and writes them as JSON. The vectors
+// are derived deterministically from a seed, so implementations in other
+// languages can be cross-checked against this package programmatically.
+//
+// Usage:
+//
+//	chacha20-vectors [-seed N] [-n COUNT] [-out FILE]
+//
+// All byte strings are hex encoded. The AEAD ciphertexts don't include the
+// 16 byte tag. The XChaCha20 keystream is the ChaCha20 keystream of the
+// HChaCha20 sub-key of the first 16 nonce bytes and of the nonce consisting
+// of 4 zero bytes followed by the last 8 nonce bytes.
+package main
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/aead/chacha20"
+	"github.com/aead/chacha20/chacha"
+)
+
+// sizes are the plaintext and additional data sizes of the vectors.
+// They are used in turn and cover the block boundaries and the boundaries
+// of the SIMD implementations.
+var sizes = []int{0, 1, 15, 16, 17, 63, 64, 65, 127, 128, 129, 255, 256, 257, 511, 512, 513, 1024, 1025, 4097}
+
+type hexBytes []byte
+
+func (b hexBytes) MarshalJSON() ([]byte, error) { return json.Marshal(hex.EncodeToString(b)) }
+
+func (b *hexBytes) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	v, err := hex.DecodeString(s)
+	*b = v
+	return err
+}
+
+type streamVector struct {
+	Key        hexBytes `json:"key"`
+	Nonce      hexBytes `json:"nonce"`
+	Counter    uint32   `json:"counter"`
+	Rounds     int      `json:"rounds"`
+	Plaintext  hexBytes `json:"plaintext"`
+	Ciphertext hexBytes `json:"ciphertext"`
+}
+
+type aeadVector struct {
+	Key        hexBytes `json:"key"`
+	Nonce      hexBytes `json:"nonce"`
+	AD         hexBytes `json:"ad"`
+	Plaintext  hexBytes `json:"plaintext"`
+	Ciphertext hexBytes `json:"ciphertext"`
+	Tag        hexBytes `json:"tag"`
+}
+
+type vectors struct {
+	Seed              int64          `json:"seed"`
+	ChaCha20          []streamVector `json:"chacha20"`
+	XChaCha20         []streamVector `json:"xchacha20"`
+	ChaCha20Poly1305  []aeadVector   `json:"chacha20poly1305"`
+	XChaCha20Poly1305 []aeadVector   `json:"xchacha20poly1305"`
+}
+
+func main() {
+	var (
+		seed = flag.Int64("seed", 1, "generate the vectors of seed `N`")
+		n    = flag.Int("n", 64, "number of vectors of each kind")
+		out  = flag.String("out", "", "write the vectors to `FILE` instead of stdout")
+	)
+	flag.Parse()
+	if flag.NArg() > 0 || *n < 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	w := io.Writer(os.Stdout)
+	if *out != "" {
+		f, err := os.Create(*out)
+		exitOnError(err)
+		defer f.Close()
+		w = f
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	exitOnError(enc.Encode(generate(*seed, *n)))
+}
+
+func exitOnError(err error) {
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "chacha20-vectors: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// generate returns n vectors of each kind derived from the seed.
+func generate(seed int64, n int) *vectors {
+	var (
+		key   [32]byte
+		nonce [8]byte
+	)
+	src := chacha.NewSource(&nonce, &key)
+	src.Seed(seed)
+	random := func(n int) []byte {
+		b := make([]byte, n)
+		for i := range b {
+			b[i] = byte(src.Uint64())
+		}
+		return b
+	}
+
+	v := &vectors{Seed: seed}
+	for i := 0; i < n; i++ {
+		size, adSize := sizes[i%len(sizes)], sizes[(i/2)%len(sizes)]
+		rounds := []int{20, 12, 8}[i%3]
+
+		sv := streamVector{
+			Key:       random(32),
+			Nonce:     random(chacha20.NonceSize),
+			Counter:   uint32(src.Uint64()),
+			Rounds:    rounds,
+			Plaintext: random(size),
+		}
+		if blocks := uint64(size+63) / 64; uint64(sv.Counter)+blocks > 1<<32 {
+			sv.Counter = uint32(1<<32 - blocks)
+		}
+		sv.Ciphertext = xorKeyStream(&sv)
+		v.ChaCha20 = append(v.ChaCha20, sv)
+
+		sv = streamVector{
+			Key:       random(32),
+			Nonce:     random(chacha20.NonceSizeX),
+			Counter:   uint32(i),
+			Rounds:    rounds,
+			Plaintext: random(size),
+		}
+		sv.Ciphertext = xorKeyStream(&sv)
+		v.XChaCha20 = append(v.XChaCha20, sv)
+
+		av := aeadVector{Key: random(32), Nonce: random(chacha20.NonceSize), AD: random(adSize), Plaintext: random(size)}
+		seal(&av)
+		v.ChaCha20Poly1305 = append(v.ChaCha20Poly1305, av)
+
+		av = aeadVector{Key: random(32), Nonce: random(chacha20.NonceSizeX), AD: random(adSize), Plaintext: random(size)}
+		seal(&av)
+		v.XChaCha20Poly1305 = append(v.XChaCha20Poly1305, av)
+	}
+	return v
+}
+
+// xorKeyStream returns the ciphertext of the ChaCha20 or - for a 24 byte
+// nonce - the XChaCha20 vector.
+func xorKeyStream(v *streamVector) []byte {
+	var (
+		key   [32]byte
+		nonce [chacha20.NonceSize]byte
+	)
+	copy(key[:], v.Key)
+	if len(v.Nonce) == chacha20.NonceSizeX {
+		var hNonce [16]byte
+		copy(hNonce[:], v.Nonce[:16])
+		chacha.HChaCha20(&key, &hNonce, &key)
+		copy(nonce[4:], v.Nonce[16:])
+	} else {
+		copy(nonce[:], v.Nonce)
+	}
+	ciphertext := make([]byte, len(v.Plaintext))
+	chacha.XORKeyStream(ciphertext, v.Plaintext, &nonce, &key, v.Counter, v.Rounds)
+	return ciphertext
+}
+
+// seal sets the ciphertext and the tag of the ChaCha20Poly1305 or - for a
+// 24 byte nonce - the XChaCha20Poly1305 vector.
+func seal(v *aeadVector) {
+	var key [32]byte
+	copy(key[:], v.Key)
+	c := chacha20.NewChaCha20Poly1305(&key)
+	if len(v.Nonce) == chacha20.NonceSizeX {
+		c = chacha20.NewXChaCha20Poly1305(&key)
+	}
+	sealed := c.Seal(nil, v.Nonce, v.Plaintext, v.AD)
+	n := len(v.Plaintext)
+	v.Ciphertext, v.Tag = sealed[:n], sealed[n:]
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/aead/chacha20"
+	"github.com/aead/chacha20/chacha/reference"
+)
+
+func TestGenerate(t *testing.T) {
+	v := generate(7, 2*len(sizes))
+	if !reflect.DeepEqual(v, generate(7, 2*len(sizes))) {
+		t.Fatal("generate is not deterministic")
+	}
+	if reflect.DeepEqual(v.ChaCha20, generate(8, 2*len(sizes)).ChaCha20) {
+		t.Fatal("generate produces the same vectors for different seeds")
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("Failed to encode the vectors: %v", err)
+	}
+	var decoded vectors
+	if err = json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Failed to decode the vectors: %v", err)
+	}
+
+	for i, sv := range decoded.ChaCha20 {
+		var key [32]byte
+		var nonce [12]byte
+		copy(key[:], sv.Key)
+		copy(nonce[:], sv.Nonce)
+		expected := make([]byte, len(sv.Plaintext))
+		reference.XORKeyStream(expected, sv.Plaintext, &nonce, &key, sv.Counter, sv.Rounds)
+		if !bytes.Equal(sv.Ciphertext, expected) {
+			t.Fatalf("ChaCha20 vector %d differs from the reference implementation", i)
+		}
+	}
+	for i, sv := range decoded.XChaCha20 {
+		var key [32]byte
+		var hNonce [16]byte
+		var nonce [12]byte
+		copy(key[:], sv.Key)
+		copy(hNonce[:], sv.Nonce)
+		copy(nonce[4:], sv.Nonce[16:])
+		reference.HChaCha20(&key, &hNonce, &key)
+		expected := make([]byte, len(sv.Plaintext))
+		reference.XORKeyStream(expected, sv.Plaintext, &nonce, &key, sv.Counter, sv.Rounds)
+		if !bytes.Equal(sv.Ciphertext, expected) {
+			t.Fatalf("XChaCha20 vector %d differs from the reference implementation", i)
+		}
+	}
+	for i, av := range append(decoded.ChaCha20Poly1305, decoded.XChaCha20Poly1305...) {
+		var key [32]byte
+		copy(key[:], av.Key)
+		c := chacha20.NewChaCha20Poly1305(&key)
+		if len(av.Nonce) == chacha20.NonceSizeX {
+			c = chacha20.NewXChaCha20Poly1305(&key)
+		}
+		plaintext, err := c.Open(nil, av.Nonce, append(av.Ciphertext, av.Tag...), av.AD)
+		if err != nil || !bytes.Equal(plaintext, av.Plaintext) {
+			t.Fatalf("AEAD vector %d cannot be opened: %v", i, err)
+		}
+	}
+}
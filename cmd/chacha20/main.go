@@ -0,0 +1,237 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+// Command chacha20 encrypts and decrypts files using the chunked
+// ChaCha20Poly1305 stream construction of github.com/aead/chacha20.
+//
+// Usage:
+//
+//	chacha20 -genkey FILE
+//	chacha20 [-d] -k KEYFILE [-in FILE] [-out FILE]
+//	chacha20 [-d] -p PASSFILE [-in FILE] [-out FILE]
+//
+// The key file contains 32 raw bytes or 64 hex characters. The passphrase is
+// the first line of the passphrase file or - if the file is "-" - the value
+// of the CHACHA20_PASSPHRASE environment variable. The key is derived from
+// the passphrase using Argon2id. Without -in and -out chacha20 reads from
+// stdin and writes to stdout.
+//
+// An encrypted file is a container of github.com/aead/chacha20 (see
+// NewContainerWriter) with a chunk size of 64 KiB. In the passphrase mode
+// the container stores the salt and the Argon2id parameters - 3 passes
+// over 64 MiB using 4 threads. Decryption rejects containers with larger
+// Argon2id parameters than 64 passes and 2 GiB.
+//
+// Decryption writes the plaintext of every chunk once it is authenticated.
+// If a later chunk is not authentic, chacha20 fails and removes the output
+// file - output written to stdout cannot be taken back.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/aead/chacha20"
+	"golang.org/x/crypto/argon2"
+)
+
+const chunkSize = chacha20.DefaultContainerChunkSize
+
+var (
+	// sealParams are the Argon2id parameters used for encryption.
+	sealParams = chacha20.KDFParams{ID: chacha20.KDFArgon2id, P1: 3, P2: 64 * 1024, P3: 4}
+
+	// openParams are the largest Argon2id parameters accepted for decryption.
+	openParams = chacha20.KDFParams{ID: chacha20.KDFArgon2id, P1: 64, P2: 2 * 1024 * 1024, P3: 255}
+)
+
+var (
+	errInvalidKey   = errors.New("key file must contain 32 bytes or 64 hex characters")
+	errNoPassphrase = errors.New("passphrase is empty")
+)
+
+func main() {
+	var (
+		decrypt  = flag.Bool("d", false, "decrypt the input")
+		keyFile  = flag.String("k", "", "read the key from `FILE`")
+		passFile = flag.String("p", "", "read the passphrase from `FILE` or from $CHACHA20_PASSPHRASE if FILE is -")
+		genKey   = flag.String("genkey", "", "write a new random key to `FILE`")
+		in       = flag.String("in", "", "read the input from `FILE` instead of stdin")
+		out      = flag.String("out", "", "write the output to `FILE` instead of stdout")
+	)
+	flag.Parse()
+	if flag.NArg() > 0 || (*genKey == "") == (*keyFile == "" && *passFile == "") || *keyFile != "" && *passFile != "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if *genKey != "" {
+		exitOnError(generateKey(*genKey))
+		return
+	}
+
+	var k secret
+	var err error
+	if *keyFile != "" {
+		k.key, err = readKeyFile(*keyFile)
+	} else {
+		k.passphrase, err = readPassphrase(*passFile)
+	}
+	exitOnError(err)
+
+	src := io.Reader(os.Stdin)
+	if *in != "" {
+		f, err := os.Open(*in)
+		exitOnError(err)
+		defer f.Close()
+		src = f
+	}
+	dst := io.WriteCloser(os.Stdout)
+	if *out != "" {
+		f, err := os.OpenFile(*out, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+		exitOnError(err)
+		dst = f
+	}
+	w := bufio.NewWriterSize(dst, chunkSize)
+
+	if *decrypt {
+		err = decryptStream(w, bufio.NewReaderSize(src, chunkSize), &k)
+	} else {
+		err = encryptStream(w, src, &k)
+	}
+	if err == nil {
+		err = w.Flush()
+	}
+	if cerr := dst.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil && *out != "" {
+		os.Remove(*out)
+	}
+	exitOnError(err)
+}
+
+func exitOnError(err error) {
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "chacha20: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// secret is either a key read from a key file or a passphrase.
+type secret struct {
+	key        *[32]byte
+	passphrase []byte
+}
+
+// passwordSealer returns the PasswordSealer for the passphrase mode
+// using the Argon2id parameters.
+func passwordSealer(params chacha20.KDFParams) *chacha20.PasswordSealer {
+	return &chacha20.PasswordSealer{KDF: chacha20.Argon2id(argon2.IDKey), Params: params}
+}
+
+// encryptStream writes src as container sealed with the secret to dst.
+func encryptStream(dst io.Writer, src io.Reader, s *secret) error {
+	var w *chacha20.ContainerWriter
+	var err error
+	if s.key != nil {
+		w, err = chacha20.NewContainerWriter(dst, s.key, chunkSize)
+	} else {
+		w, err = passwordSealer(sealParams).NewContainerWriter(dst, s.passphrase, chunkSize)
+	}
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(w, src); err != nil {
+		return err
+	}
+	return w.Close()
+}
+
+// decryptStream reads the container sealed with the secret from src and
+// writes the plaintext to dst.
+func decryptStream(dst io.Writer, src io.Reader, s *secret) error {
+	var r *chacha20.ContainerReader
+	var err error
+	if s.key != nil {
+		r, err = chacha20.NewContainerReader(src, s.key)
+	} else {
+		r, err = passwordSealer(openParams).NewContainerReader(src, s.passphrase)
+	}
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(dst, r)
+	return err
+}
+
+func generateKey(path string) error {
+	var key [32]byte
+	if _, err := io.ReadFull(rand.Reader, key[:]); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err = fmt.Fprintln(f, hex.EncodeToString(key[:])); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func readKeyFile(path string) (*[32]byte, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return parseKey(data)
+}
+
+// parseKey parses 32 raw bytes or 64 hex characters surrounded
+// by white space.
+func parseKey(data []byte) (*[32]byte, error) {
+	var key [32]byte
+	if len(data) == len(key) {
+		copy(key[:], data)
+		return &key, nil
+	}
+	data = bytes.TrimSpace(data)
+	if len(data) != hex.EncodedLen(len(key)) {
+		return nil, errInvalidKey
+	}
+	if _, err := hex.Decode(key[:], data); err != nil {
+		return nil, errInvalidKey
+	}
+	return &key, nil
+}
+
+func readPassphrase(path string) ([]byte, error) {
+	var passphrase []byte
+	if path == "-" {
+		passphrase = []byte(os.Getenv("CHACHA20_PASSPHRASE"))
+	} else {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if i := bytes.IndexAny(data, "\r\n"); i >= 0 {
+			data = data[:i]
+		}
+		passphrase = data
+	}
+	if len(passphrase) == 0 {
+		return nil, errNoPassphrase
+	}
+	return passphrase, nil
+}
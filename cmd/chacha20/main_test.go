@@ -0,0 +1,123 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"crypto/rand"
+	"io/ioutil"
+	"testing"
+
+	"github.com/aead/chacha20"
+)
+
+func encryptTest(t *testing.T, s *secret, plaintext []byte) []byte {
+	var buf bytes.Buffer
+	if err := encryptStream(&buf, bytes.NewReader(plaintext), s); err != nil {
+		t.Fatalf("encryptStream failed: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	key, _ := parseKey(bytes.Repeat([]byte("ab"), 32))
+	secrets := map[string]*secret{
+		"key":        {key: key},
+		"passphrase": {passphrase: []byte("correct horse battery staple")},
+	}
+	for name, s := range secrets {
+		for _, size := range []int{0, 1, chunkSize, 2*chunkSize + 17} {
+			plaintext := make([]byte, size)
+			rand.Read(plaintext)
+			ciphertext := encryptTest(t, s, plaintext)
+
+			var out bytes.Buffer
+			if err := decryptStream(&out, bytes.NewReader(ciphertext), s); err != nil {
+				t.Fatalf("%s: Size %d: decryptStream failed: %v", name, size, err)
+			}
+			if !bytes.Equal(out.Bytes(), plaintext) {
+				t.Fatalf("%s: Size %d: decryptStream produces unexpected plaintext", name, size)
+			}
+			if size != 1 || s.key == nil {
+				continue
+			}
+
+			// Every header byte is bound to the stream key. The passphrase
+			// mode is skipped since every attempt runs Argon2id.
+			for i := 0; i < len(ciphertext); i++ {
+				ciphertext[i] ^= 1
+				if err := decryptStream(&out, bytes.NewReader(ciphertext), s); err == nil {
+					t.Fatalf("%s: decryptStream accepted modified byte %d", name, i)
+				}
+				ciphertext[i] ^= 1
+			}
+			if err := decryptStream(&out, bytes.NewReader(ciphertext[:len(ciphertext)-1]), s); err == nil {
+				t.Fatalf("%s: decryptStream accepted truncated file", name)
+			}
+		}
+	}
+}
+
+func TestDecryptWrongSecret(t *testing.T) {
+	key, _ := parseKey(make([]byte, 32))
+	byKey := encryptTest(t, &secret{key: key}, []byte("Hello"))
+	byPassphrase := encryptTest(t, &secret{passphrase: []byte("passphrase")}, []byte("Hello"))
+
+	var out bytes.Buffer
+	if err := decryptStream(&out, bytes.NewReader(byKey), &secret{passphrase: []byte("passphrase")}); err == nil {
+		t.Fatal("decryptStream accepted a passphrase for a file encrypted with a key")
+	}
+	if err := decryptStream(&out, bytes.NewReader(byPassphrase), &secret{key: key}); err == nil {
+		t.Fatal("decryptStream accepted a key for a file encrypted with a passphrase")
+	}
+	if err := decryptStream(&out, bytes.NewReader(byPassphrase), &secret{passphrase: []byte("Passphrase")}); err == nil {
+		t.Fatal("decryptStream accepted a wrong passphrase")
+	}
+	if err := decryptStream(&out, bytes.NewReader([]byte("not encrypted")), &secret{key: key}); err == nil {
+		t.Fatal("decryptStream accepted an invalid file")
+	}
+	if out.Len() != 0 {
+		t.Fatalf("decryptStream wrote %d bytes of unauthenticated plaintext", out.Len())
+	}
+}
+
+func TestDecryptKDFCost(t *testing.T) {
+	// The KDF is never run by decryptStream, so a fake KDF is sufficient.
+	sealer := &chacha20.PasswordSealer{
+		KDF:    func(password, salt []byte, params chacha20.KDFParams) ([]byte, error) { return make([]byte, 32), nil },
+		Params: openParams,
+	}
+	sealer.Params.P1++
+
+	var buf bytes.Buffer
+	w, err := sealer.NewContainerWriter(&buf, []byte("passphrase"), chunkSize)
+	if err != nil {
+		t.Fatalf("NewContainerWriter failed: %v", err)
+	}
+	if err = w.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if err = decryptStream(ioutil.Discard, &buf, &secret{passphrase: []byte("passphrase")}); err == nil {
+		t.Fatal("decryptStream accepted Argon2id parameters larger than openParams")
+	}
+}
+
+func TestParseKey(t *testing.T) {
+	raw := bytes.Repeat([]byte{0xAB}, 32)
+	for _, data := range [][]byte{raw, bytes.Repeat([]byte("ab"), 32), []byte("  " + string(bytes.Repeat([]byte("AB"), 32)) + "\n")} {
+		key, err := parseKey(data)
+		if err != nil {
+			t.Fatalf("parseKey(%q) failed: %v", data, err)
+		}
+		if !bytes.Equal(key[:], raw) {
+			t.Fatalf("parseKey(%q) returns unexpected key: %x", data, key)
+		}
+	}
+	for _, data := range [][]byte{nil, make([]byte, 31), bytes.Repeat([]byte("xy"), 32)} {
+		if _, err := parseKey(data); err != errInvalidKey {
+			t.Fatalf("parseKey(%q) accepted an invalid key", data)
+		}
+	}
+}
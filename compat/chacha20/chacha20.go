@@ -0,0 +1,146 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+// Package chacha20 provides the API of golang.org/x/crypto/chacha20 backed
+// by github.com/aead/chacha20/chacha. Projects using x/crypto/chacha20 can
+// switch to - or benchmark against - the implementations of this module by
+// replacing the import path. The behavior - including the panics on counter
+// rollback, counter overflow and overlapping buffers - matches x/crypto.
+package chacha20 // import "github.com/aead/chacha20/compat/chacha20"
+
+import (
+	"errors"
+	"unsafe"
+
+	"github.com/aead/chacha20/chacha"
+)
+
+const (
+	// KeySize is the size of the key used by this cipher, in bytes.
+	KeySize = 32
+
+	// NonceSize is the size of the nonce used with the standard variant of
+	// this cipher, in bytes.
+	NonceSize = 12
+
+	// NonceSizeX is the size of the nonce used with the XChaCha20 variant of
+	// this cipher, in bytes.
+	NonceSizeX = 24
+)
+
+// maxKeystream is the keystream of one key and nonce: 2^32 blocks.
+const maxKeystream = 1 << 38
+
+var (
+	errKeySize          = errors.New("chacha20: wrong key size")
+	errNonceSize        = errors.New("chacha20: wrong nonce size")
+	errHChaChaKeySize   = errors.New("chacha20: wrong HChaCha20 key size")
+	errHChaChaNonceSize = errors.New("chacha20: wrong HChaCha20 nonce size")
+)
+
+// Cipher is a stateful instance of ChaCha20 or XChaCha20 using a particular
+// key and nonce. A *Cipher implements the cipher.Stream interface.
+type Cipher struct {
+	c   *chacha.Cipher
+	pos uint64 // keystream bytes used so far
+}
+
+// NewUnauthenticatedCipher creates a new ChaCha20 stream cipher with the given
+// 32 bytes key and a 12 or 24 bytes nonce. If a nonce of 24 bytes is provided,
+// the XChaCha20 construction will be used. It returns an error if key or nonce
+// have any other length.
+//
+// Note that ChaCha20, like all stream ciphers, is not authenticated and allows
+// attackers to silently tamper with the plaintext. For this reason, it is more
+// appropriate as a building block than as a standalone encryption mechanism.
+// Instead, consider using package github.com/aead/chacha20.
+func NewUnauthenticatedCipher(key, nonce []byte) (*Cipher, error) {
+	if len(key) != KeySize {
+		return nil, errKeySize
+	}
+	var k [32]byte
+	var n [NonceSize]byte
+	copy(k[:], key)
+	switch len(nonce) {
+	case NonceSize:
+		copy(n[:], nonce)
+	case NonceSizeX:
+		var hNonce [16]byte
+		copy(hNonce[:], nonce[:16])
+		chacha.HChaCha20(&k, &hNonce, &k)
+		copy(n[4:], nonce[16:])
+	default:
+		return nil, errNonceSize
+	}
+	return &Cipher{c: chacha.NewCipher(&n, &k, 20)}, nil
+}
+
+// SetCounter sets the Cipher counter. The next invocation of XORKeyStream will
+// behave as if (64 * counter) bytes had been encrypted so far.
+//
+// To prevent accidental counter reuse, SetCounter panics if counter is less
+// than the current value.
+func (s *Cipher) SetCounter(counter uint32) {
+	if s.pos == maxKeystream || uint64(counter) < (s.pos+63)/64 {
+		panic("chacha20: SetCounter attempted to rollback counter")
+	}
+	s.c.SetCounter(counter)
+	s.pos = 64 * uint64(counter)
+}
+
+// XORKeyStream XORs each byte in the given slice with a byte from the
+// cipher's key stream. Dst and src must overlap entirely or not at all.
+//
+// If len(dst) < len(src), XORKeyStream will panic. It is acceptable
+// to pass a dst bigger than src, and in that case, XORKeyStream will
+// only update dst[:len(src)] and will not touch the rest of dst.
+//
+// Multiple calls to XORKeyStream behave as if the concatenation of
+// the src buffers was passed in a single run. That is, Cipher
+// maintains state and does not reset at each XORKeyStream call.
+func (s *Cipher) XORKeyStream(dst, src []byte) {
+	if len(src) == 0 {
+		return
+	}
+	if len(dst) < len(src) {
+		panic("chacha20: output smaller than input")
+	}
+	dst = dst[:len(src)]
+	if inexactOverlap(dst, src) {
+		panic("chacha20: invalid buffer overlap")
+	}
+	if s.pos+uint64(len(src)) > maxKeystream {
+		panic("chacha20: counter overflow")
+	}
+	s.c.XORKeyStream(dst, src)
+	s.pos += uint64(len(src))
+}
+
+// HChaCha20 uses the ChaCha20 core to generate a derived key from a 32 bytes
+// key and a 16 bytes nonce. It returns an error if key or nonce have any other
+// length. It is used as part of the XChaCha20 construction.
+func HChaCha20(key, nonce []byte) ([]byte, error) {
+	if len(key) != KeySize {
+		return nil, errHChaChaKeySize
+	}
+	if len(nonce) != 16 {
+		return nil, errHChaChaNonceSize
+	}
+	var k, out [32]byte
+	var n [16]byte
+	copy(k[:], key)
+	copy(n[:], nonce)
+	chacha.HChaCha20(&out, &n, &k)
+	return out[:], nil
+}
+
+// inexactOverlap reports whether x and y share memory at any non-corresponding
+// index.
+func inexactOverlap(x, y []byte) bool {
+	if len(x) == 0 || len(y) == 0 || &x[0] == &y[0] {
+		return false
+	}
+	return uintptr(unsafe.Pointer(&x[0])) <= uintptr(unsafe.Pointer(&y[len(y)-1])) &&
+		uintptr(unsafe.Pointer(&y[0])) <= uintptr(unsafe.Pointer(&x[len(x)-1]))
+}
@@ -0,0 +1,122 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package chacha20
+
+import (
+	"bytes"
+	"crypto/cipher"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	xchacha20 "golang.org/x/crypto/chacha20"
+)
+
+var _ cipher.Stream = (*Cipher)(nil)
+
+// TestCompatibility compares random sequences of XORKeyStream and SetCounter
+// calls against golang.org/x/crypto/chacha20.
+func TestCompatibility(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 64; i++ {
+		key := make([]byte, KeySize)
+		nonce := make([]byte, []int{NonceSize, NonceSizeX}[i%2])
+		rng.Read(key)
+		rng.Read(nonce)
+
+		c, err := NewUnauthenticatedCipher(key, nonce)
+		if err != nil {
+			t.Fatalf("NewUnauthenticatedCipher failed: %s", err)
+		}
+		x, _ := xchacha20.NewUnauthenticatedCipher(key, nonce)
+		counter := uint32(0)
+		for j := 0; j < 32; j++ {
+			if rng.Intn(4) == 0 {
+				counter += uint32(rng.Intn(3)) + 1 // the next block or later
+				c.SetCounter(counter)
+				x.SetCounter(counter)
+			}
+			src := make([]byte, rng.Intn(300))
+			rng.Read(src)
+			dst, expected := make([]byte, len(src)), make([]byte, len(src))
+			c.XORKeyStream(dst, src)
+			x.XORKeyStream(expected, src)
+			if !bytes.Equal(dst, expected) {
+				t.Fatalf("Cipher %d: call %d: XORKeyStream differs from x/crypto", i, j)
+			}
+			counter += uint32(len(src)+63) / 64
+		}
+	}
+}
+
+func TestHChaCha20(t *testing.T) {
+	key, nonce := make([]byte, KeySize), make([]byte, 16)
+	for i := range key {
+		key[i] = byte(i)
+	}
+	out, err := HChaCha20(key, nonce)
+	expected, _ := xchacha20.HChaCha20(key, nonce)
+	if err != nil || !bytes.Equal(out, expected) {
+		t.Fatalf("HChaCha20 differs from x/crypto: %v", err)
+	}
+	if _, err = HChaCha20(key[:31], nonce); err == nil {
+		t.Fatal("HChaCha20 accepted a 31 byte key")
+	}
+	if _, err = HChaCha20(key, nonce[:15]); err == nil {
+		t.Fatal("HChaCha20 accepted a 15 byte nonce")
+	}
+	if _, err = NewUnauthenticatedCipher(key, make([]byte, 8)); err == nil {
+		t.Fatal("NewUnauthenticatedCipher accepted an 8 byte nonce")
+	}
+}
+
+func mustPanic(t *testing.T, msg string, f func()) {
+	defer func() {
+		if r := recover(); r != msg {
+			t.Fatalf("Unexpected panic: %v - want %q", r, msg)
+		}
+	}()
+	f()
+}
+
+func TestPanics(t *testing.T) {
+	key, nonce := make([]byte, KeySize), make([]byte, NonceSize)
+	buf := make([]byte, 128)
+
+	c, _ := NewUnauthenticatedCipher(key, nonce)
+	c.XORKeyStream(buf[:65], buf[:65])
+	c.SetCounter(2)
+	mustPanic(t, "chacha20: SetCounter attempted to rollback counter", func() { c.SetCounter(1) })
+	mustPanic(t, "chacha20: output smaller than input", func() { c.XORKeyStream(buf[:1], buf[:2]) })
+	mustPanic(t, "chacha20: invalid buffer overlap", func() { c.XORKeyStream(buf[1:], buf[:64]) })
+
+	c.SetCounter(1<<32 - 1)
+	c.XORKeyStream(buf[:63], buf[:63])
+	mustPanic(t, "chacha20: counter overflow", func() { c.XORKeyStream(buf[:2], buf[:2]) })
+	c.XORKeyStream(buf[:1], buf[:1])
+	mustPanic(t, "chacha20: counter overflow", func() { c.XORKeyStream(buf[:1], buf[:1]) })
+	mustPanic(t, "chacha20: SetCounter attempted to rollback counter", func() { c.SetCounter(1<<32 - 1) })
+}
+
+func BenchmarkXORKeyStream(b *testing.B) {
+	key, nonce := make([]byte, KeySize), make([]byte, NonceSize)
+	for _, size := range []int{64, 1024, 16 * 1024} {
+		buf := make([]byte, size)
+		b.Run(fmt.Sprintf("aead/%d", size), func(b *testing.B) {
+			c, _ := NewUnauthenticatedCipher(key, nonce)
+			b.SetBytes(int64(size))
+			for i := 0; i < b.N; i++ {
+				c.XORKeyStream(buf, buf)
+			}
+		})
+		b.Run(fmt.Sprintf("x-crypto/%d", size), func(b *testing.B) {
+			c, _ := xchacha20.NewUnauthenticatedCipher(key, nonce)
+			b.SetBytes(int64(size))
+			for i := 0; i < b.N; i++ {
+				c.XORKeyStream(buf, buf)
+			}
+		})
+	}
+}
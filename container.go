@@ -0,0 +1,594 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package chacha20
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"io"
+	"sort"
+)
+
+const (
+	// ContainerVersion is the current version of the container format.
+	ContainerVersion = ContainerVersion2
+
+	// ContainerVersion1 is the container format without metadata.
+	ContainerVersion1 = 1
+
+	// ContainerVersion2 is the container format with metadata.
+	ContainerVersion2 = 2
+
+	// DefaultContainerChunkSize is the chunk size used by a ContainerWriter
+	// if no chunk size is specified.
+	DefaultContainerChunkSize = 64 * 1024
+
+	// MaxContainerChunkSize is the largest chunk size accepted by a
+	// ContainerReader. It limits the memory used for one chunk.
+	MaxContainerChunkSize = 16 * 1024 * 1024
+
+	// MaxContainerMetadataSize is the max. size of the encoded metadata
+	// of a container.
+	MaxContainerMetadataSize = 64 * 1024
+)
+
+var containerMagic = [4]byte{'c', 'c', '2', 'c'}
+
+var (
+	errInvalidContainer     = errors.New("invalid container encoding")
+	errUnsupportedContainer = errors.New("unsupported container version")
+	errContainerChunkSize   = errors.New("container chunk size must be between 1 and MaxContainerChunkSize")
+	errContainerNeedsKey    = errors.New("container was sealed with a key and not with a password")
+	errContainerNeedsPass   = errors.New("container was sealed with a password and not with a key")
+	errInvalidMetadata      = errors.New("container metadata keys must be 1 to 255 bytes long and values at most 65535 bytes")
+	errMetadataTooLarge     = errors.New("container metadata exceeds MaxContainerMetadataSize")
+	errInvalidCheckpoint    = errors.New("invalid container checkpoint")
+	errCheckpointState      = errors.New("container writer is closed or has failed")
+	errCheckpointMismatch   = errors.New("resumed data differs from the data sealed before the checkpoint")
+)
+
+// The container format is a file format for large or streamed data, like
+// backups and build artifacts. The binary encoding of a container is:
+//
+//	magic "cc2c" (4 bytes) | version (1 byte) | chunk size (4 bytes) |
+//	nonce (StreamNonceSize bytes) | KDF length (1 byte) | KDF |
+//	metadata length (4 bytes) | metadata | chunks
+//
+// The chunk size is big endian. The KDF field is empty if the container
+// was sealed with a key. Otherwise it has the encoding of the KDF field
+// of an envelope: the KDF ID, the big endian KDF parameters and the salt.
+// The metadata length and the metadata are only present in version 2
+// containers. The metadata is a sequence of key-value pairs sorted by key:
+//
+//	key length (1 byte) | key | value length (2 bytes) | value
+//
+// The metadata is not encrypted but - as part of the header - it is
+// authenticated like additional data.
+//
+// The chunks are the output of a StreamWriter: Every chunk is sealed
+// separately and carries its own tag, and the final chunk is marked as
+// such, so reordered, dropped and truncated chunks are detected. The key
+// of the stream is derived from the key - or the key derived from the
+// password - and the header (everything before the chunks) using Expand.
+// So a modified header is detected like a modified chunk.
+
+// containerHeader is the decoded header of a container.
+type containerHeader struct {
+	chunkSize uint32
+	nonce     [StreamNonceSize]byte
+	kdf       []byte
+	metadata  map[string]string
+}
+
+func (h *containerHeader) marshal() ([]byte, error) {
+	if len(h.kdf) > 255 {
+		return nil, errEnvelopeFieldTooLarge
+	}
+	metadata, err := marshalMetadata(h.metadata)
+	if err != nil {
+		return nil, err
+	}
+	header := make([]byte, 0, len(containerMagic)+1+4+StreamNonceSize+1+len(h.kdf)+4+len(metadata))
+	header = append(header, containerMagic[:]...)
+	header = append(header, ContainerVersion)
+	header = appendUint32(header, h.chunkSize)
+	header = append(header, h.nonce[:]...)
+	header = append(header, byte(len(h.kdf)))
+	header = append(header, h.kdf...)
+	header = appendUint32(header, uint32(len(metadata)))
+	return append(header, metadata...), nil
+}
+
+func marshalMetadata(metadata map[string]string) ([]byte, error) {
+	keys := make([]string, 0, len(metadata))
+	size := 0
+	for k, v := range metadata {
+		if len(k) == 0 || len(k) > 255 || len(v) > 0xffff {
+			return nil, errInvalidMetadata
+		}
+		keys = append(keys, k)
+		size += 1 + len(k) + 2 + len(v)
+	}
+	if size > MaxContainerMetadataSize {
+		return nil, errMetadataTooLarge
+	}
+	sort.Strings(keys)
+
+	b := make([]byte, 0, size)
+	for _, k := range keys {
+		v := metadata[k]
+		b = append(b, byte(len(k)))
+		b = append(b, k...)
+		b = append(b, byte(len(v)>>8), byte(len(v)))
+		b = append(b, v...)
+	}
+	return b, nil
+}
+
+// unmarshalMetadata decodes the metadata and rejects encodings which are
+// not sorted by key, so every metadata map has exactly one encoding.
+func unmarshalMetadata(b []byte) (map[string]string, error) {
+	metadata := make(map[string]string)
+	var prev string
+	for len(b) > 0 {
+		n := int(b[0])
+		if n == 0 || len(b) < 1+n+2 {
+			return nil, errInvalidContainer
+		}
+		k := string(b[1 : 1+n])
+		m := int(b[1+n])<<8 | int(b[2+n])
+		b = b[3+n:]
+		if len(b) < m || (len(metadata) > 0 && k <= prev) {
+			return nil, errInvalidContainer
+		}
+		metadata[k] = string(b[:m])
+		b, prev = b[m:], k
+	}
+	return metadata, nil
+}
+
+// readContainerHeader reads and validates the header of a container and
+// returns it together with its encoding.
+func readContainerHeader(r io.Reader) (*containerHeader, []byte, error) {
+	// read appends n bytes read from r to buf and returns them.
+	var buf []byte
+	read := func(n int) ([]byte, error) {
+		buf = append(buf, make([]byte, n)...)
+		if _, err := io.ReadFull(r, buf[len(buf)-n:]); err != nil {
+			if err == io.EOF || err == io.ErrUnexpectedEOF {
+				return nil, errInvalidContainer
+			}
+			return nil, err
+		}
+		return buf[len(buf)-n:], nil
+	}
+
+	b, err := read(len(containerMagic) + 1 + 4 + StreamNonceSize + 1)
+	if err != nil {
+		return nil, nil, err
+	}
+	for i, v := range containerMagic {
+		if b[i] != v {
+			return nil, nil, errInvalidContainer
+		}
+	}
+	version := b[4]
+	if version != ContainerVersion1 && version != ContainerVersion2 {
+		return nil, nil, errUnsupportedContainer
+	}
+	h := &containerHeader{chunkSize: readUint32(b[5:])}
+	if h.chunkSize == 0 || h.chunkSize > MaxContainerChunkSize {
+		return nil, nil, errInvalidContainer
+	}
+	copy(h.nonce[:], b[9:])
+
+	n := int(b[len(b)-1])
+	if n != 0 && n < 13 {
+		return nil, nil, errInvalidContainer
+	}
+	if b, err = read(n); err != nil {
+		return nil, nil, err
+	}
+	h.kdf = append([]byte(nil), b...)
+	if version == ContainerVersion2 {
+		if b, err = read(4); err != nil {
+			return nil, nil, err
+		}
+		n := readUint32(b)
+		if n > MaxContainerMetadataSize {
+			return nil, nil, errInvalidContainer
+		}
+		if b, err = read(int(n)); err != nil {
+			return nil, nil, err
+		}
+		if h.metadata, err = unmarshalMetadata(b); err != nil {
+			return nil, nil, err
+		}
+	}
+	return h, buf, nil
+}
+
+// containerKey derives the stream key of a container from the key and
+// the encoded header.
+func containerKey(key *[32]byte, header []byte) *[32]byte {
+	var streamKey [32]byte
+	copy(streamKey[:], Expand(key, header, len(streamKey)))
+	return &streamKey
+}
+
+// ContainerWriter encrypts and authenticates data written to it and writes
+// it in the container format to the underlying io.Writer. The container
+// must be closed by calling Close to write the final chunk.
+type ContainerWriter struct {
+	s      *StreamWriter
+	header []byte
+	resume *resumeWriter
+}
+
+// NewContainerWriter writes the header of a container sealed with the key to
+// w and returns a ContainerWriter for the data. The data is split into chunks
+// of chunkSize bytes - if chunkSize is 0 DefaultContainerChunkSize is used.
+// The nonce of the container is read from crypto/rand.
+func NewContainerWriter(w io.Writer, key *[32]byte, chunkSize int) (*ContainerWriter, error) {
+	return newContainerWriter(w, key, nil, nil, chunkSize, rand.Reader)
+}
+
+// NewContainerWriterWithMetadata is like NewContainerWriter but stores the
+// metadata - e.g. a filename, timestamps or a content type - in the header.
+// The metadata is authenticated but not encrypted. Keys must be 1 to 255
+// bytes and values at most 65535 bytes long.
+func NewContainerWriterWithMetadata(w io.Writer, key *[32]byte, chunkSize int, metadata map[string]string) (*ContainerWriter, error) {
+	return newContainerWriter(w, key, nil, metadata, chunkSize, rand.Reader)
+}
+
+// NewContainerWriter writes the header of a container sealed with a key
+// derived from the password to w and returns a ContainerWriter for the data.
+// The salt and the KDF parameters are stored in the header, so reading the
+// container only needs the password. The data is split into chunks of
+// chunkSize bytes - if chunkSize is 0 DefaultContainerChunkSize is used.
+func (p *PasswordSealer) NewContainerWriter(w io.Writer, password []byte, chunkSize int) (*ContainerWriter, error) {
+	return p.NewContainerWriterWithMetadata(w, password, chunkSize, nil)
+}
+
+// NewContainerWriterWithMetadata is like NewContainerWriter but stores the
+// authenticated but not encrypted metadata in the header.
+func (p *PasswordSealer) NewContainerWriterWithMetadata(w io.Writer, password []byte, chunkSize int, metadata map[string]string) (*ContainerWriter, error) {
+	random := p.Rand
+	if random == nil {
+		random = rand.Reader
+	}
+	saltSize := p.SaltSize
+	if saltSize <= 0 {
+		saltSize = DefaultSaltSize
+	}
+
+	kdf := make([]byte, 13+saltSize)
+	if _, err := io.ReadFull(random, kdf[13:]); err != nil {
+		return nil, err
+	}
+	encodeKDFParams(kdf, &p.Params)
+	key, err := p.deriveKey(password, kdf[13:], p.Params)
+	if err != nil {
+		return nil, err
+	}
+	return newContainerWriter(w, key, kdf, metadata, chunkSize, random)
+}
+
+func newContainerWriter(w io.Writer, key *[32]byte, kdf []byte, metadata map[string]string, chunkSize int, random io.Reader) (*ContainerWriter, error) {
+	if chunkSize == 0 {
+		chunkSize = DefaultContainerChunkSize
+	}
+	if chunkSize < 0 || chunkSize > MaxContainerChunkSize {
+		return nil, errContainerChunkSize
+	}
+	h := &containerHeader{chunkSize: uint32(chunkSize), kdf: kdf, metadata: metadata}
+	if _, err := io.ReadFull(random, h.nonce[:]); err != nil {
+		return nil, err
+	}
+	header, err := h.marshal()
+	if err != nil {
+		return nil, err
+	}
+	s, err := NewStreamWriter(w, containerKey(key, header), &h.nonce, chunkSize)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = w.Write(header); err != nil {
+		return nil, err
+	}
+	return &ContainerWriter{s: s, header: header}, nil
+}
+
+// Write encrypts p and writes all complete chunks to the underlying io.Writer.
+func (c *ContainerWriter) Write(p []byte) (int, error) { return c.s.Write(p) }
+
+// Close seals the remaining data as the final chunk and writes it to the
+// underlying io.Writer. Close does not close the underlying io.Writer.
+func (c *ContainerWriter) Close() error { return c.s.Close() }
+
+// ContainerCheckpoint is the state of a ContainerWriter after a number of
+// sealed chunks. A ContainerWriter resumed from a checkpoint produces the
+// same output as the uninterrupted ContainerWriter. A checkpoint contains
+// no plaintext and no key material - the header and the tag are part of
+// the output anyway.
+//
+// A resumed ContainerWriter seals the chunks following the checkpoint with
+// the same key and nonces as the interrupted one. If the data after the
+// checkpoint differs from the data sealed before the interruption - even
+// if the output has been truncated - chunks with different plaintext but
+// the same nonce may exist, which reveals the XOR of the plaintexts to
+// anyone who has seen both. So the resumed data must be byte-identical to
+// the original data, the output must be truncated to CiphertextOffset
+// before resuming and the truncated chunks must not have been published.
+// To detect a changed input, a resumed ContainerWriter seals the last chunk
+// before the checkpoint again and compares its tag with the Tag of the
+// checkpoint.
+type ContainerCheckpoint struct {
+	// Header is the encoded header of the container.
+	Header []byte
+
+	// Chunks is the number of chunks written to the output.
+	Chunks uint64
+
+	// Tag is the tag of the last chunk written to the output.
+	// It is empty if Chunks is 0.
+	Tag []byte
+}
+
+// Checkpoint returns the current state of the ContainerWriter. The data
+// which has been written but not sealed yet - at most one chunk - is not
+// part of the checkpoint. So after resuming from the checkpoint the data
+// must be written again starting at the PlaintextOffset of the checkpoint.
+func (c *ContainerWriter) Checkpoint() (*ContainerCheckpoint, error) {
+	if c.s.err != nil || c.s.closed {
+		return nil, errCheckpointState
+	}
+	cp := &ContainerCheckpoint{
+		Header: append([]byte(nil), c.header...),
+		Chunks: c.s.counter,
+	}
+	switch {
+	case c.resume != nil && c.resume.tag != nil:
+		// The last chunk before the checkpoint hasn't been sealed again yet.
+		cp.Chunks++
+		cp.Tag = append([]byte(nil), c.resume.tag...)
+	case cp.Chunks > 0:
+		cp.Tag = append([]byte(nil), c.s.lastTag()...)
+	}
+	return cp, nil
+}
+
+// PlaintextOffset returns the offset of the data a resumed ContainerWriter
+// expects: the start of the last chunk sealed before the checkpoint. The
+// resumed ContainerWriter verifies this chunk against the Tag of the
+// checkpoint but does not write it again.
+func (cp *ContainerCheckpoint) PlaintextOffset() int64 {
+	if cp.Chunks == 0 {
+		return 0
+	}
+	return int64(cp.Chunks-1) * int64(cp.chunkSize())
+}
+
+// CiphertextOffset returns the size of the container output at the
+// checkpoint. The output must be truncated to this size before a resumed
+// ContainerWriter appends to it.
+func (cp *ContainerCheckpoint) CiphertextOffset() int64 {
+	return int64(len(cp.Header)) + int64(cp.Chunks)*int64(cp.chunkSize()+TagSize)
+}
+
+func (cp *ContainerCheckpoint) chunkSize() int {
+	if len(cp.Header) < len(containerMagic)+1+4 {
+		return 0
+	}
+	return int(readUint32(cp.Header[len(containerMagic)+1:]))
+}
+
+// MarshalBinary returns the binary encoding of the checkpoint.
+func (cp *ContainerCheckpoint) MarshalBinary() ([]byte, error) {
+	b := make([]byte, 0, 4+len(cp.Header)+8+1+len(cp.Tag))
+	b = appendUint32(b, uint32(len(cp.Header)))
+	b = append(b, cp.Header...)
+	b = appendUint32(b, uint32(cp.Chunks>>32))
+	b = appendUint32(b, uint32(cp.Chunks))
+	b = append(b, byte(len(cp.Tag)))
+	return append(b, cp.Tag...), nil
+}
+
+// UnmarshalBinary decodes the binary encoding of a checkpoint.
+func (cp *ContainerCheckpoint) UnmarshalBinary(data []byte) error {
+	if len(data) < 4 || uint64(len(data)) < 4+uint64(readUint32(data))+8+1 {
+		return errInvalidCheckpoint
+	}
+	n := 4 + int(readUint32(data))
+	if len(data) != n+8+1+int(data[n+8]) {
+		return errInvalidCheckpoint
+	}
+	cp.Header = append([]byte(nil), data[4:n]...)
+	cp.Chunks = uint64(readUint32(data[n:]))<<32 | uint64(readUint32(data[n+4:]))
+	cp.Tag = append([]byte(nil), data[n+9:]...)
+	return nil
+}
+
+// ResumeContainerWriter returns a ContainerWriter for a container sealed with
+// the key which continues at the checkpoint. It writes the chunks following
+// the checkpoint to w, which must be the container output truncated to the
+// CiphertextOffset of the checkpoint. The key must be the key of the
+// container - otherwise the resumed part cannot be decrypted.
+//
+// The data must be written starting at the PlaintextOffset of the checkpoint
+// and must be byte-identical to the data written to the interrupted
+// ContainerWriter (see ContainerCheckpoint). The first chunk is checked
+// against the Tag of the checkpoint - if it differs, Write and Close return
+// an error and nothing is written to w.
+func ResumeContainerWriter(w io.Writer, key *[32]byte, checkpoint *ContainerCheckpoint) (*ContainerWriter, error) {
+	h, err := checkpoint.header()
+	if err != nil {
+		return nil, err
+	}
+	if len(h.kdf) != 0 {
+		return nil, errContainerNeedsPass
+	}
+	return resumeContainerWriter(w, key, h, checkpoint)
+}
+
+// ResumeContainerWriter returns a ContainerWriter for a container sealed
+// with the password which continues at the checkpoint. See the function
+// ResumeContainerWriter for details.
+func (p *PasswordSealer) ResumeContainerWriter(w io.Writer, password []byte, checkpoint *ContainerCheckpoint) (*ContainerWriter, error) {
+	h, err := checkpoint.header()
+	if err != nil {
+		return nil, err
+	}
+	if len(h.kdf) == 0 {
+		return nil, errContainerNeedsKey
+	}
+	key, err := p.deriveKey(password, h.kdf[13:], decodeKDFParams(h.kdf))
+	if err != nil {
+		return nil, err
+	}
+	return resumeContainerWriter(w, key, h, checkpoint)
+}
+
+// header decodes the header of the checkpoint.
+func (cp *ContainerCheckpoint) header() (*containerHeader, error) {
+	r := bytes.NewReader(cp.Header)
+	h, _, err := readContainerHeader(r)
+	if err != nil || r.Len() != 0 || cp.Chunks > maxStreamChunks {
+		return nil, errInvalidCheckpoint
+	}
+	if (cp.Chunks == 0 && len(cp.Tag) != 0) || (cp.Chunks > 0 && len(cp.Tag) != TagSize) {
+		return nil, errInvalidCheckpoint
+	}
+	return h, nil
+}
+
+func resumeContainerWriter(w io.Writer, key *[32]byte, h *containerHeader, checkpoint *ContainerCheckpoint) (*ContainerWriter, error) {
+	c := &ContainerWriter{header: append([]byte(nil), checkpoint.Header...)}
+	counter := checkpoint.Chunks
+	if counter > 0 {
+		c.resume = &resumeWriter{w: w, tag: append([]byte(nil), checkpoint.Tag...)}
+		w = c.resume
+		counter--
+	}
+	s, err := NewStreamWriter(w, containerKey(key, checkpoint.Header), &h.nonce, int(h.chunkSize))
+	if err != nil {
+		return nil, err
+	}
+	s.counter = counter
+	c.s = s
+	return c, nil
+}
+
+// resumeWriter drops the first chunk written by a resumed ContainerWriter -
+// the last chunk before the checkpoint, which is already part of the
+// output - if its tag matches the tag of the checkpoint.
+type resumeWriter struct {
+	w   io.Writer
+	tag []byte // nil once the first chunk has been verified
+}
+
+func (r *resumeWriter) Write(p []byte) (int, error) {
+	if r.tag == nil {
+		return r.w.Write(p)
+	}
+	if len(p) < TagSize || !bytes.Equal(p[len(p)-TagSize:], r.tag) {
+		return 0, errCheckpointMismatch
+	}
+	r.tag = nil
+	return len(p), nil
+}
+
+// ContainerReader reads a container from the underlying io.Reader and
+// decrypts it. Like a StreamReader it only returns plaintext after the
+// tag of its chunk has been verified.
+type ContainerReader struct {
+	s        *StreamReader
+	metadata map[string]string
+}
+
+// ReadContainerMetadata reads the header of a container from r and returns
+// its metadata without decrypting the container. The metadata is NOT
+// authenticated - use the Metadata method of a ContainerReader to get
+// authenticated metadata.
+func ReadContainerMetadata(r io.Reader) (map[string]string, error) {
+	h, _, err := readContainerHeader(r)
+	if err != nil {
+		return nil, err
+	}
+	return h.metadata, nil
+}
+
+// NewContainerReader reads the header of a container sealed with the key
+// from r and returns a ContainerReader for the data. It verifies the header
+// and the first chunk, so it fails if the key is wrong or the header has
+// been modified.
+func NewContainerReader(r io.Reader, key *[32]byte) (*ContainerReader, error) {
+	h, header, err := readContainerHeader(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(h.kdf) != 0 {
+		return nil, errContainerNeedsPass
+	}
+	return newContainerReader(r, h, containerKey(key, header))
+}
+
+// NewContainerReader reads the header of a container sealed with a password
+// from r, derives the key from the password and returns a ContainerReader for
+// the data. Like NewContainerReader it verifies the header and the first
+// chunk. Like Open it rejects containers with a different KDF ID or with
+// larger cost parameters than p.Params.
+func (p *PasswordSealer) NewContainerReader(r io.Reader, password []byte) (*ContainerReader, error) {
+	h, header, err := readContainerHeader(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(h.kdf) == 0 {
+		return nil, errContainerNeedsKey
+	}
+	params := decodeKDFParams(h.kdf)
+	if params.ID != p.Params.ID {
+		return nil, errKDFMismatch
+	}
+	if params.P1 > p.Params.P1 || params.P2 > p.Params.P2 || params.P3 > p.Params.P3 {
+		return nil, errKDFCostTooLarge
+	}
+	key, err := p.deriveKey(password, h.kdf[13:], params)
+	if err != nil {
+		return nil, err
+	}
+	return newContainerReader(r, h, containerKey(key, header))
+}
+
+func newContainerReader(r io.Reader, h *containerHeader, key *[32]byte) (*ContainerReader, error) {
+	s, err := NewStreamReader(r, key, &h.nonce, int(h.chunkSize))
+	if err != nil {
+		return nil, err
+	}
+	// The header is bound to the stream key, so the first chunk
+	// authenticates the metadata.
+	if err = s.openChunk(); err != nil {
+		return nil, err
+	}
+	return &ContainerReader{s: s, metadata: h.metadata}, nil
+}
+
+// Metadata returns the authenticated metadata of the container. It is
+// empty for containers without metadata.
+func (c *ContainerReader) Metadata() map[string]string { return c.metadata }
+
+// Read reads authenticated plaintext into p. It returns io.EOF after the final
+// chunk has been read and verified and io.ErrUnexpectedEOF if the container
+// was truncated.
+func (c *ContainerReader) Read(p []byte) (int, error) { return c.s.Read(p) }
+
+func appendUint32(b []byte, v uint32) []byte {
+	return append(b, byte(v>>24), byte(v>>16), byte(v>>8), byte(v))
+}
+
+func readUint32(b []byte) uint32 {
+	return uint32(b[0])<<24 | uint32(b[1])<<16 | uint32(b[2])<<8 | uint32(b[3])
+}
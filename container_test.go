@@ -0,0 +1,379 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package chacha20
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"reflect"
+	"testing"
+)
+
+func sealContainer(t *testing.T, w *ContainerWriter, err error, plaintext []byte) {
+	if err != nil {
+		t.Fatalf("Failed to create ContainerWriter: %s", err)
+	}
+	if _, err = w.Write(plaintext); err != nil {
+		t.Fatalf("Write failed: %s", err)
+	}
+	if err = w.Close(); err != nil {
+		t.Fatalf("Close failed: %s", err)
+	}
+}
+
+func TestContainer(t *testing.T) {
+	var key [32]byte
+	for i := range key {
+		key[i] = byte(i)
+	}
+
+	for _, size := range []int{0, 1, 64, 1000} {
+		plaintext := make([]byte, size)
+		for i := range plaintext {
+			plaintext[i] = byte(i * 3)
+		}
+		var buf bytes.Buffer
+		w, err := NewContainerWriter(&buf, &key, 64)
+		sealContainer(t, w, err, plaintext)
+		container := buf.Bytes()
+
+		r, err := NewContainerReader(bytes.NewReader(container), &key)
+		if err != nil {
+			t.Fatalf("Size %d: Failed to create ContainerReader: %s", size, err)
+		}
+		decrypted, err := ioutil.ReadAll(r)
+		if err != nil {
+			t.Fatalf("Size %d: failed to decrypt container: %s", size, err)
+		}
+		if !bytes.Equal(decrypted, plaintext) {
+			t.Fatalf("Size %d: decrypted container differs from plaintext", size)
+		}
+		if size != 64 {
+			continue
+		}
+
+		// Every header byte is bound to the key of the stream.
+		for i := range container {
+			container[i] ^= 1
+			r, err := NewContainerReader(bytes.NewReader(container), &key)
+			if err == nil {
+				_, err = ioutil.ReadAll(r)
+			}
+			if err == nil {
+				t.Fatalf("Modified byte %d was not detected", i)
+			}
+			container[i] ^= 1
+		}
+		r, err = NewContainerReader(bytes.NewReader(container[:len(container)-1]), &key)
+		if err == nil {
+			_, err = ioutil.ReadAll(r)
+		}
+		if err == nil {
+			t.Fatal("Truncated container was not detected")
+		}
+	}
+}
+
+func TestContainerPassword(t *testing.T) {
+	sealer := &PasswordSealer{
+		KDF:    Argon2id(testIDKey),
+		Params: KDFParams{ID: KDFArgon2id, P1: 1, P2: 64 * 1024, P3: 4},
+	}
+	password, plaintext := []byte("password"), bytes.Repeat([]byte("Hello, World"), 100)
+
+	var buf bytes.Buffer
+	w, err := sealer.NewContainerWriter(&buf, password, 0)
+	sealContainer(t, w, err, plaintext)
+	container := buf.Bytes()
+
+	r, err := sealer.NewContainerReader(bytes.NewReader(container), password)
+	if err != nil {
+		t.Fatalf("Failed to create ContainerReader: %s", err)
+	}
+	decrypted, err := ioutil.ReadAll(r)
+	if err != nil {
+		t.Fatalf("Failed to decrypt container: %s", err)
+	}
+	if !bytes.Equal(decrypted, plaintext) {
+		t.Fatal("Decrypted container differs from plaintext")
+	}
+
+	r, err = sealer.NewContainerReader(bytes.NewReader(container), []byte("Password"))
+	if err == nil {
+		_, err = ioutil.ReadAll(r)
+	}
+	if err == nil {
+		t.Fatal("Container was decrypted with a wrong password")
+	}
+	var key [32]byte
+	if _, err = NewContainerReader(bytes.NewReader(container), &key); err != errContainerNeedsPass {
+		t.Fatalf("NewContainerReader returns unexpected error: %v", err)
+	}
+	cheap := *sealer
+	cheap.Params.P2 /= 2
+	if _, err = cheap.NewContainerReader(bytes.NewReader(container), password); err != errKDFCostTooLarge {
+		t.Fatalf("NewContainerReader accepted larger KDF parameters: %v", err)
+	}
+
+	buf.Reset()
+	w, err = NewContainerWriter(&buf, &key, 0)
+	sealContainer(t, w, err, plaintext)
+	if _, err = sealer.NewContainerReader(&buf, password); err != errContainerNeedsKey {
+		t.Fatalf("NewContainerReader returns unexpected error: %v", err)
+	}
+}
+
+func TestContainerInvalidHeader(t *testing.T) {
+	var key [32]byte
+	for _, chunkSize := range []int{-1, MaxContainerChunkSize + 1} {
+		if _, err := NewContainerWriter(ioutil.Discard, &key, chunkSize); err != errContainerChunkSize {
+			t.Fatalf("Chunk size %d: NewContainerWriter returns unexpected error: %v", chunkSize, err)
+		}
+	}
+
+	var buf bytes.Buffer
+	w, err := NewContainerWriter(&buf, &key, 0)
+	sealContainer(t, w, err, nil)
+	header := buf.Bytes()[:len(containerMagic)+1+4+StreamNonceSize+1]
+	for i := range header {
+		if _, err := NewContainerReader(bytes.NewReader(header[:i]), &key); err != errInvalidContainer {
+			t.Fatalf("NewContainerReader accepted a header of %d bytes: %v", i, err)
+		}
+	}
+
+	h := append([]byte(nil), header...)
+	h[4] = ContainerVersion + 1
+	if _, err = NewContainerReader(bytes.NewReader(h), &key); err != errUnsupportedContainer {
+		t.Fatalf("NewContainerReader returns unexpected error for an unknown version: %v", err)
+	}
+	h = append([]byte(nil), header...)
+	h[5] = 0xff
+	if _, err = NewContainerReader(bytes.NewReader(h), &key); err != errInvalidContainer {
+		t.Fatalf("NewContainerReader accepted a too large chunk size: %v", err)
+	}
+	h = append([]byte(nil), header...)
+	h[len(h)-1] = 12
+	if _, err = NewContainerReader(io.MultiReader(bytes.NewReader(h), bytes.NewReader(make([]byte, 12))), &key); err != errInvalidContainer {
+		t.Fatalf("NewContainerReader accepted a too short KDF field: %v", err)
+	}
+}
+
+func TestContainerMetadata(t *testing.T) {
+	var key [32]byte
+	metadata := map[string]string{
+		"filename":     "backup.tar",
+		"content-type": "application/x-tar",
+		"mtime":        "2016-09-01T12:00:00Z",
+		"empty":        "",
+	}
+	var buf bytes.Buffer
+	w, err := NewContainerWriterWithMetadata(&buf, &key, 0, metadata)
+	sealContainer(t, w, err, []byte("Hello, World"))
+	container := buf.Bytes()
+
+	m, err := ReadContainerMetadata(bytes.NewReader(container))
+	if err != nil || !reflect.DeepEqual(m, metadata) {
+		t.Fatalf("ReadContainerMetadata returns %v: %v", m, err)
+	}
+	r, err := NewContainerReader(bytes.NewReader(container), &key)
+	if err != nil {
+		t.Fatalf("Failed to create ContainerReader: %s", err)
+	}
+	if !reflect.DeepEqual(r.Metadata(), metadata) {
+		t.Fatalf("Metadata returns %v", r.Metadata())
+	}
+
+	// The metadata is not encrypted but authenticated.
+	i := bytes.Index(container, []byte("backup.tar"))
+	if i < 0 {
+		t.Fatal("Metadata is not stored in plaintext")
+	}
+	container[i] ^= 1
+	if _, err = NewContainerReader(bytes.NewReader(container), &key); err == nil {
+		t.Fatal("NewContainerReader accepted modified metadata")
+	}
+
+	for _, m := range []map[string]string{
+		{"": "value"},
+		{string(make([]byte, 256)): "value"},
+		{"key": string(make([]byte, 0x10000))},
+	} {
+		if _, err = NewContainerWriterWithMetadata(ioutil.Discard, &key, 0, m); err != errInvalidMetadata {
+			t.Fatalf("NewContainerWriterWithMetadata returns unexpected error: %v", err)
+		}
+	}
+	large := map[string]string{"a": string(make([]byte, 0xffff)), "b": "b"}
+	if _, err = NewContainerWriterWithMetadata(ioutil.Discard, &key, 0, large); err != errMetadataTooLarge {
+		t.Fatalf("NewContainerWriterWithMetadata accepted too large metadata: %v", err)
+	}
+}
+
+func TestUnmarshalMetadata(t *testing.T) {
+	encoded, _ := marshalMetadata(map[string]string{"a": "1", "b": "2"})
+	if m, err := unmarshalMetadata(encoded); err != nil || len(m) != 2 || m["a"] != "1" || m["b"] != "2" {
+		t.Fatalf("unmarshalMetadata returns %v: %v", m, err)
+	}
+	for i := 0; i < len(encoded); i++ {
+		if _, err := unmarshalMetadata(encoded[:i]); i > 0 && i != len(encoded)/2 && err == nil {
+			t.Fatalf("unmarshalMetadata accepted truncated metadata of %d bytes", i)
+		}
+	}
+	unsorted := append(append([]byte(nil), encoded[len(encoded)/2:]...), encoded[:len(encoded)/2]...)
+	if _, err := unmarshalMetadata(unsorted); err != errInvalidContainer {
+		t.Fatalf("unmarshalMetadata accepted unsorted keys: %v", err)
+	}
+	duplicate := append(append([]byte(nil), encoded[:len(encoded)/2]...), encoded[:len(encoded)/2]...)
+	if _, err := unmarshalMetadata(duplicate); err != errInvalidContainer {
+		t.Fatalf("unmarshalMetadata accepted duplicate keys: %v", err)
+	}
+}
+
+func TestContainerVersion1(t *testing.T) {
+	var key [32]byte
+	var nonce [StreamNonceSize]byte
+	header := append([]byte(nil), containerMagic[:]...)
+	header = append(header, ContainerVersion1)
+	header = appendUint32(header, 64)
+	header = append(header, nonce[:]...)
+	header = append(header, 0)
+
+	plaintext := make([]byte, 100)
+	container := append(header, sealStream(t, containerKey(&key, header), &nonce, 64, plaintext)...)
+	r, err := NewContainerReader(bytes.NewReader(container), &key)
+	if err != nil {
+		t.Fatalf("Failed to create ContainerReader: %s", err)
+	}
+	if len(r.Metadata()) != 0 {
+		t.Fatalf("Version 1 container has metadata: %v", r.Metadata())
+	}
+	if decrypted, err := ioutil.ReadAll(r); err != nil || !bytes.Equal(decrypted, plaintext) {
+		t.Fatalf("Failed to decrypt version 1 container: %v", err)
+	}
+}
+
+func TestContainerCheckpoint(t *testing.T) {
+	var key [32]byte
+	sealer := &PasswordSealer{
+		KDF:    Argon2id(testIDKey),
+		Params: KDFParams{ID: KDFArgon2id, P1: 1, P2: 64 * 1024, P3: 4},
+	}
+	password := []byte("password")
+	plaintext := make([]byte, 1000)
+	for i := range plaintext {
+		plaintext[i] = byte(i)
+	}
+	metadata := map[string]string{"filename": "backup.tar"}
+
+	for _, withPassword := range []bool{false, true} {
+		// The checkpoint is taken after 300 bytes - one chunk of 64 bytes
+		// is buffered but not sealed.
+		var buf bytes.Buffer
+		var w *ContainerWriter
+		var err error
+		if withPassword {
+			w, err = sealer.NewContainerWriterWithMetadata(&buf, password, 64, metadata)
+		} else {
+			w, err = NewContainerWriterWithMetadata(&buf, &key, 64, metadata)
+		}
+		if err != nil {
+			t.Fatalf("Failed to create ContainerWriter: %s", err)
+		}
+		w.Write(plaintext[:300])
+		cp, err := w.Checkpoint()
+		if err != nil {
+			t.Fatalf("Checkpoint failed: %s", err)
+		}
+		encoded, _ := cp.MarshalBinary()
+		sealContainer(t, w, nil, plaintext[300:])
+		expected := buf.Bytes()
+
+		var restored ContainerCheckpoint
+		if err = restored.UnmarshalBinary(encoded); err != nil {
+			t.Fatalf("UnmarshalBinary failed: %s", err)
+		}
+		if restored.Chunks != 4 || restored.PlaintextOffset() != 3*64 || len(restored.Tag) != TagSize {
+			t.Fatalf("Unexpected checkpoint: %d chunks - plaintext offset %d", restored.Chunks, restored.PlaintextOffset())
+		}
+
+		// Resuming with data which differs from the sealed data fails
+		// without writing anything.
+		modified := append([]byte(nil), plaintext[restored.PlaintextOffset():]...)
+		modified[0] ^= 1
+		out := bytes.NewBuffer(append([]byte(nil), expected[:restored.CiphertextOffset()]...))
+		if withPassword {
+			w, err = sealer.ResumeContainerWriter(out, password, &restored)
+		} else {
+			w, err = ResumeContainerWriter(out, &key, &restored)
+		}
+		if err != nil {
+			t.Fatalf("Failed to resume ContainerWriter: %s", err)
+		}
+		w.Write(modified)
+		if err = w.Close(); err != errCheckpointMismatch || int64(out.Len()) != restored.CiphertextOffset() {
+			t.Fatalf("Password %v: resuming with modified data returns unexpected error: %v", withPassword, err)
+		}
+
+		// Resume after a crash which left a partially written chunk.
+		out = bytes.NewBuffer(append([]byte(nil), expected[:restored.CiphertextOffset()+10]...))
+		out.Truncate(int(restored.CiphertextOffset()))
+		if withPassword {
+			w, err = sealer.ResumeContainerWriter(out, password, &restored)
+		} else {
+			w, err = ResumeContainerWriter(out, &key, &restored)
+		}
+		if err == nil {
+			// A checkpoint taken before the first chunk has been verified
+			// equals the one the ContainerWriter has been resumed from.
+			cp, err = w.Checkpoint()
+			if err != nil || cp.Chunks != restored.Chunks || !bytes.Equal(cp.Tag, restored.Tag) {
+				t.Fatalf("Checkpoint of the resumed ContainerWriter differs: %v", err)
+			}
+		}
+		sealContainer(t, w, err, plaintext[restored.PlaintextOffset():])
+		if !bytes.Equal(out.Bytes(), expected) {
+			t.Fatalf("Password %v: resumed container differs from the uninterrupted one", withPassword)
+		}
+
+		if withPassword {
+			_, err = ResumeContainerWriter(out, &key, &restored)
+		} else {
+			_, err = sealer.ResumeContainerWriter(out, password, &restored)
+		}
+		if err != errContainerNeedsKey && err != errContainerNeedsPass {
+			t.Fatalf("Resuming with the wrong secret type returns unexpected error: %v", err)
+		}
+		if _, err = w.Checkpoint(); err != errCheckpointState {
+			t.Fatalf("Checkpoint of a closed ContainerWriter returns unexpected error: %v", err)
+		}
+	}
+}
+
+func TestContainerCheckpointInvalid(t *testing.T) {
+	var key [32]byte
+	var buf bytes.Buffer
+	w, _ := NewContainerWriter(&buf, &key, 64)
+	cp, _ := w.Checkpoint()
+	encoded, _ := cp.MarshalBinary()
+
+	var restored ContainerCheckpoint
+	for i := 0; i < len(encoded); i++ {
+		if err := restored.UnmarshalBinary(encoded[:i]); err != errInvalidCheckpoint {
+			t.Fatalf("UnmarshalBinary accepted a checkpoint of %d bytes", i)
+		}
+	}
+	for _, c := range []ContainerCheckpoint{
+		{Header: cp.Header[:len(cp.Header)-1]},
+		{Header: append(cp.Header, 0)},
+		{Header: cp.Header, Chunks: maxStreamChunks + 1, Tag: make([]byte, TagSize)},
+		{Header: cp.Header, Chunks: 1},
+		{Header: cp.Header, Chunks: 1, Tag: make([]byte, TagSize-1)},
+		{Header: cp.Header, Tag: make([]byte, TagSize)},
+	} {
+		if _, err := ResumeContainerWriter(ioutil.Discard, &key, &c); err != errInvalidCheckpoint {
+			t.Fatalf("ResumeContainerWriter accepted an invalid checkpoint: %v", err)
+		}
+	}
+}
@@ -0,0 +1,201 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package chacha20
+
+import (
+	"context"
+	"io"
+
+	"github.com/aead/chacha20/chacha"
+)
+
+// contextChunkSize is the number of bytes processed between two checks
+// of the context.
+const contextChunkSize = 1 << 20
+
+// ContextAEAD is implemented by all cipher.AEAD implementations returned by
+// this package. It seals and opens very large messages in chunks and checks
+// the context between the chunks, so the work can be abandoned once the
+// context is canceled. The output is the same as the one of Seal and Open.
+type ContextAEAD interface {
+	// SealContext is like Seal but returns the error of the context if
+	// the context is done before the plaintext has been sealed.
+	SealContext(ctx context.Context, dst, nonce, plaintext, additionalData []byte) ([]byte, error)
+
+	// OpenContext is like Open but returns the error of the context if
+	// the context is done before the ciphertext has been opened. No
+	// plaintext is returned in this case.
+	OpenContext(ctx context.Context, dst, nonce, ciphertext, additionalData []byte) ([]byte, error)
+}
+
+func (c *aead) SealContext(ctx context.Context, dst, nonce, plaintext, additionalData []byte) ([]byte, error) {
+	if n := len(nonce); n != c.NonceSize() {
+		return nil, errInvalidNonceSize
+	}
+	if c.key.wiped {
+		return nil, errKeyWiped
+	}
+	if exceedsPlaintextSize(len(plaintext), 0) {
+		return nil, ErrMessageTooLarge
+	}
+	engine := c.engine()
+	ret, err := c.sealContext(ctx, engine, dst, nonce, plaintext, additionalData)
+	c.key.release(&c.key.engines, engine)
+	if err == nil {
+		countSeal(len(plaintext))
+	}
+	return ret, err
+}
+
+func (c *aead) OpenContext(ctx context.Context, dst, nonce, ciphertext, additionalData []byte) ([]byte, error) {
+	if n := len(nonce); n != c.NonceSize() {
+		return nil, errInvalidNonceSize
+	}
+	if c.key.wiped {
+		return nil, errKeyWiped
+	}
+	if exceedsPlaintextSize(len(ciphertext), c.tagsize) {
+		return nil, ErrMessageTooLarge
+	}
+	engine := c.engine()
+	ret, err := c.openContext(ctx, engine, dst, nonce, ciphertext, additionalData)
+	c.key.release(&c.key.engines, engine)
+	countOpen(len(ciphertext)-c.tagsize, err)
+	return ret, err
+}
+
+func (c *xaead) SealContext(ctx context.Context, dst, nonce, plaintext, additionalData []byte) ([]byte, error) {
+	if n := len(nonce); n != NonceSizeX {
+		return nil, errInvalidNonceSize
+	}
+	if c.key.wiped {
+		return nil, errKeyWiped
+	}
+	if exceedsPlaintextSize(len(plaintext), 0) {
+		return nil, ErrMessageTooLarge
+	}
+	var subNonce [NonceSize]byte
+	engine := c.subCipher(&subNonce, nonce)
+	sub := aead{tagsize: c.tagsize}
+	ret, err := sub.sealContext(ctx, engine, dst, subNonce[:], plaintext, additionalData)
+	c.key.release(&c.key.subEngines, engine)
+	if err == nil {
+		countSeal(len(plaintext))
+	}
+	return ret, err
+}
+
+func (c *xaead) OpenContext(ctx context.Context, dst, nonce, ciphertext, additionalData []byte) ([]byte, error) {
+	if n := len(nonce); n != NonceSizeX {
+		return nil, errInvalidNonceSize
+	}
+	if c.key.wiped {
+		return nil, errKeyWiped
+	}
+	if exceedsPlaintextSize(len(ciphertext), c.tagsize) {
+		return nil, ErrMessageTooLarge
+	}
+	var subNonce [NonceSize]byte
+	engine := c.subCipher(&subNonce, nonce)
+	sub := aead{tagsize: c.tagsize}
+	ret, err := sub.openContext(ctx, engine, dst, subNonce[:], ciphertext, additionalData)
+	c.key.release(&c.key.subEngines, engine)
+	countOpen(len(ciphertext)-c.tagsize, err)
+	return ret, err
+}
+
+// sealContext is like seal but encrypts and authenticates the plaintext in
+// chunks of contextChunkSize bytes.
+func (c *aead) sealContext(ctx context.Context, engine *chacha.Cipher, dst, nonce, plaintext, additionalData []byte) ([]byte, error) {
+	var polyKey [32]byte
+	c.polyKey(engine, &polyKey, nonce)
+	mac := newAEADMAC(&polyKey, additionalData, c.legacy)
+
+	n := len(plaintext)
+	ret, ciphertext := sliceForAppend(dst, n+c.tagsize)
+	for off := 0; off < n; off += contextChunkSize {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		end := off + contextChunkSize
+		if end > n {
+			end = n
+		}
+		engine.XORKeyStream(ciphertext[off:end], plaintext[off:end])
+		mac.write(ciphertext[off:end])
+	}
+
+	var tag [TagSize]byte
+	mac.sum(&tag)
+	copy(ciphertext[n:], tag[:c.tagsize])
+	return ret, nil
+}
+
+// openContext is like open but authenticates and decrypts the ciphertext in
+// chunks of contextChunkSize bytes. The partial plaintext is wiped if the
+// context is done during the decryption.
+func (c *aead) openContext(ctx context.Context, engine *chacha.Cipher, dst, nonce, ciphertext, additionalData []byte) ([]byte, error) {
+	if len(ciphertext) < c.tagsize {
+		return nil, errAuthFailed
+	}
+	var polyKey [32]byte
+	c.polyKey(engine, &polyKey, nonce)
+	mac := newAEADMAC(&polyKey, additionalData, c.legacy)
+
+	n := len(ciphertext) - c.tagsize
+	for off := 0; off < n; off += contextChunkSize {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		end := off + contextChunkSize
+		if end > n {
+			end = n
+		}
+		mac.write(ciphertext[off:end])
+	}
+	var sum [TagSize]byte
+	mac.sum(&sum)
+	if !checkTag(&sum, ciphertext[n:], c.tagsize) {
+		return nil, errAuthFailed
+	}
+
+	ret, plaintext := sliceForAppend(dst, n)
+	for off := 0; off < n; off += contextChunkSize {
+		if err := ctx.Err(); err != nil {
+			wipe(plaintext[:off])
+			return nil, err
+		}
+		end := off + contextChunkSize
+		if end > n {
+			end = n
+		}
+		engine.XORKeyStream(plaintext[off:end], ciphertext[off:end])
+	}
+	return ret, nil
+}
+
+// CopyContext copies from src to dst like io.Copy but checks the context
+// before every chunk of at most 1 MiB. It returns the error of the context
+// once it is done. CopyContext can be used to feed a StreamWriter or a
+// ContainerWriter - or to drain a StreamReader or a ContainerReader - and
+// stop once a request is canceled.
+func CopyContext(ctx context.Context, dst io.Writer, src io.Reader) (int64, error) {
+	buf := make([]byte, 32*1024)
+	var written int64
+	for {
+		if err := ctx.Err(); err != nil {
+			return written, err
+		}
+		r := io.LimitReader(src, contextChunkSize)
+		n, err := io.CopyBuffer(dst, r, buf)
+		written += n
+		if err != nil {
+			return written, err
+		}
+		if n < contextChunkSize {
+			return written, nil
+		}
+	}
+}
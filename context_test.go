@@ -0,0 +1,120 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package chacha20
+
+import (
+	"bytes"
+	"context"
+	"crypto/cipher"
+	"io"
+	"io/ioutil"
+	"testing"
+)
+
+func TestContextAEAD(t *testing.T) {
+	var key [32]byte
+	for i := range key {
+		key[i] = byte(i)
+	}
+	aeads := map[string]cipher.AEAD{
+		"ChaCha20Poly1305":       NewChaCha20Poly1305(&key),
+		"XChaCha20Poly1305":      NewXChaCha20Poly1305(&key),
+		"ChaCha20Poly1305Legacy": NewChaCha20Poly1305Legacy(&key),
+	}
+	ctx := context.Background()
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+
+	for name, c := range aeads {
+		ca, ok := c.(ContextAEAD)
+		if !ok {
+			t.Fatalf("%s does not implement ContextAEAD", name)
+		}
+		nonce := make([]byte, c.NonceSize())
+		for _, size := range []int{0, 1, 100, contextChunkSize, 2*contextChunkSize + 77} {
+			plaintext := make([]byte, size)
+			for i := range plaintext {
+				plaintext[i] = byte(i * 3)
+			}
+			data := []byte("additional data")
+
+			sealed, err := ca.SealContext(ctx, []byte("prefix"), nonce, plaintext, data)
+			if err != nil {
+				t.Fatalf("%s: size %d: SealContext failed: %v", name, size, err)
+			}
+			if want := c.Seal([]byte("prefix"), nonce, plaintext, data); !bytes.Equal(sealed, want) {
+				t.Fatalf("%s: size %d: SealContext differs from Seal", name, size)
+			}
+			sealed = sealed[len("prefix"):]
+			opened, err := ca.OpenContext(ctx, nil, nonce, sealed, data)
+			if err != nil || !bytes.Equal(opened, plaintext) {
+				t.Fatalf("%s: size %d: OpenContext failed: %v", name, size, err)
+			}
+			sealed[0] ^= 1
+			if _, err = ca.OpenContext(ctx, nil, nonce, sealed, data); err == nil && size > 0 {
+				t.Fatalf("%s: size %d: OpenContext accepted a modified ciphertext", name, size)
+			}
+			sealed[0] ^= 1
+
+			if size > 0 {
+				if _, err = ca.SealContext(canceled, nil, nonce, plaintext, data); err != context.Canceled {
+					t.Fatalf("%s: size %d: expected %v, got %v", name, size, context.Canceled, err)
+				}
+				if _, err = ca.OpenContext(canceled, nil, nonce, sealed, data); err != context.Canceled {
+					t.Fatalf("%s: size %d: expected %v, got %v", name, size, context.Canceled, err)
+				}
+			}
+		}
+		if _, err := ca.SealContext(ctx, nil, nil, nil, nil); err != errInvalidNonceSize {
+			t.Fatalf("%s: expected %v, got %v", name, errInvalidNonceSize, err)
+		}
+	}
+}
+
+// cancelReader cancels the context after n bytes have been read.
+type cancelReader struct {
+	r      io.Reader
+	n      int
+	cancel context.CancelFunc
+}
+
+func (r *cancelReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	if r.n -= n; r.n <= 0 {
+		r.cancel()
+	}
+	return n, err
+}
+
+func TestCopyContext(t *testing.T) {
+	var key [32]byte
+	var nonce [StreamNonceSize]byte
+	data := make([]byte, 3*contextChunkSize+5)
+	for i := range data {
+		data[i] = byte(i)
+	}
+
+	var sealed bytes.Buffer
+	w, _ := NewStreamWriter(&sealed, &key, &nonce, 64*1024)
+	if n, err := CopyContext(context.Background(), w, bytes.NewReader(data)); err != nil || n != int64(len(data)) {
+		t.Fatalf("CopyContext failed: %d %v", n, err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	var opened bytes.Buffer
+	r, _ := NewStreamReader(bytes.NewReader(sealed.Bytes()), &key, &nonce, 64*1024)
+	if _, err := CopyContext(context.Background(), &opened, r); err != nil || !bytes.Equal(opened.Bytes(), data) {
+		t.Fatalf("CopyContext failed: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	src := &cancelReader{r: bytes.NewReader(data), n: contextChunkSize / 2, cancel: cancel}
+	n, err := CopyContext(ctx, ioutil.Discard, src)
+	if err != context.Canceled || n != contextChunkSize {
+		t.Fatalf("CopyContext: got %d %v, want %d %v", n, err, contextChunkSize, context.Canceled)
+	}
+}
@@ -0,0 +1,273 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package chacha20
+
+import (
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"io"
+)
+
+const (
+	// DAREVersion20 is the version byte of DARE 2.0 packages.
+	DAREVersion20 = 0x20
+
+	// DARECipherChaCha20Poly1305 is the DARE cipher suite ID of
+	// ChaCha20Poly1305.
+	DARECipherChaCha20Poly1305 = 1
+
+	dareHeaderSize     = 16
+	dareMaxPayloadSize = 64 * 1024
+	dareMaxPackages    = 1<<32 - 1
+)
+
+var (
+	errDAREUnsupportedVersion = errors.New("unsupported DARE version")
+	errDAREUnsupportedCipher  = errors.New("unsupported DARE cipher suite")
+	errDAREPayloadSize        = errors.New("invalid DARE payload size")
+	errDARENonceMismatch      = errors.New("DARE header nonce mismatch")
+	errDAREUnexpectedData     = errors.New("unexpected data after the final DARE package")
+)
+
+// DARE 2.0 (github.com/minio/sio) splits a stream into packages of at most
+// 64 KiB plaintext. A package is:
+//
+//	version (1 byte) | cipher suite (1 byte) | payload size - 1 (2 bytes) |
+//	nonce (12 bytes) | ciphertext | tag (16 bytes)
+//
+// The payload size is little endian. All packages of a stream have the
+// same random nonce, except that the most significant bit of the first
+// nonce byte is set for the final package. Every package except the final
+// one has a payload of 64 KiB. A package is sealed using the nonce with
+// its little endian 32 bit sequence number XOR'ed into the last 4 bytes
+// and the first 4 header bytes as additional data.
+
+// DAREWriter encrypts data written to it in the DARE 2.0 format with the
+// CHACHA20-POLY1305 cipher suite and writes the packages to the underlying
+// io.Writer. The stream must be closed by calling Close to write the
+// final package.
+type DAREWriter struct {
+	w     io.Writer
+	c     cipher.AEAD
+	nonce [NonceSize]byte
+
+	buf    []byte
+	n      int
+	seqNum uint64
+	closed bool
+	err    error
+}
+
+// NewDAREWriter returns a new DAREWriter which encrypts data with the key and
+// writes the packages to w. The nonce is read from crypto/rand. The key must be
+// unique for every stream - like minio, which derives a key per object.
+func NewDAREWriter(w io.Writer, key *[32]byte) (*DAREWriter, error) {
+	d := &DAREWriter{
+		w:   w,
+		c:   NewChaCha20Poly1305(key),
+		buf: make([]byte, dareHeaderSize+dareMaxPayloadSize+TagSize),
+	}
+	if _, err := io.ReadFull(rand.Reader, d.nonce[:]); err != nil {
+		return nil, err
+	}
+	d.nonce[0] &= 0x7f
+	return d, nil
+}
+
+// Write encrypts p and writes all complete packages to the underlying
+// io.Writer. A full package is only sealed once more data is written or
+// the DAREWriter is closed, since the final package must be marked as such.
+func (d *DAREWriter) Write(p []byte) (n int, err error) {
+	if d.err != nil {
+		return 0, d.err
+	}
+	if d.closed {
+		return 0, errWriteAfterClose
+	}
+	for len(p) > 0 {
+		if d.n == dareMaxPayloadSize {
+			if err = d.sealPackage(false); err != nil {
+				return n, err
+			}
+		}
+		m := copy(d.buf[dareHeaderSize+d.n:dareHeaderSize+dareMaxPayloadSize], p)
+		d.n += m
+		n += m
+		p = p[m:]
+	}
+	return n, nil
+}
+
+// Close seals the remaining data as the final package and writes it to the
+// underlying io.Writer. An empty stream has no packages. Close does not close
+// the underlying io.Writer.
+func (d *DAREWriter) Close() error {
+	if d.err != nil {
+		return d.err
+	}
+	if d.closed {
+		return nil
+	}
+	d.closed = true
+	if d.n == 0 {
+		return nil
+	}
+	return d.sealPackage(true)
+}
+
+func (d *DAREWriter) sealPackage(final bool) error {
+	if d.seqNum > dareMaxPackages {
+		d.err = errStreamTooLong
+		return d.err
+	}
+	header := d.buf[:dareHeaderSize]
+	header[0], header[1] = DAREVersion20, DARECipherChaCha20Poly1305
+	header[2], header[3] = byte(d.n-1), byte((d.n-1)>>8)
+	copy(header[4:], d.nonce[:])
+	if final {
+		header[4] |= 0x80
+	}
+
+	var nonce [NonceSize]byte
+	setDARENonce(&nonce, header, d.seqNum)
+	d.seqNum++
+
+	payload := d.buf[dareHeaderSize : dareHeaderSize+d.n]
+	pkg := d.c.Seal(d.buf[:dareHeaderSize], nonce[:], payload, header[:4])
+	d.n = 0
+	if _, err := d.w.Write(pkg); err != nil {
+		d.err = err
+		return err
+	}
+	return nil
+}
+
+// DAREReader reads packages in the DARE 2.0 format with the CHACHA20-POLY1305
+// cipher suite from the underlying io.Reader and decrypts them. Plaintext is
+// only returned after the tag of its package has been verified.
+type DAREReader struct {
+	r io.Reader
+	c cipher.AEAD
+
+	refNonce  [NonceSize]byte
+	buf       []byte
+	plaintext []byte
+	seqNum    uint64
+	done      bool
+	err       error
+}
+
+// NewDAREReader returns a new DAREReader which reads packages from r and
+// decrypts them with the key.
+func NewDAREReader(r io.Reader, key *[32]byte) *DAREReader {
+	return &DAREReader{
+		r:   r,
+		c:   NewChaCha20Poly1305(key),
+		buf: make([]byte, dareHeaderSize+dareMaxPayloadSize+TagSize),
+	}
+}
+
+// Read reads authenticated plaintext into p. It returns io.EOF after the final
+// package has been read and verified and io.ErrUnexpectedEOF if the stream was
+// truncated.
+func (d *DAREReader) Read(p []byte) (n int, err error) {
+	for len(d.plaintext) == 0 {
+		if d.err != nil {
+			return 0, d.err
+		}
+		if d.done {
+			return 0, io.EOF
+		}
+		if d.err = d.openPackage(); d.err != nil {
+			return 0, d.err
+		}
+	}
+	n = copy(p, d.plaintext)
+	d.plaintext = d.plaintext[n:]
+	return n, nil
+}
+
+func (d *DAREReader) openPackage() error {
+	header := d.buf[:dareHeaderSize]
+	if _, err := io.ReadFull(d.r, header); err != nil {
+		if err == io.EOF && d.seqNum == 0 {
+			d.done = true // An empty stream has no packages.
+			return nil
+		}
+		if err == io.EOF {
+			return io.ErrUnexpectedEOF
+		}
+		return err
+	}
+	if header[0] != DAREVersion20 {
+		return errDAREUnsupportedVersion
+	}
+	if header[1] != DARECipherChaCha20Poly1305 {
+		return errDAREUnsupportedCipher
+	}
+	size := int(header[2]) | int(header[3])<<8 + 1
+	final := header[4]&0x80 != 0
+	if !final && size != dareMaxPayloadSize {
+		return errDAREPayloadSize
+	}
+	if d.seqNum > dareMaxPackages {
+		return errStreamTooLong
+	}
+
+	if d.seqNum == 0 {
+		copy(d.refNonce[:], header[4:])
+		d.refNonce[0] &= 0x7f
+	}
+	refNonce := d.refNonce
+	if final {
+		refNonce[0] |= 0x80
+	}
+	var diff byte
+	for i, v := range refNonce {
+		diff |= v ^ header[4+i]
+	}
+	if diff != 0 {
+		return errDARENonceMismatch
+	}
+
+	pkg := d.buf[dareHeaderSize : dareHeaderSize+size+TagSize]
+	if _, err := io.ReadFull(d.r, pkg); err != nil {
+		if err == io.EOF {
+			return io.ErrUnexpectedEOF
+		}
+		return err
+	}
+	var nonce [NonceSize]byte
+	setDARENonce(&nonce, header, d.seqNum)
+	plaintext, err := d.c.Open(pkg[:0], nonce[:], pkg, header[:4])
+	if err != nil {
+		return err
+	}
+	d.seqNum++
+
+	if final {
+		// The final package must be the last one.
+		var b [1]byte
+		if n, err := io.ReadFull(d.r, b[:]); n > 0 {
+			return errDAREUnexpectedData
+		} else if err != io.EOF {
+			return err
+		}
+	}
+	d.plaintext = plaintext
+	d.done = final
+	return nil
+}
+
+// setDARENonce sets the nonce of the package with the header and the
+// sequence number.
+func setDARENonce(nonce *[NonceSize]byte, header []byte, seqNum uint64) {
+	copy(nonce[:], header[4:dareHeaderSize])
+	nonce[8] ^= byte(seqNum)
+	nonce[9] ^= byte(seqNum >> 8)
+	nonce[10] ^= byte(seqNum >> 16)
+	nonce[11] ^= byte(seqNum >> 24)
+}
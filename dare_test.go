@@ -0,0 +1,120 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package chacha20
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"testing"
+)
+
+func sealDARE(t *testing.T, key *[32]byte, plaintext []byte) []byte {
+	var buf bytes.Buffer
+	w, err := NewDAREWriter(&buf, key)
+	if err != nil {
+		t.Fatalf("Failed to create DAREWriter: %s", err)
+	}
+	for p := plaintext; len(p) > 0; {
+		n := 10000
+		if n > len(p) {
+			n = len(p)
+		}
+		if _, err = w.Write(p[:n]); err != nil {
+			t.Fatalf("Write failed: %s", err)
+		}
+		p = p[n:]
+	}
+	if err = w.Close(); err != nil {
+		t.Fatalf("Close failed: %s", err)
+	}
+	return buf.Bytes()
+}
+
+func TestDARE(t *testing.T) {
+	var key [32]byte
+	for i := range key {
+		key[i] = byte(i)
+	}
+	for _, size := range []int{0, 1, dareMaxPayloadSize, dareMaxPayloadSize + 1, 3*dareMaxPayloadSize + 17} {
+		plaintext := make([]byte, size)
+		for i := range plaintext {
+			plaintext[i] = byte(i * 3)
+		}
+		stream := sealDARE(t, &key, plaintext)
+
+		packages := (size + dareMaxPayloadSize - 1) / dareMaxPayloadSize
+		if len(stream) != size+packages*(dareHeaderSize+TagSize) {
+			t.Fatalf("Size %d: unexpected stream length %d", size, len(stream))
+		}
+		for i := 0; i < packages; i++ {
+			header := stream[i*(dareHeaderSize+dareMaxPayloadSize+TagSize):]
+			final := i == packages-1
+			if header[0] != DAREVersion20 || header[1] != DARECipherChaCha20Poly1305 || (header[4]&0x80 != 0) != final {
+				t.Fatalf("Size %d: package %d has an invalid header: %x", size, i, header[:dareHeaderSize])
+			}
+		}
+
+		decrypted, err := ioutil.ReadAll(NewDAREReader(bytes.NewReader(stream), &key))
+		if err != nil {
+			t.Fatalf("Size %d: failed to decrypt stream: %s", size, err)
+		}
+		if !bytes.Equal(decrypted, plaintext) {
+			t.Fatalf("Size %d: decrypted stream differs from plaintext", size)
+		}
+	}
+}
+
+// TestDAREPackage decrypts a package built from the DARE 2.0 specification.
+func TestDAREPackage(t *testing.T) {
+	var key [32]byte
+	plaintext := []byte("Hello, World")
+	header := []byte{DAREVersion20, DARECipherChaCha20Poly1305, byte(len(plaintext) - 1), 0}
+	nonce := []byte{0x80 | 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}
+	pkg := NewChaCha20Poly1305(&key).Seal(append(header, nonce...), nonce, plaintext, header)
+
+	decrypted, err := ioutil.ReadAll(NewDAREReader(bytes.NewReader(pkg), &key))
+	if err != nil || !bytes.Equal(decrypted, plaintext) {
+		t.Fatalf("Failed to decrypt package: %v", err)
+	}
+}
+
+func TestDAREInvalid(t *testing.T) {
+	var key [32]byte
+	stream := sealDARE(t, &key, make([]byte, 2*dareMaxPayloadSize+1))
+	open := func(stream []byte) error {
+		_, err := ioutil.ReadAll(NewDAREReader(bytes.NewReader(stream), &key))
+		return err
+	}
+	pkgSize := dareHeaderSize + dareMaxPayloadSize + TagSize
+
+	for _, i := range []int{0, 1, 2, 3, 4, 5, dareHeaderSize - 1, dareHeaderSize, pkgSize - 1, pkgSize + 4, len(stream) - 1} {
+		stream[i] ^= 1
+		if err := open(stream); err == nil {
+			t.Fatalf("Modified byte %d was not detected", i)
+		}
+		stream[i] ^= 1
+	}
+
+	if err := open(stream[:2*pkgSize]); err != io.ErrUnexpectedEOF {
+		t.Fatalf("Dropped final package returns unexpected error: %v", err)
+	}
+	if err := open(stream[:len(stream)-1]); err != io.ErrUnexpectedEOF {
+		t.Fatalf("Truncated package returns unexpected error: %v", err)
+	}
+	swapped := append(append(append([]byte(nil), stream[pkgSize:2*pkgSize]...), stream[:pkgSize]...), stream[2*pkgSize:]...)
+	if err := open(swapped); err == nil {
+		t.Fatal("Reordered packages were not detected")
+	}
+	if err := open(append(append([]byte(nil), stream...), 0)); err != errDAREUnexpectedData {
+		t.Fatalf("Data after the final package returns unexpected error: %v", err)
+	}
+
+	other := sealDARE(t, &key, make([]byte, 2*dareMaxPayloadSize+1))
+	mixed := append(append([]byte(nil), stream[:pkgSize]...), other[pkgSize:]...)
+	if err := open(mixed); err != errDARENonceMismatch {
+		t.Fatalf("Packages of different streams return unexpected error: %v", err)
+	}
+}
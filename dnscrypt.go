@@ -0,0 +1,188 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package chacha20
+
+import (
+	"crypto/subtle"
+	"errors"
+)
+
+const (
+	// DNSCryptClientMagicSize is the size of the client magic - the first
+	// bytes of the resolver certificate - in a query.
+	DNSCryptClientMagicSize = 8
+
+	// DNSCryptHalfNonceSize is the size of the client and the resolver nonce.
+	// The nonce of a box is the client nonce followed by the resolver nonce -
+	// or by zeros for queries.
+	DNSCryptHalfNonceSize = NonceSizeX / 2
+
+	// DNSCryptMinQuerySize is the initial min. size of a padded UDP query.
+	DNSCryptMinQuerySize = 256
+
+	// DNSCryptResolverMagic is the magic of DNSCrypt responses.
+	DNSCryptResolverMagic = "r6fnvWj8"
+
+	dnscryptPadBlock       = 64
+	dnscryptQueryHeader    = DNSCryptClientMagicSize + 32 + DNSCryptHalfNonceSize
+	dnscryptResponseHeader = len(DNSCryptResolverMagic) + NonceSizeX
+)
+
+var (
+	errDNSCryptPacket       = errors.New("DNSCrypt packet is too short")
+	errDNSCryptMagic        = errors.New("DNSCrypt response has an invalid magic")
+	errDNSCryptNonce        = errors.New("DNSCrypt response doesn't match the client nonce")
+	errDNSCryptPadding      = errors.New("DNSCrypt message has an invalid padding")
+	errDNSCryptMinQuerySize = errors.New("DNSCrypt min. query size must be a non-negative multiple of 64")
+)
+
+// The DNSCrypt functions implement the X25519-XChacha20Poly1305 (es-version
+// 2) construction of the DNSCrypt v2 protocol. The shared key of client and
+// resolver is computed by BoxPrecompute from their X25519 shared secret and
+// messages are sealed with BoxSealAfterPrecomputation. A query is:
+//
+//	client magic (8 bytes) | client public key (32 bytes) |
+//	client nonce (12 bytes) | box
+//
+// and a response is:
+//
+//	resolver magic (8 bytes) | client nonce (12 bytes) |
+//	resolver nonce (12 bytes) | box
+//
+// Messages are padded with a 0x80 byte followed by zeros to a multiple of
+// 64 bytes before they are sealed.
+
+// DNSCryptQuery is a sealed DNSCrypt query as received by a resolver.
+type DNSCryptQuery struct {
+	ClientMagic     [DNSCryptClientMagicSize]byte
+	ClientPublicKey [32]byte
+	ClientNonce     [DNSCryptHalfNonceSize]byte
+	Box             []byte
+}
+
+// ParseDNSCryptQuery parses a sealed query. The box refers to the packet.
+// The resolver should select the certificate by the client magic and
+// compute the shared key from the client public key to open the query.
+func ParseDNSCryptQuery(packet []byte) (*DNSCryptQuery, error) {
+	if len(packet) < dnscryptQueryHeader+BoxOverhead {
+		return nil, errDNSCryptPacket
+	}
+	q := new(DNSCryptQuery)
+	copy(q.ClientMagic[:], packet)
+	copy(q.ClientPublicKey[:], packet[DNSCryptClientMagicSize:])
+	copy(q.ClientNonce[:], packet[DNSCryptClientMagicSize+32:])
+	q.Box = packet[dnscryptQueryHeader:]
+	return q, nil
+}
+
+// Open authenticates and decrypts the query with the shared key, removes
+// the padding and appends the DNS query to dst.
+func (q *DNSCryptQuery) Open(dst []byte, sharedKey *[32]byte) ([]byte, error) {
+	var nonce [NonceSizeX]byte
+	copy(nonce[:], q.ClientNonce[:])
+	return dnscryptOpen(dst, q.Box, &nonce, sharedKey)
+}
+
+// SealDNSCryptQuery pads and seals the DNS query and appends the DNSCrypt
+// query to dst. The client nonce must be unique for each query. The padded
+// query is at least minQuerySize bytes long, which must be a multiple of 64 -
+// UDP queries start with DNSCryptMinQuerySize. For TCP queries the spec
+// recommends a random min. query size.
+func SealDNSCryptQuery(dst, query []byte, clientMagic *[DNSCryptClientMagicSize]byte, clientPublicKey *[32]byte, clientNonce *[DNSCryptHalfNonceSize]byte, sharedKey *[32]byte, minQuerySize int) ([]byte, error) {
+	if minQuerySize < 0 || minQuerySize%dnscryptPadBlock != 0 {
+		return nil, errDNSCryptMinQuerySize
+	}
+	var nonce [NonceSizeX]byte
+	copy(nonce[:], clientNonce[:])
+
+	dst = append(dst, clientMagic[:]...)
+	dst = append(dst, clientPublicKey[:]...)
+	dst = append(dst, clientNonce[:]...)
+	padded := DNSCryptPad(nil, query, minQuerySize)
+	dst = BoxSealAfterPrecomputation(dst, padded, &nonce, sharedKey)
+	wipe(padded)
+	return dst, nil
+}
+
+// SealDNSCryptResponse pads and seals the DNS response to the query with the
+// client nonce and appends the DNSCrypt response to dst. The resolver nonce
+// must be unique for each response.
+func SealDNSCryptResponse(dst, response []byte, clientNonce, resolverNonce *[DNSCryptHalfNonceSize]byte, sharedKey *[32]byte) []byte {
+	var nonce [NonceSizeX]byte
+	copy(nonce[:], clientNonce[:])
+	copy(nonce[DNSCryptHalfNonceSize:], resolverNonce[:])
+
+	dst = append(dst, DNSCryptResolverMagic...)
+	dst = append(dst, nonce[:]...)
+	padded := DNSCryptPad(nil, response, 0)
+	dst = BoxSealAfterPrecomputation(dst, padded, &nonce, sharedKey)
+	wipe(padded)
+	return dst
+}
+
+// OpenDNSCryptResponse authenticates and decrypts the DNSCrypt response to
+// the query with the client nonce, removes the padding and appends the DNS
+// response to dst.
+func OpenDNSCryptResponse(dst, packet []byte, clientNonce *[DNSCryptHalfNonceSize]byte, sharedKey *[32]byte) ([]byte, error) {
+	if len(packet) < dnscryptResponseHeader+BoxOverhead {
+		return nil, errDNSCryptPacket
+	}
+	if string(packet[:len(DNSCryptResolverMagic)]) != DNSCryptResolverMagic {
+		return nil, errDNSCryptMagic
+	}
+	var nonce [NonceSizeX]byte
+	copy(nonce[:], packet[len(DNSCryptResolverMagic):])
+	if subtle.ConstantTimeCompare(nonce[:DNSCryptHalfNonceSize], clientNonce[:]) != 1 {
+		return nil, errDNSCryptNonce
+	}
+	return dnscryptOpen(dst, packet[dnscryptResponseHeader:], &nonce, sharedKey)
+}
+
+func dnscryptOpen(dst, box []byte, nonce *[NonceSizeX]byte, sharedKey *[32]byte) ([]byte, error) {
+	padded, ok := BoxOpenAfterPrecomputation(nil, box, nonce, sharedKey)
+	if !ok {
+		return nil, errAuthFailed
+	}
+	msg, err := DNSCryptUnpad(padded)
+	if err != nil {
+		return nil, err
+	}
+	dst = append(dst, msg...)
+	wipe(padded)
+	return dst, nil
+}
+
+// DNSCryptPad appends the message followed by a 0x80 byte and zeros to dst.
+// The padded message is at least minSize bytes long and its length is a
+// multiple of 64.
+func DNSCryptPad(dst, msg []byte, minSize int) []byte {
+	n := len(msg) + 1
+	if n < minSize {
+		n = minSize
+	}
+	if r := n % dnscryptPadBlock; r != 0 {
+		n += dnscryptPadBlock - r
+	}
+	ret, out := sliceForAppend(dst, n)
+	copy(out, msg)
+	out[len(msg)] = 0x80
+	for i := len(msg) + 1; i < n; i++ {
+		out[i] = 0
+	}
+	return ret
+}
+
+// DNSCryptUnpad returns the message of a padded message. The returned slice
+// refers to padded.
+func DNSCryptUnpad(padded []byte) ([]byte, error) {
+	i := len(padded) - 1
+	for i >= 0 && padded[i] == 0 {
+		i--
+	}
+	if i < 0 || padded[i] != 0x80 {
+		return nil, errDNSCryptPadding
+	}
+	return padded[:i], nil
+}
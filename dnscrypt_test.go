@@ -0,0 +1,101 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package chacha20
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestDNSCryptPad(t *testing.T) {
+	for _, test := range []struct {
+		size, minSize, padded int
+	}{
+		{0, 0, 64}, {63, 0, 64}, {64, 0, 128}, {10, 256, 256},
+		{255, 256, 256}, {256, 256, 320}, {300, 256, 320},
+	} {
+		msg := bytes.Repeat([]byte{0x80}, test.size)
+		padded := DNSCryptPad(nil, msg, test.minSize)
+		if len(padded) != test.padded {
+			t.Fatalf("Size %d: got padded length %d want %d", test.size, len(padded), test.padded)
+		}
+		unpadded, err := DNSCryptUnpad(padded)
+		if err != nil || !bytes.Equal(unpadded, msg) {
+			t.Fatalf("Size %d: DNSCryptUnpad failed: %v", test.size, err)
+		}
+	}
+	for _, padded := range [][]byte{nil, {0, 0}, {0x80, 1}, {1, 0x81, 0}} {
+		if _, err := DNSCryptUnpad(padded); err != errDNSCryptPadding {
+			t.Fatalf("DNSCryptUnpad(%x): expected %v, got %v", padded, errDNSCryptPadding, err)
+		}
+	}
+}
+
+func TestDNSCrypt(t *testing.T) {
+	var (
+		sharedKey, clientPK        [32]byte
+		clientMagic                [DNSCryptClientMagicSize]byte
+		clientNonce, resolverNonce [DNSCryptHalfNonceSize]byte
+	)
+	for i := range sharedKey {
+		sharedKey[i], clientPK[i] = byte(i), byte(2*i)
+	}
+	copy(clientMagic[:], "q6fnvWj8")
+	clientNonce[0], resolverNonce[0] = 1, 2
+	query := []byte("DNS query")
+
+	packet, err := SealDNSCryptQuery(nil, query, &clientMagic, &clientPK, &clientNonce, &sharedKey, DNSCryptMinQuerySize)
+	if err != nil {
+		t.Fatalf("SealDNSCryptQuery failed: %v", err)
+	}
+	if len(packet) != dnscryptQueryHeader+DNSCryptMinQuerySize+BoxOverhead {
+		t.Fatalf("Query has length %d", len(packet))
+	}
+	var nonce [NonceSizeX]byte
+	copy(nonce[:], clientNonce[:])
+	if box := referenceBox(DNSCryptPad(nil, query, DNSCryptMinQuerySize), &nonce, &sharedKey); !bytes.Equal(packet[dnscryptQueryHeader:], box) {
+		t.Fatal("Query box differs from the reference")
+	}
+
+	q, err := ParseDNSCryptQuery(packet)
+	if err != nil {
+		t.Fatalf("ParseDNSCryptQuery failed: %v", err)
+	}
+	if q.ClientMagic != clientMagic || q.ClientPublicKey != clientPK || q.ClientNonce != clientNonce {
+		t.Fatal("ParseDNSCryptQuery returned a wrong header")
+	}
+	opened, err := q.Open(nil, &sharedKey)
+	if err != nil || !bytes.Equal(opened, query) {
+		t.Fatalf("DNSCryptQuery.Open failed: %v", err)
+	}
+	q.Box[0] ^= 1
+	if _, err = q.Open(nil, &sharedKey); err == nil {
+		t.Fatal("DNSCryptQuery.Open accepted a modified query")
+	}
+	if _, err = SealDNSCryptQuery(nil, query, &clientMagic, &clientPK, &clientNonce, &sharedKey, 100); err != errDNSCryptMinQuerySize {
+		t.Fatalf("expected %v, got %v", errDNSCryptMinQuerySize, err)
+	}
+
+	response := []byte("DNS response")
+	packet = SealDNSCryptResponse(nil, response, &clientNonce, &resolverNonce, &sharedKey)
+	if !bytes.HasPrefix(packet, []byte(DNSCryptResolverMagic)) || (len(packet)-dnscryptResponseHeader-BoxOverhead)%64 != 0 {
+		t.Fatal("Response has an invalid format")
+	}
+	opened, err = OpenDNSCryptResponse(nil, packet, &clientNonce, &sharedKey)
+	if err != nil || !bytes.Equal(opened, response) {
+		t.Fatalf("OpenDNSCryptResponse failed: %v", err)
+	}
+	var otherNonce [DNSCryptHalfNonceSize]byte
+	if _, err = OpenDNSCryptResponse(nil, packet, &otherNonce, &sharedKey); err != errDNSCryptNonce {
+		t.Fatalf("expected %v, got %v", errDNSCryptNonce, err)
+	}
+	packet[0] ^= 1
+	if _, err = OpenDNSCryptResponse(nil, packet, &clientNonce, &sharedKey); err != errDNSCryptMagic {
+		t.Fatalf("expected %v, got %v", errDNSCryptMagic, err)
+	}
+	if _, err = OpenDNSCryptResponse(nil, packet[:dnscryptResponseHeader], &clientNonce, &sharedKey); err != errDNSCryptPacket {
+		t.Fatalf("expected %v, got %v", errDNSCryptPacket, err)
+	}
+}
@@ -0,0 +1,208 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package chacha20
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"io"
+	"sync"
+
+	"github.com/aead/chacha20/chacha"
+)
+
+const (
+	// DefaultReseedInterval is the number of requests a DRBG serves
+	// between two reseeds if no interval is specified.
+	DefaultReseedInterval = 1 << 20
+
+	// MaxDRBGRequestSize is the max. number of bytes produced by one
+	// request of a DRBG.
+	MaxDRBGRequestSize = 64 * 1024
+
+	// minDRBGEntropy is the min. number of entropy bytes.
+	minDRBGEntropy = 32
+)
+
+var (
+	errDRBGEntropy     = errors.New("entropy must be at least 32 bytes")
+	errDRBGRequestSize = errors.New("request exceeds the max. DRBG request size")
+)
+
+// DRBGConfig specifies the operational parameters of a DRBG.
+type DRBGConfig struct {
+	// Entropy is the source of the entropy read by automatic reseeds.
+	// If nil crypto/rand.Reader is used.
+	Entropy io.Reader
+
+	// ReseedInterval is the number of requests served before the DRBG
+	// reseeds itself. If zero DefaultReseedInterval is used.
+	ReseedInterval uint64
+
+	// PredictionResistance reseeds the DRBG before every request.
+	PredictionResistance bool
+}
+
+// DRBG is a deterministic random bit generator built on the ChaCha20
+// core. Every request produces the keystream of the current key, uses the
+// first 32 bytes as the next key and returns the keystream starting at
+// the second block. Entropy
+// and additional input are mixed into the key by chaining the ChaCha20
+// keystream of the key XORed with 32 byte chunks of the input.
+// A DRBG is safe for concurrent use.
+type DRBG struct {
+	mu       sync.Mutex
+	key      [32]byte
+	requests uint64
+	epoch    uint64 // fork epoch of the last (re)seed
+
+	entropy              io.Reader
+	reseedInterval       uint64
+	predictionResistance bool
+}
+
+// NewDRBG returns a new DRBG instantiated with the entropy and the optional
+// personalization string. The entropy must be at least 32 bytes long. If config
+// is nil the default parameters are used.
+func NewDRBG(entropy, personalization []byte, config *DRBGConfig) (*DRBG, error) {
+	if len(entropy) < minDRBGEntropy {
+		return nil, errDRBGEntropy
+	}
+	d := &DRBG{
+		entropy:        rand.Reader,
+		reseedInterval: DefaultReseedInterval,
+		epoch:          forkEpoch(),
+	}
+	if config != nil {
+		if config.Entropy != nil {
+			d.entropy = config.Entropy
+		}
+		if config.ReseedInterval > 0 {
+			d.reseedInterval = config.ReseedInterval
+		}
+		d.predictionResistance = config.PredictionResistance
+	}
+	d.update(entropy, drbgInstantiate)
+	d.update(personalization, drbgInstantiate)
+	return d, nil
+}
+
+// Reseed mixes the entropy into the state of the DRBG and resets the
+// request counter. The entropy must be at least 32 bytes long.
+func (d *DRBG) Reseed(entropy []byte) error {
+	if len(entropy) < minDRBGEntropy {
+		return errDRBGEntropy
+	}
+	d.mu.Lock()
+	d.reseed(entropy)
+	d.mu.Unlock()
+	return nil
+}
+
+// Generate fills out with random bytes. The optional additional input is
+// mixed into the state before the bytes are generated. Generate reseeds the
+// DRBG from the entropy source if the reseed interval is reached, if
+// prediction resistance is enabled or if the process forked. len(out) must not exceed MaxDRBGRequestSize.
+func (d *DRBG) Generate(out, additionalInput []byte) error {
+	if len(out) > MaxDRBGRequestSize {
+		return errDRBGRequestSize
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	epoch := forkEpoch()
+	if d.predictionResistance || d.requests >= d.reseedInterval || epoch != d.epoch {
+		var entropy [minDRBGEntropy]byte
+		if _, err := io.ReadFull(d.entropy, entropy[:]); err != nil {
+			return err
+		}
+		d.reseed(entropy[:])
+		d.epoch = epoch
+	}
+	if len(additionalInput) > 0 {
+		d.update(additionalInput, drbgGenerate)
+	}
+
+	// The first block yields the next key, the following blocks the output.
+	var (
+		nonce [NonceSize]byte
+		block [64]byte
+	)
+	nonce[0] = drbgOutput
+	for i := range out {
+		out[i] = 0
+	}
+	chacha.XORKeyStream(out, out, &nonce, &d.key, 1, 20)
+	chacha.Block(&block, &nonce, &d.key, 0, 20)
+	copy(d.key[:], block[:32])
+	for i := range block {
+		block[i] = 0
+	}
+	d.requests++
+	return nil
+}
+
+// Read fills p with random bytes by one or more Generate requests
+// without additional input.
+func (d *DRBG) Read(p []byte) (int, error) {
+	n := 0
+	for n < len(p) {
+		m := len(p) - n
+		if m > MaxDRBGRequestSize {
+			m = MaxDRBGRequestSize
+		}
+		if err := d.Generate(p[n:n+m], nil); err != nil {
+			return n, err
+		}
+		n += m
+	}
+	return n, nil
+}
+
+func (d *DRBG) reseed(entropy []byte) {
+	d.update(entropy, drbgReseed)
+	d.requests = 0
+}
+
+// The first nonce byte separates the ChaCha20 keystreams
+// used by the different DRBG operations and by Expand.
+const (
+	drbgInstantiate = iota + 1
+	drbgReseed
+	drbgGenerate
+	drbgOutput
+	expandContext
+	expandOutput
+)
+
+func (d *DRBG) update(data []byte, op byte) { mixKey(&d.key, data, op) }
+
+// mixKey mixes data into the key. Every 32 byte chunk of data - the
+// last one padded with zeros - is XORed into the key, which is then
+// replaced by the first 32 bytes of its keystream. The nonce holds the
+// operation, the length of data and the index of the chunk.
+func mixKey(key *[32]byte, data []byte, op byte) {
+	var nonce [NonceSize]byte
+	nonce[0] = op
+	binary.LittleEndian.PutUint32(nonce[4:], uint32(len(data)))
+
+	var block [64]byte
+	for i := 0; i == 0 || 32*i < len(data); i++ {
+		chunk := data[32*i:]
+		if len(chunk) > 32 {
+			chunk = chunk[:32]
+		}
+		for j, v := range chunk {
+			key[j] ^= v
+		}
+		binary.LittleEndian.PutUint32(nonce[8:], uint32(i))
+		chacha.Block(&block, &nonce, key, 0, 20)
+		copy(key[:], block[:32])
+	}
+	for i := range block {
+		block[i] = 0
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package chacha20
+
+import (
+	"bytes"
+	"testing"
+)
+
+func newTestDRBG(t *testing.T, config *DRBGConfig) *DRBG {
+	entropy := make([]byte, 48)
+	for i := range entropy {
+		entropy[i] = byte(i)
+	}
+	d, err := NewDRBG(entropy, []byte("personalization"), config)
+	if err != nil {
+		t.Fatalf("NewDRBG failed: %v", err)
+	}
+	return d
+}
+
+func TestDRBG(t *testing.T) {
+	if _, err := NewDRBG(make([]byte, 31), nil, nil); err == nil {
+		t.Fatal("NewDRBG accepted too little entropy")
+	}
+	config := &DRBGConfig{Entropy: bytes.NewReader(nil)}
+	d0, d1 := newTestDRBG(t, config), newTestDRBG(t, config)
+
+	out0, out1 := make([]byte, 100), make([]byte, 100)
+	for i := 0; i < 3; i++ {
+		if err := d0.Generate(out0, nil); err != nil {
+			t.Fatalf("Generate failed: %v", err)
+		}
+		d1.Generate(out1, nil)
+		if !bytes.Equal(out0, out1) {
+			t.Fatalf("Request %d: DRBG is not deterministic", i)
+		}
+	}
+	if err := d0.Generate(make([]byte, MaxDRBGRequestSize+1), nil); err == nil {
+		t.Fatal("Generate accepted a too large request")
+	}
+
+	d0.Generate(out0, []byte("additional input"))
+	d1.Generate(out1, nil)
+	if bytes.Equal(out0, out1) {
+		t.Fatal("Generate ignores the additional input")
+	}
+
+	if err := d0.Reseed(make([]byte, 31)); err == nil {
+		t.Fatal("Reseed accepted too little entropy")
+	}
+	d0, d1 = newTestDRBG(t, config), newTestDRBG(t, config)
+	if err := d0.Reseed(make([]byte, 32)); err != nil {
+		t.Fatalf("Reseed failed: %v", err)
+	}
+	d0.Generate(out0, nil)
+	d1.Generate(out1, nil)
+	if bytes.Equal(out0, out1) {
+		t.Fatal("Reseed doesn't change the state")
+	}
+}
+
+func TestDRBGReseedInterval(t *testing.T) {
+	entropy := bytes.NewReader(make([]byte, 2*minDRBGEntropy))
+	d := newTestDRBG(t, &DRBGConfig{Entropy: entropy, ReseedInterval: 2})
+	out := make([]byte, 16)
+	for i := 0; i < 6; i++ {
+		if err := d.Generate(out, nil); err != nil {
+			t.Fatalf("Request %d: Generate failed: %v", i, err)
+		}
+	}
+	if err := d.Generate(out, nil); err == nil {
+		t.Fatal("Generate didn't reseed after the reseed interval")
+	}
+
+	entropy = bytes.NewReader(make([]byte, minDRBGEntropy))
+	d = newTestDRBG(t, &DRBGConfig{Entropy: entropy, PredictionResistance: true})
+	if err := d.Generate(out, nil); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if err := d.Generate(out, nil); err == nil {
+		t.Fatal("Generate didn't reseed with prediction resistance")
+	}
+}
+
+func TestDRBGRead(t *testing.T) {
+	config := &DRBGConfig{Entropy: bytes.NewReader(nil)}
+	d0, d1 := newTestDRBG(t, config), newTestDRBG(t, config)
+	out := make([]byte, MaxDRBGRequestSize+100)
+	if n, err := d0.Read(out); n != len(out) || err != nil {
+		t.Fatalf("Read returned %d, %v", n, err)
+	}
+	expected := make([]byte, len(out))
+	d1.Generate(expected[:MaxDRBGRequestSize], nil)
+	d1.Generate(expected[MaxDRBGRequestSize:], nil)
+	if !bytes.Equal(out, expected) {
+		t.Fatal("Read produces unexpected output")
+	}
+}
@@ -0,0 +1,221 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package chacha20
+
+import (
+	"crypto/cipher"
+	"errors"
+)
+
+const (
+	// EnvelopeVersion is the version of the envelopes written by
+	// SealEnvelope. Password and multi-recipient envelopes use
+	// EnvelopeVersion2 and EnvelopeVersion3.
+	EnvelopeVersion = EnvelopeVersion1
+
+	// EnvelopeVersion1 is the envelope format without KDF parameters.
+	EnvelopeVersion1 = 1
+
+	// EnvelopeVersion2 is the envelope format with KDF parameters.
+	EnvelopeVersion2 = 2
+
+	// EnvelopeVersion3 is the envelope format with KDF parameters and
+	// recipients.
+	EnvelopeVersion3 = 3
+)
+
+var envelopeMagic = [4]byte{'c', 'c', '2', '0'}
+
+var (
+	errInvalidEnvelope       = errors.New("invalid envelope encoding")
+	errUnsupportedEnvelope   = errors.New("unsupported envelope version")
+	errEnvelopeFieldTooLarge = errors.New("envelope nonce, key ID, KDF parameters or recipient fields are larger than 255 bytes")
+	errEnvelopeNonceSize     = errors.New("envelope nonce size does not match the AEAD")
+)
+
+// Envelope is a standalone sealed message. The binary encoding of an
+// envelope is:
+//
+//	magic "cc20" (4 bytes) | version (1 byte) |
+//	nonce length (1 byte) | nonce | key ID length (1 byte) | key ID |
+//	KDF length (1 byte) | KDF | [recipients] | ciphertext
+//
+// The KDF field is only present in version 2 and 3 envelopes. It contains
+// the salt and the parameters of a password-based key derivation function.
+// The recipients are only present in version 3 envelopes:
+//
+//	recipient count (1 byte) | for each recipient:
+//	ID length (1 byte) | ID | wrapped key length (1 byte) | wrapped key
+//
+// See SealMultiRecipientEnvelope.
+//
+// The encoded header (everything before the ciphertext) is authenticated
+// as part of the additional data by Seal and Open.
+type Envelope struct {
+	Version    byte
+	Nonce      []byte
+	KeyID      []byte
+	KDF        []byte
+	Recipients []EnvelopeRecipient
+	Ciphertext []byte
+}
+
+// SealEnvelope encrypts and authenticates the plaintext and the additional data
+// using c and the nonce and returns the resulting envelope. The key ID is optional
+// and tells the recipient which key was used.
+func SealEnvelope(c cipher.AEAD, nonce, keyID, plaintext, additionalData []byte) (*Envelope, error) {
+	if len(nonce) != c.NonceSize() {
+		return nil, errEnvelopeNonceSize
+	}
+	e := &Envelope{
+		Version: EnvelopeVersion,
+		Nonce:   nonce,
+		KeyID:   keyID,
+	}
+	header, err := e.header()
+	if err != nil {
+		return nil, err
+	}
+	e.Ciphertext = c.Seal(nil, nonce, plaintext, append(header, additionalData...))
+	return e, nil
+}
+
+// Open decrypts and authenticates the envelope using c and the additional data
+// and appends the plaintext to dst.
+func (e *Envelope) Open(dst []byte, c cipher.AEAD, additionalData []byte) ([]byte, error) {
+	if len(e.Nonce) != c.NonceSize() {
+		return nil, errEnvelopeNonceSize
+	}
+	header, err := e.header()
+	if err != nil {
+		return nil, err
+	}
+	return c.Open(dst, e.Nonce, e.Ciphertext, append(header, additionalData...))
+}
+
+// MarshalBinary returns the binary encoding of the envelope.
+func (e *Envelope) MarshalBinary() ([]byte, error) {
+	header, err := e.header()
+	if err != nil {
+		return nil, err
+	}
+	return append(header, e.Ciphertext...), nil
+}
+
+// UnmarshalBinary decodes the binary encoding of an envelope. The fields
+// of e alias data.
+func (e *Envelope) UnmarshalBinary(data []byte) error {
+	if len(data) < len(envelopeMagic)+3 {
+		return errInvalidEnvelope
+	}
+	for i, v := range envelopeMagic {
+		if data[i] != v {
+			return errInvalidEnvelope
+		}
+	}
+	version := data[4]
+	if version < EnvelopeVersion1 || version > EnvelopeVersion3 {
+		return errUnsupportedEnvelope
+	}
+	data = data[5:]
+
+	nonce, data, ok := readEnvelopeField(data)
+	if !ok {
+		return errInvalidEnvelope
+	}
+	keyID, data, ok := readEnvelopeField(data)
+	if !ok {
+		return errInvalidEnvelope
+	}
+	var kdf []byte
+	if version >= EnvelopeVersion2 {
+		if kdf, data, ok = readEnvelopeField(data); !ok {
+			return errInvalidEnvelope
+		}
+	}
+	var recipients []EnvelopeRecipient
+	if version == EnvelopeVersion3 {
+		if len(data) < 1 {
+			return errInvalidEnvelope
+		}
+		recipients = make([]EnvelopeRecipient, data[0])
+		data = data[1:]
+		for i := range recipients {
+			if recipients[i].ID, data, ok = readEnvelopeField(data); !ok {
+				return errInvalidEnvelope
+			}
+			if recipients[i].WrappedKey, data, ok = readEnvelopeField(data); !ok {
+				return errInvalidEnvelope
+			}
+		}
+	}
+
+	e.Version = version
+	e.Nonce = nonce
+	e.KeyID = keyID
+	e.KDF = kdf
+	e.Recipients = recipients
+	e.Ciphertext = data
+	return nil
+}
+
+func (e *Envelope) header() ([]byte, error) {
+	if len(e.Nonce) > 255 || len(e.KeyID) > 255 || len(e.KDF) > 255 {
+		return nil, errEnvelopeFieldTooLarge
+	}
+	switch e.Version {
+	case EnvelopeVersion1:
+		if len(e.KDF) > 0 || len(e.Recipients) > 0 {
+			return nil, errUnsupportedEnvelope
+		}
+	case EnvelopeVersion2:
+		if len(e.Recipients) > 0 {
+			return nil, errUnsupportedEnvelope
+		}
+	case EnvelopeVersion3:
+		if len(e.Recipients) > 255 {
+			return nil, errEnvelopeFieldTooLarge
+		}
+		for _, r := range e.Recipients {
+			if len(r.ID) > 255 || len(r.WrappedKey) > 255 {
+				return nil, errEnvelopeFieldTooLarge
+			}
+		}
+	default:
+		return nil, errUnsupportedEnvelope
+	}
+	header := make([]byte, 0, len(envelopeMagic)+5+len(e.Nonce)+len(e.KeyID)+len(e.KDF))
+	header = append(header, envelopeMagic[:]...)
+	header = append(header, e.Version, byte(len(e.Nonce)))
+	header = append(header, e.Nonce...)
+	header = append(header, byte(len(e.KeyID)))
+	header = append(header, e.KeyID...)
+	if e.Version >= EnvelopeVersion2 {
+		header = append(header, byte(len(e.KDF)))
+		header = append(header, e.KDF...)
+	}
+	if e.Version == EnvelopeVersion3 {
+		header = append(header, byte(len(e.Recipients)))
+		for _, r := range e.Recipients {
+			header = append(header, byte(len(r.ID)))
+			header = append(header, r.ID...)
+			header = append(header, byte(len(r.WrappedKey)))
+			header = append(header, r.WrappedKey...)
+		}
+	}
+	return header, nil
+}
+
+// readEnvelopeField reads a field prefixed with a 1 byte length.
+func readEnvelopeField(data []byte) (field, rest []byte, ok bool) {
+	if len(data) < 1 {
+		return nil, nil, false
+	}
+	n := int(data[0])
+	if len(data) < 1+n {
+		return nil, nil, false
+	}
+	return data[1 : 1+n], data[1+n:], true
+}
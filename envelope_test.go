@@ -0,0 +1,75 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package chacha20
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestEnvelope(t *testing.T) {
+	var (
+		key   [32]byte
+		nonce [NonceSize]byte
+	)
+	c := NewChaCha20Poly1305(&key)
+	plaintext, data := []byte("Hello, World"), []byte("additional data")
+
+	e, err := SealEnvelope(c, nonce[:], []byte("key-1"), plaintext, data)
+	if err != nil {
+		t.Fatalf("SealEnvelope failed: %s", err)
+	}
+	encoded, err := e.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary failed: %s", err)
+	}
+
+	var d Envelope
+	if err = d.UnmarshalBinary(encoded); err != nil {
+		t.Fatalf("UnmarshalBinary failed: %s", err)
+	}
+	if !bytes.Equal(d.KeyID, []byte("key-1")) || !bytes.Equal(d.Nonce, nonce[:]) {
+		t.Fatalf("Decoded envelope differs: %+v", d)
+	}
+	decrypted, err := d.Open(nil, c, data)
+	if err != nil {
+		t.Fatalf("Open failed: %s", err)
+	}
+	if !bytes.Equal(decrypted, plaintext) {
+		t.Fatalf("Decrypted envelope differs from plaintext: %x", decrypted)
+	}
+
+	if d.Version != EnvelopeVersion1 || len(d.KDF) != 0 {
+		t.Fatalf("SealEnvelope writes version %d with KDF %x - want a version 1 envelope", d.Version, d.KDF)
+	}
+
+	d.KeyID = []byte("key-2")
+	if _, err = d.Open(nil, c, data); err == nil {
+		t.Fatal("Open accepted envelope with modified key ID")
+	}
+}
+
+func TestEnvelopeUnmarshalInvalid(t *testing.T) {
+	var key [32]byte
+	var nonce [NonceSize]byte
+	e, _ := SealEnvelope(NewChaCha20Poly1305(&key), nonce[:], nil, nil, nil)
+	encoded, _ := e.MarshalBinary()
+
+	var d Envelope
+	for i := 0; i < len(envelopeMagic)+2+NonceSize; i++ {
+		if err := d.UnmarshalBinary(encoded[:i]); err == nil {
+			t.Fatalf("UnmarshalBinary accepted truncated envelope of %d bytes", i)
+		}
+	}
+
+	encoded[4] = EnvelopeVersion3 + 1
+	if err := d.UnmarshalBinary(encoded); err == nil {
+		t.Fatal("UnmarshalBinary accepted unknown version")
+	}
+	encoded[0] = 0
+	if err := d.UnmarshalBinary(encoded); err == nil {
+		t.Fatal("UnmarshalBinary accepted invalid magic")
+	}
+}
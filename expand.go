@@ -0,0 +1,26 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package chacha20
+
+import "github.com/aead/chacha20/chacha"
+
+// Expand returns n pseudorandom bytes derived from the key and bound to the
+// context. Different contexts produce independent outputs, so one key can be
+// used to derive e.g. IVs, padding and per-record secrets deterministically.
+// The key itself must be secret and uniformly random. The output for a shorter
+// n is a prefix of the output for a longer n. n must not exceed 256 GiB.
+func Expand(key *[32]byte, context []byte, n int) []byte {
+	subKey := *key
+	mixKey(&subKey, context, expandContext)
+
+	var nonce [NonceSize]byte
+	nonce[0] = expandOutput
+	out := make([]byte, n)
+	chacha.XORKeyStream(out, out, &nonce, &subKey, 0, 20)
+	for i := range subKey {
+		subKey[i] = 0
+	}
+	return out
+}
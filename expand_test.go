@@ -0,0 +1,46 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package chacha20
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestExpand(t *testing.T) {
+	var key [32]byte
+	for i := range key {
+		key[i] = byte(i)
+	}
+	out := Expand(&key, []byte("iv"), 200)
+	if len(out) != 200 {
+		t.Fatalf("Expand returns %d bytes - want 200", len(out))
+	}
+	if !bytes.Equal(Expand(&key, []byte("iv"), 200), out) {
+		t.Fatal("Expand is not deterministic")
+	}
+	if !bytes.Equal(Expand(&key, []byte("iv"), 17), out[:17]) {
+		t.Fatal("shorter output is not a prefix of the longer output")
+	}
+
+	// Contexts which only differ in trailing zeros or in the chunk
+	// boundaries must produce different outputs.
+	contexts := [][]byte{nil, {0}, []byte("i"), []byte("iv\x00"), make([]byte, 32), make([]byte, 33)}
+	for i, a := range contexts {
+		for _, b := range contexts[i+1:] {
+			if bytes.Equal(Expand(&key, a, 32), Expand(&key, b, 32)) {
+				t.Fatalf("Contexts %q and %q produce the same output", a, b)
+			}
+		}
+		if bytes.Equal(Expand(&key, a, 32), out[:32]) {
+			t.Fatalf("Contexts %q and %q produce the same output", a, "iv")
+		}
+	}
+
+	key[0] ^= 1
+	if bytes.Equal(Expand(&key, []byte("iv"), 32), out[:32]) {
+		t.Fatal("Expand ignores the key")
+	}
+}
@@ -0,0 +1,12 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+// +build !linux
+
+package chacha20
+
+import "os"
+
+// forkEpoch returns a value which changes whenever the process forks.
+func forkEpoch() uint64 { return uint64(os.Getpid()) }
@@ -0,0 +1,59 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package chacha20
+
+import (
+	"os"
+	"sync"
+	"sync/atomic"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+// fork holds the fork epoch in a page marked with MADV_WIPEONFORK.
+// The kernel zeroes the page in the child of a fork, so a zero epoch
+// means that the process forked since the epoch was stored last.
+// If the kernel doesn't support MADV_WIPEONFORK (Linux < 4.14) the
+// PID is used as epoch.
+var fork struct {
+	sync.Mutex
+	page  []byte
+	epoch uint64
+}
+
+func init() {
+	page, err := unix.Mmap(-1, 0, os.Getpagesize(), unix.PROT_READ|unix.PROT_WRITE, unix.MAP_PRIVATE|unix.MAP_ANONYMOUS)
+	if err != nil {
+		return
+	}
+	if err = unix.Madvise(page, unix.MADV_WIPEONFORK); err != nil {
+		unix.Munmap(page)
+		return
+	}
+	fork.page = page
+	fork.epoch = 1
+	atomic.StoreUint64((*uint64)(unsafe.Pointer(&page[0])), fork.epoch)
+}
+
+// forkEpoch returns a value which changes whenever the process forks.
+func forkEpoch() uint64 {
+	if fork.page == nil {
+		return uint64(os.Getpid())
+	}
+	epoch := (*uint64)(unsafe.Pointer(&fork.page[0]))
+	if v := atomic.LoadUint64(epoch); v != 0 {
+		return v
+	}
+
+	fork.Lock()
+	defer fork.Unlock()
+	if v := atomic.LoadUint64(epoch); v != 0 {
+		return v
+	}
+	fork.epoch++
+	atomic.StoreUint64(epoch, fork.epoch)
+	return fork.epoch
+}
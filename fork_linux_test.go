@@ -0,0 +1,78 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package chacha20
+
+import (
+	"bytes"
+	"testing"
+)
+
+// simulateFork zeroes the fork epoch like the kernel does in the child
+// of a fork.
+func simulateFork(t *testing.T) {
+	if fork.page == nil {
+		t.Skip("MADV_WIPEONFORK is not supported")
+	}
+	for i := range fork.page {
+		fork.page[i] = 0
+	}
+}
+
+func TestForkEpoch(t *testing.T) {
+	epoch := forkEpoch()
+	if forkEpoch() != epoch {
+		t.Fatal("fork epoch changes without fork")
+	}
+	simulateFork(t)
+	if forkEpoch() == epoch {
+		t.Fatal("fork epoch doesn't change after fork")
+	}
+}
+
+func TestRandFork(t *testing.T) {
+	seed := make([]byte, 4*32)
+	for i := range seed {
+		seed[i] = byte(i)
+	}
+	r := NewRand(bytes.NewReader(seed))
+	out := make([]byte, 16)
+	r.Read(out)
+	simulateFork(t)
+	r.Read(out)
+
+	var key [32]byte
+	var nonce [NonceSize]byte
+	copy(key[:], seed[32:])
+	expected := make([]byte, len(out))
+	XORKeyStream(expected, expected, &nonce, &key, 0)
+	if !bytes.Equal(out, expected) {
+		t.Fatal("Rand doesn't reseed after fork")
+	}
+
+	// Without fork the second read returns the buffered keystream.
+	copy(key[:], seed)
+	block := make([]byte, 768)
+	XORKeyStream(block, block, &nonce, &key, 0)
+	k, _ := NewKeyErasureRand(bytes.NewReader(seed))
+	k.Read(out)
+	simulateFork(t)
+	k.Read(out)
+	if bytes.Equal(out, block[32+16:32+32]) {
+		t.Fatal("KeyErasureRand replays buffered output after fork")
+	}
+
+	out0, out1 := make([]byte, 16), make([]byte, 16)
+	d0 := newTestDRBG(t, &DRBGConfig{Entropy: bytes.NewReader(seed)})
+	d1 := newTestDRBG(t, &DRBGConfig{Entropy: bytes.NewReader(nil)})
+	simulateFork(t)
+	if err := d0.Generate(out0, nil); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	d1.epoch = forkEpoch()
+	d1.Generate(out1, nil)
+	if bytes.Equal(out0, out1) {
+		t.Fatal("DRBG doesn't reseed after fork")
+	}
+}
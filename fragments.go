@@ -0,0 +1,174 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package chacha20
+
+import (
+	"github.com/aead/chacha20/chacha"
+	"github.com/aead/poly1305"
+)
+
+// FragmentOpener is implemented by all cipher.AEAD implementations returned
+// by this package. It opens a ciphertext which is split into fragments - for
+// example by the wraparound of a ring buffer or by the iovecs of a network
+// stack - without copying it into one buffer first.
+type FragmentOpener interface {
+	// OpenFragments is like Open but takes the ciphertext - including the
+	// tag - as the concatenation of the fragments. The fragments may have
+	// any length and the tag may span several fragments. The plaintext is
+	// appended to dst, which must not overlap the fragments.
+	OpenFragments(dst, nonce []byte, ciphertext [][]byte, additionalData []byte) ([]byte, error)
+}
+
+func (c *aead) OpenFragments(dst, nonce []byte, ciphertext [][]byte, additionalData []byte) ([]byte, error) {
+	if n := len(nonce); n != c.NonceSize() {
+		return nil, errInvalidNonceSize
+	}
+	if c.key.wiped {
+		return nil, errKeyWiped
+	}
+	if exceedsPlaintextSize(fragmentsLength(ciphertext), c.tagsize) {
+		return nil, ErrMessageTooLarge
+	}
+	engine := c.engine()
+	ret, err := c.openFragments(engine, dst, nonce, ciphertext, additionalData)
+	c.key.release(&c.key.engines, engine)
+	countOpen(fragmentsLength(ciphertext)-c.tagsize, err)
+	return ret, err
+}
+
+func (c *xaead) OpenFragments(dst, nonce []byte, ciphertext [][]byte, additionalData []byte) ([]byte, error) {
+	if n := len(nonce); n != NonceSizeX {
+		return nil, errInvalidNonceSize
+	}
+	if c.key.wiped {
+		return nil, errKeyWiped
+	}
+	if exceedsPlaintextSize(fragmentsLength(ciphertext), c.tagsize) {
+		return nil, ErrMessageTooLarge
+	}
+	var subNonce [NonceSize]byte
+	engine := c.subCipher(&subNonce, nonce)
+	sub := aead{tagsize: c.tagsize}
+	ret, err := sub.openFragments(engine, dst, subNonce[:], ciphertext, additionalData)
+	c.key.release(&c.key.subEngines, engine)
+	countOpen(fragmentsLength(ciphertext)-c.tagsize, err)
+	return ret, err
+}
+
+// openFragments is like open but reads the ciphertext from the fragments.
+func (c *aead) openFragments(engine *chacha.Cipher, dst, nonce []byte, fragments [][]byte, additionalData []byte) ([]byte, error) {
+	total := fragmentsLength(fragments)
+	if total < c.tagsize {
+		return nil, errAuthFailed
+	}
+	n := total - c.tagsize
+
+	var tag [TagSize]byte
+	off := 0
+	for _, f := range fragments {
+		if off+len(f) > n {
+			start := 0
+			if off < n {
+				start = n - off
+			}
+			copy(tag[off+start-n:], f[start:])
+		}
+		off += len(f)
+	}
+
+	var polyKey [32]byte
+	c.polyKey(engine, &polyKey, nonce)
+	var sum [TagSize]byte
+	c.authenticateFragments(&sum, fragments, n, additionalData, &polyKey)
+	if !checkTag(&sum, tag[:c.tagsize], c.tagsize) {
+		return nil, errAuthFailed
+	}
+
+	ret, plaintext := sliceForAppend(dst, n)
+	forEachFragment(fragments, n, func(f []byte) {
+		engine.XORKeyStream(plaintext, f)
+		plaintext = plaintext[len(f):]
+	})
+	return ret, nil
+}
+
+// authenticateFragments computes the poly1305 tag of the first n bytes of
+// the fragments and the additional data like authenticate - or
+// authenticateLegacy.
+func (c *aead) authenticateFragments(out *[TagSize]byte, fragments [][]byte, n int, additionalData []byte, key *[32]byte) {
+	mac := newAEADMAC(key, additionalData, c.legacy)
+	forEachFragment(fragments, n, mac.write)
+	mac.sum(out)
+}
+
+// aeadMAC computes the poly1305 tag of the ChaCha20Poly1305 constructions
+// for a ciphertext which is written in several parts.
+type aeadMAC struct {
+	poly   *poly1305.Hash
+	adLen  int
+	ctLen  int
+	legacy bool
+}
+
+func newAEADMAC(key *[32]byte, additionalData []byte, legacy bool) *aeadMAC {
+	m := &aeadMAC{poly: poly1305.New(key), adLen: len(additionalData), legacy: legacy}
+	m.poly.Write(additionalData)
+	if legacy {
+		adLength := segmentLength(len(additionalData))
+		m.poly.Write(adLength[:])
+	} else {
+		m.pad(len(additionalData))
+	}
+	return m
+}
+
+func (m *aeadMAC) write(ciphertext []byte) {
+	m.poly.Write(ciphertext)
+	m.ctLen += len(ciphertext)
+}
+
+func (m *aeadMAC) pad(n int) {
+	var zeros [TagSize]byte
+	if r := n % TagSize; r != 0 {
+		m.poly.Write(zeros[:TagSize-r])
+	}
+}
+
+func (m *aeadMAC) sum(out *[TagSize]byte) {
+	if m.legacy {
+		ctLength := segmentLength(m.ctLen)
+		m.poly.Write(ctLength[:])
+	} else {
+		m.pad(m.ctLen)
+		lengths := aeadLengths(m.adLen, m.ctLen)
+		m.poly.Write(lengths[:])
+	}
+	m.poly.Sum(out)
+}
+
+// forEachFragment calls fn with the non-empty fragments of the first n
+// bytes of the fragments.
+func forEachFragment(fragments [][]byte, n int, fn func([]byte)) {
+	for _, f := range fragments {
+		if n == 0 {
+			return
+		}
+		if len(f) > n {
+			f = f[:n]
+		}
+		if len(f) > 0 {
+			fn(f)
+		}
+		n -= len(f)
+	}
+}
+
+func fragmentsLength(fragments [][]byte) int {
+	n := 0
+	for _, f := range fragments {
+		n += len(f)
+	}
+	return n
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package chacha20
+
+import (
+	"bytes"
+	"crypto/cipher"
+	"math/rand"
+	"testing"
+)
+
+// splitFragments splits b into fragments of random length - including
+// empty fragments.
+func splitFragments(r *rand.Rand, b []byte) [][]byte {
+	var fragments [][]byte
+	for len(b) > 0 {
+		n := r.Intn(len(b)/2 + 20)
+		if n > len(b) {
+			n = len(b)
+		}
+		fragments = append(fragments, b[:n])
+		b = b[n:]
+	}
+	return append(fragments, nil)
+}
+
+func TestOpenFragments(t *testing.T) {
+	var key [32]byte
+	for i := range key {
+		key[i] = byte(i)
+	}
+	shortTag, _ := NewChaCha20Poly1305WithTagSize(&key, 12)
+	aeads := map[string]cipher.AEAD{
+		"ChaCha20Poly1305":       NewChaCha20Poly1305(&key),
+		"ChaCha20Poly1305-12":    shortTag,
+		"XChaCha20Poly1305":      NewXChaCha20Poly1305(&key),
+		"ChaCha20Poly1305Legacy": NewChaCha20Poly1305Legacy(&key),
+	}
+	r := rand.New(rand.NewSource(1))
+	for name, c := range aeads {
+		f, ok := c.(FragmentOpener)
+		if !ok {
+			t.Fatalf("%s does not implement FragmentOpener", name)
+		}
+		nonce := make([]byte, c.NonceSize())
+		for _, size := range []int{0, 1, 15, 16, 63, 64, 65, 200, 1000, 5000} {
+			plaintext := make([]byte, size)
+			r.Read(plaintext)
+			data := []byte("additional data")
+			sealed := c.Seal(nil, nonce, plaintext, data)
+
+			for i := 0; i < 10; i++ {
+				fragments := splitFragments(r, sealed)
+				opened, err := f.OpenFragments([]byte("prefix"), nonce, fragments, data)
+				if err != nil || !bytes.Equal(opened, append([]byte("prefix"), plaintext...)) {
+					t.Fatalf("%s: size %d: OpenFragments failed: %v", name, size, err)
+				}
+				if _, err = f.OpenFragments(nil, nonce, fragments, nil); err == nil {
+					t.Fatalf("%s: size %d: OpenFragments accepted wrong additional data", name, size)
+				}
+			}
+
+			fragments := splitFragments(r, sealed)
+			for _, fragment := range fragments {
+				for j := range fragment {
+					fragment[j] ^= 1
+					if _, err := f.OpenFragments(nil, nonce, fragments, data); err == nil {
+						t.Fatalf("%s: size %d: OpenFragments accepted a modified ciphertext", name, size)
+					}
+					fragment[j] ^= 1
+				}
+			}
+		}
+		if _, err := f.OpenFragments(nil, nonce, [][]byte{make([]byte, c.Overhead()-1)}, nil); err == nil {
+			t.Fatalf("%s: OpenFragments accepted a too short ciphertext", name)
+		}
+	}
+}
+
+func BenchmarkOpenFragments(b *testing.B) {
+	var key [32]byte
+	var nonce [NonceSize]byte
+	c := NewChaCha20Poly1305(&key)
+	sealed := c.Seal(nil, nonce[:], make([]byte, 1024), nil)
+	fragments := [][]byte{sealed[:500], sealed[500:1030], sealed[1030:]}
+	dst := make([]byte, 0, 1024)
+	b.SetBytes(1024)
+	for i := 0; i < b.N; i++ {
+		if _, err := c.(FragmentOpener).OpenFragments(dst, nonce[:], fragments, nil); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
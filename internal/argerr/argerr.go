@@ -0,0 +1,14 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+// Package argerr contains the panic value of the argument checks of the
+// chacha20 and the chacha package.
+package argerr
+
+// Error is the panic value of functions called with invalid arguments -
+// e.g. an invalid nonce size or a too small dst buffer. chacha20.Catch
+// recovers panics with an Error but no other panics.
+type Error string
+
+func (e Error) Error() string { return string(e) }
@@ -0,0 +1,159 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package chacha20
+
+import (
+	"crypto/cipher"
+	"errors"
+	"sync"
+	"unsafe"
+
+	"github.com/aead/chacha20/chacha"
+)
+
+var (
+	errKeyWiped          = errors.New("key has been wiped")
+	errLockedUnsupported = errors.New("locked memory is not supported by the platform")
+)
+
+// Key is an immutable 256 bit key which can be shared by many AEADs.
+// All AEADs created from one Key use the same key and the same pools
+// of keyed ChaCha20 engines - so e.g. a server can create one AEAD per
+// connection without holding one copy of the key state per connection.
+// A Key doesn't change until it's wiped and is safe for concurrent use.
+type Key struct {
+	key    *[32]byte // points to buf or to locked
+	buf    [32]byte
+	locked []byte
+
+	// engines holds ChaCha20 engines keyed with key and
+	// subEngines holds engines keyed with XChaCha20 sub-keys.
+	engines    sync.Pool
+	subEngines sync.Pool
+
+	// state is a ChaCha20 engine keyed with key. The AEADs copy it, so the
+	// constants and the key words are set up once and every message only
+	// sets the nonce and the counter. Locked Keys have no state since it
+	// would be a copy of the key outside the locked memory.
+	state *chacha.Cipher
+
+	wiped bool
+}
+
+// NewKey returns a new Key holding a copy of key.
+func NewKey(key *[32]byte) *Key {
+	var nonce [12]byte
+	k := &Key{buf: *key}
+	k.key = &k.buf
+	k.state = chacha.NewCipher(&nonce, k.key, 20)
+	return k
+}
+
+// NewLockedKey returns a new Key holding a copy of key in locked memory,
+// which is neither swapped to disk nor included in core dumps. The pooled
+// ChaCha20 engines hold the key, too, so the AEADs of a locked Key don't
+// pool their engines and wipe them after every message. Locked memory is
+// only supported on Linux. The locked memory is released by Wipe.
+func NewLockedKey(key *[32]byte) (*Key, error) {
+	mem, err := allocLocked(len(key))
+	if err != nil {
+		return nil, err
+	}
+	k := &Key{locked: mem}
+	k.key = (*[32]byte)(unsafe.Pointer(&mem[0]))
+	*k.key = *key
+	return k, nil
+}
+
+// Wipe overwrites the key and the pooled ChaCha20 engines with zeros.
+// The AEADs created from the Key must not be used afterwards: Seal panics,
+// Open returns an error and Verify returns false. Wipe must not be called
+// concurrently with the AEADs.
+func (k *Key) Wipe() {
+	k.wiped = true
+	for i := range k.key {
+		k.key[i] = 0
+	}
+	if k.locked != nil {
+		freeLocked(k.locked)
+		k.key, k.locked = &k.buf, nil
+	}
+	if k.state != nil {
+		k.state.Wipe()
+	}
+	wipeEngines(&k.engines)
+	wipeEngines(&k.subEngines)
+}
+
+// newEngine returns a new ChaCha20 engine keyed with the key.
+func (k *Key) newEngine() *chacha.Cipher {
+	if k.state == nil {
+		var nonce [12]byte
+		return chacha.NewCipher(&nonce, k.key, 20)
+	}
+	engine := *k.state
+	return &engine
+}
+
+// xorKeyStream crypts src to dst with the keystream for the nonce
+// starting at counter 0.
+func (k *Key) xorKeyStream(dst, src []byte, nonce *[12]byte) {
+	if k.state == nil {
+		chacha.XORKeyStream(dst, src, nonce, k.key, 0, 20)
+		return
+	}
+	engine := *k.state
+	engine.SetNonce(nonce)
+	engine.XORKeyStream(dst, src)
+}
+
+// release returns the engine to the pool. The engines of a locked
+// Key are wiped instead, so they don't keep a copy of the key.
+func (k *Key) release(pool *sync.Pool, engine *chacha.Cipher) {
+	if k.locked != nil {
+		engine.Wipe()
+		return
+	}
+	pool.Put(engine)
+}
+
+// wipeEngines wipes and removes all engines from the pool.
+func wipeEngines(pool *sync.Pool) {
+	for {
+		engine, ok := pool.Get().(*chacha.Cipher)
+		if !ok {
+			return
+		}
+		engine.Wipe()
+	}
+}
+
+// ChaCha20Poly1305 returns a cipher.AEAD implementing the
+// ChaCha20Poly1305 construction specified in RFC 7539 with a
+// 128 bit auth. tag. The AEAD doesn't copy the key.
+func (k *Key) ChaCha20Poly1305() cipher.AEAD {
+	return &aead{key: k, tagsize: TagSize}
+}
+
+// ChaCha20Poly1305WithTagSize returns a cipher.AEAD implementing the
+// ChaCha20Poly1305 construction specified in RFC 7539 with arbitrary tag size.
+// The tagsize must be between the min. tag size - see SetMinTagSize - and the
+// TagSize constant. The AEAD doesn't copy the key.
+func (k *Key) ChaCha20Poly1305WithTagSize(tagsize int) (cipher.AEAD, error) {
+	if tagsize < 1 || tagsize > TagSize {
+		return nil, errInvalidTagSize
+	}
+	if tagsize < minTagSize {
+		return nil, errTagTooShort
+	}
+	return &aead{key: k, tagsize: tagsize}, nil
+}
+
+// XChaCha20Poly1305 returns a cipher.AEAD implementing the
+// XChaCha20Poly1305 construction with a 192 bit nonce and a
+// 128 bit auth. tag. The AEAD doesn't copy the key.
+func (k *Key) XChaCha20Poly1305() cipher.AEAD {
+	return &xaead{key: k, tagsize: TagSize}
+}
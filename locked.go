@@ -0,0 +1,11 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+// +build !linux
+
+package chacha20
+
+func allocLocked(size int) ([]byte, error) { return nil, errLockedUnsupported }
+
+func freeLocked(mem []byte) {}
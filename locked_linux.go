@@ -0,0 +1,42 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package chacha20
+
+import (
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// allocLocked returns size bytes of memory which is locked into RAM and
+// excluded from core dumps. The memory must be released by freeLocked.
+func allocLocked(size int) ([]byte, error) {
+	pageSize := os.Getpagesize()
+	mem, err := unix.Mmap(-1, 0, (size+pageSize-1)/pageSize*pageSize, unix.PROT_READ|unix.PROT_WRITE, unix.MAP_PRIVATE|unix.MAP_ANONYMOUS)
+	if err != nil {
+		return nil, err
+	}
+	if err = unix.Mlock(mem); err != nil {
+		unix.Munmap(mem)
+		return nil, err
+	}
+	if err = unix.Madvise(mem, unix.MADV_DONTDUMP); err != nil {
+		unix.Munlock(mem)
+		unix.Munmap(mem)
+		return nil, err
+	}
+	return mem[:size], nil
+}
+
+// freeLocked overwrites the memory returned by allocLocked with
+// zeros and releases it.
+func freeLocked(mem []byte) {
+	mem = mem[:cap(mem)]
+	for i := range mem {
+		mem[i] = 0
+	}
+	unix.Munlock(mem)
+	unix.Munmap(mem)
+}
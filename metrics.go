@@ -0,0 +1,106 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package chacha20
+
+import (
+	"sync/atomic"
+
+	"github.com/aead/chacha20/chacha"
+)
+
+// Metrics is a snapshot of the package-wide counters collected once
+// EnableMetrics has been called. The counters cover all AEADs returned by
+// this package - and the constructions built on them - as well as the
+// rekeys of sessions, Noise cipher states and QUIC key updates.
+//
+// The snapshot can be published by expvar or exported to Prometheus, e.g:
+//
+//	expvar.Publish("chacha20", expvar.Func(func() interface{} { return chacha20.ReadMetrics() }))
+type Metrics struct {
+	MessagesSealed uint64 // number of sealed messages
+	MessagesOpened uint64 // number of authentic opened messages
+	BytesSealed    uint64 // number of sealed plaintext bytes
+	BytesOpened    uint64 // number of opened plaintext bytes
+	AuthFailures   uint64 // number of messages rejected as not authentic
+	Rekeys         uint64 // number of key changes
+
+	Backend string // the active ChaCha20 implementation
+}
+
+// metricsEnabled is 1 if the counters are collected.
+var metricsEnabled int32
+
+// metrics holds the counters. All fields are 64 bit values, so they are
+// aligned for atomic access on 32 bit platforms.
+var metrics struct {
+	messagesSealed, messagesOpened uint64
+	bytesSealed, bytesOpened       uint64
+	authFailures, rekeys           uint64
+}
+
+// EnableMetrics enables or disables the collection of the counters. The
+// collection is disabled by default, so the counters don't cost anything
+// unless they are used. EnableMetrics may be called at any time.
+func EnableMetrics(enabled bool) {
+	var v int32
+	if enabled {
+		v = 1
+	}
+	atomic.StoreInt32(&metricsEnabled, v)
+}
+
+// ReadMetrics returns a snapshot of the counters.
+func ReadMetrics() Metrics {
+	return Metrics{
+		MessagesSealed: atomic.LoadUint64(&metrics.messagesSealed),
+		MessagesOpened: atomic.LoadUint64(&metrics.messagesOpened),
+		BytesSealed:    atomic.LoadUint64(&metrics.bytesSealed),
+		BytesOpened:    atomic.LoadUint64(&metrics.bytesOpened),
+		AuthFailures:   atomic.LoadUint64(&metrics.authFailures),
+		Rekeys:         atomic.LoadUint64(&metrics.rekeys),
+		Backend:        chacha.ActiveBackend().String(),
+	}
+}
+
+// ResetMetrics sets all counters to zero.
+func ResetMetrics() {
+	atomic.StoreUint64(&metrics.messagesSealed, 0)
+	atomic.StoreUint64(&metrics.messagesOpened, 0)
+	atomic.StoreUint64(&metrics.bytesSealed, 0)
+	atomic.StoreUint64(&metrics.bytesOpened, 0)
+	atomic.StoreUint64(&metrics.authFailures, 0)
+	atomic.StoreUint64(&metrics.rekeys, 0)
+}
+
+// countSeal counts a sealed message of n plaintext bytes.
+func countSeal(n int) {
+	if atomic.LoadInt32(&metricsEnabled) == 0 {
+		return
+	}
+	atomic.AddUint64(&metrics.messagesSealed, 1)
+	atomic.AddUint64(&metrics.bytesSealed, uint64(n))
+}
+
+// countOpen counts an opened message of n plaintext bytes or an
+// authentication failure. Other errors are not counted.
+func countOpen(n int, err error) {
+	if atomic.LoadInt32(&metricsEnabled) == 0 {
+		return
+	}
+	switch err {
+	case nil:
+		atomic.AddUint64(&metrics.messagesOpened, 1)
+		atomic.AddUint64(&metrics.bytesOpened, uint64(n))
+	case errAuthFailed:
+		atomic.AddUint64(&metrics.authFailures, 1)
+	}
+}
+
+// countRekey counts a key change.
+func countRekey() {
+	if atomic.LoadInt32(&metricsEnabled) != 0 {
+		atomic.AddUint64(&metrics.rekeys, 1)
+	}
+}
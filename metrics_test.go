@@ -0,0 +1,63 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package chacha20
+
+import (
+	"testing"
+
+	"github.com/aead/chacha20/chacha"
+)
+
+func TestMetrics(t *testing.T) {
+	var key [32]byte
+	ResetMetrics()
+	EnableMetrics(true)
+	defer EnableMetrics(false)
+	defer ResetMetrics()
+
+	nonce := make([]byte, NonceSize)
+	c := NewChaCha20Poly1305(&key)
+	sealed := c.Seal(nil, nonce, make([]byte, 100), nil)
+	xnonce := make([]byte, NonceSizeX)
+	xsealed := NewXChaCha20Poly1305(&key).Seal(nil, xnonce, make([]byte, 10), nil)
+	if _, err := c.Open(nil, nonce, sealed, nil); err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	if _, err := NewXChaCha20Poly1305(&key).Open(nil, xnonce, xsealed, nil); err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	sealed[0] ^= 1
+	if _, err := c.Open(nil, nonce, sealed, nil); err == nil {
+		t.Fatal("Open accepted a modified ciphertext")
+	}
+	if _, err := c.Open(nil, nonce[:1], sealed, nil); err == nil {
+		t.Fatal("Open accepted an invalid nonce")
+	}
+	NewSendSession(&key, nil).Rekey(&key)
+
+	m := ReadMetrics()
+	want := Metrics{
+		MessagesSealed: 2,
+		MessagesOpened: 2,
+		BytesSealed:    110,
+		BytesOpened:    110,
+		AuthFailures:   1,
+		Rekeys:         1,
+		Backend:        chacha.ActiveBackend().String(),
+	}
+	if m != want {
+		t.Fatalf("ReadMetrics: got %+v want %+v", m, want)
+	}
+
+	EnableMetrics(false)
+	c.Seal(nil, nonce, nil, nil)
+	if ReadMetrics().MessagesSealed != want.MessagesSealed {
+		t.Fatal("counters are collected after EnableMetrics(false)")
+	}
+	ResetMetrics()
+	if m = ReadMetrics(); m.MessagesSealed != 0 || m.AuthFailures != 0 || m.Rekeys != 0 {
+		t.Fatalf("ResetMetrics didn't reset the counters: %+v", m)
+	}
+}
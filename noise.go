@@ -0,0 +1,140 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package chacha20
+
+import (
+	"crypto/cipher"
+	"errors"
+	"io"
+)
+
+// noiseMaxNonce is the nonce reserved for rekeying. It must not be used
+// to encrypt or decrypt messages.
+const noiseMaxNonce = 1<<64 - 1
+
+var errNoiseNonceExhausted = errors.New("Noise cipher state nonce is exhausted")
+
+// NoiseCipherState implements the CipherState of the Noise Protocol
+// Framework with the ChaChaPoly cipher functions. The nonce of a message is
+// 4 zero bytes followed by the little endian 64 bit counter n. A
+// NoiseCipherState without a key passes messages through unchanged - as
+// specified by Noise. It is not safe for concurrent use.
+type NoiseCipherState struct {
+	key    [32]byte
+	hasKey bool
+	c      cipher.AEAD
+	n      uint64
+}
+
+// NewNoiseCipherState returns a NoiseCipherState initialized with the key
+// like InitializeKey.
+func NewNoiseCipherState(key *[32]byte) *NoiseCipherState {
+	s := new(NoiseCipherState)
+	s.InitializeKey(key)
+	return s
+}
+
+// InitializeKey sets the key and resets the nonce to 0. A nil key removes
+// the key of the cipher state.
+func (s *NoiseCipherState) InitializeKey(key *[32]byte) {
+	s.n = 0
+	if key == nil {
+		wipe(s.key[:])
+		s.hasKey = false
+		s.setCipher(nil)
+		return
+	}
+	s.key, s.hasKey = *key, true
+	s.setCipher(NewChaCha20Poly1305(&s.key))
+}
+
+// setCipher replaces the AEAD of the cipher state and closes the previous
+// one, which wipes its copy of the key.
+func (s *NoiseCipherState) setCipher(c cipher.AEAD) {
+	if s.c != nil {
+		s.c.(io.Closer).Close()
+	}
+	s.c = c
+}
+
+// HasKey returns true if the cipher state has a key.
+func (s *NoiseCipherState) HasKey() bool { return s.hasKey }
+
+// SetNonce sets the nonce of the next message.
+func (s *NoiseCipherState) SetNonce(n uint64) { s.n = n }
+
+// Nonce returns the nonce of the next message.
+func (s *NoiseCipherState) Nonce() uint64 { return s.n }
+
+// EncryptWithAd encrypts and authenticates the plaintext and the additional
+// data, appends the result to dst and increments the nonce. Without a key
+// the plaintext is appended unchanged. EncryptWithAd fails once the nonce
+// reached 2^64-1.
+func (s *NoiseCipherState) EncryptWithAd(dst, additionalData, plaintext []byte) ([]byte, error) {
+	if !s.hasKey {
+		return append(dst, plaintext...), nil
+	}
+	if s.n == noiseMaxNonce {
+		return nil, errNoiseNonceExhausted
+	}
+	nonce := noiseNonce(s.n)
+	s.n++
+	return s.c.Seal(dst, nonce[:], plaintext, additionalData), nil
+}
+
+// DecryptWithAd authenticates and decrypts the ciphertext and the additional
+// data, appends the plaintext to dst and increments the nonce. The nonce is
+// not incremented if the ciphertext is not authentic. Without a key the
+// ciphertext is appended unchanged.
+func (s *NoiseCipherState) DecryptWithAd(dst, additionalData, ciphertext []byte) ([]byte, error) {
+	if !s.hasKey {
+		return append(dst, ciphertext...), nil
+	}
+	if s.n == noiseMaxNonce {
+		return nil, errNoiseNonceExhausted
+	}
+	nonce := noiseNonce(s.n)
+	plaintext, err := s.c.Open(dst, nonce[:], ciphertext, additionalData)
+	if err != nil {
+		return nil, err
+	}
+	s.n++
+	return plaintext, nil
+}
+
+// Rekey replaces the key by the REKEY function of the ChaChaPoly cipher
+// functions - see NoiseRekey. The nonce is not changed. Rekey does
+// nothing if the cipher state has no key.
+func (s *NoiseCipherState) Rekey() {
+	if !s.hasKey {
+		return
+	}
+	NoiseRekey(&s.key, &s.key)
+	s.setCipher(NewChaCha20Poly1305(&s.key))
+	countRekey()
+}
+
+// NoiseRekey computes the REKEY function of the Noise ChaChaPoly cipher
+// functions: the new key is the first 32 bytes of the encryption of 32 zero
+// bytes with the nonce 2^64-1 and empty additional data. The new key is
+// written to dst, which may be the same as key.
+func NoiseRekey(dst, key *[32]byte) {
+	var zeros [32]byte
+	nonce := noiseNonce(noiseMaxNonce)
+	c := NewChaCha20Poly1305(key)
+	sealed := c.Seal(nil, nonce[:], zeros[:], nil)
+	c.(io.Closer).Close()
+	copy(dst[:], sealed[:32])
+	wipe(sealed)
+}
+
+func noiseNonce(n uint64) [NonceSize]byte {
+	var nonce [NonceSize]byte
+	for i := 4; i < NonceSize; i++ {
+		nonce[i] = byte(n)
+		n >>= 8
+	}
+	return nonce
+}
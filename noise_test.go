@@ -0,0 +1,99 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package chacha20
+
+import (
+	"bytes"
+	"testing"
+
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+func TestNoiseRekey(t *testing.T) {
+	var key, rekeyed [32]byte
+	for i := range key {
+		key[i] = byte(i)
+	}
+	c, err := chacha20poly1305.New(key[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	nonce := []byte{0, 0, 0, 0, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}
+	want := c.Seal(nil, nonce, make([]byte, 32), nil)[:32]
+
+	NoiseRekey(&rekeyed, &key)
+	if !bytes.Equal(rekeyed[:], want) {
+		t.Fatalf("NoiseRekey: got %x want %x", rekeyed, want)
+	}
+	NoiseRekey(&key, &key)
+	if key != rekeyed {
+		t.Fatal("NoiseRekey fails if dst and key are the same")
+	}
+}
+
+func TestNoiseCipherState(t *testing.T) {
+	var key [32]byte
+	for i := range key {
+		key[i] = byte(255 - i)
+	}
+	alice, bob := NewNoiseCipherState(&key), NewNoiseCipherState(&key)
+	ref, _ := chacha20poly1305.New(key[:])
+
+	for i := 0; i < 3; i++ {
+		msg := []byte("Hello, Bob")
+		ct, err := alice.EncryptWithAd(nil, []byte("ad"), msg)
+		if err != nil {
+			t.Fatalf("Message %d: EncryptWithAd failed: %v", i, err)
+		}
+		nonce := []byte{0, 0, 0, 0, byte(i), 0, 0, 0, 0, 0, 0, 0}
+		if want := ref.Seal(nil, nonce, msg, []byte("ad")); !bytes.Equal(ct, want) {
+			t.Fatalf("Message %d: ciphertext differs from the reference", i)
+		}
+		if _, err := bob.DecryptWithAd(nil, nil, ct); err == nil {
+			t.Fatalf("Message %d: DecryptWithAd accepted wrong additional data", i)
+		}
+		pt, err := bob.DecryptWithAd(nil, []byte("ad"), ct)
+		if err != nil || !bytes.Equal(pt, msg) {
+			t.Fatalf("Message %d: DecryptWithAd failed: %v", i, err)
+		}
+	}
+
+	alice.Rekey()
+	bob.Rekey()
+	if alice.Nonce() != 3 || bob.Nonce() != 3 {
+		t.Fatalf("Rekey changed the nonce: %d %d", alice.Nonce(), bob.Nonce())
+	}
+	ct, _ := alice.EncryptWithAd(nil, nil, []byte("rekeyed"))
+	if pt, err := bob.DecryptWithAd(nil, nil, ct); err != nil || string(pt) != "rekeyed" {
+		t.Fatalf("DecryptWithAd after Rekey failed: %v", err)
+	}
+
+	alice.SetNonce(noiseMaxNonce)
+	if _, err := alice.EncryptWithAd(nil, nil, nil); err != errNoiseNonceExhausted {
+		t.Fatalf("expected %v, got %v", errNoiseNonceExhausted, err)
+	}
+
+	empty := NewNoiseCipherState(nil)
+	empty.Rekey()
+	if ct, _ := empty.EncryptWithAd(nil, nil, []byte("plain")); empty.HasKey() || string(ct) != "plain" {
+		t.Fatal("NoiseCipherState without a key doesn't pass messages through")
+	}
+}
+
+func TestNoiseCipherStateClose(t *testing.T) {
+	var key [32]byte
+	s := NewNoiseCipherState(&key)
+	for i, replace := range []func(){
+		s.Rekey,
+		func() { s.InitializeKey(&key) },
+		func() { s.InitializeKey(nil) },
+	} {
+		c := s.c.(*aead)
+		replace()
+		if !c.key.wiped {
+			t.Fatalf("Replacement %d: the previous AEAD is not closed", i)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package chacha20
+
+import (
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"io"
+)
+
+// ErrNonceOverflow is returned by IncrementNonce and IncrementNonceBigEndian
+// if the nonce would wrap around to zero.
+var ErrNonceOverflow = errors.New("nonce overflow")
+
+// SealWithRandomNonce generates a random nonce, encrypts and authenticates the
+// plaintext and the additional data using c and appends the nonce followed by
+// the ciphertext to dst. The nonce is read from random. If random is nil
+// crypto/rand.Reader is used. A deterministic random source can be used to
+// produce reproducible ciphertexts in tests.
+//
+// Random nonces should only be used with AEADs with a large nonce, like
+// XChaCha20Poly1305, because the 96 bit nonce of ChaCha20Poly1305 may collide
+// after a large number of messages.
+func SealWithRandomNonce(random io.Reader, c cipher.AEAD, dst, plaintext, additionalData []byte) ([]byte, error) {
+	if random == nil {
+		random = rand.Reader
+	}
+	nonceSize := c.NonceSize()
+	ret, nonce := sliceForAppend(dst, nonceSize)
+	if _, err := io.ReadFull(random, nonce); err != nil {
+		return nil, err
+	}
+	return c.Seal(ret, nonce, plaintext, additionalData), nil
+}
+
+// OpenWithRandomNonce decrypts and authenticates a ciphertext produced by
+// SealWithRandomNonce using c and the additional data and appends the plaintext
+// to dst.
+func OpenWithRandomNonce(c cipher.AEAD, dst, ciphertext, additionalData []byte) ([]byte, error) {
+	nonceSize := c.NonceSize()
+	if len(ciphertext) < nonceSize {
+		return nil, errAuthFailed
+	}
+	return c.Open(dst, ciphertext[:nonceSize], ciphertext[nonceSize:], additionalData)
+}
+
+// IncrementNonce increments the nonce, interpreted as a little endian
+// integer, by one. If all bytes of the nonce are 0xFF it returns
+// ErrNonceOverflow and leaves the nonce unchanged instead of wrapping
+// around to a nonce which was used before.
+func IncrementNonce(nonce []byte) error {
+	for i := range nonce {
+		if nonce[i] != 0xFF {
+			nonce[i]++
+			for j := 0; j < i; j++ {
+				nonce[j] = 0
+			}
+			return nil
+		}
+	}
+	return ErrNonceOverflow
+}
+
+// IncrementNonceBigEndian increments the nonce, interpreted as a big endian
+// integer, by one like IncrementNonce.
+func IncrementNonceBigEndian(nonce []byte) error {
+	for i := len(nonce) - 1; i >= 0; i-- {
+		if nonce[i] != 0xFF {
+			nonce[i]++
+			for j := i + 1; j < len(nonce); j++ {
+				nonce[j] = 0
+			}
+			return nil
+		}
+	}
+	return ErrNonceOverflow
+}
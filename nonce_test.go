@@ -0,0 +1,84 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package chacha20
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestSealWithRandomNonce(t *testing.T) {
+	var key [32]byte
+	c := NewXChaCha20Poly1305(&key)
+	plaintext, data := []byte("Hello, World"), []byte("additional data")
+
+	random := bytes.NewReader(bytes.Repeat([]byte{0xAB}, 2*NonceSizeX))
+	sealed0, err := SealWithRandomNonce(random, c, nil, plaintext, data)
+	if err != nil {
+		t.Fatalf("SealWithRandomNonce failed: %s", err)
+	}
+	sealed1, err := SealWithRandomNonce(random, c, nil, plaintext, data)
+	if err != nil {
+		t.Fatalf("SealWithRandomNonce failed: %s", err)
+	}
+	if !bytes.Equal(sealed0, sealed1) {
+		t.Fatal("SealWithRandomNonce is not deterministic for a deterministic random source")
+	}
+	if !bytes.Equal(sealed0[:NonceSizeX], bytes.Repeat([]byte{0xAB}, NonceSizeX)) {
+		t.Fatal("SealWithRandomNonce did not use the nonce from the random source")
+	}
+	if _, err = SealWithRandomNonce(random, c, nil, plaintext, data); err == nil {
+		t.Fatal("SealWithRandomNonce ignored the error of the random source")
+	}
+
+	decrypted, err := OpenWithRandomNonce(c, nil, sealed0, data)
+	if err != nil {
+		t.Fatalf("OpenWithRandomNonce failed: %s", err)
+	}
+	if !bytes.Equal(decrypted, plaintext) {
+		t.Fatalf("Decrypted message differs from plaintext: %x", decrypted)
+	}
+	if _, err = OpenWithRandomNonce(c, nil, sealed0[:NonceSizeX-1], data); err == nil {
+		t.Fatal("OpenWithRandomNonce accepted truncated ciphertext")
+	}
+
+	if sealed, err := SealWithRandomNonce(nil, c, nil, plaintext, data); err != nil || len(sealed) != NonceSizeX+len(plaintext)+Overhead {
+		t.Fatalf("SealWithRandomNonce failed with default random source: %v", err)
+	}
+}
+
+func TestIncrementNonce(t *testing.T) {
+	testCases := []struct {
+		nonce, little, big []byte
+	}{
+		{nonce: []byte{0, 0, 0}, little: []byte{1, 0, 0}, big: []byte{0, 0, 1}},
+		{nonce: []byte{0xFF, 0, 0}, little: []byte{0, 1, 0}, big: []byte{0xFF, 0, 1}},
+		{nonce: []byte{0, 0, 0xFF}, little: []byte{1, 0, 0xFF}, big: []byte{0, 1, 0}},
+		{nonce: []byte{0xFF, 0xFF, 0}, little: []byte{0, 0, 1}, big: []byte{0xFF, 0xFF, 1}},
+		{nonce: []byte{0, 0xFF, 0xFF}, little: []byte{1, 0xFF, 0xFF}, big: []byte{1, 0, 0}},
+	}
+	for i, test := range testCases {
+		nonce := append([]byte(nil), test.nonce...)
+		if err := IncrementNonce(nonce); err != nil || !bytes.Equal(nonce, test.little) {
+			t.Fatalf("Test %d: IncrementNonce(%x) = %x, %v - want %x", i, test.nonce, nonce, err, test.little)
+		}
+		nonce = append(nonce[:0], test.nonce...)
+		if err := IncrementNonceBigEndian(nonce); err != nil || !bytes.Equal(nonce, test.big) {
+			t.Fatalf("Test %d: IncrementNonceBigEndian(%x) = %x, %v - want %x", i, test.nonce, nonce, err, test.big)
+		}
+	}
+
+	max := bytes.Repeat([]byte{0xFF}, NonceSize)
+	nonce := append([]byte(nil), max...)
+	if err := IncrementNonce(nonce); err != ErrNonceOverflow || !bytes.Equal(nonce, max) {
+		t.Fatalf("IncrementNonce wraps around: %x, %v", nonce, err)
+	}
+	if err := IncrementNonceBigEndian(nonce); err != ErrNonceOverflow || !bytes.Equal(nonce, max) {
+		t.Fatalf("IncrementNonceBigEndian wraps around: %x, %v", nonce, err)
+	}
+	if err := IncrementNonce(nil); err != ErrNonceOverflow {
+		t.Fatalf("IncrementNonce accepts an empty nonce: %v", err)
+	}
+}
@@ -0,0 +1,185 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package chacha20
+
+import (
+	"crypto/cipher"
+	"errors"
+)
+
+const (
+	// OpenVPNImplicitIVSize is the size of the implicit part of the
+	// nonce derived from the OpenVPN key material.
+	OpenVPNImplicitIVSize = NonceSize - 4
+
+	openVPNDataV1        = 6 // P_DATA_V1 opcode
+	openVPNDataV2        = 9 // P_DATA_V2 opcode
+	openVPNReplayWindow  = 64
+	openVPNMaxPacketID   = 1<<32 - 1
+	openVPNPacketIDSize  = 4
+	openVPNHeaderSizeV1  = 1
+	openVPNHeaderSizeV2  = 4
+	openVPNMaxKeyID      = 7
+	openVPNMaxPeerID     = 1<<24 - 1
+	openVPNPacketIDStart = 1
+)
+
+var (
+	errOpenVPNPacketTooShort = errors.New("OpenVPN packet is too short")
+	errOpenVPNOpcode         = errors.New("OpenVPN packet is not a data channel packet")
+	errOpenVPNKeyID          = errors.New("OpenVPN packet has a different key ID")
+	errOpenVPNReplay         = errors.New("OpenVPN packet ID was replayed or is too old")
+	errOpenVPNIDExhausted    = errors.New("OpenVPN packet IDs are exhausted - renegotiate the keys")
+	errOpenVPNInvalidID      = errors.New("OpenVPN key ID must be at most 7 and peer ID at most 2^24-1")
+)
+
+// OpenVPNDataChannel implements the AEAD data channel framing of OpenVPN 2.4
+// and later with the CHACHA20-POLY1305 cipher. A data channel packet is:
+//
+//	opcode/key ID (1 byte) | [peer ID (3 bytes)] | packet ID (4 bytes) |
+//	tag (16 bytes) | ciphertext
+//
+// The peer ID is only present in P_DATA_V2 packets. The nonce is the packet
+// ID followed by the implicit IV and the additional data is the packet ID -
+// preceded by the opcode, key ID and peer ID for P_DATA_V2 packets. The
+// packet ID of the first packet is 1. An OpenVPNDataChannel handles one
+// direction of one key - it is not safe for concurrent use.
+type OpenVPNDataChannel struct {
+	c          cipher.AEAD
+	implicitIV [OpenVPNImplicitIVSize]byte
+	header     [openVPNHeaderSizeV2]byte
+	headerSize int
+
+	packetID uint32 // ID of the last sealed packet
+
+	// The replay window of opened packets: maxID is the largest opened
+	// packet ID and bit i of window is set if maxID-i has been opened.
+	maxID  uint32
+	window uint64
+}
+
+// NewOpenVPNDataChannel returns an OpenVPNDataChannel using the key and the
+// implicit IV of one direction of the data channel. The key ID is the key ID
+// of the TLS session. If peerID is negative P_DATA_V1 packets are used and
+// otherwise P_DATA_V2 packets with the peer ID.
+func NewOpenVPNDataChannel(key *[32]byte, implicitIV *[OpenVPNImplicitIVSize]byte, keyID byte, peerID int32) (*OpenVPNDataChannel, error) {
+	if keyID > openVPNMaxKeyID || peerID > openVPNMaxPeerID {
+		return nil, errOpenVPNInvalidID
+	}
+	d := &OpenVPNDataChannel{c: NewChaCha20Poly1305(key), implicitIV: *implicitIV}
+	if peerID < 0 {
+		d.header[0] = openVPNDataV1<<3 | keyID
+		d.headerSize = openVPNHeaderSizeV1
+	} else {
+		d.header = [4]byte{openVPNDataV2<<3 | keyID, byte(peerID >> 16), byte(peerID >> 8), byte(peerID)}
+		d.headerSize = openVPNHeaderSizeV2
+	}
+	return d, nil
+}
+
+// Seal encrypts and authenticates the payload as the next data channel packet
+// and appends the packet to dst. Seal fails once all packet IDs have been used.
+func (d *OpenVPNDataChannel) Seal(dst, payload []byte) ([]byte, error) {
+	if d.packetID == openVPNMaxPacketID {
+		return nil, errOpenVPNIDExhausted
+	}
+	d.packetID++
+
+	overhead := d.headerSize + openVPNPacketIDSize + TagSize
+	ret, out := sliceForAppend(dst, overhead+len(payload))
+	copy(out, d.header[:d.headerSize])
+	packetID := out[d.headerSize : d.headerSize+openVPNPacketIDSize]
+	packetID[0], packetID[1], packetID[2], packetID[3] = byte(d.packetID>>24), byte(d.packetID>>16), byte(d.packetID>>8), byte(d.packetID)
+
+	var nonce [NonceSize]byte
+	copy(nonce[:], packetID)
+	copy(nonce[openVPNPacketIDSize:], d.implicitIV[:])
+
+	// OpenVPN places the tag in front of the ciphertext.
+	sealed := d.c.Seal(out[overhead:overhead], nonce[:], payload, d.additionalData(out))
+	n := len(sealed) - TagSize
+	var tag [TagSize]byte
+	copy(tag[:], sealed[n:])
+	copy(out[overhead:], sealed[:n])
+	copy(out[overhead-TagSize:], tag[:])
+	return ret, nil
+}
+
+// Open authenticates and decrypts the data channel packet and appends the
+// payload to dst. It rejects packets with a different key ID and packets
+// whose packet ID has been opened before or is outside the replay window.
+func (d *OpenVPNDataChannel) Open(dst, packet []byte) ([]byte, error) {
+	if len(packet) < 1 {
+		return nil, errOpenVPNPacketTooShort
+	}
+	headerSize := openVPNHeaderSizeV1
+	switch packet[0] >> 3 {
+	case openVPNDataV1:
+	case openVPNDataV2:
+		headerSize = openVPNHeaderSizeV2
+	default:
+		return nil, errOpenVPNOpcode
+	}
+	if packet[0]&openVPNMaxKeyID != d.header[0]&openVPNMaxKeyID {
+		return nil, errOpenVPNKeyID
+	}
+	overhead := headerSize + openVPNPacketIDSize + TagSize
+	if len(packet) < overhead {
+		return nil, errOpenVPNPacketTooShort
+	}
+	packetID := packet[headerSize : headerSize+openVPNPacketIDSize]
+	id := uint32(packetID[0])<<24 | uint32(packetID[1])<<16 | uint32(packetID[2])<<8 | uint32(packetID[3])
+	if !d.replayCheck(id) {
+		return nil, errOpenVPNReplay
+	}
+
+	var nonce [NonceSize]byte
+	copy(nonce[:], packetID)
+	copy(nonce[openVPNPacketIDSize:], d.implicitIV[:])
+
+	ciphertext := make([]byte, len(packet)-overhead+TagSize)
+	copy(ciphertext, packet[overhead:])
+	copy(ciphertext[len(ciphertext)-TagSize:], packet[overhead-TagSize:overhead])
+	ret, err := d.c.Open(dst, nonce[:], ciphertext, d.additionalData(packet[:headerSize+openVPNPacketIDSize]))
+	if err != nil {
+		return nil, err
+	}
+	d.replayUpdate(id)
+	return ret, nil
+}
+
+// additionalData returns the additional data of the packet, which
+// starts with the header and the packet ID.
+func (d *OpenVPNDataChannel) additionalData(packet []byte) []byte {
+	if packet[0]>>3 == openVPNDataV2 {
+		return packet[:openVPNHeaderSizeV2+openVPNPacketIDSize]
+	}
+	return packet[openVPNHeaderSizeV1 : openVPNHeaderSizeV1+openVPNPacketIDSize]
+}
+
+// replayCheck reports whether the packet ID is valid and has not been opened.
+func (d *OpenVPNDataChannel) replayCheck(id uint32) bool {
+	if id < openVPNPacketIDStart {
+		return false
+	}
+	if id > d.maxID {
+		return true
+	}
+	diff := d.maxID - id
+	return diff < openVPNReplayWindow && d.window&(1<<diff) == 0
+}
+
+// replayUpdate marks the packet ID as opened.
+func (d *OpenVPNDataChannel) replayUpdate(id uint32) {
+	if id > d.maxID {
+		if shift := id - d.maxID; shift < openVPNReplayWindow {
+			d.window <<= shift
+		} else {
+			d.window = 0
+		}
+		d.maxID = id
+	}
+	d.window |= 1 << (d.maxID - id)
+}
@@ -0,0 +1,139 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package chacha20
+
+import (
+	"bytes"
+	"testing"
+)
+
+func newOpenVPNTestChannels(t *testing.T, peerID int32) (sender, receiver *OpenVPNDataChannel) {
+	var key [32]byte
+	var iv [OpenVPNImplicitIVSize]byte
+	for i := range key {
+		key[i] = byte(i)
+	}
+	for i := range iv {
+		iv[i] = byte(0xf0 + i)
+	}
+	sender, err := NewOpenVPNDataChannel(&key, &iv, 2, peerID)
+	if err != nil {
+		t.Fatalf("Failed to create OpenVPNDataChannel: %s", err)
+	}
+	receiver, _ = NewOpenVPNDataChannel(&key, &iv, 2, peerID)
+	return sender, receiver
+}
+
+func TestOpenVPNDataChannel(t *testing.T) {
+	var key [32]byte
+	for i := range key {
+		key[i] = byte(i)
+	}
+	for _, peerID := range []int32{-1, 0x123456} {
+		sender, receiver := newOpenVPNTestChannels(t, peerID)
+		payload := []byte("IP packet")
+
+		packet, err := sender.Seal(nil, payload)
+		if err != nil {
+			t.Fatalf("Peer ID %d: Seal failed: %s", peerID, err)
+		}
+
+		// Build the expected packet from the specification.
+		header := []byte{6<<3 | 2}
+		if peerID >= 0 {
+			header = []byte{9<<3 | 2, 0x12, 0x34, 0x56}
+		}
+		packetID := []byte{0, 0, 0, 1}
+		nonce := append(append([]byte(nil), packetID...), 0xf0, 0xf1, 0xf2, 0xf3, 0xf4, 0xf5, 0xf6, 0xf7)
+		data := packetID
+		if peerID >= 0 {
+			data = append(append([]byte(nil), header...), packetID...)
+		}
+		sealed := NewChaCha20Poly1305(&key).Seal(nil, nonce, payload, data)
+		n := len(payload)
+		expected := append(append(append(header, packetID...), sealed[n:]...), sealed[:n]...)
+		if !bytes.Equal(packet, expected) {
+			t.Fatalf("Peer ID %d: packet differs from the specification:\n%x\n%x", peerID, packet, expected)
+		}
+
+		opened, err := receiver.Open(nil, packet)
+		if err != nil || !bytes.Equal(opened, payload) {
+			t.Fatalf("Peer ID %d: Open failed: %v", peerID, err)
+		}
+		if _, err = receiver.Open(nil, packet); err != errOpenVPNReplay {
+			t.Fatalf("Peer ID %d: Open accepted a replayed packet: %v", peerID, err)
+		}
+		packet, _ = sender.Seal(nil, payload)
+		for i := range packet {
+			packet[i] ^= 1
+			if _, err = receiver.Open(nil, packet); err == nil {
+				t.Fatalf("Peer ID %d: modified byte %d was not detected", peerID, i)
+			}
+			packet[i] ^= 1
+		}
+		if _, err = receiver.Open(nil, packet); err != nil {
+			t.Fatalf("Peer ID %d: a failed Open marked the packet ID as used: %v", peerID, err)
+		}
+	}
+}
+
+func TestOpenVPNReplayWindow(t *testing.T) {
+	sender, receiver := newOpenVPNTestChannels(t, 7)
+	packets := make([][]byte, 2*openVPNReplayWindow)
+	for i := range packets {
+		packets[i], _ = sender.Seal(nil, []byte{byte(i)})
+	}
+
+	// Packets within the window may arrive out of order.
+	for _, i := range []int{1, 0, 5, 3, 2, 4, openVPNReplayWindow + 4} {
+		if _, err := receiver.Open(nil, packets[i]); err != nil {
+			t.Fatalf("Packet %d was rejected: %s", i, err)
+		}
+	}
+	for _, i := range []int{0, 3, openVPNReplayWindow + 4, 4} {
+		if _, err := receiver.Open(nil, packets[i]); err != errOpenVPNReplay {
+			t.Fatalf("Packet %d was accepted twice: %v", i, err)
+		}
+	}
+	if _, err := receiver.Open(nil, packets[6]); err != nil {
+		t.Fatalf("Packet within the window was rejected: %v", err)
+	}
+	if _, err := receiver.Open(nil, packets[len(packets)-1]); err != nil {
+		t.Fatalf("Last packet was rejected: %v", err)
+	}
+	if _, err := receiver.Open(nil, packets[7]); err != errOpenVPNReplay {
+		t.Fatalf("Packet outside the window was accepted: %v", err)
+	}
+}
+
+func TestOpenVPNDataChannelInvalid(t *testing.T) {
+	var key [32]byte
+	var iv [OpenVPNImplicitIVSize]byte
+	if _, err := NewOpenVPNDataChannel(&key, &iv, 8, 0); err != errOpenVPNInvalidID {
+		t.Fatalf("NewOpenVPNDataChannel accepted key ID 8: %v", err)
+	}
+	if _, err := NewOpenVPNDataChannel(&key, &iv, 0, 1<<24); err != errOpenVPNInvalidID {
+		t.Fatalf("NewOpenVPNDataChannel accepted peer ID 2^24: %v", err)
+	}
+
+	sender, receiver := newOpenVPNTestChannels(t, 1)
+	packet, _ := sender.Seal(nil, nil)
+	if _, err := receiver.Open(nil, packet[:len(packet)-1]); err != errOpenVPNPacketTooShort {
+		t.Fatalf("Open returns unexpected error for a short packet: %v", err)
+	}
+	other, _ := NewOpenVPNDataChannel(&key, &iv, 3, 1)
+	if _, err := other.Open(nil, packet); err != errOpenVPNKeyID {
+		t.Fatalf("Open returns unexpected error for another key ID: %v", err)
+	}
+	packet[0] = 4<<3 | 2 // P_ACK_V1
+	if _, err := receiver.Open(nil, packet); err != errOpenVPNOpcode {
+		t.Fatalf("Open returns unexpected error for a control packet: %v", err)
+	}
+
+	sender.packetID = openVPNMaxPacketID
+	if _, err := sender.Seal(nil, nil); err != errOpenVPNIDExhausted {
+		t.Fatalf("Seal returns unexpected error after the last packet ID: %v", err)
+	}
+}
@@ -0,0 +1,179 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package chacha20
+
+import (
+	"crypto/cipher"
+	"errors"
+	"net"
+	"sync"
+)
+
+// SecurePacketOverhead is the number of bytes a SecurePacketConn adds to
+// every packet - the counter and the tag.
+const SecurePacketOverhead = secureCounterSize + TagSize
+
+const (
+	secureCounterSize    = 8
+	secureMaxPacketCount = 1<<64 - 1
+)
+
+var errPacketCounterExhausted = errors.New("SecurePacketConn packet counter is exhausted - rekey required")
+
+// SecurePacketConn wraps a net.PacketConn and seals every packet sent over
+// it. A packet is:
+//
+//	counter (8 bytes, big endian) | ciphertext | tag
+//
+// The counter is incremented for every packet and the nonce is 4 zero bytes
+// followed by the counter. The counter is authenticated as additional data.
+// Since packets may be lost or reordered the counter is sent explicitly.
+// With a replay window the receiver rejects packets whose counter has been
+// seen before or is older than the window.
+//
+// A key must only be used by one sender - so each direction must use its
+// own key, for example derived by SecureConnKeys. ReadFrom drops packets
+// which are not authentic, replayed or truncated instead of returning an
+// error, so spoofed datagrams cannot break the connection.
+type SecurePacketConn struct {
+	net.PacketConn
+
+	smu     sync.Mutex
+	send    cipher.AEAD
+	counter uint64
+
+	rmu    sync.Mutex
+	recv   cipher.AEAD
+	replay *replayWindow
+}
+
+// NewSecurePacketConn returns a SecurePacketConn which sends packets sealed
+// with the send key and reads packets sealed with the receive key. If
+// replayWindow is greater than 0 the receiver keeps track of the last
+// replayWindow counters - rounded up to a multiple of 64 - and drops
+// replayed packets.
+func NewSecurePacketConn(conn net.PacketConn, sendKey, recvKey *[32]byte, replayWindow int) *SecurePacketConn {
+	c := &SecurePacketConn{
+		PacketConn: conn,
+		send:       NewChaCha20Poly1305(sendKey),
+		recv:       NewChaCha20Poly1305(recvKey),
+	}
+	if replayWindow > 0 {
+		c.replay = newReplayWindow(replayWindow)
+	}
+	return c
+}
+
+// WriteTo seals p as one packet and writes it to addr. It returns the
+// number of bytes of p written.
+func (c *SecurePacketConn) WriteTo(p []byte, addr net.Addr) (int, error) {
+	c.smu.Lock()
+	if c.counter == secureMaxPacketCount {
+		c.smu.Unlock()
+		return 0, errPacketCounterExhausted
+	}
+	counter := c.counter
+	c.counter++
+	c.smu.Unlock()
+
+	var nonce [NonceSize]byte
+	packet := make([]byte, secureCounterSize, SecurePacketOverhead+len(p))
+	for i := 0; i < secureCounterSize; i++ {
+		packet[i] = byte(counter >> (8 * uint(secureCounterSize-1-i)))
+	}
+	copy(nonce[NonceSize-secureCounterSize:], packet)
+	packet = c.send.Seal(packet, nonce[:], p, packet[:secureCounterSize])
+	if _, err := c.PacketConn.WriteTo(packet, addr); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// ReadFrom reads the next authentic packet, copies its payload into p and
+// returns the number of bytes copied and the address of the sender. Packets
+// with a payload longer than p are dropped.
+func (c *SecurePacketConn) ReadFrom(p []byte) (int, net.Addr, error) {
+	buf := make([]byte, len(p)+SecurePacketOverhead+1)
+	for {
+		n, addr, err := c.PacketConn.ReadFrom(buf)
+		if err != nil {
+			return 0, addr, err
+		}
+		if n < SecurePacketOverhead || n == len(buf) {
+			continue
+		}
+		packet := buf[:n]
+		var counter uint64
+		for _, b := range packet[:secureCounterSize] {
+			counter = counter<<8 | uint64(b)
+		}
+
+		c.rmu.Lock()
+		if c.replay != nil && !c.replay.check(counter) {
+			c.rmu.Unlock()
+			continue
+		}
+		var nonce [NonceSize]byte
+		copy(nonce[NonceSize-secureCounterSize:], packet)
+		plaintext, err := c.recv.Open(p[:0], nonce[:], packet[secureCounterSize:], packet[:secureCounterSize])
+		if err != nil {
+			c.rmu.Unlock()
+			continue
+		}
+		if c.replay != nil {
+			c.replay.update(counter)
+		}
+		c.rmu.Unlock()
+		return len(plaintext), addr, nil
+	}
+}
+
+// replayWindow is a sliding window of the last seen counters. Bit i%size
+// of bits is set if counter i has been seen - see RFC 6479.
+type replayWindow struct {
+	bits []uint64
+	max  uint64
+	seen bool
+}
+
+func newReplayWindow(size int) *replayWindow {
+	return &replayWindow{bits: make([]uint64, (size+63)/64)}
+}
+
+func (w *replayWindow) size() uint64 { return uint64(len(w.bits)) * 64 }
+
+// check reports whether the counter is within the window and has not
+// been seen.
+func (w *replayWindow) check(counter uint64) bool {
+	if !w.seen || counter > w.max {
+		return true
+	}
+	if w.max-counter >= w.size() {
+		return false
+	}
+	i := counter % w.size()
+	return w.bits[i/64]&(1<<(i%64)) == 0
+}
+
+// update marks the counter as seen and slides the window if the counter
+// is larger than all seen counters.
+func (w *replayWindow) update(counter uint64) {
+	if !w.seen || counter > w.max {
+		start := w.max + 1
+		if !w.seen || counter-w.max >= w.size() {
+			for i := range w.bits {
+				w.bits[i] = 0
+			}
+			start = counter
+		}
+		for c := start; c < counter; c++ {
+			i := c % w.size()
+			w.bits[i/64] &^= 1 << (i % 64)
+		}
+		w.max, w.seen = counter, true
+	}
+	i := counter % w.size()
+	w.bits[i/64] |= 1 << (i % 64)
+}
@@ -0,0 +1,116 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package chacha20
+
+import (
+	"io"
+	"net"
+	"testing"
+)
+
+type testAddr string
+
+func (a testAddr) Network() string { return "test" }
+func (a testAddr) String() string  { return string(a) }
+
+// queueConn is a net.PacketConn which returns the queued packets and
+// records written packets.
+type queueConn struct {
+	net.PacketConn
+	queue   [][]byte
+	written [][]byte
+}
+
+func (c *queueConn) ReadFrom(p []byte) (int, net.Addr, error) {
+	if len(c.queue) == 0 {
+		return 0, nil, io.EOF
+	}
+	n := copy(p, c.queue[0])
+	c.queue = c.queue[1:]
+	return n, testAddr("peer"), nil
+}
+
+func (c *queueConn) WriteTo(p []byte, addr net.Addr) (int, error) {
+	c.written = append(c.written, append([]byte(nil), p...))
+	return len(p), nil
+}
+
+func TestSecurePacketConn(t *testing.T) {
+	clientKeys, serverKeys := testSecureConnKeys()
+	sender := &queueConn{}
+	client := NewSecurePacketConn(sender, clientKeys[0], clientKeys[1], 0)
+	for _, msg := range []string{"first", "second", "", "third"} {
+		if n, err := client.WriteTo([]byte(msg), testAddr("server")); err != nil || n != len(msg) {
+			t.Fatalf("WriteTo failed: %d %v", n, err)
+		}
+	}
+	packets := sender.written
+	if len(packets[0]) != len("first")+SecurePacketOverhead {
+		t.Fatalf("Packet has length %d", len(packets[0]))
+	}
+
+	tampered := append([]byte(nil), packets[1]...)
+	tampered[len(tampered)-1] ^= 1
+	receiver := &queueConn{queue: [][]byte{
+		packets[3], tampered, packets[0], packets[3], packets[1], packets[2][:5],
+		packets[2], packets[0][:SecurePacketOverhead-1],
+	}}
+	server := NewSecurePacketConn(receiver, serverKeys[0], serverKeys[1], 64)
+
+	buf := make([]byte, 16)
+	for _, want := range []string{"third", "first", "second", ""} {
+		n, addr, err := server.ReadFrom(buf)
+		if err != nil {
+			t.Fatalf("ReadFrom failed: %v", err)
+		}
+		if string(buf[:n]) != want || addr != testAddr("peer") {
+			t.Fatalf("ReadFrom: got %q want %q", buf[:n], want)
+		}
+	}
+	if _, _, err := server.ReadFrom(buf); err != io.EOF {
+		t.Fatalf("expected %v, got %v", io.EOF, err)
+	}
+
+	// Without a replay window replayed packets are accepted and packets
+	// longer than the buffer are dropped.
+	receiver.queue = [][]byte{packets[1], packets[0], packets[0]}
+	server = NewSecurePacketConn(receiver, serverKeys[0], serverKeys[1], 0)
+	for i := 0; i < 2; i++ {
+		if n, _, err := server.ReadFrom(buf[:5]); err != nil || string(buf[:n]) != "first" {
+			t.Fatalf("ReadFrom failed: %v", err)
+		}
+	}
+}
+
+func TestReplayWindow(t *testing.T) {
+	w := newReplayWindow(100)
+	if w.size() != 128 {
+		t.Fatalf("window size: got %d want 128", w.size())
+	}
+	accept := func(counter uint64, want bool) {
+		if ok := w.check(counter); ok != want {
+			t.Fatalf("check(%d): got %v want %v", counter, ok, want)
+		}
+		if want {
+			w.update(counter)
+		}
+	}
+	accept(5, true)
+	accept(5, false)
+	accept(0, true)
+	accept(200, true)
+	accept(72, false)
+	accept(73, true)
+	accept(73, false)
+	accept(199, true)
+	accept(300, true)
+	accept(199, false)
+	accept(200, false)
+	accept(299, true)
+	accept(1000, true)
+	accept(873, true)
+	accept(872, false)
+	accept(300, false)
+}
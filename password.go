@@ -0,0 +1,205 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package chacha20
+
+import (
+	"crypto/rand"
+	"errors"
+	"io"
+)
+
+// DefaultSaltSize is the size of the random salt used by a PasswordSealer
+// if no salt size is specified.
+const DefaultSaltSize = 16
+
+// KDF identifiers stored in the envelope header.
+const (
+	KDFArgon2id = 1
+	KDFScrypt   = 2
+)
+
+var (
+	errNoKDF           = errors.New("password sealer has no KDF")
+	errKDFMismatch     = errors.New("envelope was sealed with a different KDF")
+	errKDFCostTooLarge = errors.New("envelope KDF parameters exceed the configured cost")
+	errInvalidKDFKey   = errors.New("KDF did not return a 32 byte key")
+	errInvalidArgon2id = errors.New("Argon2id parameters are invalid: time must be at least 1 and threads between 1 and 255")
+)
+
+// KDFParams are the parameters of a password-based key derivation function.
+// The meaning of P1, P2 and P3 depends on the KDF. For Argon2id they are
+// the time, the memory (in KiB) and the number of threads. For scrypt they
+// are N, r and p.
+type KDFParams struct {
+	ID         byte
+	P1, P2, P3 uint32
+}
+
+// KDFFunc derives a 32 byte key from a password and a salt using the given parameters.
+type KDFFunc func(password, salt []byte, params KDFParams) ([]byte, error)
+
+// Argon2id turns a function with the signature of argon2.IDKey
+// (golang.org/x/crypto/argon2) into a KDFFunc. The KDFFunc returns an
+// error if the time (P1) is 0 or the number of threads (P3) is not
+// between 1 and 255.
+func Argon2id(idKey func(password, salt []byte, time, memory uint32, threads uint8, keyLen uint32) []byte) KDFFunc {
+	return func(password, salt []byte, params KDFParams) ([]byte, error) {
+		if !validArgon2idParams(params) {
+			return nil, errInvalidArgon2id
+		}
+		return idKey(password, salt, params.P1, params.P2, uint8(params.P3), KeySize), nil
+	}
+}
+
+// validArgon2idParams returns true if the parameters can be passed
+// to argon2.IDKey without truncating the number of threads.
+func validArgon2idParams(params KDFParams) bool {
+	return params.P1 > 0 && params.P3 > 0 && params.P3 <= 255
+}
+
+// Scrypt turns a function with the signature of scrypt.Key
+// (golang.org/x/crypto/scrypt) into a KDFFunc.
+func Scrypt(key func(password, salt []byte, N, r, p, keyLen int) ([]byte, error)) KDFFunc {
+	return func(password, salt []byte, params KDFParams) ([]byte, error) {
+		return key(password, salt, int(params.P1), int(params.P2), int(params.P3), KeySize)
+	}
+}
+
+// PasswordSealer seals messages with a key derived from a password.
+// The salt and the KDF parameters are stored in the envelope, so
+// Open only needs the password. The messages are sealed with
+// XChaCha20Poly1305 using a random nonce.
+type PasswordSealer struct {
+	// KDF derives the key from the password.
+	KDF KDFFunc
+
+	// Params are the KDF parameters used by Seal. Open rejects envelopes
+	// with a different KDF ID or with larger cost parameters.
+	Params KDFParams
+
+	// SaltSize is the size of the random salt. If zero DefaultSaltSize is used.
+	SaltSize int
+
+	// Rand is the source of the salt and the nonce. If nil crypto/rand.Reader
+	// is used.
+	Rand io.Reader
+}
+
+// Seal encrypts and authenticates the plaintext and the additional data with
+// a key derived from the password and returns the binary encoded envelope.
+func (p *PasswordSealer) Seal(password, plaintext, additionalData []byte) ([]byte, error) {
+	random := p.Rand
+	if random == nil {
+		random = rand.Reader
+	}
+	saltSize := p.SaltSize
+	if saltSize <= 0 {
+		saltSize = DefaultSaltSize
+	}
+
+	nonce := make([]byte, NonceSizeX)
+	kdf := make([]byte, 13+saltSize)
+	if _, err := io.ReadFull(random, nonce); err != nil {
+		return nil, err
+	}
+	if _, err := io.ReadFull(random, kdf[13:]); err != nil {
+		return nil, err
+	}
+	encodeKDFParams(kdf, &p.Params)
+
+	c, err := p.deriveAEAD(password, kdf[13:], p.Params)
+	if err != nil {
+		return nil, err
+	}
+	e := &Envelope{
+		Version: EnvelopeVersion2,
+		Nonce:   nonce,
+		KDF:     kdf,
+	}
+	header, err := e.header()
+	if err != nil {
+		return nil, err
+	}
+	e.Ciphertext = c.Seal(nil, nonce, plaintext, append(header, additionalData...))
+	return e.MarshalBinary()
+}
+
+// Open decodes the envelope, derives the key from the password and the stored
+// KDF parameters and decrypts and authenticates the envelope. The plaintext is
+// appended to dst.
+func (p *PasswordSealer) Open(dst, password, envelope, additionalData []byte) ([]byte, error) {
+	var e Envelope
+	if err := e.UnmarshalBinary(envelope); err != nil {
+		return nil, err
+	}
+	if e.Version != EnvelopeVersion2 || len(e.KDF) < 13 {
+		return nil, errInvalidEnvelope
+	}
+	params := decodeKDFParams(e.KDF)
+	if params.ID != p.Params.ID {
+		return nil, errKDFMismatch
+	}
+	if params.ID == KDFArgon2id && !validArgon2idParams(params) {
+		return nil, errInvalidArgon2id
+	}
+	if params.P1 > p.Params.P1 || params.P2 > p.Params.P2 || params.P3 > p.Params.P3 {
+		return nil, errKDFCostTooLarge
+	}
+
+	c, err := p.deriveAEAD(password, e.KDF[13:], params)
+	if err != nil {
+		return nil, err
+	}
+	return e.Open(dst, c, additionalData)
+}
+
+func (p *PasswordSealer) deriveAEAD(password, salt []byte, params KDFParams) (*xaead, error) {
+	key, err := p.deriveKey(password, salt, params)
+	if err != nil {
+		return nil, err
+	}
+	c := NewXChaCha20Poly1305(key).(*xaead)
+	wipe(key[:])
+	return c, nil
+}
+
+func (p *PasswordSealer) deriveKey(password, salt []byte, params KDFParams) (*[32]byte, error) {
+	if p.KDF == nil {
+		return nil, errNoKDF
+	}
+	key, err := p.KDF(password, salt, params)
+	if err != nil {
+		return nil, err
+	}
+	defer wipe(key)
+	if len(key) != KeySize {
+		return nil, errInvalidKDFKey
+	}
+	var Key [32]byte
+	copy(Key[:], key)
+	return &Key, nil
+}
+
+// encodeKDFParams writes the KDF ID followed by the
+// big endian parameters to the first 13 bytes of dst.
+func encodeKDFParams(dst []byte, params *KDFParams) {
+	dst[0] = params.ID
+	for i, v := range [3]uint32{params.P1, params.P2, params.P3} {
+		dst[1+4*i] = byte(v >> 24)
+		dst[2+4*i] = byte(v >> 16)
+		dst[3+4*i] = byte(v >> 8)
+		dst[4+4*i] = byte(v)
+	}
+}
+
+func decodeKDFParams(src []byte) (params KDFParams) {
+	var v [3]uint32
+	for i := range v {
+		v[i] = uint32(src[1+4*i])<<24 | uint32(src[2+4*i])<<16 | uint32(src[3+4*i])<<8 | uint32(src[4+4*i])
+	}
+	params.ID = src[0]
+	params.P1, params.P2, params.P3 = v[0], v[1], v[2]
+	return
+}
@@ -0,0 +1,124 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package chacha20
+
+import (
+	"bytes"
+	"testing"
+)
+
+// testIDKey has the signature of argon2.IDKey but is not a real KDF.
+func testIDKey(password, salt []byte, time, memory uint32, threads uint8, keyLen uint32) []byte {
+	key := make([]byte, keyLen)
+	copy(key, password)
+	for i, v := range salt {
+		key[i%len(key)] ^= v
+	}
+	key[0] ^= byte(time)
+	key[1] ^= byte(memory)
+	key[2] ^= threads
+	return key
+}
+
+func TestPasswordSealer(t *testing.T) {
+	sealer := &PasswordSealer{
+		KDF:    Argon2id(testIDKey),
+		Params: KDFParams{ID: KDFArgon2id, P1: 1, P2: 64 * 1024, P3: 4},
+	}
+	password, plaintext, data := []byte("password"), []byte("Hello, World"), []byte("additional data")
+
+	sealed, err := sealer.Seal(password, plaintext, data)
+	if err != nil {
+		t.Fatalf("Seal failed: %s", err)
+	}
+	var e Envelope
+	if err = e.UnmarshalBinary(sealed); err != nil {
+		t.Fatalf("Sealed message is not a valid envelope: %s", err)
+	}
+	if len(e.KDF) != 13+DefaultSaltSize || e.KDF[0] != KDFArgon2id {
+		t.Fatalf("Envelope contains unexpected KDF parameters: %x", e.KDF)
+	}
+
+	decrypted, err := sealer.Open(nil, password, sealed, data)
+	if err != nil {
+		t.Fatalf("Open failed: %s", err)
+	}
+	if !bytes.Equal(decrypted, plaintext) {
+		t.Fatalf("Decrypted message differs from plaintext: %x", decrypted)
+	}
+	if _, err = sealer.Open(nil, []byte("wrong password"), sealed, data); err == nil {
+		t.Fatal("Open accepted wrong password")
+	}
+
+	weaker := &PasswordSealer{KDF: sealer.KDF, Params: sealer.Params}
+	weaker.Params.P2 /= 2
+	if _, err = weaker.Open(nil, password, sealed, data); err == nil {
+		t.Fatal("Open accepted KDF parameters exceeding the configured cost")
+	}
+	other := &PasswordSealer{KDF: sealer.KDF, Params: sealer.Params}
+	other.Params.ID = KDFScrypt
+	if _, err = other.Open(nil, password, sealed, data); err == nil {
+		t.Fatal("Open accepted envelope sealed with a different KDF")
+	}
+}
+
+func TestPasswordSealerArgon2idParams(t *testing.T) {
+	for _, params := range []KDFParams{
+		{ID: KDFArgon2id, P1: 1, P2: 1024, P3: 0},
+		{ID: KDFArgon2id, P1: 1, P2: 1024, P3: 256},
+		{ID: KDFArgon2id, P1: 0, P2: 1024, P3: 1},
+	} {
+		sealer := &PasswordSealer{KDF: Argon2id(testIDKey), Params: params}
+		if _, err := sealer.Seal([]byte("password"), nil, nil); err != errInvalidArgon2id {
+			t.Fatalf("%+v: Seal returned %v - want %v", params, err, errInvalidArgon2id)
+		}
+	}
+
+	// An envelope with 256 threads must be rejected even if the configured
+	// cost allows it, since argon2.IDKey takes the threads as uint8.
+	sealer := &PasswordSealer{
+		KDF:    Argon2id(testIDKey),
+		Params: KDFParams{ID: KDFArgon2id, P1: 1, P2: 1024, P3: 1},
+	}
+	sealed, err := sealer.Seal([]byte("password"), nil, nil)
+	if err != nil {
+		t.Fatalf("Seal failed: %s", err)
+	}
+	var e Envelope
+	if err = e.UnmarshalBinary(sealed); err != nil {
+		t.Fatalf("UnmarshalBinary failed: %s", err)
+	}
+	params := decodeKDFParams(e.KDF)
+	params.P3 = 256
+	encodeKDFParams(e.KDF, &params)
+	if sealed, err = e.MarshalBinary(); err != nil {
+		t.Fatalf("MarshalBinary failed: %s", err)
+	}
+	sealer.Params.P3 = 1 << 16
+	if _, err = sealer.Open(nil, []byte("password"), sealed, nil); err != errInvalidArgon2id {
+		t.Fatalf("Open returned %v for 256 threads - want %v", err, errInvalidArgon2id)
+	}
+}
+
+func TestPasswordSealerDeterministic(t *testing.T) {
+	newSealer := func() *PasswordSealer {
+		return &PasswordSealer{
+			KDF:    Argon2id(testIDKey),
+			Params: KDFParams{ID: KDFArgon2id, P1: 1, P2: 1024, P3: 1},
+			Rand:   bytes.NewReader(make([]byte, NonceSizeX+DefaultSaltSize)),
+		}
+	}
+	sealed0, err := newSealer().Seal([]byte("password"), nil, nil)
+	if err != nil {
+		t.Fatalf("Seal failed: %s", err)
+	}
+	sealed1, err := newSealer().Seal([]byte("password"), nil, nil)
+	if err != nil {
+		t.Fatalf("Seal failed: %s", err)
+	}
+	if !bytes.Equal(sealed0, sealed1) {
+		t.Fatal("Seal is not deterministic for a deterministic random source")
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package chacha20
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+// macStateMaxLength is the max. number of bytes (additional data and
+// ciphertext) authenticated using macState. Longer messages are
+// authenticated using poly1305.Hash, which may use an assembly
+// implementation but has a higher per-message cost - unless macState
+// can use the vector implementation.
+const macStateMaxLength = 128
+
+// macState computes the Poly1305 tag of the ChaCha20Poly1305 construction.
+// Since the additional data and the ciphertext are padded to a multiple of
+// 16 bytes it processes the input blocks directly from the given buffers
+// - without the buffering and copying of poly1305.Hash.
+type macState struct {
+	h0, h1, h2 uint64 // the accumulator
+	r0, r1     uint64 // the clamped first half of the key
+	s0, s1     uint64 // the second half of the key
+}
+
+func (m *macState) init(key *[32]byte) {
+	*m = macState{
+		r0: binary.LittleEndian.Uint64(key[0:]) & 0x0FFFFFFC0FFFFFFF,
+		r1: binary.LittleEndian.Uint64(key[8:]) & 0x0FFFFFFC0FFFFFFC,
+		s0: binary.LittleEndian.Uint64(key[16:]),
+		s1: binary.LittleEndian.Uint64(key[24:]),
+	}
+}
+
+// writePadded processes msg as 16 byte blocks. The last block is padded
+// with zeros if len(msg) is not a multiple of 16.
+func (m *macState) writePadded(msg []byte) {
+	n := len(msg) &^ (TagSize - 1)
+	if n > 0 {
+		m.blocks(msg[:n])
+	}
+	if n < len(msg) {
+		var block [TagSize]byte
+		copy(block[:], msg[n:])
+		m.blocks(block[:])
+	}
+}
+
+// blocks adds the 16 byte blocks of msg (and the 2^128 bit) to the
+// accumulator and multiplies it with r modulo 2^130 - 5 after each block.
+// len(msg) must be a multiple of 16.
+func (m *macState) blocks(msg []byte) {
+	if useVectorMAC && len(msg) >= vectorMACMinLength {
+		n := len(msg) &^ 63
+		m.blocksVector(msg[:n])
+		msg = msg[n:]
+	}
+
+	h0, h1, h2 := m.h0, m.h1, m.h2
+	r0, r1 := m.r0, m.r1
+
+	for len(msg) >= TagSize {
+		var c uint64
+		h0, c = bits.Add64(h0, binary.LittleEndian.Uint64(msg[0:]), 0)
+		h1, c = bits.Add64(h1, binary.LittleEndian.Uint64(msg[8:]), c)
+		h2 += c + 1
+
+		// h2 is at most 7 and r0, r1 are less than 2^60, so
+		// h2*r0 and h2*r1 fit into 64 bits.
+		h0r0hi, h0r0lo := bits.Mul64(h0, r0)
+		h1r0hi, h1r0lo := bits.Mul64(h1, r0)
+		h0r1hi, h0r1lo := bits.Mul64(h0, r1)
+		h1r1hi, h1r1lo := bits.Mul64(h1, r1)
+		h2r0 := h2 * r0
+		h2r1 := h2 * r1
+
+		m1lo, c := bits.Add64(h1r0lo, h0r1lo, 0)
+		m1hi, _ := bits.Add64(h1r0hi, h0r1hi, c)
+		m2lo, c := bits.Add64(h2r0, h1r1lo, 0)
+		m2hi := h1r1hi + c
+
+		t0 := h0r0lo
+		t1, c := bits.Add64(m1lo, h0r0hi, 0)
+		t2, c := bits.Add64(m2lo, m1hi, c)
+		t3 := h2r1 + m2hi + c
+
+		// reduce modulo 2^130 - 5: h = t mod 2^130 + 5 * (t >> 130)
+		cc0, cc1 := t2&^3, t3
+		h0, c = bits.Add64(t0, cc0, 0)
+		h1, c = bits.Add64(t1, cc1, c)
+		h2 = t2&3 + c
+		cc0, cc1 = cc0>>2|cc1<<62, cc1>>2
+		h0, c = bits.Add64(h0, cc0, 0)
+		h1, c = bits.Add64(h1, cc1, c)
+		h2 += c
+
+		msg = msg[TagSize:]
+	}
+	m.h0, m.h1, m.h2 = h0, h1, h2
+}
+
+// sum writes the Poly1305 tag to out.
+func (m *macState) sum(out *[TagSize]byte) {
+	// compute h - p and select it if h >= p
+	t0, b := bits.Sub64(m.h0, 0xFFFFFFFFFFFFFFFB, 0)
+	t1, b := bits.Sub64(m.h1, 0xFFFFFFFFFFFFFFFF, b)
+	_, b = bits.Sub64(m.h2, 3, b)
+	mask := b - 1 // all ones if h >= p
+	h0 := m.h0&^mask | t0&mask
+	h1 := m.h1&^mask | t1&mask
+
+	h0, c := bits.Add64(h0, m.s0, 0)
+	h1, _ = bits.Add64(h1, m.s1, c)
+	binary.LittleEndian.PutUint64(out[0:], h0)
+	binary.LittleEndian.PutUint64(out[8:], h1)
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+// +build go1.7,amd64,!gccgo,!appengine,!purego
+
+package chacha20
+
+import (
+	"math/bits"
+
+	"golang.org/x/sys/cpu"
+)
+
+// useVectorMAC is true if the AVX2 Poly1305 implementation can be used.
+var useVectorMAC = cpu.X86.HasAVX2
+
+// vectorMACMinLength is the min. number of bytes processed by the
+// AVX2 Poly1305 implementation. Shorter messages don't amortize the
+// computation of the key powers.
+const vectorMACMinLength = 512
+
+const mask26 = 1<<26 - 1
+
+// blocksVector processes len(msg) - (len(msg) mod 64) bytes of msg using
+// four 26 bit limb accumulators - one for every 4th block.
+func (m *macState) blocksVector(msg []byte) {
+	// The powers of r for the lanes which hold the blocks 0, 2, 1 and 3
+	// of every 64 byte chunk.
+	var powers [4][5]uint64
+	powers[3] = macLimbs(m.r0, m.r1, 0)
+	macMul(&powers[1], &powers[3], &powers[3])
+	macMul(&powers[2], &powers[1], &powers[3])
+	macMul(&powers[0], &powers[1], &powers[1])
+
+	h := macLimbs(m.h0, m.h1, m.h2)
+	macBlocksAVX2(&h, msg, &powers)
+
+	h[1] += h[0] >> 26
+	h[0] &= mask26
+	h[2] += h[1] >> 26
+	h[1] &= mask26
+	h[3] += h[2] >> 26
+	h[2] &= mask26
+	h[4] += h[3] >> 26
+	h[3] &= mask26
+	h[0] += 5 * (h[4] >> 26)
+	h[4] &= mask26
+	h[1] += h[0] >> 26
+	h[0] &= mask26
+
+	var c uint64
+	m.h0, c = bits.Add64(h[0]+h[1]<<26, h[2]<<52, 0)
+	m.h1, c = bits.Add64(h[2]>>12+h[3]<<14, h[4]<<40, c)
+	m.h2 = h[4]>>24 + c
+}
+
+// macLimbs splits the 130 bit number h2:h1:h0 into five 26 bit limbs.
+// The last limb is not masked.
+func macLimbs(h0, h1, h2 uint64) [5]uint64 {
+	return [5]uint64{
+		h0 & mask26,
+		(h0 >> 26) & mask26,
+		(h0>>52 | h1<<12) & mask26,
+		(h1 >> 14) & mask26,
+		h1>>40 | h2<<24,
+	}
+}
+
+// macMul sets d to a * b modulo 2^130 - 5 with limbs less than 2^26 + 2^10.
+func macMul(d, a, b *[5]uint64) {
+	s1, s2, s3, s4 := 5*b[1], 5*b[2], 5*b[3], 5*b[4]
+	d0 := a[0]*b[0] + a[1]*s4 + a[2]*s3 + a[3]*s2 + a[4]*s1
+	d1 := a[0]*b[1] + a[1]*b[0] + a[2]*s4 + a[3]*s3 + a[4]*s2
+	d2 := a[0]*b[2] + a[1]*b[1] + a[2]*b[0] + a[3]*s4 + a[4]*s3
+	d3 := a[0]*b[3] + a[1]*b[2] + a[2]*b[1] + a[3]*b[0] + a[4]*s4
+	d4 := a[0]*b[4] + a[1]*b[3] + a[2]*b[2] + a[3]*b[1] + a[4]*b[0]
+
+	d1 += d0 >> 26
+	d2 += d1 >> 26
+	d3 += d2 >> 26
+	d4 += d3 >> 26
+	d0 = d0&mask26 + 5*(d4>>26)
+	d[0], d[1], d[2], d[3], d[4] = d0&mask26, d1&mask26+d0>>26, d2&mask26, d3&mask26, d4&mask26
+}
+
+// macBlocksAVX2 adds the 16 byte blocks of msg to the four lane accumulators
+// and multiplies them with r^4 - and with the given powers after the last
+// chunk. The first lane is initialized with h. When it returns, h contains
+// the sum of the lanes. len(msg) must be a non-zero multiple of 64.
+//go:noescape
+func macBlocksAVX2(h *[5]uint64, msg []byte, powers *[4][5]uint64)
@@ -0,0 +1,183 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+// +build go1.7,amd64,!gccgo,!appengine,!purego
+
+#include "textflag.h"
+
+DATA mask26<>+0x00(SB)/8, $0x3FFFFFF
+GLOBL mask26<>(SB), (NOPTR+RODATA), $8
+
+DATA hibit<>+0x00(SB)/8, $0x1000000
+GLOBL hibit<>(SB), (NOPTR+RODATA), $8
+
+// The lane accumulators a0 - a4 are Y0 - Y4, the products d0 - d4 are
+// Y5 - Y9. The powers of r are read from the table at DI:
+// r0 - r4 at 0 - 128(DI) and 5*r1 - 5*r4 at 160 - 256(DI).
+// macBlocksAVX2 keeps the table for r^4 at 0(SP) and the table for the
+// final powers at 288(SP).
+
+// SPLAT_POWER stores limb i of r^4 in all lanes of the first table.
+#define SPLAT_POWER(i) \
+	VPBROADCASTQ (8*i)(BX), Y10; \
+	VMOVDQU Y10, (32*i)(SP)
+
+// SPLAT_POWER_5 stores limb i of r^4 and 5*r^4 in the first table.
+#define SPLAT_POWER_5(i) \
+	SPLAT_POWER(i); \
+	VPSLLQ $2, Y10, Y11; \
+	VPADDQ Y10, Y11, Y11; \
+	VMOVDQU Y11, (128+32*i)(SP)
+
+// GATHER_POWER stores limb i of the final powers in the second table.
+#define GATHER_POWER(i) \
+	VMOVQ (8*i)(BX), X10; \
+	VPINSRQ $1, (40+8*i)(BX), X10, X10; \
+	VMOVQ (80+8*i)(BX), X11; \
+	VPINSRQ $1, (120+8*i)(BX), X11, X11; \
+	VINSERTI128 $1, X11, Y10, Y10; \
+	VMOVDQU Y10, (288+32*i)(SP)
+
+// GATHER_POWER_5 stores limb i of the final powers and 5 times the
+// limb in the second table.
+#define GATHER_POWER_5(i) \
+	GATHER_POWER(i); \
+	VPSLLQ $2, Y10, Y11; \
+	VPADDQ Y10, Y11, Y11; \
+	VMOVDQU Y11, (416+32*i)(SP)
+
+#define MUL_ADD(off, a, d, t) \
+	VPMULUDQ off(DI), a, t; \
+	VPADDQ t, d, d
+
+#define MUL_R \
+	VPMULUDQ 0(DI), Y0, Y5; \
+	MUL_ADD(256, Y1, Y5, Y10); \
+	MUL_ADD(224, Y2, Y5, Y11); \
+	MUL_ADD(192, Y3, Y5, Y12); \
+	MUL_ADD(160, Y4, Y5, Y13); \
+	VPMULUDQ 32(DI), Y0, Y6; \
+	MUL_ADD(0, Y1, Y6, Y10); \
+	MUL_ADD(256, Y2, Y6, Y11); \
+	MUL_ADD(224, Y3, Y6, Y12); \
+	MUL_ADD(192, Y4, Y6, Y13); \
+	VPMULUDQ 64(DI), Y0, Y7; \
+	MUL_ADD(32, Y1, Y7, Y10); \
+	MUL_ADD(0, Y2, Y7, Y11); \
+	MUL_ADD(256, Y3, Y7, Y12); \
+	MUL_ADD(224, Y4, Y7, Y13); \
+	VPMULUDQ 96(DI), Y0, Y8; \
+	MUL_ADD(64, Y1, Y8, Y10); \
+	MUL_ADD(32, Y2, Y8, Y11); \
+	MUL_ADD(0, Y3, Y8, Y12); \
+	MUL_ADD(256, Y4, Y8, Y13); \
+	VPMULUDQ 128(DI), Y0, Y9; \
+	MUL_ADD(96, Y1, Y9, Y10); \
+	MUL_ADD(64, Y2, Y9, Y11); \
+	MUL_ADD(32, Y3, Y9, Y12); \
+	MUL_ADD(0, Y4, Y9, Y13)
+
+// CARRY reduces the products d0 - d4 to 26 bit limbs and stores them in a0 - a4.
+#define CARRY \
+	VPSRLQ $26, Y5, Y10; \
+	VPAND Y15, Y5, Y0; \
+	VPADDQ Y10, Y6, Y6; \
+	VPSRLQ $26, Y6, Y10; \
+	VPAND Y15, Y6, Y1; \
+	VPADDQ Y10, Y7, Y7; \
+	VPSRLQ $26, Y7, Y10; \
+	VPAND Y15, Y7, Y2; \
+	VPADDQ Y10, Y8, Y8; \
+	VPSRLQ $26, Y8, Y10; \
+	VPAND Y15, Y8, Y3; \
+	VPADDQ Y10, Y9, Y9; \
+	VPSRLQ $26, Y9, Y10; \
+	VPAND Y15, Y9, Y4; \
+	VPSLLQ $2, Y10, Y11; \
+	VPADDQ Y11, Y10, Y10; \
+	VPADDQ Y10, Y0, Y0; \
+	VPSRLQ $26, Y0, Y10; \
+	VPAND Y15, Y0, Y0; \
+	VPADDQ Y10, Y1, Y1
+
+// SUM_LANES adds the four lanes of a and stores the sum at dst.
+#define SUM_LANES(a, x, dst) \
+	VEXTRACTI128 $1, a, X10; \
+	VPADDQ X10, x, x; \
+	VPSHUFD $0x4E, x, X10; \
+	VPADDQ X10, x, x; \
+	VMOVQ x, dst
+
+// func macBlocksAVX2(h *[5]uint64, msg []byte, powers *[4][5]uint64)
+TEXT ·macBlocksAVX2(SB),4,$576-40
+	MOVQ h+0(FP), AX
+	MOVQ msg_base+8(FP), SI
+	MOVQ msg_len+16(FP), DX
+	MOVQ powers+32(FP), BX
+	SHRQ $6, DX
+
+	SPLAT_POWER(0)
+	SPLAT_POWER_5(1)
+	SPLAT_POWER_5(2)
+	SPLAT_POWER_5(3)
+	SPLAT_POWER_5(4)
+	GATHER_POWER(0)
+	GATHER_POWER_5(1)
+	GATHER_POWER_5(2)
+	GATHER_POWER_5(3)
+	GATHER_POWER_5(4)
+	MOVQ SP, BX
+	LEAQ 288(SP), R8
+
+	VPBROADCASTQ mask26<>(SB), Y15
+	VPBROADCASTQ hibit<>(SB), Y14
+	VMOVQ 0(AX), X0
+	VMOVQ 8(AX), X1
+	VMOVQ 16(AX), X2
+	VMOVQ 24(AX), X3
+	VMOVQ 32(AX), X4
+
+LOOP:
+	// The 4 blocks are split into the lanes 0, 2, 1 and 3.
+	VMOVDQU 0(SI), Y10
+	VMOVDQU 32(SI), Y11
+	VPUNPCKLQDQ Y11, Y10, Y12
+	VPUNPCKHQDQ Y11, Y10, Y13
+
+	VPAND Y15, Y12, Y10
+	VPADDQ Y10, Y0, Y0
+	VPSRLQ $26, Y12, Y10
+	VPAND Y15, Y10, Y10
+	VPADDQ Y10, Y1, Y1
+	VPSRLQ $52, Y12, Y10
+	VPSLLQ $12, Y13, Y11
+	VPOR Y11, Y10, Y10
+	VPAND Y15, Y10, Y10
+	VPADDQ Y10, Y2, Y2
+	VPSRLQ $14, Y13, Y10
+	VPAND Y15, Y10, Y10
+	VPADDQ Y10, Y3, Y3
+	VPSRLQ $40, Y13, Y10
+	VPOR Y14, Y10, Y10
+	VPADDQ Y10, Y4, Y4
+
+	// multiply the last blocks with the final powers
+	MOVQ BX, DI
+	CMPQ DX, $1
+	CMOVQEQ R8, DI
+
+	MUL_R
+	CARRY
+
+	ADDQ $64, SI
+	SUBQ $1, DX
+	JNZ LOOP
+
+	SUM_LANES(Y0, X0, 0(AX))
+	SUM_LANES(Y1, X1, 8(AX))
+	SUM_LANES(Y2, X2, 16(AX))
+	SUM_LANES(Y3, X3, 24(AX))
+	SUM_LANES(Y4, X4, 32(AX))
+	VZEROUPPER
+	RET
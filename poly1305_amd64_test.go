@@ -0,0 +1,49 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+// +build go1.7,amd64,!gccgo,!appengine,!purego
+
+package chacha20
+
+import "testing"
+
+func TestBlocksVector(t *testing.T) {
+	if !useVectorMAC {
+		t.Skip("AVX2 is not supported")
+	}
+	defer func(v bool) { useVectorMAC = v }(useVectorMAC)
+
+	for _, b := range []byte{0x00, 0x5a, 0xff} {
+		var key [32]byte
+		msg := make([]byte, 2048+48)
+		for i := range key {
+			key[i] = b
+		}
+		for i := range msg {
+			msg[i] = b ^ byte(i)*b
+		}
+
+		for n := 0; n <= len(msg); n += 48 {
+			var tag0, tag1 [TagSize]byte
+			var mac0, mac1 macState
+			mac0.init(&key)
+			mac1.init(&key)
+
+			useVectorMAC = false
+			mac0.blocks(msg[:n])
+			mac0.blocks(msg)
+			mac0.sum(&tag0)
+			if n >= 64 {
+				mac1.blocksVector(msg[:n])
+			}
+			mac1.blocks(msg[n&^63 : n])
+			mac1.blocksVector(msg)
+			mac1.blocks(msg[len(msg)&^63:])
+			mac1.sum(&tag1)
+			if tag0 != tag1 {
+				t.Fatalf("Pattern %x: Size %d: blocksVector produces unexpected tag", b, n)
+			}
+		}
+	}
+}
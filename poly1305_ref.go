@@ -0,0 +1,17 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+// +build !amd64 gccgo appengine purego !go1.7
+
+package chacha20
+
+// useVectorMAC is always false since there is no vector Poly1305
+// implementation. It is a variable - like on amd64 - so that the
+// power-on self test can disable the vector implementation on every
+// platform.
+var useVectorMAC = false
+
+const vectorMACMinLength = 0
+
+func (m *macState) blocksVector(msg []byte) { panic("chacha20: vector Poly1305 is not available") }
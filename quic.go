@@ -0,0 +1,350 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package chacha20
+
+import (
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/sha256"
+	"errors"
+
+	"github.com/aead/chacha20/chacha"
+	"github.com/aead/chacha20/internal/argerr"
+)
+
+// The QUIC types implement the packet protection of QUIC with the
+// TLS_CHACHA20_POLY1305_SHA256 cipher suite as specified in RFC 9001.
+// Their methods match the AEAD and header protection interfaces of
+// quic-go, with packet numbers and key phases as plain integers.
+
+// QUICInvalidPacketNumber marks that no packet number has been seen yet.
+const QUICInvalidPacketNumber = -1
+
+var (
+	errQUICKeysDropped      = errors.New("QUIC keys of the previous key phase have been dropped")
+	errQUICDecryptionFailed = errors.New("QUIC packet decryption failed")
+	errQUICKeyUpdateTooSoon = errors.New("QUIC peer updated the keys too quickly")
+	errQUICKeyUpdateBlocked = errors.New("QUIC key update before a packet of the current key phase was acknowledged")
+)
+
+// QUICAEAD protects QUIC packet payloads (RFC 9001 5.3). The nonce of a
+// packet is the IV XOR'ed with the packet number.
+type QUICAEAD struct {
+	c  cipher.AEAD
+	iv [NonceSize]byte
+}
+
+// NewQUICAEAD returns a QUICAEAD using the packet protection key and IV.
+func NewQUICAEAD(key *[32]byte, iv *[NonceSize]byte) *QUICAEAD {
+	return &QUICAEAD{c: NewChaCha20Poly1305(key), iv: *iv}
+}
+
+// NewQUICAEADFromSecret returns a QUICAEAD and the header protection key
+// derived from a TLS traffic secret as described in RFC 9001 5.1.
+func NewQUICAEADFromSecret(secret []byte) (*QUICAEAD, *[32]byte) {
+	var key, hpKey [32]byte
+	var iv [NonceSize]byte
+	copy(key[:], hkdfExpandLabel(secret, "quic key", len(key)))
+	copy(iv[:], hkdfExpandLabel(secret, "quic iv", len(iv)))
+	copy(hpKey[:], hkdfExpandLabel(secret, "quic hp", len(hpKey)))
+	a := NewQUICAEAD(&key, &iv)
+	wipe(key[:])
+	return a, &hpKey
+}
+
+// NextQUICSecret returns the traffic secret of the next key phase
+// (RFC 9001 6.1).
+func NextQUICSecret(secret []byte) []byte {
+	return hkdfExpandLabel(secret, "quic ku", len(secret))
+}
+
+// Overhead returns the size of the tag.
+func (a *QUICAEAD) Overhead() int { return TagSize }
+
+// Seal encrypts and authenticates the payload of the packet with the packet
+// number and the associated data - the header - and appends it to dst.
+func (a *QUICAEAD) Seal(dst, src []byte, pn int64, associatedData []byte) []byte {
+	nonce := a.nonce(pn)
+	return a.c.Seal(dst, nonce[:], src, associatedData)
+}
+
+// Open authenticates and decrypts the payload of the packet with the packet
+// number and the associated data and appends the plaintext to dst.
+func (a *QUICAEAD) Open(dst, src []byte, pn int64, associatedData []byte) ([]byte, error) {
+	nonce := a.nonce(pn)
+	return a.c.Open(dst, nonce[:], src, associatedData)
+}
+
+func (a *QUICAEAD) nonce(pn int64) [NonceSize]byte {
+	nonce := a.iv
+	for i := 0; i < 8; i++ {
+		nonce[NonceSize-1-i] ^= byte(pn >> (8 * uint(i)))
+	}
+	return nonce
+}
+
+// QUICHeaderProtector implements the ChaCha20 based header protection of QUIC
+// (RFC 9001 5.4.4).
+type QUICHeaderProtector struct {
+	key          [32]byte
+	isLongHeader bool
+}
+
+// NewQUICHeaderProtector returns a QUICHeaderProtector using the header
+// protection key for long or short header packets.
+func NewQUICHeaderProtector(hpKey *[32]byte, isLongHeader bool) *QUICHeaderProtector {
+	return &QUICHeaderProtector{key: *hpKey, isLongHeader: isLongHeader}
+}
+
+// EncryptHeader masks the first byte and the packet number bytes of the
+// header using the 16 byte sample of the ciphertext.
+func (p *QUICHeaderProtector) EncryptHeader(sample []byte, firstByte *byte, pnBytes []byte) {
+	p.apply(sample, firstByte, pnBytes)
+}
+
+// DecryptHeader removes the mask of EncryptHeader.
+func (p *QUICHeaderProtector) DecryptHeader(sample []byte, firstByte *byte, pnBytes []byte) {
+	p.apply(sample, firstByte, pnBytes)
+}
+
+func (p *QUICHeaderProtector) apply(sample []byte, firstByte *byte, pnBytes []byte) {
+	if len(sample) != 16 {
+		panic(argerr.Error("chacha20: QUIC header protection sample must be 16 bytes"))
+	}
+	var (
+		nonce [NonceSize]byte
+		mask  [64]byte
+	)
+	copy(nonce[:], sample[4:])
+	counter := uint32(sample[0]) | uint32(sample[1])<<8 | uint32(sample[2])<<16 | uint32(sample[3])<<24
+	chacha.Block(&mask, &nonce, &p.key, counter, 20)
+
+	if p.isLongHeader {
+		*firstByte ^= mask[0] & 0x0f
+	} else {
+		*firstByte ^= mask[0] & 0x1f
+	}
+	for i := range pnBytes {
+		pnBytes[i] ^= mask[1+i]
+	}
+}
+
+// QUICPacketNumberLen returns the number of bytes needed to encode the packet
+// number given the largest acknowledged packet number (RFC 9000 A.2). For no
+// acknowledged packet largestAcked is QUICInvalidPacketNumber.
+func QUICPacketNumberLen(pn, largestAcked int64) int {
+	unacked := pn - largestAcked
+	switch {
+	case unacked < 1<<7:
+		return 1
+	case unacked < 1<<15:
+		return 2
+	case unacked < 1<<23:
+		return 3
+	default:
+		return 4
+	}
+}
+
+// DecodeQUICPacketNumber reconstructs the full packet number from the
+// truncated packet number of pnLen bytes and the largest packet number
+// received so far (RFC 9000 A.3).
+func DecodeQUICPacketNumber(largest, truncated int64, pnLen int) int64 {
+	expected := largest + 1
+	win := int64(1) << (8 * uint(pnLen))
+	hwin, mask := win/2, win-1
+	candidate := (expected &^ mask) | truncated
+	if candidate <= expected-hwin && candidate < 1<<62-win {
+		return candidate + win
+	}
+	if candidate > expected+hwin && candidate >= win {
+		return candidate - win
+	}
+	return candidate
+}
+
+// QUICUpdatableAEAD protects 1-RTT packets and implements the key update
+// of QUIC (RFC 9001 6). The key phase bit is KeyPhase()&1. The header
+// protection keys are not updated.
+type QUICUpdatableAEAD struct {
+	keyPhase uint64
+
+	sendSecret, rcvSecret  []byte
+	sendAEAD, nextSendAEAD *QUICAEAD
+	rcvAEAD, nextRcvAEAD   *QUICAEAD
+	prevRcvAEAD            *QUICAEAD
+
+	sendHP, rcvHP *QUICHeaderProtector
+
+	firstSentWithCurrentKey int64
+	firstRcvdWithCurrentKey int64
+	largestAcked            int64
+	largestRcvd             int64
+}
+
+// NewQUICUpdatableAEAD returns a QUICUpdatableAEAD using the 1-RTT traffic
+// secrets of both directions.
+func NewQUICUpdatableAEAD(sendSecret, rcvSecret []byte) *QUICUpdatableAEAD {
+	a := &QUICUpdatableAEAD{
+		sendSecret:              append([]byte(nil), sendSecret...),
+		rcvSecret:               append([]byte(nil), rcvSecret...),
+		firstSentWithCurrentKey: QUICInvalidPacketNumber,
+		firstRcvdWithCurrentKey: QUICInvalidPacketNumber,
+		largestAcked:            QUICInvalidPacketNumber,
+		largestRcvd:             QUICInvalidPacketNumber,
+	}
+	var hpKey *[32]byte
+	a.sendAEAD, hpKey = NewQUICAEADFromSecret(sendSecret)
+	a.sendHP = NewQUICHeaderProtector(hpKey, false)
+	a.rcvAEAD, hpKey = NewQUICAEADFromSecret(rcvSecret)
+	a.rcvHP = NewQUICHeaderProtector(hpKey, false)
+	a.deriveNextKeys()
+	return a
+}
+
+func (a *QUICUpdatableAEAD) deriveNextKeys() {
+	a.sendSecret = NextQUICSecret(a.sendSecret)
+	a.rcvSecret = NextQUICSecret(a.rcvSecret)
+	a.nextSendAEAD, _ = NewQUICAEADFromSecret(a.sendSecret)
+	a.nextRcvAEAD, _ = NewQUICAEADFromSecret(a.rcvSecret)
+}
+
+func (a *QUICUpdatableAEAD) rollKeys() {
+	a.keyPhase++
+	a.firstSentWithCurrentKey = QUICInvalidPacketNumber
+	a.firstRcvdWithCurrentKey = QUICInvalidPacketNumber
+	a.prevRcvAEAD = a.rcvAEAD
+	a.rcvAEAD, a.sendAEAD = a.nextRcvAEAD, a.nextSendAEAD
+	a.deriveNextKeys()
+	countRekey()
+}
+
+// KeyPhase returns the current key phase. The key phase bit of a packet
+// is KeyPhase()&1.
+func (a *QUICUpdatableAEAD) KeyPhase() uint64 { return a.keyPhase }
+
+// Overhead returns the size of the tag.
+func (a *QUICUpdatableAEAD) Overhead() int { return TagSize }
+
+// Seal encrypts and authenticates the payload of a packet with the keys of
+// the current key phase and appends it to dst.
+func (a *QUICUpdatableAEAD) Seal(dst, src []byte, pn int64, associatedData []byte) []byte {
+	if a.firstSentWithCurrentKey == QUICInvalidPacketNumber {
+		a.firstSentWithCurrentKey = pn
+	}
+	return a.sendAEAD.Seal(dst, src, pn, associatedData)
+}
+
+// Open authenticates and decrypts the payload of a packet with the key phase
+// bit of its header. A packet of the next key phase updates the keys - the
+// peer initiated a key update. Packets of the previous key phase are opened
+// until DropPreviousKeys is called.
+func (a *QUICUpdatableAEAD) Open(dst, src []byte, pn int64, keyPhaseBit uint8, associatedData []byte) ([]byte, error) {
+	if uint64(keyPhaseBit&1) != a.keyPhase&1 {
+		if a.keyPhase > 0 && (a.firstRcvdWithCurrentKey == QUICInvalidPacketNumber || pn < a.firstRcvdWithCurrentKey) {
+			if a.prevRcvAEAD == nil {
+				return nil, errQUICKeysDropped
+			}
+			dec, err := a.prevRcvAEAD.Open(dst, src, pn, associatedData)
+			if err != nil {
+				return nil, errQUICDecryptionFailed
+			}
+			a.received(pn)
+			return dec, nil
+		}
+		dec, err := a.nextRcvAEAD.Open(dst, src, pn, associatedData)
+		if err != nil {
+			return nil, errQUICDecryptionFailed
+		}
+		if a.keyPhase > 0 && a.firstSentWithCurrentKey == QUICInvalidPacketNumber {
+			return nil, errQUICKeyUpdateTooSoon
+		}
+		a.rollKeys()
+		a.firstRcvdWithCurrentKey = pn
+		a.received(pn)
+		return dec, nil
+	}
+
+	dec, err := a.rcvAEAD.Open(dst, src, pn, associatedData)
+	if err != nil {
+		return nil, errQUICDecryptionFailed
+	}
+	if a.firstRcvdWithCurrentKey == QUICInvalidPacketNumber {
+		a.firstRcvdWithCurrentKey = pn
+	}
+	a.received(pn)
+	return dec, nil
+}
+
+func (a *QUICUpdatableAEAD) received(pn int64) {
+	if pn > a.largestRcvd {
+		a.largestRcvd = pn
+	}
+}
+
+// SetLargestAcked sets the largest packet number acknowledged by the peer.
+func (a *QUICUpdatableAEAD) SetLargestAcked(pn int64) {
+	if pn > a.largestAcked {
+		a.largestAcked = pn
+	}
+}
+
+// PacketNumberLen returns the number of bytes used to encode the packet
+// number given the largest acknowledged packet number.
+func (a *QUICUpdatableAEAD) PacketNumberLen(pn int64) int {
+	return QUICPacketNumberLen(pn, a.largestAcked)
+}
+
+// DecodePacketNumber reconstructs the full packet number of a received
+// packet given the largest packet number received so far.
+func (a *QUICUpdatableAEAD) DecodePacketNumber(truncated int64, pnLen int) int64 {
+	return DecodeQUICPacketNumber(a.largestRcvd, truncated, pnLen)
+}
+
+// UpdateKeys initiates a key update. It fails if no packet sent with the
+// current keys has been acknowledged yet.
+func (a *QUICUpdatableAEAD) UpdateKeys() error {
+	if a.firstSentWithCurrentKey == QUICInvalidPacketNumber || a.largestAcked < a.firstSentWithCurrentKey {
+		return errQUICKeyUpdateBlocked
+	}
+	a.rollKeys()
+	return nil
+}
+
+// DropPreviousKeys drops the receive keys of the previous key phase. It
+// should be called three PTOs after a key update (RFC 9001 6.5).
+func (a *QUICUpdatableAEAD) DropPreviousKeys() { a.prevRcvAEAD = nil }
+
+// EncryptHeader applies the header protection to a short header packet.
+func (a *QUICUpdatableAEAD) EncryptHeader(sample []byte, firstByte *byte, pnBytes []byte) {
+	a.sendHP.EncryptHeader(sample, firstByte, pnBytes)
+}
+
+// DecryptHeader removes the header protection of a short header packet.
+func (a *QUICUpdatableAEAD) DecryptHeader(sample []byte, firstByte *byte, pnBytes []byte) {
+	a.rcvHP.DecryptHeader(sample, firstByte, pnBytes)
+}
+
+// hkdfExpandLabel implements HKDF-Expand-Label of TLS 1.3 (RFC 8446 7.1)
+// with SHA-256 and an empty context.
+func hkdfExpandLabel(secret []byte, label string, length int) []byte {
+	info := make([]byte, 0, 2+1+6+len(label)+1)
+	info = append(info, byte(length>>8), byte(length), byte(6+len(label)))
+	info = append(info, "tls13 "...)
+	info = append(info, label...)
+	info = append(info, 0)
+
+	out := make([]byte, 0, length+sha256.Size)
+	var t []byte
+	for i := byte(1); len(out) < length; i++ {
+		h := hmac.New(sha256.New, secret)
+		h.Write(t)
+		h.Write(info)
+		h.Write([]byte{i})
+		t = h.Sum(nil)
+		out = append(out, t...)
+	}
+	return out[:length]
+}
@@ -0,0 +1,127 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package chacha20
+
+import (
+	"bytes"
+	"testing"
+)
+
+// TestQUICVector checks the ChaCha20-Poly1305 short header packet of
+// RFC 9001 A.5.
+func TestQUICVector(t *testing.T) {
+	secret := fromHex("9ac312a7f877468ebe69422748ad00a15443f18203a07d6060f688f30f21632b")
+	if key := hkdfExpandLabel(secret, "quic key", 32); !bytes.Equal(key, fromHex("c6d98ff3441c3fe1b2182094f69caa2ed4b716b65488960a7a984979fb23e1c8")) {
+		t.Fatalf("quic key: got %x", key)
+	}
+	if iv := hkdfExpandLabel(secret, "quic iv", 12); !bytes.Equal(iv, fromHex("e0459b3474bdd0e44a41c144")) {
+		t.Fatalf("quic iv: got %x", iv)
+	}
+	if ku := NextQUICSecret(secret); !bytes.Equal(ku, fromHex("1223504755036d556342ee9361d253421a826c9ecdf3c7148684b36b714881f9")) {
+		t.Fatalf("quic ku: got %x", ku)
+	}
+
+	aead, hpKey := NewQUICAEADFromSecret(secret)
+	if !bytes.Equal(hpKey[:], fromHex("25a282b9e82f06f21f488917a4fc8f1b73573685608597d0efcb076b0ab7a7a4")) {
+		t.Fatalf("quic hp: got %x", hpKey[:])
+	}
+	const pn = 654360564
+	header := fromHex("4200bff4")
+	packet := aead.Seal(append([]byte{}, header...), []byte{0x01}, pn, header)
+	hp := NewQUICHeaderProtector(hpKey, false)
+	hp.EncryptHeader(packet[5:21], &packet[0], packet[1:4])
+	if want := fromHex("4cfe4189655e5cd55c41f69080575d7999c25a5bfb"); !bytes.Equal(packet, want) {
+		t.Fatalf("protected packet: got %x want %x", packet, want)
+	}
+
+	hp.DecryptHeader(packet[5:21], &packet[0], packet[1:4])
+	if !bytes.Equal(packet[:4], header) {
+		t.Fatalf("DecryptHeader: got %x want %x", packet[:4], header)
+	}
+	truncated := int64(packet[1])<<16 | int64(packet[2])<<8 | int64(packet[3])
+	if decoded := DecodeQUICPacketNumber(pn-1, truncated, int(packet[0]&3)+1); decoded != pn {
+		t.Fatalf("DecodeQUICPacketNumber: got %d want %d", decoded, pn)
+	}
+	payload, err := aead.Open(nil, packet[4:], pn, packet[:4])
+	if err != nil || !bytes.Equal(payload, []byte{0x01}) {
+		t.Fatalf("Open failed: %v", err)
+	}
+}
+
+func TestQUICPacketNumber(t *testing.T) {
+	// RFC 9000 A.2 and A.3
+	if n := QUICPacketNumberLen(0xac5c02, 0xabe8b3); n != 2 {
+		t.Fatalf("QUICPacketNumberLen: got %d want 2", n)
+	}
+	if n := QUICPacketNumberLen(0xace8fe, 0xabe8b3); n != 3 {
+		t.Fatalf("QUICPacketNumberLen: got %d want 3", n)
+	}
+	if n := QUICPacketNumberLen(0, QUICInvalidPacketNumber); n != 1 {
+		t.Fatalf("QUICPacketNumberLen: got %d want 1", n)
+	}
+	if pn := DecodeQUICPacketNumber(0xa82f30ea, 0x9b32, 2); pn != 0xa82f9b32 {
+		t.Fatalf("DecodeQUICPacketNumber: got %x want a82f9b32", pn)
+	}
+	for largest := int64(QUICInvalidPacketNumber); largest < 1000; largest++ {
+		for pn := largest + 1; pn < largest+128; pn++ {
+			if got := DecodeQUICPacketNumber(largest, pn&0xff, 1); got != pn {
+				t.Fatalf("DecodeQUICPacketNumber(%d, %d): got %d", largest, pn&0xff, got)
+			}
+		}
+	}
+}
+
+func TestQUICKeyUpdate(t *testing.T) {
+	clientSecret, serverSecret := make([]byte, 32), make([]byte, 32)
+	for i := range clientSecret {
+		clientSecret[i], serverSecret[i] = byte(i), byte(255-i)
+	}
+	client := NewQUICUpdatableAEAD(clientSecret, serverSecret)
+	server := NewQUICUpdatableAEAD(serverSecret, clientSecret)
+
+	send := func(from, to *QUICUpdatableAEAD, pn int64) {
+		ad := []byte{byte(pn)}
+		sealed := from.Seal(nil, []byte("payload"), pn, ad)
+		payload, err := to.Open(nil, sealed, pn, uint8(from.KeyPhase()&1), ad)
+		if err != nil || string(payload) != "payload" {
+			t.Fatalf("packet %d: Open failed: %v", pn, err)
+		}
+	}
+
+	if err := client.UpdateKeys(); err == nil {
+		t.Fatal("UpdateKeys succeeded before a packet was acknowledged")
+	}
+	send(client, server, 0)
+	send(server, client, 0)
+	client.SetLargestAcked(0)
+	if err := client.UpdateKeys(); err != nil {
+		t.Fatalf("UpdateKeys failed: %v", err)
+	}
+
+	// A packet of the old key phase sent before the update is still opened.
+	delayed := server.Seal(nil, []byte("payload"), 1, nil)
+	send(client, server, 1)
+	if server.KeyPhase() != 1 {
+		t.Fatalf("server didn't update its keys: key phase %d", server.KeyPhase())
+	}
+	if _, err := client.Open(nil, delayed, 1, 0, nil); err != nil {
+		t.Fatalf("Open of a packet of the previous key phase failed: %v", err)
+	}
+
+	client.DropPreviousKeys()
+	if _, err := client.Open(nil, delayed, 1, 0, nil); err != errQUICKeysDropped {
+		t.Fatalf("expected %v, got %v", errQUICKeysDropped, err)
+	}
+
+	// The peer must not update again before it has sent with the new keys.
+	client.SetLargestAcked(1)
+	if err := client.UpdateKeys(); err != nil {
+		t.Fatalf("second UpdateKeys failed: %v", err)
+	}
+	sealed := client.Seal(nil, []byte("payload"), 2, nil)
+	if _, err := server.Open(nil, sealed, 2, 0, nil); err != errQUICKeyUpdateTooSoon {
+		t.Fatalf("expected %v, got %v", errQUICKeyUpdateTooSoon, err)
+	}
+}
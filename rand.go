@@ -0,0 +1,262 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package chacha20
+
+import (
+	"crypto/rand"
+	"io"
+	"sync"
+
+	"github.com/aead/chacha20/chacha"
+)
+
+const (
+	// randReseedInterval is the number of bytes a Rand produces before
+	// it reads a new key from its seed source.
+	randReseedInterval = 1024 * 1024
+
+	// randBufferSize is the size of the keystream buffer of a Rand.
+	// Reads shorter than the buffer are served from the buffer.
+	randBufferSize = 512
+)
+
+// Reader is a global, shared instance of a Rand seeded from
+// crypto/rand.Reader. It serves small reads from buffered keystream,
+// so they don't need a system call. It is safe for concurrent use.
+var Reader io.Reader = NewRand(nil)
+
+// InstallReader replaces crypto/rand.Reader with Reader, so crypto/rand.Read
+// and all packages reading from crypto/rand.Reader use the ChaCha20 generator.
+// Reader keeps reading its keys from the original crypto/rand.Reader.
+// InstallReader should be called during program initialization - it must
+// not be called concurrently with reads from crypto/rand.Reader.
+func InstallReader() { rand.Reader = Reader }
+
+// Rand is a cryptographically secure random number generator producing
+// the ChaCha20 keystream of a random key. The key is read from a seed
+// source and replaced after every 1 MiB of output and after the process
+// forked. A Rand is safe for concurrent use.
+type Rand struct {
+	mu     sync.Mutex
+	seed   io.Reader
+	cipher *chacha.Cipher
+	n      int    // keystream bytes left until the next reseed
+	epoch  uint64 // fork epoch of the current key
+
+	buf      [randBufferSize]byte
+	buffered int // unused keystream at the end of buf
+}
+
+// NewRand returns a new Rand which reads its keys from seed. If seed
+// is nil crypto/rand.Reader is used. The first key is read by the first
+// call of Read.
+func NewRand(seed io.Reader) *Rand {
+	if seed == nil {
+		seed = rand.Reader
+	}
+	return &Rand{seed: seed}
+}
+
+// Read fills p with random bytes. It only returns an error if a new
+// key cannot be read from the seed source.
+func (r *Rand) Read(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if epoch := forkEpoch(); epoch != r.epoch {
+		r.discard()
+		r.n, r.epoch = 0, epoch
+	}
+	n := 0
+	for n < len(p) {
+		if r.buffered > 0 {
+			off := len(r.buf) - r.buffered
+			m := copy(p[n:], r.buf[off:])
+			for i := off; i < off+m; i++ {
+				r.buf[i] = 0
+			}
+			r.buffered -= m
+			n += m
+			continue
+		}
+		if r.n == 0 {
+			if err := r.reseed(); err != nil {
+				return n, err
+			}
+		}
+		if m := len(p) - n; m < len(r.buf) {
+			if m = len(r.buf); m > r.n {
+				m = r.n
+			}
+			r.cipher.XORKeyStream(r.buf[len(r.buf)-m:], r.buf[len(r.buf)-m:])
+			r.buffered = m
+			r.n -= m
+			continue
+		}
+
+		m := len(p) - n
+		if m > r.n {
+			m = r.n
+		}
+		for i := n; i < n+m; i++ {
+			p[i] = 0
+		}
+		r.cipher.XORKeyStream(p[n:n+m], p[n:n+m])
+		r.n -= m
+		n += m
+	}
+	return n, nil
+}
+
+// discard wipes the buffered keystream.
+func (r *Rand) discard() {
+	for i := range r.buf {
+		r.buf[i] = 0
+	}
+	r.buffered = 0
+}
+
+// reseed reads a new key from the seed source and resets the cipher.
+func (r *Rand) reseed() error {
+	var (
+		key   [32]byte
+		nonce [NonceSize]byte
+	)
+	if _, err := io.ReadFull(r.seed, key[:]); err != nil {
+		return err
+	}
+	if r.cipher == nil {
+		r.cipher = chacha.NewCipher(&nonce, &key, 20)
+	} else {
+		r.cipher.SetKey(&key)
+		r.cipher.SetCounter(0)
+	}
+	for i := range key {
+		key[i] = 0
+	}
+	r.n = randReseedInterval
+	return nil
+}
+
+// ShardedRand is a cryptographically secure random number generator for
+// many concurrent readers. It keeps a pool of independently keyed Rand
+// instances - usually one per P - so concurrent reads don't serialize on
+// a single mutex. A ShardedRand is safe for concurrent use.
+type ShardedRand struct {
+	seed   io.Reader
+	shards sync.Pool
+}
+
+// NewShardedRand returns a new ShardedRand whose Rand instances read their
+// keys from seed. The seed source must be safe for concurrent use. If seed is
+// nil crypto/rand.Reader is used.
+func NewShardedRand(seed io.Reader) *ShardedRand {
+	if seed == nil {
+		seed = rand.Reader
+	}
+	return &ShardedRand{seed: seed}
+}
+
+// Read fills p with random bytes. It only returns an error if a new
+// key cannot be read from the seed source.
+func (r *ShardedRand) Read(p []byte) (int, error) {
+	shard, ok := r.shards.Get().(*Rand)
+	if !ok {
+		shard = NewRand(r.seed)
+	}
+	n, err := shard.Read(p)
+	r.shards.Put(shard)
+	return n, err
+}
+
+// KeyErasureRand is a cryptographically secure random number generator
+// using the fast-key-erasure construction: It generates 768 bytes of
+// ChaCha20 keystream, uses the first 32 bytes as the next key and returns
+// the remaining bytes. The key and every returned byte are overwritten
+// immediately, so a compromised state doesn't reveal previous output.
+// A KeyErasureRand is safe for concurrent use.
+type KeyErasureRand struct {
+	mu    sync.Mutex
+	key   [32]byte
+	buf   [768]byte
+	off   int
+	seed  io.Reader
+	epoch uint64 // fork epoch of the key
+}
+
+// NewKeyErasureRand returns a new KeyErasureRand which reads its first key
+// from seed. If seed is nil crypto/rand.Reader is used. If the process
+// forks, the child mixes a new key from seed into the key before producing
+// any output.
+func NewKeyErasureRand(seed io.Reader) (*KeyErasureRand, error) {
+	if seed == nil {
+		seed = rand.Reader
+	}
+	r := &KeyErasureRand{seed: seed, epoch: forkEpoch()}
+	if _, err := io.ReadFull(seed, r.key[:]); err != nil {
+		return nil, err
+	}
+	r.off = len(r.buf)
+	return r, nil
+}
+
+// Read fills p with random bytes. It only returns an error if a new key
+// cannot be read from the seed source after the process forked.
+func (r *KeyErasureRand) Read(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if epoch := forkEpoch(); epoch != r.epoch {
+		if err := r.rekey(); err != nil {
+			return 0, err
+		}
+		r.epoch = epoch
+	}
+	n := 0
+	for n < len(p) {
+		if r.off == len(r.buf) {
+			r.refill()
+		}
+		m := copy(p[n:], r.buf[r.off:])
+		for i := r.off; i < r.off+m; i++ {
+			r.buf[i] = 0
+		}
+		r.off += m
+		n += m
+	}
+	return n, nil
+}
+
+// rekey discards the buffered output and XORs a new key from the seed
+// source into the key.
+func (r *KeyErasureRand) rekey() error {
+	var key [32]byte
+	if _, err := io.ReadFull(r.seed, key[:]); err != nil {
+		return err
+	}
+	for i := range r.buf {
+		r.buf[i] = 0
+	}
+	for i := range key {
+		r.key[i] ^= key[i]
+		key[i] = 0
+	}
+	r.off = len(r.buf)
+	return nil
+}
+
+// refill replaces the key and the buffer with the keystream of the key.
+func (r *KeyErasureRand) refill() {
+	var nonce [NonceSize]byte
+	for i := range r.buf {
+		r.buf[i] = 0
+	}
+	chacha.XORKeyStream(r.buf[:], r.buf[:], &nonce, &r.key, 0, 20)
+	copy(r.key[:], r.buf[:32])
+	for i := 0; i < 32; i++ {
+		r.buf[i] = 0
+	}
+	r.off = 32
+}
@@ -0,0 +1,195 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package chacha20
+
+import (
+	"bytes"
+	"crypto/rand"
+	"io"
+	"sync"
+	"testing"
+)
+
+func TestRand(t *testing.T) {
+	seed := make([]byte, 64)
+	for i := range seed {
+		seed[i] = byte(i)
+	}
+	r := NewRand(bytes.NewReader(seed))
+
+	// The output is the keystream of the first key until the reseed
+	// and then the keystream of the second key.
+	var key0, key1 [32]byte
+	var nonce [NonceSize]byte
+	copy(key0[:], seed)
+	copy(key1[:], seed[32:])
+	expected := make([]byte, randReseedInterval+100)
+	XORKeyStream(expected[:randReseedInterval], expected[:randReseedInterval], &nonce, &key0, 0)
+	XORKeyStream(expected[randReseedInterval:], expected[randReseedInterval:], &nonce, &key1, 0)
+
+	out := make([]byte, len(expected))
+	for i := range out {
+		out[i] = 0xff
+	}
+	for off, n := 0, 1; off < len(out); off, n = off+n, 2*n+1 {
+		if off+n > len(out) {
+			n = len(out) - off
+		}
+		if _, err := r.Read(out[off : off+n]); err != nil {
+			t.Fatalf("Read failed: %v", err)
+		}
+	}
+	if !bytes.Equal(out, expected) {
+		t.Fatal("Read produces unexpected output")
+	}
+
+	// The seed source is exhausted after two keys.
+	buf := make([]byte, randReseedInterval)
+	if n, err := r.Read(buf); err != io.EOF || n != randReseedInterval-100 {
+		t.Fatalf("Read returned %d, %v - want %d, %v", n, err, randReseedInterval-100, io.EOF)
+	}
+}
+
+func TestRandConcurrent(t *testing.T) {
+	r := NewRand(nil)
+	outputs := make([][]byte, 8)
+
+	var wg sync.WaitGroup
+	for i := range outputs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			outputs[i] = make([]byte, 64*1024)
+			r.Read(outputs[i])
+		}(i)
+	}
+	wg.Wait()
+	for i := range outputs {
+		for j := i + 1; j < len(outputs); j++ {
+			if bytes.Equal(outputs[i][:32], outputs[j][:32]) {
+				t.Fatalf("Read %d and %d produce the same output", i, j)
+			}
+		}
+	}
+}
+
+func TestShardedRand(t *testing.T) {
+	r := NewShardedRand(nil)
+	outputs := make([][]byte, 64)
+
+	var wg sync.WaitGroup
+	for i := range outputs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			outputs[i] = make([]byte, 100)
+			if _, err := r.Read(outputs[i]); err != nil {
+				t.Errorf("Read failed: %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+	seen := make(map[string]bool)
+	for i := range outputs {
+		if seen[string(outputs[i])] {
+			t.Fatalf("Read %d produces the output of another read", i)
+		}
+		seen[string(outputs[i])] = true
+	}
+
+	if _, err := NewShardedRand(bytes.NewReader(nil)).Read(outputs[0]); err == nil {
+		t.Fatal("Read ignores the error of the seed source")
+	}
+}
+
+func BenchmarkRand(b *testing.B) {
+	r := NewRand(nil)
+	buf := make([]byte, 32)
+	b.SetBytes(int64(len(buf)))
+	for i := 0; i < b.N; i++ {
+		r.Read(buf)
+	}
+}
+
+func TestKeyErasureRand(t *testing.T) {
+	seed := make([]byte, 32)
+	for i := range seed {
+		seed[i] = byte(i)
+	}
+	if _, err := NewKeyErasureRand(bytes.NewReader(seed[:31])); err == nil {
+		t.Fatal("NewKeyErasureRand accepted a short seed")
+	}
+	r, err := NewKeyErasureRand(bytes.NewReader(seed))
+	if err != nil {
+		t.Fatalf("NewKeyErasureRand failed: %v", err)
+	}
+
+	// Every 768 byte keystream block yields the next key and 736 bytes of output.
+	var key [32]byte
+	var nonce [NonceSize]byte
+	copy(key[:], seed)
+	var expected []byte
+	for i := 0; i < 4; i++ {
+		block := make([]byte, 768)
+		XORKeyStream(block, block, &nonce, &key, 0)
+		copy(key[:], block)
+		expected = append(expected, block[32:]...)
+	}
+
+	out := make([]byte, len(expected))
+	for off, n := 0, 1; off < len(out); off, n = off+n, 2*n+1 {
+		if off+n > len(out) {
+			n = len(out) - off
+		}
+		r.Read(out[off : off+n])
+	}
+	if !bytes.Equal(out, expected) {
+		t.Fatal("Read produces unexpected output")
+	}
+	if r.key != key {
+		t.Fatal("Read doesn't replace the key")
+	}
+	for i, v := range r.buf[:r.off] {
+		if v != 0 {
+			t.Fatalf("Read doesn't erase the returned byte %d", i)
+		}
+	}
+}
+
+func TestInstallReader(t *testing.T) {
+	defer func(r io.Reader) { rand.Reader = r }(rand.Reader)
+	seed := Reader.(*Rand).seed
+
+	InstallReader()
+	if rand.Reader != Reader {
+		t.Fatal("InstallReader doesn't replace crypto/rand.Reader")
+	}
+	if seed == Reader {
+		t.Fatal("Reader reads its keys from itself")
+	}
+	buf0, buf1 := make([]byte, 32), make([]byte, 32)
+	if _, err := rand.Read(buf0); err != nil {
+		t.Fatalf("crypto/rand.Read failed: %v", err)
+	}
+	rand.Read(buf1)
+	if bytes.Equal(buf0, buf1) {
+		t.Fatal("crypto/rand.Read produces the same output twice")
+	}
+}
+
+func BenchmarkRandParallel(b *testing.B) {
+	b.Run("Rand", func(b *testing.B) { benchmarkRandParallel(b, NewRand(nil)) })
+	b.Run("ShardedRand", func(b *testing.B) { benchmarkRandParallel(b, NewShardedRand(nil)) })
+}
+
+func benchmarkRandParallel(b *testing.B, r io.Reader) {
+	b.SetBytes(32)
+	b.RunParallel(func(pb *testing.PB) {
+		buf := make([]byte, 32)
+		for pb.Next() {
+			r.Read(buf)
+		}
+	})
+}
@@ -0,0 +1,146 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package chacha20
+
+import (
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"io"
+)
+
+var (
+	errNoRecipients       = errors.New("envelope must have between 1 and 255 recipients")
+	errRecipientNotFound  = errors.New("envelope has no matching recipient")
+	errNotMultiRecipient  = errors.New("envelope is not a multi-recipient envelope")
+	errInvalidWrappedKey  = errors.New("invalid wrapped content key")
+	errDuplicateRecipient = errors.New("envelope recipient IDs must be unique")
+)
+
+// EnvelopeRecipient is the slot of one recipient of a multi-recipient
+// envelope. The wrapped key is the 24 byte nonce followed by the content
+// key sealed with XChaCha20Poly1305 and the wrapping key of the recipient.
+type EnvelopeRecipient struct {
+	ID         []byte
+	WrappedKey []byte
+}
+
+// RecipientKey is the wrapping key of one recipient - for example derived
+// from a shared secret. The ID tells the recipient which slot to open and
+// may be empty.
+type RecipientKey struct {
+	ID  []byte
+	Key *[32]byte
+}
+
+// SealMultiRecipientEnvelope encrypts and authenticates the plaintext and the
+// additional data once with a random content key and wraps the content key
+// for every recipient. It returns a version 3 envelope. The content key and
+// the nonces are read from random - if nil crypto/rand.Reader is used.
+//
+// The envelope header - including all recipient slots - is authenticated
+// with the content key. So every recipient can detect modifications by
+// outsiders, but a recipient knows the content key and can create new
+// envelopes for the other recipients.
+func SealMultiRecipientEnvelope(random io.Reader, recipients []RecipientKey, plaintext, additionalData []byte) (*Envelope, error) {
+	if len(recipients) == 0 || len(recipients) > 255 {
+		return nil, errNoRecipients
+	}
+	if random == nil {
+		random = rand.Reader
+	}
+	var contentKey [32]byte
+	defer wipe(contentKey[:])
+	nonce := make([]byte, NonceSizeX)
+	if _, err := io.ReadFull(random, contentKey[:]); err != nil {
+		return nil, err
+	}
+	if _, err := io.ReadFull(random, nonce); err != nil {
+		return nil, err
+	}
+
+	e := &Envelope{
+		Version:    EnvelopeVersion3,
+		Nonce:      nonce,
+		Recipients: make([]EnvelopeRecipient, len(recipients)),
+	}
+	seen := make(map[string]bool, len(recipients))
+	for i, r := range recipients {
+		if len(r.ID) > 0 {
+			if seen[string(r.ID)] {
+				return nil, errDuplicateRecipient
+			}
+			seen[string(r.ID)] = true
+		}
+		wrapNonce := make([]byte, NonceSizeX, NonceSizeX+len(contentKey)+TagSize)
+		if _, err := io.ReadFull(random, wrapNonce); err != nil {
+			return nil, err
+		}
+		c := NewXChaCha20Poly1305(r.Key)
+		e.Recipients[i] = EnvelopeRecipient{
+			ID:         r.ID,
+			WrappedKey: c.Seal(wrapNonce, wrapNonce, contentKey[:], wrapAdditionalData(r.ID, nonce)),
+		}
+	}
+
+	header, err := e.header()
+	if err != nil {
+		return nil, err
+	}
+	e.Ciphertext = NewXChaCha20Poly1305(&contentKey).Seal(nil, nonce, plaintext, append(header, additionalData...))
+	return e, nil
+}
+
+// OpenRecipient unwraps the content key of the recipient with the ID and
+// its wrapping key, decrypts and authenticates the envelope and appends the
+// plaintext to dst. If id is empty every slot without an ID is tried.
+func (e *Envelope) OpenRecipient(dst, id []byte, key *[32]byte, additionalData []byte) ([]byte, error) {
+	if e.Version != EnvelopeVersion3 {
+		return nil, errNotMultiRecipient
+	}
+	if len(e.Nonce) != NonceSizeX {
+		return nil, errEnvelopeNonceSize
+	}
+	c := NewXChaCha20Poly1305(key)
+	for _, r := range e.Recipients {
+		if string(r.ID) != string(id) {
+			continue
+		}
+		contentKey, err := unwrapContentKey(c, &r, e.Nonce)
+		if err == errAuthFailed && len(id) == 0 {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		defer wipe(contentKey[:])
+		return e.Open(dst, NewXChaCha20Poly1305(contentKey), additionalData)
+	}
+	return nil, errRecipientNotFound
+}
+
+// unwrapContentKey opens the wrapped content key of the recipient slot.
+func unwrapContentKey(c cipher.AEAD, r *EnvelopeRecipient, nonce []byte) (*[32]byte, error) {
+	if len(r.WrappedKey) != NonceSizeX+32+TagSize {
+		return nil, errInvalidWrappedKey
+	}
+	var contentKey [32]byte
+	wrapNonce, wrapped := r.WrappedKey[:NonceSizeX], r.WrappedKey[NonceSizeX:]
+	if _, err := c.Open(contentKey[:0], wrapNonce, wrapped, wrapAdditionalData(r.ID, nonce)); err != nil {
+		return nil, errAuthFailed
+	}
+	return &contentKey, nil
+}
+
+// wrapAdditionalData binds a wrapped content key to the recipient ID and
+// to the envelope nonce, so slots cannot be moved between recipients or
+// envelopes.
+func wrapAdditionalData(id, nonce []byte) []byte {
+	data := make([]byte, 0, len(envelopeMagic)+2+len(id)+len(nonce))
+	data = append(data, envelopeMagic[:]...)
+	data = append(data, EnvelopeVersion3, byte(len(id)))
+	data = append(data, id...)
+	return append(data, nonce...)
+}
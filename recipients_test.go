@@ -0,0 +1,105 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package chacha20
+
+import (
+	"bytes"
+	"testing"
+)
+
+func testRecipientKeys() []RecipientKey {
+	keys := make([]RecipientKey, 3)
+	for i := range keys {
+		keys[i].Key = new([32]byte)
+		keys[i].Key[0] = byte(i + 1)
+	}
+	keys[0].ID, keys[1].ID = []byte("alice"), []byte("bob")
+	return keys
+}
+
+func TestMultiRecipientEnvelope(t *testing.T) {
+	recipients := testRecipientKeys()
+	plaintext, data := []byte("Hello, World"), []byte("additional data")
+
+	e, err := SealMultiRecipientEnvelope(nil, recipients, plaintext, data)
+	if err != nil {
+		t.Fatalf("SealMultiRecipientEnvelope failed: %s", err)
+	}
+	encoded, err := e.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary failed: %s", err)
+	}
+	var d Envelope
+	if err = d.UnmarshalBinary(encoded); err != nil {
+		t.Fatalf("UnmarshalBinary failed: %s", err)
+	}
+	if d.Version != EnvelopeVersion3 || len(d.Recipients) != len(recipients) {
+		t.Fatalf("Decoded envelope differs: %+v", d)
+	}
+
+	for i, r := range recipients {
+		decrypted, err := d.OpenRecipient(nil, r.ID, r.Key, data)
+		if err != nil {
+			t.Fatalf("Recipient %d: OpenRecipient failed: %s", i, err)
+		}
+		if !bytes.Equal(decrypted, plaintext) {
+			t.Fatalf("Recipient %d: decrypted envelope differs from plaintext: %x", i, decrypted)
+		}
+	}
+	if _, err = d.OpenRecipient(nil, []byte("bob"), recipients[0].Key, data); err != errAuthFailed {
+		t.Fatalf("expected %v, got %v", errAuthFailed, err)
+	}
+	if _, err = d.OpenRecipient(nil, []byte("carol"), recipients[0].Key, data); err != errRecipientNotFound {
+		t.Fatalf("expected %v, got %v", errRecipientNotFound, err)
+	}
+	if _, err = d.OpenRecipient(nil, nil, recipients[0].Key, data); err != errRecipientNotFound {
+		t.Fatalf("expected %v, got %v", errRecipientNotFound, err)
+	}
+	if _, err = d.OpenRecipient(nil, []byte("alice"), recipients[0].Key, nil); err == nil {
+		t.Fatal("OpenRecipient accepted wrong additional data")
+	}
+
+	// Moving a slot to another recipient or removing a slot is detected.
+	d.Recipients[0].ID, d.Recipients[1].ID = d.Recipients[1].ID, d.Recipients[0].ID
+	if _, err = d.OpenRecipient(nil, []byte("bob"), recipients[0].Key, data); err == nil {
+		t.Fatal("OpenRecipient accepted a moved slot")
+	}
+	d.Recipients[0].ID, d.Recipients[1].ID = d.Recipients[1].ID, d.Recipients[0].ID
+	d.Recipients = d.Recipients[:2]
+	if _, err = d.OpenRecipient(nil, []byte("alice"), recipients[0].Key, data); err == nil {
+		t.Fatal("OpenRecipient accepted an envelope with a removed slot")
+	}
+
+	var v2 Envelope
+	v2.Version = EnvelopeVersion2
+	if _, err = v2.OpenRecipient(nil, nil, recipients[0].Key, nil); err != errNotMultiRecipient {
+		t.Fatalf("expected %v, got %v", errNotMultiRecipient, err)
+	}
+	v2.Recipients = e.Recipients
+	if _, err = v2.MarshalBinary(); err != errUnsupportedEnvelope {
+		t.Fatalf("expected %v, got %v", errUnsupportedEnvelope, err)
+	}
+}
+
+func TestMultiRecipientEnvelopeInvalid(t *testing.T) {
+	if _, err := SealMultiRecipientEnvelope(nil, nil, nil, nil); err != errNoRecipients {
+		t.Fatalf("expected %v, got %v", errNoRecipients, err)
+	}
+	recipients := testRecipientKeys()
+	recipients[1].ID = recipients[0].ID
+	if _, err := SealMultiRecipientEnvelope(nil, recipients, nil, nil); err != errDuplicateRecipient {
+		t.Fatalf("expected %v, got %v", errDuplicateRecipient, err)
+	}
+
+	e, _ := SealMultiRecipientEnvelope(nil, testRecipientKeys(), nil, nil)
+	encoded, _ := e.MarshalBinary()
+	header, _ := e.header()
+	var d Envelope
+	for i := 0; i < len(header); i++ {
+		if err := d.UnmarshalBinary(encoded[:i]); err == nil {
+			t.Fatalf("UnmarshalBinary accepted truncated envelope of %d bytes", i)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package chacha20
+
+import (
+	"crypto/subtle"
+
+	"github.com/aead/chacha20/chacha"
+	"github.com/aead/chacha20/internal/argerr"
+	"github.com/aead/poly1305"
+)
+
+// ReEncrypt converts src, encrypted with the old nonce and key, into a ciphertext
+// encrypted with the new nonce and key and writes it to dst. Both keystreams start
+// at the given counter. The buffer is processed in a single pass and the plaintext
+// never appears in memory. Src and dst may be the same slice but otherwise should
+// not overlap. If len(dst) < len(src) this function panics.
+func ReEncrypt(dst, src []byte, oldNonce *[NonceSize]byte, oldKey *[32]byte, newNonce *[NonceSize]byte, newKey *[32]byte, counter uint32) {
+	if len(dst) < len(src) {
+		panic(argerr.Error("chacha20: dst buffer is to small"))
+	}
+	oldCipher, newCipher := chacha.NewCipher(oldNonce, oldKey, 20), chacha.NewCipher(newNonce, newKey, 20)
+	oldCipher.SetCounter(counter)
+	newCipher.SetCounter(counter)
+
+	reEncrypt(dst, src, oldCipher, newCipher, nil, nil)
+}
+
+// ReSeal converts a ChaCha20Poly1305 ciphertext, sealed with the old nonce and key,
+// into a ciphertext sealed with the new nonce and key and appends it to dst. The
+// additional data is authenticated under both keys. The old auth. tag is verified
+// and the new one computed in the same pass which re-encrypts the ciphertext.
+// The plaintext never appears in memory. If the old tag is invalid ReSeal returns
+// an error and zeroes the re-encrypted ciphertext - so if dst aliases ciphertext
+// the ciphertext is lost.
+func ReSeal(dst, ciphertext, additionalData []byte, oldNonce *[NonceSize]byte, oldKey *[32]byte, newNonce *[NonceSize]byte, newKey *[32]byte) ([]byte, error) {
+	n := len(ciphertext) - TagSize
+	if n < 0 {
+		return nil, errAuthFailed
+	}
+	var oldTag, oldSum, newSum [TagSize]byte
+	copy(oldTag[:], ciphertext[n:])
+
+	var oldPolyKey, newPolyKey [32]byte
+	oldCipher, newCipher := chacha.NewCipher(oldNonce, oldKey, 20), chacha.NewCipher(newNonce, newKey, 20)
+	oldCipher.XORKeyStream(oldPolyKey[:], oldPolyKey[:])
+	newCipher.XORKeyStream(newPolyKey[:], newPolyKey[:])
+	oldCipher.SetCounter(1)
+	newCipher.SetCounter(1)
+
+	oldPoly, newPoly := poly1305.New(&oldPolyKey), poly1305.New(&newPolyKey)
+	writeWithPadding(oldPoly, additionalData)
+	writeWithPadding(newPoly, additionalData)
+
+	ret, out := sliceForAppend(dst, n+TagSize)
+	reEncrypt(out[:n], ciphertext[:n], oldCipher, newCipher, oldPoly, newPoly)
+
+	if padCT := n % TagSize; padCT > 0 {
+		oldPoly.Write(zeroPad[:TagSize-padCT])
+		newPoly.Write(zeroPad[:TagSize-padCT])
+	}
+	lengths := aeadLengths(len(additionalData), n)
+	oldPoly.Write(lengths[:])
+	newPoly.Write(lengths[:])
+	oldPoly.Sum(&oldSum)
+	newPoly.Sum(&newSum)
+
+	if subtle.ConstantTimeCompare(oldSum[:], oldTag[:]) != 1 {
+		for i := range out {
+			out[i] = 0
+		}
+		return nil, errAuthFailed
+	}
+	copy(out[n:], newSum[:])
+	return ret, nil
+}
+
+// reEncrypt xors src with both keystreams and writes the result to dst.
+// If oldPoly and newPoly are not nil src is written to oldPoly and dst
+// to newPoly.
+func reEncrypt(dst, src []byte, oldCipher, newCipher *chacha.Cipher, oldPoly, newPoly *poly1305.Hash) {
+	var keystream [256]byte
+	for len(src) > 0 {
+		n := len(keystream)
+		if n > len(src) {
+			n = len(src)
+		}
+		ks := keystream[:n]
+		for i := range ks {
+			ks[i] = 0
+		}
+		oldCipher.XORKeyStream(ks, ks)
+		newCipher.XORKeyStream(ks, ks)
+
+		if oldPoly != nil {
+			oldPoly.Write(src[:n])
+		}
+		for i, v := range ks {
+			dst[i] = src[i] ^ v
+		}
+		if newPoly != nil {
+			newPoly.Write(dst[:n])
+		}
+		src, dst = src[n:], dst[n:]
+	}
+}
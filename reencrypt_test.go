@@ -0,0 +1,65 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package chacha20
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestReEncrypt(t *testing.T) {
+	var (
+		oldKey, newKey     [32]byte
+		oldNonce, newNonce [NonceSize]byte
+	)
+	oldKey[0], newKey[0], newNonce[0] = 1, 2, 3
+
+	for _, size := range []int{0, 1, 64, 255, 256, 1000} {
+		plaintext := make([]byte, size)
+		for i := range plaintext {
+			plaintext[i] = byte(i)
+		}
+		ciphertext, expected := make([]byte, size), make([]byte, size)
+		XORKeyStream(ciphertext, plaintext, &oldNonce, &oldKey, 7)
+		XORKeyStream(expected, plaintext, &newNonce, &newKey, 7)
+
+		ReEncrypt(ciphertext, ciphertext, &oldNonce, &oldKey, &newNonce, &newKey, 7)
+		if !bytes.Equal(ciphertext, expected) {
+			t.Fatalf("Size %d: ReEncrypt produces unexpected ciphertext", size)
+		}
+	}
+}
+
+func TestReSeal(t *testing.T) {
+	var (
+		oldKey, newKey     [32]byte
+		oldNonce, newNonce [NonceSize]byte
+	)
+	oldKey[0], newKey[0], newNonce[0] = 1, 2, 3
+	oldAEAD, newAEAD := NewChaCha20Poly1305(&oldKey), NewChaCha20Poly1305(&newKey)
+	data := []byte("additional data")
+
+	for _, size := range []int{0, 1, 64, 255, 256, 1000} {
+		plaintext := make([]byte, size)
+		for i := range plaintext {
+			plaintext[i] = byte(i)
+		}
+		ciphertext := oldAEAD.Seal(nil, oldNonce[:], plaintext, data)
+		expected := newAEAD.Seal(nil, newNonce[:], plaintext, data)
+
+		resealed, err := ReSeal(ciphertext[:0], ciphertext, data, &oldNonce, &oldKey, &newNonce, &newKey)
+		if err != nil {
+			t.Fatalf("Size %d: ReSeal failed: %s", size, err)
+		}
+		if !bytes.Equal(resealed, expected) {
+			t.Fatalf("Size %d: ReSeal produces unexpected ciphertext", size)
+		}
+
+		resealed[0]++
+		if _, err = ReSeal(nil, resealed, data, &newNonce, &newKey, &oldNonce, &oldKey); err == nil {
+			t.Fatalf("Size %d: ReSeal accepted modified ciphertext", size)
+		}
+	}
+}
@@ -0,0 +1,248 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package chacha20
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"errors"
+	"io"
+
+	"github.com/aead/chacha20/chacha"
+	"github.com/aead/poly1305"
+)
+
+const (
+	// SecretStreamHeaderSize is the size of the header of a secret stream.
+	SecretStreamHeaderSize = 24
+
+	// SecretStreamOverhead is the number of bytes a message grows when
+	// pushed to a secret stream: the encrypted tag and the poly1305 tag.
+	SecretStreamOverhead = 1 + TagSize
+)
+
+// The tags of secret stream messages. The tag of a message is encrypted
+// and authenticated with the message.
+const (
+	// SecretStreamTagMessage marks an ordinary message.
+	SecretStreamTagMessage byte = 0x00
+
+	// SecretStreamTagPush marks the end of a set of messages but not the
+	// end of the stream.
+	SecretStreamTagPush byte = 0x01
+
+	// SecretStreamTagRekey marks a message after which the key is
+	// replaced by both sides.
+	SecretStreamTagRekey byte = 0x02
+
+	// SecretStreamTagFinal marks the last message of the stream. The key
+	// is replaced after it, like after SecretStreamTagRekey.
+	SecretStreamTagFinal = SecretStreamTagPush | SecretStreamTagRekey
+)
+
+var errSecretStreamTooShort = errors.New("secret stream message is shorter than SecretStreamOverhead")
+
+// The secret stream construction is compatible with the
+// crypto_secretstream_xchacha20poly1305 API of libsodium. The header is
+// a random 24 byte value: the stream key is derived from the key and the
+// first 16 bytes of the header by HChaCha20 and the remaining 8 bytes
+// are the initial nonce. The nonce of every message is a 32 bit counter
+// followed by the 8 byte nonce. After every message the 8 byte nonce is
+// XOR-ed with the first 8 bytes of the message's poly1305 tag and the
+// counter is incremented. The key and the 8 byte nonce are replaced by
+// encrypting them with the current key and nonce - a rekey - after every
+// message tagged with SecretStreamTagRekey and whenever the counter wraps.
+
+// secretStreamState is the state shared by both sides of a secret stream.
+type secretStreamState struct {
+	key   [32]byte
+	nonce [NonceSize]byte // 32 bit LE counter | 8 byte nonce
+}
+
+func (s *secretStreamState) init(key *[32]byte, header *[SecretStreamHeaderSize]byte) {
+	var hNonce [16]byte
+	copy(hNonce[:], header[:16])
+	chacha.HChaCha20(&s.key, &hNonce, key)
+	copy(s.nonce[4:], header[16:])
+	s.resetCounter()
+}
+
+func (s *secretStreamState) resetCounter() {
+	s.nonce[0], s.nonce[1], s.nonce[2], s.nonce[3] = 1, 0, 0, 0
+}
+
+// rekey replaces the key and the 8 byte nonce by encrypting them with
+// the current key and nonce and resets the counter.
+func (s *secretStreamState) rekey() {
+	var buf [32 + 8]byte
+	copy(buf[:], s.key[:])
+	copy(buf[32:], s.nonce[4:])
+	chacha.XORKeyStream(buf[:], buf[:], &s.nonce, &s.key, 0, 20)
+	copy(s.key[:], buf[:32])
+	copy(s.nonce[4:], buf[32:])
+	wipe(buf[:])
+	s.resetCounter()
+	countRekey()
+}
+
+// authenticate computes the poly1305 tag of the additional data, the
+// encrypted tag block and the ciphertext. The first byte of block must be
+// the encrypted tag.
+func (s *secretStreamState) authenticate(out *[TagSize]byte, block *[64]byte, ciphertext, additionalData []byte) {
+	var polyKey [64]byte
+	chacha.XORKeyStream(polyKey[:], polyKey[:], &s.nonce, &s.key, 0, 20)
+	var key [32]byte
+	copy(key[:], polyKey[:32])
+	poly := poly1305.New(&key)
+	wipe(polyKey[:])
+	wipe(key[:])
+
+	writeWithPadding(poly, additionalData)
+	poly.Write(block[:])
+	poly.Write(ciphertext)
+	poly.Write(zeroPad[:len(ciphertext)%TagSize]) // libsodium pads len(ciphertext) mod 16 bytes
+	lengths := aeadLengths(len(additionalData), len(block)+len(ciphertext))
+	poly.Write(lengths[:])
+	poly.Sum(out)
+}
+
+// next updates the state after a message with the given tag and
+// poly1305 tag.
+func (s *secretStreamState) next(tag byte, mac *[TagSize]byte) {
+	for i := 0; i < 8; i++ {
+		s.nonce[4+i] ^= mac[i]
+	}
+	for i := 0; i < 4; i++ {
+		s.nonce[i]++
+		if s.nonce[i] != 0 {
+			break
+		}
+	}
+	if tag&SecretStreamTagRekey != 0 || s.nonce[0]|s.nonce[1]|s.nonce[2]|s.nonce[3] == 0 {
+		s.rekey()
+	}
+}
+
+// SecretStreamPush encrypts a sequence of messages compatible with the
+// crypto_secretstream_xchacha20poly1305_push function of libsodium.
+// It is not safe for concurrent use.
+type SecretStreamPush struct {
+	state secretStreamState
+
+	rekeyAfter uint64
+	pushed     uint64 // plaintext bytes pushed since the last rekey
+}
+
+// NewSecretStreamPush returns a new SecretStreamPush and the header of
+// the stream. The header is generated from crypto/rand and must be sent
+// to the receiver before the first message. The key may be used for more
+// than one stream.
+func NewSecretStreamPush(key *[32]byte) (*SecretStreamPush, [SecretStreamHeaderSize]byte, error) {
+	return newSecretStreamPush(key, rand.Reader)
+}
+
+func newSecretStreamPush(key *[32]byte, random io.Reader) (*SecretStreamPush, [SecretStreamHeaderSize]byte, error) {
+	var header [SecretStreamHeaderSize]byte
+	if _, err := io.ReadFull(random, header[:]); err != nil {
+		return nil, header, err
+	}
+	s := new(SecretStreamPush)
+	s.state.init(key, &header)
+	return s, header, nil
+}
+
+// SetRekeyInterval enables the automatic rekey: once at least n plaintext
+// bytes have been pushed since the last rekey, the tag of the next message
+// gets the SecretStreamTagRekey bit. Since the rekey is signaled by the
+// tag, the receiver - e.g. libsodium - needs no configuration. An interval
+// of 0 disables the automatic rekey.
+func (s *SecretStreamPush) SetRekeyInterval(n uint64) { s.rekeyAfter, s.pushed = n, 0 }
+
+// Push encrypts and authenticates the message, its tag and the additional
+// data and appends the result to dst. The result is len(message) +
+// SecretStreamOverhead bytes long. Push replaces the key after a message
+// tagged with SecretStreamTagRekey or SecretStreamTagFinal.
+func (s *SecretStreamPush) Push(dst, message, additionalData []byte, tag byte) []byte {
+	if s.rekeyAfter > 0 && s.pushed >= s.rekeyAfter {
+		tag |= SecretStreamTagRekey
+	}
+
+	var block [64]byte
+	block[0] = tag
+	chacha.XORKeyStream(block[:], block[:], &s.state.nonce, &s.state.key, 1, 20)
+
+	ret, out := sliceForAppend(dst, len(message)+SecretStreamOverhead)
+	out[0] = block[0]
+	ciphertext := out[1 : 1+len(message)]
+	chacha.XORKeyStream(ciphertext, message, &s.state.nonce, &s.state.key, 2, 20)
+
+	var mac [TagSize]byte
+	s.state.authenticate(&mac, &block, ciphertext, additionalData)
+	copy(out[1+len(message):], mac[:])
+
+	s.pushed += uint64(len(message))
+	if tag&SecretStreamTagRekey != 0 {
+		s.pushed = 0
+	}
+	s.state.next(tag, &mac)
+	countSeal(len(message))
+	return ret
+}
+
+// Rekey replaces the key without sending a message. The receiver must
+// call Rekey at the same position of the stream.
+func (s *SecretStreamPush) Rekey() {
+	s.state.rekey()
+	s.pushed = 0
+}
+
+// SecretStreamPull decrypts a sequence of messages compatible with the
+// crypto_secretstream_xchacha20poly1305_pull function of libsodium.
+// It is not safe for concurrent use.
+type SecretStreamPull struct {
+	state secretStreamState
+}
+
+// NewSecretStreamPull returns a new SecretStreamPull for the stream with
+// the given header.
+func NewSecretStreamPull(key *[32]byte, header *[SecretStreamHeaderSize]byte) *SecretStreamPull {
+	s := new(SecretStreamPull)
+	s.state.init(key, header)
+	return s
+}
+
+// Pull authenticates and decrypts the ciphertext and the additional data,
+// appends the message to dst and returns it with its tag. The state is
+// not changed if the ciphertext is not authentic. Pull replaces the key
+// after a message tagged with SecretStreamTagRekey or
+// SecretStreamTagFinal. The caller must check whether the stream ended
+// with a message tagged with SecretStreamTagFinal.
+func (s *SecretStreamPull) Pull(dst, ciphertext, additionalData []byte) ([]byte, byte, error) {
+	if len(ciphertext) < SecretStreamOverhead {
+		return nil, 0, errSecretStreamTooShort
+	}
+	var block [64]byte
+	block[0] = ciphertext[0]
+	chacha.XORKeyStream(block[:], block[:], &s.state.nonce, &s.state.key, 1, 20)
+	tag := block[0]
+	block[0] = ciphertext[0]
+
+	n := len(ciphertext) - TagSize
+	var mac [TagSize]byte
+	s.state.authenticate(&mac, &block, ciphertext[1:n], additionalData)
+	if subtle.ConstantTimeCompare(mac[:], ciphertext[n:]) != 1 {
+		countOpen(0, errAuthFailed)
+		return nil, 0, errAuthFailed
+	}
+
+	ret, message := sliceForAppend(dst, n-1)
+	chacha.XORKeyStream(message, ciphertext[1:n], &s.state.nonce, &s.state.key, 2, 20)
+	s.state.next(tag, &mac)
+	countOpen(len(message), nil)
+	return ret, tag, nil
+}
+
+// Rekey replaces the key like SecretStreamPush.Rekey.
+func (s *SecretStreamPull) Rekey() { s.state.rekey() }
@@ -0,0 +1,148 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package chacha20
+
+import (
+	"bytes"
+	"testing"
+)
+
+// secretStreamVectors have been generated by the crypto_secretstream_xchacha20poly1305
+// functions of libsodium with the key 0, 1, ..., 31. An entry with rekey set is a
+// call of crypto_secretstream_xchacha20poly1305_rekey.
+var secretStreamHeader = "87274a93a96d8b7e869a4029ef1f5ffdcfe1e4ee0e00f9d1"
+
+var secretStreamVectors = []struct {
+	message, ad string
+	tag         byte
+	ciphertext  string
+	rekey       bool
+}{
+	{
+		message:    "",
+		ad:         "",
+		tag:        0,
+		ciphertext: "f5611d9e9f2382708f4e40e8347a339c6a",
+	},
+	{
+		message:    "68656c6c6f",
+		ad:         "6164",
+		tag:        0,
+		ciphertext: "1c227ea25af5c37a09d3f4c551e58b0ef6eeb8b654f0",
+	},
+	{
+		message:    "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f404142434445464748494a4b4c4d4e4f505152535455565758595a5b5c5d5e5f60616263",
+		ad:         "",
+		tag:        2,
+		ciphertext: "dfc1045a6cfe381eb9f41037f75461e130c2f97da3a31714e7ca86dc3441552b3a5b185a2dc8b15f07dfcb8082bd0b32588abdabb4591578a576b9e4064d0c027b2fccca775d8458d6922430b56de6f153e2a6c37ef83f4cff7795cd1ba8726b8776b39450fbdb43fb83b3c0b8ebd502d09afc6a9d",
+	},
+	{
+		message:    "61667465722072656b6579",
+		ad:         "",
+		tag:        0,
+		ciphertext: "21c9723ed9ae15f0a7407d3ff8de957ae5d855a92544ca05a92f6b78",
+	},
+	{rekey: true},
+	{
+		message:    "78787878787878787878787878787878787878787878787878787878787878787878787878787878787878787878787878787878787878787878787878787878787878787878",
+		ad:         "6164646974696f6e616c2064617461",
+		tag:        1,
+		ciphertext: "9d5eaf42babeadfd7c1cb499697af1b6538bfa969549ad07254ddb349ad1f04b75cc58df9018abb5d4eb7b7c1e46f4c20505ffdd5537b223059f4bf411811b624517409e97632ff2212d662991c5d8d69a8148d3b682d6",
+	},
+	{
+		message:    "627965",
+		ad:         "",
+		tag:        3,
+		ciphertext: "231465c2f634001732720a205cfffc4f8d085550",
+	},
+}
+
+func TestSecretStreamVectors(t *testing.T) {
+	var key, header = [32]byte{}, [SecretStreamHeaderSize]byte{}
+	for i := range key {
+		key[i] = byte(i)
+	}
+	copy(header[:], fromHex(secretStreamHeader))
+
+	push, h, err := newSecretStreamPush(&key, bytes.NewReader(header[:]))
+	if err != nil {
+		t.Fatalf("Failed to create SecretStreamPush: %v", err)
+	}
+	if h != header {
+		t.Fatalf("Unexpected header: got %x want %x", h, header)
+	}
+	pull := NewSecretStreamPull(&key, &header)
+	for i, v := range secretStreamVectors {
+		if v.rekey {
+			push.Rekey()
+			pull.Rekey()
+			continue
+		}
+		message, ad, ciphertext := fromHex(v.message), fromHex(v.ad), fromHex(v.ciphertext)
+		if c := push.Push(nil, message, ad, v.tag); !bytes.Equal(c, ciphertext) {
+			t.Fatalf("Test vector %d: Push: got %x want %x", i, c, ciphertext)
+		}
+		m, tag, err := pull.Pull(nil, ciphertext, ad)
+		if err != nil {
+			t.Fatalf("Test vector %d: Pull failed: %v", i, err)
+		}
+		if !bytes.Equal(m, message) || tag != v.tag {
+			t.Fatalf("Test vector %d: Pull: got %x, %d want %x, %d", i, m, tag, message, v.tag)
+		}
+	}
+}
+
+func TestSecretStreamPullModified(t *testing.T) {
+	var key [32]byte
+	push, header, err := NewSecretStreamPush(&key)
+	if err != nil {
+		t.Fatalf("Failed to create SecretStreamPush: %v", err)
+	}
+	first := push.Push(nil, []byte("first"), nil, SecretStreamTagMessage)
+	second := push.Push(nil, []byte("second"), nil, SecretStreamTagFinal)
+
+	pull := NewSecretStreamPull(&key, &header)
+	if _, _, err = pull.Pull(nil, second, nil); err == nil {
+		t.Fatal("Pull accepted a reordered message")
+	}
+	first[0] ^= 1
+	if _, _, err = pull.Pull(nil, first, nil); err == nil {
+		t.Fatal("Pull accepted a modified tag")
+	}
+	first[0] ^= 1
+	if _, _, err = pull.Pull(nil, first, []byte("ad")); err == nil {
+		t.Fatal("Pull accepted modified additional data")
+	}
+	if _, _, err = pull.Pull(nil, first[:SecretStreamOverhead-1], nil); err == nil {
+		t.Fatal("Pull accepted a too short message")
+	}
+	if _, _, err = pull.Pull(nil, first, nil); err != nil {
+		t.Fatalf("Pull failed: %v", err)
+	}
+	if m, tag, err := pull.Pull(nil, second, nil); err != nil || string(m) != "second" || tag != SecretStreamTagFinal {
+		t.Fatalf("Pull failed: %q, %d, %v", m, tag, err)
+	}
+}
+
+func TestSecretStreamRekeyInterval(t *testing.T) {
+	var key, header = [32]byte{}, [SecretStreamHeaderSize]byte{}
+	message := make([]byte, 40)
+
+	auto, _, _ := newSecretStreamPush(&key, bytes.NewReader(header[:]))
+	auto.SetRekeyInterval(64)
+	explicit, _, _ := newSecretStreamPush(&key, bytes.NewReader(header[:]))
+	pull := NewSecretStreamPull(&key, &header)
+
+	tags := []byte{0, 0, SecretStreamTagRekey, 0, 0, SecretStreamTagRekey}
+	for i, tag := range tags {
+		c := auto.Push(nil, message, nil, SecretStreamTagMessage)
+		if e := explicit.Push(nil, message, nil, tag); !bytes.Equal(c, e) {
+			t.Fatalf("Message %d: automatic rekey differs from explicit rekey", i)
+		}
+		if _, got, err := pull.Pull(nil, c, nil); err != nil || got != tag {
+			t.Fatalf("Message %d: Pull: got tag %d, %v want tag %d", i, got, err, tag)
+		}
+	}
+}
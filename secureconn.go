@@ -0,0 +1,177 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package chacha20
+
+import (
+	"errors"
+	"io"
+	"net"
+	"sync"
+)
+
+const (
+	// MaxSecureConnRecordSize is the max. size of the plaintext of one record
+	// sent by a SecureConn.
+	MaxSecureConnRecordSize = 16 * 1024
+
+	secureConnHeaderSize = 2
+)
+
+var errSecureConnRecord = errors.New("SecureConn record has an invalid length")
+
+// SecureConn wraps a net.Conn and encrypts the data sent over it. The data
+// is split into records which are sealed by a SendSession and opened by a
+// RecvSession - so the nonce of a record is its sequence number and dropped,
+// reordered or replayed records are detected. A record is:
+//
+//	length (2 bytes, big endian) | ciphertext | tag
+//
+// The length is the size of the ciphertext and the tag and is authenticated
+// as additional data. Each direction must use its own key - see
+// SecureConnKeys. The end of the stream is not authenticated: an attacker
+// can truncate the stream at a record boundary, so protocols must detect
+// the end of their messages themselves.
+//
+// Read and Write may be called concurrently. A read which times out can be
+// retried since partially received records are buffered. Any other error
+// - including a write timeout - is permanent.
+type SecureConn struct {
+	net.Conn
+
+	rmu       sync.Mutex
+	recv      *RecvSession
+	rbuf      []byte
+	rn        int
+	plaintext []byte
+	rerr      error
+
+	wmu  sync.Mutex
+	send *SendSession
+	wbuf []byte
+	werr error
+}
+
+// NewSecureConn returns a SecureConn which sends records sealed with the
+// send key and reads records sealed with the receive key. If limits is nil
+// the DefaultSessionLimits are used.
+func NewSecureConn(conn net.Conn, sendKey, recvKey *[32]byte, limits *SessionLimits) *SecureConn {
+	return &SecureConn{
+		Conn: conn,
+		recv: NewRecvSession(recvKey, limits),
+		rbuf: make([]byte, secureConnHeaderSize+MaxSecureConnRecordSize+TagSize),
+		send: NewSendSession(sendKey, limits),
+		wbuf: make([]byte, 0, secureConnHeaderSize+MaxSecureConnRecordSize+TagSize),
+	}
+}
+
+// SecureConnKeys derives the send and the receive key of one side of a
+// SecureConn from a shared key. The client keys match the server keys of
+// the peer.
+func SecureConnKeys(key *[32]byte, client bool) (sendKey, recvKey *[32]byte) {
+	sendKey, recvKey = new([32]byte), new([32]byte)
+	clientKey := Expand(key, []byte("SecureConn client to server"), 32)
+	serverKey := Expand(key, []byte("SecureConn server to client"), 32)
+	if client {
+		copy(sendKey[:], clientKey)
+		copy(recvKey[:], serverKey)
+	} else {
+		copy(sendKey[:], serverKey)
+		copy(recvKey[:], clientKey)
+	}
+	wipe(clientKey)
+	wipe(serverKey)
+	return sendKey, recvKey
+}
+
+// Read reads and opens records from the underlying connection and copies
+// their plaintext into p.
+func (c *SecureConn) Read(p []byte) (int, error) {
+	c.rmu.Lock()
+	defer c.rmu.Unlock()
+
+	if len(p) == 0 {
+		return 0, nil
+	}
+	for len(c.plaintext) == 0 {
+		if c.rerr != nil {
+			return 0, c.rerr
+		}
+		if err := c.readRecord(); err != nil {
+			if ne, ok := err.(net.Error); !ok || !ne.Timeout() {
+				c.rerr = err
+			}
+			return 0, err
+		}
+	}
+	n := copy(p, c.plaintext)
+	c.plaintext = c.plaintext[n:]
+	return n, nil
+}
+
+// readRecord reads the next record into rbuf and opens it. Partially read
+// records are kept in rbuf, so readRecord continues where it stopped.
+func (c *SecureConn) readRecord() error {
+	need := secureConnHeaderSize
+	for {
+		if c.rn >= secureConnHeaderSize {
+			length := int(c.rbuf[0])<<8 | int(c.rbuf[1])
+			if length < TagSize || length > MaxSecureConnRecordSize+TagSize {
+				return errSecureConnRecord
+			}
+			need = secureConnHeaderSize + length
+		}
+		if c.rn == need {
+			break
+		}
+		n, err := c.Conn.Read(c.rbuf[c.rn:need])
+		c.rn += n
+		if err != nil && c.rn < need {
+			if err == io.EOF && c.rn > 0 {
+				err = io.ErrUnexpectedEOF
+			}
+			return err
+		}
+	}
+
+	record := c.rbuf[secureConnHeaderSize:need]
+	plaintext, err := c.recv.Open(record[:0], record, c.rbuf[:secureConnHeaderSize])
+	if err != nil {
+		return err
+	}
+	c.rn = 0
+	c.plaintext = plaintext
+	return nil
+}
+
+// Write seals p as one or more records and writes them to the underlying
+// connection.
+func (c *SecureConn) Write(p []byte) (n int, err error) {
+	c.wmu.Lock()
+	defer c.wmu.Unlock()
+
+	if c.werr != nil {
+		return 0, c.werr
+	}
+	for len(p) > 0 {
+		m := len(p)
+		if m > MaxSecureConnRecordSize {
+			m = MaxSecureConnRecordSize
+		}
+		length := m + TagSize
+		header := [secureConnHeaderSize]byte{byte(length >> 8), byte(length)}
+		record := append(c.wbuf[:0], header[:]...)
+		if record, err = c.send.Seal(record, p[:m], header[:]); err != nil {
+			c.werr = err
+			return n, err
+		}
+		if _, err = c.Conn.Write(record); err != nil {
+			c.werr = err
+			return n, err
+		}
+		n += m
+		p = p[m:]
+	}
+	return n, nil
+}
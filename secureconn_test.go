@@ -0,0 +1,197 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package chacha20
+
+import (
+	"bytes"
+	"io"
+	"net"
+	"testing"
+)
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+// scriptedConn records writes and returns the chunks as reads. A nil chunk
+// is returned as a timeout.
+type scriptedConn struct {
+	net.Conn
+	written bytes.Buffer
+	chunks  [][]byte
+}
+
+func (c *scriptedConn) Write(p []byte) (int, error) { return c.written.Write(p) }
+
+func (c *scriptedConn) Read(p []byte) (int, error) {
+	if len(c.chunks) == 0 {
+		return 0, io.EOF
+	}
+	chunk := c.chunks[0]
+	if chunk == nil {
+		c.chunks = c.chunks[1:]
+		return 0, timeoutError{}
+	}
+	n := copy(p, chunk)
+	if c.chunks[0] = chunk[n:]; len(c.chunks[0]) == 0 {
+		c.chunks = c.chunks[1:]
+	}
+	return n, nil
+}
+
+func testSecureConnKeys() (client, server [2]*[32]byte) {
+	var key [32]byte
+	for i := range key {
+		key[i] = byte(i)
+	}
+	client[0], client[1] = SecureConnKeys(&key, true)
+	server[0], server[1] = SecureConnKeys(&key, false)
+	return
+}
+
+func TestSecureConnKeys(t *testing.T) {
+	client, server := testSecureConnKeys()
+	if *client[0] != *server[1] || *client[1] != *server[0] || *client[0] == *client[1] {
+		t.Fatal("SecureConnKeys doesn't derive matching per-direction keys")
+	}
+}
+
+func TestSecureConnPipe(t *testing.T) {
+	clientKeys, serverKeys := testSecureConnKeys()
+	a, b := net.Pipe()
+	client := NewSecureConn(a, clientKeys[0], clientKeys[1], nil)
+	server := NewSecureConn(b, serverKeys[0], serverKeys[1], nil)
+
+	data := make([]byte, 3*MaxSecureConnRecordSize+100)
+	for i := range data {
+		data[i] = byte(i * 7)
+	}
+	done := make(chan error, 1)
+	go func() {
+		// echo everything back to the client
+		buf := make([]byte, 1000)
+		for {
+			n, err := server.Read(buf)
+			if err != nil {
+				done <- err
+				return
+			}
+			if _, err = server.Write(buf[:n]); err != nil {
+				done <- err
+				return
+			}
+		}
+	}()
+
+	received := make([]byte, 0, len(data))
+	go func() {
+		buf := make([]byte, 333)
+		for len(received) < len(data) {
+			n, err := client.Read(buf)
+			if err != nil {
+				done <- err
+				return
+			}
+			received = append(received, buf[:n]...)
+		}
+		done <- nil
+	}()
+	if n, err := client.Write(data); err != nil || n != len(data) {
+		t.Fatalf("Write failed: %d %v", n, err)
+	}
+	if err := <-done; err != nil {
+		t.Fatalf("Read failed: %v", err)
+	}
+	if !bytes.Equal(received, data) {
+		t.Fatal("received data differs from the sent data")
+	}
+	client.Close()
+	if err := <-done; err != io.EOF {
+		t.Fatalf("server: expected %v, got %v", io.EOF, err)
+	}
+}
+
+func TestSecureConnPartialReads(t *testing.T) {
+	clientKeys, serverKeys := testSecureConnKeys()
+	sender := &scriptedConn{}
+	client := NewSecureConn(sender, clientKeys[0], clientKeys[1], nil)
+	data := make([]byte, MaxSecureConnRecordSize+1000)
+	for i := range data {
+		data[i] = byte(i)
+	}
+	client.Write([]byte("Hello"))
+	client.Write(nil)
+	client.Write(data)
+	records := sender.written.Bytes()
+
+	receiver := &scriptedConn{}
+	for len(records) > 0 {
+		n := 7
+		if n > len(records) {
+			n = len(records)
+		}
+		receiver.chunks = append(receiver.chunks, records[:n], nil)
+		records = records[n:]
+	}
+	server := NewSecureConn(receiver, serverKeys[0], serverKeys[1], nil)
+
+	var received []byte
+	buf := make([]byte, 100)
+	for {
+		n, err := server.Read(buf)
+		received = append(received, buf[:n]...)
+		if err == io.EOF {
+			break
+		}
+		if err != nil && err != (timeoutError{}) {
+			t.Fatalf("Read failed: %v", err)
+		}
+	}
+	if !bytes.Equal(received, append([]byte("Hello"), data...)) {
+		t.Fatal("received data differs from the sent data")
+	}
+}
+
+func TestSecureConnTampering(t *testing.T) {
+	clientKeys, serverKeys := testSecureConnKeys()
+	sender := &scriptedConn{}
+	client := NewSecureConn(sender, clientKeys[0], clientKeys[1], nil)
+	client.Write([]byte("first record"))
+	client.Write([]byte("second record"))
+	records := sender.written.Bytes()
+
+	modified := append([]byte(nil), records...)
+	modified[len(modified)-1] ^= 1
+	server := NewSecureConn(&scriptedConn{chunks: [][]byte{modified}}, serverKeys[0], serverKeys[1], nil)
+	buf := make([]byte, 100)
+	if n, err := server.Read(buf); err != nil || string(buf[:n]) != "first record" {
+		t.Fatalf("Read failed: %v", err)
+	}
+	if _, err := server.Read(buf); err != errAuthFailed {
+		t.Fatalf("expected %v, got %v", errAuthFailed, err)
+	}
+	if _, err := server.Read(buf); err != errAuthFailed {
+		t.Fatalf("Read error is not permanent: %v", err)
+	}
+
+	// Swapping the records changes their sequence numbers.
+	n := secureConnHeaderSize + len("first record") + TagSize
+	swapped := append(append([]byte(nil), records[n:]...), records[:n]...)
+	server = NewSecureConn(&scriptedConn{chunks: [][]byte{swapped}}, serverKeys[0], serverKeys[1], nil)
+	if _, err := server.Read(buf); err != errAuthFailed {
+		t.Fatalf("expected %v, got %v", errAuthFailed, err)
+	}
+
+	server = NewSecureConn(&scriptedConn{chunks: [][]byte{records[:n-1]}}, serverKeys[0], serverKeys[1], nil)
+	if _, err := server.Read(buf); err != io.ErrUnexpectedEOF {
+		t.Fatalf("expected %v, got %v", io.ErrUnexpectedEOF, err)
+	}
+	server = NewSecureConn(&scriptedConn{chunks: [][]byte{{0, 1}}}, serverKeys[0], serverKeys[1], nil)
+	if _, err := server.Read(buf); err != errSecureConnRecord {
+		t.Fatalf("expected %v, got %v", errSecureConnRecord, err)
+	}
+}
@@ -0,0 +1,195 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package chacha20
+
+import (
+	"github.com/aead/chacha20/chacha"
+	"github.com/aead/chacha20/internal/argerr"
+	"github.com/aead/poly1305"
+)
+
+// SegmentAEAD is implemented by all cipher.AEAD implementations returned by
+// this package. It takes the additional data as a list of segments - for
+// example the fields of a protocol header - so they don't have to be
+// concatenated into a temporary buffer.
+//
+// The segments are authenticated as the additional data returned by
+// FrameAdditionalData. Since every segment is prefixed with its length
+// different lists of segments never produce the same additional data.
+// So SealSegments(dst, nonce, plaintext, segments) is equal to
+// Seal(dst, nonce, plaintext, FrameAdditionalData(segments)).
+type SegmentAEAD interface {
+	// SealSegments is like Seal but takes the additional data as segments.
+	SealSegments(dst, nonce, plaintext []byte, additionalData [][]byte) []byte
+
+	// OpenSegments is like Open but takes the additional data as segments.
+	OpenSegments(dst, nonce, ciphertext []byte, additionalData [][]byte) ([]byte, error)
+}
+
+// FrameAdditionalData returns the additional data authenticated by
+// SealSegments and OpenSegments: every segment is prefixed with its
+// length as 64 bit little endian integer.
+func FrameAdditionalData(segments [][]byte) []byte {
+	n := 0
+	for _, s := range segments {
+		n += 8 + len(s)
+	}
+	data := make([]byte, 0, n)
+	for _, s := range segments {
+		length := segmentLength(len(s))
+		data = append(data, length[:]...)
+		data = append(data, s...)
+	}
+	return data
+}
+
+func segmentLength(n int) (length [8]byte) {
+	for i, v := 0, uint64(n); i < 8; i++ {
+		length[i] = byte(v)
+		v >>= 8
+	}
+	return
+}
+
+func (c *aead) SealSegments(dst, nonce, plaintext []byte, additionalData [][]byte) []byte {
+	if n := len(nonce); n != c.NonceSize() {
+		panic(argerr.Error("chacha20: nonce size is invalid"))
+	}
+	if c.key.wiped {
+		panic(errKeyWiped)
+	}
+	if exceedsPlaintextSize(len(plaintext), 0) {
+		panic(ErrMessageTooLarge)
+	}
+	countSeal(len(plaintext))
+	engine := c.engine()
+	ret := c.sealSegments(engine, dst, nonce, plaintext, additionalData)
+	c.key.release(&c.key.engines, engine)
+	return ret
+}
+
+func (c *aead) OpenSegments(dst, nonce, ciphertext []byte, additionalData [][]byte) ([]byte, error) {
+	if n := len(nonce); n != c.NonceSize() {
+		return nil, errInvalidNonceSize
+	}
+	if c.key.wiped {
+		return nil, errKeyWiped
+	}
+	if exceedsPlaintextSize(len(ciphertext), c.tagsize) {
+		return nil, ErrMessageTooLarge
+	}
+	engine := c.engine()
+	ret, err := c.openSegments(engine, dst, nonce, ciphertext, additionalData)
+	c.key.release(&c.key.engines, engine)
+	countOpen(len(ciphertext)-c.tagsize, err)
+	return ret, err
+}
+
+func (c *xaead) SealSegments(dst, nonce, plaintext []byte, additionalData [][]byte) []byte {
+	if n := len(nonce); n != NonceSizeX {
+		panic(argerr.Error("chacha20: nonce size is invalid"))
+	}
+	if c.key.wiped {
+		panic(errKeyWiped)
+	}
+	if exceedsPlaintextSize(len(plaintext), 0) {
+		panic(ErrMessageTooLarge)
+	}
+	countSeal(len(plaintext))
+	var subNonce [NonceSize]byte
+	engine := c.subCipher(&subNonce, nonce)
+	sub := aead{tagsize: c.tagsize}
+	ret := sub.sealSegments(engine, dst, subNonce[:], plaintext, additionalData)
+	c.key.release(&c.key.subEngines, engine)
+	return ret
+}
+
+func (c *xaead) OpenSegments(dst, nonce, ciphertext []byte, additionalData [][]byte) ([]byte, error) {
+	if n := len(nonce); n != NonceSizeX {
+		return nil, errInvalidNonceSize
+	}
+	if c.key.wiped {
+		return nil, errKeyWiped
+	}
+	if exceedsPlaintextSize(len(ciphertext), c.tagsize) {
+		return nil, ErrMessageTooLarge
+	}
+	var subNonce [NonceSize]byte
+	engine := c.subCipher(&subNonce, nonce)
+	sub := aead{tagsize: c.tagsize}
+	ret, err := sub.openSegments(engine, dst, subNonce[:], ciphertext, additionalData)
+	c.key.release(&c.key.subEngines, engine)
+	countOpen(len(ciphertext)-c.tagsize, err)
+	return ret, err
+}
+
+// sealSegments is like seal but authenticates the framed segments.
+func (c *aead) sealSegments(engine *chacha.Cipher, dst, nonce, plaintext []byte, segments [][]byte) []byte {
+	var polyKey [32]byte
+	c.polyKey(engine, &polyKey, nonce)
+
+	n := len(plaintext)
+	ret, ciphertext := sliceForAppend(dst, n+c.tagsize)
+	engine.XORKeyStream(ciphertext, plaintext)
+
+	var tag [poly1305.TagSize]byte
+	c.authenticateSegments(&tag, ciphertext[:n], segments, &polyKey)
+	copy(ciphertext[n:], tag[:c.tagsize])
+	return ret
+}
+
+// openSegments is like open but authenticates the framed segments.
+func (c *aead) openSegments(engine *chacha.Cipher, dst, nonce, ciphertext []byte, segments [][]byte) ([]byte, error) {
+	if len(ciphertext) < c.tagsize {
+		return nil, errAuthFailed
+	}
+	var polyKey [32]byte
+	c.polyKey(engine, &polyKey, nonce)
+
+	n := len(ciphertext) - c.tagsize
+	var sum [poly1305.TagSize]byte
+	c.authenticateSegments(&sum, ciphertext[:n], segments, &polyKey)
+	if !checkTag(&sum, ciphertext[n:], c.tagsize) {
+		return nil, errAuthFailed
+	}
+
+	ret, plaintext := sliceForAppend(dst, n)
+	engine.XORKeyStream(plaintext, ciphertext[:n])
+	return ret, nil
+}
+
+// authenticateSegments computes the poly1305 tag of the ciphertext and the
+// framed segments like authenticate - or authenticateLegacy - without
+// concatenating the segments.
+func (c *aead) authenticateSegments(out *[TagSize]byte, ciphertext []byte, segments [][]byte, key *[32]byte) {
+	var zeros [TagSize]byte
+	poly := poly1305.New(key)
+	adLen := 0
+	for _, s := range segments {
+		length := segmentLength(len(s))
+		poly.Write(length[:])
+		poly.Write(s)
+		adLen += len(length) + len(s)
+	}
+	if c.legacy {
+		adLength, ctLength := segmentLength(adLen), segmentLength(len(ciphertext))
+		poly.Write(adLength[:])
+		poly.Write(ciphertext)
+		poly.Write(ctLength[:])
+		poly.Sum(out)
+		return
+	}
+
+	if r := adLen % TagSize; r != 0 {
+		poly.Write(zeros[:TagSize-r])
+	}
+	poly.Write(ciphertext)
+	if r := len(ciphertext) % TagSize; r != 0 {
+		poly.Write(zeros[:TagSize-r])
+	}
+	lengths := aeadLengths(adLen, len(ciphertext))
+	poly.Write(lengths[:])
+	poly.Sum(out)
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package chacha20
+
+import (
+	"bytes"
+	"crypto/cipher"
+	"testing"
+)
+
+func TestFrameAdditionalData(t *testing.T) {
+	framed := FrameAdditionalData([][]byte{[]byte("ab"), nil, []byte("c")})
+	want := []byte{2, 0, 0, 0, 0, 0, 0, 0, 'a', 'b', 0, 0, 0, 0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 'c'}
+	if !bytes.Equal(framed, want) {
+		t.Fatalf("FrameAdditionalData: got %x want %x", framed, want)
+	}
+	if len(FrameAdditionalData(nil)) != 0 {
+		t.Fatal("FrameAdditionalData of no segments is not empty")
+	}
+	if bytes.Equal(FrameAdditionalData([][]byte{[]byte("ab"), []byte("c")}), FrameAdditionalData([][]byte{[]byte("a"), []byte("bc")})) {
+		t.Fatal("FrameAdditionalData is ambiguous")
+	}
+}
+
+func TestSegmentAEAD(t *testing.T) {
+	var key [32]byte
+	for i := range key {
+		key[i] = byte(i)
+	}
+	shortTag, _ := NewChaCha20Poly1305WithTagSize(&key, 12)
+	aeads := map[string]cipher.AEAD{
+		"ChaCha20Poly1305":       NewChaCha20Poly1305(&key),
+		"ChaCha20Poly1305-12":    shortTag,
+		"XChaCha20Poly1305":      NewXChaCha20Poly1305(&key),
+		"ChaCha20Poly1305Legacy": NewChaCha20Poly1305Legacy(&key),
+	}
+	segmentLists := [][][]byte{
+		nil,
+		{nil},
+		{[]byte("header")},
+		{[]byte("version"), nil, bytes.Repeat([]byte{1}, 31), []byte("x")},
+		{bytes.Repeat([]byte{2}, 300), []byte("trailer")},
+	}
+	for name, c := range aeads {
+		s, ok := c.(SegmentAEAD)
+		if !ok {
+			t.Fatalf("%s does not implement SegmentAEAD", name)
+		}
+		nonce := make([]byte, c.NonceSize())
+		for _, size := range []int{0, 1, 15, 16, 64, 65, 200, 1000} {
+			plaintext := bytes.Repeat([]byte{byte(size)}, size)
+			for i, segments := range segmentLists {
+				sealed := s.SealSegments([]byte("prefix"), nonce, plaintext, segments)
+				want := c.Seal([]byte("prefix"), nonce, plaintext, FrameAdditionalData(segments))
+				if !bytes.Equal(sealed, want) {
+					t.Fatalf("%s: size %d, segments %d: SealSegments differs from Seal", name, size, i)
+				}
+				opened, err := s.OpenSegments(nil, nonce, sealed[len("prefix"):], segments)
+				if err != nil || !bytes.Equal(opened, plaintext) {
+					t.Fatalf("%s: size %d, segments %d: OpenSegments failed: %v", name, size, i, err)
+				}
+				if _, err = s.OpenSegments(nil, nonce, sealed[len("prefix"):], append(segments, nil)); err == nil {
+					t.Fatalf("%s: size %d, segments %d: OpenSegments accepted an additional segment", name, size, i)
+				}
+			}
+		}
+		if _, err := s.OpenSegments(nil, nonce, make([]byte, c.Overhead()-1), nil); err == nil {
+			t.Fatalf("%s: OpenSegments accepted a too short ciphertext", name)
+		}
+	}
+}
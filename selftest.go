@@ -0,0 +1,235 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package chacha20
+
+import (
+	"bytes"
+	"crypto/cipher"
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/aead/chacha20/chacha"
+)
+
+//go:generate go run selftest_gen.go
+
+// selfTestVector is an AEAD test vector in the layout of the Wycheproof
+// AEAD test groups. Vectors which are not valid must be rejected by Open.
+type selfTestVector struct {
+	name                 string
+	xchacha              bool
+	key, nonce, aad      string
+	msg, ciphertext, tag string
+	valid                bool
+}
+
+// selfTestVectors are the ChaCha20Poly1305 and XChaCha20Poly1305 known-answer
+// vectors checked by SelfTest.
+var selfTestVectors = []selfTestVector{
+	{
+		name: "RFC 8439 2.8.2",
+		key: "808182838485868788898a8b8c8d8e8f" +
+			"909192939495969798999a9b9c9d9e9f",
+		nonce: "070000004041424344454647",
+		aad:   "50515253c0c1c2c3c4c5c6c7",
+		msg: "4c616469657320616e642047656e746c656d656e206f662074686520636c6173" +
+			"73206f66202739393a204966204920636f756c64206f6666657220796f75206f" +
+			"6e6c79206f6e652074697020666f7220746865206675747572652c2073756e73" +
+			"637265656e20776f756c642062652069742e",
+		ciphertext: "d31a8d34648e60db7b86afbc53ef7ec2a4aded51296e08fea9e2b5a736ee62d6" +
+			"3dbea45e8ca9671282fafb69da92728b1a71de0a9e060b2905d6a5b67ecd3b36" +
+			"92ddbd7f2d778b8c9803aee328091b58fab324e4fad675945585808b4831d7bc" +
+			"3ff4def08e4b7a9de576d26586cec64b6116",
+		tag:   "1ae10b594f09e26a7e902ecbd0600691",
+		valid: true,
+	},
+	{
+		name: "RFC 8439 2.8.2 modified tag",
+		key: "808182838485868788898a8b8c8d8e8f" +
+			"909192939495969798999a9b9c9d9e9f",
+		nonce: "070000004041424344454647",
+		aad:   "50515253c0c1c2c3c4c5c6c7",
+		msg: "4c616469657320616e642047656e746c656d656e206f662074686520636c6173" +
+			"73206f66202739393a204966204920636f756c64206f6666657220796f75206f" +
+			"6e6c79206f6e652074697020666f7220746865206675747572652c2073756e73" +
+			"637265656e20776f756c642062652069742e",
+		ciphertext: "d31a8d34648e60db7b86afbc53ef7ec2a4aded51296e08fea9e2b5a736ee62d6" +
+			"3dbea45e8ca9671282fafb69da92728b1a71de0a9e060b2905d6a5b67ecd3b36" +
+			"92ddbd7f2d778b8c9803aee328091b58fab324e4fad675945585808b4831d7bc" +
+			"3ff4def08e4b7a9de576d26586cec64b6116",
+		tag:   "1ae10b594f09e26a7e902ecbd0600611",
+		valid: false,
+	},
+	{
+		name:    "draft-irtf-cfrg-xchacha-01 A.3.1",
+		xchacha: true,
+		key: "808182838485868788898a8b8c8d8e8f" +
+			"909192939495969798999a9b9c9d9e9f",
+		nonce: "404142434445464748494a4b4c4d4e4f5051525354555657",
+		aad:   "50515253c0c1c2c3c4c5c6c7",
+		msg: "4c616469657320616e642047656e746c656d656e206f662074686520636c6173" +
+			"73206f66202739393a204966204920636f756c64206f6666657220796f75206f" +
+			"6e6c79206f6e652074697020666f7220746865206675747572652c2073756e73" +
+			"637265656e20776f756c642062652069742e",
+		ciphertext: "bd6d179d3e83d43b9576579493c0e939572a1700252bfaccbed2902c21396cbb" +
+			"731c7f1b0b4aa6440bf3a82f4eda7e39ae64c6708c54c216cb96b72e1213b452" +
+			"2f8c9ba40db5d945b11b69b982c1bb9e3f3fac2bc369488f76b2383565d3fff9" +
+			"21f9664c97637da9768812f615c68b13b52e",
+		tag:   "c0875924c1c7987947deafd8780acf49",
+		valid: true,
+	},
+	{
+		name:    "draft-irtf-cfrg-xchacha-01 A.3.1 modified ciphertext",
+		xchacha: true,
+		key: "808182838485868788898a8b8c8d8e8f" +
+			"909192939495969798999a9b9c9d9e9f",
+		nonce: "404142434445464748494a4b4c4d4e4f5051525354555657",
+		aad:   "50515253c0c1c2c3c4c5c6c7",
+		msg: "4c616469657320616e642047656e746c656d656e206f662074686520636c6173" +
+			"73206f66202739393a204966204920636f756c64206f6666657220796f75206f" +
+			"6e6c79206f6e652074697020666f7220746865206675747572652c2073756e73" +
+			"637265656e20776f756c642062652069742e",
+		ciphertext: "bc6d179d3e83d43b9576579493c0e939572a1700252bfaccbed2902c21396cbb" +
+			"731c7f1b0b4aa6440bf3a82f4eda7e39ae64c6708c54c216cb96b72e1213b452" +
+			"2f8c9ba40db5d945b11b69b982c1bb9e3f3fac2bc369488f76b2383565d3fff9" +
+			"21f9664c97637da9768812f615c68b13b52e",
+		tag:   "c0875924c1c7987947deafd8780acf49",
+		valid: false,
+	},
+}
+
+// selfTestLongVectors are the SHA-256 digests of the ciphertexts of long
+// messages produced by the Generic backend. The short vectors are crypted
+// by the generic code on most platforms - the long messages pass the SIMD
+// code of the active backend.
+var selfTestLongVectors = []struct {
+	name    string
+	xchacha bool
+	size    int
+	digest  string
+}{
+	{
+		name: "ChaCha20Poly1305 1 block", size: 64,
+		digest: "a15554e9ec5909b5707dcbcaca671527cabf8a16171d972b3c8bda57f023da75",
+	},
+	{
+		name: "ChaCha20Poly1305 4 KiB + 17", size: 4*1024 + 17,
+		digest: "1167f011fb34808e78692b1f2fd3b50dc748a80e7968d09fe5ed42b07b152aeb",
+	},
+	{
+		name: "ChaCha20Poly1305 128 KiB + 63", size: 128*1024 + 63,
+		digest: "abf3a9b4617b18e9e7a17f770d730e9e12d68e00c58347fc421a571717462bd2",
+	},
+	{
+		name: "XChaCha20Poly1305 128 KiB + 1", xchacha: true, size: 128*1024 + 1,
+		digest: "308215cfcb5ab2b94b4310c12a0915a19e9f86b6aacfdd52ca848dd6456b2ceb",
+	},
+}
+
+// SelfTestError is returned by SelfTest if a known-answer vector fails.
+type SelfTestError struct {
+	Vector  string         // The name of the failing vector.
+	Backend chacha.Backend // The backend which produced the wrong result.
+}
+
+func (e *SelfTestError) Error() string {
+	return "chacha20: self test vector \"" + e.Vector + "\" failed using the " + e.Backend.String() + " backend"
+}
+
+// SelfTest crypts known-answer vectors - including all Wycheproof vectors -
+// using the active backend of the chacha package and returns a
+// *SelfTestError if the backend produces a wrong result or accepts an
+// invalid vector. It verifies that ChaCha20Poly1305 and XChaCha20Poly1305
+// work on the running CPU - e.g. after selecting a backend using
+// chacha.SetBackend. SelfTest is safe for concurrent use.
+func SelfTest() error {
+	backend := chacha.ActiveBackend()
+	for _, vectors := range [][]selfTestVector{selfTestVectors, wycheproofVectors} {
+		for i := range vectors {
+			if !checkSelfTestVector(&vectors[i]) {
+				return &SelfTestError{Vector: vectors[i].name, Backend: backend}
+			}
+		}
+	}
+	for _, v := range selfTestLongVectors {
+		if hex.EncodeToString(selfTestLongDigest(v.xchacha, v.size)) != v.digest {
+			return &SelfTestError{Vector: v.name, Backend: backend}
+		}
+	}
+	return nil
+}
+
+func checkSelfTestVector(v *selfTestVector) bool {
+	var key [32]byte
+	copy(key[:], mustHex(v.key))
+	nonce, aad, msg := mustHex(v.nonce), mustHex(v.aad), mustHex(v.msg)
+	ciphertext := append(mustHex(v.ciphertext), mustHex(v.tag)...)
+
+	c := selfTestAEAD(&key, v.xchacha)
+	plaintext, err := c.Open(nil, nonce, ciphertext, aad)
+	if !v.valid {
+		return err != nil
+	}
+	if err != nil || !bytes.Equal(plaintext, msg) {
+		return false
+	}
+	return bytes.Equal(c.Seal(nil, nonce, msg, aad), ciphertext)
+}
+
+// selfTestLongDigest returns the SHA-256 digest of the sealed message of
+// the given size. The key, nonce, message and additional data are counting
+// byte sequences.
+func selfTestLongDigest(xchacha bool, size int) []byte {
+	var key [32]byte
+	for i := range key {
+		key[i] = byte(i)
+	}
+	c := selfTestAEAD(&key, xchacha)
+	nonce := make([]byte, c.NonceSize())
+	for i := range nonce {
+		nonce[i] = byte(0x40 + i)
+	}
+	msg := make([]byte, size)
+	for i := range msg {
+		msg[i] = byte(i % 251)
+	}
+	sum := sha256.Sum256(c.Seal(nil, nonce, msg, key[:13]))
+	return sum[:]
+}
+
+func selfTestAEAD(key *[32]byte, xchacha bool) cipher.AEAD {
+	if xchacha {
+		return NewXChaCha20Poly1305(key)
+	}
+	return NewChaCha20Poly1305(key)
+}
+
+func mustHex(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// powerOnSelfTest runs SelfTest and selects the Generic backend and the
+// scalar Poly1305 implementation if the active implementations fail. It
+// panics if the fallback fails, too. It returns the error of the failed
+// implementations.
+func powerOnSelfTest() error {
+	err := selfTest()
+	if err == nil {
+		return nil
+	}
+	chacha.SetBackend(chacha.Generic)
+	useVectorMAC = false
+	if err := selfTest(); err != nil {
+		panic(err)
+	}
+	return err
+}
+
+// selfTest is called by powerOnSelfTest. Tests replace it to inject failures.
+var selfTest = SelfTest
@@ -0,0 +1,85 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+// +build ignore
+
+// This program generates selftest_wycheproof.go from the Wycheproof test
+// vectors in testdata/wycheproof. Run it with go generate.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"log"
+	"path/filepath"
+)
+
+type testFile struct {
+	TestGroups []struct {
+		Tests []struct {
+			TcID    int    `json:"tcId"`
+			Comment string `json:"comment"`
+			Key     string `json:"key"`
+			IV      string `json:"iv"`
+			AAD     string `json:"aad"`
+			Msg     string `json:"msg"`
+			CT      string `json:"ct"`
+			Tag     string `json:"tag"`
+			Result  string `json:"result"`
+		} `json:"tests"`
+	} `json:"testGroups"`
+}
+
+func main() {
+	var buf bytes.Buffer
+	buf.WriteString(`// Code generated by go run selftest_gen.go. DO NOT EDIT.
+
+package chacha20
+
+// wycheproofVectors are the ChaCha20Poly1305 and XChaCha20Poly1305 test
+// vectors of Project Wycheproof (testdata/wycheproof) checked by SelfTest.
+var wycheproofVectors = []selfTestVector{
+`)
+	for _, f := range []struct {
+		name, file string
+		xchacha    bool
+	}{
+		{"ChaCha20Poly1305", "chacha20_poly1305_test.json", false},
+		{"XChaCha20Poly1305", "xchacha20_poly1305_test.json", true},
+	} {
+		data, err := ioutil.ReadFile(filepath.Join("testdata", "wycheproof", f.file))
+		if err != nil {
+			log.Fatal(err)
+		}
+		var vectors testFile
+		if err = json.Unmarshal(data, &vectors); err != nil {
+			log.Fatalf("%s: %v", f.file, err)
+		}
+		for _, g := range vectors.TestGroups {
+			for _, t := range g.Tests {
+				if t.Result != "valid" && t.Result != "invalid" {
+					log.Fatalf("%s: test %d has the unexpected result %q", f.file, t.TcID, t.Result)
+				}
+				name := fmt.Sprintf("Wycheproof %s %d", f.name, t.TcID)
+				if t.Comment != "" {
+					name += " - " + t.Comment
+				}
+				fmt.Fprintf(&buf, "{name: %q, xchacha: %v, key: %q, nonce: %q, aad: %q, msg: %q, ciphertext: %q, tag: %q, valid: %v},\n",
+					name, f.xchacha, t.Key, t.IV, t.AAD, t.Msg, t.CT, t.Tag, t.Result == "valid")
+			}
+		}
+	}
+	buf.WriteString("}\n")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err = ioutil.WriteFile("selftest_wycheproof.go", src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
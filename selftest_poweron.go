@@ -0,0 +1,12 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+// +build chacha20_selftest
+
+package chacha20
+
+// If built with the chacha20_selftest tag the package runs SelfTest
+// on startup and falls back to the Generic backend if the selected
+// backend fails. If the Generic backend fails, too, the program panics.
+func init() { powerOnSelfTest() }
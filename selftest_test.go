@@ -0,0 +1,147 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package chacha20
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/aead/chacha20/chacha"
+)
+
+func TestSelfTest(t *testing.T) {
+	defer chacha.SetBackend(chacha.ActiveBackend())
+
+	for b := chacha.Generic; b <= chacha.SIMD128; b++ {
+		if err := chacha.SetBackend(b); err != nil {
+			continue
+		}
+		if err := SelfTest(); err != nil {
+			t.Fatalf("SelfTest failed: %v", err)
+		}
+	}
+}
+
+func TestSelfTestFailure(t *testing.T) {
+	v := &selfTestLongVectors[0]
+	defer func(digest string) { v.digest = digest }(v.digest)
+	v.digest = v.digest[1:] + v.digest[:1]
+
+	err, ok := SelfTest().(*SelfTestError)
+	if !ok {
+		t.Fatalf("SelfTest accepted a wrong digest")
+	}
+	if err.Vector != v.name || err.Backend != chacha.ActiveBackend() {
+		t.Fatalf("SelfTest reports unexpected failure: %v", err)
+	}
+}
+
+func TestSelfTestInvalidVector(t *testing.T) {
+	var v *selfTestVector
+	for i := range wycheproofVectors {
+		if !wycheproofVectors[i].valid {
+			v = &wycheproofVectors[i]
+			break
+		}
+	}
+	v.valid = true
+	defer func() { v.valid = false }()
+
+	err, ok := SelfTest().(*SelfTestError)
+	if !ok {
+		t.Fatalf("SelfTest accepted an invalid vector")
+	}
+	if err.Vector != v.name {
+		t.Fatalf("SelfTest reports unexpected failure: %v", err)
+	}
+}
+
+func TestWycheproofVectors(t *testing.T) {
+	type testFile struct {
+		TestGroups []struct {
+			Tests []struct {
+				Key, IV, AAD, Msg, CT, Tag, Result string
+			}
+		}
+	}
+
+	var n int
+	for _, f := range []string{"chacha20_poly1305_test.json", "xchacha20_poly1305_test.json"} {
+		data, err := ioutil.ReadFile(filepath.Join("testdata", "wycheproof", f))
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", f, err)
+		}
+		var vectors testFile
+		if err = json.Unmarshal(data, &vectors); err != nil {
+			t.Fatalf("Failed to parse %s: %v", f, err)
+		}
+		for _, g := range vectors.TestGroups {
+			for _, v := range g.Tests {
+				if n >= len(wycheproofVectors) {
+					t.Fatalf("wycheproofVectors is missing vectors of %s - run go generate", f)
+				}
+				w := wycheproofVectors[n]
+				if w.key != v.Key || w.nonce != v.IV || w.aad != v.AAD || w.msg != v.Msg ||
+					w.ciphertext != v.CT || w.tag != v.Tag || w.valid != (v.Result == "valid") {
+					t.Fatalf("Vector %d: %s doesn't match %s - run go generate", n, w.name, f)
+				}
+				n++
+			}
+		}
+	}
+	if n != len(wycheproofVectors) {
+		t.Fatalf("wycheproofVectors contains %d vectors - want %d", len(wycheproofVectors), n)
+	}
+}
+
+func TestPowerOnSelfTest(t *testing.T) {
+	backend := chacha.ActiveBackend()
+	defer chacha.SetBackend(backend)
+	defer func(v bool) { useVectorMAC = v }(useVectorMAC)
+
+	if err := powerOnSelfTest(); err != nil || chacha.ActiveBackend() != backend {
+		t.Fatalf("powerOnSelfTest failed: %v - backend: %v", err, chacha.ActiveBackend())
+	}
+
+	v := &selfTestLongVectors[0]
+	defer func(digest string) { v.digest = digest }(v.digest)
+	v.digest = v.digest[1:] + v.digest[:1]
+	defer func() {
+		if _, ok := recover().(*SelfTestError); !ok {
+			t.Fatalf("powerOnSelfTest didn't panic with a SelfTestError")
+		}
+		if b := chacha.ActiveBackend(); b != chacha.Generic {
+			t.Fatalf("powerOnSelfTest didn't select the Generic backend: %v", b)
+		}
+	}()
+	powerOnSelfTest()
+}
+
+func TestPowerOnSelfTestVectorMAC(t *testing.T) {
+	backend := chacha.ActiveBackend()
+	defer chacha.SetBackend(backend)
+	defer func(v bool) { useVectorMAC = v }(useVectorMAC)
+	defer func() { selfTest = SelfTest }()
+
+	// Simulate a vector Poly1305 implementation which computes wrong tags.
+	useVectorMAC = true
+	selfTest = func() error {
+		if useVectorMAC {
+			return &SelfTestError{Vector: "vector MAC", Backend: chacha.ActiveBackend()}
+		}
+		return SelfTest()
+	}
+	if err := powerOnSelfTest(); err == nil {
+		t.Fatalf("powerOnSelfTest ignored the failing MAC")
+	}
+	if useVectorMAC {
+		t.Fatalf("powerOnSelfTest didn't disable the vector MAC")
+	}
+	if b := chacha.ActiveBackend(); b != chacha.Generic {
+		t.Fatalf("powerOnSelfTest didn't select the Generic backend: %v", b)
+	}
+}
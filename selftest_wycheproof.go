@@ -0,0 +1,592 @@
+// Code generated by go run selftest_gen.go. DO NOT EDIT.
+
+package chacha20
+
+// wycheproofVectors are the ChaCha20Poly1305 and XChaCha20Poly1305 test
+// vectors of Project Wycheproof (testdata/wycheproof) checked by SelfTest.
+var wycheproofVectors = []selfTestVector{
+	{name: "Wycheproof ChaCha20Poly1305 1 - RFC 7539", xchacha: false, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "070000004041424344454647", aad: "50515253c0c1c2c3c4c5c6c7", msg: "4c616469657320616e642047656e746c656d656e206f662074686520636c617373206f66202739393a204966204920636f756c64206f6666657220796f75206f6e6c79206f6e652074697020666f7220746865206675747572652c2073756e73637265656e20776f756c642062652069742e", ciphertext: "d31a8d34648e60db7b86afbc53ef7ec2a4aded51296e08fea9e2b5a736ee62d63dbea45e8ca9671282fafb69da92728b1a71de0a9e060b2905d6a5b67ecd3b3692ddbd7f2d778b8c9803aee328091b58fab324e4fad675945585808b4831d7bc3ff4def08e4b7a9de576d26586cec64b6116", tag: "1ae10b594f09e26a7e902ecbd0600691", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 2", xchacha: false, key: "80ba3192c803ce965ea371d5ff073cf0f43b6a2ab576b208426e11409c09b9b0", nonce: "4da5bf8dfd5852c1ea12379d", aad: "", msg: "", ciphertext: "", tag: "76acb342cf3166a5b63c0c0ea1383c8d", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 3", xchacha: false, key: "7a4cd759172e02eb204db2c3f5c746227df584fc1345196391dbb9577a250742", nonce: "a92ef0ac991dd516a3c6f689", aad: "bd506764f2d2c410", msg: "", ciphertext: "", tag: "906fa6284b52f87b7359cbaa7563c709", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 4", xchacha: false, key: "cc56b680552eb75008f5484b4cb803fa5063ebd6eab91f6ab6aef4916a766273", nonce: "99e23ec48985bccdeeab60f1", aad: "", msg: "2a", ciphertext: "3a", tag: "cac27dec0968801e9f6eded69d807522", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 5", xchacha: false, key: "46f0254965f769d52bdb4a70b443199f8ef207520d1220c55e4b70f0fda620ee", nonce: "ab0dca716ee051d2782f4403", aad: "91ca6c592cbcca53", msg: "51", ciphertext: "c4", tag: "168310ca45b1f7c66cad4e99e43f72b9", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 6", xchacha: false, key: "2f7f7e4f592bb389194989743507bf3ee9cbde1786b6695fe6c025fd9ba4c100", nonce: "461af122e9f2e0347e03f2db", aad: "", msg: "5c60", ciphertext: "4d13", tag: "91e8b61efb39c122195453077b22e5e2", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 7", xchacha: false, key: "c8833dce5ea9f248aa2030eacfe72bffe69a620caf793344e5718fe0d7ab1a58", nonce: "61546ba5f1720590b6040ac6", aad: "88364fc8060518bf", msg: "ddf2", ciphertext: "b60d", tag: "ead0fd4697ec2e5558237719d02437a2", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 8", xchacha: false, key: "bd8ed7fb0d607522f04d0b12d42c92570bccc5ba2486953d70ba2e8193f6225a", nonce: "d2ab0abb50a8e9fba25429e1", aad: "", msg: "201221", ciphertext: "3cf470", tag: "a27a69c9d7ee84586f11388c6884e63a", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 9", xchacha: false, key: "1c8b59b17a5ceced31bde97d4cefd9aaaa63362e096e863ec1c89580bca79b7a", nonce: "94f32a6dff588f2b5a2ead45", aad: "6c8cf2ab3820b695", msg: "453f95", ciphertext: "610925", tag: "a8a7883eb7e40bc40e2e5922ae95ddc3", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 10", xchacha: false, key: "e4912cb75a1174345f1a457366f18885fe8460b06478e04be2f7fb4ec9c113e5", nonce: "7aa5ad8bf5254762171ec869", aad: "", msg: "9e4c1d03", ciphertext: "fe6849aa", tag: "99ad07871b25c27defc31a541bd5c418", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 11", xchacha: false, key: "e05777ef3d989ace7d2abfba452bfded54801dbd5c66e91c0c2ef00479d85572", nonce: "b7f526e3fd71cf5720961aec", aad: "15d93a96d0e6c5a9", msg: "17bfda03", ciphertext: "f4710e51", tag: "b957c6a37b6a4c94996c002186d63b2b", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 12", xchacha: false, key: "1a4c4f39abe890e62345c947bcf7de7c2e33bd5ceeda0a0abf0e7ef935ddf3ee", nonce: "9447bf85d5b97d8aee0f8e51", aad: "", msg: "c15a593bd0", ciphertext: "f711647ff1", tag: "22b12dc38cb79629f84cdbdc2425c09d", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 13", xchacha: false, key: "800e9a24791700c9609736695ba2a8b99b2d57f1c3bfb61ed49db1c6c5219583", nonce: "3dbe876bd880ec8ea2017043", aad: "96224835610b782b", msg: "a7bfd041e3", ciphertext: "d171f046ea", tag: "d179b1b9c4184378df009019dbb8c249", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 14", xchacha: false, key: "208c2c376c9430433db20e1a6b7ba817f8ffbfa6827f26759ccede42e591d3ec", nonce: "27fb58ec6a21e84696cb8830", aad: "", msg: "af104b5ccd0e", ciphertext: "9351b1b1b082", tag: "560785509f60f26b681933d9cdbfd29f", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 15", xchacha: false, key: "2eb168e53b07ab04355ea792fe11a6be2ce9c39cfe15a997076b1e38c17ad620", nonce: "b5965470c383fd29fe7eaee7", aad: "6d52feb2509f7fbf", msg: "6fdf2927e169", ciphertext: "41abff7b71cc", tag: "9b5174297c03cf8902d1f706fd008902", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 16", xchacha: false, key: "55568158d3a6483f1f7021eab69b703f614251cadc1af5d34a374fdbfc5adac7", nonce: "3c4e654d663fa4596dc55bb7", aad: "", msg: "ab85e9c1571731", ciphertext: "5dfe3440dbb3c3", tag: "ed7a434e2602d394281e0afa9fb7aa42", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 17", xchacha: false, key: "e3c09e7fab1aefb516da6a33022a1dd4eb272c80d540c5da52a730f34d840d7f", nonce: "58389375c69ee398de948396", aad: "84e46be8c0919053", msg: "4ee5cda20d4290", ciphertext: "4bd47212941ce3", tag: "185f1408ee7fbf18f5abad6e2253a1ba", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 18", xchacha: false, key: "51e4bf2bad92b7aff1a4bc05550ba81df4b96fabf41c12c7b00e60e48db7e152", nonce: "4f07afedfdc3b6c2361823d3", aad: "", msg: "be3308f72a2c6aed", ciphertext: "8e9439a56eeec817", tag: "fbe8a6ed8fabb1937539dd6c00e90021", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 19", xchacha: false, key: "1131c1418577a054de7a4ac551950f1a053f9ae46e5b75fe4abd5608d7cddadd", nonce: "b4ea666ee119563366484a78", aad: "66c0ae70076cb14d", msg: "a4c9c2801b71f7df", ciphertext: "b9b910433af052b0", tag: "4530f51aeee024e0a445a6328fa67a18", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 20", xchacha: false, key: "e1094967f86d893cdfe2e2e6d5c7ee4dfef67da3c9c5d64e6ad7c1577dcb38c5", nonce: "8092fc245b3326cddbd1424c", aad: "", msg: "c37aa791ddd6accf91", ciphertext: "d9d897a9c1c5bb9f01", tag: "085a430373058f1a12a0d589fd5be68b", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 21", xchacha: false, key: "236f9baee4f9da15beeca40ff4af7c760f254a64bc3a3d7f4fad557e61b68586", nonce: "f1ca81338629587acf9372bf", aad: "8c32f47a386152ec", msg: "d7f26d5252e1765f5b", ciphertext: "8fdb429d47761cbf8e", tag: "8ef647ed334fdebbc2bef80be02884e0", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 22", xchacha: false, key: "4de207a3b70c51e5f23048eed5a5da9bb65e917a69aa93e7c8b4a815cd9724de", nonce: "4c15a71dc6791a8c005ad502", aad: "", msg: "f2c54b6b5e490da18659", ciphertext: "700d35adf5100a22a1de", tag: "102d992ffaff599b5bddddeb2dfb399b", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 23", xchacha: false, key: "6d667fd79e5fb725f50343dccc4863227c75ee3f7a578476e3e9f32598d81559", nonce: "6220527aba88e27f766658b2", aad: "e1e27ccddb3cb407", msg: "0c8c5a252681f2b5b4c0", ciphertext: "04aad66c60e0bf8ebba9", tag: "c15f69a4d2aef97d7748756ff49d894b", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 24", xchacha: false, key: "8f4bd94ef73e75d1e068c30b37ead576c5344e093ece1330e9101c82f793cf05", nonce: "ec1e2967f0f6979e5f5b07fb", aad: "", msg: "b89812b34d9bced4a0ba07", ciphertext: "1c3d53baaa36eaa1d8ec4d", tag: "4d94ebf960f12433bec43aa86d7e6e6d", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 25", xchacha: false, key: "2aa3bc7033351cac51364cdaf6ffac2c20f64046e1550a7b1c65f41800599019", nonce: "28cce57a5db2cd206321e340", aad: "a9bc350eaf2e6e3d", msg: "83016823123484b56095b0", ciphertext: "1c8578f8e75203d0336a52", tag: "5910f7a9d5e4df05d7248bd7a8d65e63", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 26", xchacha: false, key: "99b62bd5afbe3fb015bde93f0abf483957a1c3eb3ca59cb50b39f7f8a9cc51be", nonce: "9a59fce26df0005e07538656", aad: "", msg: "42baae5978feaf5c368d14e0", ciphertext: "ff7dc203b26c467a6b50db33", tag: "578c0f2758c2e14e36d4fc106dcb29b4", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 27", xchacha: false, key: "85f35b6282cff440bc1020c8136ff27031110fa63ec16f1e825118b006b91257", nonce: "58dbd4ad2c4ad35dd906e9ce", aad: "a506e1a5c69093f9", msg: "fdc85b94a4b2a6b759b1a0da", ciphertext: "9f8816de0994e938d9e53f95", tag: "d086fc6c9d8fa915fd8423a7cf05072f", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 28", xchacha: false, key: "faf4bfe8019a891c74901b17f4f48cee5cd065d55fdea60118aaf6c4319a0ea5", nonce: "b776c3fddba7c81362ce6e1b", aad: "", msg: "8dadff8d60c8e88f604f274833", ciphertext: "e6b33a74a4ac443bd93f9c1b94", tag: "0c115172bdb02bbad3130fff22790d60", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 29", xchacha: false, key: "841020d1606edcfc536abfb1a638a7b958e21efc10c386ac45a18493450afd5f", nonce: "6d62f159731b140eb18ce074", aad: "5a8e1c7aa39810d5", msg: "d6af138f701b801e60c85ffd5c", ciphertext: "b0a7500aca45bb15f01ece4389", tag: "0160e83adbec7f6a2ee2ff0215f9ef00", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 30", xchacha: false, key: "470f9ce3d2250bd60cbbefdb2e6a1178c012299b5590639c7797b6024fa703d8", nonce: "a9ea4d619fe405d04cba7d7a", aad: "", msg: "6ca67dd023fba6507b9f9a1f667e", ciphertext: "d3017e0bb1705b380b34cc333450", tag: "5708e72ca2bd354f487f82f67fbc3acb", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 31", xchacha: false, key: "e4b97e91e4c8e85eb7ce0a7f30bf8a0abf4468251e4c6386c0e7aacb8e879aa8", nonce: "0e23c942a0c9fb526586eead", aad: "eaaaeab26957f9a1", msg: "b84b3f74cd23064bb426fe2ced2b", ciphertext: "52e9672b416d84d97033796072d0", tag: "e83839dc1fd9b8b9d1444c40e488d493", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 32", xchacha: false, key: "67119627bd988eda906219e08c0d0d779a07d208ce8a4fe0709af755eeec6dcb", nonce: "68ab7fdbf61901dad461d23c", aad: "", msg: "51f8c1f731ea14acdb210a6d973e07", ciphertext: "0b29638e1fbdd6df53970be2210042", tag: "2a9134087d67a46e79178d0a93f5e1d2", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 33", xchacha: false, key: "e6f1118d41e4b43fb58221b7ed79673834e0d8ac5c4fa60bbc8bc4893a58894d", nonce: "d95b3243afaef714c5035b6a", aad: "6453a53384632212", msg: "97469da667d6110f9cbda1d1a20673", ciphertext: "32db66c4a3819d81557455e5980fed", tag: "feae30dec94e6ad3a9eea06a0d703917", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 34", xchacha: false, key: "59d4eafb4de0cfc7d3db99a8f54b15d7b39f0acc8da69763b019c1699f87674a", nonce: "2fcb1b38a99e71b84740ad9b", aad: "", msg: "549b365af913f3b081131ccb6b825588", ciphertext: "e9110e9f56ab3ca483500ceabab67a13", tag: "836ccabf15a6a22a51c1071cfa68fa0c", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 35", xchacha: false, key: "b907a45075513fe8a8019edee3f2591487b2a030b03c6e1d771c862571d2ea1e", nonce: "118a6964c2d3e380071f5266", aad: "034585621af8d7ff", msg: "55a465644f5b650928cbee7c063214d6", ciphertext: "e4b113cb775945f3d3a8ae9ec141c00c", tag: "7c43f16ce096d0dc27c95849dc383b7d", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 36", xchacha: false, key: "3b2458d8176e1621c0cc24c0c0e24c1e80d72f7ee9149a4b166176629616d011", nonce: "45aaa3e5d16d2d42dc03445d", aad: "", msg: "3ff1514b1c503915918f0c0c31094a6e1f", ciphertext: "02cc3acb5ee1fcdd12a03bb857976474d3", tag: "d83b7463a2c3800fe958c28eaa290813", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 37", xchacha: false, key: "f60c6a1b625725f76c7037b48fe3577fa7f7b87b1bd5a982176d182306ffb870", nonce: "f0384fb876121410633d993d", aad: "9aaf299eeea78f79", msg: "63858ca3e2ce69887b578a3c167b421c9c", ciphertext: "35766488d2bc7c2b8d17cbbb9abfad9e6d", tag: "1f391e657b2738dda08448cba2811ceb", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 38", xchacha: false, key: "37ceb574ccb0b701dd11369388ca27101732339f49d8d908ace4b23af0b7ce89", nonce: "37270b368f6b1e3e2ca51744", aad: "", msg: "f26991537257378151f4776aad28ae8bd16b", ciphertext: "b621d76a8dacff00b3f840cdf26c894cc5d1", tag: "e0a21716ed94c0382fa9b0903d15bb68", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 39", xchacha: false, key: "68888361919bc10622f45df168e5f6a03bd8e884c0611bea2f34c1882ed9832b", nonce: "bfd6ff40f2df8ca7845980cc", aad: "b8373438ddb2d6c3", msg: "ff97f2eefb3401ac31fc8dc1590d1a92cbc1", ciphertext: "e0a745186c1a7b147f74faff2a715df5c19d", tag: "917baf703e355d4d950e6c05fe8f349f", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 40", xchacha: false, key: "1b35b856b5a86d3403d28fc2103a631d42deca5175cdb0669a5e5d90b2caafc5", nonce: "2343de88be6c7196d33b8694", aad: "", msg: "21ef185c3ae9a96fa5eb473878f4d0b242781d", ciphertext: "d6e0ed54fccef30bd605d72da3320e249a9cb5", tag: "c68bc6724ec803c43984ce42f6bd09ff", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 41", xchacha: false, key: "d6484e3973f6be8c83ed3208d5be5cfa06fda72fbfdc5b19d09be3f4e4eba29d", nonce: "1af1d90e877e11a496efa3df", aad: "cc4efd8364fb114a", msg: "7335ab04b03e706109ec3ee835db9a246ea0ad", ciphertext: "29e54d608237c3c3609dba16e6edf43842d72f", tag: "d3365fdcd506aaaa5368661e80e9d99b", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 42", xchacha: false, key: "422add37849d6e4c3dfd8020dc6a07e8a249788f3d6a83b9cb4d802362c97542", nonce: "1e7e67be948de7352ffdb727", aad: "", msg: "d7f5e611dd3a2750fb843fc1b6b93087310dc87d", ciphertext: "7fe606652d858f595ec2e706754fa3d933fcc834", tag: "78d59235aa5d03a4c32590e590c04d22", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 43", xchacha: false, key: "cdccfe3f46d782ef47df4e72f0c02d9c7f774def970d23486f11a57f54247f17", nonce: "376187894605a8d45e30de51", aad: "956846a209e087ed", msg: "e28e0e9f9d22463ac0e42639b530f42102fded75", ciphertext: "14f707c446988a4903775ec7acec6da114d43112", tag: "987d4b147c490d43d376a198cab383f0", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 44", xchacha: false, key: "e79dfc6d2fc465b8439e1c5baccb5d8ef2853899fc19753b397e6c25b35e977e", nonce: "f9d6320d7ce51d8ed0677d3a", aad: "", msg: "4f543e7938d1b878dacaeec81dce4899974816813b", ciphertext: "1003f13ea1329cbb187316f64c3ff3a87cf5b96661", tag: "d2323ad625094bec84790d7958d5583f", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 45", xchacha: false, key: "1d7b8f1d96a1424923aef8a984869d4a777a110990ba465627acf80396c7f376", nonce: "50ba1962cdc32a5a2d36e640", aad: "093053e20261daab", msg: "5d3efd5767f3c12efd08af9a44e028ae68c9eff843", ciphertext: "2d48b0834e9ffe3046103ef7a214f02e8e4d33360e", tag: "d533ad089be229ea606ec0f3fa22eb33", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 46", xchacha: false, key: "dd433e28cfbcb5de4ab36a02bf38686d83208771a0e63dcd08b4df1a07ac47a1", nonce: "c9cc0a1afc38ec6c30c38c68", aad: "", msg: "8a3e17aba9606dd49e3b1a4d9e5e42f1742373632489", ciphertext: "e9917ff3e64bbe1783579375e75ea823976b35539949", tag: "074a890669b25105434c75beed3248db", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 47", xchacha: false, key: "a60924101b42ac24154a88de42142b2334cf599176caf4d1226f712dd9172930", nonce: "8ba77644b08d65d5e9f31942", aad: "b2a4e12a19a61c75", msg: "c949957e66439deee4b2ac1d4a6c98a6c527b90f52ab", ciphertext: "db4c700513818972b0dc0e531b1c281ca03e40c60dea", tag: "63f4478bba2af469a7a4dc3b4f141360", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 48", xchacha: false, key: "1aa42027836965b1e6086fa137f9cf7f1ff48676696829bd281ff81c8ea0a4a9", nonce: "4b3dca84ecc407f424f281a9", aad: "", msg: "37252a3eb5c8960f0567e503a9035783b3d0a19a4b9a47", ciphertext: "b5f14617491fc923b683e2cc9562d043dd5986b97dbdbd", tag: "972ce54713c05c4bb4d088c0a30cacd3", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 49", xchacha: false, key: "5d40db0cc18ef2e42815d3b6245a466a0b30a0f93e318ac10edde3bf8ad98160", nonce: "acad618039b317470d21621b", aad: "413036411af75745", msg: "959dde1ef3129b27702c558849e466f2baca1a45bdf4b2", ciphertext: "b7ca3879f95140bf6a97b3212218b7bf864a51e5bb0b3e", tag: "fe558fb570145470ea693eb76eb73171", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 50", xchacha: false, key: "0212a8de5007ed87b33f1a7090b6114f9e08cefd9607f2c276bdcfdbc5ce9cd7", nonce: "e6b1adf2fd58a8762c65f31b", aad: "", msg: "10f1ecf9c60584665d9ae5efe279e7f7377eea6916d2b111", ciphertext: "42f26c56cb4be21d9d8d0c80fc99dde00d75f38074bfe764", tag: "54aa7e13d48fff7d7557039457040a3a", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 51", xchacha: false, key: "c5bc09565646e7edda954f1f739223dada20b95c44ab033d0fae4b0283d18be3", nonce: "6b282ebecc541bcd7834ed55", aad: "3e8bc5ade182ff08", msg: "9222f9018e54fd6de1200806a9ee8e4cc904d29f25cba193", ciphertext: "123032437b4bfd6920e8f7e7e0087ae4889ebe7a0ad0e900", tag: "3cf68f179550da63d3b96c2d55411865", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 52", xchacha: false, key: "9460b3c44ed86e70f3bda66385e1ca10b0c1677ef4f1360532830d17535f996f", nonce: "abfaf42e0dba884efcf07823", aad: "", msg: "5c5cce881b93fb7a1b7939af1ffc5f84d3280ada778cca0953", ciphertext: "1d218c9f1f9f02f248a6f976a7557057f37d9393d9f213c1f3", tag: "bc88344c6fdc898feed394fb28511316", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 53", xchacha: false, key: "c111d6d5d78a071b15ab37cc8c3819199387ab7c1933aa97b1489f6584ba8e2a", nonce: "85f18ad8ff72cafee2452ab8", aad: "84cdff939391c022", msg: "6989c646a10b7c76f4d9f7d574da40e152013cf0dd78f5aa8a", ciphertext: "9715d344e8d3f3a3eaa98a9cea57c0cd717c6ef5076027c9ec", tag: "3056ff5ee0aa8636bb639984edb5236b", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 54", xchacha: false, key: "8a1b1e699a0c4a3e610b10902daedab1bf1ea0d505c47d7842cbcee0d3b1b6e6", nonce: "a6f9a8d335fa84c3b27dcd2a", aad: "", msg: "ee6a15fc183108f0877e7f2b8a9615f4b3fc36e1c83440f66aad", ciphertext: "9089bbdb8bcfd124e227bf75c4bfe1cba2004a274fc31aa32358", tag: "fd2e21c64a019621c68594826cd7b1cd", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 55", xchacha: false, key: "74b384e6e013ec4172ed7a28a10fb9bb79b4be2a24f6999e3d3caa28e64a8656", nonce: "ebc19fc9ecb2339908ea3836", aad: "85073f2edc13d3a1", msg: "3aa9f7372f056e5a0729752d9a37132d6dd07c56792e1c7582a9", ciphertext: "796ffb70ab43e7fa79f95583e384524727bb3e47fc45b969f714", tag: "c3322b4445de5f3c9f18dcc847cc94c3", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 56", xchacha: false, key: "77d824795d2029f0eb0e0baab5cfeb32f7e93474913a7f95c737a667a3c33314", nonce: "f3307430f492d2b8a72d3a81", aad: "", msg: "0c4179a497d8fdd72796fb725692b805d63b7c718359cf10518aee", ciphertext: "49c81d17d67d7ba9954f497d0b0ddc21f3f839c9d2cc198d30bc2c", tag: "50009899e5b2a9726c8f3556cadfbe84", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 57", xchacha: false, key: "bec5eac68f893951cbd7d1ecd3ee6611130dd9c3f80cddf95111d07d5edd76d1", nonce: "342ada4f0c115124b222df80", aad: "73365f6d80edb1d8", msg: "481433d8b1cd38af4a750e13a64b7a4e8507682b3517595938a20e", ciphertext: "4c129fc13cbdd9d3fe81ac755bf4fbea2fdd7e0aca0505a6ee9637", tag: "9cede1d30a03db5d55265d3648bc40d4", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 58", xchacha: false, key: "a59c1e13064df8f2b8df77a492b0ca2eae921b52a84b305a3a9a51408a9ecb69", nonce: "9544d41ece0c92ef01cfac2d", aad: "", msg: "1c35b898821ba55c2617c25df9e6df2a8002b384902186cd69dfd20e", ciphertext: "a6fa8f57ddc81d6099f667dd62402b6a5d5b7d05a329298029113169", tag: "bb24e38b31dbbc3e575b9e3ee076af2a", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 59", xchacha: false, key: "084b5d7365f1a8fec6365939ed741e6ea5893e0318d82ab47500a97d77aaa041", nonce: "829f005e980f0a6e2f983eaa", aad: "770f6e6e89a3fe8e", msg: "7510016efadc385a71ed689ceb590c8ea9cc1e81b793338bddf5f10c", ciphertext: "fd42cb5cf894f879e3cf751662aaa58a2288cc53548802becaf42359", tag: "188329438afe1cd7225d0478aa90c773", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 60", xchacha: false, key: "5a7f850a1d9aafa77d59ae1b731965e8aaec6352280fc76a7b5e23ef3610cfe4", nonce: "4946a0d6adea93b82d4332e5", aad: "", msg: "3c161d791f624fb0388e808f0f69ed790dbe4cbd089ebac46627bcf01d", ciphertext: "402302b56140c4dcc39774732c55883de124ce4bf0a0261cfa1569e2cf", tag: "e830bfe933a96786cff2dd72b82c4bd5", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 61", xchacha: false, key: "e6d5a4246f6f05618b59c8f9ec3ac8068cc0d3f351c571aa52b09cb251f9c2f6", nonce: "2f90a65e9e48725de6ffc727", aad: "f2415377ad283fd8", msg: "964fc9e0e8355947aa1c2caadd7b3dbef82a1024e623606fac436ef573", ciphertext: "d052932bad6e6c4f835f02019e52d7ff807dc2a5aac2040883c79dd3d5", tag: "655f93396b4d755dc4475721665fed91", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 62", xchacha: false, key: "09e822123adbb1ed89b79a58619c64853992f8371d46338712f6c91ab11a68bb", nonce: "a797205a6cacdd7e47a4789d", aad: "", msg: "80b71bbe833629841bd3aeaeb9db6123e51d367b436fe9d2d3454b62cfad", ciphertext: "83f5c77396cabd28dfcc002cba0756d4ea5455e0261d847d5708aac21e8d", tag: "705a05820a21f381d244d40e58d2f16b", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 63", xchacha: false, key: "625735fe7f8fc81b0c1edc3d08a78b41268f87a3c68488b674222630c1d587a5", nonce: "9d8cdf289dddd09afdc1b02f", aad: "200a9c95946ff05c", msg: "67ae1882d0b1c1b2485bec98115ecf53b9b438deb1d0400531705038873a", ciphertext: "209b7539385c8b19ecd0fd8b5011b2996e316f1942064e68edfa363acbcd", tag: "fa2f454b9fa2608f780f7c6f9b780fe1", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 64", xchacha: false, key: "2eb51c469aa8eb9e6c54a8349bae50a20f0e382711bba1152c424f03b6671d71", nonce: "04a9be03508a5f31371a6fd2", aad: "", msg: "b053999286a2824f42cc8c203ab24e2c97a685adcc2ad32662558e55a5c729", ciphertext: "45c7d6b53acad4abb68876a6e96a48fb59524d2c92c9d8a189c9fd2db91746", tag: "566d3ca10e311b695f3eae1551652493", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 65", xchacha: false, key: "7f5b74c07ed1b40fd14358fe2ff2a740c116c7706510e6a437f19ea49911cec4", nonce: "470a339ecb3219b8b81a1f8b", aad: "374618a06ea98a48", msg: "f45206abc25552b2abc9ab7fa243035fedaaddc3b2293956f1ea6e7156e7eb", ciphertext: "46a80c4187024720084627580080dde5a3f4a11093a7076ed6f3d326bc7b70", tag: "534d4aa2835a52e72d14df0e4f47f25f", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 66", xchacha: false, key: "e1731d5854e1b70cb3ffe8b786a2b3ebf0994370954757b9dc8c7bc5354634a3", nonce: "72cfd90ef3026ca22b7e6e6a", aad: "", msg: "b9c554cbc36ac18ae897df7beecac1dbeb4eafa156bb60ce2e5d48f05715e678", ciphertext: "ea29afa49d36e8760f5fe19723b9811ed5d519934a440f5081ac430b953b0e21", tag: "222541af46b86533c6b68d2ff108a7ea", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 67", xchacha: false, key: "27d860631b0485a410702fea61bc873f3442260caded4abde25b786a2d97f145", nonce: "262880d475f3dac5340dd1b8", aad: "2333e5ce0f93b059", msg: "6b2604996cd30c14a13a5257ed6cffd3bc5e29d6b97eb1799eb335e281ea451e", ciphertext: "6dad637897544d8bf6be9507ed4d1bb2e954bc427e5de729daf50762846ff2f4", tag: "7b997d93c982189d7095dc794c746232", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 68", xchacha: false, key: "5155dee9aade1cc61ee7e3f92660f7590f5e5ba82f1b59b850e3fa453d2fa6b3", nonce: "c26c4b3bfdb97ee6b0f63ca1", aad: "", msg: "2734e08eff8f5c4f84fa0c207f49c7fd78af1ad5123ff81f83f500edf4eda09edf", ciphertext: "f5982b601c7a18fc72a65b218c44974dc564d8314cbe6f87fcf6c6cfbe618b34b1", tag: "c43632f55760b5d1ed37556a94d049b5", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 69", xchacha: false, key: "573f08ebbe0cce4ac9618e8c3b224bea0a32f055c6996838a32f527ca3c3b695", nonce: "ad8050dc6d122dce3e5639ed", aad: "e99698241c599b5f", msg: "668d5e3f95fe030daf432a5fc5837af3a79c81e94b28d8204c5ee262ab3c9908a7", ciphertext: "eaf6810e6ec1cb7a2918856257d1aa3d51a827879146c6337ecf535e9c89b149c5", tag: "a2950c2f394a3466c345f796323c1aa7", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 70", xchacha: false, key: "cf0d40a4644e5f51815165d5301b22631f4544c49a1878e3a0a5e8e1aae0f264", nonce: "e74a515e7e2102b90bef55d2", aad: "", msg: "973d0c753826bae466cf9abb3493152e9de7819e2bd0c71171346b4d2cebf8041aa3cedc0dfd7b467e26228bc86c9a", ciphertext: "fba78ae4f9d808a62e3da40be2cb7700c3613d9eb2c529c652e76a432c658d27095f0eb8f940c324981ea935e507f9", tag: "8f046956db3a512908bd7afc8f2ab0a9", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 71", xchacha: false, key: "6cbfd71c645d184cf5d23c402bdb0d25ec54898c8a0273d42eb5be109fdcb2ac", nonce: "d4d807341683825b31cd4d95", aad: "b3e4064683b02d84", msg: "a98995504df16f748bfb7785ff91eeb3b660ea9ed3450c3d5e7b0e79ef653659a9978d75542ef91c456762215640b9", ciphertext: "a1ffed80761829ecce242e0e88b138049016bca018da2b6e19986b3e318cae8d806198fb4c527cc39350ebddeac573", tag: "c4cbf0befda0b70242c640d7cd02d7a3", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 72", xchacha: false, key: "5b1d1035c0b17ee0b0444767f80a25b8c1b741f4b50a4d3052226baa1c6fb701", nonce: "d61040a313ed492823cc065b", aad: "", msg: "d096803181beef9e008ff85d5ddc38ddacf0f09ee5f7e07f1e4079cb64d0dc8f5e6711cd4921a7887de76e2678fdc67618f1185586bfea9d4c685d50e4bb9a82", ciphertext: "9a4ef22b181677b5755c08f747c0f8d8e8d4c18a9cc2405c12bb51bb1872c8e8b877678bec442cfcbb0ff464a64b74332cf072898c7e0eddf6232ea6e27efe50", tag: "9ff3427a0f32fa566d9ca0a78aefc013", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 73", xchacha: false, key: "97d635c4f47574d9998a90875da1d3a284b755b2d39297a5725235190e10a97e", nonce: "d31c21aba175b70de4ebb19c", aad: "7193f623663321a2", msg: "94ee166d6d6ecf8832437136b4ae805d428864359586d9193a25016293edba443c58e07e7b7195ec5bd84582a9d56c8d4a108c7d7ce34e6c6f8ea1bec0567317", ciphertext: "5fbbdecc34be201614f636031eeb42f1cace3c79a12cffd871ee8e73820c829749f1abb4294367849fb6c2aa56bda8a3078f723d7c1c852024b017b58973fb1e", tag: "09263da7b4cb921452f97dca40f580ec", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 74", xchacha: false, key: "fe6e55bdaed1f7284ca5fc0f8c5f2b8df56dc0f49e8ca66a41995e783351f901", nonce: "17c86a8abbb7e003acde2799", aad: "", msg: "b429eb80fb8fe8baeda0c85b9c333458e7c2992e558475069d12d45c22217564121588032297eff56783742a5fc22d7410ffb29d66098661d76f126c3c27689e43b37267cac5a3a6d3ab49e391da29cd3054a5692e2807e4c3ea46c8761d50f592", ciphertext: "d0102f6c258bf49742cec34cf2d0fedf23d105fb4c84cf98515e1bc9a64f8ad5be8f0721bde50645d00083c3a263a31053b760245f52ae2866a5ec83b19f61be1d30d5c5d9fecc4cbbe08fd385813a2aa39a00ff9c10f7f23702add1e4b2ffa31c", tag: "41865fc71de12b19612127ce49993bb0", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 75", xchacha: false, key: "aabc063474e65c4c3e9bdc480dea97b45110c8618846ff6b15bdd2a4a5682c4e", nonce: "46362f45d6379e63e5229460", aad: "a11c40b603767330", msg: "ceb534ce50dc23ff638ace3ef63ab2cc2973eeada80785fc165d06c2f5100ff5e8ab2882c475afcd05ccd49f2e7d8f55ef3a72e3dc51d6852b8e6b9e7aece57be6556b0b6d9413e33fc5fc24a9a205ad59574bb39d944a92dc47970d84a6ad3176", ciphertext: "7545391b51de01d5c53dfaca777909063e58edee4bb1227e7110ac4d2620c2aec2f848f56deeb037a8dced75afa8a6c890e2dee42f950bb33d9e2424d08a505d899563973ed38870f3de6ee2adc7fe072c366c14e2cf7ca62fb3d36bee11685461", tag: "b70d44ef8c66c5c7bbf10dcadd7facf6", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 76", xchacha: false, key: "d7addd3889fadf8c893eee14ba2b7ea5bf56b449904869615bd05d5f114cf377", nonce: "8a3ad26b28cd13ba6504e260", aad: "", msg: "c877a76bf595560772167c6e3bcc705305db9c6fcbeb90f4fea85116038bc53c3fa5b4b4ea0de5cc534fbe1cf9ae44824c6c2c0a5c885bd8c3cdc906f12675737e434b983e1e231a52a275db5fb1a0cac6a07b3b7dcb19482a5d3b06a9317a54826cea6b36fce452fa9b5475e2aaf25499499d8a8932a19eb987c903bd8502fe", ciphertext: "294a764c03353f5f4f6e93cd7e977480d6c343071db0b7c1f0db1e95b85e6053f0423168a9c7533268db9a194e7665359d14489bc47172a9f21370e89b0bd0e5ef9661738de282572bcc3e541247626e57e75dec0f91ac5c530bd1a53271842996dcd04d865321b1ecb6e7630114fe780291b8dc3e5d0abc8e65b1c5493e9af0", tag: "f2b974ca0f14fb9f92014bff18573cff", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 77", xchacha: false, key: "80be86fb6fc49bc73428cab576f6ad72ff6aca04001b8b1c57a7128be73900aa", nonce: "903188433c1ce8971aa19b9d", aad: "0587af8530ad0547", msg: "67ce499cd8ed68bd717dfe61c60f27d260b1c163a72e8cc8597253d3d987c2dbe1bff2e44d9bd4765d3e53d9c3f8eb3b90e751f47c7157bdc1142bc33f5833ac1cd1262cbb239066b334a4ed99ae82c74f2b49540f1a614bc239d8fc5add8c178184e41281f6e66c5c3117fd953547f7c829425b5082aa69686847eaf5784692", ciphertext: "2b90b4f3de280c44913d1984bdd5dfa0566c6a14a058659a9b623277b0bb6e82101e79395d12e643f62d9a822bae497907493e4f8213fcf99da8a78fdf867af36bc8b0931c1886b4f0ae5729986494dbd59737e956cd8f226c7c522689d082f023894d54acab0c4d609f3746a67369bb8876008f7fd3dc6681c5fb9d728c5911", tag: "f005ebe1c1ada75a9cee8d630881d5b8", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 78", xchacha: false, key: "7d00b48095adfa3272050607b264185002ba99957c498be022770f2ce2f3143c", nonce: "87345f1055fd9e2102d50656", aad: "02", msg: "e5ccaa441bc814688f8f6e8f28b500b2", ciphertext: "7e72f5a185af16a611921b438f749f0b", tag: "1242c670732334029adfe1c5001651e4", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 79", xchacha: false, key: "6432717f1db85e41ac7836bce25185a080d5762b9e2b18444b6ec72c3bd8e4dc", nonce: "87a3163ec0598ad95b3aa713", aad: "b648", msg: "02cde168fba3f544bbd0332f7adeada8", ciphertext: "85f29a719557cdd14d1f8fffab6d9e60", tag: "732ca32becd515a1ed353f542e999858", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 80", xchacha: false, key: "7afa0f59dfcb5ad3a76490c5c804327c8d052be737a60fa8bcbf0a2c36630a43", nonce: "25b7bdf4a6dcbf7c9a3ec2b3", aad: "8b71ac", msg: "623e6ba6d3166a338bfcc7af90a230c8", ciphertext: "d46e8265a8c6a25393dd956bb44397ad", tag: "e28f3ad9e3ef4a3d94ee07bf538eaafb", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 81", xchacha: false, key: "2ec25b0ec7ac244224e9c7fc2fa5d3ef17809e19fd6e954158dd0d72738a4cc8", nonce: "6fb0d1417cdfff4df37db08c", aad: "3a5ddf40", msg: "a1c933768a6d573ebf68a99e5e18dae8", ciphertext: "2d3cb2d9303491e264f2904f0e0753f4", tag: "6c1db959362d217b2322b466536bfea0", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 82", xchacha: false, key: "0a2cf52371cf9d9f95b10108fc82b4fd6110a8ba9a88a26083685ad29826891a", nonce: "2538fc67afb9eab333f83290", aad: "9eec540bb0", msg: "0d8c691d044a3978d790432dc71d69f8", ciphertext: "a988c03c71b956ff086d0470d706bd34", tag: "b35d7cbf2beb894b0c746e0730429e15", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 83", xchacha: false, key: "307e886b38bb18b445f8a2c6d6f8932492a9cea8d041ba72eb5efdfa70d0b8d2", nonce: "a071be999151e2a1c41c81e9", aad: "56e014d97c74", msg: "9aba22b495cb7ec887ddaa62019aa14d", ciphertext: "32bf95d4c195dbaf58d9af4001c6e57d", tag: "4393808703d67a90870578046cd8b525", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 84", xchacha: false, key: "dacd51a8a8e4d5905b4cbb947ef4013eb296889353f3c9ee35f5577b26737a51", nonce: "3fa378a1befdddd61ae68cf4", aad: "bb5a3812f0aefd", msg: "e148313883a77da121124d06b1c77dca", ciphertext: "2a207ca7e9da6b13a229604304d87eb1", tag: "8a6b6afec87d93ec6e8dbe13d84c0f8c", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 85", xchacha: false, key: "7b5fbbb202c16108fd13066446853a850d8b34e9da40519580da446a922f9162", nonce: "aa077a5ce9161bde8d8edc40", aad: "f94bb92c1c668a695b", msg: "da471cd6935a0ca8307ddedc6b959962", ciphertext: "548a5ca0ae49211cdf30bbdcb1352d31", tag: "204dacb98f8c8908cc5ea22bb23f901f", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 86", xchacha: false, key: "1ffd101eb97531f6faa821ec4d5c5702725dd033d3b830bb760c4ef27ba983df", nonce: "598114e8cf7fbdea8ad29683", aad: "2155627ec15a978fbcb2", msg: "28668ca8db535c7e8eb27491ad0fb7cb", ciphertext: "28cedac24f14caa326c7fe401f68a87c", tag: "2bf1b2c43d3039f8f5ce359c1102f879", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 87", xchacha: false, key: "d2d0a973d5951af352cbee57ac9dab1c284c99af3b992ce015f219506f64888d", nonce: "9acd213570ce9bb9d886c6ef", aad: "37ad668d4d4fe889949763", msg: "3f3f0076250352e1b6b5c12cfa12625e", ciphertext: "7256e856872ad3a54b34a2a6bdca8838", tag: "3b12e4586e45223f78a6eea811efb863", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 88", xchacha: false, key: "adcc520b381382237d05a6400a7dfbcd0771b6aa9edb7966131ddef6af21f1be", nonce: "9183cdf3a8ba7397b6b2d5d5", aad: "b334375415f6215c0bf89a9a", msg: "958295619cf1b36f0b474663c0bc79eb", ciphertext: "852c141b4239a31feeda03550d70a2be", tag: "5fc59287b92d3fcf7d66f13defb11b0d", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 89", xchacha: false, key: "bd534f7adeca466844fb3ba34658be807f15c5291ed6026860a24f179b712c89", nonce: "412c3e13ee1f7864bd15ce39", aad: "2866afff0bcc6135dc63af88c8", msg: "d92f8ce5d8d0ad2eb5f11af02ef63949", ciphertext: "89d6d089c4a255952aca11b24a01ff95", tag: "f88fa4531204da315e7317970240ce9e", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 90", xchacha: false, key: "910ade7d324d2c9688439e1f142e0e5f9d130ff832e507fe1985e5a26452a6d0", nonce: "9be090dba93deff27adf99ee", aad: "ea2575f123268e936c8e4c8c1bb8", msg: "6e356094ed9d9a7053c7906c48ba3d9f", ciphertext: "01ffb343c757b27843d8a900a36ce39d", tag: "a315541b7d6313c6fddf64b303d71d60", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 91", xchacha: false, key: "8e34cf73d245a1082a920b86364eb896c4946467bcb3d58929fcb36690e6394f", nonce: "6f573aa86baa492ba46596df", aad: "bd4cd02fc7502bbdbdf6c9a3cbe8f0", msg: "16ddd23ff53f3d23c06334487040eb47", ciphertext: "c1b295936d56fadac03e5f742bff73a1", tag: "39c457dbab66382babb3b55800cda5b8", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 92", xchacha: false, key: "cb5575f5c7c45c91cf320b139fb594237560d0a3e6f865a67d4f633f2c08f016", nonce: "1a6518f02ede1da6809266d9", aad: "89cce9fb47441d07e0245a66fe8b778b", msg: "623b7850c321e2cf0c6fbcc8dfd1aff2", ciphertext: "c84c9bb7c61c1bcb17772a1c500c5095", tag: "dbadf7a5138ca03459a2cd65831e092f", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 93", xchacha: false, key: "a5569e729a69b24ba6e0ff15c4627897436824c941e9d00b2e93fddc4ba77657", nonce: "564dee49ab00d240fc1068c3", aad: "d19f2d989095f7ab03a5fde84416e00c0e", msg: "87b3a4d7b26d8d3203a0de1d64ef82e3", ciphertext: "94bc80621ed1e71b1fd2b5c3a15e3568", tag: "333511861796978401598b963722f5b3", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 94", xchacha: false, key: "56207465b4e48e6d04630f4a42f35cfc163ab289c22a2b4784f6f9290330bee0", nonce: "df8713e87ec3dbcfad14d53e", aad: "5e6470facd99c1d81e37cd44015fe19480a2a4d3352a4ff560c0640fdbda", msg: "e601b38557797da2f8a4106a089d1da6", ciphertext: "299b5d3f3d03c087209a16e285143111", tag: "4b454ed198de117e83ec49fa8d8508d6", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 95", xchacha: false, key: "077433022ab34d380fc192fc24c2edc6301fec6f24442f572a1087ff2e05b39a", nonce: "28adcbc74364f26dd4b3108b", aad: "e0100eb116cdc5e22a3b9f9b4126c149595e75107f6e237c69e82960052270", msg: "03c874eeaaa6fa9f0da62c758fb0ad04", ciphertext: "1e9687b35fbc8eaa1825ed3847798f76", tag: "0788bf70fd04030ecd1c96d0bc1fcd5d", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 96", xchacha: false, key: "3937986af86dafc1ba0c4672d8abc46c207062682d9c264ab06d6c5807205130", nonce: "8df4b15a888c33286a7b7651", aad: "ba446f6f9a0ced22450feb10737d9007fd69abc19b1d4d9049a5551e86ec2b37", msg: "dc9e9eaf11e314182df6a4eba17aec9c", ciphertext: "605bbf90aeb974f6602bc778056f0dca", tag: "38ea23d99054b46b42ffe004129d2204", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 97", xchacha: false, key: "36372abcdb78e0279646ac3d176b9674e9154eecf0d5469c651ec7e16b4c1199", nonce: "be40e5f1a11817a0a8fa8949", aad: "d41a828d5e71829247021905402ea257dccbc3b80fcd5675056b68bb59e62e8873", msg: "81ce84ede9b35859cc8c49a8f6be7dc6", ciphertext: "7b7ce0d824809a70de32562ccf2c2bbd", tag: "15d44a00ce0d19b4231f921e22bc0a43", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 98", xchacha: false, key: "9f1479ed097d7fe529c11f2f5add9aaff4a1ca0b68997a2cb7f79749bd90aaf4", nonce: "84c87dae4eee27730ec35d12", aad: "3f2dd49bbf09d69a78a3d80ea2566614fc379474196c1aae84583da73d7ff85c6f42ca42056a9792cc1b9fb3c7d261", msg: "a66747c89e857af3a18e2c79500087ed", ciphertext: "ca82bff3e2f310ccc976672c4415e69b", tag: "57638c62a5d85ded774f913c813ea032", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 99", xchacha: false, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b", aad: "00000000000000000000000000000000", msg: "65b63bf074b7283992e24b1ac0df0d22b555dbe2254d94a43f1de748d3cc6f0d", ciphertext: "0000000000000000000000000000000000000000000000000000000000000000", tag: "39f4fce3026d83789ffd1ee6f2cd7c4f", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 100", xchacha: false, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b", aad: "00000000000000000000000000000000", msg: "65b63bf074b7283992e24b1ac0df0d22b555dbe2254d94a43f1de748d3cc6f0d20c142fe898fbbe668d4324394434c1b18b58ead710aed9c31db1f2a8a1f1bb2", ciphertext: "00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000", tag: "f5eaa804605c3a4785f9d7f13b6f67d6", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 101", xchacha: false, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b", aad: "00000000000000000000000000000000", msg: "65b63bf074b7283992e24b1ac0df0d22b555dbe2254d94a43f1de748d3cc6f0d20c142fe898fbbe668d4324394434c1b18b58ead710aed9c31db1f2a8a1f1bb24405c183af94ee1ad630cd931158a6213d48c8fff10d0a1f9ef760188e658802aad55e41a1d99069a18db55c56af7c10a6f21ecc8af9b7ce0a7ea0b67426e925", ciphertext: "0000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000", tag: "9b5c43a78d954e8a3c659eebc13d5d55", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 102", xchacha: false, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b", aad: "ffffffffffffffffffffffffffffffff", msg: "9a49c40f8b48d7c66d1db4e53f20f2dd4aaa241ddab26b5bc0e218b72c3390f2", ciphertext: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", tag: "37e3399d9ca696799f08f4f72bc0cdd8", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 103", xchacha: false, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b", aad: "ffffffffffffffffffffffffffffffff", msg: "9a49c40f8b48d7c66d1db4e53f20f2dd4aaa241ddab26b5bc0e218b72c3390f2df3ebd0176704419972bcdbc6bbcb3e4e74a71528ef51263ce24e0d575e0e44d", ciphertext: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", tag: "3d52710bec86d4ea9fea2ff269549191", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 104", xchacha: false, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b", aad: "ffffffffffffffffffffffffffffffff", msg: "9a49c40f8b48d7c66d1db4e53f20f2dd4aaa241ddab26b5bc0e218b72c3390f2df3ebd0176704419972bcdbc6bbcb3e4e74a71528ef51263ce24e0d575e0e44dbbfa3e7c506b11e529cf326ceea759dec2b737000ef2f5e061089fe7719a77fd552aa1be5e266f965e724aa3a95083ef590de13375064831f5815f498bd916da", ciphertext: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", tag: "51356329e280b12d55d3d98f0a580cbe", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 105", xchacha: false, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b", aad: "00000080000000800000008000000080", msg: "65b63b7074b728b992e24b9ac0df0da2b555db62254d94243f1de7c8d3cc6f8d", ciphertext: "0000008000000080000000800000008000000080000000800000008000000080", tag: "c152a4b90c548c71dc479edeaf9211bf", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 106", xchacha: false, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b", aad: "00000080000000800000008000000080", msg: "65b63b7074b728b992e24b9ac0df0da2b555db62254d94243f1de7c8d3cc6f8d20c1427e898fbb6668d432c394434c9b18b58e2d710aed1c31db1faa8a1f1b32", ciphertext: "00000080000000800000008000000080000000800000008000000080000000800000008000000080000000800000008000000080000000800000008000000080", tag: "40ef6383052d91c2e4b4611b0e32c5ff", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 107", xchacha: false, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b", aad: "00000080000000800000008000000080", msg: "65b63b7074b728b992e24b9ac0df0da2b555db62254d94243f1de7c8d3cc6f8d20c1427e898fbb6668d432c394434c9b18b58e2d710aed1c31db1faa8a1f1b324405c103af94ee9ad630cd131158a6a13d48c87ff10d0a9f9ef760988e658882aad55ec1a1d990e9a18db5dc56af7c90a6f21e4c8af9b74e0a7ea0367426e9a5", ciphertext: "0000008000000080000000800000008000000080000000800000008000000080000000800000008000000080000000800000008000000080000000800000008000000080000000800000008000000080000000800000008000000080000000800000008000000080000000800000008000000080000000800000008000000080", tag: "ae9b542541e84fc74542eed6be638fee", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 108", xchacha: false, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b", aad: "80000000800000008000000080000000", msg: "e5b63bf0f4b7283912e24b1a40df0d223555dbe2a54d94a4bf1de74853cc6f0d", ciphertext: "8000000080000000800000008000000080000000800000008000000080000000", tag: "10fee3ecfba9cdf797bae37a626ec83b", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 109", xchacha: false, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b", aad: "80000000800000008000000080000000", msg: "e5b63bf0f4b7283912e24b1a40df0d223555dbe2a54d94a4bf1de74853cc6f0da0c142fe098fbbe6e8d4324314434c1b98b58eadf10aed9cb1db1f2a0a1f1bb2", ciphertext: "80000000800000008000000080000000800000008000000080000000800000008000000080000000800000008000000080000000800000008000000080000000", tag: "7490795bdbbbf5d0aecb9a4f65aa379f", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 110", xchacha: false, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b", aad: "80000000800000008000000080000000", msg: "e5b63bf0f4b7283912e24b1a40df0d223555dbe2a54d94a4bf1de74853cc6f0da0c142fe098fbbe6e8d4324314434c1b98b58eadf10aed9cb1db1f2a0a1f1bb2c405c1832f94ee1a5630cd939158a621bd48c8ff710d0a1f1ef760180e6588022ad55e4121d99069218db55cd6af7c1026f21ecc0af9b7ce8a7ea0b6f426e925", ciphertext: "8000000080000000800000008000000080000000800000008000000080000000800000008000000080000000800000008000000080000000800000008000000080000000800000008000000080000000800000008000000080000000800000008000000080000000800000008000000080000000800000008000000080000000", tag: "1d1096a8ca9e2bda2762c41d5b16f62f", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 111", xchacha: false, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b", aad: "ffffff7fffffff7fffffff7fffffff7f", msg: "9a49c48f8b48d7466d1db4653f20f25d4aaa249ddab26bdbc0e218372c339072", ciphertext: "ffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7f", tag: "af8492c792bf8d8062be74ff6efb3869", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 112", xchacha: false, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b", aad: "ffffff7fffffff7fffffff7fffffff7f", msg: "9a49c48f8b48d7466d1db4653f20f25d4aaa249ddab26bdbc0e218372c339072df3ebd8176704499972bcd3c6bbcb364e74a71d28ef512e3ce24e05575e0e4cd", ciphertext: "ffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7f", tag: "f24db68c46b67d6f402fa6c897913368", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 113", xchacha: false, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b", aad: "ffffff7fffffff7fffffff7fffffff7f", msg: "9a49c48f8b48d7466d1db4653f20f25d4aaa249ddab26bdbc0e218372c339072df3ebd8176704499972bcd3c6bbcb364e74a71d28ef512e3ce24e05575e0e4cdbbfa3efc506b116529cf32eceea7595ec2b737800ef2f56061089f67719a777d552aa13e5e266f165e724a23a950836f590de1b3750648b1f5815fc98bd9165a", ciphertext: "ffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7f", tag: "43f651ab2e2eb0f04bf689a40d32da24", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 114", xchacha: false, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b", aad: "7fffffff7fffffff7fffffff7fffffff", msg: "1a49c40f0b48d7c6ed1db4e5bf20f2ddcaaa241d5ab26b5b40e218b7ac3390f2", ciphertext: "7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff", tag: "60d95294a3694cfaa64b2f63bc1f82ec", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 115", xchacha: false, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b", aad: "7fffffff7fffffff7fffffff7fffffff", msg: "1a49c40f0b48d7c6ed1db4e5bf20f2ddcaaa241d5ab26b5b40e218b7ac3390f25f3ebd01f6704419172bcdbcebbcb3e4674a71520ef512634e24e0d5f5e0e44d", ciphertext: "7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff", tag: "beaca0b47027196176186d944019c1c8", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 116", xchacha: false, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b", aad: "7fffffff7fffffff7fffffff7fffffff", msg: "1a49c40f0b48d7c6ed1db4e5bf20f2ddcaaa241d5ab26b5b40e218b7ac3390f25f3ebd01f6704419172bcdbcebbcb3e4674a71520ef512634e24e0d5f5e0e44d3bfa3e7cd06b11e5a9cf326c6ea759de42b737008ef2f5e0e1089fe7f19a77fdd52aa1bede266f96de724aa3295083efd90de133f506483175815f490bd916da", ciphertext: "7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff", tag: "d4811028a577d4dd69d6b35d717f73e3", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 117", xchacha: false, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b", aad: "00000000ffffffff00000000ffffffff", msg: "65b63bf08b48d7c692e24b1a3f20f2ddb555dbe2dab26b5b3f1de7482c3390f2", ciphertext: "00000000ffffffff00000000ffffffff00000000ffffffff00000000ffffffff", tag: "10fb61272b555bee104f5a71818716d6", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 118", xchacha: false, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b", aad: "00000000ffffffff00000000ffffffff", msg: "65b63bf08b48d7c692e24b1a3f20f2ddb555dbe2dab26b5b3f1de7482c3390f220c142fe7670441968d432436bbcb3e418b58ead8ef5126331db1f2a75e0e44d", ciphertext: "00000000ffffffff00000000ffffffff00000000ffffffff00000000ffffffff00000000ffffffff00000000ffffffff00000000ffffffff00000000ffffffff", tag: "4756764e59583504182877d8c33120f0", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 119", xchacha: false, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b", aad: "00000000ffffffff00000000ffffffff", msg: "65b63bf08b48d7c692e24b1a3f20f2ddb555dbe2dab26b5b3f1de7482c3390f220c142fe7670441968d432436bbcb3e418b58ead8ef5126331db1f2a75e0e44d4405c183506b11e5d630cd93eea759de3d48c8ff0ef2f5e09ef76018719a77fdaad55e415e266f96a18db55ca95083efa6f21ecc750648310a7ea0b68bd916da", ciphertext: "00000000ffffffff00000000ffffffff00000000ffffffff00000000ffffffff00000000ffffffff00000000ffffffff00000000ffffffff00000000ffffffff00000000ffffffff00000000ffffffff00000000ffffffff00000000ffffffff00000000ffffffff00000000ffffffff00000000ffffffff00000000ffffffff", tag: "95a2b12a4a280089d4bd4f904253e754", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 120", xchacha: false, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b", aad: "ffffffff00000000ffffffff00000000", msg: "9a49c40f74b728396d1db4e5c0df0d224aaa241d254d94a4c0e218b7d3cc6f0d", ciphertext: "ffffffff00000000ffffffff00000000ffffffff00000000ffffffff00000000", tag: "60dcd45974bebe032eb7b86c9d063452", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 121", xchacha: false, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b", aad: "ffffffff00000000ffffffff00000000", msg: "9a49c40f74b728396d1db4e5c0df0d224aaa241d254d94a4c0e218b7d3cc6f0ddf3ebd01898fbbe6972bcdbc94434c1be74a7152710aed9cce24e0d58a1f1bb2", ciphertext: "ffffffff00000000ffffffff00000000ffffffff00000000ffffffff00000000ffffffff00000000ffffffff00000000ffffffff00000000ffffffff00000000", tag: "f0e6a3c1f28ad92d0dbc900be291d877", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 122", xchacha: false, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b", aad: "ffffffff00000000ffffffff00000000", msg: "9a49c40f74b728396d1db4e5c0df0d224aaa241d254d94a4c0e218b7d3cc6f0ddf3ebd01898fbbe6972bcdbc94434c1be74a7152710aed9cce24e0d58a1f1bb2bbfa3e7caf94ee1a29cf326c1158a621c2b73700f10d0a1f61089fe78e658802552aa1bea1d990695e724aa356af7c10590de1338af9b7cef5815f497426e925", ciphertext: "ffffffff00000000ffffffff00000000ffffffff00000000ffffffff00000000ffffffff00000000ffffffff00000000ffffffff00000000ffffffff00000000ffffffff00000000ffffffff00000000ffffffff00000000ffffffff00000000ffffffff00000000ffffffff00000000ffffffff00000000ffffffff00000000", tag: "57eff4a525eeff2ebd7a28eb894282be", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 123 - Flipped bit 0 in tag expected tag:f4409bb729039d0814ac514054323f44", xchacha: false, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b", aad: "000102", msg: "", ciphertext: "", tag: "f5409bb729039d0814ac514054323f44", valid: false},
+	{name: "Wycheproof ChaCha20Poly1305 124 - Flipped bit 1 in tag expected tag:f4409bb729039d0814ac514054323f44", xchacha: false, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b", aad: "000102", msg: "", ciphertext: "", tag: "f6409bb729039d0814ac514054323f44", valid: false},
+	{name: "Wycheproof ChaCha20Poly1305 125 - Flipped bit 7 in tag expected tag:f4409bb729039d0814ac514054323f44", xchacha: false, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b", aad: "000102", msg: "", ciphertext: "", tag: "74409bb729039d0814ac514054323f44", valid: false},
+	{name: "Wycheproof ChaCha20Poly1305 126 - Flipped bit 8 in tag expected tag:f4409bb729039d0814ac514054323f44", xchacha: false, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b", aad: "000102", msg: "", ciphertext: "", tag: "f4419bb729039d0814ac514054323f44", valid: false},
+	{name: "Wycheproof ChaCha20Poly1305 127 - Flipped bit 31 in tag expected tag:f4409bb729039d0814ac514054323f44", xchacha: false, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b", aad: "000102", msg: "", ciphertext: "", tag: "f4409b3729039d0814ac514054323f44", valid: false},
+	{name: "Wycheproof ChaCha20Poly1305 128 - Flipped bit 32 in tag expected tag:f4409bb729039d0814ac514054323f44", xchacha: false, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b", aad: "000102", msg: "", ciphertext: "", tag: "f4409bb728039d0814ac514054323f44", valid: false},
+	{name: "Wycheproof ChaCha20Poly1305 129 - Flipped bit 33 in tag expected tag:f4409bb729039d0814ac514054323f44", xchacha: false, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b", aad: "000102", msg: "", ciphertext: "", tag: "f4409bb72b039d0814ac514054323f44", valid: false},
+	{name: "Wycheproof ChaCha20Poly1305 130 - Flipped bit 63 in tag expected tag:f4409bb729039d0814ac514054323f44", xchacha: false, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b", aad: "000102", msg: "", ciphertext: "", tag: "f4409bb729039d8814ac514054323f44", valid: false},
+	{name: "Wycheproof ChaCha20Poly1305 131 - Flipped bit 64 in tag expected tag:f4409bb729039d0814ac514054323f44", xchacha: false, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b", aad: "000102", msg: "", ciphertext: "", tag: "f4409bb729039d0815ac514054323f44", valid: false},
+	{name: "Wycheproof ChaCha20Poly1305 132 - Flipped bit 77 in tag expected tag:f4409bb729039d0814ac514054323f44", xchacha: false, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b", aad: "000102", msg: "", ciphertext: "", tag: "f4409bb729039d08148c514054323f44", valid: false},
+	{name: "Wycheproof ChaCha20Poly1305 133 - Flipped bit 80 in tag expected tag:f4409bb729039d0814ac514054323f44", xchacha: false, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b", aad: "000102", msg: "", ciphertext: "", tag: "f4409bb729039d0814ac504054323f44", valid: false},
+	{name: "Wycheproof ChaCha20Poly1305 134 - Flipped bit 96 in tag expected tag:f4409bb729039d0814ac514054323f44", xchacha: false, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b", aad: "000102", msg: "", ciphertext: "", tag: "f4409bb729039d0814ac514055323f44", valid: false},
+	{name: "Wycheproof ChaCha20Poly1305 135 - Flipped bit 97 in tag expected tag:f4409bb729039d0814ac514054323f44", xchacha: false, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b", aad: "000102", msg: "", ciphertext: "", tag: "f4409bb729039d0814ac514056323f44", valid: false},
+	{name: "Wycheproof ChaCha20Poly1305 136 - Flipped bit 120 in tag expected tag:f4409bb729039d0814ac514054323f44", xchacha: false, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b", aad: "000102", msg: "", ciphertext: "", tag: "f4409bb729039d0814ac514054323f45", valid: false},
+	{name: "Wycheproof ChaCha20Poly1305 137 - Flipped bit 121 in tag expected tag:f4409bb729039d0814ac514054323f44", xchacha: false, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b", aad: "000102", msg: "", ciphertext: "", tag: "f4409bb729039d0814ac514054323f46", valid: false},
+	{name: "Wycheproof ChaCha20Poly1305 138 - Flipped bit 126 in tag expected tag:f4409bb729039d0814ac514054323f44", xchacha: false, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b", aad: "000102", msg: "", ciphertext: "", tag: "f4409bb729039d0814ac514054323f04", valid: false},
+	{name: "Wycheproof ChaCha20Poly1305 139 - Flipped bit 127 in tag expected tag:f4409bb729039d0814ac514054323f44", xchacha: false, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b", aad: "000102", msg: "", ciphertext: "", tag: "f4409bb729039d0814ac514054323fc4", valid: false},
+	{name: "Wycheproof ChaCha20Poly1305 140 - Flipped bit 63 and 127 in tag expected tag:f4409bb729039d0814ac514054323f44", xchacha: false, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b", aad: "000102", msg: "", ciphertext: "", tag: "f4409bb729039d8814ac514054323fc4", valid: false},
+	{name: "Wycheproof ChaCha20Poly1305 141 - Tag changed to all zero expected tag:f4409bb729039d0814ac514054323f44", xchacha: false, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b", aad: "000102", msg: "", ciphertext: "", tag: "00000000000000000000000000000000", valid: false},
+	{name: "Wycheproof ChaCha20Poly1305 142 - tag change to all 1 expected tag:f4409bb729039d0814ac514054323f44", xchacha: false, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b", aad: "000102", msg: "", ciphertext: "", tag: "ffffffffffffffffffffffffffffffff", valid: false},
+	{name: "Wycheproof ChaCha20Poly1305 143 - Flipped bit 0 in tag expected tag:29914007a6119dd3f109bba21ce9a7d6", xchacha: false, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b", aad: "000102", msg: "000102030405060708090a0b0c0d0e0f", ciphertext: "d03bcb3ca52d48d1d203b1e7b1a5995a", tag: "28914007a6119dd3f109bba21ce9a7d6", valid: false},
+	{name: "Wycheproof ChaCha20Poly1305 144 - Flipped bit 1 in tag expected tag:29914007a6119dd3f109bba21ce9a7d6", xchacha: false, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b", aad: "000102", msg: "000102030405060708090a0b0c0d0e0f", ciphertext: "d03bcb3ca52d48d1d203b1e7b1a5995a", tag: "2b914007a6119dd3f109bba21ce9a7d6", valid: false},
+	{name: "Wycheproof ChaCha20Poly1305 145 - Flipped bit 7 in tag expected tag:29914007a6119dd3f109bba21ce9a7d6", xchacha: false, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b", aad: "000102", msg: "000102030405060708090a0b0c0d0e0f", ciphertext: "d03bcb3ca52d48d1d203b1e7b1a5995a", tag: "a9914007a6119dd3f109bba21ce9a7d6", valid: false},
+	{name: "Wycheproof ChaCha20Poly1305 146 - Flipped bit 8 in tag expected tag:29914007a6119dd3f109bba21ce9a7d6", xchacha: false, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b", aad: "000102", msg: "000102030405060708090a0b0c0d0e0f", ciphertext: "d03bcb3ca52d48d1d203b1e7b1a5995a", tag: "29904007a6119dd3f109bba21ce9a7d6", valid: false},
+	{name: "Wycheproof ChaCha20Poly1305 147 - Flipped bit 31 in tag expected tag:29914007a6119dd3f109bba21ce9a7d6", xchacha: false, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b", aad: "000102", msg: "000102030405060708090a0b0c0d0e0f", ciphertext: "d03bcb3ca52d48d1d203b1e7b1a5995a", tag: "29914087a6119dd3f109bba21ce9a7d6", valid: false},
+	{name: "Wycheproof ChaCha20Poly1305 148 - Flipped bit 32 in tag expected tag:29914007a6119dd3f109bba21ce9a7d6", xchacha: false, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b", aad: "000102", msg: "000102030405060708090a0b0c0d0e0f", ciphertext: "d03bcb3ca52d48d1d203b1e7b1a5995a", tag: "29914007a7119dd3f109bba21ce9a7d6", valid: false},
+	{name: "Wycheproof ChaCha20Poly1305 149 - Flipped bit 33 in tag expected tag:29914007a6119dd3f109bba21ce9a7d6", xchacha: false, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b", aad: "000102", msg: "000102030405060708090a0b0c0d0e0f", ciphertext: "d03bcb3ca52d48d1d203b1e7b1a5995a", tag: "29914007a4119dd3f109bba21ce9a7d6", valid: false},
+	{name: "Wycheproof ChaCha20Poly1305 150 - Flipped bit 63 in tag expected tag:29914007a6119dd3f109bba21ce9a7d6", xchacha: false, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b", aad: "000102", msg: "000102030405060708090a0b0c0d0e0f", ciphertext: "d03bcb3ca52d48d1d203b1e7b1a5995a", tag: "29914007a6119d53f109bba21ce9a7d6", valid: false},
+	{name: "Wycheproof ChaCha20Poly1305 151 - Flipped bit 64 in tag expected tag:29914007a6119dd3f109bba21ce9a7d6", xchacha: false, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b", aad: "000102", msg: "000102030405060708090a0b0c0d0e0f", ciphertext: "d03bcb3ca52d48d1d203b1e7b1a5995a", tag: "29914007a6119dd3f009bba21ce9a7d6", valid: false},
+	{name: "Wycheproof ChaCha20Poly1305 152 - Flipped bit 77 in tag expected tag:29914007a6119dd3f109bba21ce9a7d6", xchacha: false, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b", aad: "000102", msg: "000102030405060708090a0b0c0d0e0f", ciphertext: "d03bcb3ca52d48d1d203b1e7b1a5995a", tag: "29914007a6119dd3f129bba21ce9a7d6", valid: false},
+	{name: "Wycheproof ChaCha20Poly1305 153 - Flipped bit 80 in tag expected tag:29914007a6119dd3f109bba21ce9a7d6", xchacha: false, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b", aad: "000102", msg: "000102030405060708090a0b0c0d0e0f", ciphertext: "d03bcb3ca52d48d1d203b1e7b1a5995a", tag: "29914007a6119dd3f109baa21ce9a7d6", valid: false},
+	{name: "Wycheproof ChaCha20Poly1305 154 - Flipped bit 96 in tag expected tag:29914007a6119dd3f109bba21ce9a7d6", xchacha: false, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b", aad: "000102", msg: "000102030405060708090a0b0c0d0e0f", ciphertext: "d03bcb3ca52d48d1d203b1e7b1a5995a", tag: "29914007a6119dd3f109bba21de9a7d6", valid: false},
+	{name: "Wycheproof ChaCha20Poly1305 155 - Flipped bit 97 in tag expected tag:29914007a6119dd3f109bba21ce9a7d6", xchacha: false, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b", aad: "000102", msg: "000102030405060708090a0b0c0d0e0f", ciphertext: "d03bcb3ca52d48d1d203b1e7b1a5995a", tag: "29914007a6119dd3f109bba21ee9a7d6", valid: false},
+	{name: "Wycheproof ChaCha20Poly1305 156 - Flipped bit 120 in tag expected tag:29914007a6119dd3f109bba21ce9a7d6", xchacha: false, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b", aad: "000102", msg: "000102030405060708090a0b0c0d0e0f", ciphertext: "d03bcb3ca52d48d1d203b1e7b1a5995a", tag: "29914007a6119dd3f109bba21ce9a7d7", valid: false},
+	{name: "Wycheproof ChaCha20Poly1305 157 - Flipped bit 121 in tag expected tag:29914007a6119dd3f109bba21ce9a7d6", xchacha: false, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b", aad: "000102", msg: "000102030405060708090a0b0c0d0e0f", ciphertext: "d03bcb3ca52d48d1d203b1e7b1a5995a", tag: "29914007a6119dd3f109bba21ce9a7d4", valid: false},
+	{name: "Wycheproof ChaCha20Poly1305 158 - Flipped bit 126 in tag expected tag:29914007a6119dd3f109bba21ce9a7d6", xchacha: false, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b", aad: "000102", msg: "000102030405060708090a0b0c0d0e0f", ciphertext: "d03bcb3ca52d48d1d203b1e7b1a5995a", tag: "29914007a6119dd3f109bba21ce9a796", valid: false},
+	{name: "Wycheproof ChaCha20Poly1305 159 - Flipped bit 127 in tag expected tag:29914007a6119dd3f109bba21ce9a7d6", xchacha: false, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b", aad: "000102", msg: "000102030405060708090a0b0c0d0e0f", ciphertext: "d03bcb3ca52d48d1d203b1e7b1a5995a", tag: "29914007a6119dd3f109bba21ce9a756", valid: false},
+	{name: "Wycheproof ChaCha20Poly1305 160 - Flipped bit 63 and 127 in tag expected tag:29914007a6119dd3f109bba21ce9a7d6", xchacha: false, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b", aad: "000102", msg: "000102030405060708090a0b0c0d0e0f", ciphertext: "d03bcb3ca52d48d1d203b1e7b1a5995a", tag: "29914007a6119d53f109bba21ce9a756", valid: false},
+	{name: "Wycheproof ChaCha20Poly1305 161 - Tag changed to all zero expected tag:29914007a6119dd3f109bba21ce9a7d6", xchacha: false, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b", aad: "000102", msg: "000102030405060708090a0b0c0d0e0f", ciphertext: "d03bcb3ca52d48d1d203b1e7b1a5995a", tag: "00000000000000000000000000000000", valid: false},
+	{name: "Wycheproof ChaCha20Poly1305 162 - tag change to all 1 expected tag:29914007a6119dd3f109bba21ce9a7d6", xchacha: false, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b", aad: "000102", msg: "000102030405060708090a0b0c0d0e0f", ciphertext: "d03bcb3ca52d48d1d203b1e7b1a5995a", tag: "ffffffffffffffffffffffffffffffff", valid: false},
+	{name: "Wycheproof ChaCha20Poly1305 163 - Flipped bit 0 in tag expected tag:66405a16e8b44eba92aa47f5cea52b7a", xchacha: false, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b", aad: "000102", msg: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20", ciphertext: "d03bcb3ca52d48d1d203b1e7b1a5995af1a0466a61bb386a2e12d189a2c4ea15e9", tag: "67405a16e8b44eba92aa47f5cea52b7a", valid: false},
+	{name: "Wycheproof ChaCha20Poly1305 164 - Flipped bit 1 in tag expected tag:66405a16e8b44eba92aa47f5cea52b7a", xchacha: false, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b", aad: "000102", msg: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20", ciphertext: "d03bcb3ca52d48d1d203b1e7b1a5995af1a0466a61bb386a2e12d189a2c4ea15e9", tag: "64405a16e8b44eba92aa47f5cea52b7a", valid: false},
+	{name: "Wycheproof ChaCha20Poly1305 165 - Flipped bit 7 in tag expected tag:66405a16e8b44eba92aa47f5cea52b7a", xchacha: false, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b", aad: "000102", msg: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20", ciphertext: "d03bcb3ca52d48d1d203b1e7b1a5995af1a0466a61bb386a2e12d189a2c4ea15e9", tag: "e6405a16e8b44eba92aa47f5cea52b7a", valid: false},
+	{name: "Wycheproof ChaCha20Poly1305 166 - Flipped bit 8 in tag expected tag:66405a16e8b44eba92aa47f5cea52b7a", xchacha: false, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b", aad: "000102", msg: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20", ciphertext: "d03bcb3ca52d48d1d203b1e7b1a5995af1a0466a61bb386a2e12d189a2c4ea15e9", tag: "66415a16e8b44eba92aa47f5cea52b7a", valid: false},
+	{name: "Wycheproof ChaCha20Poly1305 167 - Flipped bit 31 in tag expected tag:66405a16e8b44eba92aa47f5cea52b7a", xchacha: false, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b", aad: "000102", msg: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20", ciphertext: "d03bcb3ca52d48d1d203b1e7b1a5995af1a0466a61bb386a2e12d189a2c4ea15e9", tag: "66405a96e8b44eba92aa47f5cea52b7a", valid: false},
+	{name: "Wycheproof ChaCha20Poly1305 168 - Flipped bit 32 in tag expected tag:66405a16e8b44eba92aa47f5cea52b7a", xchacha: false, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b", aad: "000102", msg: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20", ciphertext: "d03bcb3ca52d48d1d203b1e7b1a5995af1a0466a61bb386a2e12d189a2c4ea15e9", tag: "66405a16e9b44eba92aa47f5cea52b7a", valid: false},
+	{name: "Wycheproof ChaCha20Poly1305 169 - Flipped bit 33 in tag expected tag:66405a16e8b44eba92aa47f5cea52b7a", xchacha: false, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b", aad: "000102", msg: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20", ciphertext: "d03bcb3ca52d48d1d203b1e7b1a5995af1a0466a61bb386a2e12d189a2c4ea15e9", tag: "66405a16eab44eba92aa47f5cea52b7a", valid: false},
+	{name: "Wycheproof ChaCha20Poly1305 170 - Flipped bit 63 in tag expected tag:66405a16e8b44eba92aa47f5cea52b7a", xchacha: false, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b", aad: "000102", msg: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20", ciphertext: "d03bcb3ca52d48d1d203b1e7b1a5995af1a0466a61bb386a2e12d189a2c4ea15e9", tag: "66405a16e8b44e3a92aa47f5cea52b7a", valid: false},
+	{name: "Wycheproof ChaCha20Poly1305 171 - Flipped bit 64 in tag expected tag:66405a16e8b44eba92aa47f5cea52b7a", xchacha: false, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b", aad: "000102", msg: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20", ciphertext: "d03bcb3ca52d48d1d203b1e7b1a5995af1a0466a61bb386a2e12d189a2c4ea15e9", tag: "66405a16e8b44eba93aa47f5cea52b7a", valid: false},
+	{name: "Wycheproof ChaCha20Poly1305 172 - Flipped bit 77 in tag expected tag:66405a16e8b44eba92aa47f5cea52b7a", xchacha: false, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b", aad: "000102", msg: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20", ciphertext: "d03bcb3ca52d48d1d203b1e7b1a5995af1a0466a61bb386a2e12d189a2c4ea15e9", tag: "66405a16e8b44eba928a47f5cea52b7a", valid: false},
+	{name: "Wycheproof ChaCha20Poly1305 173 - Flipped bit 80 in tag expected tag:66405a16e8b44eba92aa47f5cea52b7a", xchacha: false, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b", aad: "000102", msg: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20", ciphertext: "d03bcb3ca52d48d1d203b1e7b1a5995af1a0466a61bb386a2e12d189a2c4ea15e9", tag: "66405a16e8b44eba92aa46f5cea52b7a", valid: false},
+	{name: "Wycheproof ChaCha20Poly1305 174 - Flipped bit 96 in tag expected tag:66405a16e8b44eba92aa47f5cea52b7a", xchacha: false, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b", aad: "000102", msg: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20", ciphertext: "d03bcb3ca52d48d1d203b1e7b1a5995af1a0466a61bb386a2e12d189a2c4ea15e9", tag: "66405a16e8b44eba92aa47f5cfa52b7a", valid: false},
+	{name: "Wycheproof ChaCha20Poly1305 175 - Flipped bit 97 in tag expected tag:66405a16e8b44eba92aa47f5cea52b7a", xchacha: false, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b", aad: "000102", msg: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20", ciphertext: "d03bcb3ca52d48d1d203b1e7b1a5995af1a0466a61bb386a2e12d189a2c4ea15e9", tag: "66405a16e8b44eba92aa47f5cca52b7a", valid: false},
+	{name: "Wycheproof ChaCha20Poly1305 176 - Flipped bit 120 in tag expected tag:66405a16e8b44eba92aa47f5cea52b7a", xchacha: false, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b", aad: "000102", msg: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20", ciphertext: "d03bcb3ca52d48d1d203b1e7b1a5995af1a0466a61bb386a2e12d189a2c4ea15e9", tag: "66405a16e8b44eba92aa47f5cea52b7b", valid: false},
+	{name: "Wycheproof ChaCha20Poly1305 177 - Flipped bit 121 in tag expected tag:66405a16e8b44eba92aa47f5cea52b7a", xchacha: false, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b", aad: "000102", msg: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20", ciphertext: "d03bcb3ca52d48d1d203b1e7b1a5995af1a0466a61bb386a2e12d189a2c4ea15e9", tag: "66405a16e8b44eba92aa47f5cea52b78", valid: false},
+	{name: "Wycheproof ChaCha20Poly1305 178 - Flipped bit 126 in tag expected tag:66405a16e8b44eba92aa47f5cea52b7a", xchacha: false, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b", aad: "000102", msg: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20", ciphertext: "d03bcb3ca52d48d1d203b1e7b1a5995af1a0466a61bb386a2e12d189a2c4ea15e9", tag: "66405a16e8b44eba92aa47f5cea52b3a", valid: false},
+	{name: "Wycheproof ChaCha20Poly1305 179 - Flipped bit 127 in tag expected tag:66405a16e8b44eba92aa47f5cea52b7a", xchacha: false, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b", aad: "000102", msg: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20", ciphertext: "d03bcb3ca52d48d1d203b1e7b1a5995af1a0466a61bb386a2e12d189a2c4ea15e9", tag: "66405a16e8b44eba92aa47f5cea52bfa", valid: false},
+	{name: "Wycheproof ChaCha20Poly1305 180 - Flipped bit 63 and 127 in tag expected tag:66405a16e8b44eba92aa47f5cea52b7a", xchacha: false, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b", aad: "000102", msg: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20", ciphertext: "d03bcb3ca52d48d1d203b1e7b1a5995af1a0466a61bb386a2e12d189a2c4ea15e9", tag: "66405a16e8b44e3a92aa47f5cea52bfa", valid: false},
+	{name: "Wycheproof ChaCha20Poly1305 181 - Tag changed to all zero expected tag:66405a16e8b44eba92aa47f5cea52b7a", xchacha: false, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b", aad: "000102", msg: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20", ciphertext: "d03bcb3ca52d48d1d203b1e7b1a5995af1a0466a61bb386a2e12d189a2c4ea15e9", tag: "00000000000000000000000000000000", valid: false},
+	{name: "Wycheproof ChaCha20Poly1305 182 - tag change to all 1 expected tag:66405a16e8b44eba92aa47f5cea52b7a", xchacha: false, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b", aad: "000102", msg: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20", ciphertext: "d03bcb3ca52d48d1d203b1e7b1a5995af1a0466a61bb386a2e12d189a2c4ea15e9", tag: "ffffffffffffffffffffffffffffffff", valid: false},
+	{name: "Wycheproof ChaCha20Poly1305 183 - edge case for poly1305 key:ffffffefeb344f6bc37ba77ea2ee06dfe8c7f4ae10810422124fc5e1bd7fe301", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "000102030405060710abb165", aad: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", msg: "dc8ce708bf26aab862d97e1b42f31ef38c382cf07174142ea564920612997b1c2e38aca2438b588d5459493e97e7fa330ff9bc3b9458297ba0967d86ed090b435103478f2869b93ee29c837e95fb6b9903f3b735b7345428eb93b3db1d9b5187cebb889aa177d83e4f63fc9a5c0596eed939883d06aacdfdea44fdecdf5cb7fc", ciphertext: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", tag: "c296436246c3a7c4b3ba09ab2a6a0889", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 184 - edge case for poly1305 key:278de313ffffffdfffe9acbf3ea59357c4e16a5bc120d346af4a8cf694a84374", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "0001020304050607051e9373", aad: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", msg: "931227274a89d0b3aade7fac62c96262c1e77b8dafd248f10ad37c6ccb69cb7131b041593c8bb8c3db38f39dd8a124c424fce4389dede1d3cb9d46cf95970aea9856b6e313d756197baf4fcb58df275bca8a2188f9e8a1ad04354ede542ddc30e8b735b2f5905f5811799282be94ae842ec126c55d2e667235e9acf1d48798f0", ciphertext: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", tag: "99a3b0fff6fdcbcce9dc5820f2a64861", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 185 - edge case for poly1305 key:0050799fe9e74fcffcffffcfd21aa8b5cb5aa2c6ab347b6886eedaca4bfff3c0", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "0001020304050607048c3c5f", aad: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", msg: "0df91f31230e8941e700a752fef08c897c511ed618fdf8a378a1f439013b40a48d4634c27d9ada7c0bb6f3fa92e341425903d7ecd0c49bee4c77e84b11f1c721922308642885b813fae364da32eaf120d6a43a74fb1632443667bfea6eef1be73eb1c3c0b5a57cee8dc4feed4a1fb9ae02f7b1695588c3c878451cb6ee0cb3dc", ciphertext: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", tag: "eaff8f47ef9268fd0d94e8a9c4b78d24", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 186 - edge case for poly1305 key:dc46b3c53be153ccd4986678ffffffafe484c316c93f64195da65a2742fd3fec", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "000102030405060703e76f6f", aad: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", msg: "1fde9b9ec8b247d42bbee2016d6715ba428a85431430eada56a2c5dc944b6aa6cef0b056a2eecc51d30838e640615e1458e0943e30f91ba41b4362fa9ed6037b21d14da7b4f76f9f68fa8903138d563ce2590af1201c7cfec2290cfce98a822ebb8d1ed9dc4e20d241755aff91cdfd10fdb69efa0d5c8082692601cbfbb955c7", ciphertext: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", tag: "86ed21fda080a7d13981078d86b3e3cd", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 187 - edge case for poly1305 key:946aff9f2a13f56f92a5f9cfee3cdb1fef6d98d5a55ab563cb28620cd57f19d2", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "00010203040506072dd4cd40", aad: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", msg: "66115e67ecd3d4178c4c60e713ab4e5e66f8d1f971da17437a2b5e04fbca1671e847139a5f4e3f8e92d7a3b71eb4ff0e50354c0c1580af3662d5f8151e3f7e8264a0085c32ddfcbeb01a8be4c34d53319800ac4ef9d4e4014524bc7cd3387242e774f4d1a7a0521e42ec44844d0bd8b9d73fec959212fd7e8eacf4d984996d9b", ciphertext: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", tag: "34f9e0faa515eee0e784e6ef2678befa", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 188 - edge case for poly1305 key:0000003059ffce96438a246ff9536787d92bc40eafa0241a2972780ef6ca1ef8", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "000102030405060726c6961b", aad: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", msg: "e97244259af5a379238da0cad2a5f493655ec0e5024fd553bbb3deb66a94036d106c3d513407b2dd1cc5936c4c9c1e4f4b37b54dec261c601dc99e90680e23e2dc5c9a8d503d8bea49a8cdca3706bfd2a3daa0afb19a70fd3d355fc37c13f3f9e5c8d0864a5f80a780b36d4698ec2ce9ccc27b97ecbe672e41628ebd773acb81", ciphertext: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", tag: "3c94b9fe60bdb35c6b7b73b765083492", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 189 - edge case for poly1305 key:3fa0ea9c030000a036217d42e775ad189b96e24ee591952e2922ff151334b9ec", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "0001020304050607013da060", aad: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", msg: "9453aa159c3d87f17e21e88adabc37e553b904d00eefc66b8e0905e23576fbdc9c7bea9777f3b8368481932534b3344d309e6307cddfe7b3549300dd9cda7efe9d43c8a115912a392904079ee92bcd33099f7022ea94c1e7353b89bfc54de3ceb56f529a1a608bb5a970e1359609d1f56806b37f8605f4c27451da6066fc557a", ciphertext: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", tag: "2b11cf9f8db8490d409fc62afd7379f3", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 190 - edge case for poly1305 key:a556cb502baf395b020000f03c5108fb1cf76df1b8a8f724e877bd3c588d3285", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "000102030405060707db33de", aad: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", msg: "2e1836640d810c2709fb83ccf1aef3a971085d1bbfb58a425abf75ccec70b3abde0e80539e83a82546e7372a19481547053308dd7842675e9c4f61302426da0d71c1da3102031030ed928152be009b15b52f71b5911991d39f68a8658d99729df2bbef31c8989f9604558df9f2aba4b3766c58aaef3548de545ec1f080225a88", ciphertext: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", tag: "c9c8366920f88381407712cec61e6607", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 191 - edge case for poly1305 key:0c327fbcc564555545d4fe75020000d0a65799f363ec51b1c5c427b4a04af190", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "000102030405060702a11942", aad: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", msg: "0ecb4d85c956b5268c9b35a8c63b4e9d3e5cb72b64ef98773841b947bd7d59ef7d0eb0e1c050d49a5424ce7deb527d76087e4746674c958965df32d9e5fb03b46501706128d481217aaeae2f78f9259273358a2954cac0bc2fbfe77447d1d387b9314c6541b69f1270b3438b1042b2b4663e62ba4d49c07ac6f163034afa80af", ciphertext: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", tag: "2373cfa2ab24446ad5a236167b8027fe", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 192 - edge case for poly1305 key:415f08302f210340240d0e903e2b01205ba43e106aebd7e2481016b31118b1ae", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "00010203040506073c0df637", aad: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", msg: "2e8e45e903bfab32f2f0d49d9a3e449bef6f4093e2722cdab2cf935c1822b830fb5a4056516d560dfc8638c9a57d2927200a56f0b67153271d498e8f08dc888c61ef634f7ae40f4608f96f92fea5a1e5bd45131120098dc5de0378e58f2ddb46fa4aa5adb38fe006bb19b69146382f77a79e06214def547cfb5ce37a7008b9b6", ciphertext: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", tag: "5f93946478d8081e7247f414ad39a515", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 193 - edge case for poly1305 key:feffff1ff6b87403fd6435b09775bc92491a0ae62c5842a30e3b82710cc2dad1", xchacha: false, key: "9de836aa579585081f330a7c4036e20e38ef15eff3945184d231867f505fffdf", nonce: "00000000101112130bc672c3", aad: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", msg: "3619cb470af86dceceb6940f2d9abb34c9a9131476053387445ffebbe240d4f9818377855652f46a8219c7f71c3554f8acef8258de4b7d17c0f3d353ac981cc6a13287be1e6b41dc6d133df4ababebdf43d665ce7a4a5c982a0b139cb8202eebc74173e3224a440e4c37d2b595f384290e939ba016df0d49b36cdb4bd91c39", ciphertext: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", tag: "133fe62391744d11ce44594b96c53baf", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 194 - edge case for poly1305 key:bf358f18ffffffbf4b62ed6e1f53790785c4dabdfc72e2a219d377a682c85f38", xchacha: false, key: "9de836aa579585081f330a7c4036e20e38ef15eff3945184d231867f505fffdf", nonce: "000000001011121303e9b9a4", aad: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", msg: "af205bda819f7451be0f28667d4b01b59ff2daa8173cab52046c3c9e0d989889c5e021ef7afd06e9ce6cc30e3a6ebab509134ba10d10e570c55587c13eee53e73be54804c8539ffbf23b35922b1ca37b9e9bc24ee204837ca5a294ce05d12600c7eff6aee32270db2feff47dc5a04176169e15850628e6035f78994f9f5603", ciphertext: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", tag: "e3451adb9d23a7710a1aafba26f56387", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 195 - edge case for poly1305 key:d0b7b3a352a4010ffeffffbfe8cc66dc6e5e7451dc61762c5753174fed88e746", xchacha: false, key: "9de836aa579585081f330a7c4036e20e38ef15eff3945184d231867f505fffdf", nonce: "00000000101112130700b982", aad: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", msg: "68c67272036fb652a0182eeb4781358e4704a4a702fd731bf3b3ea994717989e7d9104e0ae81732a8c7e9a82b3d31d541761a366b67c3396f1a6c67e293ddb65a59e42541dda144dc6c78388cfca982e23350958ac5b3d54a1722fd64733577862e1879c9e9445ebdec5315d1706db7ebbedd4c779935e72057e5b0ecde081", ciphertext: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", tag: "b0bb8a55ff5f52a5043c6e7795847557", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 196 - edge case for poly1305 key:7bee33931a4157a8cb701becfeffff4fbe7e69f19cd065313bb49a252628dd3d", xchacha: false, key: "9de836aa579585081f330a7c4036e20e38ef15eff3945184d231867f505fffdf", nonce: "0000000010111213019836bb", aad: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", msg: "c483b7334ebe2e879b0c3f9db4fcd9f5219062360d6ce44cdae0f94e04c8345ea7e3ae33855118741dcafe0de4ae98c4e43af7b12b04ee8ab175625823ac040e5abac4403f1d45238adcb8c0cf44bd56917f9f5d93974c82b56951986a9c0450bd9047b5a616e814526ad0580e3ecd8189c9fef2cdb979a22ad3a01930fbd1", ciphertext: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", tag: "f4fc25f4c5543a9afee9819e2904fb68", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 197 - edge case for poly1305 key:7cb5fbdffb40ff5f3c7de74f655ffc1fac03013a7fe468440b861ebe0ab1650a", xchacha: false, key: "9de836aa579585081f330a7c4036e20e38ef15eff3945184d231867f505fffdf", nonce: "00000000101112131d59f288", aad: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", msg: "bc7f4f15fd1e4c1399740836670abe39a05707be19956ce169b32321759e0f213ae19ad34aa612b3a29f02c4bbac9f785a55a3adfe419ab891bbe0acee9921322ea21002c9dd3dcdd13a7f8554dddc10f9b529ce94be7050937dab76557b7eb17c685aad8f0797e39d62553988989aab1d9764fe431cc1d4c595062ce93ce9", ciphertext: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", tag: "5e67a7b8733e0e4b01ac2178a205ae7e", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 198 - edge case for poly1305 key:00000090e6e328c242cde5c83e3d8262d467f2bcd53d3755c781f3c6a2cb0648", xchacha: false, key: "9de836aa579585081f330a7c4036e20e38ef15eff3945184d231867f505fffdf", nonce: "00000000101112130552a411", aad: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", msg: "eaccaa778935ef249e0900149dd889462d2a061486ba102b8caebe465f3959fb3119ebb5689676ffdd6d851a26739e772b54a2f5f473ea9c7e58ccbc4cfc953e8c420b2175d9dd519265630bb79bd87a601b113231a8b16ce54c331347ec04c2b1c9160f38207aa46e96feb06dee883eb422fa14908df300bb1a1ef758c408", ciphertext: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", tag: "177a77fce114a4349c4f8d5ec825d06f", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 199 - edge case for poly1305 key:9e98d64e000000505a07183c5c68c63c14c9266dd37ff86aafc22ddbdb355617", xchacha: false, key: "9de836aa579585081f330a7c4036e20e38ef15eff3945184d231867f505fffdf", nonce: "00000000101112130c807a72", aad: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", msg: "a76c330e015060a17e64cb7b6d753f201f75be8759fd7539fb92b22aef54c9d3029dba0c15cbf7c95135888319c6b2e6276da21e0c351fd522b29aabb5883a3291d6f427de773b124390ef6fd96621ffbc42dfbf7a34da272cbc9ccb1a498d078033d1ac3bf7e92715948b06d69d5c5039e9164ba9c3a02219ec5908206b3b", ciphertext: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", tag: "623c7d4424f5497aedfd1339cf8cecce", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 200 - edge case for poly1305 key:1048a92e65f5e63102000080d9ae08de4319a7c45fdbe707b9ec1b7e0d635161", xchacha: false, key: "9de836aa579585081f330a7c4036e20e38ef15eff3945184d231867f505fffdf", nonce: "00000000101112130397a143", aad: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", msg: "228a7e15bcce13051de9145f77f7f4ff7921828b4f99efc4ff55ee0d9344955b69ec2d4798b0517f0273c4456ae5ffc5929cbe74ddb0da51d4f2b4df7578a31240c88ae922c3c5eca7b97d72d497062050a587447c562b343d5c71921944872f9fd06b8f34b3eb5d4341f5ff8a907dd7c2e1676b81252726ba54814da51eab", ciphertext: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", tag: "1c18b69354b189731a1a83fe8f0d57c9", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 201 - edge case for poly1305 key:01517a2ceb89bbfb5741f7d9000000401a65b132ad661072a00ffe7defbb18a5", xchacha: false, key: "9de836aa579585081f330a7c4036e20e38ef15eff3945184d231867f505fffdf", nonce: "000000001011121308cb0f3f", aad: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", msg: "c7d843188ab193dfef5c4daf583f952cd4b195f240fa2e704d021723023c123371a41e87dfc6e6c3874a42f331cf035988a38c72ba2da854b1208f98bf8cc29948169481ab3a402d5fcc7ff78f9e31925576dc3938074b8c5b27960e3afc750ad686563688b7441787288d5256c1301d563b7744843bd1ab4eff5be6f1653d", ciphertext: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", tag: "2045815b8211b9a2995effe0b8ed9868", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 202 - edge case for poly1305 key:bc90156087e0125006d90c30babd0590427bff19de1f2e7d0757a79528731138", xchacha: false, key: "9de836aa579585081f330a7c4036e20e38ef15eff3945184d231867f505fffdf", nonce: "00000000101112130d8fcf4e", aad: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", msg: "cfc3db8631c81c69023a3c8a9ad66c35053685144c4fa2a9510add72e211dad9ca5b982e4c194591fdb74116280311d1299ad81227258cb52f079bbcb12aff161d278dec33a326d71276b3de01a8327ee7f45f94179dff18a3fe643e56c30cfd03871c8110ab00f6612b9e17a4647360d7847bb63a3122613c2e7cdddd08ae", ciphertext: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", tag: "1ae2ed84ea9774d78d782bf8d972a8b8", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 203 - edge case for tag", xchacha: false, key: "404142434445464748494a4b4c4d4e4f505152535455565758595a5b5c5d5e5f", nonce: "000102030405060708090a0b", aad: "ffffffffffffffffffffffffffffffff415771fda4fbcc55c377f73203e60226", msg: "e48caf8a76183327c9561a4651c07c822ccd1642c06607d0d4bc0afb4de15915dbfa3b0b422e77e15c64bf6247031f15fdb643117809821870000adf83834da5", ciphertext: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", tag: "000102030405060708090a0b0c0d0e0f", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 204 - edge case for tag", xchacha: false, key: "404142434445464748494a4b4c4d4e4f505152535455565758595a5b5c5d5e5f", nonce: "000102030405060708090a0b", aad: "f1ffffffffffffffffffffffffffffff615af39eddb5fcd2519190d5507d3b06", msg: "e48caf8a76183327c9561a4651c07c822ccd1642c06607d0d4bc0afb4de15915dbfa3b0b422e77e15c64bf6247031f15fdb643117809821870000adf83834da5", ciphertext: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", tag: "00000000000000000000000000000000", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 205 - edge case for tag", xchacha: false, key: "404142434445464748494a4b4c4d4e4f505152535455565758595a5b5c5d5e5f", nonce: "000102030405060708090a0b", aad: "b5ffffffffffffffffffffffffffffff764e5d82ce7da0d44148484fd96a6107", msg: "e48caf8a76183327c9561a4651c07c822ccd1642c06607d0d4bc0afb4de15915dbfa3b0b422e77e15c64bf6247031f15fdb643117809821870000adf83834da5", ciphertext: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", tag: "ffffffffffffffffffffffffffffffff", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 206 - edge case for tag", xchacha: false, key: "404142434445464748494a4b4c4d4e4f505152535455565758595a5b5c5d5e5f", nonce: "000102030405060708090a0b", aad: "fdffffffffffffffffffffffffffffff2bdbf16d8ea4d39dab8dcb3d4bc4e104", msg: "e48caf8a76183327c9561a4651c07c822ccd1642c06607d0d4bc0afb4de15915dbfa3b0b422e77e15c64bf6247031f15fdb643117809821870000adf83834da5", ciphertext: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", tag: "00000080000000800000008000000080", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 207 - edge case for tag", xchacha: false, key: "404142434445464748494a4b4c4d4e4f505152535455565758595a5b5c5d5e5f", nonce: "000102030405060708090a0b", aad: "a9ffffffffffffffffffffffffffffffaccd5eb31d8fc909e84b0de7de23bb08", msg: "e48caf8a76183327c9561a4651c07c822ccd1642c06607d0d4bc0afb4de15915dbfa3b0b422e77e15c64bf6247031f15fdb643117809821870000adf83834da5", ciphertext: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", tag: "ffffff7fffffff7fffffff7fffffff7f", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 208 - edge case for tag", xchacha: false, key: "404142434445464748494a4b4c4d4e4f505152535455565758595a5b5c5d5e5f", nonce: "000102030405060708090a0b", aad: "d2ffffffffffffffffffffffffffffffdd4b933e7b1a7ed93cc7c050db71dc03", msg: "e48caf8a76183327c9561a4651c07c822ccd1642c06607d0d4bc0afb4de15915dbfa3b0b422e77e15c64bf6247031f15fdb643117809821870000adf83834da5", ciphertext: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", tag: "01000000010000000100000001000000", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 209 - edge case for tag", xchacha: false, key: "404142434445464748494a4b4c4d4e4f505152535455565758595a5b5c5d5e5f", nonce: "000102030405060708090a0b", aad: "ffffffffffffffffffffffffffffffffa08164425d7642e9e90fc8d5c32d2cf6", msg: "e48caf8a76183327c9561a4651c07c822ccd1642c06607d0d4bc0afb4de15915dbfa3b0b422e77e15c64bf6247031f15fdb643117809821870000adf83834da5", ciphertext: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", tag: "ffffffff000000000000000000000000", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 210 - edge case intermediate sums in poly1305. poly_key:ffffffefeb344f6bc37ba77ea2ee06dfe8c7f4ae10810422124fc5e1bd7fe301", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "000102030405060710abb165", aad: "ffffffff", msg: "c68ce708bf26aab862d97e1b42f31ef37bb66f8090c149e452ec7f20327eb2ea2e38aca2438b588d5459493e97e7fa330ff9bc23c897df6b00af86931d6c81555103478f2869b93ee29c837e95fb6b9903f3b72debfba2384baa48ceedfedb91", ciphertext: "e5ffffffffffffffffffffffffffffff0871bc8f1e4aa235087712d9df183609ffffffffffffffffffffffffffffffffffffffe7a33009ef5fc604ea0f9a75e9ffffffffffffffffffffffffffffffffffffffe7a33009ef5fc604ea0f9a75e9", tag: "3572162777262c518eef573b720e8e64", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 211 - edge case intermediate sums in poly1305. poly_key:ffffffefeb344f6bc37ba77ea2ee06dfe8c7f4ae10810422124fc5e1bd7fe301", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "000102030405060710abb165", aad: "ffffffff", msg: "c78ce708bf26aab862d97e1b42f31ef376209eef141691fba5d10eaf581affe62e38aca2438b588d5459493e97e7fa330e73d2dc3bbd954989cb8433b7d6597b5103478f2869b93ee29c837e95fb6b990279d9d218d1e81ac2ce4a6e474403bf", ciphertext: "e4ffffffffffffffffffffffffffffff05e74de09a9d7a2aff4a6356b57c7b05fffffffffffffffffffffffffffffffffe759118501a43cdd6a2064aa520adc7fffffffffffffffffffffffffffffffffe759118501a43cdd6a2064aa520adc7", tag: "347216375f5b7b5c4e6bff4912fd9473", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 212 - edge case intermediate sums in poly1305. poly_key:ffffffefeb344f6bc37ba77ea2ee06dfe8c7f4ae10810422124fc5e1bd7fe301", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "000102030405060710abb165", aad: "ffffffff", msg: "fc8ce708bf26aab862d97e1b42f31ef38b79403dfaabc0d8c18d23a3469c13e62e38aca2438b588d5459493e97e7fa330a4b941e6b66fcc2ed7d8cb3e8cc7ffc5103478f2869b93ee29c837e95fb6b9906419f10480a8191a67842ee185e2538", ciphertext: "dffffffffffffffffffffffffffffffff8be933274202b099b164e5aabfa9705fffffffffffffffffffffffffffffffffa4dd7da00c12a46b2140ecafa3a8b40fffffffffffffffffffffffffffffffffa4dd7da00c12a46b2140ecafa3a8b40", tag: "30721677ff2eb8894e5a9d8492b7b0af", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 213 - edge case intermediate sums in poly1305. poly_key:ffffffefeb344f6bc37ba77ea2ee06dfe8c7f4ae10810422124fc5e1bd7fe301", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "000102030405060710abb165", aad: "ffffffff", msg: "fa8ce708bf26aab862d97e1b42f31ef39bcbb8da477d580d772de4229bba7de22938aca2438b588d5459493e97e7fa331e9dedf9dd64a0681bac2969549425bc5603478f2869b93ee29c837e95fb6b991297e6f7fe08dd3b50a9e734a4067f78", ciphertext: "d9ffffffffffffffffffffffffffffffe80c6bd5c9f6b3dc2db689db76dcf901f8ffffffffffffffffffffffffffffffee9bae3db6c376ec44c5ab104662d100f8ffffffffffffffffffffffffffffffee9bae3db6c376ec44c5ab104662d100", tag: "2b7216c7873744c20ec5e2cdb260d3fa", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 214 - edge case intermediate sums in poly1305. poly_key:ffffffefeb344f6bc37ba77ea2ee06dfe8c7f4ae10810422124fc5e1bd7fe301", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "000102030405060710abb165", aad: "ffffffff", msg: "ee8ce708bf26aab862d97e1b42f31ef3b9f55bd56e0fd74b46063a96354cfbee3238aca2438b588d5459493e97e7fa3320c78886a6f6292d6cc5fbddb546a2b04d03478f2869b93ee29c837e95fb6b992ccd8388859a547e27c0358045d4f874", ciphertext: "cdffffffffffffffffffffffffffffffca3288dae0843c9a1c9d576fd82a7f0de3ffffffffffffffffffffffffffffffd0c1cb42cd51ffa933ac79a4a7b0560ce3ffffffffffffffffffffffffffffffd0c1cb42cd51ffa933ac79a4a7b0560c", tag: "22721657b0130d28cf1ec65153c41182", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 215 - edge case intermediate sums in poly1305. poly_key:ffffffefeb344f6bc37ba77ea2ee06dfe8c7f4ae10810422124fc5e1bd7fe301", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "000102030405060710abb165", aad: "ffffffff", msg: "ef8ce708bf26aab862d97e1b42f31ef3b46fca24d353ff5e49eac51540e840ea3038aca2438b588d5459493e97e7fa333d311e572202011a75e948586fe268b44f03478f2869b93ee29c837e95fb6b99313b1559016e7c493eec86059f703270", ciphertext: "ccffffffffffffffffffffffffffffffc7a8192b5dd8148f1371a8ecad8ec409e1ffffffffffffffffffffffffffffffcd375d9349a5d79e2a80ca217d149c08e1ffffffffffffffffffffffffffffffcd375d9349a5d79e2a80ca217d149c08", tag: "2172166798485c338f9a6d60f3b21891", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 216 - edge case intermediate sums in poly1305. poly_key:ffffffefeb344f6bc37ba77ea2ee06dfe8c7f4ae10810422124fc5e1bd7fe301", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "000102030405060710abb165", aad: "ffffffff", msg: "f59d56151de28bef83505f6d89c0b0f7f75b2fa8e6dce386075db283ec85ee62555baffad423af25f66069bb69fb6f4d", ciphertext: "d6ee4ee25d3bdea81e76de8934cc51fb849cfca7685708575dc6df7a01e36a81849cfca7685708575dc6df7a01e36a81", tag: "831312cbb0f165dc3e8ff52125f48640", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 217 - edge case intermediate sums in poly1305. poly_key:ffffffefeb344f6bc37ba77ea2ee06dfe8c7f4ae10810422124fc5e1bd7fe301", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "000102030405060710abb165", aad: "ffffffff", msg: "f717f8d5b28032d5c8e8061cd44d71e4f2d55de772fe7a91ce85e410db3e2d8d50d5ddb5400136323fb83f285e40aca2", ciphertext: "d464e022f259679255ce87f8694190e881128ee8fc759140941e89e93658a96e81128ee8fc759140941e89e93658a96e", tag: "821312db9826b5e7fe0a9d30c5e28d4f", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 218 - edge case intermediate sums in poly1305. poly_key:ffffffefeb344f6bc37ba77ea2ee06dfe8c7f4ae10810422124fc5e1bd7fe301", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "000102030405060710abb165", aad: "ffffffff", msg: "f28ce708bf26aab862d97e1b42f31ef3e68a922c9219d30f07554d7d99f2bde92c38aca2438b588d5459493e97e7fa33e24c07dd98f9b253ab0c318d9b14f6b15303478f2869b93ee29c837e95fb6b99ee460cd3bb95cf00e009ffd06b86ac75", ciphertext: "d1ffffffffffffffffffffffffffffff954d41231c9238de5dce20847494390afdffffffffffffffffffffffffffffff124a4419f35e64d7f465b3f489e2020dfdffffffffffffffffffffffffffffff124a4419f35e64d7f465b3f489e2020d", tag: "c1045769d487d545cef3f0d34b7a8733", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 219 - edge case intermediate sums in poly1305. poly_key:ffffffefeb344f6bc37ba77ea2ee06dfe8c7f4ae10810422124fc5e1bd7fe301", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "000102030405060710abb165", aad: "ffffffff", msg: "dc8ce708bf26aab862d97e1b42f31ef32e6784d857df07543d0dc72f179935fbede8c8baf01ee2044b162cbb343b355acc29d82327cd93f2bfd918034ed5c42a", ciphertext: "ffffffffffffffffffffffffffffffff5da057d7d954ec856796aad6faffb1183c2f9be74c6a4576e0b09a7a5c2330963c2f9be74c6a4576e0b09a7a5c233096", tag: "64e7efd24516a83e2c87e06a76e2dea3", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 220 - edge case intermediate sums in poly1305. poly_key:ffffffefeb344f6bc37ba77ea2ee06dfe8c7f4ae10810422124fc5e1bd7fe301", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "000102030405060710abb165", aad: "ffffffff", msg: "f78ce708bf26aab862d97e1b42f31ef34c6ead26f84a0225d557745d32fc72e72c38aca2438b588d5459493e97e7fa3364db334b69bee579383e61ae742c71bb5303478f2869b93ee29c837e95fb6b9968d138454ad2982a733baff384be2b7f", ciphertext: "d4ffffffffffffffffffffffffffffff3fa97e2976c1e9f48fcc19a4df9af604fdffffffffffffffffffffffffffffff94dd708f021933fd6757e3d766da8507fdffffffffffffffffffffffffffffff94dd708f021933fd6757e3d766da8507", tag: "e6cc6729d79ba558cd73b03cba54d660", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 221 - edge case intermediate sums in poly1305. poly_key:ffffffefeb344f6bc37ba77ea2ee06dfe8c7f4ae10810422124fc5e1bd7fe301", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "000102030405060710abb165", aad: "ffffffff", msg: "f08ce708bf26aab862d97e1b42f31ef34fd8c3757c9f2938dc3b07d85898bfe22a38aca2438b588d5459493e97e7fa336155412415cbdd760142b62c2ec83fbf5503478f2869b93ee29c837e95fb6b996d5f4a2a36a7a0254a477871de5a657b", ciphertext: "d3ffffffffffffffffffffffffffffff3c1f107af214c2e986a06a21b5fe3b01fbffffffffffffffffffffffffffffff915302e07e6c0bf25e2b34553c3ecb03fbffffffffffffffffffffffffffffff915302e07e6c0bf25e2b34553c3ecb03", tag: "e5cc6739bfd0f4638def574b5a43dd6f", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 222 - edge case intermediate sums in poly1305. poly_key:ffffffefeb344f6bc37ba77ea2ee06dfe8c7f4ae10810422124fc5e1bd7fe301", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "000102030405060710abb165", aad: "ffffffff", msg: "f28ce708bf26aab862d97e1b42f31ef3df03ca84082f7f70ad8e4004cabd2ce42b38aca2438b588d5459493e97e7fa3328fd413caab1d02bf1c65753aa2ad3b95403478f2869b93ee29c837e95fb6b9924f74a3289ddad78bac3990e5ab8897d", ciphertext: "d1ffffffffffffffffffffffffffffffacc4198b86a494a1f7152dfd27dba807faffffffffffffffffffffffffffffffd8fb02f8c11606afaeafd52ab8dc2705faffffffffffffffffffffffffffffffd8fb02f8c11606afaeafd52ab8dc2705", tag: "0fca702228817d53ee64d142b192e665", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 223 - edge case intermediate sums in poly1305. poly_key:ffffffefeb344f6bc37ba77ea2ee06dfe8c7f4ae10810422124fc5e1bd7fe301", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "000102030405060710abb165", aad: "ffffffff", msg: "f38ce708bf26aab862d97e1b42f31ef31ffc31ae69399394b8c338674c3dfde92938aca2438b588d5459493e97e7fa33477ec8cf3ea3d4d5d76d85ad2b7f0bb85603478f2869b93ee29c837e95fb6b994b74c3c11dcfa9869c684bf0dbed517c", ciphertext: "d0ffffffffffffffffffffffffffffff6c3be2a1e7b27845e258559ea15b790af8ffffffffffffffffffffffffffffffb7788b0b55040251880407d43989ff04f8ffffffffffffffffffffffffffffffb7788b0b55040251880407d43989ff04", tag: "efc3b035ded6b460bfce6f494955e677", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 224 - edge case intermediate sums in poly1305. poly_key:ffffffefeb344f6bc37ba77ea2ee06dfe8c7f4ae10810422124fc5e1bd7fe301", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "000102030405060710abb165", aad: "ffffffff", msg: "2bfd0d56ece98771756d60d9d9106cd0c6fc106936c7ef347c078fd71c54228164fc903b0438a3978d3a54ef992aa3ae", ciphertext: "088e15a1ac30d236e84be13d641c8ddcb53bc366b84c04e5269ce22ef132a662b53bc366b84c04e5269ce22ef132a662", tag: "345fc9fe573c136c1be83730500ce662", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 225 - edge case intermediate sums in poly1305. poly_key:ffffffefeb344f6bc37ba77ea2ee06dfe8c7f4ae10810422124fc5e1bd7fe301", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "000102030405060710abb165", aad: "ffffffff", msg: "f68ce708bf26aab862d97e1b42f31ef37cc2255decdf8e0fe1373591da0e28e42838aca2438b588d5459493e97e7fa33e291fb4838019c51dfb7141515bb53b15703478f2869b93ee29c837e95fb6b99ee9bf0461b6de10294b2da48e5290975", ciphertext: "d5ffffffffffffffffffffffffffffff0f05f652625465debbac58683768ac07f9ffffffffffffffffffffffffffffff1297b88c53a64ad580de966c074da70df9ffffffffffffffffffffffffffffff1297b88c53a64ad580de966c074da70d", tag: "336f97a5faa995a2a03781b591588da8", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 226 - edge case intermediate sums in poly1305. poly_key:ffffffefeb344f6bc37ba77ea2ee06dfe8c7f4ae10810422124fc5e1bd7fe301", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "000102030405060710abb165", aad: "ffffffff", msg: "c68ce708bf26aab862d97e1b42f31ef37ab66f8090c149e452ec7f20327eb2ea0438aca2438b588d5459493e97e7fa338d2613ea0ef8b656b247373ecec015bc7b03478f2869b93ee29c837e95fb6b99812c18e42d94cb05f942f9633e524f78", ciphertext: "e5ffffffffffffffffffffffffffffff0971bc8f1e4aa235087712d9df183609d5ffffffffffffffffffffffffffffff7d20502e655f60d2ed2eb547dc36e100d5ffffffffffffffffffffffffffffff7d20502e655f60d2ed2eb547dc36e100", tag: "9351c680c8a5d34882d42145e89745c4", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 227 - edge case intermediate sums in poly1305. poly_key:ffffffefeb344f6bc37ba77ea2ee06dfe8c7f4ae10810422124fc5e1bd7fe301", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "000102030405060710abb165", aad: "ffffffff", msg: "c68ce708bf26aab862d97e1b42f31ef374b66f8090c149e452ec7f20327eb2ea2e38aca2438b588d5459493e97e7fa33acd9ec859e0866620cc24c8a97d5d9f55103478f2869b93ee29c837e95fb6b99a0d3e78bbd641b3147c782d767478331", ciphertext: "e5ffffffffffffffffffffffffffffff0771bc8f1e4aa235087712d9df183609ffffffffffffffffffffffffffffffff5cdfaf41f5afb0e653abcef385232d49ffffffffffffffffffffffffffffffff5cdfaf41f5afb0e653abcef385232d49", tag: "d79266cd25a784599a0a8e31fc84d604", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 228 - edge case intermediate sums in poly1305. poly_key:ffffffefeb344f6bc37ba77ea2ee06dfe8c7f4ae10810422124fc5e1bd7fe301", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "000102030405060710abb165", aad: "ffffffff", msg: "f78ce708bf26aab862d97e1b42f31ef34251cd29b0aaa960557c9ea2828334e4e4e231db0a27fac9ec9e744886eb0133c5232142ddf48b3f185140f0fc05f043", ciphertext: "d4ffffffffffffffffffffffffffffff31961e263e2142b10fe7f35b6fe5b00735256286b6535dbb4738c289eef304ff35256286b6535dbb4738c289eef304ff", tag: "9d671d407d7660459d5d582d83915efe", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 229 - edge case intermediate sums in poly1305. poly_key:ffffffefeb344f6bc37ba77ea2ee06dfe8c7f4ae10810422124fc5e1bd7fe301", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "000102030405060710abb165", aad: "ffffffff", msg: "f58ce708bf26aab862d97e1b42f31ef373bd9f01bf3331b12e31dd14cf11feee1d38aca2438b588d5459493e97e7fa33625c6965f61a1c36118c747076d5b7b76203478f2869b93ee29c837e95fb6b996e56626bd57661655a89ba2d8647ed73", ciphertext: "d6ffffffffffffffffffffffffffffff007a4c0e31b8da6074aab0ed22777a0dccffffffffffffffffffffffffffffff925a2aa19dbdcab24ee5f6096423430bccffffffffffffffffffffffffffffff925a2aa19dbdcab24ee5f6096423430b", tag: "7b207c2c3278c64f0d6b913fe371fe63", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 230 - edge case intermediate sums in poly1305. poly_key:ffffffefeb344f6bc37ba77ea2ee06dfe8c7f4ae10810422124fc5e1bd7fe301", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "000102030405060710abb165", aad: "ffffffff", msg: "dc8ce708bf26aab862d97e1b42f31ef3ec0933f0bfb91218cea0d74e061f559e2d38aca2438b588d5459493e97e7fa338d5b67e0acee534ce2d9791487b1ecb25203478f2869b93ee29c837e95fb6b9981516cee8f822e1fa9dcb7497723b676", ciphertext: "ffffffffffffffffffffffffffffffff9fcee0ff3132f9c9943bbab7eb79d17dfcffffffffffffffffffffffffffffff7d5d2424c74985c8bdb0fb6d9547180efcffffffffffffffffffffffffffffff7d5d2424c74985c8bdb0fb6d9547180e", tag: "3672162bb1f3ff537ece013f1aca4f68", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 231 - edge case intermediate sums in poly1305. poly_key:ffffffefeb344f6bc37ba77ea2ee06dfe8c7f4ae10810422124fc5e1bd7fe301", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "000102030405060710abb165", aad: "ffffffff", msg: "dc8ce708bf26aab862d97e1b42f31ef3ee83a14f48db696291080edfcc898b882b38aca2438b588d5459493e97e7fa338ad5f6b0283a8b39ebedce92785da9b65403478f2869b93ee29c837e95fb6b9986dffdbe0b56f66aa0e800cf88cff372", ciphertext: "ffffffffffffffffffffffffffffffff9d447240c65082b3cb93632621ef0f6bfaffffffffffffffffffffffffffffff7ad3b574439d5dbdb4844ceb6aab5d0afaffffffffffffffffffffffffffffff7ad3b574439d5dbdb4844ceb6aab5d0a", tag: "3572163b99284f5f3e4aa94dbab85677", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 232 - edge case intermediate sums in poly1305. poly_key:ffffffefeb344f6bc37ba77ea2ee06dfe8c7f4ae10810422124fc5e1bd7fe301", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "000102030405060710abb165", aad: "ffffffff", msg: "dc8ce708bf26aab862d97e1b42f31ef3e87dd08ed4e4e04c5877616cbb02cabb2938aca2438b588d5459493e97e7fa33874f0401d457e336f4311f1152f957ba5603478f2869b93ee29c837e95fb6b998b450f0ff73b9e65bf34d14ca26b0d7e", ciphertext: "ffffffffffffffffffffffffffffffff9bba03815a6f0b9d02ec0c9556644e58f8ffffffffffffffffffffffffffffff774947c5bff035b2ab589d68400fa306f8ffffffffffffffffffffffffffffff774947c5bff035b2ab589d68400fa306", tag: "3472164b815d9e6afec5505c5aa75d86", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 233 - edge case intermediate sums in poly1305. poly_key:ffffffefeb344f6bc37ba77ea2ee06dfe8c7f4ae10810422124fc5e1bd7fe301", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "000102030405060710abb165", aad: "ffffffff", msg: "c88ce708bf26aab862d97e1b42f31ef36be436e346f8f2b32f4cbbaef95150ef0438aca2438b588d5459493e97e7fa332fb76b5132e930f6d0acf70875e977b57b03478f2869b93ee29c837e95fb6b9923bd605f11854da59ba93955857b2d71", ciphertext: "ebffffffffffffffffffffffffffffff1823e5ecc873196275d7d6571437d40cd5ffffffffffffffffffffffffffffffdfb12895594ee6728fc57571671f8309d5ffffffffffffffffffffffffffffffdfb12895594ee6728fc57571671f8309", tag: "3a7216d7ee1da018ce8412f251656b19", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 234 - edge case intermediate sums in poly1305. poly_key:ffffffefeb344f6bc37ba77ea2ee06dfe8c7f4ae10810422124fc5e1bd7fe301", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "000102030405060710abb165", aad: "ffffffff", msg: "c58ce708bf26aab862d97e1b42f31ef3783cf9302c7d22914b38aca2e7d374ef1d38aca2438b588d5459493e97e7fa33228f2d23597640d574f8e20c4f6b6bb56203478f2869b93ee29c837e95fb6b992e85262d7a1a3d863ffd2c51bff93171", ciphertext: "e6ffffffffffffffffffffffffffffff0bfb2a3fa2f6c94011a3c15b0ab5f00cccffffffffffffffffffffffffffffffd2896ee732d196512b9160755d9d9f09ccffffffffffffffffffffffffffffffd2896ee732d196512b9160755d9d9f09", tag: "367216178ff1dc45ce73b02cd21f8755", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 235 - edge case intermediate sums in poly1305. poly_key:ffffffefeb344f6bc37ba77ea2ee06dfe8c7f4ae10810422124fc5e1bd7fe301", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "000102030405060710abb165", aad: "ffffffff", msg: "dc8ce708bf26aab862d97e1b42f31ef35db72f89d1402b1a0373ff0a9c5cd44b6d67af40798f5455501792953248ec234ca6bfd9ae5c25a3a4d8a62d48a61d53", ciphertext: "ffffffffffffffffffffffffffffffff2e70fc865fcbc0cb59e892f3713a50a8bca0fc1dc5fbf327fbb124545a50e9efbca0fc1dc5fbf327fbb124545a50e9ef", tag: "0b4961c9525ea2f2cdad6273e1c7824c", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 236 - edge case intermediate sums in poly1305. poly_key:ffffffefeb344f6bc37ba77ea2ee06dfe8c7f4ae10810422124fc5e1bd7fe301", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "000102030405060710abb165", aad: "ffffffff", msg: "dc8ce708bf26aab862d97e1b42f31ef35f215ec87d62a264cadb519b4ac90a7668d1dd03e56eda6399ac7803e7dd22114910cd9a32bdab956d634cbb9d33d361", ciphertext: "ffffffffffffffffffffffffffffffff2ce68dc7f3e949b590403c62a7af8e95b9168e5e591a7d11320acec28fc527ddb9168e5e591a7d11320acec28fc527dd", tag: "0a4961d93a93f1fd8d290a8281b6895b", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 237 - edge case intermediate sums in poly1305. poly_key:ffffffefeb344f6bc37ba77ea2ee06dfe8c7f4ae10810422124fc5e1bd7fe301", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "000102030405060710abb165", aad: "ffffffff", msg: "dc8ce708bf26aab862d97e1b42f31ef3d15ad590dd0f40ba18acd168f6ac777a0f38aca2438b588d5459493e97e7fa33932a097f1d39a04ad30f1b6c650260bf7003478f2869b93ee29c837e95fb6b999f2002713e55dd19980ad53195903a7b", ciphertext: "ffffffffffffffffffffffffffffffffa29d069f5384ab6b4237bc911bcaf399deffffffffffffffffffffffffffffff632c4abb769e76ce8c66991577f49403deffffffffffffffffffffffffffffff632c4abb769e76ce8c66991577f49403", tag: "3572161355240943de9406292a64c551", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 238 - edge case intermediate sums in poly1305. poly_key:946aff9f2a13f56f92a5f9cfee3cdb1fef6d98d5a55ab563cb28620cd57f19d2", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "00010203040506072dd4cd40", aad: "ffffffff", msg: "40115e67ecd3d4178c4c60e713ab4e5e390ef93aeb61aa307f141323c38e0685fa47139a5f4e3f8e92d7a3b71eb4ff0e259445f4ffc31bce540190edd6ad207876a0085c32ddfcbeb01a8be4c34d5331eda1a5b6139750f973f0d4841baa2cb8", ciphertext: "d9ffffffffffffffffffffffffffffffa009d73c6544428cfac0b2d8c7bbef0bedffffffffffffffffffffffffffffff8a5ef60715bc4b07c92b9707376da105edffffffffffffffffffffffffffffff8a5ef60715bc4b07c92b9707376da105", tag: "19532d9fa0b5fbd582aaeda830602f1d", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 239 - edge case intermediate sums in poly1305. poly_key:946aff9f2a13f56f92a5f9cfee3cdb1fef6d98d5a55ab563cb28620cd57f19d2", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "00010203040506072dd4cd40", aad: "ffffffff", msg: "49115e67ecd3d4178c4c60e713ab4e5ee02b87aeae8c3da8895f8cb0f6b9cc80f447139a5f4e3f8e92d7a3b71eb4ff0ecc4b7b803a5f8f4647df169080fe567a78a0085c32ddfcbeb01a8be4c34d5331047e9bc2d60bc471602e52f94df95aba", ciphertext: "d0ffffffffffffffffffffffffffffff792ca9a820a9d5140c8b2d4bf28c250ee3ffffffffffffffffffffffffffffff6381c873d020df8fdaf5117a613ed707e3ffffffffffffffffffffffffffffff6381c873d020df8fdaf5117a613ed707", tag: "adbd2cafc8c8f0e51250e7b81c9d0a2d", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 240 - edge case intermediate sums in poly1305. poly_key:946aff9f2a13f56f92a5f9cfee3cdb1fef6d98d5a55ab563cb28620cd57f19d2", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "00010203040506072dd4cd40", aad: "ffffffff", msg: "43eadae036f733ea9b5b7eb22aee395db6f51a4d10bc2460810c229651556acf384ad82e3e280cad69f0df25b42b83b0", ciphertext: "da047b7825db1802e8e8e1aac6ba88fc2ff2344b9e99ccdc04d8836d556083412ff2344b9e99ccdc04d8836d55608341", tag: "973e270a7afcab75348e14dbe19c5156", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 241 - edge case intermediate sums in poly1305. poly_key:946aff9f2a13f56f92a5f9cfee3cdb1fef6d98d5a55ab563cb28620cd57f19d2", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "00010203040506072dd4cd40", aad: "ffffffff", msg: "66115e67ecd3d4178c4c60e713ab4e5e891b797521ba925b24090aaf6c4482bae847139a5f4e3f8e92d7a3b71eb4ff0e6d50c32d05a946cb8cea57c9f1442cb164a0085c32ddfcbeb01a8be4c34d5331a565236fe9fd0dfcab1b13a03c432071", ciphertext: "ffffffffffffffffffffffffffffffff101c5773af9f7ae7a1ddab5468716b34ffffffffffffffffffffffffffffffffc29a70deefd6160211c050231084adccffffffffffffffffffffffffffffffffc29a70deefd6160211c050231084adcc", tag: "e17c273f31758e752322ae4869c1bfbb", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 242 - edge case intermediate sums in poly1305. poly_key:946aff9f2a13f56f92a5f9cfee3cdb1fef6d98d5a55ab563cb28620cd57f19d2", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "00010203040506072dd4cd40", aad: "ffffffff", msg: "6a115e67ecd3d4178c4c60e713ab4e5e519cccebf72573dbee8c12f74255d18c0add1035861ffc0b7f40079b969f8c63b2af4fa3ccd16cb38f425c3996140def", ciphertext: "f3ffffffffffffffffffffffffffffffc89be2ed79009b676b58b30c466038021d65fc5026ae3c7a12685bd377d48c921d65fc5026ae3c7a12685bd377d48c92", tag: "a22390224c5db0f01696743d870725c5", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 243 - edge case intermediate sums in poly1305. poly_key:946aff9f2a13f56f92a5f9cfee3cdb1fef6d98d5a55ab563cb28620cd57f19d2", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "00010203040506072dd4cd40", aad: "ffffffff", msg: "e235b8c21384557085c3f2eb2a8fa36058cffd2af743dacf96b4ae4d51b4e488d6703f49d9d7f2027e4853feb4ca0df7", ciphertext: "7bdb195a00a87e98f6706df3c6db12c1c1c8d32c7966327313600fb655810d06c1c8d32c7966327313600fb655810d06", tag: "437d1efad21b0865a541b5cab62e2a44", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 244 - edge case intermediate sums in poly1305. poly_key:946aff9f2a13f56f92a5f9cfee3cdb1fef6d98d5a55ab563cb28620cd57f19d2", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "00010203040506072dd4cd40", aad: "ffffffff", msg: "66115e67ecd3d4178c4c60e713ab4e5e8fab58574a322bac6f394474e4ce7eaec347139a5f4e3f8e92d7a3b71eb4ff0e71532dfb0e9141b00983394722829e7c4fa0085c32ddfcbeb01a8be4c34d5331b966cdb9e2c50a872e727d2eef8592bc", ciphertext: "ffffffffffffffffffffffffffffffff16ac7651c417c310eaede58fe0fb9720d4ffffffffffffffffffffffffffffffde999e08e4ee117994a93eadc3421f01d4ffffffffffffffffffffffffffffffde999e08e4ee117994a93eadc3421f01", tag: "acf4ffa20c0d06d61a18e9a8d4c84d1d", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 245 - edge case intermediate sums in poly1305. poly_key:946aff9f2a13f56f92a5f9cfee3cdb1fef6d98d5a55ab563cb28620cd57f19d2", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "00010203040506072dd4cd40", aad: "ffffffff", msg: "61115e67ecd3d4178c4c60e713ab4e5e5efe679ba17384c55eb8cc193666fe8d04608c3503d217aa3f90a9b0e1b3b313bc12d3a3491c8712cf92f212e138329f", ciphertext: "f8ffffffffffffffffffffffffffffffc7f9499d2f566c79db6c6de23253170313d86050a363d7db52b8f5f800f8b3e213d86050a363d7db52b8f5f800f8b3e2", tag: "cd466d06e75b7fd18d5fe21d9227d9a7", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 246 - edge case intermediate sums in poly1305. poly_key:946aff9f2a13f56f92a5f9cfee3cdb1fef6d98d5a55ab563cb28620cd57f19d2", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "00010203040506072dd4cd40", aad: "ffffffff", msg: "9064b88a282052a1ee44df05ad213da679f8d1f971da17437a2b5e04fbca167151b2650ec945fec70588bc65a616a5f24f354c0c1580af3662d5f8151e3f7e82dd557ec8a4d63df7274594367bef09cd", ciphertext: "098a19123b0c79499df7401d41758c07e0ffffffffffffffffffffffffffffff460a896b69f43eb668a0e02d475da503e0ffffffffffffffffffffffffffffff460a896b69f43eb668a0e02d475da503", tag: "ce8a3d4d887d95613d829b538ed01196", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 247 - edge case intermediate sums in poly1305. poly_key:946aff9f2a13f56f92a5f9cfee3cdb1fef6d98d5a55ab563cb28620cd57f19d2", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "00010203040506072dd4cd40", aad: "ffffffff", msg: "43115e67ecd3d4178c4c60e713ab4e5eeef67bd4795b74015a3493905d544a86e847139a5f4e3f8e92d7a3b71eb4ff0e3197be28eff843592bd8fc8d578421d664a0085c32ddfcbeb01a8be4c34d5331f9a25e6a03ac086e0c29b8e49a832d16", ciphertext: "daffffffffffffffffffffffffffffff77f155d2f77e9cbddfe0326b5961a308ffffffffffffffffffffffffffffffff9e5d0ddb05871390b6f2fb67b644a0abffffffffffffffffffffffffffffffff9e5d0ddb05871390b6f2fb67b644a0ab", tag: "08289f5199df476fe90475cb95225566", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 248 - edge case intermediate sums in poly1305. poly_key:946aff9f2a13f56f92a5f9cfee3cdb1fef6d98d5a55ab563cb28620cd57f19d2", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "00010203040506072dd4cd40", aad: "ffffffff", msg: "6b115e67ecd3d4178c4c60e713ab4e5e1e34412ab0a056e809d5d4b92be1128a4b2a651a62aeab26cf437fb195407574f3583a8c28603b9e3f41241395cbf4f8", ciphertext: "f2ffffffffffffffffffffffffffffff87336f2c3e85be548c0175422fd4fb045c92897fc21f6b57a26b23f9740b75855c92897fc21f6b57a26b23f9740b7585", tag: "06df93f651ea5cc56911f30d3e58f997", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 249 - edge case intermediate sums in poly1305. poly_key:946aff9f2a13f56f92a5f9cfee3cdb1fef6d98d5a55ab563cb28620cd57f19d2", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "00010203040506072dd4cd40", aad: "ffffffff", msg: "3fe606108f35869df4c7aa0128464a1265f8d1f971da17437a2b5e04fbca1671fdbe843a0ad9be25055992ab6dcbc9f153354c0c1580af3662d5f8151e3f7e8271599ffc674a7d152794baf8b03265ce", ciphertext: "a608a7889c19ad7587743519c412fbb3fcffffffffffffffffffffffffffffffea06685faa687e546871cee38c80c900fcffffffffffffffffffffffffffffffea06685faa687e546871cee38c80c900", tag: "9264fc0f47febb30661254daf9a06189", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 250 - edge case intermediate sums in poly1305. poly_key:946aff9f2a13f56f92a5f9cfee3cdb1fef6d98d5a55ab563cb28620cd57f19d2", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "00010203040506072dd4cd40", aad: "ffffffff", msg: "6e8eb98cf7fffe4cd683568cf892991564f8d1f971da17437a2b5e04fbca1671c70f5d8b30c64bf2e6d1d613f40e0bf052354c0c1580af3662d5f8151e3f7e824be8464d5d5588c2c41cfe4029f7a7cf", ciphertext: "f7601814e4d3d5a4a530c99414c628b4fdffffffffffffffffffffffffffffffd0b7b1ee90778b838bf98a5b15450b01fdffffffffffffffffffffffffffffffd0b7b1ee90778b838bf98a5b15450b01", tag: "69a124fc7f96e220d1a031ced5527279", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 251 - edge case intermediate sums in poly1305. poly_key:946aff9f2a13f56f92a5f9cfee3cdb1fef6d98d5a55ab563cb28620cd57f19d2", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "00010203040506072dd4cd40", aad: "ffffffff", msg: "4f115e67ecd3d4178c4c60e713ab4e5e4156269fe3da101eeb0abf8dda20fe8fff47139a5f4e3f8e92d7a3b71eb4ff0e6aece983e64f97e43ff5295bc884fa7773a0085c32ddfcbeb01a8be4c34d5331a2d909c10a1bdcd318046d320583f6b7", ciphertext: "d6ffffffffffffffffffffffffffffffd85108996dfff8a26ede1e76de151701e8ffffffffffffffffffffffffffffffc5265a700c30c72da2df2eb129447b0ae8ffffffffffffffffffffffffffffffc5265a700c30c72da2df2eb129447b0a", tag: "3ea8f9b2012321e63d5fb5bc2c5d332d", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 252 - edge case intermediate sums in poly1305. poly_key:946aff9f2a13f56f92a5f9cfee3cdb1fef6d98d5a55ab563cb28620cd57f19d2", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "00010203040506072dd4cd40", aad: "ffffffff", msg: "66115e67ecd3d4178c4c60e713ab4e5e18f125ef374c1454b680e23427e7dc69e447139a5f4e3f8e92d7a3b71eb4ff0e858b08eb1d581570a7cd1e48593b757568a0085c32ddfcbeb01a8be4c34d53314dbee8a9f10c5e47803c5a21943c79b5", ciphertext: "ffffffffffffffffffffffffffffffff81f60be9b969fce8335443cf23d235e7f3ffffffffffffffffffffffffffffff2a41bb18f72745b93ae719a2b8fbf408f3ffffffffffffffffffffffffffffff2a41bb18f72745b93ae719a2b8fbf408", tag: "dfaf8a3a15d45e7f4c3430048d8589f0", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 253 - edge case intermediate sums in poly1305. poly_key:946aff9f2a13f56f92a5f9cfee3cdb1fef6d98d5a55ab563cb28620cd57f19d2", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "00010203040506072dd4cd40", aad: "ffffffff", msg: "b02ab747a310d6a3bbdb97018a3be8b341f8d1f971da17437a2b5e04fbca1671b7a338bc3423895f0fd96cdb27a787f277354c0c1580af3662d5f8151e3f7e823b44237a59b04a6f2d144488fa5e2bcd", ciphertext: "29c416dfb03cfd4bc8680819666f5912d8ffffffffffffffffffffffffffffffa01bd4d99492492e62f13093c6ec8703d8ffffffffffffffffffffffffffffffa01bd4d99492492e62f13093c6ec8703", tag: "3408eb2b13a9b76befcedf699422d61f", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 254 - edge case intermediate sums in poly1305. poly_key:946aff9f2a13f56f92a5f9cfee3cdb1fef6d98d5a55ab563cb28620cd57f19d2", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "00010203040506072dd4cd40", aad: "ffffffff", msg: "40115e67ecd3d4178c4c60e713ab4e5e380ef93aeb61aa307f141323c38e0685f647139a5f4e3f8e92d7a3b71eb4ff0e3f769a30e8951ff2fb365fa780fdde7e7aa0085c32ddfcbeb01a8be4c34d5331f7437a7204c154c5dcc71bce4dfad2be", ciphertext: "d9ffffffffffffffffffffffffffffffa109d73c6544428cfac0b2d8c7bbef0be1ffffffffffffffffffffffffffffff90bc29c302ea4f3b661c584d613d5f03e1ffffffffffffffffffffffffffffff90bc29c302ea4f3b661c584d613d5f03", tag: "09f4f2a3936d7461a67ce022176bb8dd", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 255 - edge case intermediate sums in poly1305. poly_key:946aff9f2a13f56f92a5f9cfee3cdb1fef6d98d5a55ab563cb28620cd57f19d2", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "00010203040506072dd4cd40", aad: "ffffffff", msg: "40115e67ecd3d4178c4c60e713ab4e5e060ef93aeb61aa307f141323c38e0685ee47139a5f4e3f8e92d7a3b71eb4ff0e2bca70bfcdf1171ab611d12bed5d627a62a0085c32ddfcbeb01a8be4c34d5331e3ff90fd21a55c2d91e09542205a6eba", ciphertext: "d9ffffffffffffffffffffffffffffff9f09d73c6544428cfac0b2d8c7bbef0bf9ffffffffffffffffffffffffffffff8400c34c278e47d32b3bd6c10c9de307f9ffffffffffffffffffffffffffffff8400c34c278e47d32b3bd6c10c9de307", tag: "2eb2679aadfd824a5fd8fa2e4a55a65c", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 256 - edge case intermediate sums in poly1305. poly_key:946aff9f2a13f56f92a5f9cfee3cdb1fef6d98d5a55ab563cb28620cd57f19d2", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "00010203040506072dd4cd40", aad: "ffffffff", msg: "56115e67ecd3d4178c4c60e713ab4e5e6c7e1312c6774fae7d1e5d0cc609028ff547139a5f4e3f8e92d7a3b71eb4ff0e81c9e61cbeeed5546b1ce5d8fef21a7a79a0085c32ddfcbeb01a8be4c34d533149fc065e52ba9e634ceda1b133f516ba", ciphertext: "cffffffffffffffffffffffffffffffff5793d144852a712f8cafcf7c23ceb01e2ffffffffffffffffffffffffffffff2e0355ef5491859df636e2321f329b07e2ffffffffffffffffffffffffffffff2e0355ef5491859df636e2321f329b07", tag: "5e89349f6b011cd6e24ee6ac2f590c21", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 257 - edge case intermediate sums in poly1305. poly_key:946aff9f2a13f56f92a5f9cfee3cdb1fef6d98d5a55ab563cb28620cd57f19d2", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "00010203040506072dd4cd40", aad: "ffffffff", msg: "2ea8410b4dca8c9d5369a033d8db61e46cf8d1f971da17437a2b5e04fbca1671f0f58e8bba6cf1a52146273d8fe0c4fc5a354c0c1580af3662d5f8151e3f7e827c12954dd7ff3295038b0f6e521968c3", ciphertext: "b746e0935ee6a77520da3f2b348fd045f5ffffffffffffffffffffffffffffffe74d62ee1add31d44c6e7b756eabc40df5ffffffffffffffffffffffffffffffe74d62ee1add31d44c6e7b756eabc40d", tag: "b24537fcb0dcb6200b0285cafc9c3a7d", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 258 - edge case intermediate sums in poly1305. poly_key:946aff9f2a13f56f92a5f9cfee3cdb1fef6d98d5a55ab563cb28620cd57f19d2", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "00010203040506072dd4cd40", aad: "ffffffff", msg: "17059a7c8883a28b90bd94ae44d1543662f8d1f971da17437a2b5e04fbca1671a23018bf8e68e413e99ac2d4ab3f8df154354c0c1580af3662d5f8151e3f7e822ed70379e3fb2723cb57ea8776c621ce", ciphertext: "8eeb3be49baf8963e30e0bb6a885e597fbffffffffffffffffffffffffffffffb588f4da2ed9246284b29e9c4a748d00fbffffffffffffffffffffffffffffffb588f4da2ed9246284b29e9c4a748d00", tag: "43300400ea36e720361153ce0c5d637d", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 259 - edge case intermediate sums in poly1305. poly_key:946aff9f2a13f56f92a5f9cfee3cdb1fef6d98d5a55ab563cb28620cd57f19d2", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "00010203040506072dd4cd40", aad: "ffffffff", msg: "aaa1b258fd4b54b497b520806a66d7aa68f8d1f971da17437a2b5e04fbca167199132a234a8c789bf8544547940ec3f35e354c0c1580af3662d5f8151e3f7e8215f431e5271fbbabda996d1449f76fcc", ciphertext: "334f13c0ee677f5ce406bf988632660bf1ffffffffffffffffffffffffffffff8eabc646ea3db8ea957c190f7545c302f1ffffffffffffffffffffffffffffff8eabc646ea3db8ea957c190f7545c302", tag: "d79a0310124adc30c6b64cdef8993e8d", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 260 - edge case intermediate sums in poly1305. poly_key:946aff9f2a13f56f92a5f9cfee3cdb1fef6d98d5a55ab563cb28620cd57f19d2", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "00010203040506072dd4cd40", aad: "ffffffff", msg: "4c115e67ecd3d4178c4c60e713ab4e5ebb5357ed314ad740b9910fad6f01d781f047139a5f4e3f8e92d7a3b71eb4ff0ec8042b414fdd1bba3a6c936b7ed678797ca0085c32ddfcbeb01a8be4c34d53310031cb03a389508d1d9dd702b3d174b9", ciphertext: "d5ffffffffffffffffffffffffffffff225479ebbf6f3ffc3c45ae566b343e0fe7ffffffffffffffffffffffffffffff67ce98b2a5a24b73a74694819f16f904e7ffffffffffffffffffffffffffffff67ce98b2a5a24b73a74694819f16f904", tag: "e6022cc3ba20e3f9065fdfcc43a9dc40", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 261 - edge case intermediate sums in poly1305. poly_key:946aff9f2a13f56f92a5f9cfee3cdb1fef6d98d5a55ab563cb28620cd57f19d2", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "00010203040506072dd4cd40", aad: "ffffffff", msg: "66115e67ecd3d4178c4c60e713ab4e5ef64296975af7fced168181f76c6508e1c947139a5f4e3f8e92d7a3b71eb4ff0e4975060f7ddef4a098699333b30fbf7c45a0085c32ddfcbeb01a8be4c34d53318140e64d918abf97bf98d75a7e08b3bc", ciphertext: "ffffffffffffffffffffffffffffffff6f45b891d4d214519355200c6850e16fdeffffffffffffffffffffffffffffffe6bfb5fc97a1a469054394d952cf3e01deffffffffffffffffffffffffffffffe6bfb5fc97a1a469054394d952cf3e01", tag: "353e304fd8553286b26e0d59942fe7cd", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 262 - edge case intermediate sums in poly1305. poly_key:946aff9f2a13f56f92a5f9cfee3cdb1fef6d98d5a55ab563cb28620cd57f19d2", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "00010203040506072dd4cd40", aad: "ffffffff", msg: "9841cfc927a57dc491ab35427ff935e66ef8d1f971da17437a2b5e04fbca1671a683c8f9f9e6780fda4940ddedd76bf258354c0c1580af3662d5f8151e3f7e822a64d33f9475bb3ff884688e302ec7cd", ciphertext: "01af6e513489562ce218aa5a93ad8447f7ffffffffffffffffffffffffffffffb13b249c5957b87eb7611c950c9c6b03f7ffffffffffffffffffffffffffffffb13b249c5957b87eb7611c950c9c6b03", tag: "0aeb04ecf7def40c42025bbae5509169", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 263 - edge case intermediate sums in poly1305. poly_key:946aff9f2a13f56f92a5f9cfee3cdb1fef6d98d5a55ab563cb28620cd57f19d2", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "00010203040506072dd4cd40", aad: "ffffffff", msg: "42115e67ecd3d4178c4c60e713ab4e5e0b61bf9b7caf83cc34da625593514289e847139a5f4e3f8e92d7a3b71eb4ff0e696a5c7fb9da9cd4a39c8591086db42d64a0085c32ddfcbeb01a8be4c34d5331a15fbc3d558ed7e3846dc1f8c56ab8ed", ciphertext: "dbffffffffffffffffffffffffffffff9266919df28a6b70b10ec3ae9764ab07ffffffffffffffffffffffffffffffffc6a0ef8c53a5cc1d3eb6827be9ad3550ffffffffffffffffffffffffffffffffc6a0ef8c53a5cc1d3eb6827be9ad3550", tag: "8fc4f77a6ee052a4c314780b8df9a2d0", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 264 - edge case intermediate sums in poly1305. poly_key:946aff9f2a13f56f92a5f9cfee3cdb1fef6d98d5a55ab563cb28620cd57f19d2", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "00010203040506072dd4cd40", aad: "ffffffff", msg: "4b115e67ecd3d4178c4c60e713ab4e5ef28e4d0f20ca1644470c9cdac6000887ed47139a5f4e3f8e92d7a3b71eb4ff0e1464775bacd5c69fe26e1a74968ea27e61a0085c32ddfcbeb01a8be4c34d5331dc51971940818da8c59f5e1d5b89aebe", ciphertext: "d2ffffffffffffffffffffffffffffff6b896309aeeffef8c2d83d21c235e109faffffffffffffffffffffffffffffffbbaec4a846aa96567f441d9e774e2303faffffffffffffffffffffffffffffffbbaec4a846aa96567f441d9e774e2303", tag: "232ff78a96f347b453ba711b79367ee0", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 265 - edge case intermediate sums in poly1305. poly_key:946aff9f2a13f56f92a5f9cfee3cdb1fef6d98d5a55ab563cb28620cd57f19d2", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "00010203040506072dd4cd40", aad: "ffffffff", msg: "4d115e67ecd3d4178c4c60e713ab4e5e6ee628fc4b5830184cd293364a213e84fe47139a5f4e3f8e92d7a3b71eb4ff0e29db953ad5458fea61f013ea1854fe7572a0085c32ddfcbeb01a8be4c34d5331e1ee75783911c4dd46015783d553f2b5", ciphertext: "d4fffffffffffffffffffffffffffffff7e106fac57dd8a4c90632cd4e14d70ae9ffffffffffffffffffffffffffffff861126c93f3adf23fcda1400f9947f08e9ffffffffffffffffffffffffffffff861126c93f3adf23fcda1400f9947f08", tag: "e00d2e8bae5d09c28e9bf59409545d09", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 266 - edge case intermediate sums in poly1305. poly_key:dc46b3c53be153ccd4986678ffffffafe484c316c93f64195da65a2742fd3fec", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "000102030405060703e76f6f", aad: "ffffffff", msg: "19de9b9ec8b247d42bbee2016d6715babc286fd979807951b183a188930ad15edcf0b056a2eecc51d30838e640615e14890e659fd3028c904e65018fdfd6038333d14da7b4f76f9f68fa8903138d563c33b7fb50c3e7ebca970f6f89a88a82d6", ciphertext: "f9ffffffffffffffffffffffffffffff015d1565924f6c7418de9babf8be4407edffffffffffffffffffffffffffffff2e110e5e1c0468cbaad99c8abeffff07edffffffffffffffffffffffffffffff2e110e5e1c0468cbaad99c8abeffff07", tag: "47e5d4294239db73b836c04070ff5b2d", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 267 - edge case intermediate sums in poly1305. poly_key:dc46b3c53be153ccd4986678ffffffafe484c316c93f64195da65a2742fd3fec", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "000102030405060703e76f6f", aad: "ffffffff", msg: "1fde9b9ec8b247d42bbee2016d6715ba839f811ad0310c77052f45320b0d9560c4f0b056a2eecc51d30838e640615e1470d6b14fd209fedf261fd1d250d3478d2bd14da7b4f76f9f68fa8903138d563cca6f2f80c2ec9985ff75bfd4278fc6d8", ciphertext: "ffffffffffffffffffffffffffffffff3eeafba63bfe1952ac727f1160b90039f5ffffffffffffffffffffffffffffffd7c9da8e1d0f1a84c2a34cd731fabb09f5ffffffffffffffffffffffffffffffd7c9da8e1d0f1a84c2a34cd731fabb09", tag: "232c882f7a1a2f808ccf26496cff5b3d", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 268 - edge case intermediate sums in poly1305. poly_key:dc46b3c53be153ccd4986678ffffffafe484c316c93f64195da65a2742fd3fec", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "000102030405060703e76f6f", aad: "ffffffff", msg: "97311cd6e2d25a7b4eaa16f0a61ca6246b8a85431430eada56a2c5dc944b6aa695136310b6b6b5c17c9f8c02ba7d0aeb71e0943e30f91ba41b4362fa9ed6037b7a329ee1a0af160fc76d3de7e99102c3", ciphertext: "771078b7d59fe2509aeb0b0e34844c61d6ffffffffffffffffffffffffffffffa41c2cb9eba7866f50684b1b05e3ab00d6ffffffffffffffffffffffffffffffa41c2cb9eba7866f50684b1b05e3ab00", tag: "d71bc70d5adc74e7dfd89406fc15f044", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 269 - edge case intermediate sums in poly1305. poly_key:dc46b3c53be153ccd4986678ffffffafe484c316c93f64195da65a2742fd3fec", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "000102030405060703e76f6f", aad: "ffffffff", msg: "34de9b9ec8b247d42bbee2016d6715ba74cf7e9d82b7e8ed9ec965f6ea310951dc104940e08a4222556828eba459f65a4a006d28729d95d79d2372f77aeeab35", ciphertext: "d4ffffffffffffffffffffffffffffffc9ba04216978fdc837945fd581859c08ed1f06e9bd9b718c799feff21bc757b1ed1f06e9bd9b718c799feff21bc757b1", tag: "21e63987d494673f3040ae9de2bc0da0", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 270 - edge case intermediate sums in poly1305. poly_key:dc46b3c53be153ccd4986678ffffffafe484c316c93f64195da65a2742fd3fec", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "000102030405060703e76f6f", aad: "ffffffff", msg: "e72b83514e5e50509070359c1cac7e1c428a85431430eada56a2c5dc944b6aa6dad35950d8a9b55a472f9bb8860a526358e0943e30f91ba41b4362fa9ed6037b35f2a4a1ceb01694fcdd2a5dd5e65a4b", ciphertext: "070ae7307913e87b443128628e349459ffffffffffffffffffffffffffffffffebdc16f985b886f46bd85ca13994f388ffffffffffffffffffffffffffffffffebdc16f985b886f46bd85ca13994f388", tag: "e4fb945d6a2d0b947834317cc415f024", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 271 - edge case intermediate sums in poly1305. poly_key:dc46b3c53be153ccd4986678ffffffafe484c316c93f64195da65a2742fd3fec", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "000102030405060703e76f6f", aad: "ffffffff", msg: "8c6165f445443588041b6e044fb6baae728a85431430eada56a2c5dc944b6aa6881a54c09516a1f1cae7b9dd71130ee168e0943e30f91ba41b4362fa9ed6037b673ba931830f023f7115083822ff06c9", ciphertext: "6c40019572098da3d05a73fadd2e50ebcfffffffffffffffffffffffffffffffb9151b69c807925fe6107ec4ce8daf0acfffffffffffffffffffffffffffffffb9151b69c807925fe6107ec4ce8daf0a", tag: "c0424863a20e5fa04ccd9784c015f034", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 272 - edge case intermediate sums in poly1305. poly_key:dc46b3c53be153ccd4986678ffffffafe484c316c93f64195da65a2742fd3fec", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "000102030405060703e76f6f", aad: "ffffffff", msg: "18e36174545fa7ec9ea9f05d7057c5ca638a85431430eada56a2c5dc944b6aa6434e1c5e71005b690ca5cb8d580b89ed79e0943e30f91ba41b4362fa9ed6037bac6fe1af6719f8a7b7577a680be781c5", ciphertext: "f8c2051563121fc74ae8eda3e2cf2f8fdeffffffffffffffffffffffffffffff724153f72c1168c720520c94e7952806deffffffffffffffffffffffffffffff724153f72c1168c720520c94e7952806", tag: "aa7293ffe5db30a31f2581e0e7ae56ed", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 273 - edge case intermediate sums in poly1305. poly_key:dc46b3c53be153ccd4986678ffffffafe484c316c93f64195da65a2742fd3fec", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "000102030405060703e76f6f", aad: "ffffffff", msg: "12de9b9ec8b247d42bbee2016d6715ba54305dff6b61c40b775c352d025c1a56d7f0b056a2eecc51d30838e640615e14bce574e9e11afedbdca021e53bb9188338d14da7b4f76f9f68fa8903138d563c065cea26f1ff998105ca4fe34ce599d6", ciphertext: "f2ffffffffffffffffffffffffffffffe945274380aed12ede010f0e69e88f0fe6ffffffffffffffffffffffffffffff1bfa1f282e1c1a80381cbce05a90e407e6ffffffffffffffffffffffffffffff1bfa1f282e1c1a80381cbce05a90e407", tag: "42e5d43d1e808e79f017144d4498c235", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 274 - edge case intermediate sums in poly1305. poly_key:dc46b3c53be153ccd4986678ffffffafe484c316c93f64195da65a2742fd3fec", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "000102030405060703e76f6f", aad: "ffffffff", msg: "1fde9b9ec8b247d42bbee2016d6715badf0599194b0ce890cc1d8eb383b57f38dcf0b056a2eecc51d30838e640615e1435df81077d068077ce805ea592f6f88833d14da7b4f76f9f68fa8903138d563c8f661fc86de3e72d17ea30a3e5aa79dd", ciphertext: "ffffffffffffffffffffffffffffffff6270e3a5a0c3fdb56540b490e801ea61edffffffffffffffffffffffffffffff92c0eac6b200642c2a3cc3a0f3df040cedffffffffffffffffffffffffffffff92c0eac6b200642c2a3cc3a0f3df040c", tag: "6cf2f9230af8679e7ecb19421362fce3", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 275 - edge case intermediate sums in poly1305. poly_key:dc46b3c53be153ccd4986678ffffffafe484c316c93f64195da65a2742fd3fec", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "000102030405060703e76f6f", aad: "ffffffff", msg: "39de9b9ec8b247d42bbee2016d6715ba4092e1f9a22c8b18184d805c128ade57c7f0b056a2eecc51d30838e640615e1464fe8b9bdd215a620973affefe93398528d14da7b4f76f9f68fa8903138d563cde471554cdc43d38d019c1f889cfb8d0", ciphertext: "d9fffffffffffffffffffffffffffffffde79b4549e39e3db110ba7f793e4b0ef6ffffffffffffffffffffffffffffffc3e1e05a1227be39edcf32fb9fbac501f6ffffffffffffffffffffffffffffffc3e1e05a1227be39edcf32fb9fbac501", tag: "6d46d2230a9848d518f9d94bb2c49caa", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 276 - edge case intermediate sums in poly1305. poly_key:dc46b3c53be153ccd4986678ffffffafe484c316c93f64195da65a2742fd3fec", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "000102030405060703e76f6f", aad: "ffffffff", msg: "12de9b9ec8b247d42bbee2016d6715ba327f3a1befb4287c17450391ed0eb854d6f0b056a2eecc51d30838e640615e141460d3545c29ddc790711b8e7533698539d14da7b4f76f9f68fa8903138d563caed94d9b4cccba9d491b7588026fe8d0", ciphertext: "f2ffffffffffffffffffffffffffffff8f0a40a7047b3d59be1839b286ba2d0de7ffffffffffffffffffffffffffffffb37fb895932f399c74cd868b141a9501e7ffffffffffffffffffffffffffffffb37fb895932f399c74cd868b141a9501", tag: "74dda12e0558877bc0e40c3eace0af29", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 277 - edge case intermediate sums in poly1305. poly_key:dc46b3c53be153ccd4986678ffffffafe484c316c93f64195da65a2742fd3fec", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "000102030405060703e76f6f", aad: "ffffffff", msg: "1bde9b9ec8b247d42bbee2016d6715ba85b67664ee49fa347fbfd2dd92007c57def0b056a2eecc51d30838e640615e14fb27ee075b3c0f0f682babdde63dad8731d14da7b4f76f9f68fa8903138d563c419e70c84bd96855b141c5db91612cd2", ciphertext: "fbffffffffffffffffffffffffffffff38c30cd80586ef11d6e2e8fef9b4e90eefffffffffffffffffffffffffffffff5c3885c6943aeb548c9736d887145103efffffffffffffffffffffffffffffff5c3885c6943aeb548c9736d887145103", tag: "502455343d39db87947d7346a8e0af39", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 278 - edge case intermediate sums in poly1305. poly_key:dc46b3c53be153ccd4986678ffffffafe484c316c93f64195da65a2742fd3fec", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "000102030405060703e76f6f", aad: "ffffffff", msg: "36de9b9ec8b247d42bbee2016d6715ba1132811b2f18321ba99b12432c7f865aa3352cd2d7ac70b4c6f5419767926e20352508ba45bba7410ebe1b8bb925334f", ciphertext: "d6ffffffffffffffffffffffffffffffac47fba7c4d7273e00c6286047cb1303923a637b8abd431aea02868ed80ccfcb923a637b8abd431aea02868ed80ccfcb", tag: "14fba149d1c0edc8aa665851126b5afd", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 279 - edge case intermediate sums in poly1305. poly_key:dc46b3c53be153ccd4986678ffffffafe484c316c93f64195da65a2742fd3fec", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "000102030405060703e76f6f", aad: "ffffffff", msg: "1fde9b9ec8b247d42bbee2016d6715baf999461058f6d7733e5cd0d1639d9025cbf0b056a2eecc51d30838e640615e14520a0da50439db00e289e1791342068e24d14da7b4f76f9f68fa8903138d563ce8b3936a14dcbc5a3be38f7f641e87db", ciphertext: "ffffffffffffffffffffffffffffffff44ec3cacb339c2569701eaf20829057cfafffffffffffffffffffffffffffffff5156664cb3f3f5b06357c7c726bfa0afafffffffffffffffffffffffffffffff5156664cb3f3f5b06357c7c726bfa0a", tag: "bf7fbd422cbf0e700fd1605be8fd212f", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 280 - edge case intermediate sums in poly1305. poly_key:dc46b3c53be153ccd4986678ffffffafe484c316c93f64195da65a2742fd3fec", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "000102030405060703e76f6f", aad: "ffffffff", msg: "15de9b9ec8b247d42bbee2016d6715bacc1629a40cd11eafdf04138b45afe458eff0b056a2eecc51d30838e640615e14340ac9b45a5896a418a8cee8032e078f00d14da7b4f76f9f68fa8903138d563c8eb3577b4abdf1fec1c2a0ee747286da", ciphertext: "f5ffffffffffffffffffffffffffffff71635318e71e0b8a765929a82e1b7101deffffffffffffffffffffffffffffff9315a275955e72fffc1453ed6207fb0bdeffffffffffffffffffffffffffffff9315a275955e72fffc1453ed6207fb0b", tag: "c6f23204865b0adde0070037d6538dd3", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 281 - edge case intermediate sums in poly1305. poly_key:dc46b3c53be153ccd4986678ffffffafe484c316c93f64195da65a2742fd3fec", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "000102030405060703e76f6f", aad: "ffffffff", msg: "31de9b9ec8b247d42bbee2016d6715baff746ef53ec3357cbc3c3ce4ab1d2d51ed9eb456dc9d9b59f656a5d2d974d26a7b8e903e4e8a4cac3e1dffce07c38f05", ciphertext: "d1ffffffffffffffffffffffffffffff42011449d50c2059156106c7c0a9b808dc91fbff818ca8f7daa162cb66ea7381dc91fbff818ca8f7daa162cb66ea7381", tag: "8cff61b7b3919ed6bde72b36e0d31326", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 282 - edge case intermediate sums in poly1305. poly_key:dc46b3c53be153ccd4986678ffffffafe484c316c93f64195da65a2742fd3fec", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "000102030405060703e76f6f", aad: "ffffffff", msg: "19de9b9ec8b247d42bbee2016d6715babf286fd979807951b183a188930ad15ecef0b056a2eecc51d30838e640615e1464413d71939b9cb0a4d32ef115da9e1021d14da7b4f76f9f68fa8903138d563cdef8a3be837efbea7db940f762861f45", ciphertext: "f9ffffffffffffffffffffffffffffff025d1565924f6c7418de9babf8be4407ffffffffffffffffffffffffffffffffc35e56b05c9d78eb406fb3f474f36294ffffffffffffffffffffffffffffffffc35e56b05c9d78eb406fb3f474f36294", tag: "369cf17011cae47539e2723f010cf980", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 283 - edge case intermediate sums in poly1305. poly_key:dc46b3c53be153ccd4986678ffffffafe484c316c93f64195da65a2742fd3fec", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "000102030405060703e76f6f", aad: "ffffffff", msg: "19de9b9ec8b247d42bbee2016d6715babd286fd979807951b183a188930ad15ee3f0b056a2eecc51d30838e640615e14f25e78fe1b53ae416d1fbc698522618f0cd14da7b4f76f9f68fa8903138d563c48e7e6310bb6c91bb475d26ff27ee0da", ciphertext: "f9ffffffffffffffffffffffffffffff005d1565924f6c7418de9babf8be4407d2ffffffffffffffffffffffffffffff5541133fd4554a1a89a3216ce40b9d0bd2ffffffffffffffffffffffffffffff5541133fd4554a1a89a3216ce40b9d0b", tag: "532eb8e272a8d171378b0d42dff2bed9", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 284 - edge case intermediate sums in poly1305. poly_key:dc46b3c53be153ccd4986678ffffffafe484c316c93f64195da65a2742fd3fec", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "000102030405060703e76f6f", aad: "ffffffff", msg: "32de9b9ec8b247d42bbee2016d6715ba258d5d3e441683f546beba2e23755f5ccef0b056a2eecc51d30838e640615e149d13fdf8fa899836fa5c410d4ccd25ea21d14da7b4f76f9f68fa8903138d563c27aa6337ea6cff6c23362f0b3b91a4bf", ciphertext: "d2ffffffffffffffffffffffffffffff98f82782afd996d0efe3800d48c1ca05ffffffffffffffffffffffffffffffff3a0c9639358f7c6d1ee0dc082de4d96effffffffffffffffffffffffffffffff3a0c9639358f7c6d1ee0dc082de4d96e", tag: "d1be7426cd12446fe52e8d45331e0835", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 285 - edge case intermediate sums in poly1305. poly_key:dc46b3c53be153ccd4986678ffffffafe484c316c93f64195da65a2742fd3fec", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "000102030405060703e76f6f", aad: "ffffffff", msg: "1fde9b9ec8b247d42bbee2016d6715bad64add2aa3c5a30a31d9e65e90f93ad1cbf0b056a2eecc51d30838e640615e14de9aeab86144d5464811b2373ba4cc8324d14da7b4f76f9f68fa8903138d563c6423747771a1b21c917bdc314cf84dd6", ciphertext: "ffffffffffffffffffffffffffffffff6b3fa796480ab62f9884dc7dfb4daf88faffffffffffffffffffffffffffffff79858179ae42311dacad2f325a8d3007faffffffffffffffffffffffffffffff79858179ae42311dacad2f325a8d3007", tag: "62630c18de8c10876adb9f30f300963f", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 286 - edge case intermediate sums in poly1305. poly_key:dc46b3c53be153ccd4986678ffffffafe484c316c93f64195da65a2742fd3fec", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "000102030405060703e76f6f", aad: "ffffffff", msg: "1fde9b9ec8b247d42bbee2016d6715bacc3492272b8a4b112a4e7d7ccf092692cef0b056a2eecc51d30838e640615e1430ce678e9375b2af0b82c2d2fbd7928c21d14da7b4f76f9f68fa8903138d563c8a77f9418390d5f5d2e8acd48c8b13d9", ciphertext: "ffffffffffffffffffffffffffffffff7141e89bc0455e348313475fa4bdb3cbffffffffffffffffffffffffffffffff97d10c4f5c7356f4ef3e5fd79afe6e08ffffffffffffffffffffffffffffffff97d10c4f5c7356f4ef3e5fd79afe6e08", tag: "feb6412b9031f076eddcd9426fff5b31", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 287 - edge case intermediate sums in poly1305. poly_key:dc46b3c53be153ccd4986678ffffffafe484c316c93f64195da65a2742fd3fec", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "000102030405060703e76f6f", aad: "ffffffff", msg: "34de9b9ec8b247d42bbee2016d6715ba722b6549c9df0f4b04b5f7432203fa54cef0b056a2eecc51d30838e640615e1487de186cd28e43544c73de628fd1d60e21d14da7b4f76f9f68fa8903138d563c3d6786a3c26b240e9519b064f88d575b", ciphertext: "d4ffffffffffffffffffffffffffffffcf5e1ff522101a6eade8cd6049b76f0dffffffffffffffffffffffffffffffff20c173ad1d88a70fa8cf4367eef82a8affffffffffffffffffffffffffffffff20c173ad1d88a70fa8cf4367eef82a8a", tag: "dafdf430c8124483c175404b6bff5b41", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 288 - edge case intermediate sums in poly1305. poly_key:dc46b3c53be153ccd4986678ffffffafe484c316c93f64195da65a2742fd3fec", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "000102030405060703e76f6f", aad: "ffffffff", msg: "3dde9b9ec8b247d42bbee2016d6715bac5629699cfd4d9036cef478ed705be5650f575882c3800f757ea6e0f8c6d47acc6e551e0be2fd7029fa1341352da1ac3", ciphertext: "ddffffffffffffffffffffffffffffff7817ec25241bcc26c5b27dadbcb12b0f61fa3a21712933597b1da91633f3e64761fa3a21712933597b1da91633f3e647", tag: "f8800c5b6283dddfc41f935c01bd0d24", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 289 - edge case intermediate sums in poly1305. poly_key:dc46b3c53be153ccd4986678ffffffafe484c316c93f64195da65a2742fd3fec", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "000102030405060703e76f6f", aad: "ffffffff", msg: "1fde9b9ec8b247d42bbee2016d6715ba66d624f288f52941ca24865ce96f0d9736ff33a27c23f4976fc74f1fcd82f5cca0ef17caee342362a78c15031335a8a3", ciphertext: "ffffffffffffffffffffffffffffffffdba35e4e633a3c646379bc7f82db98ce07f07c0b2132c73943308806721c542707f07c0b2132c73943308806721c5427", tag: "38bfb8318c627d86c34bab1f1ebd0db0", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 290 - edge case intermediate sums in poly1305. poly_key:dc46b3c53be153ccd4986678ffffffafe484c316c93f64195da65a2742fd3fec", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "000102030405060703e76f6f", aad: "ffffffff", msg: "f4ebbe3fca96bc4885b35582c43e0eb3588a85431430eada56a2c5dc944b6aa6b4570e8446e886bcbff82a24f49be5ed42e0943e30f91ba41b4362fa9ed6037b5b76f37550f12572040a9bc1a777edc5", ciphertext: "14cada5efddb046351f2487c56a6e4f6e5ffffffffffffffffffffffffffffff8558412d1bf9b512930fed3d4b054406e5ffffffffffffffffffffffffffffff8558412d1bf9b512930fed3d4b054406", tag: "af7293eb09957d9de7432dd41316f0e4", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 291 - edge case intermediate sums in poly1305. poly_key:dc46b3c53be153ccd4986678ffffffafe484c316c93f64195da65a2742fd3fec", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "000102030405060703e76f6f", aad: "ffffffff", msg: "1ade9b9ec8b247d42bbee2016d6715ba571a3fca3cda7def4c93d4a382ca3a57eaf0b056a2eecc51d30838e640615e1476cddbee2f185776174f6df3bbe5b38105d14da7b4f76f9f68fa8903138d563ccc7445213ffd302cce2503f5ccb932d4", ciphertext: "faffffffffffffffffffffffffffffffea6f4576d71568cae5ceee80e97eaf0edbffffffffffffffffffffffffffffffd1d2b02fe01eb32df3f3f0f6dacc4f05dbffffffffffffffffffffffffffffffd1d2b02fe01eb32df3f3f0f6dacc4f05", tag: "e178b0d5eb9bc551fa645c49f9f17667", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 292 - edge case intermediate sums in poly1305. poly_key:dc46b3c53be153ccd4986678ffffffafe484c316c93f64195da65a2742fd3fec", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "000102030405060703e76f6f", aad: "ffffffff", msg: "1fde9b9ec8b247d42bbee2016d6715babe31a501536a7c91e4a102cc27cdfe09d2f0b056a2eecc51d30838e640615e14dd9416a12e2f81bdee023d462feef7833dd14da7b4f76f9f68fa8903138d563c672d886e3ecae6e73768534058b276d6", ciphertext: "ffffffffffffffffffffffffffffffff0344dfbdb8a569b44dfc38ef4c796b50e3ffffffffffffffffffffffffffffff7a8b7d60e12965e60abea0434ec70b07e3ffffffffffffffffffffffffffffff7a8b7d60e12965e60abea0434ec70b07", tag: "bdbf63db237d195ecefdc251f5f17677", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 293 - edge case intermediate sums in poly1305. poly_key:dc46b3c53be153ccd4986678ffffffafe484c316c93f64195da65a2742fd3fec", xchacha: false, key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", nonce: "000102030405060703e76f6f", aad: "ffffffff", msg: "3ede9b9ec8b247d42bbee2016d6715ba8567a7fde812a3aa2f552a33c1718c58e2f0b056a2eecc51d30838e640615e14bb8729fd148f23b2a916b7f40f2f29810dd14da7b4f76f9f68fa8903138d563c013eb732046a44e8707cd9f27873a8d4", ciphertext: "deffffffffffffffffffffffffffffff3812dd4103ddb68f86081010aac51901d3ffffffffffffffffffffffffffffff1c98423cdb89c7e94daa2af16e06d505d3ffffffffffffffffffffffffffffff1c98423cdb89c7e94daa2af16e06d505", tag: "b4ccb422bc5f7264aff73f3675ff5b19", valid: true},
+	{name: "Wycheproof ChaCha20Poly1305 294 - invalid nonce size", xchacha: false, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "", aad: "", msg: "", ciphertext: "", tag: "", valid: false},
+	{name: "Wycheproof ChaCha20Poly1305 295 - invalid nonce size", xchacha: false, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "0001020304050607", aad: "", msg: "", ciphertext: "", tag: "", valid: false},
+	{name: "Wycheproof ChaCha20Poly1305 296 - invalid nonce size", xchacha: false, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a", aad: "", msg: "", ciphertext: "", tag: "", valid: false},
+	{name: "Wycheproof ChaCha20Poly1305 297 - invalid nonce size", xchacha: false, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b0c", aad: "", msg: "", ciphertext: "", tag: "", valid: false},
+	{name: "Wycheproof ChaCha20Poly1305 298 - invalid nonce size", xchacha: false, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b0c0d", aad: "", msg: "", ciphertext: "", tag: "", valid: false},
+	{name: "Wycheproof ChaCha20Poly1305 299 - invalid nonce size", xchacha: false, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b0c0d0e0f", aad: "", msg: "", ciphertext: "", tag: "", valid: false},
+	{name: "Wycheproof ChaCha20Poly1305 300 - invalid nonce size", xchacha: false, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b0c0d0e0f10111213", aad: "", msg: "", ciphertext: "", tag: "", valid: false},
+	{name: "Wycheproof XChaCha20Poly1305 1 - draft-arciszewski-xchacha-02", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "404142434445464748494a4b4c4d4e4f5051525354555657", aad: "50515253c0c1c2c3c4c5c6c7", msg: "4c616469657320616e642047656e746c656d656e206f662074686520636c617373206f66202739393a204966204920636f756c64206f6666657220796f75206f6e6c79206f6e652074697020666f7220746865206675747572652c2073756e73637265656e20776f756c642062652069742e", ciphertext: "bd6d179d3e83d43b9576579493c0e939572a1700252bfaccbed2902c21396cbb731c7f1b0b4aa6440bf3a82f4eda7e39ae64c6708c54c216cb96b72e1213b4522f8c9ba40db5d945b11b69b982c1bb9e3f3fac2bc369488f76b2383565d3fff921f9664c97637da9768812f615c68b13b52e", tag: "c0875924c1c7987947deafd8780acf49", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 2", xchacha: true, key: "ab1562faea9f47af3ae1c3d6d030e3af230255dff3df583ced6fbbcbf9d606a9", nonce: "6a5e0c4617e07091b605a4de2c02dde117de2ebd53b23497", aad: "", msg: "", ciphertext: "", tag: "e2697ea6877aba39d9555a00e14db041", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 3", xchacha: true, key: "d821dce9b890ea37ae1c89e7cb6aeae9371b8179add0d08f5494718322ae0071", nonce: "3ec3f7c45e687d75a895bf5e71809e7cdac32158bb48ec0d", aad: "8780fb400f94c55d", msg: "", ciphertext: "", tag: "966c22d655b9e56326024f028cf887ad", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 4", xchacha: true, key: "303ccb2e1567c3d9f629a5c632dbc62a9a82c525674f67988b31bd1dee990538", nonce: "05188738844ab90a8b11beef38eaec3e100d8f4f85ae7a41", aad: "", msg: "62", ciphertext: "45", tag: "d15734f984d749fa3f0550a70c43dddf", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 5", xchacha: true, key: "697c197c9e0023c8eee42ddf08c12c46718a436561b0c66d998c81879f7cb74c", nonce: "cd78f4533c94648feacd5aef0291b00b454ee3dcdb76dcc8", aad: "6384f4714ff18c18", msg: "e1", ciphertext: "b0", tag: "e5e35f5332f91bdd2d28e59d68a0b141", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 6", xchacha: true, key: "c11213bcff39a88b0e3ecc47b23acf6c3014e4708d80dcca162da7377b316ab3", nonce: "b60ca1ab736deebe4d9da78bc7cbbab91be14a2f884240b7", aad: "", msg: "57f9", ciphertext: "5e03", tag: "eed21c2cd3f395538d677602964ed578", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 7", xchacha: true, key: "b0f51b8227013464943370e926b6ed1c9fb45b5994af829ff3a9f998b77d822c", nonce: "4fd76cbf27cb387502a706461564e5a5c14e027d40bc6eef", aad: "322f82a87ee82997", msg: "ab8c", ciphertext: "b56a", tag: "edcafa2c9032aff695e427fc2a344767", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 8", xchacha: true, key: "17afb080753f2aa0af0a7f4821f6ab2709a6b2b5b9f2f262910e3b27b82c6c1c", nonce: "737e3e7699f788c4136938c0f65310684eacbb5f96ecd98d", aad: "", msg: "2af96a", ciphertext: "31a461", tag: "2b745098b154bb90903b0240c3bc95e9", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 9", xchacha: true, key: "b720aea3df85fb3fb00583eddbebc5c545bcdcb7f6f2a94c1087950e16d68278", nonce: "1436f36466fce5db337a73ec18e269e6e985d91035128183", aad: "9d53316bd2aa3e3d", msg: "4799c4", ciphertext: "d41c02", tag: "8faa889d7f189cd9473e19200ef03920", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 10", xchacha: true, key: "d7704e505826124ab02935e7349a4e13391e6dc020fee95cd30654cdc5d5f393", nonce: "7c39999d498286d974d266b2f027a26d7fbcd330869d9f93", aad: "", msg: "c44efab6", ciphertext: "a3b405bb", tag: "c50e2ddb97df1ee58561c97a7b746c24", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 11", xchacha: true, key: "c70ef9ee59259019960c918bfc91237ed6786c73f2b62427e4cbd4d8096a1f03", nonce: "eb4e36c637d1908db2c2ae9c72cfbae50655cb5f6504c4b6", aad: "8e0ac97934605052", msg: "2738c9d0", ciphertext: "9406a621", tag: "916b78ee04b20b8cd90f00b81bb8091c", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 12", xchacha: true, key: "7fac2a879ffddf5e36e04e3edcb8aa6be18a8326b28f76b15623307badc1ece2", nonce: "49875536d4946af49288f36684e25ff35998d50be6bcfcc2", aad: "", msg: "2c4c38f435", ciphertext: "2a01d08fe2", tag: "9cbe5f3e782f57a33a45b1f4aeeeea6e", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 13", xchacha: true, key: "48f1389d9222a80898ca26b5cfef5dc82dfc0af7cf66ea1e01bc5279e7414247", nonce: "88ccb58d435ea760f19e1fa6172139a071c0c5143959a56c", aad: "5cbdd482f3429a27", msg: "945a1fd040", ciphertext: "fb5daf8c6e", tag: "ec1682b61957493c2eb758d7a2b7a179", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 14", xchacha: true, key: "737cdaa2ce1e4740e75af4aaf68c0296c1607bde871d2452e628f1456239c753", nonce: "89c9806ad153b805f1bf5b50738319011d5fc070bb551ee1", aad: "", msg: "fae858dd3150", ciphertext: "856c300cfceb", tag: "aaa9875ebd42a11d12cf0aca26021f4a", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 15", xchacha: true, key: "9f7cd632bd5eb5f017b898590d645571ef56e521024eda36eff893a6ad04b935", nonce: "5cbdc34772b54fb4fba9eca1e2745e0e3704d9d7b5c78fb4", aad: "71b29930f84a572d", msg: "53abb8943ada", ciphertext: "6438fc8f8788", tag: "af05a4def2ad39a195a7b8c222050111", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 16", xchacha: true, key: "ecf60cd2af8c7155c0be848ecdaa5baddad6bd5f254a2d98f47bef83999f60ee", nonce: "a020b016d952a5948a3d226bb1b73efc39d46845f3bf0ca5", aad: "", msg: "ea30907da57d78", ciphertext: "843f1039531fe1", tag: "efd99acdab540690ec91a7ad5697cb33", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 17", xchacha: true, key: "a9376583c47176728d7b2ed1039f0b12b2c7a97563937f7fe976ce4548f7cb00", nonce: "b1f05bb66d29bcddf7412f6a556ff7540aac452457dd69e6", aad: "0c87cc97c49e166a", msg: "4a3d9926dc9757", ciphertext: "f99f3fb49ec920", tag: "91c3356ee6601ae7073673d2ef30293b", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 18", xchacha: true, key: "bf9ae8ceceb8d3001da7652c4cec02adda8696294a4ab542b41b5ba86c096a75", nonce: "f4f3484cacdce37cf5134a12f57903096acd3553607eb682", aad: "", msg: "6eb5e11b358c0ab1", ciphertext: "5b596bab0890286e", tag: "d4474d9520f7178e9811f624209721ea", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 19", xchacha: true, key: "d447796ed4ceb2e43942700e7759e335f67afa8653748db95f924c94488195db", nonce: "cc4781134455e89c836f7433bd0426776f945d82f6358276", aad: "06947c3afa797e99", msg: "77c46ada19c81849", ciphertext: "80c8e9ac2cec97ce", tag: "9b62dcc8076098affcb6e7995aaa99a6", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 20", xchacha: true, key: "08eb57d7bc113f7fbdda1b32237cdd06cccd52ef4a89a831c5e0564370c885ad", nonce: "200a30270bc911dd3b8a8ea2a6e6ce75be9cfb0f5431db3d", aad: "", msg: "704df23a31893799ee", ciphertext: "37d696264f781338c9", tag: "5fddaf74438159acc3c5667b5e84af13", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 21", xchacha: true, key: "9f093b6bb75f1609ab1e00a4bf4667961d885f01deb6520c5bb16ec21e033766", nonce: "a613e0b17fafb47c79614d39959b986ba2c97b0215676d41", aad: "00fc4f61d9777504", msg: "472578ece9fe828dc6", ciphertext: "a55cbb308f81e449e9", tag: "8174bd595da1be72cc226e74c46a4af5", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 22", xchacha: true, key: "e421bb3269130c731d1947e7b5d233c11d195ceed1d08634743db9c252bfefa5", nonce: "21b40036745f64b2aab3e89665cf4dab2b690d88721fe9a3", aad: "", msg: "1155c7f0ee3e1faa641e", ciphertext: "8bd51b64fcd244f0b3aa", tag: "dbdd1558934b83ae4393ade73e9edadb", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 23", xchacha: true, key: "8a275c90eb8688c5d9e82b74331cf104a2c8757d6257079b1d8035bb40d6a8d9", nonce: "33dfa71a0cb2aca008e4c8e8a72dbda4c407bbadd5d7e1a5", aad: "e7c9d1dda90b699e", msg: "3c2da491f244acfbd1dc", ciphertext: "e5aad5c055dc6df73cf0", tag: "96fc30292cc8381c345d5f2964ba5626", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 24", xchacha: true, key: "2d97a35e4b6617e5f4a0f50dcda7622f321cad936a246d9beada9d75e142ef3d", nonce: "5a44801d2baabfe8cbee6da52bb51b5297856065fbf33944", aad: "", msg: "b94df0d444dac848ffcad4", ciphertext: "2a41cc14a6a65bbb153758", tag: "1044cd75f2e61cbecbf3a7a77c13ef01", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 25", xchacha: true, key: "70d11ca92903865c6a6d8ba497f5a2d65f23b72198d7fc7fdaeda6c2632f7e46", nonce: "07590877a1e1df3a78fe4d04dd64b6cb79f1df45de17685b", aad: "d78dcb5431ef5669", msg: "f61bb0dd66e5905f1a7ea1", ciphertext: "5b3193405830b6840a4474", tag: "4b10bef8e8a3c2e6ae87fb8fb2a8bdd5", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 26", xchacha: true, key: "05c7317f07a0e89ce1b5ac41df8064faa9fd569ee1c357cd01a2872076477ac5", nonce: "94f86b0fd8a6ed90d3780eca23a82f4387da82b0894ae317", aad: "", msg: "b63e50c9bcd01406b6f78f86", ciphertext: "528dfb79ea182945f13bafb7", tag: "4fc22f4491449bb4ffe6a1eb266e2a91", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 27", xchacha: true, key: "924aafdb5b8a206b3e49aefe8944918cdcc8ccb5bb4b8c4ee81b847aa6fa52a0", nonce: "829cb09e40c2cc5f7648adc177e56ef53a58bfa16a859338", aad: "a67a57310055b193", msg: "68576b935acaab8b33ab62e3", ciphertext: "2345bfc502f9c62d64ad87f9", tag: "6736f095a28b887238f80dc562eaa25c", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 28", xchacha: true, key: "332b7ec9bf4a983eb02af7efee8ffaf5627b66f29e3e4728f50894fe176788d8", nonce: "016dac89c624a9d425ae377132421c37c4486895bef270f0", aad: "", msg: "8289397a58921bb3201b29c505", ciphertext: "d1f725ace69f7899ef51c11dd3", tag: "0d2858cc30497107a035929fdf2eb6af", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 29", xchacha: true, key: "b75fd9dd7ecca4f3eab36c36a176530dd3ffc825c202613740311d11cd501804", nonce: "e8252b018f9e0c3fbd4a6ad0d06346302b8ed7dcb206c3ad", aad: "4dc711c827a6f626", msg: "9800f8b835c4ff490ebd764914", ciphertext: "6c0e9d31b8e45591726f4cfc63", tag: "2ce700f1f3dc7d3f60607058ac3b817e", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 30", xchacha: true, key: "2bddfb332f74ac31fcf91d652c7b41fbcb26a10f2792ecf8075478e645042f87", nonce: "e698d39b3cec2634dbe035a55b8fce3b0041aabe4156f713", aad: "", msg: "813974b924c7618c63070d0247f0", ciphertext: "23a49dbe4b699d481621d9fc2db6", tag: "ef2cfb8423ae6f9faaec81025e6e274b", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 31", xchacha: true, key: "ea029c829c13a580b66aca21133a16933235c11c42905a640104a2ae9bb5cf82", nonce: "d025b0188edc9c40a8d6fc807cead97749016c9016d62ea5", aad: "0b9df4ffd1c9ccbe", msg: "a67e672df18cfbe125b212d63ec8", ciphertext: "0596f5709407a62fffce84240346", tag: "893772def69053b0aaf3bf1c21144ebf", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 32", xchacha: true, key: "1c838d9f68e687fbdddc6dff7f2e44b277bfeb316ae5d11b3e935889b48539d8", nonce: "9ce202557c11a57cb14e7e4bd7986f1cf6232196672d25ea", aad: "", msg: "37905d98be9839e02923d119a88d56", ciphertext: "c5aa0caf82b963f1e9b84a789a77d3", tag: "59c3e2e43cc098ed413ece9d9a6fd47a", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 33", xchacha: true, key: "51a99f0646767fbc01d7736df0340191acfbb5ae0288ed6fff2d34f0ea31470f", nonce: "ffdca5c51a0852ab18dd484af6664b63ab4097d303450837", aad: "a2e44e165e7ca5f7", msg: "93553954f0be4e24185601ce5c3c34", ciphertext: "f91d01453f568774115f75b5dad642", tag: "8fc36af6ae5ee3e05b38ed43598bbfcd", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 34", xchacha: true, key: "6a1f808358461e75072a054e2fc4e4c3e7f882c57920dda3278d0c860ca704e3", nonce: "25dc279923c1bcdaa7a36e7b884b51f62343abad71986037", aad: "", msg: "f242209c67698ea32c2152f8785b7d82", ciphertext: "732715c60018fb0ed55c14c1fa9a5273", tag: "afe3c4f050bf001e1dfcb2313dd8edd2", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 35", xchacha: true, key: "7fb18b56f3f5122585754a3b6c6a4e523036e66793db569c3e8e28032e916eb6", nonce: "c02c8c595064ac303b1be5df6ab43048856e97ae9962fb8f", aad: "8981c7260d514ab6", msg: "6e8c0bb3361908f5b33e059408651ae3", ciphertext: "a7eb11bfaa0d1c2ce457598049399575", tag: "485a94f61aa5f47a3036e85a57effd2f", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 36", xchacha: true, key: "3b11469dc670f5dfbe0aad7d15ee4862c92cb07842e5dcc48fa8e5fc817f1749", nonce: "9a61cf35aecbd40a65b35a64b516896f3de7f977b5c9901d", aad: "", msg: "540731e4ba3e4e2fd623a1a13233736ee7", ciphertext: "0fd7386b41396e0558495c45cdba029062", tag: "29f601a11f6a1072342c60b631de6085", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 37", xchacha: true, key: "e6d9fc8a9e3fa6ecadd9faffbb6ff387aa96502e60adadab029a9146ee39de28", nonce: "6570889af7acab7f555337bdce05499e8eb0d8d3d1a77660", aad: "23230be73ba2a6fb", msg: "deec95974eeef6e2b99739bed2f4a74771", ciphertext: "86d0fd1a325d501fe9efe83d3a3f62e346", tag: "1ed9a79616c787a8de2ff5cdac6af0c9", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 38", xchacha: true, key: "cbaa654cd4ad70ae96d3412680e60522807e9b887ec6dbfcd6e71e917e29ce62", nonce: "f3d84207ab5574e4bc74ae61b17ccaccc7c46eb3471e0e53", aad: "", msg: "f55aaf5a55432c20fb782c552e5ae096eb23", ciphertext: "daea40da316b8e78254a737c57063c4ad8b7", tag: "e13ff7a7e2c85b1abb5350134dfa7f9b", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 39", xchacha: true, key: "5b51ea4943ce173baa53f84a6ef59cb1e25b794768508b8dd8dcbfbc1744c18a", nonce: "de1e034363b0daec9828159e7996faff33a5f63eb552eb5f", aad: "b6bea5c60f288109", msg: "953939dd7601f17071b2bf776e4b1ed629ce", ciphertext: "eef62d53545698255648a483708c9cc93937", tag: "182529b1d07dbcb4bd89b3c5e4c8fac9", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 40", xchacha: true, key: "c5d3917ffb42b0508296cb245d468b04bbaa2c8c8c32e845415a911ea85f95f1", nonce: "74533cbe3ff9ec5a66604c88f5dae4d7efe4f604111f79fc", aad: "", msg: "0afab6dbab51f929332d743ccfbb9f34877bc9", ciphertext: "03dfbb3407a55ab0dbc451d0289de44acb5f33", tag: "a050def2e06a9ed3d10be180bafa636d", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 41", xchacha: true, key: "77cbd62759966c03b4487ce7cb3fca652c30198cdc0de5d447256e979e041c87", nonce: "562f3b788783bbb72e465c9d04eb555f366c66de32356e7b", aad: "880ac1004984fb3e", msg: "0e677082f7dd9c56bd365310c15a18de78df6d", ciphertext: "95a9bd7bf7e9836e5f8a75393c70da0d9b1d97", tag: "f028003066f8902c5d74ca6bc526e346", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 42", xchacha: true, key: "40e231268005ff28c36bd00167ea39131d262f3a591b0d1508c11b00ed04a0b6", nonce: "5fb9a00843c4b192bf6c3bc29451c237f30a607d3c637b85", aad: "", msg: "d34b950a1c4f2ae5c94a1fddd6574c5d9c0ab18f", ciphertext: "4ad85a75f1a975bbf3ee5302b71949036e3a2198", tag: "b82c05b09328949aa70bb537e871cd70", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 43", xchacha: true, key: "d66e92c86712132b1e3f5ba3a4cd006b9de1fa444246d99ef02e5b190a73089d", nonce: "7ade1bc01148ac071bfbe9870fe2023a7769b92312f45e0a", aad: "043cd9069dbd8cb5", msg: "1cf9f2a93cb056fa4222c5850872d9989bc8c185", ciphertext: "dfca9d845c21093f43348a4f6e72e324e9673129", tag: "9defc3de90d493be2a1945d11c569095", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 44", xchacha: true, key: "841404f7e07cdebeb48efd25a75444b6de170995cd460e38ff5930dc9cf5eba2", nonce: "45ccb4a19073c79a4ac1e052d4664d0dd1c730a6a2e87fe8", aad: "", msg: "5d583f68421d00cd8d95896a091b9bb10b744c61c4", ciphertext: "74634f111539fac80bb29d76ba656e5af90fd37f8e", tag: "c04ce25d27416ae5f181238acf9508bc", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 45", xchacha: true, key: "77a812cdbce2b7327dbbaecf6f81340b0ac97589676939d1ff0e69c3373326a3", nonce: "89248df60acfa757945d12647a14cc5bc6508bb2b9e4999c", aad: "91b46ee1f7a9361b", msg: "2573f8f0276ce3b2b38fb727575f376a2eeb305758", ciphertext: "0c1afa5419abb32e479b181a6e51cd99eb041bc37d", tag: "6c0b51ea2fc63841893216b03eb47be0", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 46", xchacha: true, key: "f2f9bdba59206e8c31a3338213d6a46a40aee237f631906aff076fe2d29d3b85", nonce: "ec272b052c33c84a611512a483c3fcec40501240eb7a42ee", aad: "", msg: "408c4cac91b4bd3ce25c8971b1ed8adb20ed667f8393", ciphertext: "59d9c3f18cbc59a3c04cdc6904cb860aae69a5485147", tag: "63e55e220873e295a5b86543334b1715", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 47", xchacha: true, key: "d9aa0213bfac5ee89f9ef2c6f616d8f71c3725dafe7926504e18b141192c33b0", nonce: "a131b4b0582be36dcce56beb036ec4fc31147efed7ff4718", aad: "1bc37fc6729b401d", msg: "081280932efbce0a5500d76d41c7dd2ddbc3311dc0cd", ciphertext: "d5a1f87dae98ab385d5d34626c295cca0ed6931635f4", tag: "25f2fa45c86c4cb0f02f99050e9d5ab7", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 48", xchacha: true, key: "d7b0b278c5ede48da2db2f6ec6f8b23282d3c940bd1eb59f7102bf69c683298d", nonce: "df72b7fe00eb070276ba1b0de6b17a6100fe0d660bf3c6c7", aad: "", msg: "0f44c184d297c0a66467d54ac982f922b119d5b4c8b238", ciphertext: "93034cdc9298d0086b8e8bbf3aea637484454015cf544d", tag: "b1e1dcf03663a995c6c14991b5558159", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 49", xchacha: true, key: "bd5040047cd7bd0bd1ca22164058a2901feb383c1ccba5c71c853f186d4e2b9e", nonce: "0378f12d4891c68477d90f16f2ff59287c81922b73cec608", aad: "04e0e991fb5a465e", msg: "29b7080f92c860ca4dd501f18b041c5cbc5c131783a720", ciphertext: "83a8bbe26ad18129459f66f6dc771c653a3dbb88a00b11", tag: "791971c0f5ba2c8b7635924267c68f32", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 50", xchacha: true, key: "3b96dbe28ee07208cdf703f1488f478134147363da1502249e025e0efe5cb663", nonce: "c9f5d4dfd5dd2276d68b25c6178d9ef2f38756df4be9d4b3", aad: "", msg: "8f37fd7e3e2f6563a9883d4adb92b5c37242a56b73a6fb7e", ciphertext: "1a0bc208b17fb629200e805da495db70c599ecb3c3b9cc94", tag: "08b9477bc98543019ddaa7ae380f83dd", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 51", xchacha: true, key: "53fc679ebe23b70714ab4ce6c8b0de5df656dca27177512654da31f6848dbe6b", nonce: "90b932e3464c8b66d3d2fec2bc9097289f147e05f18a9867", aad: "e1b2f309ce5fabe8", msg: "8b0b4038c0eebea97fa1f93b7c2f3576898e7cdc9fd702d0", ciphertext: "e9dd13d48dd7258682311bfec967e1a1ebc562855f224f41", tag: "d9038207dbfc82a9a9d507fe254d57c2", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 52", xchacha: true, key: "275ac60ffa734bf86601c951d0bd263b9651181c32f41fce90d59cb8d59da081", nonce: "d758776af8d089ef14a075ddf683e6669ed8109fe5681833", aad: "", msg: "1fa3b565515a429f78fb36e93e048425ffb64bc9e9e68336b3", ciphertext: "666f807a6e5d0253fe1967d45efea42cf1f421789b7f48e0dc", tag: "5d423636988dd257e5cbd40ee28ae94e", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 53", xchacha: true, key: "ec4d4b14860a36fe8afb2861c1376db8004cc2d37eb1ebb609343daf24bc39fb", nonce: "9628e46f25d08b206371449e7321d6bf5d811629e01ef32b", aad: "bbcbfa1779f4122c", msg: "201ec6c1d0675e818cb7a4e583ea1aa1afde1bbda1f0f549e1", ciphertext: "369a80f75ad28fd05cb3c944e0a8c8b37ce65bbd1f6d4b355a", tag: "3ca5005eda0b99d6566ac841340ad23a", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 54", xchacha: true, key: "53f9c2c335c1c5cde744e890f6bd291e4484925aaa036f1e74f0144603322648", nonce: "ec3dae28ec71ceba5b97a933d30b9fb98a40d4c92e6f54ef", aad: "", msg: "00f4f6a8c09ecbff3e6e825ca676a5cb8373d4915ecaf5d317a1", ciphertext: "b6faccf43dabd8965cb231fe96a2bdf2cb51e0b9afb6445c21eb", tag: "ee91b39d01a114f80a7c5e7e1a0b2868", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 55", xchacha: true, key: "9bb8bc991f01fb26df610032e1bf6ed0e2652629a6726aec9c23df4fefbdb594", nonce: "a7f4c26140ba7d8a884de794fb23a50c6647627fa85ef9f7", aad: "a6d7d9034512781c", msg: "ebcb0777bd1c3385376270e543521e11f4bac00d0f9c0192581e", ciphertext: "c97a4ba644788bfdeeb0a5de228948902a57359879c82cf8ead9", tag: "bf51aa205497db895f008d828040150f", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 56", xchacha: true, key: "69b8b0846c47226dbb278f83082b75476e89a77444bfa06de69395f16c6eed01", nonce: "7e4c8d0e24ab24f500053964774c92f808bafc42be0f6a34", aad: "", msg: "3b406d4c07f2ef751ac701fe944b2392bd59fb0ee4b32e6cbf8958", ciphertext: "28cf032caf586255ee3f3f70492d33458a7b42473b8e354d983dfd", tag: "58896a5d7618837701ed8dda9b18d82c", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 57", xchacha: true, key: "aa6d2da8fe7ce3228f15e09ae8c7f3d1b0220679a3e0e13e7523060b5b8d09b6", nonce: "26b2165f4b22415df4c052564b87d62c4c2c01df47c82cd8", aad: "f5fa84749ff438f4", msg: "92763e759a5c0b8c4d40d6398fa9e257900ff4b1f31000dbd9a15e", ciphertext: "be95d62d6acb3e5344f6b4ddbddfb45fa479c2d1577a42967dc0ad", tag: "61ac094fefb1237c9d44ab7f4bbbf5f9", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 58", xchacha: true, key: "31b9e848dfd3dd1ec05410975190109f550ee6e5235f040ce6faf6c380fba49d", nonce: "b595d9204461e311915cc17df51a3bbfa55c3a98aafbbaee", aad: "", msg: "95272cdea7a15889059b4e1de058c869e1776384159539470b542ed8", ciphertext: "2248e5332ed42c42fcb6a029e3d8f9f96cbc32d34fa5f302fabf1bf3", tag: "b777e88479292944c5d6ace1ffd24ac2", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 59", xchacha: true, key: "da132c34b2291a15777d3ebda2ed0078028c215038c2410d822578dcc869ea8d", nonce: "bc101b6d01bda7e13d402aa0023f0507ab02aa58758cb6aa", aad: "96fc6284d7eeb53c", msg: "331f3d53965bfee2edb463c5b21751eb445289287fada2aedae99258", ciphertext: "b10f9fbd87f51ebeae1942b9afb59749987b1575babd8008b281a662", tag: "54ad4e664b86333223fca6869c501dc2", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 60", xchacha: true, key: "d7e5e9c008af44266c876fa6b02a453854703c1a4fd221573c382c8d512a982d", nonce: "4adcd5ecf1506fe7a38adf5634b454bf90278c9ebffbac87", aad: "", msg: "f8b3ae84d6502d353d57c970da5f9bc53de7a5c6262ba7a7b2220d0ee1", ciphertext: "ffb587ec97c7d11ca75629f066881f6b2c392fa71b73fc4cb4559a645d", tag: "ec9db510c3bb11831c20684d82e45053", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 61", xchacha: true, key: "1e72be02d7ebf3c78b400efd005f5b6b983ede08443541475808d43e6d30eab8", nonce: "055776b422138960f6631e3c58f3ba0688082747de4ae5f6", aad: "cec8c976f2e25979", msg: "f2654733ca29af4bb29347f7a6508ed87913e0faa885505928ac1ee86e", ciphertext: "5d3ce03a6f43eab32a91b6eb87666af14e5e28d98d23c49c56557497d5", tag: "b324b10851d159bd3822705a9d638038", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 62", xchacha: true, key: "98362eff7af1e38d3d77d4a013bb6bf3fb3690568bf897651c578b21572fd37e", nonce: "cb52ad5674aff0762ef49fb3bed4722dcef2bcbc4f3c316a", aad: "", msg: "a40610eaf3a823c06936293473ca36a2952d0eb5e5bbc18be123a07f8bc8", ciphertext: "aa6edcb0f49535b2d2fa2e5f0b29343ba0c9c1667c401c78a3a8b8a61ad2", tag: "98d5e90a5a64e411c98d7c9e91557f5c", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 63", xchacha: true, key: "8f0e3dc43b86943ed4b0361fa5aa49999f24bc1e102bf3afb439e44f9ce43504", nonce: "f2f09c3469e2cf73b07620e461d7b1ad999c5f7d54867d21", aad: "f5203e702570c4b1", msg: "dba4ed2a7938826c43548f6976d8f0ec1838fe71cc535b2a5d56e4d3d5ca", ciphertext: "3d1add00e51e60b16825272790ff47c0d533bfe65484d105ee7a69896c48", tag: "a018e2629d5656920f1202e65624b056", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 64", xchacha: true, key: "16a376d68b3105262a07558e5e448ecdcbe075770cf60e7b7db1420f4fa4e36d", nonce: "17d6ff40ad135ac9df55fa5c0eaf03e5d91cdac63c684e8e", aad: "", msg: "bdb5500794edd38a398f18f83de03e16f135ea960d3b8c6578abc541aa1d03", ciphertext: "1ca6389e16c2f43e9e89447991d1472c8283a8dd94fdf61c4f5aee746cb537", tag: "33107bbbc06e563abf48979dbc7c66b6", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 65", xchacha: true, key: "8e1fb8cc57ca60ae091d27e292923272439c37f2dede36b2c2aaee96439d5a31", nonce: "c306b69443bfdbedb5ce9f9bb6088132a88e8a175d3bd769", aad: "3fe9ad465d0aa3fa", msg: "1d884a83a5f9b00b8951ef81778bd7c991cdc911127eee9dfeff82c48ca937", ciphertext: "e8ae311bf2e80d696c543cd272d3e50dc968a0ab47259c461e0dec35f77530", tag: "906de4c31eb2ce283eeb95388b0d83ce", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 66", xchacha: true, key: "2ed460a56867ee1a2877a8f3d2d98fb886cfcc8913e31c3d08f42374ba37ebb1", nonce: "0140f2791eb81fd4b69edf2d9ba4b2d62eab1d296741583f", aad: "", msg: "318cc4bf151c3baaee5a783ec091ab618f2ecacf38c962ba9c32c323696cc94c", ciphertext: "d34c1778d105d0e80d429c86b879d52835cf8aebc5a04a9084cff1f9646e040a", tag: "ac8a68605a0567c559442342b764b964", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 67", xchacha: true, key: "b43328e39cc6f6e94ea601fbebadb4b41cfe6a52c3a4d5eeabaa9853db45ccb1", nonce: "97438f178419732feaade58a5d5c21bed14d04c4add50465", aad: "1264b91e71865033", msg: "63cb5c20c9edf36757b795921437d3fd228af1fcdbb329505cbdde12afaf9f84", ciphertext: "cc24cfa62063d11b2c31cf25ceb7308ca376feb1dd6bc102ed7db8ed46b06759", tag: "dda7fc160e23f57e8392809f1e3b5ee8", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 68", xchacha: true, key: "92b9b40c00480a50ee16a86349a46e37b02d5ba74d2e5a67eaf333e467fa0152", nonce: "daca1f50a4c0d9b77151c75f2e58ce404847d0aab493086d", aad: "", msg: "c857f3c55da61d72563912a2534e01b6426ba41bf417c15b725086d31a1645c94d", ciphertext: "82be237be008228a8a9ff1a506d5b893cf9dcaa1dd33c0523b13582bcade4629ef", tag: "723437af0b684b6e04024352206cbaf9", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 69", xchacha: true, key: "5c271bac09a0454c83d158bcc9ec331ca92e62726903b7bb5799adff47d671ee", nonce: "ffcaddf85da09293c4352c81cbb5dd82e30b0f9e7623e92a", aad: "aaefd84240ade0ed", msg: "7c716a5b6cf0b8b0e1ff825ff9324bb5715b0d40af5338d5337f66de681932d423", ciphertext: "ff98ead89d45d70f09b9e3f31f4ff56ae8b8cad1517294a8af3c962bad24a92efe", tag: "4b8a06a1613737d0f8e3fb88184b23e4", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 70", xchacha: true, key: "c28403cce44ff256d055c2cbc84bb2d9773346e0d51bd38e80cebd861b03fa30", nonce: "64cc9f3cc334abce364cec9efe8ad54117ff0bbb03e3e8b9", aad: "", msg: "f9e8f60b70044b03a189c26f1c8fd246239bc23f8adf0f88516f88d73d11c9290882bb6ad49d956b10c9f848180065", ciphertext: "d0e84c6450f348d887c49c4b44ac38721d4a1742e72095c330249c7348bade49dc776d449272e0f3dd5422c2a6ab18", tag: "28c72dea441cffac2f7811286f8ea5dd", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 71", xchacha: true, key: "7c72c748ea0010c90e1dfbde8e91edf6ead2474148cf234e0559dcd881cc3b2b", nonce: "7b97c8b1c06b69b99220042ab2ac65b88d8b4294b76b4bd1", aad: "7185f9cbf59d2095", msg: "9a1f6c42a8a0f3032e8dfa36e0f5750479276866c920672a0454c41bfae5dd74fbf0fbcc8e6fbf4843f20d06440837", ciphertext: "4093dcbca1555835b78140fe7a3798a77bd97a01b0a7c1f7157fedb27c40d9d16cc3e935f649faf0dcf431636cd539", tag: "c7c9133ff17a296c987d72885182874d", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 72", xchacha: true, key: "7948151a374363d07dfb12869b7f90502f2de8117d3d72d5133b9b3e3dc78ef7", nonce: "8052acef0423bb07a6fbaf8f63039f1eaa2cdefc61b31b18", aad: "", msg: "76e03034be5514561e99c32ab58901eabac0f67b40c366202ac8a08ee3f68c3b283c1adeefee6f5544330d4771e5148c5231ec27b3f3f9d81a3dca52e115e1b5", ciphertext: "764ab84b844b57b0564f63ec70ad12d81dc3a0e65233a9bf06d6b2c653787eb991bc37a885a04509690ab49fd8dedcabe3c346df9036d735de3bf73ab03f5ba7", tag: "075248c91d1f246aebaa96c86627d18e", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 73", xchacha: true, key: "50a1b2b155150936609d45596e9175f3271be548574405f827593fc5a0578c3a", nonce: "f357e3b3d3d5e4187e34da08afd4817635adde91b676da1d", aad: "dc514d540551b9dc", msg: "e854b8531ace95c975a5b1497f3dec6d80b29ca673690411abe277bbfd29fa00133ee17570805c1c605452d648581be8db878e782f217b481b1268591593efc0", ciphertext: "de03f775aee744e4148e008dfefa7156ce2a23a613d4d9cae99c3164f54a173f895a9466ef046c020179383d70c813e765f207860c79dcf627f17663ea76af20", tag: "b473a9f1d5312d556bd0b62d84bb0803", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 74", xchacha: true, key: "92570a01d2b6123b67055400c8a9b0cb948e32c9b8520758cd1abd73f83c8507", nonce: "6d609141e3e4331f55344c1f5e6fad589b39ec1d12b9fbf0", aad: "", msg: "e86fc97c194d37a5e1345d139fe82dd669b6350c435cb446fcbdcc90fe5859bb2ef1f69d930e29dc343b57dfd7ff3c382652939bbd1c978a790ed1dbe5ad1fcbe157925ab4335c649c2f80c19d541e9e7eb4feb64e596bc6d7df8aa3476e0a9f7e", ciphertext: "98fc26e0cfd5a75b5bcd9e046e89c6e9dc5aaefdd5e8ea7e4d286dcdaca0fe6ae744d244678f91c9ccf6e294bd5586be671645ae87d3435836a5ab383b253602c25a6cc04353c076725b4fc4aff9b4dc9bd194fe92ef0a920f15d6b8fea9f19065", tag: "03bb49593f116a30a8390f96380a9888", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 75", xchacha: true, key: "4a3bc8f5c4aab87c20772404a291c1d6d68eb12e5f3c82e582564d6300fc28c9", nonce: "1a80def5bd8be8eef5f6643a5c1aed9947c3ee5ca0cb56df", aad: "e40cb55a18f2885f", msg: "2b0815f7eb0a83b9617e4f0906e9179b600b0c822bfb56c5012103aecb4550a57099dcebae00b6c06f3537fb1550c78b249d00a4007d23b882cb5511fdd53482575554028e9db437b8224368ead730d157a64d5571c706cbd9c0d2b10b3b14c3e2", ciphertext: "7042beb6e4f08e583752f23048e2f3433e0821423d72a7e531b86684b57b32c5bdcc11164db0b8516d7b463cf7f8b0e3ed8a7d584345934ef184e4f8fee31e126601f08558c725aaa23d38c8017b07adbf1e742128795b03458b581b8cd9100bd9", tag: "b5e3df83f18cbc0bd99427b9a172bf1b", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 76", xchacha: true, key: "2e89767b15f18b855d04c0b6b47c1f8facc9a058e2194ad2ad901ef940ab54cb", nonce: "3507ec4cd1a6c2eaf081ec32888e08839481f35b3b0f7872", aad: "", msg: "eff2e375228756f995b8ab52213177c4b7ca92bc81114f5c23aa64dd7eaff7b86ee2e674984c4b65bf4c5ff402e23902c005e05de25b3c6e8a64323aeafe04ec6cd1f6c851be39e55208d76476d3ed7100042eccb72cf1349ea101253b7a5a4a8677c1d6df5a54e9c24558e2d68c3f50acbd1ebbb4773884b0ff23d95a4ff60d", ciphertext: "cf2e17f9d8c6562de6d3e8c8bc30ba2904cf5c3616d15ea77667186ee45f444ea264327dcf210b6735a39005b62529d557480ed0462e49d982cf5962e5ee6d8ccc388d5de102e676a55426ce5a873d2e84a2d841e7b30c7ab19035274886b3c5c979d065bdde9b0b9e466b22559e30a5a5abc4817312e15d2c0dcdd99d867361", tag: "c844d555bb43a83b4aa735b2aa1d566a", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 77", xchacha: true, key: "6357cd94e2d9503288eaf3abf9604b050d4a483350a828029baaa9cae184f075", nonce: "0a5914f29abb1cb48dc686159f09480370477f6069018e18", aad: "e2f0d2f16704527e", msg: "bb266ddea2f88c2f0fea7f0cf4a1a33363344fb49672b821f76863a9edaee638d75140d21d848efc475d3814911c8bb34202c4e7ae0de1a57cea6f3af7180be454d7bc6fa5c02a999dc71eba7d5553828c963c1b7c559afa2e30e788ef2d0b479d0da1f24fcda5548773e77abc716f498b08bfe69b1a7e4b6fef27ccc72686d4", ciphertext: "4f0e805a2b3f2e1bfe3c06c83f5c77b9c4e562514a78f9f2cbf3206f68f686923656885878087d17da261666e798649d74841753525875f425e82a4795fdf8dfb629a8b1d2faa5594557d62f421f4e6a5dbb9f8336875f2fe2e2a4a1d0084358d9583e6b6662895a07c924c0a7cdba07be8a020e1b8ef3a0b5d007ec47a8e8cf", tag: "f130ad7a2b7dad5e8f8715eb5b93e45e", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 78", xchacha: true, key: "57f37ad4992d336d13c3967c701e60c7842a55195687bbc1f680a33e78e0658a", nonce: "74ef0301cc545539723c78ea9e2d75b851ea8641df1685d5", aad: "f5", msg: "58fea87518e42b504a9c53035081aabb", ciphertext: "fe59e8bf4250dc02843f3be602a7aeaa", tag: "012c6e4f5017c78b96247763c8ff5f68", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 79", xchacha: true, key: "aec0407a0cfa59096a489edc29e40cc67843ce71a95afc8deaa409a655aeba3d", nonce: "4a75dc9936c891cf5385f84e2a6d484c612115b9ce053f86", aad: "b93b", msg: "8afe8b8b22ed249e21a44247345ede5f", ciphertext: "7b2f702cf01a0007f4bd949230197e78", tag: "56bddc4fdccf099f128b177b3cc73520", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 80", xchacha: true, key: "9b0400ac1a917c7571430b78eca2c108e1824a078f21eb1995bfeae7fcc51f83", nonce: "91752e5f97bdadb6a9811c2144e27f73baa9458b6c58c9a0", aad: "d0926a", msg: "2b512160837e427a04c6bd9105cd2304", ciphertext: "f95dda4c5457dae8daffdf0ba5439ba7", tag: "3e97e87d976efe3de7d84df933ac980d", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 81", xchacha: true, key: "85b2f31409600b36ba8013f79b6aa84c9509546e218edb75c77d743a781c9bf4", nonce: "95150d436a72c173e502ac22df904f26c0f4edffc29ca98b", aad: "6cb8a59b", msg: "44872f0602c76c1d4d36fd462cc886f0", ciphertext: "9d2527bc8df2e71d20864e7789ce2a84", tag: "acd61c1b526988ec6105855ebdb7533a", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 82", xchacha: true, key: "76b087aa42ca8bb9a42133eb9279da0d0093b4e5028f4edd1c2183f81e6754b7", nonce: "383cd40e9aad35c35e3a46021b90acc87d51255be3443a7b", aad: "20976ec087", msg: "671e3615661511a8e668941126908c84", ciphertext: "9d403b239c7497781bfb4468bd930cfe", tag: "83dc6dfb3ae111ab05ac30116b89d65e", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 83", xchacha: true, key: "335d796b0dff04636e39bbc408ad6aef0d423fc1772994e61409396c9c1ff1b2", nonce: "da3fe15576474fe36bc3d2c42fe505617454c23aa1475e80", aad: "03caf0a03be7", msg: "f3a55e4591e697a9f1aaac2eda219c59", ciphertext: "610417044213e2a64c1b9b2fd1839268", tag: "444c11cdb783c3b432365ebe12378c9a", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 84", xchacha: true, key: "0a059d6ed699ffed57c6734b67eed5bd62d508772e0d1edfb5793f805908b035", nonce: "c991adee7c6e2bc5aeefd24c11be59a429b3198a3ea372cb", aad: "16317d3050bf51", msg: "b1ecd5c730695626454e8f89a598ad23", ciphertext: "25a22c28b26f1712ba56b46e0103c444", tag: "ef27336fed160e6bbf257fb0e7770aa8", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 85", xchacha: true, key: "b992dd885d0499a17268656665fde641be102d2fb642992d97e3107ee9aba20b", nonce: "b755995c547fdf21a2398d1f4adc6476d1291b1723a331ee", aad: "df2f4f832de7a1518e", msg: "5fc0609d86c5bd4e5e9e335cb1954458", ciphertext: "ceb42438dc40f7a0cc38ca0b9a48091f", tag: "7c1045faf49b58415ccdd2a1e2bc4429", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 86", xchacha: true, key: "58fcfc12acbb234bf13d28b856693a0952245bc0c1d751c52bca708c7a196137", nonce: "dd62b11c6826d2c53a8be69860f359a703594efaa42411a6", aad: "faa3abe6bc4eeb5316bf", msg: "66cc8a0fad0f6b05f0422b53ce8fecb3", ciphertext: "dbb22f3a39c46abe3cee3980c1df88bf", tag: "81da8bfd8ebbc1eca4870f8196156e3b", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 87", xchacha: true, key: "2765b4d865629fc232d37ca5e240a8532dc9a3e381daa7ca547ff5da5c417e41", nonce: "ca05d9a76be1149bcc4ef529b305854f7990b20aaffec384", aad: "9a227709205f1f740ed232", msg: "fe956a36f31adcc13ccd325f7a17f59c", ciphertext: "4905c8641a0ee3ea6687ed7452527903", tag: "7d574e549b5cd377992de204627de5fa", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 88", xchacha: true, key: "430878f3ab311fb40d2c9b0f534a4043350f0ff495c80122355ec2b7557cb831", nonce: "a37403e233f4fed7e00d9bf3a5407eae04fee3d667b65493", aad: "af191751f447cc49efff74f8", msg: "6e775f424e7d9d8e23cdbf14607d3a44", ciphertext: "06218a47245edaa15a9f1162ec011d3b", tag: "478423cbe48897ce756e3adbd9a1ee9a", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 89", xchacha: true, key: "a2c4d1d5ab1dc812200e18ccee9ef797195633d355a873c90f6d051041177cfd", nonce: "1ed54a330d347fb9ffcc68cabe540f2ff300cc3ee2691255", aad: "455fd94646f6edf9aef71275e0", msg: "cfac1a30be69f203e6efda92a19682c1", ciphertext: "27f9bbdc89a079abba54ddc01781f11a", tag: "edce3ba94ea658694368d78878ad9227", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 90", xchacha: true, key: "89f5bf87986d39fdfa8debf5a9810d3cf186f277f5fdc3f849ac7dcce6381205", nonce: "6cf744267d87aa512f949e66579c074c6ac371d5228adff3", aad: "d5736a239a1e598560a84a81a60b", msg: "9775a1e1ca33d579075e0a80f2bf1184", ciphertext: "a712f0a4c9932e6413bf501508693a5c", tag: "db8c77d539a6b41b6fc2d32ddd612ba3", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 91", xchacha: true, key: "67af73796c9eba6ac7a847825cc56fac92595a8eb17ae2fece4a1f09c9d8c85f", nonce: "7c76e9bd896c75245444f96fcfc419da23cf09b3be3610f8", aad: "c3036660fc872e55b0697104be59a2", msg: "3454b49cf7d10ec416770f76aa73bff7", ciphertext: "e2322c9638222677e4b6f7fa474accaa", tag: "82712972e906ea74f99dfb642c560db0", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 92", xchacha: true, key: "73005bc9d00e9688afcb340ea7cf81113d49e33d628e13b89949920102b1a9c1", nonce: "367a95373b3f2bd4f2bfb03619368639fcc19eccdeccd04f", aad: "f15449e7c7810a11609f5da5e33b9085", msg: "c47c17dcd3efabfe2de42702f27a840f", ciphertext: "7732ee206cd5734558c2f05f5bc1907b", tag: "4e32369f9ba08950b27b7952c3804fe8", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 93", xchacha: true, key: "3a0c554dc2242950ec97b63a7f1de739ce18c247f4ce1f23b539b51feb82bec8", nonce: "36213fcb5bff9b54db3c6af8c24a758b29b1143970b44168", aad: "17bc7a713365234f08e703a652816245d6", msg: "c04a2ebfcc30967e691a9ef1c52bcf6b", ciphertext: "8ed2c330b349dac3709bbc8ca2fd6d52", tag: "b6c38642002ac48847c715b317b26a86", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 94", xchacha: true, key: "6419d685e6804488ad4f09870db55f2448b82d4715e1d5fefa00ca9e08f21bc8", nonce: "bd605dba58a18d5a38fcab1f92f6cb406a276d8d0ca7fb30", aad: "7f1e1f7fcb831cd7501e9608fda8ccb3c54f537ad601c033fdba7f7dc419", msg: "676d9476348a31c6873016ab196852fe", ciphertext: "a4fc8309e455d263bc6b4c95e6c79cb4", tag: "9a439843444888d056b3e45a718a000d", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 95", xchacha: true, key: "d92d949112061c11471efa77552daeda52b390efcac420c453c5b8499048983d", nonce: "505a1b8d68cc2f77a10ad67cdeea4393a2ea6db590f5be17", aad: "bb044891ccae7f4f9493b8728293b772613c4ef2c088b3922f14466ea32a7a", msg: "161dc03e36cee9f246fd3a45481eea46", ciphertext: "1351d5642bfa9eaf78efd34733bc0b5c", tag: "d666424d2d66969944f2b1a9dba68ebc", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 96", xchacha: true, key: "2390931b9c99b9ac7e56bbbb86e6794b36ec3175432f731bea2e3a12c83e559b", nonce: "972f9e74b0d118734549fe0d237f0c6249c43674ceaa328d", aad: "cf8c4a35d879e5051b1cff63ac64580ee80a8d80e9b6c90ff841fab3673aa573", msg: "0a182ff667eeccab0f8054405879dc36", ciphertext: "7454d60539e1738ab6ff8609443a90f5", tag: "dd67f6363f66d20541d0aa24008be6ba", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 97", xchacha: true, key: "f563e70eefbe6cfd7a0b0d167a8b381fd14105ff4426fa326e9c2e4ca059a53e", nonce: "3654bf38ffe7d4dabab310657322af2da359fcaf79a81044", aad: "c69f4dca85af6c39b5991f9386622f98acdb24f66b785cb3636a212ec13bed601b", msg: "5133dcb7ed3fa91add15224a4a9d21a9", ciphertext: "bb5efe5a45ea17d63eb75509452daf51", tag: "31502fc74e063f0636bf9799f02c147a", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 98", xchacha: true, key: "7c6410343a2938b9cf2d82419ee8c645fc9ed819b3b2ef876af0b1221ac4590a", nonce: "f2f43b87fbb56938060cc9638d3d61ff2ebf26d037e4564e", aad: "760cd62e1d1123fd7d49b670037adb6dae66e7c8a0ca95ffed67a5965a35ca21c0ad9aa069d4edd48b71d5c93077ad", msg: "998c2e5f2900a0ab445b443b14e343e2", ciphertext: "5e8d9d12295525439d0a9fde1a585ca4", tag: "5b7be3245ab2cd28d6b8a4b884e7547c", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 99", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f1011121314151617", aad: "00000000000000000000000000000000", msg: "60e28a8f89adf230daab792c94dfebe766eda542d7c092d97ccb7501486fc6a3", ciphertext: "0000000000000000000000000000000000000000000000000000000000000000", tag: "52ca5edfb3c4fca83d5776154188a08d", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 100", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f1011121314151617", aad: "00000000000000000000000000000000", msg: "60e28a8f89adf230daab792c94dfebe766eda542d7c092d97ccb7501486fc6a31ec9568c72a762296f76685b29a5f903cb0198722ad071bde29b48a62d367f3b", ciphertext: "00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000", tag: "a7c21e96322a7f8c453961640791f3e6", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 101", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f1011121314151617", aad: "00000000000000000000000000000000", msg: "60e28a8f89adf230daab792c94dfebe766eda542d7c092d97ccb7501486fc6a31ec9568c72a762296f76685b29a5f903cb0198722ad071bde29b48a62d367f3b1e90919140f50187df7df42caa37287538c16d481265de62bbf98c235d595c824575acd33c51e271f13844673cb5dafd249dbd394b866c34aecd42c57f2630e5", ciphertext: "0000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000", tag: "70b88b3bf88b8f11f7513545b8dbfa63", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 102", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f1011121314151617", aad: "ffffffffffffffffffffffffffffffff", msg: "9f1d757076520dcf255486d36b20141899125abd283f6d2683348afeb790395c", ciphertext: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", tag: "c29cd2ef4874d93267c935cd9ffd34f1", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 103", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f1011121314151617", aad: "ffffffffffffffffffffffffffffffff", msg: "9f1d757076520dcf255486d36b20141899125abd283f6d2683348afeb790395ce136a9738d589dd6908997a4d65a06fc34fe678dd52f8e421d64b759d2c980c4", ciphertext: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", tag: "02add84dfa902f0d4a11d3bdc096417e", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 104", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f1011121314151617", aad: "ffffffffffffffffffffffffffffffff", msg: "9f1d757076520dcf255486d36b20141899125abd283f6d2683348afeb790395ce136a9738d589dd6908997a4d65a06fc34fe678dd52f8e421d64b759d2c980c4e16f6e6ebf0afe7820820bd355c8d78ac73e92b7ed9a219d440673dca2a6a37dba8a532cc3ae1d8e0ec7bb98c34a2502db6242c6b47993cb5132bd3a80d9cf1a", ciphertext: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", tag: "82a067b3b3e51cd9d139a5222ea70258", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 105", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f1011121314151617", aad: "00000080000000800000008000000080", msg: "60e28a0f89adf2b0daab79ac94dfeb6766eda5c2d7c092597ccb7581486fc623", ciphertext: "0000008000000080000000800000008000000080000000800000008000000080", tag: "2bd279a556e3dde6151e698e0496b3aa", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 106", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f1011121314151617", aad: "00000080000000800000008000000080", msg: "60e28a0f89adf2b0daab79ac94dfeb6766eda5c2d7c092597ccb7581486fc6231ec9560c72a762a96f7668db29a5f983cb0198f22ad0713de29b48262d367fbb", ciphertext: "00000080000000800000008000000080000000800000008000000080000000800000008000000080000000800000008000000080000000800000008000000080", tag: "dc37087d3aaa8b97e985152fa9f1ee04", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 107", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f1011121314151617", aad: "00000080000000800000008000000080", msg: "60e28a0f89adf2b0daab79ac94dfeb6766eda5c2d7c092597ccb7581486fc6231ec9560c72a762a96f7668db29a5f983cb0198f22ad0713de29b48262d367fbb1e90911140f50107df7df4acaa3728f538c16dc81265dee2bbf98ca35d595c024575ac533c51e2f1f13844e73cb5da7d249dbdb94b866cb4aecd42457f263065", ciphertext: "0000008000000080000000800000008000000080000000800000008000000080000000800000008000000080000000800000008000000080000000800000008000000080000000800000008000000080000000800000008000000080000000800000008000000080000000800000008000000080000000800000008000000080", tag: "3b1dbe65bafcd37fdb15b34fafabc07f", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 108", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f1011121314151617", aad: "ffffff7fffffff7fffffff7fffffff7f", msg: "9f1d75f076520d4f255486536b20149899125a3d283f6da683348a7eb79039dc", ciphertext: "ffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7f", tag: "e994b729a655f8f48e024354dcef21d4", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 109", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f1011121314151617", aad: "ffffff7fffffff7fffffff7fffffff7f", msg: "9f1d75f076520d4f255486536b20149899125a3d283f6da683348a7eb79039dce136a9f38d589d5690899724d65a067c34fe670dd52f8ec21d64b7d9d2c98044", ciphertext: "ffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7f", tag: "cd37ef66f2102302a6c41ef31e364660", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 110", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f1011121314151617", aad: "ffffff7fffffff7fffffff7fffffff7f", msg: "9f1d75f076520d4f255486536b20149899125a3d283f6da683348a7eb79039dce136a9f38d589d5690899724d65a067c34fe670dd52f8ec21d64b7d9d2c98044e16f6eeebf0afef820820b5355c8d70ac73e9237ed9a211d4406735ca2a6a3fdba8a53acc3ae1d0e0ec7bb18c34a2582db624246b479934b5132bdba80d9cf9a", ciphertext: "ffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7f", tag: "b73b3589f174d86aed75271837d73c3c", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 111", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f1011121314151617", aad: "7fffffff7fffffff7fffffff7fffffff", msg: "1f1d7570f6520dcfa55486d3eb20141819125abda83f6d2603348afe3790395c", ciphertext: "7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff", tag: "fbf9b30e0b9c1240ee0528ba82e961a8", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 112", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f1011121314151617", aad: "7fffffff7fffffff7fffffff7fffffff", msg: "1f1d7570f6520dcfa55486d3eb20141819125abda83f6d2603348afe3790395c6136a9730d589dd6108997a4565a06fcb4fe678d552f8e429d64b75952c980c4", ciphertext: "7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff", tag: "1ea55841efece2587f6f72c2a2d1e329", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 113", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f1011121314151617", aad: "7fffffff7fffffff7fffffff7fffffff", msg: "1f1d7570f6520dcfa55486d3eb20141819125abda83f6d2603348afe3790395c6136a9730d589dd6108997a4565a06fcb4fe678d552f8e429d64b75952c980c4616f6e6e3f0afe78a0820bd3d5c8d78a473e92b76d9a219dc40673dc22a6a37d3a8a532c43ae1d8e8ec7bb98434a25025b6242c6347993cbd132bd3a00d9cf1a", ciphertext: "7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff7fffffff", tag: "5adef66e4501595bc742d55c126b1896", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 114", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f1011121314151617", aad: "00000000ffffffff00000000ffffffff", msg: "60e28a8f76520dcfdaab792c6b20141866eda542283f6d267ccb7501b790395c", ciphertext: "00000000ffffffff00000000ffffffff00000000ffffffff00000000ffffffff", tag: "81682925f4f8a57392d2a9d4157f2c86", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 115", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f1011121314151617", aad: "00000000ffffffff00000000ffffffff", msg: "60e28a8f76520dcfdaab792c6b20141866eda542283f6d267ccb7501b790395c1ec9568c8d589dd66f76685bd65a06fccb019872d52f8e42e29b48a6d2c980c4", ciphertext: "00000000ffffffff00000000ffffffff00000000ffffffff00000000ffffffff00000000ffffffff00000000ffffffff00000000ffffffff00000000ffffffff", tag: "077001742d67566612633a0b3f3f8c99", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 116", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f1011121314151617", aad: "00000000ffffffff00000000ffffffff", msg: "60e28a8f76520dcfdaab792c6b20141866eda542283f6d267ccb7501b790395c1ec9568c8d589dd66f76685bd65a06fccb019872d52f8e42e29b48a6d2c980c41e909191bf0afe78df7df42c55c8d78a38c16d48ed9a219dbbf98c23a2a6a37d4575acd3c3ae1d8ef1384467c34a2502249dbd39b47993cbaecd42c580d9cf1a", ciphertext: "00000000ffffffff00000000ffffffff00000000ffffffff00000000ffffffff00000000ffffffff00000000ffffffff00000000ffffffff00000000ffffffff00000000ffffffff00000000ffffffff00000000ffffffff00000000ffffffff00000000ffffffff00000000ffffffff00000000ffffffff00000000ffffffff", tag: "1c0e1d3c611eda884919789540fc27f1", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 117", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f1011121314151617", aad: "ffffffff00000000ffffffff00000000", msg: "9f1d757089adf230255486d394dfebe799125abdd7c092d983348afe486fc6a3", ciphertext: "ffffffff00000000ffffffff00000000ffffffff00000000ffffffff00000000", tag: "93fe07aa08403068124e020ecb06a9f8", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 118", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f1011121314151617", aad: "ffffffff00000000ffffffff00000000", msg: "9f1d757089adf230255486d394dfebe799125abdd7c092d983348afe486fc6a3e136a97372a76229908997a429a5f90334fe678d2ad071bd1d64b7592d367f3b", ciphertext: "ffffffff00000000ffffffff00000000ffffffff00000000ffffffff00000000ffffffff00000000ffffffff00000000ffffffff00000000ffffffff00000000", tag: "a2fff56fff5358337de7f91689e8a8cb", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 119", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f1011121314151617", aad: "ffffffff00000000ffffffff00000000", msg: "9f1d757089adf230255486d394dfebe799125abdd7c092d983348afe486fc6a3e136a97372a76229908997a429a5f90334fe678d2ad071bd1d64b7592d367f3be16f6e6e40f5018720820bd3aa372875c73e92b71265de62440673dc5d595c82ba8a532c3c51e2710ec7bb983cb5dafddb6242c64b866c345132bd3a7f2630e5", ciphertext: "ffffffff00000000ffffffff00000000ffffffff00000000ffffffff00000000ffffffff00000000ffffffff00000000ffffffff00000000ffffffff00000000ffffffff00000000ffffffff00000000ffffffff00000000ffffffff00000000ffffffff00000000ffffffff00000000ffffffff00000000ffffffff00000000", tag: "db4ad6b24a53d2617f7262d2a586d5ca", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 120 - Flipped bit 0 in tag expected tag:0518b3e73e52c3be2eaba76807b784e1", xchacha: true, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b0c0d0e0f1011121314151617", aad: "000102", msg: "", ciphertext: "", tag: "0418b3e73e52c3be2eaba76807b784e1", valid: false},
+	{name: "Wycheproof XChaCha20Poly1305 121 - Flipped bit 1 in tag expected tag:0518b3e73e52c3be2eaba76807b784e1", xchacha: true, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b0c0d0e0f1011121314151617", aad: "000102", msg: "", ciphertext: "", tag: "0718b3e73e52c3be2eaba76807b784e1", valid: false},
+	{name: "Wycheproof XChaCha20Poly1305 122 - Flipped bit 7 in tag expected tag:0518b3e73e52c3be2eaba76807b784e1", xchacha: true, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b0c0d0e0f1011121314151617", aad: "000102", msg: "", ciphertext: "", tag: "8518b3e73e52c3be2eaba76807b784e1", valid: false},
+	{name: "Wycheproof XChaCha20Poly1305 123 - Flipped bit 8 in tag expected tag:0518b3e73e52c3be2eaba76807b784e1", xchacha: true, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b0c0d0e0f1011121314151617", aad: "000102", msg: "", ciphertext: "", tag: "0519b3e73e52c3be2eaba76807b784e1", valid: false},
+	{name: "Wycheproof XChaCha20Poly1305 124 - Flipped bit 31 in tag expected tag:0518b3e73e52c3be2eaba76807b784e1", xchacha: true, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b0c0d0e0f1011121314151617", aad: "000102", msg: "", ciphertext: "", tag: "0518b3673e52c3be2eaba76807b784e1", valid: false},
+	{name: "Wycheproof XChaCha20Poly1305 125 - Flipped bit 32 in tag expected tag:0518b3e73e52c3be2eaba76807b784e1", xchacha: true, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b0c0d0e0f1011121314151617", aad: "000102", msg: "", ciphertext: "", tag: "0518b3e73f52c3be2eaba76807b784e1", valid: false},
+	{name: "Wycheproof XChaCha20Poly1305 126 - Flipped bit 33 in tag expected tag:0518b3e73e52c3be2eaba76807b784e1", xchacha: true, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b0c0d0e0f1011121314151617", aad: "000102", msg: "", ciphertext: "", tag: "0518b3e73c52c3be2eaba76807b784e1", valid: false},
+	{name: "Wycheproof XChaCha20Poly1305 127 - Flipped bit 63 in tag expected tag:0518b3e73e52c3be2eaba76807b784e1", xchacha: true, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b0c0d0e0f1011121314151617", aad: "000102", msg: "", ciphertext: "", tag: "0518b3e73e52c33e2eaba76807b784e1", valid: false},
+	{name: "Wycheproof XChaCha20Poly1305 128 - Flipped bit 64 in tag expected tag:0518b3e73e52c3be2eaba76807b784e1", xchacha: true, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b0c0d0e0f1011121314151617", aad: "000102", msg: "", ciphertext: "", tag: "0518b3e73e52c3be2faba76807b784e1", valid: false},
+	{name: "Wycheproof XChaCha20Poly1305 129 - Flipped bit 77 in tag expected tag:0518b3e73e52c3be2eaba76807b784e1", xchacha: true, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b0c0d0e0f1011121314151617", aad: "000102", msg: "", ciphertext: "", tag: "0518b3e73e52c3be2e8ba76807b784e1", valid: false},
+	{name: "Wycheproof XChaCha20Poly1305 130 - Flipped bit 80 in tag expected tag:0518b3e73e52c3be2eaba76807b784e1", xchacha: true, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b0c0d0e0f1011121314151617", aad: "000102", msg: "", ciphertext: "", tag: "0518b3e73e52c3be2eaba66807b784e1", valid: false},
+	{name: "Wycheproof XChaCha20Poly1305 131 - Flipped bit 96 in tag expected tag:0518b3e73e52c3be2eaba76807b784e1", xchacha: true, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b0c0d0e0f1011121314151617", aad: "000102", msg: "", ciphertext: "", tag: "0518b3e73e52c3be2eaba76806b784e1", valid: false},
+	{name: "Wycheproof XChaCha20Poly1305 132 - Flipped bit 97 in tag expected tag:0518b3e73e52c3be2eaba76807b784e1", xchacha: true, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b0c0d0e0f1011121314151617", aad: "000102", msg: "", ciphertext: "", tag: "0518b3e73e52c3be2eaba76805b784e1", valid: false},
+	{name: "Wycheproof XChaCha20Poly1305 133 - Flipped bit 120 in tag expected tag:0518b3e73e52c3be2eaba76807b784e1", xchacha: true, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b0c0d0e0f1011121314151617", aad: "000102", msg: "", ciphertext: "", tag: "0518b3e73e52c3be2eaba76807b784e0", valid: false},
+	{name: "Wycheproof XChaCha20Poly1305 134 - Flipped bit 121 in tag expected tag:0518b3e73e52c3be2eaba76807b784e1", xchacha: true, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b0c0d0e0f1011121314151617", aad: "000102", msg: "", ciphertext: "", tag: "0518b3e73e52c3be2eaba76807b784e3", valid: false},
+	{name: "Wycheproof XChaCha20Poly1305 135 - Flipped bit 126 in tag expected tag:0518b3e73e52c3be2eaba76807b784e1", xchacha: true, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b0c0d0e0f1011121314151617", aad: "000102", msg: "", ciphertext: "", tag: "0518b3e73e52c3be2eaba76807b784a1", valid: false},
+	{name: "Wycheproof XChaCha20Poly1305 136 - Flipped bit 127 in tag expected tag:0518b3e73e52c3be2eaba76807b784e1", xchacha: true, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b0c0d0e0f1011121314151617", aad: "000102", msg: "", ciphertext: "", tag: "0518b3e73e52c3be2eaba76807b78461", valid: false},
+	{name: "Wycheproof XChaCha20Poly1305 137 - Flipped bit 63 and 127 in tag expected tag:0518b3e73e52c3be2eaba76807b784e1", xchacha: true, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b0c0d0e0f1011121314151617", aad: "000102", msg: "", ciphertext: "", tag: "0518b3e73e52c33e2eaba76807b78461", valid: false},
+	{name: "Wycheproof XChaCha20Poly1305 138 - Tag changed to all zero expected tag:0518b3e73e52c3be2eaba76807b784e1", xchacha: true, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b0c0d0e0f1011121314151617", aad: "000102", msg: "", ciphertext: "", tag: "00000000000000000000000000000000", valid: false},
+	{name: "Wycheproof XChaCha20Poly1305 139 - tag change to all 1 expected tag:0518b3e73e52c3be2eaba76807b784e1", xchacha: true, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b0c0d0e0f1011121314151617", aad: "000102", msg: "", ciphertext: "", tag: "ffffffffffffffffffffffffffffffff", valid: false},
+	{name: "Wycheproof XChaCha20Poly1305 140 - Flipped bit 0 in tag expected tag:2564a8ce1a360e8352971c8110885031", xchacha: true, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b0c0d0e0f1011121314151617", aad: "000102", msg: "000102030405060708090a0b0c0d0e0f", ciphertext: "b70886f2313d015e1fe741365f5e35f1", tag: "2464a8ce1a360e8352971c8110885031", valid: false},
+	{name: "Wycheproof XChaCha20Poly1305 141 - Flipped bit 1 in tag expected tag:2564a8ce1a360e8352971c8110885031", xchacha: true, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b0c0d0e0f1011121314151617", aad: "000102", msg: "000102030405060708090a0b0c0d0e0f", ciphertext: "b70886f2313d015e1fe741365f5e35f1", tag: "2764a8ce1a360e8352971c8110885031", valid: false},
+	{name: "Wycheproof XChaCha20Poly1305 142 - Flipped bit 7 in tag expected tag:2564a8ce1a360e8352971c8110885031", xchacha: true, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b0c0d0e0f1011121314151617", aad: "000102", msg: "000102030405060708090a0b0c0d0e0f", ciphertext: "b70886f2313d015e1fe741365f5e35f1", tag: "a564a8ce1a360e8352971c8110885031", valid: false},
+	{name: "Wycheproof XChaCha20Poly1305 143 - Flipped bit 8 in tag expected tag:2564a8ce1a360e8352971c8110885031", xchacha: true, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b0c0d0e0f1011121314151617", aad: "000102", msg: "000102030405060708090a0b0c0d0e0f", ciphertext: "b70886f2313d015e1fe741365f5e35f1", tag: "2565a8ce1a360e8352971c8110885031", valid: false},
+	{name: "Wycheproof XChaCha20Poly1305 144 - Flipped bit 31 in tag expected tag:2564a8ce1a360e8352971c8110885031", xchacha: true, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b0c0d0e0f1011121314151617", aad: "000102", msg: "000102030405060708090a0b0c0d0e0f", ciphertext: "b70886f2313d015e1fe741365f5e35f1", tag: "2564a84e1a360e8352971c8110885031", valid: false},
+	{name: "Wycheproof XChaCha20Poly1305 145 - Flipped bit 32 in tag expected tag:2564a8ce1a360e8352971c8110885031", xchacha: true, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b0c0d0e0f1011121314151617", aad: "000102", msg: "000102030405060708090a0b0c0d0e0f", ciphertext: "b70886f2313d015e1fe741365f5e35f1", tag: "2564a8ce1b360e8352971c8110885031", valid: false},
+	{name: "Wycheproof XChaCha20Poly1305 146 - Flipped bit 33 in tag expected tag:2564a8ce1a360e8352971c8110885031", xchacha: true, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b0c0d0e0f1011121314151617", aad: "000102", msg: "000102030405060708090a0b0c0d0e0f", ciphertext: "b70886f2313d015e1fe741365f5e35f1", tag: "2564a8ce18360e8352971c8110885031", valid: false},
+	{name: "Wycheproof XChaCha20Poly1305 147 - Flipped bit 63 in tag expected tag:2564a8ce1a360e8352971c8110885031", xchacha: true, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b0c0d0e0f1011121314151617", aad: "000102", msg: "000102030405060708090a0b0c0d0e0f", ciphertext: "b70886f2313d015e1fe741365f5e35f1", tag: "2564a8ce1a360e0352971c8110885031", valid: false},
+	{name: "Wycheproof XChaCha20Poly1305 148 - Flipped bit 64 in tag expected tag:2564a8ce1a360e8352971c8110885031", xchacha: true, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b0c0d0e0f1011121314151617", aad: "000102", msg: "000102030405060708090a0b0c0d0e0f", ciphertext: "b70886f2313d015e1fe741365f5e35f1", tag: "2564a8ce1a360e8353971c8110885031", valid: false},
+	{name: "Wycheproof XChaCha20Poly1305 149 - Flipped bit 77 in tag expected tag:2564a8ce1a360e8352971c8110885031", xchacha: true, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b0c0d0e0f1011121314151617", aad: "000102", msg: "000102030405060708090a0b0c0d0e0f", ciphertext: "b70886f2313d015e1fe741365f5e35f1", tag: "2564a8ce1a360e8352b71c8110885031", valid: false},
+	{name: "Wycheproof XChaCha20Poly1305 150 - Flipped bit 80 in tag expected tag:2564a8ce1a360e8352971c8110885031", xchacha: true, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b0c0d0e0f1011121314151617", aad: "000102", msg: "000102030405060708090a0b0c0d0e0f", ciphertext: "b70886f2313d015e1fe741365f5e35f1", tag: "2564a8ce1a360e8352971d8110885031", valid: false},
+	{name: "Wycheproof XChaCha20Poly1305 151 - Flipped bit 96 in tag expected tag:2564a8ce1a360e8352971c8110885031", xchacha: true, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b0c0d0e0f1011121314151617", aad: "000102", msg: "000102030405060708090a0b0c0d0e0f", ciphertext: "b70886f2313d015e1fe741365f5e35f1", tag: "2564a8ce1a360e8352971c8111885031", valid: false},
+	{name: "Wycheproof XChaCha20Poly1305 152 - Flipped bit 97 in tag expected tag:2564a8ce1a360e8352971c8110885031", xchacha: true, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b0c0d0e0f1011121314151617", aad: "000102", msg: "000102030405060708090a0b0c0d0e0f", ciphertext: "b70886f2313d015e1fe741365f5e35f1", tag: "2564a8ce1a360e8352971c8112885031", valid: false},
+	{name: "Wycheproof XChaCha20Poly1305 153 - Flipped bit 120 in tag expected tag:2564a8ce1a360e8352971c8110885031", xchacha: true, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b0c0d0e0f1011121314151617", aad: "000102", msg: "000102030405060708090a0b0c0d0e0f", ciphertext: "b70886f2313d015e1fe741365f5e35f1", tag: "2564a8ce1a360e8352971c8110885030", valid: false},
+	{name: "Wycheproof XChaCha20Poly1305 154 - Flipped bit 121 in tag expected tag:2564a8ce1a360e8352971c8110885031", xchacha: true, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b0c0d0e0f1011121314151617", aad: "000102", msg: "000102030405060708090a0b0c0d0e0f", ciphertext: "b70886f2313d015e1fe741365f5e35f1", tag: "2564a8ce1a360e8352971c8110885033", valid: false},
+	{name: "Wycheproof XChaCha20Poly1305 155 - Flipped bit 126 in tag expected tag:2564a8ce1a360e8352971c8110885031", xchacha: true, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b0c0d0e0f1011121314151617", aad: "000102", msg: "000102030405060708090a0b0c0d0e0f", ciphertext: "b70886f2313d015e1fe741365f5e35f1", tag: "2564a8ce1a360e8352971c8110885071", valid: false},
+	{name: "Wycheproof XChaCha20Poly1305 156 - Flipped bit 127 in tag expected tag:2564a8ce1a360e8352971c8110885031", xchacha: true, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b0c0d0e0f1011121314151617", aad: "000102", msg: "000102030405060708090a0b0c0d0e0f", ciphertext: "b70886f2313d015e1fe741365f5e35f1", tag: "2564a8ce1a360e8352971c81108850b1", valid: false},
+	{name: "Wycheproof XChaCha20Poly1305 157 - Flipped bit 63 and 127 in tag expected tag:2564a8ce1a360e8352971c8110885031", xchacha: true, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b0c0d0e0f1011121314151617", aad: "000102", msg: "000102030405060708090a0b0c0d0e0f", ciphertext: "b70886f2313d015e1fe741365f5e35f1", tag: "2564a8ce1a360e0352971c81108850b1", valid: false},
+	{name: "Wycheproof XChaCha20Poly1305 158 - Tag changed to all zero expected tag:2564a8ce1a360e8352971c8110885031", xchacha: true, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b0c0d0e0f1011121314151617", aad: "000102", msg: "000102030405060708090a0b0c0d0e0f", ciphertext: "b70886f2313d015e1fe741365f5e35f1", tag: "00000000000000000000000000000000", valid: false},
+	{name: "Wycheproof XChaCha20Poly1305 159 - tag change to all 1 expected tag:2564a8ce1a360e8352971c8110885031", xchacha: true, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b0c0d0e0f1011121314151617", aad: "000102", msg: "000102030405060708090a0b0c0d0e0f", ciphertext: "b70886f2313d015e1fe741365f5e35f1", tag: "ffffffffffffffffffffffffffffffff", valid: false},
+	{name: "Wycheproof XChaCha20Poly1305 160 - Flipped bit 0 in tag expected tag:8061d3df064071df8082a0f813417f05", xchacha: true, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b0c0d0e0f1011121314151617", aad: "000102", msg: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20", ciphertext: "b70886f2313d015e1fe741365f5e35f1080e0f78ccfb51809417e879689418ef98", tag: "8161d3df064071df8082a0f813417f05", valid: false},
+	{name: "Wycheproof XChaCha20Poly1305 161 - Flipped bit 1 in tag expected tag:8061d3df064071df8082a0f813417f05", xchacha: true, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b0c0d0e0f1011121314151617", aad: "000102", msg: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20", ciphertext: "b70886f2313d015e1fe741365f5e35f1080e0f78ccfb51809417e879689418ef98", tag: "8261d3df064071df8082a0f813417f05", valid: false},
+	{name: "Wycheproof XChaCha20Poly1305 162 - Flipped bit 7 in tag expected tag:8061d3df064071df8082a0f813417f05", xchacha: true, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b0c0d0e0f1011121314151617", aad: "000102", msg: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20", ciphertext: "b70886f2313d015e1fe741365f5e35f1080e0f78ccfb51809417e879689418ef98", tag: "0061d3df064071df8082a0f813417f05", valid: false},
+	{name: "Wycheproof XChaCha20Poly1305 163 - Flipped bit 8 in tag expected tag:8061d3df064071df8082a0f813417f05", xchacha: true, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b0c0d0e0f1011121314151617", aad: "000102", msg: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20", ciphertext: "b70886f2313d015e1fe741365f5e35f1080e0f78ccfb51809417e879689418ef98", tag: "8060d3df064071df8082a0f813417f05", valid: false},
+	{name: "Wycheproof XChaCha20Poly1305 164 - Flipped bit 31 in tag expected tag:8061d3df064071df8082a0f813417f05", xchacha: true, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b0c0d0e0f1011121314151617", aad: "000102", msg: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20", ciphertext: "b70886f2313d015e1fe741365f5e35f1080e0f78ccfb51809417e879689418ef98", tag: "8061d35f064071df8082a0f813417f05", valid: false},
+	{name: "Wycheproof XChaCha20Poly1305 165 - Flipped bit 32 in tag expected tag:8061d3df064071df8082a0f813417f05", xchacha: true, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b0c0d0e0f1011121314151617", aad: "000102", msg: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20", ciphertext: "b70886f2313d015e1fe741365f5e35f1080e0f78ccfb51809417e879689418ef98", tag: "8061d3df074071df8082a0f813417f05", valid: false},
+	{name: "Wycheproof XChaCha20Poly1305 166 - Flipped bit 33 in tag expected tag:8061d3df064071df8082a0f813417f05", xchacha: true, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b0c0d0e0f1011121314151617", aad: "000102", msg: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20", ciphertext: "b70886f2313d015e1fe741365f5e35f1080e0f78ccfb51809417e879689418ef98", tag: "8061d3df044071df8082a0f813417f05", valid: false},
+	{name: "Wycheproof XChaCha20Poly1305 167 - Flipped bit 63 in tag expected tag:8061d3df064071df8082a0f813417f05", xchacha: true, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b0c0d0e0f1011121314151617", aad: "000102", msg: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20", ciphertext: "b70886f2313d015e1fe741365f5e35f1080e0f78ccfb51809417e879689418ef98", tag: "8061d3df0640715f8082a0f813417f05", valid: false},
+	{name: "Wycheproof XChaCha20Poly1305 168 - Flipped bit 64 in tag expected tag:8061d3df064071df8082a0f813417f05", xchacha: true, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b0c0d0e0f1011121314151617", aad: "000102", msg: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20", ciphertext: "b70886f2313d015e1fe741365f5e35f1080e0f78ccfb51809417e879689418ef98", tag: "8061d3df064071df8182a0f813417f05", valid: false},
+	{name: "Wycheproof XChaCha20Poly1305 169 - Flipped bit 77 in tag expected tag:8061d3df064071df8082a0f813417f05", xchacha: true, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b0c0d0e0f1011121314151617", aad: "000102", msg: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20", ciphertext: "b70886f2313d015e1fe741365f5e35f1080e0f78ccfb51809417e879689418ef98", tag: "8061d3df064071df80a2a0f813417f05", valid: false},
+	{name: "Wycheproof XChaCha20Poly1305 170 - Flipped bit 80 in tag expected tag:8061d3df064071df8082a0f813417f05", xchacha: true, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b0c0d0e0f1011121314151617", aad: "000102", msg: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20", ciphertext: "b70886f2313d015e1fe741365f5e35f1080e0f78ccfb51809417e879689418ef98", tag: "8061d3df064071df8082a1f813417f05", valid: false},
+	{name: "Wycheproof XChaCha20Poly1305 171 - Flipped bit 96 in tag expected tag:8061d3df064071df8082a0f813417f05", xchacha: true, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b0c0d0e0f1011121314151617", aad: "000102", msg: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20", ciphertext: "b70886f2313d015e1fe741365f5e35f1080e0f78ccfb51809417e879689418ef98", tag: "8061d3df064071df8082a0f812417f05", valid: false},
+	{name: "Wycheproof XChaCha20Poly1305 172 - Flipped bit 97 in tag expected tag:8061d3df064071df8082a0f813417f05", xchacha: true, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b0c0d0e0f1011121314151617", aad: "000102", msg: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20", ciphertext: "b70886f2313d015e1fe741365f5e35f1080e0f78ccfb51809417e879689418ef98", tag: "8061d3df064071df8082a0f811417f05", valid: false},
+	{name: "Wycheproof XChaCha20Poly1305 173 - Flipped bit 120 in tag expected tag:8061d3df064071df8082a0f813417f05", xchacha: true, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b0c0d0e0f1011121314151617", aad: "000102", msg: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20", ciphertext: "b70886f2313d015e1fe741365f5e35f1080e0f78ccfb51809417e879689418ef98", tag: "8061d3df064071df8082a0f813417f04", valid: false},
+	{name: "Wycheproof XChaCha20Poly1305 174 - Flipped bit 121 in tag expected tag:8061d3df064071df8082a0f813417f05", xchacha: true, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b0c0d0e0f1011121314151617", aad: "000102", msg: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20", ciphertext: "b70886f2313d015e1fe741365f5e35f1080e0f78ccfb51809417e879689418ef98", tag: "8061d3df064071df8082a0f813417f07", valid: false},
+	{name: "Wycheproof XChaCha20Poly1305 175 - Flipped bit 126 in tag expected tag:8061d3df064071df8082a0f813417f05", xchacha: true, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b0c0d0e0f1011121314151617", aad: "000102", msg: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20", ciphertext: "b70886f2313d015e1fe741365f5e35f1080e0f78ccfb51809417e879689418ef98", tag: "8061d3df064071df8082a0f813417f45", valid: false},
+	{name: "Wycheproof XChaCha20Poly1305 176 - Flipped bit 127 in tag expected tag:8061d3df064071df8082a0f813417f05", xchacha: true, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b0c0d0e0f1011121314151617", aad: "000102", msg: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20", ciphertext: "b70886f2313d015e1fe741365f5e35f1080e0f78ccfb51809417e879689418ef98", tag: "8061d3df064071df8082a0f813417f85", valid: false},
+	{name: "Wycheproof XChaCha20Poly1305 177 - Flipped bit 63 and 127 in tag expected tag:8061d3df064071df8082a0f813417f05", xchacha: true, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b0c0d0e0f1011121314151617", aad: "000102", msg: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20", ciphertext: "b70886f2313d015e1fe741365f5e35f1080e0f78ccfb51809417e879689418ef98", tag: "8061d3df0640715f8082a0f813417f85", valid: false},
+	{name: "Wycheproof XChaCha20Poly1305 178 - Tag changed to all zero expected tag:8061d3df064071df8082a0f813417f05", xchacha: true, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b0c0d0e0f1011121314151617", aad: "000102", msg: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20", ciphertext: "b70886f2313d015e1fe741365f5e35f1080e0f78ccfb51809417e879689418ef98", tag: "00000000000000000000000000000000", valid: false},
+	{name: "Wycheproof XChaCha20Poly1305 179 - tag change to all 1 expected tag:8061d3df064071df8082a0f813417f05", xchacha: true, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b0c0d0e0f1011121314151617", aad: "000102", msg: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20", ciphertext: "b70886f2313d015e1fe741365f5e35f1080e0f78ccfb51809417e879689418ef98", tag: "ffffffffffffffffffffffffffffffff", valid: false},
+	{name: "Wycheproof XChaCha20Poly1305 180 - edge case for poly1305 key:ffffff3f24ac6f2f6436cec230be9ab31d8434bf94e1042d20952749a99cf641", xchacha: true, key: "606162636465666768696a6b6c6d6e6f707172737475767778797a7b7c7d7e7f", nonce: "000102030405060708090a0b0c0d0e0f101112133e8775b2", aad: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", msg: "7ee395bd21ada42ed12310d34918a28e596a49ee7a22f623d756b896663f68733e6c71a344f4726ac24e330679f25e492be08603aaa23f1e88c10299047c8e585983332a8b6eadcd9b6061b63fe3b58a2021b38c7cf379fe9a9f6d114f3cfe422f91af78c6fd87d4269af0e3e471abed457ae75c027e134c96cf4d9a4a646288", ciphertext: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", tag: "4921f7c24a2d42f4da7ad9d45e8ec26c", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 181 - edge case for poly1305 key:bf358f18ffffffbf4b62ed6e1f53790785c4dabdfc72e2a219d377a682c85f38", xchacha: true, key: "606162636465666768696a6b6c6d6e6f707172737475767778797a7b7c7d7e7f", nonce: "000102030405060708090a0b0c0d0e0f1011121303e9b9a4", aad: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", msg: "af205bda819f7451be0f28667d4b01b59ff2daa8173cab52046c3c9e0d989889c5e021ef7afd06e9ce6cc30e3a6ebab509134ba10d10e570c55587c13eee53e73be54804c8539ffbf23b35922b1ca37b9e9bc24ee204837ca5a294ce05d12600c7eff6aee32270db2feff47dc5a04176169e15850628e6035f78994f9f56035c", ciphertext: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", tag: "b86b0a8e9427af3516950efc81d935d5", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 182 - edge case for poly1305 key:d0b7b3a352a4010ffeffffbfe8cc66dc6e5e7451dc61762c5753174fed88e746", xchacha: true, key: "606162636465666768696a6b6c6d6e6f707172737475767778797a7b7c7d7e7f", nonce: "000102030405060708090a0b0c0d0e0f101112130700b982", aad: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", msg: "68c67272036fb652a0182eeb4781358e4704a4a702fd731bf3b3ea994717989e7d9104e0ae81732a8c7e9a82b3d31d541761a366b67c3396f1a6c67e293ddb65a59e42541dda144dc6c78388cfca982e23350958ac5b3d54a1722fd64733577862e1879c9e9445ebdec5315d1706db7ebbedd4c779935e72057e5b0ecde0814d", ciphertext: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", tag: "3661dc6ddd1852221050ff5b8d58c13f", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 183 - edge case for poly1305 key:7bee33931a4157a8cb701becfeffff4fbe7e69f19cd065313bb49a252628dd3d", xchacha: true, key: "606162636465666768696a6b6c6d6e6f707172737475767778797a7b7c7d7e7f", nonce: "000102030405060708090a0b0c0d0e0f10111213019836bb", aad: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", msg: "c483b7334ebe2e879b0c3f9db4fcd9f5219062360d6ce44cdae0f94e04c8345ea7e3ae33855118741dcafe0de4ae98c4e43af7b12b04ee8ab175625823ac040e5abac4403f1d45238adcb8c0cf44bd56917f9f5d93974c82b56951986a9c0450bd9047b5a616e814526ad0580e3ecd8189c9fef2cdb979a22ad3a01930fbd15e", ciphertext: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", tag: "02c70e4defe897a47a65063a468db630", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 184 - edge case for poly1305 key:df39fb3f36d8e58f91abffdff9f5feaf109d0e960edcf2b728446ec175ad4c7b", xchacha: true, key: "606162636465666768696a6b6c6d6e6f707172737475767778797a7b7c7d7e7f", nonce: "000102030405060708090a0b0c0d0e0f101112133f1a8eb1", aad: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", msg: "85e40e2106db6aba0fb236d3c980a72e58ce538db7aa3b0326a23d52175c7465c454d8206b4d8aedd51d8cc47424f6124d2586370f4eb51153d215e48347abf8791a6d6d3da4871ab2c0fe5718878c3942365fc75887e6ea6e779911f883fe90b6c0e5870769a860cf619f91c7eeaad69212325404ec4de4d3ab5e7aa89537a4", ciphertext: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", tag: "ecccb94178b76a769c91c27d921fcc6c", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 185 - edge case for poly1305 key:00000090e6e328c242cde5c83e3d8262d467f2bcd53d3755c781f3c6a2cb0648", xchacha: true, key: "606162636465666768696a6b6c6d6e6f707172737475767778797a7b7c7d7e7f", nonce: "000102030405060708090a0b0c0d0e0f101112130552a411", aad: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", msg: "eaccaa778935ef249e0900149dd889462d2a061486ba102b8caebe465f3959fb3119ebb5689676ffdd6d851a26739e772b54a2f5f473ea9c7e58ccbc4cfc953e8c420b2175d9dd519265630bb79bd87a601b113231a8b16ce54c331347ec04c2b1c9160f38207aa46e96feb06dee883eb422fa14908df300bb1a1ef758c408f5", ciphertext: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", tag: "f00ee0097d7dffbd3e4b216c45da89ef", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 186 - edge case for poly1305 key:9e98d64e000000505a07183c5c68c63c14c9266dd37ff86aafc22ddbdb355617", xchacha: true, key: "606162636465666768696a6b6c6d6e6f707172737475767778797a7b7c7d7e7f", nonce: "000102030405060708090a0b0c0d0e0f101112130c807a72", aad: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", msg: "a76c330e015060a17e64cb7b6d753f201f75be8759fd7539fb92b22aef54c9d3029dba0c15cbf7c95135888319c6b2e6276da21e0c351fd522b29aabb5883a3291d6f427de773b124390ef6fd96621ffbc42dfbf7a34da272cbc9ccb1a498d078033d1ac3bf7e92715948b06d69d5c5039e9164ba9c3a02219ec5908206b3bd2", ciphertext: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", tag: "8691693787763ec6c7bf957658b51370", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 187 - edge case for poly1305 key:1048a92e65f5e63102000080d9ae08de4319a7c45fdbe707b9ec1b7e0d635161", xchacha: true, key: "606162636465666768696a6b6c6d6e6f707172737475767778797a7b7c7d7e7f", nonce: "000102030405060708090a0b0c0d0e0f101112130397a143", aad: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", msg: "228a7e15bcce13051de9145f77f7f4ff7921828b4f99efc4ff55ee0d9344955b69ec2d4798b0517f0273c4456ae5ffc5929cbe74ddb0da51d4f2b4df7578a31240c88ae922c3c5eca7b97d72d497062050a587447c562b343d5c71921944872f9fd06b8f34b3eb5d4341f5ff8a907dd7c2e1676b81252726ba54814da51eab8c", ciphertext: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", tag: "7fc8d4bb91c543b9bf5dbf1e7277d823", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 188 - edge case for poly1305 key:01517a2ceb89bbfb5741f7d9000000401a65b132ad661072a00ffe7defbb18a5", xchacha: true, key: "606162636465666768696a6b6c6d6e6f707172737475767778797a7b7c7d7e7f", nonce: "000102030405060708090a0b0c0d0e0f1011121308cb0f3f", aad: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", msg: "c7d843188ab193dfef5c4daf583f952cd4b195f240fa2e704d021723023c123371a41e87dfc6e6c3874a42f331cf035988a38c72ba2da854b1208f98bf8cc29948169481ab3a402d5fcc7ff78f9e31925576dc3938074b8c5b27960e3afc750ad686563688b7441787288d5256c1301d563b7744843bd1ab4eff5be6f1653d44", ciphertext: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", tag: "834c91a6580bf514dfcb5e2f456efe3c", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 189 - edge case for poly1305 key:e73c0100fbd50c408e3c06701c3908209a66d9388dd8e29458376300cb04f56a", xchacha: true, key: "606162636465666768696a6b6c6d6e6f707172737475767778797a7b7c7d7e7f", nonce: "000102030405060708090a0b0c0d0e0f10111213d580ecf3", aad: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", msg: "56d20c8500203274099502f38d547f3008588f396cb521a2bae1800514f1f797c00386d52c09fd64a28b393431848e13dda47f65536bfc681ca73b55a7fc019a4c8358186e009ad3e22a5f08a59b19ca4b3bf11269fecaa49a9e9aff53a02ce2f235fba061ee95eae6177f1153502a50428122a73c83695f17dff5cfde23fdf9", ciphertext: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", tag: "ca3de68e124484e8bb825b069afaa53d", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 190 - edge case for tag", xchacha: true, key: "404142434445464748494a4b4c4d4e4f505152535455565758595a5b5c5d5e5f", nonce: "000102030405060708090a0b0c0d0e0f1011121314151617", aad: "abffffffffffffffffffffffffffffff5a20e89e14ed5af85da66b5e4bdbe002", msg: "660336ffb732a4dcda556c2539d3d2de6cdaed0d7d9104593f8ed69bf0db8aa33c0e746482b7dc53d40b8a5331ca33b874639cdc7a787badd436bcd56e798af3", ciphertext: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", tag: "000102030405060708090a0b0c0d0e0f", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 191 - edge case for tag", xchacha: true, key: "404142434445464748494a4b4c4d4e4f505152535455565758595a5b5c5d5e5f", nonce: "000102030405060708090a0b0c0d0e0f1011121314151617", aad: "ffffffffffffffffffffffffffffffff7c85b8e5991711f804915250b99cf7a7", msg: "660336ffb732a4dcda556c2539d3d2de6cdaed0d7d9104593f8ed69bf0db8aa33c0e746482b7dc53d40b8a5331ca33b874639cdc7a787badd436bcd56e798af3", ciphertext: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", tag: "00000000000000000000000000000000", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 192 - edge case for tag", xchacha: true, key: "404142434445464748494a4b4c4d4e4f505152535455565758595a5b5c5d5e5f", nonce: "000102030405060708090a0b0c0d0e0f1011121314151617", aad: "a8ffffffffffffffffffffffffffffff57599fb21558a903b6a3193419537e06", msg: "660336ffb732a4dcda556c2539d3d2de6cdaed0d7d9104593f8ed69bf0db8aa33c0e746482b7dc53d40b8a5331ca33b874639cdc7a787badd436bcd56e798af3", ciphertext: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", tag: "ffffffffffffffffffffffffffffffff", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 193 - edge case for tag", xchacha: true, key: "404142434445464748494a4b4c4d4e4f505152535455565758595a5b5c5d5e5f", nonce: "000102030405060708090a0b0c0d0e0f1011121314151617", aad: "c1fffffffffffffffffffffffffffffffd71560c5091b863662ffaebc0dd2501", msg: "660336ffb732a4dcda556c2539d3d2de6cdaed0d7d9104593f8ed69bf0db8aa33c0e746482b7dc53d40b8a5331ca33b874639cdc7a787badd436bcd56e798af3", ciphertext: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", tag: "00000080000000800000008000000080", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 194 - edge case for tag", xchacha: true, key: "404142434445464748494a4b4c4d4e4f505152535455565758595a5b5c5d5e5f", nonce: "000102030405060708090a0b0c0d0e0f1011121314151617", aad: "f9ffffffffffffffffffffffffffffff169a825d7ecbf7e107396a2a3dfb4508", msg: "660336ffb732a4dcda556c2539d3d2de6cdaed0d7d9104593f8ed69bf0db8aa33c0e746482b7dc53d40b8a5331ca33b874639cdc7a787badd436bcd56e798af3", ciphertext: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", tag: "ffffff7fffffff7fffffff7fffffff7f", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 195 - edge case for tag", xchacha: true, key: "404142434445464748494a4b4c4d4e4f505152535455565758595a5b5c5d5e5f", nonce: "000102030405060708090a0b0c0d0e0f1011121314151617", aad: "d9ffffffffffffffffffffffffffffffe344f9752a885ccd1a3fa5a9c4187d04", msg: "660336ffb732a4dcda556c2539d3d2de6cdaed0d7d9104593f8ed69bf0db8aa33c0e746482b7dc53d40b8a5331ca33b874639cdc7a787badd436bcd56e798af3", ciphertext: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", tag: "01000000010000000100000001000000", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 196 - edge case for tag", xchacha: true, key: "404142434445464748494a4b4c4d4e4f505152535455565758595a5b5c5d5e5f", nonce: "000102030405060708090a0b0c0d0e0f1011121314151617", aad: "d7ffffffffffffffffffffffffffffffa6627ce99c9c49deb89855b0f9e3f407", msg: "660336ffb732a4dcda556c2539d3d2de6cdaed0d7d9104593f8ed69bf0db8aa33c0e746482b7dc53d40b8a5331ca33b874639cdc7a787badd436bcd56e798af3", ciphertext: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", tag: "ffffffff000000000000000000000000", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 197 - edge case intermediate sums in poly1305", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f101112130bc672c3", aad: "ffffffff", msg: "b3df302dcb7dc4ea184e7fe455afac170395c7a18e950fff87e6de1fb6247d63c3df6823c0030e4c987ef266c4a26f1de7226805d43221db305501f8bd70d20c019264f8f28a963bf61e76272e736412a650fa30062629ead26c6ff651361dd1", ciphertext: "ffffffffffffffffffffffffffffffff9bf90b74324f392a4f5bacf25b31b293b4ffffffffffffffffffffffffffffff09b73f897139ab1417163ce2e8377d03b4ffffffffffffffffffffffffffffff09b73f897139ab1417163ce2e8377d03", tag: "eabfdde61ad23c8f2a380280248e58c3", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 198 - edge case intermediate sums in poly1305", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f101112130bc672c3", aad: "ffffffff", msg: "0d4381de8e79d6e26f93ecb286f0c39beb93332a4325c92a37428d1212ea300f8225755c15bffd71892a7cdfb3bb10e19d6aa8735af47530d8bcc2e5aab850f04068798727366506e74af89e596a1bee", ciphertext: "41634e0cbafbedf788226ca92ca0907373fffffffffffffffffffffffffffffff505e2802a430cc2eeab714688e6800373fffffffffffffffffffffffffffffff505e2802a430cc2eeab714688e68003", tag: "50c4a57ebedcdea7ca65660b209f59a5", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 199 - edge case intermediate sums in poly1305", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f101112130bc672c3", aad: "ffffffff", msg: "a098e743ac00bfe73bf07e350a8681030a93332a4325c92a37428d1212ea300f5952ee9617b1e5f6fef674f5d57ba2e27c6aa8735af47530d8bcc2e5aab850f09b1fe24d25387d819096f0b43faaa9ed", ciphertext: "ecb82891988284f2dc41fe2ea0d6d2eb92ffffffffffffffffffffffffffffff2e72794a284d14459977796cee26320092ffffffffffffffffffffffffffffff2e72794a284d14459977796cee263200", tag: "e87f0c943e93cad2aa76933330d178b3", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 200 - edge case intermediate sums in poly1305", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f101112130bc672c3", aad: "ffffffff", msg: "4f84f6651a29fbfee258748e1aca766af793332a4325c92a37428d1212ea300f4efc9bcd40b2c933349ccf9943bffee2816aa8735af47530d8bcc2e5aab850f08cb19716723b51445afc4bd8a96ef5ed", ciphertext: "03a439b72eabc0eb05e9f495b09a25826fffffffffffffffffffffffffffffff39dc0c117f4e3880531dc20078e26e006fffffffffffffffffffffffffffffff39dc0c117f4e3880531dc20078e26e00", tag: "66eaccae5e377108c3cbcb65c4cf1fc5", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 201 - edge case intermediate sums in poly1305", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f101112130bc672c3", aad: "ffffffff", msg: "89c4ebb097d8a8ca651efd999ec5e978f293332a4325c92a37428d1212ea300f1441067a4cbbeefa9753105cd0f774e2846aa8735af47530d8bcc2e5aab850f0d60c0aa17e32768df933941d3a267fed", ciphertext: "c5e42462a35a93df82af7d823495ba906affffffffffffffffffffffffffffff636191a673471f49f0d21dc5ebaae4006affffffffffffffffffffffffffffff636191a673471f49f0d21dc5ebaae400", tag: "7c10f4defe910369bb3131c06800e6e4", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 202 - edge case intermediate sums in poly1305", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f101112130bc672c3", aad: "ffffffff", msg: "fddf302dcb7dc4ea184e7fe455afac17939c6e78f0822881ce7c8770358492f05edf6823c0030e4c987ef266c4a26f1da1256412895a0a39e02b6e33de1e470f9c9264f8f28a963bf61e76272e736412e057f6275b4e02080212003d325888d2", ciphertext: "b1ffffffffffffffffffffffffffffff0bf0a2ad4c581e5406c1f59dd8915d0029ffffffffffffffffffffffffffffff4fb0339e2c5180f6c76853298b59e80029ffffffffffffffffffffffffffffff4fb0339e2c5180f6c76853298b59e800", tag: "e269df519b62d7658b8ce3487588f409", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 203 - edge case intermediate sums in poly1305", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f101112130bc672c3", aad: "ffffffff", msg: "cadf302dcb7dc4ea184e7fe455afac1775d301ea3449120a660e322211c0faf2ef3bc9988d0f611185984c8403a7c321768e09c817f81a6dc55a7c076dbdfccc", ciphertext: "86ffffffffffffffffffffffffffffffedbfcd3f889324dfaeb340cffcd53502981b5e44b2f390a2e219411d38fa53c3981b5e44b2f390a2e219411d38fa53c3", tag: "a084fcb71338faabb02bb26c1b7c1a55", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 204 - edge case intermediate sums in poly1305", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f101112130bc672c3", aad: "ffffffff", msg: "0adf302dcb7dc4ea184e7fe455afac174162bce2fd47b3594ef404de7191f6f286df6823c0030e4c987ef266c4a26f1d776e5ca8708b77eef3cb4a6b5a87d50c449264f8f28a963bf61e76272e736412361cce9da29f7fdf11f22465b6c11ad1", ciphertext: "46ffffffffffffffffffffffffffffffd90e7037419d858c864976339c843902f1ffffffffffffffffffffffffffffff99fb0b24d580fd21d48877710fc07a03f1ffffffffffffffffffffffffffffff99fb0b24d580fd21d48877710fc07a03", tag: "8f7f47eb00819694ca25bd8a5cd263cd", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 205 - edge case intermediate sums in poly1305", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f101112130bc672c3", aad: "ffffffff", msg: "14df302dcb7dc4ea184e7fe455afac17b326bb0d3f07ed0375e02c41e3162ff1f5187d3ad276581fb80055b5ab3a07c26cadbd6a48812363f8c26536c520382f", ciphertext: "58ffffffffffffffffffffffffffffff2b4a77d883dddbd6bd5d5eac0e03e0018238eae6ed8aa9acdf81582c906797208238eae6ed8aa9acdf81582c90679720", tag: "6e29bbb07bacd01dde68f7ceba8dcf6c", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 206 - edge case intermediate sums in poly1305", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f101112130bc672c3", aad: "ffffffff", msg: "26df302dcb7dc4ea184e7fe455afac1774a785d5558e3814492622e81e8334f018269cb3ac4b09e4f30a18a38601afbd81935ce336bc7298b3c82820e81b9050", ciphertext: "6affffffffffffffffffffffffffffffeccb4900e9540ec1819b5005f396fb006f060b6f93b7f857948b153abd5c3f5f6f060b6f93b7f857948b153abd5c3f5f", tag: "dc8de3adc9cf0095ab93f73b92e38f8e", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 207 - edge case intermediate sums in poly1305", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f101112130bc672c3", aad: "ffffffff", msg: "c2df302dcb7dc4ea184e7fe455afac171b2de59c9d989d18693805cc529ef7f3293410a473f31e55f38cdf2621490502b081d0f4e9046529b34eefa54f533aef", ciphertext: "8effffffffffffffffffffffffffffff834129492142abcda1857721bf8b38035e1487784c0fefe6940dd2bf1a1495e05e1487784c0fefe6940dd2bf1a1495e0", tag: "41da20c0d2480aabf6ec50678325ca55", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 208 - edge case intermediate sums in poly1305", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f101112130bc672c3", aad: "ffffffff", msg: "02df302dcb7dc4ea184e7fe455afac17f7fc079566913aaeb1a6df68b32ff3f38ddf6823c0030e4c987ef266c4a26f1d46edbe93aa3a33c96e1639a5b0b8ea0f4f9264f8f28a963bf61e76272e736412079f2ca6782e3bf88c2f57ab5cfe25d2", ciphertext: "4effffffffffffffffffffffffffffff6f90cb40da4b0c7b791bad855e3a3c03faffffffffffffffffffffffffffffffa878e91f0f31b906495504bfe5ff4500faffffffffffffffffffffffffffffffa878e91f0f31b906495504bfe5ff4500", tag: "30d56bf3bf91a69310e75b85c47b13ce", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 209 - edge case intermediate sums in poly1305", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f101112130bc672c3", aad: "ffffffff", msg: "b4061d1155ecf464e71b76b58f7001fdc393332a4325c92a37428d1212ea300fc8d4b0692d780bf3bd8b6ad483a9a6e0b56aa8735af47530d8bcc2e5aab850f00a99bcb21ff19384d3ebee956978adef", ciphertext: "f826d2c3616ecf7100aaf6ae252052155bffffffffffffffffffffffffffffffbff427b51284fa40da0a674db8f436025bffffffffffffffffffffffffffffffbff427b51284fa40da0a674db8f43602", tag: "cdfe4b827e5558a497f309fb493d209d", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 210 - edge case intermediate sums in poly1305", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f101112130bc672c3", aad: "ffffffff", msg: "79fff2a7076a9cdbe3e98b10b2de26310893332a4325c92a37428d1212ea300f2d2d2a92a3d127ed8ded071cbf2d71e37e6aa8735af47530d8bcc2e5aab850f0ef6026499158bf9ae38d835d55fc7aec", ciphertext: "35df3d7533e8a7ce04580b0b188e75d990ffffffffffffffffffffffffffffff5a0dbd4e9c2dd65eea6c0a858470e10190ffffffffffffffffffffffffffffff5a0dbd4e9c2dd65eea6c0a858470e101", tag: "d168917ea5f3a1aaf11f260b1ad87ba6", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 211 - edge case intermediate sums in poly1305", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f101112130bc672c3", aad: "ffffffff", msg: "b3df302dcb7dc4ea184e7fe455afac1788d961aaa04f7a3b682f69a70583605285df6823c0030e4c987ef266c4a26f1dd6acb067c1cfa95c474abc0784f6440d479264f8f28a963bf61e76272e73641297de225213dba16da573d20968b08bd0", ciphertext: "ffffffffffffffffffffffffffffffff10b5ad7f1c954ceea0921b4ae896afa2f2ffffffffffffffffffffffffffffff3839e7eb64c423936009811dd1b1eb02f2ffffffffffffffffffffffffffffff3839e7eb64c423936009811dd1b1eb02", tag: "7c9d1f445000923ba0f6712dcef103f8", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 212 - edge case intermediate sums in poly1305", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f101112130bc672c3", aad: "ffffffff", msg: "77df302dcb7dc4ea184e7fe455afac17fe07b21e1574641d2f3bf1f112533ff0eddf6823c0030e4c987ef266c4a26f1d01519c6f96c6673d21e6e5cad204f00d2f9264f8f28a963bf61e76272e73641240230e5a44d26f0cc3df8bc43e423fd0", ciphertext: "3bffffffffffffffffffffffffffffff666b7ecba9ae52c8e786831cff46f0009affffffffffffffffffffffffffffffefc4cbe333cdedf206a5d8d087435f029affffffffffffffffffffffffffffffefc4cbe333cdedf206a5d8d087435f02", tag: "0eff35568cd1f12e9c87c10f7ac886d9", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 213 - edge case intermediate sums in poly1305", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f101112130bc672c3", aad: "ffffffff", msg: "b3df302dcb7dc4ea184e7fe455afac170495c7a18e950fff87e6de1fb6247d632cdf6823c0030e4c987ef266c4a26f1dbd83680da360ceb5adec202a9a7b9e0cee9264f8f28a963bf61e76272e736412fcf1fa387174c6844fd54e24763d51d1", ciphertext: "ffffffffffffffffffffffffffffffff9cf90b74324f392a4f5bacf25b31b2935bffffffffffffffffffffffffffffff53163f81066b447a8aaf1d30cf3c31035bffffffffffffffffffffffffffffff53163f81066b447a8aaf1d30cf3c3103", tag: "972e0ccb273da0e432560bb025a3dafd", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 214 - edge case intermediate sums in poly1305", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f101112130bc672c3", aad: "ffffffff", msg: "b3df302dcb7dc4ea184e7fe455afac170295c7a18e950fff87e6de1fb6247d6388df6823c0030e4c987ef266c4a26f1dbd7d7050390dc18a9374c53a6e25993a4a9264f8f28a963bf61e76272e736412fc0fe265eb19c9bb714dab34826356e7", ciphertext: "ffffffffffffffffffffffffffffffff9af90b74324f392a4f5bacf25b31b293ffffffffffffffffffffffffffffffff53e827dc9c064b45b437f8203b623635ffffffffffffffffffffffffffffffff53e827dc9c064b45b437f8203b623635", tag: "3851af020e67d939221af94f2379d688", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 215 - edge case intermediate sums in poly1305", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f101112130bc672c3", aad: "ffffffff", msg: "dcdf302dcb7dc4ea184e7fe455afac17cb0c79e00a6a36ff33be642b05f84df2d8df6823c0030e4c987ef266c4a26f1dc738f395f5468655095afa9f9134cb0d1a9264f8f28a963bf61e76272e736412864a61a027528e64eb6394917d7204d0", ciphertext: "90ffffffffffffffffffffffffffffff5360b535b6b0002afb0316c6e8ed8202afffffffffffffffffffffffffffffff29ada419504d0c9a2e19c785c4736402afffffffffffffffffffffffffffffff29ada419504d0c9a2e19c785c4736402", tag: "41c5ffe07dbb3c988a9e3687296007bd", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 216 - edge case intermediate sums in poly1305", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f101112130bc672c3", aad: "ffffffff", msg: "70d7ba38c2570c77ca364185a1184c5f6793332a4325c92a37428d1212ea300fa4fb7ba93c8f1aeb596ee85db59503be116aa8735af47530d8bcc2e5aab850f066b677720e06829c370e6c1c5f4408b1", ciphertext: "3cf775eaf6d537622d87c19e0b481fb7ffffffffffffffffffffffffffffffffd3dbec750373eb583eefe5c48ec8935cffffffffffffffffffffffffffffffffd3dbec750373eb583eefe5c48ec8935c", tag: "a0dcb67a9f930f9d79381e04c38c91a1", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 217 - edge case intermediate sums in poly1305", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f101112130bc672c3", aad: "ffffffff", msg: "b3df302dcb7dc4ea184e7fe455afac176ef295891c6dd4bc8cc077c62e41f9ab88df6823c0030e4c987ef266c4a26f1da826a752304368e3b429f83cd53af9ce4a9264f8f28a963bf61e76272e736412e9543567e25760d256109632397c3613", ciphertext: "fffffffffffffffffffffffffffffffff69e595ca0b7e269447d052bc354365bffffffffffffffffffffffffffffffff46b3f0de9548e22c936ac526807d56c1ffffffffffffffffffffffffffffffff46b3f0de9548e22c936ac526807d56c1", tag: "a12b34e8828deb913809858245813ac4", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 218 - edge case intermediate sums in poly1305", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f101112130bc672c3", aad: "ffffffff", msg: "d028532613e6ac2299f545fa399347d35a56485c99d079eb0214cff4e9a45b15b51a13551af6be8dad28b0803fec0407", ciphertext: "9c089cf4276497377e44c5e193c3143bc23a8489250a4f3ecaa9bd1904b194e5c23a8489250a4f3ecaa9bd1904b194e5", tag: "3e5a4dd56980cdc49b3fc7f1a4e5de80", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 219 - edge case intermediate sums in poly1305", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f101112130bc672c3", aad: "ffffffff", msg: "d4df302dcb7dc4ea184e7fe455afac17c0441010cd4c556db2422415f1ac35f25af02df6e257058ff8b02f629b6335e1c345eda678a07ef3b8721fe1f5790a0c", ciphertext: "98ffffffffffffffffffffffffffffff5828dcc5719663b87aff56f81cb9fa022dd0ba2addabf43c9f3122fba03ea5032dd0ba2addabf43c9f3122fba03ea503", tag: "693df6c4750d80c9c6db9b8290908856", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 220 - edge case intermediate sums in poly1305", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f101112130bc672c3", aad: "ffffffff", msg: "b3df302dcb7dc4ea184e7fe455afac171c62379820fccbe5ee09c766a678900da0df6823c0030e4c987ef266c4a26f1d10d9fb4ebf330d167b33ce570a3bef0c629264f8f28a963bf61e76272e73641251ab697b6d270527990aa059e67d20d1", ciphertext: "ffffffffffffffffffffffffffffffff840efb4d9c26fd3026b4b58b4b6d5ffdd7fffffffffffffffffffffffffffffffe4cacc21a3887d95c70f34d5f7c4003d7fffffffffffffffffffffffffffffffe4cacc21a3887d95c70f34d5f7c4003", tag: "6c551dccfa2d965912e3c94d908fb1b1", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 221 - edge case intermediate sums in poly1305", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f101112130bc672c3", aad: "ffffffff", msg: "0adf302dcb7dc4ea184e7fe455afac17f2a46865a2bc9f3931a21ebec77a0bf282df6823c0030e4c987ef266c4a26f1df3fdca1d1d24c0a818c33b641cced60c409264f8f28a963bf61e76272e736412b28f5828cf30c899fafa556af08819d1", ciphertext: "46ffffffffffffffffffffffffffffff6ac8a4b01e66a9ecf91f6c532a6fc402f5ffffffffffffffffffffffffffffff1d689d91b82f4a673f80067e49897903f5ffffffffffffffffffffffffffffff1d689d91b82f4a673f80067e49897903", tag: "041184e17ae48184f2f3f675a0c1d0bf", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 222 - edge case intermediate sums in poly1305", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f101112130bc672c3", aad: "ffffffff", msg: "15df302dcb7dc4ea184e7fe455afac177ba4dff6c26c959df060bfbcace3f2f236df6823c0030e4c987ef266c4a26f1dc5a90df77ff0db40fc59c0318f88cb0cf49264f8f28a963bf61e76272e73641284db9fc2ade4d3711e60ae3f63ce04d1", ciphertext: "59ffffffffffffffffffffffffffffffe3c813237eb6a34838ddcd5141f63d0241ffffffffffffffffffffffffffffff2b3c5a7bdafb518fdb1afd2bdacf640341ffffffffffffffffffffffffffffff2b3c5a7bdafb518fdb1afd2bdacf6403", tag: "3932ed921c20c30f251e2495cafcee7d", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 223 - edge case intermediate sums in poly1305", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f101112130bc672c3", aad: "ffffffff", msg: "b3df302dcb7dc4ea184e7fe455afac17be99ee99ce9825877e959642d2f3f02688df6823c0030e4c987ef266c4a26f1d16c6f0928b580a43c7ffc494a6818c704a9264f8f28a963bf61e76272e73641257b462a7594c027225c6aa9a4ac743ad", ciphertext: "ffffffffffffffffffffffffffffffff26f5224c72421352b628e4af3fe63fd6fffffffffffffffffffffffffffffffff853a71e2e53808ce0bcf98ef3c6237ffffffffffffffffffffffffffffffffff853a71e2e53808ce0bcf98ef3c6237f", tag: "1fe14698bc0d7e1a5d622f9f4ec97681", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 224 - edge case intermediate sums in poly1305", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f101112130bc672c3", aad: "ffffffff", msg: "1adf302dcb7dc4ea184e7fe455afac17375870a70cf967d44c072c976f960cf202df6823c0030e4c987ef266c4a26f1d90342adb10594c487a08ef575ddc120fc09264f8f28a963bf61e76272e736412d146b8eec24d447998318159b19addd2", ciphertext: "56ffffffffffffffffffffffffffffffaf34bc72b023510184ba5e7a8283c30275ffffffffffffffffffffffffffffff7ea17d57b552c6875d4bd24d089bbd0075ffffffffffffffffffffffffffffff7ea17d57b552c6875d4bd24d089bbd00", tag: "48a52de01229d381e42274737fceeebe", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 225 - edge case intermediate sums in poly1305", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f101112130552a411", aad: "ffffffff", msg: "e15491e17fb1ebf66bb0a3ecbc1bc251544d1a6c930b659ca3903632f84c51b676fb4365776d1717fb3f9f45f9888c9764482cfbc35450c0a2a1c8aab04f5ac644871d72e9f20f1fd6a77429527f8b81df97e388864bad33006e671b757b158b", ciphertext: "e2ffffffffffffffffffffffffffffff2e36b18f2fc925af8ca7b8922f3d0402ffffffffffffffffffffffffffffffff730856e81fee6ffa095f96faa3c87deeffffffffffffffffffffffffffffffff730856e81fee6ffa095f96faa3c87dee", tag: "457c13b040b790624a47b6d232c96c2e", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 226 - edge case intermediate sums in poly1305", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f101112130552a411", aad: "ffffffff", msg: "c55491e17fb1ebf66bb0a3ecbc1bc25151b278ab6424829315f4b083322f0db37ffb4365776d1717fb3f9f45f9888c97f3f689761bc8de9b4c93af122a8f78224d871d72e9f20f1fd6a77429527f8b81482946055ed72368ee5c00a3efbb376f", ciphertext: "c6ffffffffffffffffffffffffffffff2bc9d348d8e6c2a03ac33e23e55e5807f6ffffffffffffffffffffffffffffffe4b6f365c772e1a1e76df14239085f0af6ffffffffffffffffffffffffffffffe4b6f365c772e1a1e76df14239085f0a", tag: "4c21dabf80184666ee0752d61aee183a", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 227 - edge case intermediate sums in poly1305", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f101112130552a411", aad: "ffffffff", msg: "fc5491e17fb1ebf66bb0a3ecbc1bc2510c5890208ce83ac784320ba346f951dc879bb667caf70858b7f01163af0ef3d019df70ee9edfdf8a18ce2f89bafea790", ciphertext: "ffffffffffffffffffffffffffffffff76233bc3302a7af4ab058503918804680e9f0afd4265e0b0b33071d9a97980b80e9f0afd4265e0b0b33071d9a97980b8", tag: "b41fc59d364e1050c2076dfe596ba799", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 228 - edge case intermediate sums in poly1305", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f101112130552a411", aad: "ffffffff", msg: "d65491e17fb1ebf66bb0a3ecbc1bc251ceae77b54a415c7267e60dd49c6196be74fb4365776d1717fb3f9f45f9888c97715e4c777914ba04fa45c4c0a4133a2a46871d72e9f20f1fd6a77429527f8b81ca8183043c0b47f7588a6b7161277567", ciphertext: "d5ffffffffffffffffffffffffffffffb4d5dc56f6831c4148d183744b10c30afdffffffffffffffffffffffffffffff661e3664a5ae853e51bb9a90b7941d02fdffffffffffffffffffffffffffffff661e3664a5ae853e51bb9a90b7941d02", tag: "8beed54dc183a687b2cdcbf6423a26a3", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 229 - edge case intermediate sums in poly1305", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f101112130552a411", aad: "ffffffff", msg: "e65491e17fb1ebf66bb0a3ecbc1bc2514c46b4b6687d80ee9e29a3621eedd4b676fb4365776d1717fb3f9f45f9888c971cff23a21f6a8bd7453510a0ae5e1f1c44871d72e9f20f1fd6a77429527f8b81a720ecd15a757624e7fabf116b6a5051", ciphertext: "e5ffffffffffffffffffffffffffffff363d1f55d4bfc0ddb11e2dc2c99c8102ffffffffffffffffffffffffffffffff0bbf59b1c3d0b4edeecb4ef0bdd93834ffffffffffffffffffffffffffffffff0bbf59b1c3d0b4edeecb4ef0bdd93834", tag: "cabbd1db01ef06a9769345176b86330c", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 230 - edge case intermediate sums in poly1305", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f101112130552a411", aad: "ffffffff", msg: "ca5491e17fb1ebf66bb0a3ecbc1bc25149abeaedc11f1dfc700d3df2a8cf80b36ffb4365776d1717fb3f9f45f9888c97fbfd1b38b0d343eaa71b3b22c0e0fb225d871d72e9f20f1fd6a77429527f8b814022d44bf5ccbe1905d4949305d4b46f", ciphertext: "c9ffffffffffffffffffffffffffffff33d0410e7ddd5dcf5f3ab3527fbed507e6ffffffffffffffffffffffffffffffecbd612b6c697cd00ce56572d367dc0ae6ffffffffffffffffffffffffffffffecbd612b6c697cd00ce56572d367dc0a", tag: "d16098eb4150bcac1a54e11a53abdf17", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 231 - edge case intermediate sums in poly1305", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f101112130552a411", aad: "ffffffff", msg: "bc5491e17fb1ebf66bb0a3ecbc1bc25122253ac53ee2d495cd59c3cf874bdfb378fb4365776d1717fb3f9f45f9888c97f85d6026b6bdd3ade44614db13a4d22e4a871d72e9f20f1fd6a77429527f8b814382af55f3a22e5e4689bb6ad6909d63", ciphertext: "bfffffffffffffffffffffffffffffff585e9126822094a6e26e4d6f503a8a07f1ffffffffffffffffffffffffffffffef1d1a356a07ec974fb84a8b0023f506f1ffffffffffffffffffffffffffffffef1d1a356a07ec974fb84a8b0023f506", tag: "6005e4ae07fba16ee6e7cfd2ee645c26", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 232 - edge case intermediate sums in poly1305", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f101112130552a411", aad: "ffffffff", msg: "8686c6910ecd2fef887e60e38f44794013658b8d8a4428cc894d48296a78289fe01a9cf4be148017a2baa633bb7e0e43", ciphertext: "852da88f8e833be61c313cf0cca044ee691e206e368668ffa67ac689bd097d2b691e206e368668ffa67ac689bd097d2b", tag: "91d0ac28cd0f09a8261194b8df0abc0d", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 233 - edge case intermediate sums in poly1305", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f101112130552a411", aad: "ffffffff", msg: "fc5491e17fb1ebf66bb0a3ecbc1bc25176cab059fb79ced851f140731235a58a23285769d164044f8231da13d8421fe7bd6c91e0854cd39d2d0fe4f9cdb24ba7", ciphertext: "ffffffffffffffffffffffffffffffff0cb11bba47bb8eeb7ec6ced3c544f03eaa2cebf359f6eca786f1baa9de356c8faa2cebf359f6eca786f1baa9de356c8f", tag: "9d652db06f7b08621f02c7f294abbe58", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 234 - edge case intermediate sums in poly1305", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f101112130552a411", aad: "ffffffff", msg: "fc5491e17fb1ebf66bb0a3ecbc1bc2519418c44ef4ee0a2eecf688092ee3893805db827dd2f54831ce2c92c414f32bb59b9f44f486dd9fe36112ac2e01037ff5", ciphertext: "ffffffffffffffffffffffffffffffffee636fad482c4a1dc3c106a9f992dc8c8cdf3ee75a67a0d9caecf27e128458dd8cdf3ee75a67a0d9caecf27e128458dd", tag: "b5893d2129f0408a5480c7fc3fc9de5d", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 235 - edge case intermediate sums in poly1305", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f101112130552a411", aad: "ffffffff", msg: "c45491e17fb1ebf66bb0a3ecbc1bc25198183353086ce0c47d429f682ef227bc70fb4365776d1717fb3f9f45f9888c97d9e04fda5db617d05384a3871e4be42f42871d72e9f20f1fd6a77429527f8b81623f80a918a9ea23f14b0c36db7fab62", ciphertext: "c7ffffffffffffffffffffffffffffffe26398b0b4aea0f7527511c8f9837208f9ffffffffffffffffffffffffffffffcea035c9810c28eaf87afdd70dccc307f9ffffffffffffffffffffffffffffffcea035c9810c28eaf87afdd70dccc307", tag: "0b88cfa42284726dad2be1de8a057626", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 236 - edge case intermediate sums in poly1305", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f101112130552a411", aad: "ffffffff", msg: "fc5491e17fb1ebf66bb0a3ecbc1bc251f4ab27dfe421f982006cf28959f81f8da248e0ece28cc88632460845a00db5e13c0c2665b6a41f549d7836afb5fde1a1", ciphertext: "ffffffffffffffffffffffffffffffff8ed08c3c58e3b9b12f5b7c298e894a392b4c5c766a1e206e368668ffa67ac6892b4c5c766a1e206e368668ffa67ac689", tag: "5e97665318961c4c95e928fc11140063", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 237 - edge case intermediate sums in poly1305", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f101112130552a411", aad: "ffffffff", msg: "fc5491e17fb1ebf66bb0a3ecbc1bc2513d04e3a75f48cd89944fe3aeb6fa3a376dfeabe47e571b9ec763395e7e0b98bbf3ba6d6d2a7fcc4c685d07b46bfbccfb", ciphertext: "ffffffffffffffffffffffffffffffff477f4844e38a8dbabb786d0e618b6f83e4fa177ef6c5f376c3a359e4787cebd3e4fa177ef6c5f376c3a359e4787cebd3", tag: "ff71ef44bc734132701144f73497756b", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 238 - edge case intermediate sums in poly1305", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f101112130552a411", aad: "ffffffff", msg: "c75491e17fb1ebf66bb0a3ecbc1bc251ea035faf17204b15b07af9577ad41ab57dfb4365776d1717fb3f9f45f9888c977c945978f412121f3e3061486ce872284f871d72e9f20f1fd6a77429527f8b81c74b960bb10defec9cffcef9a9dc3d65", ciphertext: "c4ffffffffffffffffffffffffffffff9078f44cabe20b269f4d77f7ada54f01f4ffffffffffffffffffffffffffffff6bd4236b28a82d2595ce3f187f6f5500f4ffffffffffffffffffffffffffffff6bd4236b28a82d2595ce3f187f6f5500", tag: "2ab3cabef2de4268a5313cd59dcd193e", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 239 - edge case intermediate sums in poly1305", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f101112130552a411", aad: "ffffffff", msg: "db5491e17fb1ebf66bb0a3ecbc1bc251b8328dab56edd30a61fa13c9309ca7b656fb4365776d1717fb3f9f45f9888c97a34a4557084bc0e73cf64dd52006892b64871d72e9f20f1fd6a77429527f8b8118958a244d543d149e39e264e532c666", ciphertext: "d8ffffffffffffffffffffffffffffffc2492648ea2f93394ecd9d69e7edf202dfffffffffffffffffffffffffffffffb40a3f44d4f1ffdd970813853381ae03dfffffffffffffffffffffffffffffffb40a3f44d4f1ffdd970813853381ae03", tag: "32bcb167c875045b9651816e4bedf51a", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 240 - edge case intermediate sums in poly1305", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f101112130552a411", aad: "ffffffff", msg: "c45491e17fb1ebf66bb0a3ecbc1bc2519df3bd6867975c3fe85b5ac979cd8cbd77fb4365776d1717fb3f9f45f9888c979a108f9b640c85feaa90eda0ca3b552245871d72e9f20f1fd6a77429527f8b8121cf40e82113780d085f42110f0f1a6f", ciphertext: "c7ffffffffffffffffffffffffffffffe788168bdb551c0cc76cd469aebcd909feffffffffffffffffffffffffffffff8d50f588b8b6bac4016eb3f0d9bc720afeffffffffffffffffffffffffffffff8d50f588b8b6bac4016eb3f0d9bc720a", tag: "2ec4ac70d29ad094e46ce355eb94d1ea", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 241 - edge case intermediate sums in poly1305", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f101112130552a411", aad: "ffffffff", msg: "e15491e17fb1ebf66bb0a3ecbc1bc251554d1a6c930b659ca3903632f84c51b676fb4365776d1717fb3f9f45f9888c976a196530d71b9fdcce0639e14bb7952d44871d72e9f20f1fd6a77429527f8b81d1c6aa439204622f6cc996508e83da60", ciphertext: "e2ffffffffffffffffffffffffffffff2f36b18f2fc925af8ca7b8922f3d0402ffffffffffffffffffffffffffffffff7d591f230ba1a0e665f867b15830b205ffffffffffffffffffffffffffffffff7d591f230ba1a0e665f867b15830b205", tag: "3c2b4a7555046076eeade41b7e613817", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 242 - edge case intermediate sums in poly1305", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f101112130552a411", aad: "ffffffff", msg: "e15491e17fb1ebf66bb0a3ecbc1bc251574d1a6c930b659ca3903632f84c51b646fb4365776d1717fb3f9f45f9888c97a9e8b1b1ebcd7efac51aa6a07ccbb72f74871d72e9f20f1fd6a77429527f8b8112377ec2aed2830967d50911b9fff862", ciphertext: "e2ffffffffffffffffffffffffffffff2d36b18f2fc925af8ca7b8922f3d0402cfffffffffffffffffffffffffffffffbea8cba2377741c06ee4f8f06f4c9007cfffffffffffffffffffffffffffffffbea8cba2377741c06ee4f8f06f4c9007", tag: "4ecddcea2b6ac14ea6e08789e730a145", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 243 - edge case intermediate sums in poly1305", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f101112130552a411", aad: "ffffffff", msg: "fc5491e17fb1ebf66bb0a3ecbc1bc25183a4bdec687df1f2c2ad89017dc3882773fb4365776d1717fb3f9f45f9888c97a3bf9d6304f938afc62286de1edf752a41871d72e9f20f1fd6a77429527f8b811860521041e6c55c64ed296fdbeb3a67", ciphertext: "fffffffffffffffffffffffffffffffff9df160fd4bfb1c1ed9a07a1aab2dd93faffffffffffffffffffffffffffffffb4ffe770d84307956ddcd88e0d585202faffffffffffffffffffffffffffffffb4ffe770d84307956ddcd88e0d585202", tag: "c21949bfae429c58105372c714964e39", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 244 - edge case intermediate sums in poly1305", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f101112130552a411", aad: "ffffffff", msg: "5160a0e22d2c72c25ca00ff76844f5219984541c433dbfccd0c8715f288eaa4b84bee9e212f601afdf23e0616fc61b6bf4bf85ec2345c0c55401a1afec78d8d7b6c2b7f58c6919a7f2bb0b0dc4311c7d", ciphertext: "52cbcefcad6266cbc8ef53e42ba0c88fe3ffffffffffffffffffffffffffffff0dba55789a64e947dbe380db69b16803e3ffffffffffffffffffffffffffffff0dba55789a64e947dbe380db69b16803", tag: "31827cfd0227dae6020564fe6677828e", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 245 - edge case intermediate sums in poly1305", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f101112130552a411", aad: "ffffffff", msg: "aa9b0cff80d2504071b708a267795adc9a84541c433dbfccd0c8715f288eaa4b1278f017b369022628c50b0e914a786df7bf85ec2345c0c55401a1afec78d8d72004ae002df61a2e055de0623abd7f7b", ciphertext: "a93062e1009c4449e5f854b1249d6772e0ffffffffffffffffffffffffffffff9b7c4c8d3bfbeace2c056bb4973d0b05e0ffffffffffffffffffffffffffffff9b7c4c8d3bfbeace2c056bb4973d0b05", tag: "0f146dfc74edd6e8b92e4efde9568392", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 246 - edge case intermediate sums in poly1305", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f101112130552a411", aad: "ffffffff", msg: "d65491e17fb1ebf66bb0a3ecbc1bc2513544a1ff7d10d3f8228b7397b54ab7b34afb4365776d1717fb3f9f45f9888c978f4f155538a2b7fa71deaf145ed8782278871d72e9f20f1fd6a77429527f8b813490da267dbd4a09d31100a59bec376f", ciphertext: "d5ffffffffffffffffffffffffffffff4f3f0a1cc1d293cb0dbcfd37623be207c3ffffffffffffffffffffffffffffff980f6f46e41888c0da20f1444d5f5f0ac3ffffffffffffffffffffffffffffff980f6f46e41888c0da20f1444d5f5f0a", tag: "8fcacbc3d070336717f838d754f7033d", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 247 - edge case intermediate sums in poly1305", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f101112130552a411", aad: "ffffffff", msg: "fc5491e17fb1ebf66bb0a3ecbc1bc25127c97067599ad88acd1d636b71347168702917247f01169dee95b91bb941d344ee6dd1ad2b29c14f41ab87f1acb18704", ciphertext: "ffffffffffffffffffffffffffffffff5db2db84e55898b9e22aedcba64524dcf92dabbef793fe75ea55d9a1bf36a02cf92dabbef793fe75ea55d9a1bf36a02c", tag: "ddd96272c682dd45ffb580f4db058e79", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 248 - edge case intermediate sums in poly1305", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f101112130552a411", aad: "ffffffff", msg: "fc5491e17fb1ebf66bb0a3ecbc1bc251319e8e2a169df9a16b932cf0eae456437bfb4365776d1717fb3f9f45f9888c97c943391482e56fb6754746325960302049871d72e9f20f1fd6a77429527f8b81729cf667c7fa9245d788e9839c547f6d", ciphertext: "ffffffffffffffffffffffffffffffff4be525c9aa5fb99244a4a2503d9503f7f2ffffffffffffffffffffffffffffffde0343075e5f508cdeb918624ae71708f2ffffffffffffffffffffffffffffffde0343075e5f508cdeb918624ae71708", tag: "2243326100d105501684abc0aa1010f4", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 249 - edge case intermediate sums in poly1305", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f101112130552a411", aad: "ffffffff", msg: "fe5491e17fb1ebf66bb0a3ecbc1bc2514233fe057ace7ef2ad7b40a5b53109bc417d2b9ad117d19e4636e26eac900c29df39ed13853f064ce908dc84b9605869", ciphertext: "fdffffffffffffffffffffffffffffff384855e6c60c3ec1824cce0562405c08c87997005985397642f682d4aae77f41c87997005985397642f682d4aae77f41", tag: "8a411d3fb606d039ea83c6e8e98d9e53", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 250 - edge case intermediate sums in poly1305", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f101112130552a411", aad: "ffffffff", msg: "dc5491e17fb1ebf66bb0a3ecbc1bc2514402b2a59ff82793e8c9de3fc366c3b357fb4365776d1717fb3f9f45f9888c9756054f75d716ae500d8b97befddc012065871d72e9f20f1fd6a77429527f8b81edda8006920953a3af44380f38e84e6d", ciphertext: "dfffffffffffffffffffffffffffffff3e791946233a67a0c7fe509f14179607deffffffffffffffffffffffffffffff414535660bac916aa675c9eeee5b2608deffffffffffffffffffffffffffffff414535660bac916aa675c9eeee5b2608", tag: "5ed9e3c1cacff152211b5c57fd628278", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 251 - edge case intermediate sums in poly1305", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f101112130552a411", aad: "ffffffff", msg: "c75491e17fb1ebf66bb0a3ecbc1bc2514e1cde0c37340ebdfe6eb58c35626bb54bfb4365776d1717fb3f9f45f9888c972073eb1ca8d246621b2eed2bafdfe92979871d72e9f20f1fd6a77429527f8b819bac246fedcdbb91b9e1429a6aeba664", ciphertext: "c4ffffffffffffffffffffffffffffff346775ef8bf64e8ed1593b2ce2133e01c2ffffffffffffffffffffffffffffff3733910f74687958b0d0b37bbc58ce01c2ffffffffffffffffffffffffffffff3733910f74687958b0d0b37bbc58ce01", tag: "657eaad10a31a756c5dbf75ae5872e84", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 252 - edge case intermediate sums in poly1305", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f101112130552a411", aad: "ffffffff", msg: "55cc2b7c86aa8ef3dc2545e3e79c9afa402316a313248720fc842d2942908a25b35c01da27742ffbd773c3339396acf9", ciphertext: "5667456206e49afa486a19f0a478a7543a58bd40afe6c713d3b3a38995e1df913a58bd40afe6c713d3b3a38995e1df91", tag: "25545d0636b19f9319ff5bb0191c89fb", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 253 - edge case intermediate sums in poly1305", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f10111213019836bb", aad: "ffffffff", msg: "690ed780cbde3c9617205cba5e51ca35b6361e9d88e9f262299d7730839f48db3a32d61828f3f54056c1f9113f9b8e180d52a0ddb24c9b3018d53415e705c35e1fa08298547a71b0467c59e48a75cea42a00d0f01b609d7723ec2d39056428f1", ciphertext: "deffffffffffffffffffffffffffffff0846af843d1c80165bbd914582a77702deffffffffffffffffffffffffffffffd7010c1eb016839cbd95cd5eaad61a03deffffffffffffffffffffffffffffffd7010c1eb016839cbd95cd5eaad61a03", tag: "92a14f8e928d42edaab17206102c8123", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 254 - edge case intermediate sums in poly1305", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f10111213019836bb", aad: "ffffffff", msg: "750ed780cbde3c9617205cba5e51ca351ab3232ebce6fcd6e34569079ff4a9de2632d61828f3f54056c1f9113f9b8e187b1d570cd9c860b7c98731c357edd95d03a08298547a71b0467c59e48a75cea45c4f272170e466f0f2be28efb58c32f2", ciphertext: "c2ffffffffffffffffffffffffffffffa4c3923709138ea291658f729ecc9607c2ffffffffffffffffffffffffffffffa14efbcfdb92781b6cc7c8881a3e0000c2ffffffffffffffffffffffffffffffa14efbcfdb92781b6cc7c8881a3e0000", tag: "64d2438f32085bfa32287509bce9ba2b", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 255 - edge case intermediate sums in poly1305", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f10111213019836bb", aad: "ffffffff", msg: "660ed780cbde3c9617205cba5e51ca355ff14d9062edd3279f18abddb87051de2832d61828f3f54056c1f9113f9b8e186a6ef5e04849bc75ea51708aaca4c8550da08298547a71b0467c59e48a75cea44d3c85cde165ba32d16869a64ec523fa", ciphertext: "d1ffffffffffffffffffffffffffffffe181fc89d718a153ed384da8b9486e07ccffffffffffffffffffffffffffffffb03d59234a13a4d94f1189c1e1771108ccffffffffffffffffffffffffffffffb03d59234a13a4d94f1189c1e1771108", tag: "ac951493b2f2bc2e53027f156ce0a14c", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 256 - edge case intermediate sums in poly1305", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f10111213019836bb", aad: "ffffffff", msg: "720ed780cbde3c9617205cba5e51ca3556fce497646fdbf7851de07d441414dd5f32d61828f3f54056c1f9113f9b8e186d1b1ee446cfb4a5ff56bb6a208817597aa08298547a71b0467c59e48a75cea44a496ec9efe3b2e2c46fa246c2e9fcf6", ciphertext: "c5ffffffffffffffffffffffffffffffe88c558ed19aa983f73d0608452c2b04bbffffffffffffffffffffffffffffffb748b2274495ac095a1642216d5bce04bbffffffffffffffffffffffffffffffb748b2274495ac095a1642216d5bce04", tag: "c689d997d2573770fb528b24c894c275", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 257 - edge case intermediate sums in poly1305", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f10111213019836bb", aad: "ffffffff", msg: "7f0ed780cbde3c9617205cba5e51ca359226a9fc2cd7b7102f369be8eab380d95132d61828f3f54056c1f9113f9b8e182142d9bd0e47d0461aae41fd5e68bb5c74a08298547a71b0467c59e48a75cea40610a990a76bd601219758d1bc0950f3", ciphertext: "c8ffffffffffffffffffffffffffffff2c5618e59922c5645d167d9deb8bbf00b5fffffffffffffffffffffffffffffffb11757e0c1dc8eabfeeb8b613bb6201b5fffffffffffffffffffffffffffffffb11757e0c1dc8eabfeeb8b613bb6201", tag: "28416fa072a713e6c37da13fd43fcabf", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 258 - edge case intermediate sums in poly1305", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f10111213019836bb", aad: "ffffffff", msg: "1b0ed780cbde3c9617205cba5e51ca3576a34a8ed0eca184e19e9cbf0689e1dc1b32d61828f3f54056c1f9113f9b8e18ceb8b40c75e7e49d9ca641e6b75c9fea3ea08298547a71b0467c59e48a75cea4e9eac421dccbe2daa79f58ca553d7445", ciphertext: "acffffffffffffffffffffffffffffffc8d3fb976519d3f093be7aca07b1de05ffffffffffffffffffffffffffffffff14eb18cf77bdfc3139e6b8adfa8f46b7ffffffffffffffffffffffffffffffff14eb18cf77bdfc3139e6b8adfa8f46b7", tag: "fa7163a112222cf34bf4a34280fd03c8", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 259 - edge case intermediate sums in poly1305", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f10111213019836bb", aad: "ffffffff", msg: "430ed780cbde3c9617205cba5e51ca35899db7a761de4105d249e53dc29d54de1132d61828f3f54056c1f9113f9b8e183528b78e77af595ac787df1f2e8dd55834a08298547a71b0467c59e48a75cea4127ac7a3de835f1dfcbec633ccec3ef7", ciphertext: "f4ffffffffffffffffffffffffffffff37ed06bed42b3371a0690348c3a56b07f5ffffffffffffffffffffffffffffffef7b1b4d75f541f662c72654635e0c05f5ffffffffffffffffffffffffffffffef7b1b4d75f541f662c72654635e0c05", tag: "d79da397c4431ee1c2b58810ece4491e", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 260 - edge case intermediate sums in poly1305", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f10111213019836bb", aad: "ffffffff", msg: "6e0ed780cbde3c9617205cba5e51ca35bf4a4776b55d5a843cbb180432356edd3d32d61828f3f54056c1f9113f9b8e18639ba63ca22b2fd9b4b9d8359e16285c18a08298547a71b0467c59e48a75cea444c9d6110b07299e8f80c1197c77c3f3", ciphertext: "d9ffffffffffffffffffffffffffffff013af66f00a828f04e9bfe71330d5104d9ffffffffffffffffffffffffffffffb9c80affa071377511f9217ed3c5f101d9ffffffffffffffffffffffffffffffb9c80affa071377511f9217ed3c5f101", tag: "a9ce979864be36ee4a2c8b1398a28326", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 261 - edge case intermediate sums in poly1305", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f10111213019836bb", aad: "ffffffff", msg: "1a0ed780cbde3c9617205cba5e51ca356e9e850317ce94dbf35d481f264260d85332d61828f3f54056c1f9113f9b8e18fe86ef8131ce6495df632dd83246635e76a08298547a71b0467c59e48a75cea4d9d49fac98e262d2e45a34f4d02788f1", ciphertext: "adffffffffffffffffffffffffffffffd0ee341aa23be6af817dae6a277a5f01b7ffffffffffffffffffffffffffffff24d5434233947c397a23d4937f95ba03b7ffffffffffffffffffffffffffffff24d5434233947c397a23d4937f95ba03", tag: "d48e13f63365ba66c44270d827d787cf", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 262 - edge case intermediate sums in poly1305", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f10111213019836bb", aad: "ffffffff", msg: "6a0ed780cbde3c9617205cba5e51ca351b4ed3f20484140402257331eaec0bd81432d61828f3f54056c1f9113f9b8e18a41c8f61637288515b385d363e0f9e5831a08298547a71b0467c59e48a75cea4834eff4cca5e8e166001441adc6e75f7", ciphertext: "ddffffffffffffffffffffffffffffffa53e62ebb171667070059544ebd43401f0ffffffffffffffffffffffffffffff7e4f23a2612890fdfe78a47d73dc4705f0ffffffffffffffffffffffffffffff7e4f23a2612890fdfe78a47d73dc4705", tag: "edaccb5e75b74aed654a70adeb3fc883", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 263 - edge case intermediate sums in poly1305", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f10111213019836bb", aad: "ffffffff", msg: "ccf57358a179c8c883031861c610022db8c7092f268753bd3e5593cafe1ead27e27a91d1447e2b76e54b73513f42e319", ciphertext: "7b045b2795580ba16bdcbb2467be37e706b7b836937221c94c7575bfff2692fe06b7b836937221c94c7575bfff2692fe", tag: "6c49f0fd16742ea3fc257e460099469a", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 264 - edge case intermediate sums in poly1305", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f10111213019836bb", aad: "ffffffff", msg: "a8daa1c0d4c4a8d72852d7b8138a33cc17ad57d76b0af392d306c8ed4ab55fc44d10cf2909f38b59081828768be911fa", ciphertext: "1f2b89bfe0e56bbec08d74fdb2240606a9dde6cedeff81e6a1262e984b8d601da9dde6cedeff81e6a1262e984b8d601d", tag: "3e7ae4feb6ee46b0849c8049ac5680a2", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 265 - edge case intermediate sums in poly1305", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f10111213019836bb", aad: "ffffffff", msg: "610ed780cbde3c9617205cba5e51ca35e60d3613f05d8ec15cc42053313284da3232d61828f3f54056c1f9113f9b8e182c9f615e5de52895bf4a2a0943acb55b17a08298547a71b0467c59e48a75cea40bcd1173f4c92ed284733325a1cd5ef4", ciphertext: "d6ffffffffffffffffffffffffffffff587d870a45a8fcb52ee4c626300abb03d6fffffffffffffffffffffffffffffff6cccd9d5fbf30391a0ad3420e7f6c06d6fffffffffffffffffffffffffffffff6cccd9d5fbf30391a0ad3420e7f6c06", tag: "c30d6694ea7011f02164a2035ae67221", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 266 - edge case intermediate sums in poly1305", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f10111213019836bb", aad: "ffffffff", msg: "1d0ed780cbde3c9617205cba5e51ca353b732c2b99fc7e34f71720381e4187d84f32d61828f3f54056c1f9113f9b8e18978f8d771b2b9f12df626a06ac6e9a5e6aa08298547a71b0467c59e48a75cea4b0ddfd5ab2079955e45b732a4e0f71f1", ciphertext: "aaffffffffffffffffffffffffffffff85039d322c090c408537c64d1f79b801abffffffffffffffffffffffffffffff4ddc21b4197187be7a22934de1bd4303abffffffffffffffffffffffffffffff4ddc21b4197187be7a22934de1bd4303", tag: "c75333a577a5b1e78f28df1cca585f1a", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 267 - edge case intermediate sums in poly1305", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f10111213019836bb", aad: "ffffffff", msg: "5a0ed780cbde3c9617205cba5e51ca359c6a27bcd3d6c3a2d621763b2270c9de3932d61828f3f54056c1f9113f9b8e18f0fceb5c94551f68cd4411910155b05e1ca08298547a71b0467c59e48a75cea4d7ae9b713d79192ff67d08bde3345bf1", ciphertext: "edffffffffffffffffffffffffffffff221a96a56623b1d6a401904e2348f607ddffffffffffffffffffffffffffffff2aaf479f960f07c46804e8da4c866903ddffffffffffffffffffffffffffffff2aaf479f960f07c46804e8da4c866903", tag: "79d53d3dd5457757caef7dc3cef74cac", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 268 - edge case intermediate sums in poly1305", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f10111213019836bb", aad: "ffffffff", msg: "320c4dd472095a3de21c2f57e4ca4fcbb1c072a3f1b7cd089e7ba0d8dcf3ebc1eb7dea5d934eb5c3456540431dafa5ff", ciphertext: "85fd65ab462899540ac38c1245647a010fb0c3ba4442bf7cec5b46adddcbd4180fb0c3ba4442bf7cec5b46adddcbd418", tag: "e2714a882d47f25b7373b9e68edc1721", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 269 - edge case intermediate sums in poly1305", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f10111213019836bb", aad: "ffffffff", msg: "690ed780cbde3c9617205cba5e51ca35b7361e9d88e9f262299d7730839f48db1b32d61828f3f54056c1f9113f9b8e186e9a1b20b92f6767e78d3af5dcc8834a3ea08298547a71b0467c59e48a75cea449c86b0d10036120dcb423d93ea968e5", ciphertext: "deffffffffffffffffffffffffffffff0946af843d1c80165bbd914582a77702ffffffffffffffffffffffffffffffffb4c9b7e3bb757fcb42cdc3be911b5a17ffffffffffffffffffffffffffffffffb4c9b7e3bb757fcb42cdc3be911b5a17", tag: "9f8e29a9e65f1e0e9c322b43fc73d0ff", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 270 - edge case intermediate sums in poly1305", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f10111213019836bb", aad: "ffffffff", msg: "690ed780cbde3c9617205cba5e51ca35b9361e9d88e9f262299d7730839f48db5e32d61828f3f54056c1f9113f9b8e18aa9f9098867dc8387581274c8a19515a7ba08298547a71b0467c59e48a75cea48dcde0b52f51ce7f4eb83e606878baf5", ciphertext: "deffffffffffffffffffffffffffffff0746af843d1c80165bbd914582a77702baffffffffffffffffffffffffffffff70cc3c5b8427d094d0c1de07c7ca8807baffffffffffffffffffffffffffffff70cc3c5b8427d094d0c1de07c7ca8807", tag: "80b475733ebb66ccb930bac923e43147", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 271 - edge case intermediate sums in poly1305", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f10111213019836bb", aad: "ffffffff", msg: "a2d1dde0f5b7e33fcdc15cde5ba7f27b668f4ee64a0a8d8b8ddf198afec7c0266c1d803229363cdc340ec04a0e6c16e702ac533cfda5e7535abf06b4b22c26a2498fd4b255bfb82c24b360bfbb82565b", ciphertext: "1520f59fc1962056251eff9bfa09c7b1d8ffffffffffffffffffffffffffffff88d0a9d5fe3a36639d30c6a4ce086700d8ffffffffffffffffffffffffffffff88d0a9d5fe3a36639d30c6a4ce086700", tag: "6818e1b9360bcb4c10bb8d7b2c5679f1", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 272 - edge case intermediate sums in poly1305", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f10111213019836bb", aad: "ffffffff", msg: "470ed780cbde3c9617205cba5e51ca3502c4d86c98dc9f7a9ffe71079d3e5bd84b32d61828f3f54056c1f9113f9b8e18d418d5a1df9e2f7e3072e429598d9f586ea08298547a71b0467c59e48a75cea4f34aa58c76b229390b4bfd05bbec74f7", ciphertext: "f0ffffffffffffffffffffffffffffffbcb469752d29ed0eedde97729c066401afffffffffffffffffffffffffffffff0e4b7962ddc437d295321d62145e4605afffffffffffffffffffffffffffffff0e4b7962ddc437d295321d62145e4605", tag: "612c9d78f50e3203f04ec9f36c2ceb36", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 273 - edge case intermediate sums in poly1305", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f10111213019836bb", aad: "ffffffff", msg: "600ed780cbde3c9617205cba5e51ca35d155d968456cf1cd1a073725c84880da1b32d61828f3f54056c1f9113f9b8e18ed67cc27b816759c429c10bbe85e97e43ea08298547a71b0467c59e48a75cea4ca35bc0a113a73db79a509970a3f7c4b", ciphertext: "d7ffffffffffffffffffffffffffffff6f256871f09983b96827d150c970bf03ffffffffffffffffffffffffffffffff373460e4ba4c6d30e7dce9f0a58d4eb9ffffffffffffffffffffffffffffffff373460e4ba4c6d30e7dce9f0a58d4eb9", tag: "c4ad8c8e3aac88f04c4f33077b9b8f25", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 274 - edge case intermediate sums in poly1305", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f10111213019836bb", aad: "ffffffff", msg: "0b0ed780cbde3c9617205cba5e51ca358702e63aa9e30b4c65792a0f38e09bd9ac7d109949bcb2db03e3e0201e9bf00192e395bd9ceaa0c80f9d1f85932c58bb", ciphertext: "bcffffffffffffffffffffffffffffff397257231c1679381759cc7a39d8a40048b0397e9eb0b864aadde6cedeff81e648b0397e9eb0b864aadde6cedeff81e6", tag: "b744dd541b16fb820525d29042e77b16", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 275 - edge case intermediate sums in poly1305", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f10111213019836bb", aad: "ffffffff", msg: "d5d52278d802c033f52946493353c02755a6d60f5fc86bf68dae79d22b5e6fdc0f1b4ef13d31133d56b09949ea0221e2", ciphertext: "62240a07ec23035a1df6e50c92fdf5edebd66716ea3d1982ff8e9fa72a665005ebd66716ea3d1982ff8e9fa72a665005", tag: "ead346fd87d575c364f6514fcfc6e9a7", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 276 - edge case intermediate sums in poly1305", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f10111213019836bb", aad: "ffffffff", msg: "5d0ed780cbde3c9617205cba5e51ca35bf4be799f66f059222983e90f7fb85dc0b32d61828f3f54056c1f9113f9b8e180aa51edab4ce62c016d0edb45320845b2ea08298547a71b0467c59e48a75cea42df76ef71de264872de9f498b1416ff4", ciphertext: "eaffffffffffffffffffffffffffffff013b5680439a77e650b8d8e5f6c3ba05efffffffffffffffffffffffffffffffd0f6b219b6947a6cb39014ff1ef35d06efffffffffffffffffffffffffffffffd0f6b219b6947a6cb39014ff1ef35d06", tag: "78ad8a897228c8ab026166f7b37760fa", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 277 - edge case intermediate sums in poly1305", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f10111213019836bb", aad: "ffffffff", msg: "4e0ed780cbde3c9617205cba5e51ca3580890ecba455f8e3deab706e1378addc1e32d61828f3f54056c1f9113f9b8e18d7e6b0af86c095b1aa242b7f77bcec5b3ba08298547a71b0467c59e48a75cea4f0b4c0822fec93f6911d325395dd07f4", ciphertext: "f9ffffffffffffffffffffffffffffff3ef9bfd211a08a97ac8b961b12409205faffffffffffffffffffffffffffffff0db51c6c849a8d1d0f64d2343a6f3506faffffffffffffffffffffffffffffff0db51c6c849a8d1d0f64d2343a6f3506", tag: "c0705b8df2122ae0223b7003646e471b", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 278 - edge case intermediate sums in poly1305", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f10111213019836bb", aad: "ffffffff", msg: "710ed780cbde3c9617205cba5e51ca35204cf2b6d791471c5cdaa05e680cdcdf4932d61828f3f54056c1f9113f9b8e1877b5dd4ac6a7af831863d9053d03815f6ca08298547a71b0467c59e48a75cea450e7ad676f8ba9c4235ac029df626af0", ciphertext: "c6ffffffffffffffffffffffffffffff9e3c43af626435682efa462b6934e306adffffffffffffffffffffffffffffffade67189c4fdb72fbd23204e70d05802adffffffffffffffffffffffffffffffade67189c4fdb72fbd23204e70d05802", tag: "d477566543e826f3c7f31248fd4452f6", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 279 - edge case intermediate sums in poly1305", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f10111213019836bb", aad: "ffffffff", msg: "1c0ed780cbde3c9617205cba5e51ca35d6f983783b155893ae0ba420d8a3f7da1b32d61828f3f54056c1f9113f9b8e18ec6ad8112d9330a25326f566ba64b72b3ea08298547a71b0467c59e48a75cea4cb38a83c84bf36e5681fec4a58055c84", ciphertext: "abffffffffffffffffffffffffffffff688932618ee02ae7dc2b4255d99bc803ffffffffffffffffffffffffffffffff363974d22fc9280ef6660c2df7b76e76ffffffffffffffffffffffffffffffff363974d22fc9280ef6660c2df7b76e76", tag: "a6a84a66e3623f00506a154ba9028cfe", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 280 - edge case intermediate sums in poly1305", xchacha: true, key: "808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f", nonce: "000102030405060708090a0b0c0d0e0f10111213019836bb", aad: "ffffffff", msg: "9e6c400d5714f21a9b56fcd3293916c87d8f4ee64a0a8d8b8ddf198afec7c0265d79ed5deee2b7163f72ea0ac66189e319ac533cfda5e7535abf06b4b22c26a278ebb9dd926b33e62fcf4aff738fc95f", ciphertext: "299d68726335317373895f9688972302c3ffffffffffffffffffffffffffffffb9b4c4ba39eebda9964cece40605f804c3ffffffffffffffffffffffffffffffb9b4c4ba39eebda9964cece40605f804", tag: "85b241bbbd0556368ec3fb749e7601ea", valid: true},
+	{name: "Wycheproof XChaCha20Poly1305 281 - invalid nonce size", xchacha: true, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "0001020304050607", aad: "", msg: "", ciphertext: "", tag: "", valid: false},
+	{name: "Wycheproof XChaCha20Poly1305 282 - invalid nonce size", xchacha: true, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b", aad: "", msg: "", ciphertext: "", tag: "", valid: false},
+	{name: "Wycheproof XChaCha20Poly1305 283 - invalid nonce size", xchacha: true, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b0c0d0e0f10111213", aad: "", msg: "", ciphertext: "", tag: "", valid: false},
+	{name: "Wycheproof XChaCha20Poly1305 284 - invalid nonce size", xchacha: true, key: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", nonce: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", aad: "", msg: "", ciphertext: "", tag: "", valid: false},
+}
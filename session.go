@@ -0,0 +1,160 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package chacha20
+
+import "errors"
+
+var errSessionExpired = errors.New("session key exceeded its usage limits - rekey required")
+
+// SessionLimits specifies how many messages and bytes may be processed with
+// one session key. Once one of the rekey limits is reached NeedRekey returns true.
+// Once one of the reject limits is reached the session refuses to process further
+// messages. A zero value disables the corresponding limit.
+type SessionLimits struct {
+	RekeyAfterMessages  uint64
+	RekeyAfterBytes     uint64
+	RejectAfterMessages uint64
+	RejectAfterBytes    uint64
+}
+
+// DefaultSessionLimits are the limits used by sessions if no limits are specified.
+// The message limits ensure that the 64 bit message counter never wraps around.
+var DefaultSessionLimits = SessionLimits{
+	RekeyAfterMessages:  1 << 60,
+	RejectAfterMessages: 1<<64 - 1<<13 - 1,
+}
+
+// session holds the key and the state shared by the send and the receive
+// side of a session.
+type session struct {
+	c       *aead
+	nonce   [NonceSize]byte
+	counter uint64
+	bytes   uint64
+	limits  SessionLimits
+}
+
+func (s *session) init(key *[32]byte, limits *SessionLimits) {
+	s.c = NewChaCha20Poly1305(key).(*aead)
+	if limits == nil {
+		limits = &DefaultSessionLimits
+	}
+	s.limits = *limits
+	s.counter, s.bytes = 0, 0
+}
+
+// setNonce maps the message counter into the nonce. The nonce consists of
+// 4 zero bytes followed by the little endian 64 bit message counter.
+func (s *session) setNonce() {
+	ctr := s.counter
+	for i := 4; i < NonceSize; i++ {
+		s.nonce[i] = byte(ctr)
+		ctr >>= 8
+	}
+}
+
+func (s *session) expired(n int) bool {
+	l := &s.limits
+	if l.RejectAfterMessages > 0 && s.counter >= l.RejectAfterMessages {
+		return true
+	}
+	return l.RejectAfterBytes > 0 && s.bytes+uint64(n) > l.RejectAfterBytes
+}
+
+func (s *session) needRekey() bool {
+	l := &s.limits
+	if l.RekeyAfterMessages > 0 && s.counter >= l.RekeyAfterMessages {
+		return true
+	}
+	return l.RekeyAfterBytes > 0 && s.bytes >= l.RekeyAfterBytes
+}
+
+// SendSession seals a sequence of messages with one key. The nonce of
+// every message is derived from a 64 bit message counter, so callers
+// don't have to manage nonces. A SendSession must be used together with
+// a RecvSession which opens the messages in the same order.
+type SendSession struct {
+	s session
+}
+
+// NewSendSession returns a new SendSession using the given key. If limits is
+// nil the DefaultSessionLimits are used.
+func NewSendSession(key *[32]byte, limits *SessionLimits) *SendSession {
+	s := new(SendSession)
+	s.s.init(key, limits)
+	return s
+}
+
+// Seal encrypts and authenticates the plaintext and the additional data,
+// appends the result to dst and returns the updated slice. Seal returns an
+// error if the session key exceeded its reject limits.
+func (s *SendSession) Seal(dst, plaintext, additionalData []byte) ([]byte, error) {
+	if s.s.expired(len(plaintext)) {
+		return nil, errSessionExpired
+	}
+	s.s.setNonce()
+	s.s.counter++
+	s.s.bytes += uint64(len(plaintext))
+	return s.s.c.Seal(dst, s.s.nonce[:], plaintext, additionalData), nil
+}
+
+// Counter returns the counter of the next sealed message.
+func (s *SendSession) Counter() uint64 { return s.s.counter }
+
+// NeedRekey returns true if the session key reached one of its rekey limits.
+func (s *SendSession) NeedRekey() bool { return s.s.needRekey() }
+
+// Rekey replaces the session key and resets the message counter.
+func (s *SendSession) Rekey(key *[32]byte) {
+	s.s.init(key, &s.s.limits)
+	countRekey()
+}
+
+// RecvSession opens a sequence of messages sealed by a SendSession.
+type RecvSession struct {
+	s session
+}
+
+// NewRecvSession returns a new RecvSession using the given key. If limits is
+// nil the DefaultSessionLimits are used.
+func NewRecvSession(key *[32]byte, limits *SessionLimits) *RecvSession {
+	s := new(RecvSession)
+	s.s.init(key, limits)
+	return s
+}
+
+// Open decrypts and authenticates the next message of the session, appends
+// the plaintext to dst and returns the updated slice. The message counter is
+// only incremented if the message is authentic. Open returns an error if the
+// session key exceeded its reject limits.
+func (s *RecvSession) Open(dst, ciphertext, additionalData []byte) ([]byte, error) {
+	n := len(ciphertext) - s.s.c.Overhead()
+	if n < 0 {
+		return nil, errAuthFailed
+	}
+	if s.s.expired(n) {
+		return nil, errSessionExpired
+	}
+	s.s.setNonce()
+	plaintext, err := s.s.c.Open(dst, s.s.nonce[:], ciphertext, additionalData)
+	if err != nil {
+		return nil, err
+	}
+	s.s.counter++
+	s.s.bytes += uint64(n)
+	return plaintext, nil
+}
+
+// Counter returns the counter of the next expected message.
+func (s *RecvSession) Counter() uint64 { return s.s.counter }
+
+// NeedRekey returns true if the session key reached one of its rekey limits.
+func (s *RecvSession) NeedRekey() bool { return s.s.needRekey() }
+
+// Rekey replaces the session key and resets the message counter.
+func (s *RecvSession) Rekey(key *[32]byte) {
+	s.s.init(key, &s.s.limits)
+	countRekey()
+}
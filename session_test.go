@@ -0,0 +1,73 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package chacha20
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestSession(t *testing.T) {
+	var key [32]byte
+	send, recv := NewSendSession(&key, nil), NewRecvSession(&key, nil)
+
+	var msgs [][]byte
+	for i := 0; i < 4; i++ {
+		msg, err := send.Seal(nil, []byte{byte(i), 1, 2, 3}, nil)
+		if err != nil {
+			t.Fatalf("Seal failed: %s", err)
+		}
+		msgs = append(msgs, msg)
+	}
+	if c := send.Counter(); c != 4 {
+		t.Fatalf("Expected counter %d but got %d", 4, c)
+	}
+
+	if _, err := recv.Open(nil, msgs[1], nil); err == nil {
+		t.Fatal("RecvSession accepted message out of order")
+	}
+	for i, msg := range msgs {
+		plaintext, err := recv.Open(nil, msg, nil)
+		if err != nil {
+			t.Fatalf("Open failed: %s", err)
+		}
+		if !bytes.Equal(plaintext, []byte{byte(i), 1, 2, 3}) {
+			t.Fatalf("Message %d: unexpected plaintext %x", i, plaintext)
+		}
+	}
+	if _, err := recv.Open(nil, msgs[3], nil); err == nil {
+		t.Fatal("RecvSession accepted replayed message")
+	}
+}
+
+func TestSessionLimits(t *testing.T) {
+	var key [32]byte
+	limits := SessionLimits{
+		RekeyAfterMessages:  2,
+		RejectAfterMessages: 3,
+		RejectAfterBytes:    20,
+	}
+	send := NewSendSession(&key, &limits)
+
+	for i := 0; i < 3; i++ {
+		if send.NeedRekey() != (i >= 2) {
+			t.Fatalf("Message %d: NeedRekey returned %v", i, send.NeedRekey())
+		}
+		if _, err := send.Seal(nil, make([]byte, 4), nil); err != nil {
+			t.Fatalf("Message %d: Seal failed: %s", i, err)
+		}
+	}
+	if _, err := send.Seal(nil, nil, nil); err == nil {
+		t.Fatal("Seal exceeded the message limit")
+	}
+
+	send.Rekey(&key)
+	if send.NeedRekey() || send.Counter() != 0 {
+		t.Fatal("Rekey did not reset the session")
+	}
+	if _, err := send.Seal(nil, make([]byte, 21), nil); err == nil {
+		t.Fatal("Seal exceeded the byte limit")
+	}
+}
@@ -0,0 +1,216 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package chacha20
+
+import (
+	"crypto/rand"
+	"errors"
+	"io"
+
+	"github.com/aead/chacha20/chacha"
+	"github.com/aead/chacha20/internal/argerr"
+	"github.com/aead/poly1305"
+)
+
+const (
+	// SSHKeySize is the size of a chacha20-poly1305@openssh.com key in bytes.
+	SSHKeySize = 2 * KeySize
+
+	// MaxSSHPacketSize is the max. packet length - the size of the padding
+	// length, the payload and the padding - accepted by an SSHPacketDecoder.
+	// It matches the limit of OpenSSH.
+	MaxSSHPacketSize = 256 * 1024
+
+	sshBlockSize     = 8
+	sshMinPadding    = 4
+	sshMinPacketSize = sshBlockSize
+)
+
+var (
+	errSSHPacketLength = errors.New("invalid SSH packet length")
+	errSSHPadding      = errors.New("invalid SSH padding length")
+	errSSHPacketSize   = errors.New("SSH payload exceeds the max. packet size")
+)
+
+// SSHCipher implements the chacha20-poly1305@openssh.com cipher specified
+// in OpenSSH's PROTOCOL.chacha20poly1305. The 64 byte key consists of the
+// key of the payload followed by the key of the packet length. The nonce
+// of a packet is its sequence number and the packet length is encrypted
+// separately, so it can be decrypted before the packet has been read.
+type SSHCipher struct {
+	mainKey, lengthKey [32]byte
+}
+
+// NewSSHCipher returns a SSHCipher using the given key.
+func NewSSHCipher(key *[SSHKeySize]byte) *SSHCipher {
+	c := new(SSHCipher)
+	copy(c.mainKey[:], key[:KeySize])
+	copy(c.lengthKey[:], key[KeySize:])
+	return c
+}
+
+// Seal encrypts and authenticates the packet with the sequence number and
+// appends the result to dst. The packet starts with the 4 byte big endian
+// packet length. The result is TagSize bytes longer than the packet.
+func (c *SSHCipher) Seal(dst []byte, seqNum uint32, packet []byte) []byte {
+	if len(packet) < 4 {
+		panic(argerr.Error("chacha20: SSH packet is shorter than its length field"))
+	}
+	nonce := sshNonce(seqNum)
+	ret, out := sliceForAppend(dst, len(packet)+TagSize)
+	chacha.XORKeyStream64(out[:4], packet[:4], &nonce, &c.lengthKey, 0, 20)
+	chacha.XORKeyStream64(out[4:len(packet)], packet[4:], &nonce, &c.mainKey, 1, 20)
+
+	var tag [TagSize]byte
+	polyKey := c.polyKey(&nonce)
+	poly1305.Sum(&tag, out[:len(packet)], polyKey)
+	copy(out[len(packet):], tag[:])
+	wipe(polyKey[:])
+	return ret
+}
+
+// DecryptLength decrypts the packet length from the first 4 bytes of a
+// sealed packet. The length is not authenticated - it must only be used to
+// read the rest of the packet.
+func (c *SSHCipher) DecryptLength(seqNum uint32, sealed []byte) uint32 {
+	var length [4]byte
+	nonce := sshNonce(seqNum)
+	chacha.XORKeyStream64(length[:], sealed[:4], &nonce, &c.lengthKey, 0, 20)
+	return uint32(length[0])<<24 | uint32(length[1])<<16 | uint32(length[2])<<8 | uint32(length[3])
+}
+
+// Open authenticates and decrypts the sealed packet with the sequence number
+// and appends the packet - including its length field - to dst.
+func (c *SSHCipher) Open(dst []byte, seqNum uint32, sealed []byte) ([]byte, error) {
+	if len(sealed) < 4+TagSize {
+		return nil, errAuthFailed
+	}
+	n := len(sealed) - TagSize
+	nonce := sshNonce(seqNum)
+
+	var sum [TagSize]byte
+	polyKey := c.polyKey(&nonce)
+	poly1305.Sum(&sum, sealed[:n], polyKey)
+	wipe(polyKey[:])
+	if !checkTag(&sum, sealed[n:], TagSize) {
+		return nil, errAuthFailed
+	}
+
+	ret, out := sliceForAppend(dst, n)
+	chacha.XORKeyStream64(out[:4], sealed[:4], &nonce, &c.lengthKey, 0, 20)
+	chacha.XORKeyStream64(out[4:], sealed[4:n], &nonce, &c.mainKey, 1, 20)
+	return ret, nil
+}
+
+func (c *SSHCipher) polyKey(nonce *[8]byte) *[32]byte {
+	var polyKey [32]byte
+	chacha.XORKeyStream64(polyKey[:], polyKey[:], nonce, &c.mainKey, 0, 20)
+	return &polyKey
+}
+
+func sshNonce(seqNum uint32) [8]byte {
+	return [8]byte{4: byte(seqNum >> 24), 5: byte(seqNum >> 16), 6: byte(seqNum >> 8), 7: byte(seqNum)}
+}
+
+// SSHPacketEncoder encodes payloads as SSH binary packets (RFC 4253) sealed
+// with the chacha20-poly1305@openssh.com cipher. It adds the random padding
+// and counts the sequence numbers.
+type SSHPacketEncoder struct {
+	c      *SSHCipher
+	seqNum uint32
+
+	// Rand is the source of the padding. If nil crypto/rand.Reader is used.
+	Rand io.Reader
+}
+
+// NewSSHPacketEncoder returns a new SSHPacketEncoder using the key. seqNum is
+// the sequence number of the first packet - SSH does not reset the sequence
+// number when new keys are taken into use.
+func NewSSHPacketEncoder(key *[SSHKeySize]byte, seqNum uint32) *SSHPacketEncoder {
+	return &SSHPacketEncoder{c: NewSSHCipher(key), seqNum: seqNum}
+}
+
+// Encode seals the payload as the next SSH packet and appends it to dst.
+func (e *SSHPacketEncoder) Encode(dst, payload []byte) ([]byte, error) {
+	padding := sshBlockSize - (1+len(payload))%sshBlockSize
+	if padding < sshMinPadding {
+		padding += sshBlockSize
+	}
+	length := 1 + len(payload) + padding
+	if len(payload) > MaxSSHPacketSize || length > MaxSSHPacketSize {
+		return nil, errSSHPacketSize
+	}
+
+	packet := make([]byte, 4+length)
+	packet[0], packet[1], packet[2], packet[3] = byte(length>>24), byte(length>>16), byte(length>>8), byte(length)
+	packet[4] = byte(padding)
+	copy(packet[5:], payload)
+	random := e.Rand
+	if random == nil {
+		random = rand.Reader
+	}
+	if _, err := io.ReadFull(random, packet[5+len(payload):]); err != nil {
+		return nil, err
+	}
+
+	dst = e.c.Seal(dst, e.seqNum, packet)
+	e.seqNum++
+	wipe(packet)
+	return dst, nil
+}
+
+// SSHPacketDecoder reads SSH binary packets (RFC 4253) sealed with the
+// chacha20-poly1305@openssh.com cipher from the underlying io.Reader and
+// returns their payloads.
+type SSHPacketDecoder struct {
+	r      io.Reader
+	c      *SSHCipher
+	seqNum uint32
+	buf    []byte
+}
+
+// NewSSHPacketDecoder returns a new SSHPacketDecoder reading from r using the
+// key. seqNum is the sequence number of the first packet.
+func NewSSHPacketDecoder(r io.Reader, key *[SSHKeySize]byte, seqNum uint32) *SSHPacketDecoder {
+	return &SSHPacketDecoder{r: r, c: NewSSHCipher(key), seqNum: seqNum}
+}
+
+// Decode reads the next packet, authenticates and decrypts it and returns
+// its payload. The payload is only valid until the next call of Decode.
+// Decode returns io.EOF if there is no next packet.
+func (d *SSHPacketDecoder) Decode() ([]byte, error) {
+	var length [4]byte
+	if _, err := io.ReadFull(d.r, length[:]); err != nil {
+		return nil, err
+	}
+	n := d.c.DecryptLength(d.seqNum, length[:])
+	if n < sshMinPacketSize || n > MaxSSHPacketSize || n%sshBlockSize != 0 {
+		return nil, errSSHPacketLength
+	}
+
+	size := 4 + int(n) + TagSize
+	if cap(d.buf) < 2*size {
+		d.buf = make([]byte, 2*size)
+	}
+	sealed := d.buf[:size]
+	copy(sealed, length[:])
+	if _, err := io.ReadFull(d.r, sealed[4:]); err != nil {
+		if err == io.EOF {
+			return nil, io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	packet, err := d.c.Open(d.buf[size:size], d.seqNum, sealed)
+	if err != nil {
+		return nil, err
+	}
+	d.seqNum++
+
+	padding := int(packet[4])
+	if padding < sshMinPadding || 1+padding > int(n) {
+		return nil, errSSHPadding
+	}
+	return packet[5 : 4+int(n)-padding], nil
+}
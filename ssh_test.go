@@ -0,0 +1,140 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package chacha20
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/aead/chacha20/chacha/reference"
+	"github.com/aead/poly1305"
+)
+
+// referenceSSHSeal seals the packet as specified by PROTOCOL.chacha20poly1305
+// using the reference implementation of ChaCha20.
+func referenceSSHSeal(key *[SSHKeySize]byte, seqNum uint32, packet []byte) []byte {
+	var k1, k2, polyKey [32]byte
+	var nonce [8]byte
+	var tag [TagSize]byte
+	copy(k2[:], key[:32])
+	copy(k1[:], key[32:])
+	nonce[4], nonce[5], nonce[6], nonce[7] = byte(seqNum>>24), byte(seqNum>>16), byte(seqNum>>8), byte(seqNum)
+
+	out := make([]byte, len(packet))
+	reference.XORKeyStream64(out[:4], packet[:4], &nonce, &k1, 0, 20)
+	reference.XORKeyStream64(polyKey[:], polyKey[:], &nonce, &k2, 0, 20)
+	reference.XORKeyStream64(out[4:], packet[4:], &nonce, &k2, 1, 20)
+	poly1305.Sum(&tag, out, &polyKey)
+	return append(out, tag[:]...)
+}
+
+func TestSSHCipher(t *testing.T) {
+	var key [SSHKeySize]byte
+	for i := range key {
+		key[i] = byte(i)
+	}
+	c := NewSSHCipher(&key)
+	for _, seqNum := range []uint32{0, 1, 1<<32 - 1} {
+		for _, size := range []int{4, 16, 100, 1000} {
+			packet := make([]byte, size)
+			for i := range packet {
+				packet[i] = byte(i)
+			}
+			sealed := c.Seal(nil, seqNum, packet)
+			if !bytes.Equal(sealed, referenceSSHSeal(&key, seqNum, packet)) {
+				t.Fatalf("Sequence number %d: Size %d: Seal differs from the reference", seqNum, size)
+			}
+			if n := c.DecryptLength(seqNum, sealed); n != 0x00010203 {
+				t.Fatalf("Sequence number %d: Size %d: DecryptLength returns %x", seqNum, size, n)
+			}
+			opened, err := c.Open(nil, seqNum, sealed)
+			if err != nil || !bytes.Equal(opened, packet) {
+				t.Fatalf("Sequence number %d: Size %d: Open failed: %v", seqNum, size, err)
+			}
+			for i := range sealed {
+				sealed[i] ^= 1
+				if _, err = c.Open(nil, seqNum, sealed); err == nil {
+					t.Fatalf("Sequence number %d: Size %d: modified byte %d was not detected", seqNum, size, i)
+				}
+				sealed[i] ^= 1
+			}
+			if _, err = c.Open(nil, seqNum+1, sealed); err == nil {
+				t.Fatalf("Sequence number %d: Size %d: Open accepted a wrong sequence number", seqNum, size)
+			}
+		}
+	}
+}
+
+func TestSSHPacketCodec(t *testing.T) {
+	var key [SSHKeySize]byte
+	for i := range key {
+		key[i] = byte(i * 3)
+	}
+	payloads := [][]byte{nil, []byte("a"), []byte("ssh-userauth"), make([]byte, 2), make([]byte, 3), make([]byte, 1000), make([]byte, MaxSSHPacketSize-8)}
+
+	var stream []byte
+	e := NewSSHPacketEncoder(&key, 1<<32-2) // The sequence number wraps.
+	for _, p := range payloads {
+		n := len(stream)
+		var err error
+		if stream, err = e.Encode(stream, p); err != nil {
+			t.Fatalf("Payload size %d: Encode failed: %s", len(p), err)
+		}
+		packet, _ := NewSSHCipher(&key).Open(nil, e.seqNum-1, stream[n:])
+		length := len(packet) - 4
+		if length%sshBlockSize != 0 || packet[4] < sshMinPadding || length != 1+len(p)+int(packet[4]) {
+			t.Fatalf("Payload size %d: invalid packet length %d or padding %d", len(p), length, packet[4])
+		}
+	}
+	if _, err := e.Encode(nil, make([]byte, MaxSSHPacketSize)); err != errSSHPacketSize {
+		t.Fatalf("Encode accepted a too large payload: %v", err)
+	}
+
+	d := NewSSHPacketDecoder(bytes.NewReader(stream), &key, 1<<32-2)
+	for _, p := range payloads {
+		payload, err := d.Decode()
+		if err != nil {
+			t.Fatalf("Payload size %d: Decode failed: %s", len(p), err)
+		}
+		if !bytes.Equal(payload, p) {
+			t.Fatalf("Payload size %d: decoded payload differs", len(p))
+		}
+	}
+	if _, err := d.Decode(); err != io.EOF {
+		t.Fatalf("Decode returns unexpected error at the end of the stream: %v", err)
+	}
+
+	d = NewSSHPacketDecoder(bytes.NewReader(stream[:len(stream)-1]), &key, 1<<32-2)
+	for range payloads[:len(payloads)-1] {
+		d.Decode()
+	}
+	if _, err := d.Decode(); err != io.ErrUnexpectedEOF {
+		t.Fatalf("Decode returns unexpected error for a truncated packet: %v", err)
+	}
+	if _, err := NewSSHPacketDecoder(bytes.NewReader(stream), &key, 0).Decode(); err == nil {
+		t.Fatal("Decode accepted a wrong sequence number")
+	}
+}
+
+func TestSSHPacketDecoderInvalid(t *testing.T) {
+	var key [SSHKeySize]byte
+	c := NewSSHCipher(&key)
+	for _, packet := range [][]byte{
+		{0, 0, 0, 4, 3, 0, 0, 0},                // too short
+		{0, 0, 0, 9, 4, 0, 0, 0, 0, 0, 0, 0, 0}, // not a multiple of the block size
+		{0, 4, 0, 8, 4},                         // too large
+		{0, 0, 0, 8, 3, 0, 0, 0, 0, 0, 0, 0},    // padding too short
+		{0, 0, 0, 8, 8, 0, 0, 0, 0, 0, 0, 0},    // padding too long
+	} {
+		if len(packet) < 12 {
+			packet = append(packet, make([]byte, 12-len(packet))...)
+		}
+		sealed := c.Seal(nil, 0, packet)
+		if _, err := NewSSHPacketDecoder(bytes.NewReader(sealed), &key, 0).Decode(); err != errSSHPacketLength && err != errSSHPadding {
+			t.Fatalf("Decode accepted invalid packet %x: %v", packet, err)
+		}
+	}
+}
@@ -0,0 +1,250 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package chacha20
+
+import (
+	"errors"
+	"io"
+)
+
+// StreamNonceSize is the size of the nonce prefix of the chunked
+// ChaCha20Poly1305 stream construction in bytes.
+const StreamNonceSize = NonceSize - 5
+
+var (
+	errInvalidChunkSize = errors.New("chunk size must be greater than 0")
+	errStreamTooLong    = errors.New("stream exceeds the max. number of chunks")
+	errWriteAfterClose  = errors.New("write to closed stream writer")
+)
+
+// The stream construction splits the plaintext into chunks of a fixed size
+// and seals every chunk with the ChaCha20Poly1305 AEAD. The 12 byte nonce of
+// every chunk is the nonce prefix followed by the big endian 32 bit chunk
+// counter and a byte which is 1 for the final chunk and 0 otherwise.
+// So reordering, dropping or truncating chunks is detected by the reader.
+// The nonce prefix identifies the stream: a chunk spliced in from another
+// stream - sealed with a different nonce prefix or key - fails to open
+// as well. Since the chunk index, the stream identifier and the final-chunk
+// flag are part of the nonce, they are authenticated without any additional
+// data and without any effort of the caller.
+//
+// Since every chunk carries its own tag, every chunk is an authentication
+// checkpoint: a reader verifies and releases the plaintext chunk by chunk
+// and never has to buffer more than one chunk waiting for a final tag.
+// Only the completeness of the stream is unknown before the final chunk
+// has been read - see StreamReader.Authenticated.
+
+// StreamWriter encrypts and authenticates data written to it and writes
+// the sealed chunks to the underlying io.Writer. The stream must be closed
+// by calling Close to write the final chunk.
+type StreamWriter struct {
+	w     io.Writer
+	c     *aead
+	nonce [NonceSize]byte
+
+	buf     []byte
+	n       int
+	counter uint64
+	closed  bool
+	err     error
+}
+
+// NewStreamWriter returns a new StreamWriter which splits the written data into
+// chunks of chunkSize bytes, seals them with the ChaCha20Poly1305 AEAD and writes
+// them to w. The nonce must be unique for one key for all time.
+func NewStreamWriter(w io.Writer, key *[32]byte, nonce *[StreamNonceSize]byte, chunkSize int) (*StreamWriter, error) {
+	if chunkSize <= 0 {
+		return nil, errInvalidChunkSize
+	}
+	s := &StreamWriter{
+		w:   w,
+		c:   NewChaCha20Poly1305(key).(*aead),
+		buf: make([]byte, chunkSize+TagSize),
+	}
+	copy(s.nonce[:], nonce[:])
+	return s, nil
+}
+
+// Write encrypts p and writes all complete chunks to the underlying io.Writer.
+// A full chunk is only sealed once more data is written or the StreamWriter is
+// closed, since the final chunk must be marked as such.
+func (s *StreamWriter) Write(p []byte) (n int, err error) {
+	if s.err != nil {
+		return 0, s.err
+	}
+	if s.closed {
+		return 0, errWriteAfterClose
+	}
+
+	chunkSize := len(s.buf) - TagSize
+	for len(p) > 0 {
+		if s.n == chunkSize {
+			if err = s.sealChunk(false); err != nil {
+				return n, err
+			}
+		}
+		m := copy(s.buf[s.n:chunkSize], p)
+		s.n += m
+		n += m
+		p = p[m:]
+	}
+	return n, nil
+}
+
+// Close seals the remaining data as the final chunk and writes it to the
+// underlying io.Writer. Close does not close the underlying io.Writer.
+func (s *StreamWriter) Close() error {
+	if s.err != nil {
+		return s.err
+	}
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	return s.sealChunk(true)
+}
+
+func (s *StreamWriter) sealChunk(last bool) error {
+	if s.counter > maxStreamChunks {
+		s.err = errStreamTooLong
+		return s.err
+	}
+	setStreamNonce(&s.nonce, s.counter, last)
+	s.counter++
+
+	chunk := s.c.Seal(s.buf[:0], s.nonce[:], s.buf[:s.n], nil)
+	s.n = 0
+	if _, err := s.w.Write(chunk); err != nil {
+		s.err = err
+		return err
+	}
+	return nil
+}
+
+// lastTag returns the tag of the last sealed chunk. It is only valid if
+// a chunk has been sealed and the StreamWriter is not closed - so the
+// last chunk has been a full one.
+func (s *StreamWriter) lastTag() []byte { return s.buf[len(s.buf)-TagSize:] }
+
+// StreamReader reads chunks written by a StreamWriter from the underlying
+// io.Reader and decrypts them. Plaintext is only returned after the tag of
+// its chunk has been verified. At most one chunk is buffered, so the chunk
+// size limits the memory used by a StreamReader.
+type StreamReader struct {
+	r     io.Reader
+	c     *aead
+	nonce [NonceSize]byte
+
+	buf           []byte
+	plaintext     []byte
+	next          byte
+	hasNext       bool
+	counter       uint64
+	authenticated int64
+	done          bool
+	err           error
+}
+
+// NewStreamReader returns a new StreamReader which reads and decrypts the chunks
+// written by a StreamWriter with the same key, nonce and chunkSize from r.
+func NewStreamReader(r io.Reader, key *[32]byte, nonce *[StreamNonceSize]byte, chunkSize int) (*StreamReader, error) {
+	if chunkSize <= 0 {
+		return nil, errInvalidChunkSize
+	}
+	s := &StreamReader{
+		r:   r,
+		c:   NewChaCha20Poly1305(key).(*aead),
+		buf: make([]byte, chunkSize+TagSize+1),
+	}
+	copy(s.nonce[:], nonce[:])
+	return s, nil
+}
+
+// Read reads authenticated plaintext into p. It returns io.EOF after the final
+// chunk has been read and verified and io.ErrUnexpectedEOF if the stream was
+// truncated. If a chunk cannot be authenticated Read returns an error and no
+// plaintext of that chunk.
+func (s *StreamReader) Read(p []byte) (n int, err error) {
+	for len(s.plaintext) == 0 {
+		if s.err != nil {
+			return 0, s.err
+		}
+		if s.done {
+			return 0, io.EOF
+		}
+		if s.err = s.openChunk(); s.err != nil {
+			return 0, s.err
+		}
+	}
+	n = copy(p, s.plaintext)
+	s.plaintext = s.plaintext[n:]
+	return n, nil
+}
+
+// Authenticated returns the number of plaintext bytes which have been
+// verified so far - including the plaintext buffered but not yet returned
+// by Read. It grows by one chunk at a time. All plaintext up to this offset
+// is an authentic prefix of the stream and can be flushed or processed
+// before the stream ends - unless the application must not act on
+// incomplete streams.
+func (s *StreamReader) Authenticated() int64 { return s.authenticated }
+
+func (s *StreamReader) openChunk() error {
+	off := 0
+	if s.hasNext {
+		s.buf[0] = s.next
+		off = 1
+	}
+	n, err := io.ReadFull(s.r, s.buf[off:])
+	n += off
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return err
+	}
+
+	last := n < len(s.buf)
+	chunk := s.buf[:n]
+	if !last {
+		chunk = s.buf[:n-1]
+		s.next, s.hasNext = s.buf[n-1], true
+	}
+	if len(chunk) < TagSize {
+		return io.ErrUnexpectedEOF
+	}
+	if s.counter > maxStreamChunks {
+		return errStreamTooLong
+	}
+
+	setStreamNonce(&s.nonce, s.counter, last)
+	plaintext, err := s.c.Open(chunk[:0], s.nonce[:], chunk, nil)
+	if err != nil {
+		if last {
+			// Check whether the stream was cut at a chunk boundary.
+			setStreamNonce(&s.nonce, s.counter, false)
+			if n := len(chunk) - TagSize; s.c.Verify(s.nonce[:], chunk[:n], nil, chunk[n:]) {
+				return io.ErrUnexpectedEOF
+			}
+		}
+		return err
+	}
+	s.counter++
+	s.authenticated += int64(len(plaintext))
+	s.plaintext = plaintext
+	s.done = last
+	return nil
+}
+
+const maxStreamChunks = 1<<32 - 1
+
+func setStreamNonce(nonce *[NonceSize]byte, counter uint64, last bool) {
+	nonce[StreamNonceSize+0] = byte(counter >> 24)
+	nonce[StreamNonceSize+1] = byte(counter >> 16)
+	nonce[StreamNonceSize+2] = byte(counter >> 8)
+	nonce[StreamNonceSize+3] = byte(counter)
+	if last {
+		nonce[NonceSize-1] = 1
+	} else {
+		nonce[NonceSize-1] = 0
+	}
+}
@@ -0,0 +1,172 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package chacha20
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"testing"
+)
+
+func sealStream(t *testing.T, key *[32]byte, nonce *[StreamNonceSize]byte, chunkSize int, plaintext []byte) []byte {
+	var buf bytes.Buffer
+	w, err := NewStreamWriter(&buf, key, nonce, chunkSize)
+	if err != nil {
+		t.Fatalf("Failed to create StreamWriter: %s", err)
+	}
+	for p := plaintext; len(p) > 0; {
+		n := 7
+		if n > len(p) {
+			n = len(p)
+		}
+		if _, err = w.Write(p[:n]); err != nil {
+			t.Fatalf("Write failed: %s", err)
+		}
+		p = p[n:]
+	}
+	if err = w.Close(); err != nil {
+		t.Fatalf("Close failed: %s", err)
+	}
+	return buf.Bytes()
+}
+
+func TestStream(t *testing.T) {
+	var (
+		key   [32]byte
+		nonce [StreamNonceSize]byte
+	)
+	for i := range key {
+		key[i] = byte(i)
+	}
+
+	for _, size := range []int{0, 1, 63, 64, 65, 128, 1000} {
+		plaintext := make([]byte, size)
+		for i := range plaintext {
+			plaintext[i] = byte(i * 3)
+		}
+		ciphertext := sealStream(t, &key, &nonce, 64, plaintext)
+
+		chunks := (size + 63) / 64
+		if chunks == 0 {
+			chunks = 1
+		}
+		if len(ciphertext) != size+chunks*TagSize {
+			t.Fatalf("Size %d: unexpected ciphertext length %d", size, len(ciphertext))
+		}
+
+		r, err := NewStreamReader(bytes.NewReader(ciphertext), &key, &nonce, 64)
+		if err != nil {
+			t.Fatalf("Failed to create StreamReader: %s", err)
+		}
+		decrypted, err := ioutil.ReadAll(r)
+		if err != nil {
+			t.Fatalf("Size %d: failed to decrypt stream: %s", size, err)
+		}
+		if !bytes.Equal(decrypted, plaintext) {
+			t.Fatalf("Size %d: decrypted stream differs from plaintext", size)
+		}
+	}
+}
+
+func TestStreamTruncated(t *testing.T) {
+	var (
+		key   [32]byte
+		nonce [StreamNonceSize]byte
+	)
+	ciphertext := sealStream(t, &key, &nonce, 64, make([]byte, 200))
+
+	for _, n := range []int{0, TagSize - 1, 64 + TagSize, 2 * (64 + TagSize)} {
+		r, _ := NewStreamReader(bytes.NewReader(ciphertext[:n]), &key, &nonce, 64)
+		if _, err := ioutil.ReadAll(r); err != io.ErrUnexpectedEOF {
+			t.Fatalf("Truncated at %d: expected %v but got %v", n, io.ErrUnexpectedEOF, err)
+		}
+	}
+}
+
+func TestStreamReorderedAndSpliced(t *testing.T) {
+	var (
+		key            [32]byte
+		nonceA, nonceB [StreamNonceSize]byte
+		plaintext      = make([]byte, 200)
+		chunk          = 64 + TagSize
+	)
+	nonceB[0] = 1
+	streamA := sealStream(t, &key, &nonceA, 64, plaintext)
+	streamB := sealStream(t, &key, &nonceB, 64, plaintext)
+
+	reordered := append([]byte{}, streamA...)
+	copy(reordered[:chunk], streamA[chunk:2*chunk])
+	copy(reordered[chunk:2*chunk], streamA[:chunk])
+
+	spliced := append([]byte{}, streamA...)
+	copy(spliced[chunk:2*chunk], streamB[chunk:2*chunk])
+
+	finalMoved := append([]byte{}, streamA[:chunk]...)
+	finalMoved = append(finalMoved, streamA[3*chunk:]...)
+
+	for i, ciphertext := range [][]byte{reordered, spliced, finalMoved} {
+		r, _ := NewStreamReader(bytes.NewReader(ciphertext), &key, &nonceA, 64)
+		if _, err := ioutil.ReadAll(r); err == nil {
+			t.Fatalf("Test %d: StreamReader accepted a modified stream", i)
+		}
+	}
+}
+
+func TestStreamUnverifiedPlaintext(t *testing.T) {
+	var (
+		key   [32]byte
+		nonce [StreamNonceSize]byte
+	)
+	ciphertext := sealStream(t, &key, &nonce, 64, make([]byte, 200))
+	ciphertext[64+TagSize+3]++ // modify the second chunk
+
+	r, _ := NewStreamReader(bytes.NewReader(ciphertext), &key, &nonce, 64)
+	plaintext, err := ioutil.ReadAll(r)
+	if err == nil {
+		t.Fatal("StreamReader accepted modified chunk")
+	}
+	if len(plaintext) != 64 {
+		t.Fatalf("StreamReader returned %d bytes but only the first chunk is authentic", len(plaintext))
+	}
+}
+
+func TestStreamAuthenticated(t *testing.T) {
+	var (
+		key   [32]byte
+		nonce [StreamNonceSize]byte
+	)
+	ciphertext := sealStream(t, &key, &nonce, 64, make([]byte, 200))
+	r, _ := NewStreamReader(bytes.NewReader(ciphertext), &key, &nonce, 64)
+
+	var buf [10]byte
+	for _, want := range []int64{64, 64, 64, 64, 64, 64, 64, 128} {
+		if _, err := r.Read(buf[:]); err != nil {
+			t.Fatalf("Read failed: %v", err)
+		}
+		if n := r.Authenticated(); n != want {
+			t.Fatalf("Authenticated: got %d want %d", n, want)
+		}
+	}
+	if _, err := io.Copy(ioutil.Discard, r); err != nil {
+		t.Fatalf("Read failed: %v", err)
+	}
+	if n := r.Authenticated(); n != 200 {
+		t.Fatalf("Authenticated: got %d want %d", n, 200)
+	}
+}
+
+func TestNewStreamInvalidChunkSize(t *testing.T) {
+	var (
+		key   [32]byte
+		nonce [StreamNonceSize]byte
+	)
+	if _, err := NewStreamWriter(ioutil.Discard, &key, &nonce, 0); err == nil {
+		t.Fatal("NewStreamWriter accepted invalid chunk size")
+	}
+	if _, err := NewStreamReader(bytes.NewReader(nil), &key, &nonce, 0); err == nil {
+		t.Fatal("NewStreamReader accepted invalid chunk size")
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package chacha20
+
+import (
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"io"
+)
+
+var errTinkCiphertextTooShort = errors.New("ciphertext too short")
+
+// TinkAEAD implements the AEAD primitive interface of Google Tink
+// (Encrypt and Decrypt of github.com/google/tink/go/tink.AEAD) with the
+// ciphertext format of Tink's ChaCha20Poly1305 and XChaCha20Poly1305
+// primitives:
+//
+//	random nonce | ciphertext | tag
+//
+// A TinkAEAD can replace the primitives returned by Tink's subtle package.
+// The output prefix of a keyset (the key ID) is added by Tink's keyset
+// handle, not by the primitive. The key managers in the tinkaead package
+// create TinkAEADs from Tink keysets. A TinkAEAD is safe for concurrent use.
+type TinkAEAD struct {
+	c cipher.AEAD
+
+	// Rand is the source of the nonces. If nil crypto/rand.Reader is used.
+	Rand io.Reader
+}
+
+// NewTinkChaCha20Poly1305 returns a TinkAEAD compatible with Tink's
+// ChaCha20Poly1305 primitive.
+func NewTinkChaCha20Poly1305(key *[32]byte) *TinkAEAD {
+	return &TinkAEAD{c: NewChaCha20Poly1305(key)}
+}
+
+// NewTinkXChaCha20Poly1305 returns a TinkAEAD compatible with Tink's
+// XChaCha20Poly1305 primitive.
+func NewTinkXChaCha20Poly1305(key *[32]byte) *TinkAEAD {
+	return &TinkAEAD{c: NewXChaCha20Poly1305(key)}
+}
+
+// Encrypt encrypts and authenticates the plaintext and the associated data
+// with a random nonce and returns the nonce followed by the ciphertext.
+func (t *TinkAEAD) Encrypt(plaintext, associatedData []byte) ([]byte, error) {
+	random := t.Rand
+	if random == nil {
+		random = rand.Reader
+	}
+	n := t.c.NonceSize()
+	if exceedsPlaintextSize(len(plaintext), 0) {
+		return nil, ErrMessageTooLarge
+	}
+	out := make([]byte, n, n+len(plaintext)+t.c.Overhead())
+	if _, err := io.ReadFull(random, out); err != nil {
+		return nil, err
+	}
+	return t.c.Seal(out, out, plaintext, associatedData), nil
+}
+
+// Decrypt decrypts and authenticates the ciphertext produced by Encrypt
+// and the associated data and returns the plaintext.
+func (t *TinkAEAD) Decrypt(ciphertext, associatedData []byte) ([]byte, error) {
+	n := t.c.NonceSize()
+	if len(ciphertext) < n+t.c.Overhead() {
+		return nil, errTinkCiphertextTooShort
+	}
+	return t.c.Open(nil, ciphertext[:n], ciphertext[n:], associatedData)
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package chacha20
+
+import (
+	"bytes"
+	"testing"
+)
+
+// tinkAEAD is the AEAD primitive interface of Google Tink.
+type tinkAEAD interface {
+	Encrypt(plaintext, associatedData []byte) ([]byte, error)
+	Decrypt(ciphertext, associatedData []byte) ([]byte, error)
+}
+
+var _ tinkAEAD = (*TinkAEAD)(nil)
+
+func TestTinkAEAD(t *testing.T) {
+	var key [32]byte
+	for i := range key {
+		key[i] = byte(i)
+	}
+	plaintext, data := []byte("Hello, World"), []byte("associated data")
+
+	for _, c := range []struct {
+		t         *TinkAEAD
+		nonceSize int
+		open      func(nonce, ciphertext []byte) ([]byte, error)
+	}{
+		{NewTinkChaCha20Poly1305(&key), NonceSize, func(nonce, ciphertext []byte) ([]byte, error) {
+			return NewChaCha20Poly1305(&key).Open(nil, nonce, ciphertext, data)
+		}},
+		{NewTinkXChaCha20Poly1305(&key), NonceSizeX, func(nonce, ciphertext []byte) ([]byte, error) {
+			return NewXChaCha20Poly1305(&key).Open(nil, nonce, ciphertext, data)
+		}},
+	} {
+		ciphertext, err := c.t.Encrypt(plaintext, data)
+		if err != nil {
+			t.Fatalf("Nonce size %d: Encrypt failed: %s", c.nonceSize, err)
+		}
+		if len(ciphertext) != c.nonceSize+len(plaintext)+TagSize {
+			t.Fatalf("Nonce size %d: unexpected ciphertext length %d", c.nonceSize, len(ciphertext))
+		}
+		// The ciphertext is the nonce followed by the sealed plaintext.
+		if p, err := c.open(ciphertext[:c.nonceSize], ciphertext[c.nonceSize:]); err != nil || !bytes.Equal(p, plaintext) {
+			t.Fatalf("Nonce size %d: ciphertext format differs from Tink: %v", c.nonceSize, err)
+		}
+		p, err := c.t.Decrypt(ciphertext, data)
+		if err != nil || !bytes.Equal(p, plaintext) {
+			t.Fatalf("Nonce size %d: Decrypt failed: %v", c.nonceSize, err)
+		}
+
+		if other, _ := c.t.Encrypt(plaintext, data); bytes.Equal(other[:c.nonceSize], ciphertext[:c.nonceSize]) {
+			t.Fatalf("Nonce size %d: Encrypt reuses the nonce", c.nonceSize)
+		}
+		ciphertext[0] ^= 1
+		if _, err = c.t.Decrypt(ciphertext, data); err == nil {
+			t.Fatalf("Nonce size %d: Decrypt accepted a modified nonce", c.nonceSize)
+		}
+		if _, err = c.t.Decrypt(ciphertext[:c.nonceSize+TagSize-1], data); err != errTinkCiphertextTooShort {
+			t.Fatalf("Nonce size %d: Decrypt returns unexpected error: %v", c.nonceSize, err)
+		}
+	}
+}
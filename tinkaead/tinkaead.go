@@ -0,0 +1,180 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+// Package tinkaead implements Google Tink key managers for the
+// ChaCha20Poly1305 and XChaCha20Poly1305 key types. The primitives of
+// the key managers are the chacha20.TinkAEAD implementations of this
+// repository.
+//
+// Tink's aead package registers its own key managers for both key type
+// URLs, so these key managers can't be added to Tink's registry. Pass
+// them to aead.NewWithKeyManager instead:
+//
+//	a, err := aead.NewWithKeyManager(handle, tinkaead.NewChaCha20Poly1305KeyManager())
+//
+// The key managers live in their own package, so only programs using
+// Tink depend on it.
+package tinkaead // import "github.com/aead/chacha20/tinkaead"
+
+import (
+	"crypto/rand"
+	"errors"
+	"io"
+
+	"github.com/aead/chacha20"
+	"github.com/google/tink/go/core/registry"
+	"google.golang.org/protobuf/proto"
+
+	cppb "github.com/google/tink/go/proto/chacha20_poly1305_go_proto"
+	tinkpb "github.com/google/tink/go/proto/tink_go_proto"
+	xcppb "github.com/google/tink/go/proto/xchacha20_poly1305_go_proto"
+)
+
+const (
+	// ChaCha20Poly1305TypeURL is the type URL of Tink's ChaCha20Poly1305 keys.
+	ChaCha20Poly1305TypeURL = "type.googleapis.com/google.crypto.tink.ChaCha20Poly1305Key"
+
+	// XChaCha20Poly1305TypeURL is the type URL of Tink's XChaCha20Poly1305 keys.
+	XChaCha20Poly1305TypeURL = "type.googleapis.com/google.crypto.tink.XChaCha20Poly1305Key"
+
+	keyVersion = 0
+)
+
+var (
+	errInvalidKey       = errors.New("tinkaead: invalid key")
+	errInvalidKeyFormat = errors.New("tinkaead: invalid key format")
+	errKeyVersion       = errors.New("tinkaead: unsupported key version")
+)
+
+var (
+	_ registry.KeyManager = (*chaCha20Poly1305KeyManager)(nil)
+	_ registry.KeyManager = (*xChaCha20Poly1305KeyManager)(nil)
+)
+
+// NewChaCha20Poly1305KeyManager returns a key manager for Tink's
+// ChaCha20Poly1305 keys. Its primitives are created by
+// chacha20.NewTinkChaCha20Poly1305.
+func NewChaCha20Poly1305KeyManager() registry.KeyManager {
+	return new(chaCha20Poly1305KeyManager)
+}
+
+// NewXChaCha20Poly1305KeyManager returns a key manager for Tink's
+// XChaCha20Poly1305 keys. Its primitives are created by
+// chacha20.NewTinkXChaCha20Poly1305.
+func NewXChaCha20Poly1305KeyManager() registry.KeyManager {
+	return new(xChaCha20Poly1305KeyManager)
+}
+
+type chaCha20Poly1305KeyManager struct{}
+
+func (km *chaCha20Poly1305KeyManager) Primitive(serializedKey []byte) (interface{}, error) {
+	key := new(cppb.ChaCha20Poly1305Key)
+	if len(serializedKey) == 0 || proto.Unmarshal(serializedKey, key) != nil {
+		return nil, errInvalidKey
+	}
+	k, err := keyValue(key.Version, key.KeyValue)
+	if err != nil {
+		return nil, err
+	}
+	return chacha20.NewTinkChaCha20Poly1305(k), nil
+}
+
+// NewKey ignores the key format since ChaCha20Poly1305 keys have no
+// parameters.
+func (km *chaCha20Poly1305KeyManager) NewKey(serializedKeyFormat []byte) (proto.Message, error) {
+	if proto.Unmarshal(serializedKeyFormat, new(cppb.ChaCha20Poly1305KeyFormat)) != nil {
+		return nil, errInvalidKeyFormat
+	}
+	k, err := newKeyValue()
+	if err != nil {
+		return nil, err
+	}
+	return &cppb.ChaCha20Poly1305Key{Version: keyVersion, KeyValue: k}, nil
+}
+
+func (km *chaCha20Poly1305KeyManager) NewKeyData(serializedKeyFormat []byte) (*tinkpb.KeyData, error) {
+	return newKeyData(km, serializedKeyFormat)
+}
+
+func (km *chaCha20Poly1305KeyManager) DoesSupport(typeURL string) bool {
+	return typeURL == ChaCha20Poly1305TypeURL
+}
+
+func (km *chaCha20Poly1305KeyManager) TypeURL() string { return ChaCha20Poly1305TypeURL }
+
+type xChaCha20Poly1305KeyManager struct{}
+
+func (km *xChaCha20Poly1305KeyManager) Primitive(serializedKey []byte) (interface{}, error) {
+	key := new(xcppb.XChaCha20Poly1305Key)
+	if len(serializedKey) == 0 || proto.Unmarshal(serializedKey, key) != nil {
+		return nil, errInvalidKey
+	}
+	k, err := keyValue(key.Version, key.KeyValue)
+	if err != nil {
+		return nil, err
+	}
+	return chacha20.NewTinkXChaCha20Poly1305(k), nil
+}
+
+func (km *xChaCha20Poly1305KeyManager) NewKey(serializedKeyFormat []byte) (proto.Message, error) {
+	format := new(xcppb.XChaCha20Poly1305KeyFormat)
+	if proto.Unmarshal(serializedKeyFormat, format) != nil {
+		return nil, errInvalidKeyFormat
+	}
+	if format.Version != keyVersion {
+		return nil, errKeyVersion
+	}
+	k, err := newKeyValue()
+	if err != nil {
+		return nil, err
+	}
+	return &xcppb.XChaCha20Poly1305Key{Version: keyVersion, KeyValue: k}, nil
+}
+
+func (km *xChaCha20Poly1305KeyManager) NewKeyData(serializedKeyFormat []byte) (*tinkpb.KeyData, error) {
+	return newKeyData(km, serializedKeyFormat)
+}
+
+func (km *xChaCha20Poly1305KeyManager) DoesSupport(typeURL string) bool {
+	return typeURL == XChaCha20Poly1305TypeURL
+}
+
+func (km *xChaCha20Poly1305KeyManager) TypeURL() string { return XChaCha20Poly1305TypeURL }
+
+// keyValue checks the version and the size of a serialized key.
+func keyValue(version uint32, value []byte) (*[32]byte, error) {
+	if version != keyVersion {
+		return nil, errKeyVersion
+	}
+	if len(value) != 32 {
+		return nil, errInvalidKey
+	}
+	var key [32]byte
+	copy(key[:], value)
+	return &key, nil
+}
+
+func newKeyValue() ([]byte, error) {
+	key := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+func newKeyData(km registry.KeyManager, serializedKeyFormat []byte) (*tinkpb.KeyData, error) {
+	key, err := km.NewKey(serializedKeyFormat)
+	if err != nil {
+		return nil, err
+	}
+	serializedKey, err := proto.Marshal(key)
+	if err != nil {
+		return nil, err
+	}
+	return &tinkpb.KeyData{
+		TypeUrl:         km.TypeURL(),
+		Value:           serializedKey,
+		KeyMaterialType: tinkpb.KeyData_SYMMETRIC,
+	}, nil
+}
@@ -0,0 +1,109 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package tinkaead
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/aead/chacha20"
+	"github.com/google/tink/go/aead"
+	"github.com/google/tink/go/core/registry"
+	"github.com/google/tink/go/keyset"
+	"google.golang.org/protobuf/proto"
+
+	cppb "github.com/google/tink/go/proto/chacha20_poly1305_go_proto"
+	tinkpb "github.com/google/tink/go/proto/tink_go_proto"
+	xcppb "github.com/google/tink/go/proto/xchacha20_poly1305_go_proto"
+)
+
+func TestKeyManager(t *testing.T) {
+	for i, test := range []struct {
+		km       registry.KeyManager
+		template *tinkpb.KeyTemplate
+	}{
+		{NewChaCha20Poly1305KeyManager(), aead.ChaCha20Poly1305KeyTemplate()},
+		{NewXChaCha20Poly1305KeyManager(), aead.XChaCha20Poly1305KeyTemplate()},
+	} {
+		if test.km.TypeURL() != test.template.TypeUrl || !test.km.DoesSupport(test.template.TypeUrl) {
+			t.Fatalf("Test %d: key manager doesn't support %s", i, test.template.TypeUrl)
+		}
+		handle, err := keyset.NewHandle(test.template)
+		if err != nil {
+			t.Fatalf("Test %d: failed to create keyset: %v", i, err)
+		}
+		tinkAEAD, err := aead.New(handle)
+		if err != nil {
+			t.Fatalf("Test %d: failed to create Tink's primitive: %v", i, err)
+		}
+		a, err := aead.NewWithKeyManager(handle, test.km)
+		if err != nil {
+			t.Fatalf("Test %d: failed to create primitive: %v", i, err)
+		}
+
+		plaintext, data := []byte("plaintext"), []byte("associated data")
+		ciphertext, err := a.Encrypt(plaintext, data)
+		if err != nil {
+			t.Fatalf("Test %d: encryption failed: %v", i, err)
+		}
+		if p, err := tinkAEAD.Decrypt(ciphertext, data); err != nil || !bytes.Equal(p, plaintext) {
+			t.Fatalf("Test %d: Tink failed to decrypt the ciphertext: %v", i, err)
+		}
+		ciphertext, err = tinkAEAD.Encrypt(plaintext, data)
+		if err != nil {
+			t.Fatalf("Test %d: Tink's encryption failed: %v", i, err)
+		}
+		if p, err := a.Decrypt(ciphertext, data); err != nil || !bytes.Equal(p, plaintext) {
+			t.Fatalf("Test %d: failed to decrypt Tink's ciphertext: %v", i, err)
+		}
+
+		keyData, err := test.km.NewKeyData(test.template.Value)
+		if err != nil {
+			t.Fatalf("Test %d: failed to create key data: %v", i, err)
+		}
+		if keyData.TypeUrl != test.km.TypeURL() || keyData.KeyMaterialType != tinkpb.KeyData_SYMMETRIC {
+			t.Fatalf("Test %d: invalid key data: %v", i, keyData)
+		}
+		p, err := test.km.Primitive(keyData.Value)
+		if err != nil {
+			t.Fatalf("Test %d: failed to create primitive from key data: %v", i, err)
+		}
+		if _, ok := p.(*chacha20.TinkAEAD); !ok {
+			t.Fatalf("Test %d: primitive is a %T - want *chacha20.TinkAEAD", i, p)
+		}
+	}
+}
+
+func TestInvalidKeys(t *testing.T) {
+	marshal := func(m proto.Message) []byte {
+		b, err := proto.Marshal(m)
+		if err != nil {
+			t.Fatalf("Failed to marshal key: %v", err)
+		}
+		return b
+	}
+	key := make([]byte, 32)
+	for i, test := range []struct {
+		km  registry.KeyManager
+		key []byte
+	}{
+		{NewChaCha20Poly1305KeyManager(), nil},
+		{NewChaCha20Poly1305KeyManager(), []byte{0xff}},
+		{NewChaCha20Poly1305KeyManager(), marshal(&cppb.ChaCha20Poly1305Key{Version: 1, KeyValue: key})},
+		{NewChaCha20Poly1305KeyManager(), marshal(&cppb.ChaCha20Poly1305Key{KeyValue: key[:31]})},
+		{NewXChaCha20Poly1305KeyManager(), nil},
+		{NewXChaCha20Poly1305KeyManager(), marshal(&xcppb.XChaCha20Poly1305Key{Version: 1, KeyValue: key})},
+		{NewXChaCha20Poly1305KeyManager(), marshal(&xcppb.XChaCha20Poly1305Key{KeyValue: append(key, 0)})},
+	} {
+		if _, err := test.km.Primitive(test.key); err == nil {
+			t.Fatalf("Test %d: invalid key accepted", i)
+		}
+	}
+
+	format := marshal(&xcppb.XChaCha20Poly1305KeyFormat{Version: 1})
+	if _, err := NewXChaCha20Poly1305KeyManager().NewKey(format); err == nil {
+		t.Fatal("Key format with an unsupported version accepted")
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package chacha20
+
+import (
+	"crypto/cipher"
+	"errors"
+	"sync"
+)
+
+// ErrKeyExpired is returned by LimitedAEAD.Seal if sealing the message
+// would exceed one of the usage limits of the key.
+var ErrKeyExpired = errors.New("key exceeded its usage limits")
+
+// UsageLimits specifies how many messages and plaintext bytes may be
+// sealed with one key. A zero value disables the corresponding limit.
+type UsageLimits struct {
+	MaxMessages uint64
+	MaxBytes    uint64
+}
+
+// DefaultUsageLimits are the limits used by a LimitedAEAD if no limits are
+// specified. The message limit is the number of random 96 bit nonces after
+// which the probability of a nonce collision exceeds 2^-32.
+var DefaultUsageLimits = UsageLimits{
+	MaxMessages: 1 << 32,
+}
+
+// LimitedAEAD wraps an AEAD and counts the messages and plaintext bytes
+// sealed with its key. Seal returns ErrKeyExpired once a message would
+// exceed the usage limits - so the key must be rotated. Open is not limited.
+// A LimitedAEAD is safe for concurrent use if the wrapped AEAD is.
+type LimitedAEAD struct {
+	c      cipher.AEAD
+	limits UsageLimits
+
+	mu       sync.Mutex
+	messages uint64
+	bytes    uint64
+}
+
+// NewLimitedAEAD returns a new LimitedAEAD wrapping c. If limits is nil
+// DefaultUsageLimits are used.
+func NewLimitedAEAD(c cipher.AEAD, limits *UsageLimits) *LimitedAEAD {
+	if limits == nil {
+		limits = &DefaultUsageLimits
+	}
+	return &LimitedAEAD{c: c, limits: *limits}
+}
+
+// NonceSize returns the nonce size of the wrapped AEAD.
+func (l *LimitedAEAD) NonceSize() int { return l.c.NonceSize() }
+
+// Overhead returns the overhead of the wrapped AEAD.
+func (l *LimitedAEAD) Overhead() int { return l.c.Overhead() }
+
+// Seal encrypts and authenticates the plaintext like cipher.AEAD.Seal.
+// It returns ErrKeyExpired and doesn't seal the plaintext if the message
+// would exceed the usage limits.
+func (l *LimitedAEAD) Seal(dst, nonce, plaintext, additionalData []byte) ([]byte, error) {
+	n := uint64(len(plaintext))
+	l.mu.Lock()
+	if l.limits.MaxMessages > 0 && l.messages >= l.limits.MaxMessages ||
+		l.limits.MaxBytes > 0 && (l.bytes > l.limits.MaxBytes || n > l.limits.MaxBytes-l.bytes) {
+		l.mu.Unlock()
+		return nil, ErrKeyExpired
+	}
+	l.messages++
+	l.bytes += n
+	l.mu.Unlock()
+	return l.c.Seal(dst, nonce, plaintext, additionalData), nil
+}
+
+// Open decrypts and authenticates the ciphertext like cipher.AEAD.Open.
+func (l *LimitedAEAD) Open(dst, nonce, ciphertext, additionalData []byte) ([]byte, error) {
+	return l.c.Open(dst, nonce, ciphertext, additionalData)
+}
+
+// Usage returns the number of messages and plaintext bytes sealed so far.
+func (l *LimitedAEAD) Usage() (messages, bytes uint64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.messages, l.bytes
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package chacha20
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+)
+
+func TestLimitedAEAD(t *testing.T) {
+	var key [32]byte
+	nonce := make([]byte, NonceSize)
+	c := NewChaCha20Poly1305(&key)
+
+	l := NewLimitedAEAD(c, &UsageLimits{MaxMessages: 3, MaxBytes: 100})
+	if l.NonceSize() != c.NonceSize() || l.Overhead() != c.Overhead() {
+		t.Fatal("LimitedAEAD doesn't report the nonce size and overhead of the AEAD")
+	}
+	for i, size := range []int{10, 0, 90} {
+		sealed, err := l.Seal(nil, nonce, make([]byte, size), nil)
+		if err != nil {
+			t.Fatalf("Message %d: Seal failed: %v", i, err)
+		}
+		if !bytes.Equal(sealed, c.Seal(nil, nonce, make([]byte, size), nil)) {
+			t.Fatalf("Message %d: Seal produces unexpected ciphertext", i)
+		}
+		if _, err = l.Open(nil, nonce, sealed, nil); err != nil {
+			t.Fatalf("Message %d: Open failed: %v", i, err)
+		}
+	}
+	if _, err := l.Seal(nil, nonce, nil, nil); err != ErrKeyExpired {
+		t.Fatalf("Seal exceeds the message limit: %v", err)
+	}
+	if messages, bytes := l.Usage(); messages != 3 || bytes != 100 {
+		t.Fatalf("Usage returns %d messages and %d bytes - want 3 and 100", messages, bytes)
+	}
+
+	l = NewLimitedAEAD(c, &UsageLimits{MaxBytes: 100})
+	if _, err := l.Seal(nil, nonce, make([]byte, 60), nil); err != nil {
+		t.Fatalf("Seal failed: %v", err)
+	}
+	if _, err := l.Seal(nil, nonce, make([]byte, 41), nil); err != ErrKeyExpired {
+		t.Fatalf("Seal exceeds the byte limit: %v", err)
+	}
+	if _, err := l.Seal(nil, nonce, make([]byte, 40), nil); err != nil {
+		t.Fatalf("Seal rejects a message within the byte limit: %v", err)
+	}
+	if messages, bytes := l.Usage(); messages != 2 || bytes != 100 {
+		t.Fatalf("Usage returns %d messages and %d bytes - want 2 and 100", messages, bytes)
+	}
+
+	l = NewLimitedAEAD(c, nil)
+	if l.limits != DefaultUsageLimits {
+		t.Fatalf("NewLimitedAEAD doesn't use the default limits: %v", l.limits)
+	}
+}
+
+func TestLimitedAEADConcurrent(t *testing.T) {
+	var key [32]byte
+	nonce := make([]byte, NonceSize)
+	l := NewLimitedAEAD(NewChaCha20Poly1305(&key), &UsageLimits{MaxMessages: 100})
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		expired int
+	)
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				if _, err := l.Seal(nil, nonce, make([]byte, 16), nil); err == ErrKeyExpired {
+					mu.Lock()
+					expired++
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if messages, _ := l.Usage(); messages != 100 || expired != 60 {
+		t.Fatalf("LimitedAEAD sealed %d messages and rejected %d - want 100 and 60", messages, expired)
+	}
+}
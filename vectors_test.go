@@ -6,6 +6,7 @@ package chacha20
 
 import (
 	"bytes"
+	"crypto/cipher"
 	"encoding/hex"
 	"testing"
 )
@@ -290,3 +291,72 @@ func TestAEADVectors(t *testing.T) {
 		}
 	}
 }
+
+// Test vector from:
+// https://tools.ietf.org/html/draft-irtf-cfrg-xchacha-01#appendix-A.3.1
+var xaeadTestVectors = []struct {
+	key, nonce, data string
+	msg, ciphertext  string
+}{
+	{
+		key: "808182838485868788898a8b8c8d8e8f" +
+			"909192939495969798999a9b9c9d9e9f",
+		nonce: "404142434445464748494a4b4c4d4e4f5051525354555657",
+		data:  "50515253c0c1c2c3c4c5c6c7",
+		msg: "4c616469657320616e642047656e746c656d656e206f662074686520636c6173" +
+			"73206f66202739393a204966204920636f756c64206f6666657220796f75206f" +
+			"6e6c79206f6e652074697020666f7220746865206675747572652c2073756e73" +
+			"637265656e20776f756c642062652069742e",
+		ciphertext: "bd6d179d3e83d43b9576579493c0e939572a1700252bfaccbed2902c21396cbb" +
+			"731c7f1b0b4aa6440bf3a82f4eda7e39ae64c6708c54c216cb96b72e1213b452" +
+			"2f8c9ba40db5d945b11b69b982c1bb9e3f3fac2bc369488f76b2383565d3fff9" +
+			"21f9664c97637da9768812f615c68b13b52e" +
+			"c0875924c1c7987947deafd8780acf49", // poly 1305 tag
+	},
+}
+
+// Test vector from:
+// https://tools.ietf.org/html/draft-agl-tls-chacha20poly1305-04#section-7
+var legacyAEADTestVectors = []struct {
+	key, nonce, data string
+	msg, ciphertext  string
+}{
+	{
+		key:        "4290bcb154173531f314af57f3be3b5006da371ece272afa1b5dbdd1100a1007",
+		nonce:      "cd7cf67be39c794a",
+		data:       "87e229d4500845a079c0",
+		msg:        "86d09974840bded2a5ca",
+		ciphertext: "e3e446f7ede9a19b62a4" + "677dabf4e3d24b876bb284753896e1d6", // poly 1305 tag
+	},
+}
+
+func TestAEADVectorsX(t *testing.T) {
+	for i, v := range xaeadTestVectors {
+		var Key [32]byte
+		copy(Key[:], fromHex(v.key))
+		testAEADVector(t, i, NewXChaCha20Poly1305(&Key), fromHex(v.nonce), fromHex(v.msg), fromHex(v.data), fromHex(v.ciphertext))
+	}
+}
+
+func TestAEADVectorsLegacy(t *testing.T) {
+	for i, v := range legacyAEADTestVectors {
+		var Key [32]byte
+		copy(Key[:], fromHex(v.key))
+		testAEADVector(t, i, NewChaCha20Poly1305Legacy(&Key), fromHex(v.nonce), fromHex(v.msg), fromHex(v.data), fromHex(v.ciphertext))
+	}
+}
+
+func testAEADVector(t *testing.T, i int, c cipher.AEAD, nonce, msg, data, ciphertext []byte) {
+	buf := c.Seal(nil, nonce, msg, data)
+	if !bytes.Equal(buf, ciphertext) {
+		t.Fatalf("TestVector %d Seal failed:\nFound   : %s\nExpected: %s", i, hex.EncodeToString(buf), hex.EncodeToString(ciphertext))
+	}
+
+	buf, err := c.Open(buf[:0], nonce, buf, data)
+	if err != nil {
+		t.Fatalf("TestVector %d: Open failed - Cause: %s", i, err)
+	}
+	if !bytes.Equal(msg, buf) {
+		t.Fatalf("TestVector %d Open failed:\nFound   : %s\nExpected: %s", i, hex.EncodeToString(buf), hex.EncodeToString(msg))
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright (c) 2016 Andreas Auernhammer. All rights reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package chacha20
+
+import (
+	"crypto/cipher"
+
+	"github.com/aead/chacha20/chacha"
+	"github.com/aead/chacha20/internal/argerr"
+)
+
+// NewXChaCha20Poly1305 returns a cipher.AEAD implementing the
+// XChaCha20Poly1305 construction with a 192 bit nonce and a
+// 128 bit auth. tag. The nonce is large enough to be chosen at random.
+func NewXChaCha20Poly1305(key *[32]byte) cipher.AEAD {
+	return NewKey(key).XChaCha20Poly1305()
+}
+
+// The AEAD cipher XChaCha20Poly1305
+// The ChaCha20 engines are pooled by the shared Key and keyed with
+// the sub-key of every message.
+type xaead struct {
+	key     *Key
+	tagsize int
+}
+
+func (c *xaead) Overhead() int { return c.tagsize }
+
+func (c *xaead) NonceSize() int { return NonceSizeX }
+
+// Close wipes the key of the AEAD like aead.Close.
+func (c *xaead) Close() error {
+	c.key.Wipe()
+	return nil
+}
+
+func (c *xaead) Seal(dst, nonce, plaintext, additionalData []byte) []byte {
+	if n := len(nonce); n != NonceSizeX {
+		panic(argerr.Error("chacha20: nonce size is invalid"))
+	}
+	if c.key.wiped {
+		panic(errKeyWiped)
+	}
+	if exceedsPlaintextSize(len(plaintext), 0) {
+		panic(ErrMessageTooLarge)
+	}
+	countSeal(len(plaintext))
+	var subNonce [NonceSize]byte
+	engine := c.subCipher(&subNonce, nonce)
+	sub := aead{tagsize: c.tagsize}
+	ret := sub.seal(engine, dst, subNonce[:], plaintext, additionalData)
+	c.key.release(&c.key.subEngines, engine)
+	return ret
+}
+
+func (c *xaead) Open(dst, nonce, ciphertext, additionalData []byte) ([]byte, error) {
+	if n := len(nonce); n != NonceSizeX {
+		return nil, errInvalidNonceSize
+	}
+	if c.key.wiped {
+		return nil, errKeyWiped
+	}
+	if exceedsPlaintextSize(len(ciphertext), c.tagsize) {
+		return nil, ErrMessageTooLarge
+	}
+	var subNonce [NonceSize]byte
+	engine := c.subCipher(&subNonce, nonce)
+	sub := aead{tagsize: c.tagsize}
+	ret, err := sub.open(engine, dst, subNonce[:], ciphertext, additionalData)
+	c.key.release(&c.key.subEngines, engine)
+	countOpen(len(ciphertext)-c.tagsize, err)
+	return ret, err
+}
+
+func (c *xaead) Verify(nonce, ciphertext, additionalData, tag []byte) bool {
+	if len(nonce) != NonceSizeX || len(tag) != c.tagsize || exceedsPlaintextSize(len(ciphertext), 0) || c.key.wiped {
+		return false
+	}
+	var subNonce [NonceSize]byte
+	engine := c.subCipher(&subNonce, nonce)
+	sub := aead{tagsize: c.tagsize}
+	ok := sub.verify(engine, tag, subNonce[:], ciphertext, additionalData)
+	c.key.release(&c.key.subEngines, engine)
+	return ok
+}
+
+// subCipher derives the ChaCha20Poly1305 sub-key from the first 16 bytes
+// of the nonce using HChaCha20 and returns an engine from the pool keyed
+// with the sub-key. The engine must be returned to the pool by the caller.
+// The sub-nonce consists of 4 zero bytes followed by the last 8 bytes of
+// the nonce.
+func (c *xaead) subCipher(subNonce *[NonceSize]byte, nonce []byte) *chacha.Cipher {
+	var (
+		hNonce [16]byte
+		subKey [32]byte
+	)
+	copy(hNonce[:], nonce[:16])
+	chacha.HChaCha20(&subKey, &hNonce, c.key.key)
+	copy(subNonce[4:], nonce[16:])
+
+	engine, ok := c.key.subEngines.Get().(*chacha.Cipher)
+	if !ok {
+		return chacha.NewCipher(subNonce, &subKey, 20)
+	}
+	engine.SetKey(&subKey)
+	return engine
+}